@@ -231,7 +231,7 @@ func (he *HealthExecutor) executeAndReportResults(ctx context.Context, coreRepos
 	formatter.DisplayResults(*result)
 
 	// Return appropriate exit code
-	exitCode := health.GetExitCode(*result)
+	exitCode := health.GetExitCode(*result, health.ExitPolicy{})
 	if exitCode != 0 {
 		logger.Warn("health checks failed", core.Int("exit_code", exitCode))
 		metrics.IncrementCounter("health_check_failures")
@@ -4,10 +4,15 @@ package main
 import (
 	"context"
 	"fmt"
+	"io"
 	"os"
+	"os/signal"
 	"path/filepath"
+	"sort"
+	"strconv"
 	"strings"
 	"sync"
+	"syscall"
 	"time"
 
 	"github.com/codcod/repos/internal/config"
@@ -15,8 +20,15 @@ import (
 	"github.com/codcod/repos/internal/git"
 	"github.com/codcod/repos/internal/github"
 	"github.com/codcod/repos/internal/health"
+	"github.com/codcod/repos/internal/health/analyzers/language"
+	"github.com/codcod/repos/internal/health/changes"
 	healthconfig "github.com/codcod/repos/internal/health/config"
+	"github.com/codcod/repos/internal/health/hooks"
 	"github.com/codcod/repos/internal/health/reporting"
+	"github.com/codcod/repos/internal/health/server"
+	"github.com/codcod/repos/internal/health/suppression"
+	"github.com/codcod/repos/internal/health/tracing"
+	"github.com/codcod/repos/internal/health/watch"
 	"github.com/codcod/repos/internal/runner"
 	"github.com/codcod/repos/internal/util"
 
@@ -29,9 +41,15 @@ var (
 	configFile  string
 	tag         string
 	parallel    bool
+	noColor     bool
 	logDir      string
 	defaultLogs = "logs"
 
+	// Run command flags
+	logMaxSizeMB int
+	logMaxFiles  int
+	logFormat    string
+
 	// Version information - will be set via build flags, with environment variable fallback
 	version = "dev"
 	commit  = "unknown"
@@ -45,6 +63,7 @@ var (
 	commitMsg  string
 	prDraft    bool
 	prToken    string
+	prAPIURL   string
 	createOnly bool
 
 	// Init command flags
@@ -52,18 +71,63 @@ var (
 	overwrite  bool
 
 	// Health command flags
-	healthConfig           string
+	healthConfigs          []string
 	healthCategories       []string
+	healthCheckers         []string
 	healthParallel         bool
-	healthTimeout          int
+	healthTimeout          time.Duration
 	healthDryRun           bool
 	healthVerbose          bool
 	healthListCategories   bool
 	healthGenConfig        bool
 	healthComplexityReport bool
 	healthMaxComplexity    int
+	healthSince            string
+	healthPath             string
+	healthInput            string
+	healthInputData        *health.Input // Loaded from healthInput by loadHealthRepositories
+	healthWorkingTreeOnly  bool
+	healthScanHistory      bool
+	healthEcosystems       []string
+	healthValidateConfig   bool
+	healthFormats          []string
+	healthFormat           string // The format written to stdout, "" if none
+	healthTop              int
+	healthMinScore         int
+	healthOutputs          []string
+	healthOutputFile       string
+	healthReportOutputs    []reporting.ReportOutput
+	healthBaseline         string
+	healthWriteBaseline    string
+	healthState            string
+	healthCacheDir         string
+	healthNoCache          bool
+	healthNoEmoji          bool
+	healthQuiet            bool
+
+	// Health serve command flags
+	healthServeAddr          string
+	healthServeRoot          string
+	healthServeMaxConcurrent int
+	healthServeTimeout       int
+
+	// Health diff command flags
+	healthDiffFormat string
+
+	// Health watch command flags
+	healthWatchDebounce time.Duration
+
+	// Health drift command flags
+	healthDriftFrom   string
+	healthDriftTo     string
+	healthDriftFormat string
 )
 
+// healthFormatterOptions returns the console formatter options selected by flags
+func healthFormatterOptions() []reporting.FormatterOption {
+	return []reporting.FormatterOption{reporting.WithEmoji(!healthNoEmoji)}
+}
+
 // getEnvOrDefault returns the environment variable value or default if empty
 func getEnvOrDefault(key, defaultValue string) string {
 	if value := os.Getenv(key); value != "" {
@@ -72,6 +136,49 @@ func getEnvOrDefault(key, defaultValue string) string {
 	return defaultValue
 }
 
+// maxHealthTimeout is the longest --timeout accepted for a health run
+const maxHealthTimeout = 2 * time.Hour
+
+// timeoutValue is a --timeout flag given as a Go duration such as "90s" or
+// "2m", or as a whole number of seconds for backward compatibility
+type timeoutValue time.Duration
+
+func (t *timeoutValue) Set(value string) error {
+	timeout, err := parseTimeout(value)
+	if err != nil {
+		return err
+	}
+	*t = timeoutValue(timeout)
+	return nil
+}
+
+func (t *timeoutValue) String() string {
+	return time.Duration(*t).String()
+}
+
+func (t *timeoutValue) Type() string {
+	return "duration"
+}
+
+// parseTimeout parses a --timeout value. Zero disables the timeout.
+func parseTimeout(value string) (time.Duration, error) {
+	timeout, err := time.ParseDuration(value)
+	if err != nil {
+		seconds, atoiErr := strconv.Atoi(value)
+		if atoiErr != nil {
+			return 0, fmt.Errorf("use a duration such as 90s or 2m, or a number of seconds")
+		}
+		timeout = time.Duration(seconds) * time.Second
+	}
+	if timeout < 0 {
+		return 0, fmt.Errorf("must not be negative")
+	}
+	if timeout > maxHealthTimeout {
+		return 0, fmt.Errorf("must not exceed %s", maxHealthTimeout)
+	}
+	return timeout, nil
+}
+
 // init function to handle environment variable fallback for version info
 func init() {
 	// Use environment variables as fallback when build-time flags weren't set
@@ -171,8 +278,19 @@ var runCmd = &cobra.Command{
 			os.Exit(1)
 		}
 
+		logOptions := runner.LogOptions{
+			Dir:      absLogDir,
+			MaxSize:  int64(logMaxSizeMB) << 20,
+			MaxFiles: logMaxFiles,
+			Format:   logFormat,
+		}
+		if err := logOptions.Validate(); err != nil {
+			color.Red("Error: %v", err)
+			os.Exit(1)
+		}
+
 		err = processRepos(repositories, parallel, func(r config.Repository) error {
-			return runner.RunCommand(r, command, absLogDir)
+			return runner.RunCommandWithOptions(r, command, logOptions)
 		})
 
 		if err != nil {
@@ -221,6 +339,7 @@ var prCmd = &cobra.Command{
 			CommitMsg:  commitMsg,
 			Draft:      prDraft,
 			Token:      prToken,
+			APIURL:     prAPIURL,
 			CreateOnly: createOnly,
 		}
 
@@ -388,8 +507,17 @@ func init() {
 	rootCmd.PersistentFlags().StringVarP(&configFile, "config", "c", "config.yaml", "config file path")
 	rootCmd.PersistentFlags().StringVarP(&tag, "tag", "t", "", "filter repositories by tag")
 	rootCmd.PersistentFlags().BoolVarP(&parallel, "parallel", "p", false, "execute operations in parallel")
+	rootCmd.PersistentFlags().BoolVar(&noColor, "no-color", false, "disable colored output (also set by NO_COLOR or when output is not a terminal)")
+	cobra.OnInitialize(func() {
+		if noColor {
+			color.NoColor = true
+		}
+	})
 
 	runCmd.Flags().StringVarP(&logDir, "logs", "l", defaultLogs, "directory to store log files")
+	runCmd.Flags().IntVar(&logMaxSizeMB, "log-max-size-mb", 0, "rotate a text log when it reaches this many megabytes, and truncate JSON output to it (0 for no limit)")
+	runCmd.Flags().IntVar(&logMaxFiles, "log-max-files", 0, "log files to keep per repository, rotated ones included (0 keeps all)")
+	runCmd.Flags().StringVar(&logFormat, "log-format", runner.LogFormatText, "log file format: text, or json for one record per command with its exit code, duration and output")
 
 	// PR command flags
 	prCmd.Flags().StringVar(&prTitle, "title", "Automated changes", "Title for the pull request")
@@ -399,6 +527,7 @@ func init() {
 	prCmd.Flags().StringVar(&commitMsg, "message", "", "Commit message (defaults to PR title)")
 	prCmd.Flags().BoolVar(&prDraft, "draft", false, "Create PR as draft")
 	prCmd.Flags().StringVar(&prToken, "token", "", "GitHub token (can also use GITHUB_TOKEN env var)")
+	prCmd.Flags().StringVar(&prAPIURL, "api-url", "", "GitHub API base URL for GitHub Enterprise, e.g. https://github.example.com/api/v3 (default: GITHUB_API_URL or https://api.github.com)")
 	prCmd.Flags().BoolVar(&createOnly, "create-only", false, "Only create PR, don't commit changes")
 
 	// Init command flags
@@ -406,16 +535,53 @@ func init() {
 	initCmd.Flags().BoolVar(&overwrite, "overwrite", false, "Overwrite existing file if it exists")
 
 	// Health command flags
-	healthCmd.Flags().StringVar(&healthConfig, "config", "", "health config file path (optional, uses built-in defaults if not provided)")
+	healthCmd.Flags().StringArrayVarP(&healthConfigs, "config", "c", nil, "health config file path; repeat to merge several files in order (later wins), use - for stdin (optional, uses built-in defaults if not provided)")
 	healthCmd.Flags().StringSliceVar(&healthCategories, "category", []string{}, "filter checkers and analyzers by categories (comma-separated, e.g., 'git,security')")
+	healthCmd.Flags().StringSliceVar(&healthCheckers, "checker", []string{}, "run only these checker IDs, in addition to any --category (repeatable, e.g., --checker git-status)")
 	healthCmd.Flags().BoolVar(&healthParallel, "parallel", false, "Execute health checks in parallel")
-	healthCmd.Flags().IntVar(&healthTimeout, "timeout", 30, "Timeout in seconds for health checks (default: 30)")
+	healthTimeout = 30 * time.Second
+	healthCmd.Flags().Var((*timeoutValue)(&healthTimeout), "timeout", "Timeout for health checks as a duration (e.g. 90s, 2m) or in seconds, at most 2h; 0 disables it")
 	healthCmd.Flags().BoolVar(&healthDryRun, "dry-run", false, "Dry run mode - show what would be executed")
 	healthCmd.Flags().BoolVar(&healthVerbose, "verbose", false, "Enable verbose output for health checks")
 	healthCmd.Flags().BoolVar(&healthListCategories, "list-categories", false, "List all available categories, checkers, and analyzers")
+	healthCmd.Flags().BoolVar(&healthValidateConfig, "validate-config", false, "Validate the health config files and report every problem found, without running checks")
 	healthCmd.Flags().BoolVar(&healthGenConfig, "gen-config", false, "Generate a comprehensive configuration template with all available options")
 	healthCmd.Flags().BoolVar(&healthComplexityReport, "complexity-report", false, "Generate a cyclomatic complexity report for the codebase")
 	healthCmd.Flags().IntVar(&healthMaxComplexity, "max-complexity", 0, "Fail if any function exceeds this cyclomatic complexity (0 disables check)")
+	healthCmd.Flags().StringSliceVar(&healthFormats, "format", []string{"console"}, "Output formats, repeatable: console, json, csv, ndjson, sarif, html, junit; all but one need an --output file")
+	healthCmd.Flags().IntVar(&healthTop, "top", health.DefaultTop, "Number of lowest-scoring repositories to highlight in the summary")
+	healthCmd.Flags().IntVar(&healthMinScore, "min-score", 0, "Fail the run when a repository's overall score is below this (0-100; replaces the config's min_score, 0 keeps it)")
+	healthCmd.Flags().StringArrayVar(&healthOutputs, "output", nil, "Write a report to this file, repeatable; the format is inferred from the extension (.json, .csv, .xml, .html, .sarif, .ndjson)")
+	healthCmd.Flags().StringVar(&healthOutputFile, "output-file", "", "Same as --output")
+	healthCmd.Flags().StringVar(&healthBaseline, "baseline", "", "Report findings recorded in this baseline file as known; only new findings fail the run")
+	healthCmd.Flags().StringVar(&healthWriteBaseline, "write-baseline", "", "Record the current findings to this baseline file")
+	healthCmd.Flags().StringVar(&healthCacheDir, "cache-dir", "", "Store analysis results in this directory and reuse them while a repository's commit is unchanged")
+	healthCmd.Flags().BoolVar(&healthNoCache, "no-cache", false, "Analyze every repository again, ignoring --cache-dir")
+	healthCmd.Flags().StringVar(&healthState, "state", "", "Record finished repositories to this file and skip those recorded at their current HEAD and configuration")
+	healthCmd.Flags().BoolVar(&healthQuiet, "quiet", false, "Only show repositories and checks that are not healthy, followed by a one-line tally")
+	healthCmd.Flags().BoolVar(&healthNoEmoji, "no-emoji", false, "Show statuses as text markers such as [OK] and [FAIL] instead of emoji")
+	healthCmd.Flags().BoolVar(&healthWorkingTreeOnly, "working-tree-only", false, "Only check files in the current checkout for large files, skipping git history (faster for CI)")
+	healthCmd.Flags().BoolVar(&healthScanHistory, "scan-history", false, "Also scan recent commit history for committed secrets, bounded by the secrets checker's max_commits")
+	healthCmd.Flags().StringSliceVar(&healthEcosystems, "ecosystem", []string{}, "check dependencies of only these ecosystems (comma-separated, e.g., 'go,python'), skipping the others")
+	healthServeCmd.Flags().StringArrayVarP(&healthConfigs, "config", "c", nil, "health config file path; repeat to merge several files in order (later wins)")
+	healthServeCmd.Flags().StringVar(&healthServeAddr, "addr", "127.0.0.1:8080", "Address to listen on")
+	healthServeCmd.Flags().StringVar(&healthServeRoot, "root", "", "Directory whose repositories may be checked (default: current directory)")
+	healthServeCmd.Flags().IntVar(&healthServeMaxConcurrent, "max-concurrent", server.DefaultMaxConcurrent, "Maximum number of health checks run at the same time")
+	healthServeCmd.Flags().IntVar(&healthServeTimeout, "timeout", int(server.DefaultTimeout/time.Second), "Timeout in seconds for a single health check request")
+	healthDiffCmd.Flags().StringVar(&healthDiffFormat, "format", "console", "Output format: console, json")
+	healthWatchCmd.Flags().StringArrayVarP(&healthConfigs, "config", "c", nil, "health config file path; repeat to merge several files in order (later wins)")
+	healthWatchCmd.Flags().StringSliceVar(&healthCategories, "category", []string{}, "filter checkers and analyzers by categories (comma-separated, e.g., 'git,security')")
+	healthWatchCmd.Flags().StringSliceVar(&healthCheckers, "checker", []string{}, "run only these checker IDs, in addition to any --category (repeatable)")
+	healthWatchCmd.Flags().DurationVar(&healthWatchDebounce, "debounce", watch.DefaultDebounce, "How long files must be unchanged before checks re-run")
+	healthWatchCmd.Flags().BoolVar(&healthVerbose, "verbose", false, "Enable verbose output for health checks")
+	healthCmd.Flags().StringVar(&healthSince, "since", "", "Only analyze files changed between this git ref and HEAD (e.g. origin/main)")
+	healthCmd.Flags().StringVar(&healthPath, "path", "", "Check this directory as a single repository instead of the repositories in config.yaml")
+	healthDriftCmd.Flags().StringVar(&healthDriftFrom, "from", "", "git ref to compare from, e.g. the previous release tag (required)")
+	healthDriftCmd.Flags().StringVar(&healthDriftTo, "to", "HEAD", "git ref to compare to")
+	healthDriftCmd.Flags().StringVar(&healthDriftFormat, "format", "console", "Output format: console, json")
+	healthDriftCmd.Flags().StringVar(&healthPath, "path", "", "Compare this directory as a single repository instead of the repositories in config.yaml")
+	_ = healthDriftCmd.MarkFlagRequired("from")
+	healthCmd.Flags().StringVar(&healthInput, "input", "", "Check the repositories, and only the files, listed in this JSON file instead of those in config.yaml")
 
 	rootCmd.AddCommand(cloneCmd)
 	rootCmd.AddCommand(runCmd)
@@ -423,6 +589,11 @@ func init() {
 	rootCmd.AddCommand(rmCmd)
 	rootCmd.AddCommand(initCmd)   // Add the init command
 	rootCmd.AddCommand(healthCmd) // Add the health command
+	healthCmd.AddCommand(healthExplainCmd)
+	healthCmd.AddCommand(healthServeCmd)
+	healthCmd.AddCommand(healthDiffCmd)
+	healthCmd.AddCommand(healthWatchCmd)
+	healthCmd.AddCommand(healthDriftCmd)
 
 	// Add version command
 	rootCmd.AddCommand(&cobra.Command{
@@ -451,15 +622,69 @@ If you want to customize the checks, you can provide an optional configuration f
 
 Examples:
   repos health                           # Run with built-in defaults
+  repos health --path .                  # Check the current directory without a config.yaml
+  repos health --input files.json        # Check the files a CI job lists, without walking the repositories
   repos health --config custom.yaml     # Use custom configuration
+  repos health -c base.yaml -c ci.yaml  # Merge configurations, later files win
   repos health --category git,security  # Run only git and security checks
   repos health --complexity-report      # Run only cyclomatic complexity analysis
-  repos health --complexity-report --category docs,security # Run complexity and other checks
+  repos health --complexity-report --category documentation,security # Run complexity and other checks
+  repos health --complexity-report --since origin/main # Analyze only files changed since origin/main
+  repos health --complexity-report --format json # Machine-readable complexity report
+  repos health --complexity-report --format csv  # One row per function for spreadsheets
+  repos health --output health.sarif   # Print the console summary and also write a SARIF file
+  repos health --format console --format sarif --output report.sarif # The same, naming both formats
   repos health --verbose                # Show detailed output
   repos health --list-categories        # List all available categories and checks
   repos health --gen-config             # Generate comprehensive configuration template
-  repos health --dry-run                # Preview what would be executed`,
+  repos health -c ci.yaml --validate-config # Lint a configuration without running checks
+  repos health serve --root /src        # Serve health checks over HTTP for repositories under /src
+  repos health diff old.json new.json   # Compare two --format json results
+  repos health watch                    # Re-run checks as files change
+  repos health --dry-run                # Preview what would be executed
+  repos health --write-baseline baseline.json  # Accept current findings
+  repos health --baseline baseline.json        # Fail only on new findings`,
 	Run: func(_ *cobra.Command, _ []string) {
+		outputPaths := healthOutputs
+		if healthOutputFile != "" {
+			outputPaths = append([]string{healthOutputFile}, outputPaths...)
+		}
+		outputs, err := reporting.PlanOutputs(healthFormats, outputPaths)
+		if err != nil {
+			color.Red("Error: %v", err)
+			os.Exit(1)
+		}
+		healthReportOutputs = outputs
+		healthFormat = reporting.StdoutFormat(outputs)
+		if healthFormat != "console" {
+			// Keep stdout clean for the report document; progress goes to stderr
+			color.Output = color.Error
+		}
+		if healthComplexityReport {
+			for _, output := range outputs {
+				if output.Format == "ndjson" {
+					color.Red("Error: --format ndjson cannot be used with --complexity-report")
+					os.Exit(1)
+				}
+				if output.Path != "" && output.Format != "json" && output.Format != "csv" {
+					color.Red("Error: --output with --complexity-report supports .json and .csv, got %s", output.Path)
+					os.Exit(1)
+				}
+			}
+		}
+		if healthComplexityReport && (healthBaseline != "" || healthWriteBaseline != "") {
+			color.Red("Error: --baseline and --write-baseline cannot be used with --complexity-report")
+			os.Exit(1)
+		}
+		if healthComplexityReport && healthState != "" {
+			color.Red("Error: --state cannot be used with --complexity-report")
+			os.Exit(1)
+		}
+		if healthMinScore < 0 || healthMinScore > 100 {
+			color.Red("Error: --min-score must be between 0 and 100, got %d", healthMinScore)
+			os.Exit(1)
+		}
+
 		// Handle list-categories option first
 		if healthListCategories {
 			listHealthCategories()
@@ -472,36 +697,23 @@ Examples:
 			return
 		}
 
-		// If --complexity-report is set and no categories are specified, run only complexity analysis
-		if healthComplexityReport && len(healthCategories) == 0 {
+		// Handle validate-config option
+		if healthValidateConfig {
+			os.Exit(validateHealthConfigs(healthConfigs))
+		}
+
+		// If --complexity-report is set and no categories or checkers are specified, run only complexity analysis
+		if healthComplexityReport && len(healthCategories) == 0 && len(healthCheckers) == 0 {
 			color.Green("Running cyclomatic complexity analysis on all supported repositories...")
-			cfg, err := config.LoadConfig(configFile)
+			coreRepos, err := loadHealthRepositories(nil)
 			if err != nil {
 				color.Red("Error: %v", err)
 				os.Exit(1)
 			}
-			repositories := cfg.FilterRepositoriesByTag(tag)
-			if len(repositories) == 0 {
+			if len(coreRepos) == 0 {
 				color.Yellow("No repositories found with tag: %s", tag)
 				return
 			}
-			coreRepos := make([]core.Repository, len(repositories))
-			for i, repo := range repositories {
-				repoPath := repo.Path
-				if repoPath == "" {
-					repoPath = filepath.Join("cloned_repos", repo.Name)
-				}
-				language := detectRepositoryLanguage(repo, repoPath)
-				coreRepos[i] = core.Repository{
-					Name:     repo.Name,
-					Path:     repoPath,
-					URL:      repo.URL,
-					Branch:   repo.Branch,
-					Tags:     repo.Tags,
-					Language: language,
-					Metadata: make(map[string]string),
-				}
-			}
 			fs := health.NewFileSystem()
 			analyzerReg := health.NewAnalyzerRegistry(fs, &simpleLogger{})
 			results := make([]*core.AnalysisResult, 0, len(coreRepos))
@@ -512,19 +724,58 @@ Examples:
 					results = append(results, nil)
 					continue
 				}
-				result, err := analyzer.Analyze(context.Background(), repo.Path, core.AnalyzerConfig{})
+				analyzerConfig := core.AnalyzerConfig{Index: inputFileIndex(repo)}
+				if healthSince != "" {
+					files, err := changedAnalysisFiles(repo.Path, healthSince, analyzer.SupportedExtensions())
+					if err != nil {
+						color.Red("Error: %v", err)
+						os.Exit(1)
+					}
+					analyzerConfig.IncludeFiles = files
+				}
+				result, err := analyzer.Analyze(context.Background(), repo.Path, analyzerConfig)
 				if err != nil {
 					color.Red("Error analyzing %s: %v", repo.Name, err)
 					results = append(results, nil)
 					continue
 				}
+				suppression.NewIndex(repo.Path).FilterFunctions(result)
+				if healthSince != "" {
+					changed, err := git.ChangedLines(repo.Path, healthSince)
+					if err != nil {
+						color.Red("Error: %s: %v", repo.Path, err)
+						os.Exit(1)
+					}
+					changes.FilterChangedFunctions(result, repo.Path, changed)
+				}
 				results = append(results, result)
 			}
+			for _, output := range healthReportOutputs {
+				if output.Path == "" {
+					continue
+				}
+				if err := writeComplexityOutputFile(output.Path, coreRepos, results); err != nil {
+					color.Red("Error: %v", err)
+					os.Exit(1)
+				}
+			}
+
+			if healthFormat == "json" || healthFormat == "csv" {
+				if err := writeComplexityReport(os.Stdout, healthFormat, coreRepos, results); err != nil {
+					color.Red("Error writing %s report: %v", healthFormat, err)
+					os.Exit(1)
+				}
+				return
+			}
+			if healthFormat != "console" {
+				return
+			}
+
 			var formatter *reporting.Formatter
 			if healthMaxComplexity > 0 {
-				formatter = reporting.NewComplexityFormatterWithThreshold(healthVerbose, healthMaxComplexity)
+				formatter = reporting.NewComplexityFormatterWithThreshold(healthVerbose, healthMaxComplexity, healthFormatterOptions()...)
 			} else {
-				formatter = reporting.NewComplexityFormatterWithThreshold(healthVerbose, 1) // show all functions >= 1
+				formatter = reporting.NewComplexityFormatterWithThreshold(healthVerbose, 1, healthFormatterOptions()...) // show all functions >= 1
 			}
 			for i, repo := range coreRepos {
 				if i >= len(results) || results[i] == nil {
@@ -544,229 +795,1073 @@ Examples:
 		// Create simple logger
 		logger := &simpleLogger{}
 
-		// If no config file is specified, use default name or empty for built-in defaults
-		configPath := healthConfig
-		if configPath == "" {
-			configPath = "orchestration.yaml" // Try default file, will use built-in defaults if not found
+		// Load advanced configuration
+		advConfig, err := loadHealthConfig()
+		if err != nil {
+			color.Red("Error loading health config: %v", err)
+			os.Exit(1)
 		}
-
-		// Load advanced configuration or use defaults if file doesn't exist
-		advConfig, err := healthconfig.LoadAdvancedConfigOrDefault(configPath)
+		consoleTemplate, err := reporting.ConsoleTemplate(advConfig)
 		if err != nil {
 			color.Red("Error loading health config: %v", err)
 			os.Exit(1)
 		}
 
 		// Load basic config for repositories
-		cfg, err := config.LoadConfig(configFile)
+		coreRepos, err := loadHealthRepositories(advConfig)
 		if err != nil {
 			color.Red("Error: %v", err)
 			os.Exit(1)
 		}
-
-		repositories := cfg.FilterRepositoriesByTag(tag)
-		if len(repositories) == 0 {
+		if len(coreRepos) == 0 {
 			color.Yellow("No repositories found with tag: %s", tag)
 			return
 		}
 
-		// Convert repositories to core.Repository format
-		coreRepos := make([]core.Repository, len(repositories))
-		for i, repo := range repositories {
-			// Use the actual repository path if it exists, otherwise use the specified path
-			repoPath := repo.Path
-			if repoPath == "" {
-				repoPath = filepath.Join("cloned_repos", repo.Name)
-			}
-
-			// Detect language from repository tags or directory structure
-			language := detectRepositoryLanguage(repo, repoPath)
-
-			coreRepos[i] = core.Repository{
-				Name:     repo.Name,
-				Path:     repoPath,
-				URL:      repo.URL,
-				Branch:   repo.Branch,
-				Tags:     repo.Tags,
-				Language: language,
-				Metadata: make(map[string]string),
-			}
+		if !healthQuiet {
+			color.Green("Running comprehensive health checks on %d repositories...", len(coreRepos))
 		}
 
-		color.Green("Running comprehensive health checks on %d repositories...", len(repositories))
-
 		// Apply category filtering if specified
 		if len(healthCategories) > 0 {
-			color.Blue("Filtering by categories: %v", healthCategories)
+			if !healthQuiet {
+				color.Blue("Filtering by categories: %v", healthCategories)
+			}
 			advConfig = advConfig.FilterByCategories(healthCategories)
 		}
 
 		// Create command executor and registries
-		executor := health.NewCommandExecutor(time.Duration(healthTimeout) * time.Second)
+		executor := health.NewCommandExecutor(healthTimeout)
 		checkerRegistry := health.NewCheckerRegistry(executor)
+		if err := checkerRegistry.RegisterCustomCheckers(advConfig.Extensions.CustomCheckers, executor); err != nil {
+			color.Red("Error: %v", err)
+			os.Exit(1)
+		}
+		if err := checkerRegistry.ValidateCheckerIDs(healthCheckers); err != nil {
+			color.Red("Error: %v", err)
+			os.Exit(1)
+		}
+
+		// Skip the history scan of the repository size check
+		if healthWorkingTreeOnly {
+			setCheckerOption(advConfig, checkerRegistry, "git-size", "working_tree_only", true)
+		}
+
+		// Look for secrets removed from HEAD but still in history
+		if healthScanHistory {
+			setCheckerOption(advConfig, checkerRegistry, "secrets", "scan_history", true)
+		}
+
+		// Restrict dependency checks to the chosen ecosystems
+		if len(healthEcosystems) > 0 {
+			setCheckerOption(advConfig, checkerRegistry, "dependencies-outdated", "ecosystems", healthEcosystems)
+		}
 
 		// Create filesystem and analyzer registry
 		fs := health.NewFileSystem()
 		analyzerReg := health.NewAnalyzerRegistry(fs, logger)
+		if err := validateAnalyzerExtensions(advConfig, analyzerReg); err != nil {
+			color.Red("Error: %v", err)
+			os.Exit(1)
+		}
 
 		// Create orchestration engine
 		engine := health.NewOrchestrationEngine(checkerRegistry, analyzerReg, advConfig, logger)
+		if len(healthCheckers) > 0 && !healthQuiet {
+			color.Blue("Selecting checkers: %v", healthCheckers)
+		}
+		engine.SelectCheckers(healthCategories, healthCheckers)
+		engine.SetTop(healthTop)
+		if healthInputData != nil {
+			healthInputData.Apply(engine)
+		}
 
 		// Execute health checks
 		if healthDryRun {
-			showDryRunDetails(coreRepos, advConfig, analyzerReg, healthCategories)
+			showDryRunDetails(coreRepos, advConfig, engine, healthCategories, healthCheckers)
 			return
 		}
 
+		// Restrict analysis to changed files when --since is set
+		if healthSince != "" {
+			if err := restrictAnalysisToChanges(engine, analyzerReg, advConfig, coreRepos, healthSince); err != nil {
+				color.Red("Error: %v", err)
+				os.Exit(1)
+			}
+		}
+
+		// Resume an interrupted run, skipping repositories already recorded
+		if healthState != "" {
+			state, err := health.LoadState(healthState)
+			if err != nil {
+				color.Red("Error: %v", err)
+				os.Exit(1)
+			}
+			engine.SetState(state)
+		}
+
+		// Reuse analysis results of unchanged commits from earlier runs
+		if healthCacheDir != "" && !healthNoCache {
+			engine.SetAnalysisCache(health.NewAnalysisCache(healthCacheDir, version+"-"+commit))
+		}
+
+		var baseline *reporting.Baseline
+		if healthBaseline != "" {
+			baseline, err = reporting.LoadBaseline(healthBaseline)
+			if err != nil {
+				color.Red("Error: %v", err)
+				os.Exit(1)
+			}
+		}
+
+		// Stream each repository's result as soon as it completes, with the
+		// baseline applied before each line is written
+		var ndjson *reporting.NDJSONFormatter
+		if healthFormat == "ndjson" {
+			ndjson = reporting.NewNDJSONFormatter(os.Stdout)
+			ndjson.SetBaseline(baseline)
+			engine.SetProgressReporter(ndjson)
+		}
+
 		ctx := context.Background()
 		if healthTimeout > 0 {
 			var cancel context.CancelFunc
-			ctx, cancel = context.WithTimeout(context.Background(), time.Duration(healthTimeout)*time.Second)
+			ctx, cancel = context.WithTimeout(context.Background(), healthTimeout)
 			defer cancel()
 		}
 
+		// Trace the run when an OTLP endpoint is configured; otherwise spans are no-ops
+		tracer, shutdownTracer := tracing.NewTracer(advConfig.Integrations.Tracing)
+		engine.SetTracer(tracer)
+		engine.SetHooks(hooks.NewRunner(advConfig.Extensions.Hooks, logger))
+
 		result, err := engine.ExecuteHealthCheck(ctx, coreRepos)
+		if shutdownErr := shutdownTracer(context.Background()); shutdownErr != nil {
+			color.Yellow("Warning: failed to export traces: %v", shutdownErr)
+		}
 		if err != nil {
 			color.Red("Error executing code analysis: %v", err)
 			os.Exit(1)
 		}
 
-		// Display results using the formatter
-		formatter := health.NewFormatter(healthVerbose)
-		formatter.DisplayResults(*result)
+		if baseline != nil {
+			baseline.Apply(result)
+		}
 
-		// Exit with appropriate code based on results
-		os.Exit(health.GetExitCode(*result))
-	},
-}
+		// Display results using the requested format
+		switch healthFormat {
+		case "json":
+			if err := reporting.WriteJSON(os.Stdout, result); err != nil {
+				color.Red("Error writing JSON report: %v", err)
+				os.Exit(1)
+			}
+		case "csv":
+			if err := reporting.NewCSVFormatter().WriteCheckResults(os.Stdout, *result); err != nil {
+				color.Red("Error writing CSV report: %v", err)
+				os.Exit(1)
+			}
+		case "ndjson":
+			if err := ndjson.WriteSummary(*result); err != nil {
+				color.Red("Error writing NDJSON report: %v", err)
+				os.Exit(1)
+			}
+		case "console":
+			options := append(healthFormatterOptions(), reporting.WithQuiet(healthQuiet), reporting.WithTemplate(consoleTemplate))
+			formatter := health.NewFormatter(healthVerbose, options...)
+			formatter.DisplayResults(*result)
+		}
 
-// simpleLogger provides a basic logger implementation
-type simpleLogger struct{}
+		for _, output := range healthReportOutputs {
+			if output.Path == "" {
+				continue
+			}
+			if err := reporting.WriteReportFile(output.Path, *result); err != nil {
+				color.Red("Error: %v", err)
+				os.Exit(1)
+			}
+		}
 
-func (l *simpleLogger) Debug(msg string, fields ...core.Field) {
-	if healthVerbose {
-		fmt.Print("[DEBUG] " + msg + l.formatFieldsAsString(fields))
-	}
-}
+		if healthWriteBaseline != "" {
+			baseline := reporting.NewBaseline(*result)
+			if err := baseline.Save(healthWriteBaseline); err != nil {
+				color.Red("Error: %v", err)
+				os.Exit(1)
+			}
+			color.Green("Wrote %d findings to baseline %s", len(baseline.Findings), healthWriteBaseline)
+		}
 
-func (l *simpleLogger) Info(msg string, fields ...core.Field) {
-	fmt.Print("[INFO] " + msg + l.formatFieldsAsString(fields))
-}
+		// Create tickets for critical findings; failures are logged and never fail the run
+		if advConfig.Integrations.JIRA.Enabled {
+			created := reporting.NewJIRAReporter(advConfig.Integrations.JIRA, logger).Report(context.Background(), *result)
+			if created > 0 {
+				color.Blue("Created %d JIRA ticket(s)", created)
+			}
+		}
+		if advConfig.Integrations.GitHub.Enabled && advConfig.Integrations.GitHub.CreateIssues {
+			created := reporting.NewGitHubIssueReporter(advConfig.Integrations.GitHub, logger).Report(context.Background(), *result)
+			if created > 0 {
+				color.Blue("Created %d GitHub issue(s)", created)
+			}
+		}
 
-func (l *simpleLogger) Warn(msg string, fields ...core.Field) {
-	color.Yellow("[WARN] " + msg + l.formatFieldsAsString(fields))
-}
+		// Report repositories below their minimum score on stderr, so that
+		// structured output on stdout stays intact
+		gate := advConfig.ScoreGate(coreRepos, healthMinScore)
+		for _, shortfall := range reporting.ScoreShortfalls(*result, gate) {
+			subject := shortfall.Repository
+			if shortfall.Category != "" {
+				subject += " " + shortfall.Category
+			}
+			_, _ = color.New(color.FgRed).Fprintf(os.Stderr, "Score gate: %s scored %d, %d below the minimum of %d\n",
+				subject, shortfall.Score, shortfall.Gap(), shortfall.MinScore)
+		}
 
-func (l *simpleLogger) Error(msg string, fields ...core.Field) {
-	color.Red("[ERROR] " + msg + l.formatFieldsAsString(fields))
+		// Exit with the code configured for the outcome, based on results, category
+		// fail_on levels and minimum scores
+		codes := advConfig.OutcomeExitCodes()
+		os.Exit(health.GetExitCode(*result, health.ExitPolicy{FailOn: advConfig.CategoryFailOn(), Gate: gate, Codes: &codes}))
+	},
 }
 
-func (l *simpleLogger) Fatal(msg string, fields ...core.Field) {
-	color.Red("[FATAL] " + msg + l.formatFieldsAsString(fields))
-	os.Exit(1)
-}
+var healthServeCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Serve health checks over HTTP",
+	Long: `Start an HTTP server that runs health checks on demand.
 
-func (l *simpleLogger) formatFieldsAsString(fields []core.Field) string {
-	if len(fields) == 0 {
-		return "\n"
-	}
+Endpoints:
+  POST /health   Run health checks for {"path": "...", "categories": [...]} and
+                 return the JSON result; "timeout_seconds" shortens the timeout
+  GET  /healthz  Liveness probe
 
-	var result string
-	for _, field := range fields {
-		result += fmt.Sprintf(" [%s=%v]", field.Key, field.Value)
-	}
-	return result + "\n"
-}
+Only directories under --root can be checked; other paths are rejected with
+403. The server has no authentication, so it listens on 127.0.0.1 by default.
+Requests beyond --max-concurrent wait for a free slot until their timeout.
 
-// detectRepositoryLanguage attempts to detect the primary language of a repository
-//
-//nolint:gocyclo
-func detectRepositoryLanguage(repo config.Repository, repoPath string) string {
-	// First, check tags for language hints
-	for _, tag := range repo.Tags {
-		switch tag {
-		case "go", "golang":
-			return "go"
-		case "python", "py":
-			return "python"
-		case "javascript", "js", "node", "nodejs":
-			return "javascript"
-		case "java":
-			return "java"
-		case "rust":
-			return "rust"
-		case "cpp", "c++":
-			return "cpp"
-		case "c":
-			return "c"
+Examples:
+  repos health serve                             # Listen on 127.0.0.1:8080
+  repos health serve --root /src --addr 127.0.0.1:9000 -c ci.yaml
+  curl -d '{"path": "/src/app", "categories": ["git"]}' localhost:8080/health`,
+	Run: func(_ *cobra.Command, _ []string) {
+		advConfig, err := loadHealthConfig()
+		if err != nil {
+			color.Red("Error loading health config: %v", err)
+			os.Exit(1)
 		}
-	}
 
-	// If no language tag found, try to detect from directory structure
-	if _, err := os.Stat(repoPath); err == nil {
-		// Check for language-specific files
-		if hasFile(repoPath, "go.mod", "main.go", "*.go") {
-			return "go"
-		}
-		if hasFile(repoPath, "requirements.txt", "setup.py", "pyproject.toml", "*.py") {
-			return "python"
-		}
-		if hasFile(repoPath, "package.json", "*.js", "*.ts") {
-			return "javascript"
+		timeout := time.Duration(healthServeTimeout) * time.Second
+		logger := &simpleLogger{}
+		executor := health.NewCommandExecutor(timeout)
+		checkerRegistry := health.NewCheckerRegistry(executor)
+		if err := checkerRegistry.RegisterCustomCheckers(advConfig.Extensions.CustomCheckers, executor); err != nil {
+			color.Red("Error: %v", err)
+			os.Exit(1)
 		}
-		if hasFile(repoPath, "pom.xml", "build.gradle", "*.java") {
-			return "java"
+		analyzerReg := health.NewAnalyzerRegistry(health.NewFileSystem(), logger)
+		if err := validateAnalyzerExtensions(advConfig, analyzerReg); err != nil {
+			color.Red("Error: %v", err)
+			os.Exit(1)
 		}
-		if hasFile(repoPath, "Cargo.toml", "*.rs") {
-			return "rust"
+		srv, err := server.NewServer(checkerRegistry, analyzerReg, advConfig, logger, healthServeRoot, healthServeMaxConcurrent, timeout)
+		if err != nil {
+			color.Red("Error: %v", err)
+			os.Exit(1)
 		}
-	}
 
-	return "" // Unknown language
-}
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
 
-// hasFile checks if any of the specified files exist in the repository path
-func hasFile(repoPath string, patterns ...string) bool {
-	for _, pattern := range patterns {
-		if strings.Contains(pattern, "*") {
-			// Use glob pattern
-			matches, err := filepath.Glob(filepath.Join(repoPath, pattern))
-			if err == nil && len(matches) > 0 {
-				return true
-			}
-		} else {
-			// Check for exact file
-			if _, err := os.Stat(filepath.Join(repoPath, pattern)); err == nil {
-				return true
-			}
+		color.Green("Serving health checks on %s", healthServeAddr)
+		if err := srv.ListenAndServe(ctx, healthServeAddr); err != nil {
+			color.Red("Error: %v", err)
+			os.Exit(1)
 		}
-	}
-	return false
+	},
 }
 
-// capitalizeFirst capitalizes the first letter of a string
-func capitalizeFirst(s string) string {
-	if len(s) == 0 {
-		return s
-	}
-	return strings.ToUpper(s[:1]) + s[1:]
-}
+var healthDiffCmd = &cobra.Command{
+	Use:   "diff <old.json> <new.json>",
+	Short: "Compare two health check results",
+	Long: `Compare two results written with --format json and print the issues the
+newer run introduced, the issues it resolved, and score changes per repository
+and checker. Exits with status 2 if any new issue is critical.
 
-// listHealthCategories lists all available categories, checkers, and analyzers
-func listHealthCategories() {
-	logger := &simpleLogger{}
+Examples:
+  repos health --format json > before.json
+  repos health --format json > after.json
+  repos health diff before.json after.json`,
+	Args: cobra.ExactArgs(2),
+	Run: func(_ *cobra.Command, args []string) {
+		oldResult, err := reporting.LoadWorkflowResult(args[0])
+		if err != nil {
+			color.Red("Error: %v", err)
+			os.Exit(1)
+		}
+		newResult, err := reporting.LoadWorkflowResult(args[1])
+		if err != nil {
+			color.Red("Error: %v", err)
+			os.Exit(1)
+		}
 
-	// Create registries to discover available checkers and analyzers
-	executor := health.NewCommandExecutor(30 * time.Second)
-	checkerRegistry := health.NewCheckerRegistry(executor)
+		diff := reporting.DiffResults(*oldResult, *newResult)
+		switch healthDiffFormat {
+		case "json":
+			err = reporting.WriteJSON(os.Stdout, diff)
+		case "console":
+			err = diff.Write(os.Stdout)
+		default:
+			err = fmt.Errorf("unsupported format %q (use console or json)", healthDiffFormat)
+		}
+		if err != nil {
+			color.Red("Error: %v", err)
+			os.Exit(1)
+		}
 
-	fs := health.NewFileSystem()
-	analyzerRegistry := health.NewAnalyzerRegistry(fs, logger)
+		if critical := diff.NewCriticalIssues(); critical > 0 && healthDiffFormat == "console" {
+			color.Red("❌ %d new critical issue(s)", critical)
+		}
+		os.Exit(diff.ExitCode())
+	},
+}
 
-	fmt.Println("=== Available Health Check Categories ===")
-	fmt.Println()
+var healthDriftCmd = &cobra.Command{
+	Use:   "drift",
+	Short: "List the dependencies changed between two git refs",
+	Long: `Compare the dependency manifests (go.mod, package.json, requirements.txt,
+Cargo.toml and composer.json) of each repository at two git refs and list the
+dependencies added, removed and given another version, per ecosystem. A manifest
+that exists at only one of the refs has all its dependencies listed as added or
+removed. Exits with status 1 if a repository could not be compared.
 
-	// List checkers by category
+Examples:
+  repos health drift --from v1.2.0
+  repos health drift --from v1.2.0 --to v1.3.0 --format json
+  repos health drift --path . --from origin/main`,
+	Run: func(_ *cobra.Command, _ []string) {
+		if healthDriftFormat != "console" && healthDriftFormat != "json" {
+			color.Red("Error: unsupported format %q (use console or json)", healthDriftFormat)
+			os.Exit(1)
+		}
+		advConfig, err := loadHealthConfig()
+		if err != nil {
+			color.Red("Error loading health config: %v", err)
+			os.Exit(1)
+		}
+		coreRepos, err := loadHealthRepositories(advConfig)
+		if err != nil {
+			color.Red("Error: %v", err)
+			os.Exit(1)
+		}
+		if len(coreRepos) == 0 {
+			color.Yellow("No repositories found with tag: %s", tag)
+			return
+		}
+
+		executor := health.NewCommandExecutor(healthTimeout)
+		drifts := make([]health.DependencyDrift, 0, len(coreRepos))
+		failed := false
+		for _, repo := range coreRepos {
+			drift, err := health.DiffDependencies(context.Background(), executor, repo.Path, healthDriftFrom, healthDriftTo)
+			if err != nil {
+				color.Red("%s: %v", repo.Name, err)
+				failed = true
+				continue
+			}
+			drift.Repository = repo.Name
+			drifts = append(drifts, drift)
+		}
+
+		if healthDriftFormat == "json" {
+			err = reporting.WriteJSON(os.Stdout, drifts)
+		} else {
+			for _, drift := range drifts {
+				if err = drift.Write(os.Stdout); err != nil {
+					break
+				}
+			}
+		}
+		if err != nil {
+			color.Red("Error: %v", err)
+			os.Exit(1)
+		}
+		if failed {
+			os.Exit(1)
+		}
+	},
+}
+
+var healthWatchCmd = &cobra.Command{
+	Use:   "watch",
+	Short: "Re-run health checks when files change",
+	Long: `Run the health checks once, then watch the repositories and re-run the checks
+of each repository whose files change. Each run prints the changed repositories'
+results and an updated summary of all repositories.
+
+Changes are debounced so that a burst of saves triggers one run, and complexity
+analysis only re-analyzes the files that changed. Stop with Ctrl-C.
+
+Examples:
+  repos health watch
+  repos health watch --category quality --debounce 1s
+  repos health watch -t backend --checker tech-debt`,
+	Run: func(_ *cobra.Command, _ []string) {
+		advConfig, err := loadHealthConfig()
+		if err != nil {
+			color.Red("Error loading health config: %v", err)
+			os.Exit(1)
+		}
+		consoleTemplate, err := reporting.ConsoleTemplate(advConfig)
+		if err != nil {
+			color.Red("Error loading health config: %v", err)
+			os.Exit(1)
+		}
+		coreRepos, err := loadHealthRepositories(advConfig)
+		if err != nil {
+			color.Red("Error: %v", err)
+			os.Exit(1)
+		}
+		if len(coreRepos) == 0 {
+			color.Yellow("No repositories found with tag: %s", tag)
+			return
+		}
+		if len(healthCategories) > 0 {
+			advConfig = advConfig.FilterByCategories(healthCategories)
+		}
+
+		logger := &simpleLogger{}
+		executor := health.NewCommandExecutor(healthTimeout)
+		checkerRegistry := health.NewCheckerRegistry(executor)
+		if err := checkerRegistry.RegisterCustomCheckers(advConfig.Extensions.CustomCheckers, executor); err != nil {
+			color.Red("Error: %v", err)
+			os.Exit(1)
+		}
+		if err := checkerRegistry.ValidateCheckerIDs(healthCheckers); err != nil {
+			color.Red("Error: %v", err)
+			os.Exit(1)
+		}
+		analyzerReg := health.NewAnalyzerRegistry(health.NewFileSystem(), logger)
+		if err := validateAnalyzerExtensions(advConfig, analyzerReg); err != nil {
+			color.Red("Error: %v", err)
+			os.Exit(1)
+		}
+		newEngine := func() *health.Engine {
+			engine := health.NewOrchestrationEngine(checkerRegistry, analyzerReg, advConfig, logger)
+			engine.SelectCheckers(healthCategories, healthCheckers)
+			engine.SetHooks(hooks.NewRunner(advConfig.Extensions.Hooks, logger))
+			return engine
+		}
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+
+		watcher, err := watch.New(coreRepos, healthWatchDebounce)
+		if err != nil {
+			color.Red("Error: %v", err)
+			os.Exit(1)
+		}
+		defer func() { _ = watcher.Close() }()
+
+		formatter := health.NewFormatter(healthVerbose, append(healthFormatterOptions(), reporting.WithTemplate(consoleTemplate))...)
+		latest := make(map[string]core.RepositoryResult)
+
+		color.Green("Running health checks on %d repositories...", len(coreRepos))
+		result, err := newEngine().ExecuteHealthCheck(ctx, coreRepos)
+		if err != nil {
+			color.Red("Error executing health checks: %v", err)
+			os.Exit(1)
+		}
+		for _, repoResult := range result.RepositoryResults {
+			latest[repoResult.Repository.Name] = repoResult
+		}
+		formatter.DisplayResults(*result)
+		printWatchSummary(coreRepos, latest)
+		color.Blue("Watching %d repositories for changes (Ctrl-C to stop)...", len(coreRepos))
+
+		err = watcher.Run(ctx, func(changes watch.Changes) {
+			engine := newEngine()
+			var changed []core.Repository
+			for _, repo := range coreRepos {
+				files, ok := changes[repo.Name]
+				if !ok {
+					continue
+				}
+				color.Cyan("%s: %d file(s) changed", repo.Name, len(files))
+				changed = append(changed, repo)
+
+				// Re-analyze only the changed files the repository's analyzer handles
+				language := latest[repo.Name].Repository.Language
+				if analyzer, err := analyzerReg.GetAnalyzer(language); err == nil {
+					engine.SetAnalysisFiles(repo.Name, filesWithExtensions(files, analyzerExtensions(advConfig, analyzer)))
+				}
+			}
+
+			result, err := engine.ExecuteHealthCheck(ctx, changed)
+			if err != nil {
+				color.Red("Error executing health checks: %v", err)
+				return
+			}
+			for i, repoResult := range result.RepositoryResults {
+				name := repoResult.Repository.Name
+				if previous, ok := latest[name]; ok {
+					repoResult.AnalysisResult = watch.MergeAnalysis(previous.AnalysisResult, repoResult.AnalysisResult, changes[name])
+				}
+				result.RepositoryResults[i] = repoResult
+				latest[name] = repoResult
+			}
+			formatter.DisplayResults(*result)
+			printWatchSummary(coreRepos, latest)
+		})
+		if err != nil {
+			color.Red("Error: %v", err)
+			os.Exit(1)
+		}
+	},
+}
+
+// filesWithExtensions returns the files that end with one of the extensions
+func filesWithExtensions(files, extensions []string) []string {
+	matched := []string{}
+	for _, file := range files {
+		for _, ext := range extensions {
+			if strings.HasSuffix(file, ext) {
+				matched = append(matched, file)
+				break
+			}
+		}
+	}
+	return matched
+}
+
+// printWatchSummary prints the latest status and score of every repository
+func printWatchSummary(repos []core.Repository, latest map[string]core.RepositoryResult) {
+	results := make([]core.RepositoryResult, 0, len(repos))
+	for _, repo := range repos {
+		if result, ok := latest[repo.Name]; ok {
+			results = append(results, result)
+		}
+	}
+	summary := health.Summarize(results)
+
+	fmt.Println()
+	color.Cyan("=== Summary at %s ===", time.Now().Format("15:04:05"))
+	for _, result := range results {
+		issues := 0
+		for _, check := range result.CheckResults {
+			issues += len(check.Issues)
+		}
+		fmt.Printf("  %-30s %-9s %3d/100  %d issue(s)\n", result.Repository.Name, result.Status, result.Score, issues)
+	}
+	fmt.Printf("  Average score: %d/100, %d issue(s), %d failed\n", summary.AverageScore, summary.TotalIssues, summary.FailedRepos)
+	fmt.Println()
+}
+
+// loadHealthConfig loads the health config files given with --config. Several
+// files are merged in order; a single missing file or no file at all falls back
+// to built-in defaults.
+func loadHealthConfig() (*healthconfig.AdvancedConfig, error) {
+	switch {
+	case len(healthConfigs) > 1 || (len(healthConfigs) == 1 && healthConfigs[0] == healthconfig.StdinConfigPath):
+		return healthconfig.LoadAdvancedConfigs(healthConfigs, os.Stdin)
+	case len(healthConfigs) == 1:
+		return healthconfig.LoadAdvancedConfigOrDefault(healthConfigs[0])
+	default:
+		return healthconfig.LoadAdvancedConfigOrDefault("orchestration.yaml")
+	}
+}
+
+// writeComplexityReport writes complexity-only results as json or csv
+func writeComplexityReport(w io.Writer, format string, repos []core.Repository, results []*core.AnalysisResult) error {
+	repoResults := make([]core.RepositoryResult, 0, len(repos))
+	for i, repo := range repos {
+		if i < len(results) && results[i] != nil {
+			repoResults = append(repoResults, core.RepositoryResult{Repository: repo, AnalysisResult: results[i]})
+		}
+	}
+
+	if format == "csv" {
+		return reporting.NewCSVFormatter().WriteComplexity(w, repoResults)
+	}
+
+	threshold := healthMaxComplexity
+	if threshold <= 0 {
+		threshold = reporting.NewFormatter(false).ComplexityThreshold
+	}
+	return reporting.WriteJSON(w, reporting.NewComplexityDetailedReport(threshold, repoResults))
+}
+
+// writeComplexityOutputFile writes complexity-only results to path; only .json and .csv are supported
+func writeComplexityOutputFile(path string, repos []core.Repository, results []*core.AnalysisResult) error {
+	format, err := reporting.ReportFormatForPath(path)
+	if err != nil {
+		return err
+	}
+	if format != "json" && format != "csv" {
+		return fmt.Errorf("--output with --complexity-report supports .json and .csv, got .%s", format)
+	}
+
+	file, err := os.Create(path) //nolint:gosec // Path is provided by the user
+	if err != nil {
+		return fmt.Errorf("failed to create output file: %w", err)
+	}
+	if err := writeComplexityReport(file, format, repos, results); err != nil {
+		_ = file.Close()
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return file.Close()
+}
+
+// simpleLogger provides a basic logger implementation
+type simpleLogger struct{}
+
+func (l *simpleLogger) Debug(msg string, fields ...core.Field) {
+	if healthVerbose {
+		_, _ = fmt.Fprint(color.Output, "[DEBUG] "+msg+l.formatFieldsAsString(fields))
+	}
+}
+
+func (l *simpleLogger) Info(msg string, fields ...core.Field) {
+	if healthQuiet {
+		return
+	}
+	_, _ = fmt.Fprint(color.Output, "[INFO] "+msg+l.formatFieldsAsString(fields))
+}
+
+func (l *simpleLogger) Warn(msg string, fields ...core.Field) {
+	color.Yellow("[WARN] " + msg + l.formatFieldsAsString(fields))
+}
+
+func (l *simpleLogger) Error(msg string, fields ...core.Field) {
+	color.Red("[ERROR] " + msg + l.formatFieldsAsString(fields))
+}
+
+func (l *simpleLogger) Fatal(msg string, fields ...core.Field) {
+	color.Red("[FATAL] " + msg + l.formatFieldsAsString(fields))
+	os.Exit(1)
+}
+
+func (l *simpleLogger) formatFieldsAsString(fields []core.Field) string {
+	if len(fields) == 0 {
+		return "\n"
+	}
+
+	var result string
+	for _, field := range fields {
+		result += fmt.Sprintf(" [%s=%v]", field.Key, field.Value)
+	}
+	return result + "\n"
+}
+
+// detectRepositoryLanguage attempts to detect the primary language of a repository
+//
+//nolint:gocyclo
+func detectRepositoryLanguage(repo config.Repository, repoPath string, excludes []string) string {
+	// First, check tags for language hints
+	for _, tag := range repo.Tags {
+		switch tag {
+		case "go", "golang":
+			return "go"
+		case "python", "py":
+			return "python"
+		case "javascript", "js", "node", "nodejs":
+			return "javascript"
+		case "java":
+			return "java"
+		case "rust":
+			return "rust"
+		case "cpp", "c++":
+			return "cpp"
+		case "c":
+			return "c"
+		case "shell", "bash", "sh":
+			return "shell"
+		case "dart", "flutter":
+			return "dart"
+		case "elixir", "phoenix":
+			return "elixir"
+		}
+	}
+
+	// If no language tag found, count source files by extension
+	languages, err := language.Detect(repoPath, excludes)
+	if err != nil {
+		return "" // Unknown language
+	}
+	return languages.Primary()
+}
+
+// changedAnalysisFiles returns the files changed since ref that have one of the given
+// extensions, joined with the repository path to match the paths analyzers discover
+func changedAnalysisFiles(repoPath, ref string, extensions []string) ([]string, error) {
+	changed, err := git.ChangedFiles(repoPath, ref)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", repoPath, err)
+	}
+
+	files := []string{}
+	for _, file := range changed {
+		for _, ext := range extensions {
+			if strings.HasSuffix(file, ext) {
+				files = append(files, filepath.Join(repoPath, file))
+				break
+			}
+		}
+	}
+	return files, nil
+}
+
+// validateHealthConfigs reports every problem in the given health config files
+// and returns the exit code: 0 when all files are valid, 1 otherwise
+func validateHealthConfigs(paths []string) int {
+	if len(paths) == 0 {
+		if _, err := os.Stat("orchestration.yaml"); os.IsNotExist(err) {
+			color.Green("No health config found; built-in defaults are used")
+			return 0
+		}
+		paths = []string{"orchestration.yaml"}
+	}
+
+	knownCheckers := registeredCheckerIDs()
+	validator := healthconfig.NewConfigValidator()
+	analyzerReg := health.NewAnalyzerRegistry(health.NewFileSystem(), &simpleLogger{})
+	validator.AddRule(&healthconfig.AnalyzerExtensionRule{Defaults: analyzerReg.DefaultExtensions()})
+	validator.AddRule(reporting.ConsoleTemplateRule{})
+	problemCount := 0
+	readStdin := false
+	for _, path := range paths {
+		if path == healthconfig.StdinConfigPath {
+			if readStdin {
+				color.Red("Error: config can be read from stdin only once")
+				return 1
+			}
+			readStdin = true
+		}
+		name, data, err := readHealthConfig(path)
+		if err != nil {
+			color.Red("%s: %v", name, err)
+			problemCount++
+			continue
+		}
+
+		problems := validator.ValidateConfigData(name, data, knownCheckers, analyzerReg.GetSupportedLanguages())
+		if len(problems) == 0 {
+			color.Green("✅ %s is valid", name)
+			continue
+		}
+		for _, problem := range problems {
+			color.Red("%s", problem)
+		}
+		problemCount += len(problems)
+	}
+
+	if problemCount > 0 {
+		color.Red("❌ %d problem(s) found", problemCount)
+		return 1
+	}
+	return 0
+}
+
+// registeredCheckerIDs returns the IDs of the built-in checkers
+func registeredCheckerIDs() []string {
+	checkerRegistry := health.NewCheckerRegistry(health.NewCommandExecutor(healthTimeout))
+	ids := make([]string, 0)
+	for _, checker := range checkerRegistry.GetCheckers() {
+		ids = append(ids, checker.ID())
+	}
+	return ids
+}
+
+// readHealthConfig reads a health config file, or stdin for "-", and returns
+// the name to report its problems under
+func readHealthConfig(path string) (string, []byte, error) {
+	if path == healthconfig.StdinConfigPath {
+		data, err := io.ReadAll(os.Stdin)
+		return "<stdin>", data, err
+	}
+	data, err := os.ReadFile(path) //nolint:gosec // Config path is from user input
+	return path, data, err
+}
+
+// loadHealthRepositories loads the repositories selected by --tag from the
+// repository config, detecting each one's language
+func loadHealthRepositories(advConfig *healthconfig.AdvancedConfig) ([]core.Repository, error) {
+	if healthInput != "" {
+		if healthPath != "" {
+			return nil, fmt.Errorf("--input and --path cannot be combined")
+		}
+		return inputRepositories(healthInput, analyzerExcludePatterns(advConfig))
+	}
+	if healthPath != "" {
+		repo, err := pathRepository(healthPath, analyzerExcludePatterns(advConfig))
+		if err != nil {
+			return nil, err
+		}
+		return []core.Repository{repo}, nil
+	}
+
+	cfg, err := config.LoadConfig(configFile)
+	if err != nil {
+		return nil, err
+	}
+
+	repositories := cfg.FilterRepositoriesByTag(tag)
+	coreRepos := make([]core.Repository, len(repositories))
+	for i, repo := range repositories {
+		// Use the actual repository path if it exists, otherwise use the specified path
+		repoPath := repo.Path
+		if repoPath == "" {
+			repoPath = filepath.Join("cloned_repos", repo.Name)
+		}
+
+		// Detect language from repository tags or directory structure
+		language := detectRepositoryLanguage(repo, repoPath, analyzerExcludePatterns(advConfig))
+
+		coreRepos[i] = core.Repository{
+			Name:     repo.Name,
+			Path:     repoPath,
+			URL:      repo.URL,
+			Branch:   repo.Branch,
+			Tags:     repo.Tags,
+			Language: language,
+			Metadata: make(map[string]string),
+		}
+	}
+	return coreRepos, nil
+}
+
+// inputRepositories loads the repositories listed in an --input file, detecting
+// the language of those that do not name one from their listed files
+func inputRepositories(path string, excludes []string) ([]core.Repository, error) {
+	input, err := health.LoadInput(path)
+	if err != nil {
+		return nil, err
+	}
+	healthInputData = input
+
+	repos := input.CoreRepositories()
+	for i, repo := range input.Repositories {
+		if repos[i].Language != "" {
+			continue
+		}
+		if repo.Files != nil {
+			repos[i].Language = language.DetectFiles(repo.Path, repo.Files, excludes).Primary()
+		} else {
+			repos[i].Language = detectRepositoryLanguage(config.Repository{Name: repo.Name, Tags: repo.Tags}, repo.Path, excludes)
+		}
+	}
+	return repos, nil
+}
+
+// inputFileIndex returns an index of the files the --input file lists for the
+// repository, or nil when it is walked as usual
+func inputFileIndex(repo core.Repository) *core.FileIndex {
+	if healthInputData == nil {
+		return nil
+	}
+	for _, listed := range healthInputData.Repositories {
+		if listed.Name == repo.Name && listed.Files != nil {
+			return core.NewListedFileIndex(repo.Path, listed.Files)
+		}
+	}
+	return nil
+}
+
+// pathRepository describes a directory given with --path as a repository, named
+// after the directory, so that it can be checked without a config.yaml
+func pathRepository(dir string, excludes []string) (core.Repository, error) {
+	absPath, err := filepath.Abs(dir)
+	if err != nil {
+		return core.Repository{}, err
+	}
+	info, err := os.Stat(absPath)
+	if err != nil {
+		return core.Repository{}, fmt.Errorf("--path: %w", err)
+	}
+	if !info.IsDir() {
+		return core.Repository{}, fmt.Errorf("--path: %s is not a directory", dir)
+	}
+
+	repo := config.Repository{Name: filepath.Base(absPath), Path: absPath}
+	return core.Repository{
+		Name:     repo.Name,
+		Path:     repo.Path,
+		Language: detectRepositoryLanguage(repo, absPath, excludes),
+		Metadata: make(map[string]string),
+	}, nil
+}
+
+// analyzerExcludePatterns collects the exclude patterns of every configured analyzer
+func analyzerExcludePatterns(advConfig *healthconfig.AdvancedConfig) []string {
+	if advConfig == nil {
+		return nil
+	}
+	var excludes []string
+	for _, analyzerConfig := range advConfig.Analyzers {
+		excludes = append(excludes, analyzerConfig.ExcludePatterns...)
+	}
+	return excludes
+}
+
+// setCheckerOption sets an option of one checker, starting from the checker's
+// defaults when the config does not mention it
+func setCheckerOption(advConfig *healthconfig.AdvancedConfig, checkerRegistry *health.CheckerRegistry, checkerID, key string, value interface{}) {
+	config, exists := advConfig.Checkers[checkerID]
+	if !exists {
+		checker, err := checkerRegistry.GetChecker(checkerID)
+		if err != nil {
+			return
+		}
+		config = checker.Config()
+	}
+
+	options := make(map[string]interface{}, len(config.Options)+1)
+	for k, v := range config.Options {
+		options[k] = v
+	}
+	options[key] = value
+	config.Options = options
+
+	if advConfig.Checkers == nil {
+		advConfig.Checkers = make(map[string]core.CheckerConfig)
+	}
+	advConfig.Checkers[checkerID] = config
+}
+
+// restrictAnalysisToChanges limits the engine's analysis of each repository to
+// files changed since ref, and its functions to those with changed lines
+func restrictAnalysisToChanges(engine *health.Engine, analyzerReg *health.AnalyzerRegistry, advConfig *healthconfig.AdvancedConfig, repos []core.Repository, ref string) error {
+	for _, repo := range repos {
+		analyzer, err := analyzerReg.GetAnalyzer(repo.Language)
+		if err != nil {
+			continue // No analysis runs for this repository anyway
+		}
+
+		files, err := changedAnalysisFiles(repo.Path, ref, analyzerExtensions(advConfig, analyzer))
+		if err != nil {
+			return err
+		}
+		engine.SetAnalysisFiles(repo.Name, files)
+
+		changed, err := git.ChangedLines(repo.Path, ref)
+		if err != nil {
+			return fmt.Errorf("%s: %w", repo.Path, err)
+		}
+		engine.SetChangedLines(repo.Name, changed)
+	}
+	return nil
+}
+
+// validateAnalyzerExtensions rejects configured file extensions that more than one analyzer would process
+func validateAnalyzerExtensions(advConfig *healthconfig.AdvancedConfig, analyzerReg *health.AnalyzerRegistry) error {
+	rule := &healthconfig.AnalyzerExtensionRule{Defaults: analyzerReg.DefaultExtensions()}
+	return rule.Validate(advConfig)
+}
+
+// analyzerExtensions returns the file extensions an analyzer processes: its
+// configured file_extensions, or its defaults
+func analyzerExtensions(advConfig *healthconfig.AdvancedConfig, analyzer core.Analyzer) []string {
+	configured, _ := advConfig.GetAnalyzerConfig(analyzer.Language())
+	return configured.Extensions(analyzer.SupportedExtensions())
+}
+
+// capitalizeFirst capitalizes the first letter of a string
+func capitalizeFirst(s string) string {
+	if len(s) == 0 {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
+
+var healthExplainCmd = &cobra.Command{
+	Use:   "explain [checker-id...]",
+	Short: "Describe what health checkers verify and how to configure them",
+	Long: `Print each checker's description, category, default severity, configurable
+options with their defaults, and the external tools it needs.
+
+Without arguments every checker is described.
+
+Examples:
+  repos health explain                    # Describe all checkers
+  repos health explain branch-protection  # Describe a single checker`,
+	Run: func(_ *cobra.Command, args []string) {
+		checkerRegistry := health.NewCheckerRegistry(health.NewCommandExecutor(30 * time.Second))
+		if err := checkerRegistry.ValidateCheckerIDs(args); err != nil {
+			color.Red("Error: %v", err)
+			os.Exit(1)
+		}
+
+		var checkers []core.Checker
+		if len(args) == 0 {
+			checkers = checkerRegistry.GetCheckers()
+			sort.Slice(checkers, func(i, j int) bool { return checkers[i].ID() < checkers[j].ID() })
+		} else {
+			for _, id := range args {
+				checker, _ := checkerRegistry.GetChecker(id)
+				checkers = append(checkers, checker)
+			}
+		}
+
+		for i, checker := range checkers {
+			if i > 0 {
+				fmt.Println()
+			}
+			explainChecker(os.Stdout, checker)
+		}
+	},
+}
+
+// explainChecker writes a checker's metadata in a human-readable form
+func explainChecker(w io.Writer, checker core.Checker) {
+	config := checker.Config()
+	status := "enabled by default"
+	if !config.Enabled {
+		status = "opt-in, enable under checkers." + checker.ID()
+	}
+
+	var metadata core.CheckerMetadata
+	if describer, ok := checker.(core.CheckerDescriber); ok {
+		metadata = describer.Metadata()
+	}
+	description := metadata.Description
+	if description == "" {
+		description = "No description available."
+	}
+
+	_, _ = fmt.Fprintf(w, "%s (%s)\n", checker.ID(), checker.Name())
+	_, _ = fmt.Fprintf(w, "  %s\n", description)
+	_, _ = fmt.Fprintf(w, "  Category:         %s\n", checker.Category())
+	_, _ = fmt.Fprintf(w, "  Default severity: %s\n", config.Severity)
+	_, _ = fmt.Fprintf(w, "  Status:           %s\n", status)
+
+	if len(metadata.RequiredTools) > 0 {
+		_, _ = fmt.Fprintf(w, "  Required tools:   %s\n", strings.Join(metadata.RequiredTools, ", "))
+	} else {
+		_, _ = fmt.Fprintln(w, "  Required tools:   none")
+	}
+
+	if len(metadata.Options) == 0 {
+		_, _ = fmt.Fprintln(w, "  Options:          none")
+		return
+	}
+	_, _ = fmt.Fprintln(w, "  Options:")
+	for _, option := range metadata.Options {
+		_, _ = fmt.Fprintf(w, "    %-26s %s (default: %v)\n", option.Name, option.Description, option.Default)
+	}
+}
+
+// listHealthCategories lists all available categories, checkers, and analyzers
+func listHealthCategories() {
+	logger := &simpleLogger{}
+
+	// Create registries to discover available checkers and analyzers
+	executor := health.NewCommandExecutor(30 * time.Second)
+	checkerRegistry := health.NewCheckerRegistry(executor)
+
+	fs := health.NewFileSystem()
+	analyzerRegistry := health.NewAnalyzerRegistry(fs, logger)
+
+	fmt.Println("=== Available Health Check Categories ===")
+	fmt.Println()
+
+	// List checkers by category
 	checkers := checkerRegistry.GetCheckers()
 	checkersByCategory := make(map[string][]core.Checker)
 
@@ -775,18 +1870,29 @@ func listHealthCategories() {
 		checkersByCategory[category] = append(checkersByCategory[category], checker)
 	}
 
+	categories := make([]string, 0, len(checkersByCategory))
+	for category := range checkersByCategory {
+		categories = append(categories, category)
+	}
+	sort.Strings(categories)
+
 	fmt.Println("📋 CHECKERS:")
-	for category, categoryCheckers := range checkersByCategory {
+	for _, category := range categories {
+		categoryCheckers := checkersByCategory[category]
+		sort.Slice(categoryCheckers, func(i, j int) bool {
+			return categoryCheckers[i].ID() < categoryCheckers[j].ID()
+		})
+
 		fmt.Printf("  Category: %s\n", category)
 		for _, checker := range categoryCheckers {
 			config := checker.Config()
-			status := "disabled"
-			if config.Enabled {
-				status = "enabled"
+			status := "enabled"
+			if !config.Enabled {
+				status = "opt-in"
 			}
-			fmt.Printf("    • %s (%s) - %s [%s]\n",
-				checker.Name(),
+			fmt.Printf("    • %-22s %s [%s, %s]\n",
 				checker.ID(),
+				checker.Name(),
 				status,
 				config.Severity)
 		}
@@ -812,6 +1918,8 @@ func listHealthCategories() {
 
 	fmt.Println("\nUsage Examples:")
 	fmt.Println("  repos health --category git,security     # Run only git and security checkers")
+	fmt.Println("  repos health --checker git-status        # Run a single checker by ID")
+	fmt.Println("  repos health explain git-status          # Describe a checker and its options")
 	fmt.Println("  repos health --verbose                   # Show detailed output")
 	fmt.Println("  repos health --dry-run                   # Preview what would be executed")
 }
@@ -842,9 +1950,22 @@ func generateHealthConfig() {
 	fmt.Println("# Engine configuration for parallel execution and performance")
 	fmt.Println("engine:")
 	fmt.Println("  max_concurrency: 4        # Maximum parallel checkers (default: 4)")
+	fmt.Println("  max_external_processes: 2 # Dependency and security checkers running tools like mvn at once (0: no limit)")
+	fmt.Println("  on_missing_tool: warn      # Tools that are not installed: warn, skip or error (per checker: options.on_missing_tool)")
 	fmt.Println("  timeout: 5m                # Global timeout for all checks")
 	fmt.Println("  cache_enabled: true        # Enable result caching")
 	fmt.Println("  cache_ttl: 1h             # Cache time-to-live")
+	fmt.Println("  subprojects:               # Check each project of a monorepo separately")
+	fmt.Println("    enabled: false")
+	fmt.Println("    manifests: [\"go.mod\", \"package.json\"] # Files marking a sub-project (default: common build manifests)")
+	fmt.Println("    max_depth: 3             # Deepest directory level searched for sub-projects")
+	fmt.Println("  scoring:")
+	fmt.Println("    model: checker           # checker (scores as reported), binary (any issue -> 0) or graded")
+	fmt.Println("    penalties:               # Per-issue penalty by severity for the graded model")
+	fmt.Println("      critical: 50")
+	fmt.Println("      high: 25")
+	fmt.Println("      medium: 10")
+	fmt.Println("      low: 5")
 	fmt.Println()
 
 	// Checkers configuration
@@ -868,7 +1989,7 @@ func generateHealthConfig() {
 			config := checker.Config()
 			fmt.Printf("  %s:\n", checker.ID())
 			fmt.Printf("    enabled: %t             # Enable/disable this checker\n", config.Enabled)
-			fmt.Printf("    severity: %s           # Severity level: low, medium, high, critical\n", config.Severity)
+			fmt.Printf("    severity: %s           # Severity of findings (info, low, medium, high, critical); high/critical fail the run, info never does\n", config.Severity)
 			fmt.Printf("    timeout: %s            # Timeout for this specific checker\n", config.Timeout)
 			fmt.Printf("    categories: [\"%s\"]      # Category classification\n", category)
 
@@ -877,27 +1998,63 @@ func generateHealthConfig() {
 			switch checker.ID() {
 			case "git-status":
 				fmt.Println("      check_uncommitted: true    # Check for uncommitted changes")
-				fmt.Println("      check_untracked: true      # Check for untracked files")
+				fmt.Println("      ignore_untracked: false    # Do not count untracked files (?? entries)")
+				fmt.Println("      report_ignored: false      # Count files matched by .gitignore in the ignored_files metric")
+				fmt.Println("      staged_severity: medium    # Severity of staged changes; info makes them advisory")
+				fmt.Println("      unstaged_severity: medium  # Severity of unstaged changes")
 				fmt.Println("      check_unpushed: true       # Check for unpushed commits")
 
 			case "git-last-commit":
 				fmt.Println("      max_days_since_commit: 30  # Alert if last commit is older than N days")
 				fmt.Println("      check_commit_messages: true # Validate commit message format")
 
+			case "git-bus-factor":
+				fmt.Println("      months: 12                 # Months of history to count")
+				fmt.Println("      max_commits: 1000          # Most recent commits to count at most")
+				fmt.Println("      max_author_share: 80       # Warn when one author made more than this percentage of the commits")
+				fmt.Println("      min_commits: 10            # Fewer commits than this are too few to judge")
+
+			case "git-hooks":
+				fmt.Println("      # Detects pre-commit, husky and custom .git/hooks scripts")
+
+			case "git-size":
+				fmt.Println("      max_file_size_mb: 5        # Report files larger than this without an LFS filter")
+				fmt.Println("      top_offenders: 10          # Number of largest files to report")
+				fmt.Println("      working_tree_only: false   # Skip history and only check the checkout (--working-tree-only)")
+
+			case "git-remote":
+				fmt.Println("      remote: origin             # Remote that must be reachable and hold the branch")
+				fmt.Println("      timeout_seconds: 10        # Seconds to wait for 'git ls-remote'")
+
 			case "dependencies-outdated":
 				fmt.Println("      package_managers: [\"npm\", \"pip\", \"go\", \"maven\"] # Supported package managers")
 				fmt.Println("      severity_threshold: \"minor\" # Minimum severity to report: patch, minor, major")
-				fmt.Println("      max_age_days: 180          # Consider packages outdated after N days")
+				fmt.Println("      max_major_behind: 0        # Report Go/npm dependencies more than N major versions behind as high (0 = off)")
+				fmt.Println("      max_age_months: 0          # Report Go dependencies more than N months behind their latest release as high (0 = off)")
+				fmt.Println("      check_deprecated: true     # Report deprecated and retracted Go modules and npm packages")
+				fmt.Println("      ecosystems: []             # Only check these ecosystems, e.g. [\"go\"] (--ecosystem); empty checks all")
+				fmt.Println("      manifests:                 # Extra dependency files and the lockfiles expected next to them")
+				fmt.Println("        - file: mix.exs")
+				fmt.Println("          ecosystem: elixir")
+				fmt.Println("          lockfiles: [\"mix.lock\"]")
 
 			case "vulnerability-scan":
 				fmt.Println("      scan_dependencies: true    # Scan dependencies for vulnerabilities")
 				fmt.Println("      scan_code: false          # Scan source code (requires additional tools)")
 				fmt.Println("      severity_threshold: \"medium\" # Minimum severity to report")
 
+			case "secrets":
+				fmt.Println("      scan_history: false        # Also scan commit patches for secrets (--scan-history)")
+				fmt.Println("      max_commits: 200           # Most recent commits scanned with scan_history")
+
 			case "branch-protection":
 				fmt.Println("      require_reviews: true      # Require pull request reviews")
 				fmt.Println("      require_status_checks: true # Require status checks to pass")
 				fmt.Println("      enforce_admins: false      # Enforce restrictions for admins")
+				fmt.Println("      default_branch: \"\"         # Used when neither origin's HEAD nor the local HEAD names one")
+				fmt.Println("      platform: auto             # github, gitlab or bitbucket; auto detects it from the remote URL")
+				fmt.Println("      gitlab_url: \"\"             # Self-hosted GitLab, e.g. https://git.example.com")
+				fmt.Println("      gitlab_token: \"\"           # GitLab token with read_api scope (default: GITLAB_TOKEN)")
 
 			case "license-check":
 				fmt.Println("      allowed_licenses:          # List of allowed licenses")
@@ -906,6 +2063,9 @@ func generateHealthConfig() {
 				fmt.Println("        - \"BSD-3-Clause\"")
 				fmt.Println("      check_compatibility: true  # Check license compatibility")
 
+			case "dependency-licenses":
+				fmt.Println("      accepted_licenses: [\"MIT\", \"Apache-2.0\", \"BSD-3-Clause\", \"ISC\"] # Licenses dependencies may use; empty accepts any compatible license")
+
 			case "ci-config":
 				fmt.Println("      platforms: [\"github\", \"gitlab\", \"jenkins\"] # CI platforms to check")
 				fmt.Println("      require_tests: true        # Require test execution in CI")
@@ -916,13 +2076,67 @@ func generateHealthConfig() {
 				fmt.Println("      require_code_examples: true # Require code examples and usage")
 				fmt.Println("      require_license_info: true # Require license information")
 				fmt.Println("      min_length: 200            # Minimum content length")
-				fmt.Println("      required_sections:         # Required sections in README")
+				fmt.Println("      required_sections:         # Required headings in README, matched case-insensitively at any level")
 				fmt.Println("        - \"description\"")
 				fmt.Println("        - \"installation\"")
 				fmt.Println("        - \"usage\"")
+				fmt.Println("      min_sections: 0            # How many required sections must be present (0: all)")
 				fmt.Println("      custom_badge_patterns:     # Custom badge patterns to check")
 				fmt.Println("        - \"travis-ci\"")
 				fmt.Println("        - \"codecov\"")
+				fmt.Println("      check_links: false         # Verify relative links and anchors in the README")
+				fmt.Println("      check_external_links: false # Also request http(s) links (needs network access)")
+				fmt.Println("      external_link_timeout: 10  # Seconds to wait for each external link")
+				fmt.Println("      external_link_concurrency: 4 # Maximum concurrent external link requests")
+				fmt.Println("      external_link_allowed_domains: [] # Only request links on these domains (empty = all)")
+				fmt.Println("      external_link_denied_domains: [\"localhost\"] # Never request links on these domains")
+				fmt.Println("      follow_redirects: false    # Judge links by their redirect target; otherwise 3xx counts as OK")
+
+			case "tech-debt":
+				fmt.Println("      threshold: 50              # Maximum TODO/FIXME/HACK/XXX markers before the check degrades")
+
+			case "function-length":
+				fmt.Println("      max_lines: 100             # Longest a function may be before it is reported")
+
+			case "code-duplication":
+				fmt.Println("      min_lines: 6               # Shortest block of identical code lines reported")
+				fmt.Println("      max_duplication_percent: 5 # Share of duplicated lines above which the check warns")
+
+			case "cyclomatic-complexity":
+				fmt.Println("      max_complexity: 10         # Highest cyclomatic complexity before a function is reported")
+				fmt.Println("      max_file_complexity: 0     # Highest total complexity of a file's functions; 0 disables the file check")
+
+			case "test-presence":
+				fmt.Println("      patterns:                  # Test file patterns per language, replacing that language's defaults")
+				fmt.Println("        python: [\"test_*.py\", \"tests/\"] # dir/ matches files under a directory")
+
+			case "go-import-cycles":
+				fmt.Println("      # Opt-in: set enabled: true to report package import cycles in Go modules")
+
+			case "go-unused-exports":
+				fmt.Println("      # Opt-in: set enabled: true to report exported Go symbols unused within the module")
+				fmt.Println("      allowlist:                 # Symbols or packages used via reflection/plugins")
+				fmt.Println("        - \"ServeHTTP\"")
+				fmt.Println("        - \"example.com/mod/plugins/...\"")
+
+			case "go-lint":
+				fmt.Println("      # Opt-in: set enabled: true to run go vet and golangci-lint on Go modules")
+				fmt.Println("      use_go_vet: true           # Run 'go vet ./...'")
+				fmt.Println("      use_golangci_lint: true    # Run 'golangci-lint run --out-format json'")
+
+			case "build":
+				fmt.Println("      compile_tests: false       # Also run 'go test -run=^$ ./...'")
+				fmt.Println("      # commands:                # Build commands per language; go is built in")
+				fmt.Println("      #   - language: rust")
+				fmt.Println("      #     manifest: Cargo.toml")
+				fmt.Println("      #     command: [cargo, check]")
+				fmt.Println("      #     test_command: [cargo, check, --tests]")
+
+			case "terraform-deprecated":
+				fmt.Println("      # Runs on repositories with .tf files; no options")
+
+			case "k8s-manifests":
+				fmt.Println("      # Runs on repositories with Kubernetes manifests; no options")
 
 			default:
 				fmt.Println("      # Checker-specific options would be documented here")
@@ -949,7 +2163,7 @@ func generateHealthConfig() {
 		// Add language-specific exclude patterns
 		switch language {
 		case "go":
-			fmt.Println("    exclude_patterns: [\"vendor\", \"_test.go\", \"*.pb.go\"]")
+			fmt.Println("    exclude_patterns: [\"vendor\", \"*.pb.go\"]")
 		case "python":
 			fmt.Println("    exclude_patterns: [\"__pycache__\", \"*.pyc\", \".venv\", \"venv\"]")
 		case "javascript":
@@ -959,10 +2173,16 @@ func generateHealthConfig() {
 		default:
 			fmt.Println("    exclude_patterns: [\"build\", \"dist\", \"target\"]")
 		}
+		fmt.Println("    include_tests: false       # Also analyze test files such as *_test.go, *.test.js, test_*.py and *Test.java")
+		fmt.Println("    include_generated: false   # Also analyze generated files, e.g. with a \"Code generated ... DO NOT EDIT.\" header")
 
 		fmt.Println("    complexity_enabled: true   # Enable complexity analysis")
 		fmt.Println("    function_level: true       # Analyze at function level")
 		fmt.Println("    categories: [\"quality\", \"analysis\"]")
+		if language == "go" {
+			fmt.Println("    options:")
+			fmt.Println("      detect_import_cycles: false # Opt-in: record package import cycles (requires go.mod)")
+		}
 		fmt.Println()
 	}
 
@@ -971,7 +2191,7 @@ func generateHealthConfig() {
 	fmt.Println("reporters:")
 	fmt.Println("  console:")
 	fmt.Println("    enabled: true              # Console output")
-	fmt.Println("    template: table            # Output format: table, list, summary")
+	fmt.Println("    template: table            # Built-in layout; or a Go text/template, inline or as a file path")
 	fmt.Println("    options:")
 	fmt.Println("      show_summary: true       # Show summary statistics")
 	fmt.Println("      show_details: true       # Show detailed results")
@@ -997,6 +2217,7 @@ func generateHealthConfig() {
 	fmt.Println("# Category configurations for organizing checks")
 	fmt.Println("categories:")
 
+	defaultCategories := healthconfig.NewDefaultAdvancedConfig()
 	categories := make(map[string]bool)
 	for category := range checkersByCategory {
 		categories[category] = true
@@ -1036,6 +2257,13 @@ func generateHealthConfig() {
 		}
 
 		fmt.Printf("    severity: %s              # Default severity for category\n", severity)
+		weight, ok := defaultCategories.GetCategoryWeight(category)
+		if !ok {
+			weight = core.DefaultCategoryWeight
+		}
+		fmt.Printf("    weight: %-18v # Relative weight in the overall score (default 1; disabled categories count as 0)\n", weight)
+		fmt.Println("    fail_on: critical          # Lowest check status that fails the run: warning, critical or never")
+		fmt.Println("    # min_score: 60            # Category score every repository needs (0-100)")
 		fmt.Println()
 	}
 
@@ -1054,6 +2282,76 @@ func generateHealthConfig() {
 	fmt.Println("#         enabled: false          # Disable for legacy repos")
 	fmt.Println("#     engine:")
 	fmt.Println("#       max_concurrency: 1       # Run sequentially for legacy repos")
+	fmt.Println("#     min_score: 50              # Overall score these repos need, replacing min_score")
+	fmt.Println("#   - name: \"payments-services\"")
+	fmt.Println("#     conditions:")
+	fmt.Println("#       - type: \"path\"")
+	fmt.Println("#         operator: \"glob\"     # Or \"regex\"")
+	fmt.Println("#         value: \"services/payments/**\"")
+	fmt.Println("#       - type: \"subpath\"      # Repository contains files matching the glob")
+	fmt.Println("#         operator: \"glob\"")
+	fmt.Println("#         value: \"deploy/**/*.yaml\"")
+	fmt.Println("#     checkers:")
+	fmt.Println("#       vulnerability-scan:")
+	fmt.Println("#         severity: critical")
+	fmt.Println()
+	fmt.Println("# Overall score every repository needs for the run to pass (0-100; --min-score replaces it)")
+	fmt.Println("# min_score: 70")
+	fmt.Println()
+	fmt.Println("# Exit code of each outcome of a run, e.g. to mark builds with warnings unstable")
+	fmt.Println("# exit_codes:")
+	fmt.Println("#   healthy: 0")
+	fmt.Println("#   warning: 0                   # Passing run with warnings in a repository")
+	fmt.Println("#   critical: 2                  # Findings that fail the run, or a score below its minimum")
+	fmt.Println("#   errored: 3                   # Checks that could not run")
+	fmt.Println()
+	fmt.Println("# Checkers never run on repositories with a tag")
+	fmt.Println("# skip_checkers:")
+	fmt.Println("#   archived:")
+	fmt.Println("#     - branch-protection")
+	fmt.Println("#     - git-remote")
+	fmt.Println()
+
+	// Integrations configuration
+	fmt.Println("# External integrations")
+	fmt.Println("# integrations:")
+	fmt.Println("#   github:")
+	fmt.Println("#     enabled: true")
+	fmt.Println("#     create_issues: true      # Open issues labelled repos-health for critical findings")
+	fmt.Println("#     token: \"\"               # Or set GITHUB_TOKEN")
+	fmt.Println("#     base_url: \"https://github.example.com/api/v3\"  # GitHub Enterprise; default: GITHUB_API_URL or api.github.com")
+	fmt.Println("#   jira:")
+	fmt.Println("#     enabled: true            # Create tickets for critical findings")
+	fmt.Println("#     base_url: \"https://example.atlassian.net\"")
+	fmt.Println("#     username: \"bot@example.com\"")
+	fmt.Println("#     api_token: \"\"            # Or set JIRA_API_TOKEN")
+	fmt.Println("#     project: \"OPS\"")
+	fmt.Println("#     issue_type: \"Bug\"        # Default: Bug")
+	fmt.Println("#     timeout: 30s             # Per-request timeout")
+	fmt.Println("#   tracing:")
+	fmt.Println("#     otlp_endpoint: \"http://localhost:4318\"  # Or set OTEL_EXPORTER_OTLP_ENDPOINT")
+	fmt.Println("#     service_name: \"repos-health\"")
+	fmt.Println("#     headers:")
+	fmt.Println("#       Authorization: \"Bearer <token>\"")
+	fmt.Println("#     timeout: 10s             # Export timeout")
+	fmt.Println()
+
+	// Extensions configuration
+	fmt.Println("# Custom checkers run a command in each repository and read its exit code")
+	fmt.Println("# and JSON output: {\"status\", \"score\", \"issues\": [{type, severity, message, file, line}], \"metrics\"}")
+	fmt.Println("# extensions:")
+	fmt.Println("#   custom_checkers:")
+	fmt.Println("#     - id: no-secrets")
+	fmt.Println("#       name: \"No Secrets\"")
+	fmt.Println("#       category: security     # Default: custom")
+	fmt.Println("#       command: ./scripts/check-secrets.sh")
+	fmt.Println("#       args: [\"--json\"]")
+	fmt.Println("#   hooks:                     # Run in the repository with REPOS_* environment variables")
+	fmt.Println("#     - name: notify")
+	fmt.Println("#       event: post_check      # pre_check, post_check or on_error")
+	fmt.Println("#       command: ./scripts/notify.sh")
+	fmt.Println("#       timeout: 30s")
+	fmt.Println("#       fail_on_error: false   # Fail the repository when the hook fails")
 	fmt.Println()
 
 	fmt.Println("# Usage Instructions:")
@@ -1063,159 +2361,94 @@ func generateHealthConfig() {
 	fmt.Println("# 4. Test with: repos health --config health-config.yaml --dry-run")
 }
 
-// showDryRunDetails displays comprehensive dry-run information based on actual configuration
-//
-//nolint:gocyclo
-func showDryRunDetails(repos []core.Repository, advConfig *healthconfig.AdvancedConfig, analyzerReg *health.AnalyzerRegistry, categories []string) {
+// showDryRunDetails displays what the engine would run on each repository for
+// the given flags, from the registered checkers and analyzers
+func showDryRunDetails(repos []core.Repository, advConfig *healthconfig.AdvancedConfig, engine *health.Engine, categories, checkerIDs []string) {
 	fmt.Println()
 	color.Yellow("=== DRY RUN MODE - HEALTH CHECK EXECUTION PLAN ===")
 	fmt.Println()
 
-	// Repository information
-	color.Cyan("📁 REPOSITORIES TO ANALYZE:")
-	for i, repo := range repos {
-		fmt.Printf("  %d. %s", i+1, repo.Name)
-		if repo.Language != "" {
-			fmt.Printf(" (Language: %s)", repo.Language)
-		}
-		if len(repo.Tags) > 0 {
-			fmt.Printf(" [Tags: %v]", repo.Tags)
-		}
-		fmt.Printf("\n     Path: %s\n", repo.Path)
-	}
-	fmt.Printf("  Total repositories: %d\n", len(repos))
-	fmt.Println()
-
-	// Get checkers from the actual configuration (after filtering)
-	allAnalyzers := analyzerReg.GetAnalyzers()
-
-	// Show category filtering if applied
 	if len(categories) > 0 {
 		color.Blue("🔍 CATEGORY FILTERING APPLIED: %v", categories)
-		fmt.Println()
 	}
-
-	// Show checkers that would be executed (from actual configuration)
-	color.Cyan("🔧 CHECKERS TO EXECUTE:")
-	if len(advConfig.Checkers) == 0 {
-		color.Red("  No checkers configured")
-	} else {
-		// Group checkers by category from configuration
-		checkersByCategory := make(map[string][]string)
-		enabledCount := 0
-		disabledCount := 0
-
-		for checkerID, checkerConfig := range advConfig.Checkers {
-			// Get category from checker categories
-			category := "uncategorized"
-			if len(checkerConfig.Categories) > 0 {
-				category = checkerConfig.Categories[0] // Use first category
-			}
-			checkersByCategory[category] = append(checkersByCategory[category], checkerID)
-
-			if checkerConfig.Enabled {
-				enabledCount++
-			} else {
-				disabledCount++
-			}
-		}
-
-		for category, checkerIDs := range checkersByCategory {
-			fmt.Printf("  Category: %s\n", capitalizeFirst(category))
-			for _, checkerID := range checkerIDs {
-				checkerConfig := advConfig.Checkers[checkerID]
-				status := "enabled"
-				if !checkerConfig.Enabled {
-					status = "disabled"
-					color.Red("    ❌ %s - %s [%s] - DISABLED",
-						checkerID, status, checkerConfig.Severity)
-				} else {
-					color.Green("    ✓ %s - %s [%s]",
-						checkerID, status, checkerConfig.Severity)
-				}
-				if checkerConfig.Timeout > 0 {
-					fmt.Printf(" (timeout: %s)", checkerConfig.Timeout)
-				}
-				fmt.Println()
-			}
-			fmt.Println()
-		}
+	if len(checkerIDs) > 0 {
+		color.Blue("🔍 CHECKER SELECTION APPLIED: %v", checkerIDs)
+	}
+	if len(categories) > 0 || len(checkerIDs) > 0 {
+		fmt.Println()
 	}
 
-	// Show analyzers that would be executed
-	color.Cyan("🔬 ANALYZERS TO EXECUTE:")
-	if len(allAnalyzers) == 0 {
-		color.Red("  No analyzers available")
-	} else {
-		// Group analyzers by language and show which repositories they'd analyze
-		analyzersByLang := make(map[string][]core.Analyzer)
-		for _, analyzer := range allAnalyzers {
-			lang := analyzer.Language()
-			analyzersByLang[lang] = append(analyzersByLang[lang], analyzer)
-		}
-
-		for language, langAnalyzers := range analyzersByLang {
-			fmt.Printf("  Language: %s\n", capitalizeFirst(language))
-
-			// Count repositories that match this language
-			repoCount := 0
-			var matchingRepos []string
-			for _, repo := range repos {
-				if repo.Language == language {
-					repoCount++
-					matchingRepos = append(matchingRepos, repo.Name)
-				}
-			}
-
-			for _, analyzer := range langAnalyzers {
-				color.Green("    ✓ %s", analyzer.Name())
-				fmt.Printf(" (Extensions: %v)", analyzer.SupportedExtensions())
-				if repoCount > 0 {
-					fmt.Printf(" - Would analyze %d repositories: %v", repoCount, matchingRepos)
-				} else {
-					color.Yellow(" - No matching repositories")
-				}
-				fmt.Println()
-			}
-			fmt.Println()
-		}
+	plannedRuns := 0
+	for i, repo := range repos {
+		plannedRuns += showRepositoryPlan(i+1, repo, advConfig, engine)
 	}
 
 	// Configuration summary
 	color.Cyan("⚙️  CONFIGURATION SUMMARY:")
 	if advConfig != nil {
-		fmt.Printf("  Engine max concurrency: %d\n", advConfig.Engine.MaxConcurrency)
-		if advConfig.Engine.Timeout > 0 {
-			fmt.Printf("  Engine timeout: %s\n", advConfig.Engine.Timeout)
-		}
-		fmt.Printf("  Cache enabled: %t\n", advConfig.Engine.CacheEnabled)
-		if advConfig.Engine.CacheTTL > 0 {
-			fmt.Printf("  Cache TTL: %s\n", advConfig.Engine.CacheTTL)
-		}
+		showEngineSettings(advConfig.Engine)
 	}
 	fmt.Println()
 
 	// Execution summary
 	color.Cyan("📊 EXECUTION SUMMARY:")
-	enabledCheckers := 0
-	totalCheckers := 0
-	for _, checkerConfig := range advConfig.Checkers {
-		totalCheckers++
-		if checkerConfig.Enabled {
-			enabledCheckers++
-		}
-	}
-
 	fmt.Printf("  Total repositories: %d\n", len(repos))
-	fmt.Printf("  Total checkers: %d (enabled: %d, disabled: %d)\n",
-		totalCheckers, enabledCheckers, totalCheckers-enabledCheckers)
-	fmt.Printf("  Total analyzers: %d\n", len(allAnalyzers))
-	if len(categories) > 0 {
-		fmt.Printf("  Category filter: %v\n", categories)
-	}
+	fmt.Printf("  Total checker runs: %d\n", plannedRuns)
 	fmt.Println()
 
 	color.Yellow("=== This was a DRY RUN - no actual checks were performed ===")
 	color.Blue("To execute the checks, run the same command without --dry-run")
 	fmt.Println()
 }
+
+// showRepositoryPlan displays the checkers and analyzers the engine would run
+// on a repository, and returns how many checkers that is
+func showRepositoryPlan(number int, repo core.Repository, advConfig *healthconfig.AdvancedConfig, engine *health.Engine) int {
+	plan := engine.Plan(repo)
+
+	color.Cyan("📁 %d. %s", number, repo.Name)
+	fmt.Printf("     Path: %s\n", repo.Path)
+	if len(repo.Tags) > 0 {
+		fmt.Printf("     Tags: %v\n", repo.Tags)
+	}
+	if len(plan.Languages) > 0 {
+		fmt.Printf("     Analyzers: %s\n", strings.Join(plan.Languages, ", "))
+	} else {
+		fmt.Println("     Analyzers: none")
+	}
+
+	fmt.Printf("     Checkers to execute (%d):\n", len(plan.Checkers))
+	for _, checker := range plan.Checkers {
+		config := checker.Config()
+		if configured, ok := advConfig.GetCheckerConfig(checker.ID()); ok && configured.Severity != "" {
+			config.Severity = configured.Severity
+		}
+		color.Green("       ✓ %-24s %s [%s]", checker.ID(), checker.Category(), config.Severity)
+	}
+	if len(plan.Excluded) > 0 {
+		fmt.Printf("     Not executed (%d):\n", len(plan.Excluded))
+		for _, excluded := range plan.Excluded {
+			fmt.Printf("       - %-24s %s\n", excluded.Checker.ID(), excluded.Reason)
+		}
+	}
+	fmt.Println()
+	return len(plan.Checkers)
+}
+
+// showEngineSettings displays the engine settings of the health configuration
+func showEngineSettings(engine core.EngineConfig) {
+	fmt.Printf("  Engine max concurrency: %d\n", engine.MaxConcurrency)
+	if engine.MaxExternalProcesses > 0 {
+		fmt.Printf("  Engine max external processes: %d\n", engine.MaxExternalProcesses)
+	}
+	if engine.OnMissingTool != "" {
+		fmt.Printf("  Missing tools: %s\n", engine.OnMissingTool)
+	}
+	if engine.Timeout > 0 {
+		fmt.Printf("  Engine timeout: %s\n", engine.Timeout)
+	}
+	fmt.Printf("  Cache enabled: %t\n", engine.CacheEnabled)
+	if engine.CacheTTL > 0 {
+		fmt.Printf("  Cache TTL: %s\n", engine.CacheTTL)
+	}
+}
@@ -4,8 +4,10 @@ import (
 	"io"
 	"os"
 	"os/exec"
+	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 )
 
 func TestGetEnvOrDefault(t *testing.T) {
@@ -395,3 +397,66 @@ func TestHealthCommandWithListCategories(t *testing.T) {
 		}
 	}
 }
+
+func TestParseTimeout(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected time.Duration
+		err      string
+	}{
+		{value: "2m", expected: 2 * time.Minute},
+		{value: "2m30s", expected: 150 * time.Second},
+		{value: "45", expected: 45 * time.Second},
+		{value: "0", expected: 0},
+		{value: "2h", expected: 2 * time.Hour},
+		{value: "soon", err: "use a duration"},
+		{value: "-5s", err: "negative"},
+		{value: "7201", err: "must not exceed"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			timeout, err := parseTimeout(tt.value)
+			if tt.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.err) {
+					t.Errorf("Expected error containing %q, got %v", tt.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if timeout != tt.expected {
+				t.Errorf("Expected %v, got %v", tt.expected, timeout)
+			}
+		})
+	}
+}
+
+func TestPathRepository(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "myproject")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module example.com/myproject\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "main.go"), []byte("package main\n\nfunc main() {}\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	repo, err := pathRepository(dir, nil)
+	if err != nil {
+		t.Fatalf("pathRepository failed: %v", err)
+	}
+	if repo.Name != "myproject" || repo.Path != dir || repo.Language != "go" {
+		t.Errorf("Unexpected repository: %+v", repo)
+	}
+
+	if _, err := pathRepository(filepath.Join(dir, "go.mod"), nil); err == nil || !strings.Contains(err.Error(), "not a directory") {
+		t.Errorf("Expected a file to be rejected, got %v", err)
+	}
+	if _, err := pathRepository(filepath.Join(dir, "missing"), nil); err == nil {
+		t.Error("Expected a missing directory to be rejected")
+	}
+}
@@ -0,0 +1,143 @@
+package core
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// IndexedFile is a file recorded by a FileIndex
+type IndexedFile struct {
+	Path string // Path below the index root, as produced by walking it
+	Ext  string
+	Size int64
+}
+
+// FileIndex lists the files of a repository. The tree is walked once, on first
+// use, and the result is shared by the analyzers and checkers of a run instead
+// of each walking the repository again. A FileIndex is safe for concurrent use.
+type FileIndex struct {
+	root   string
+	listed []string // Files provided instead of walking the root, nil to walk
+
+	once   sync.Once
+	files  []IndexedFile
+	errors []AnalysisError
+	err    error
+}
+
+// NewFileIndex creates an index of the files below root
+func NewFileIndex(root string) *FileIndex {
+	return &FileIndex{root: root}
+}
+
+// NewListedFileIndex creates an index of the given files below root, which is
+// not walked. Paths may be absolute or relative to root; files that do not
+// exist, such as those deleted in a change, are left out.
+func NewListedFileIndex(root string, files []string) *FileIndex {
+	if files == nil {
+		files = []string{}
+	}
+	return &FileIndex{root: root, listed: files}
+}
+
+// Root returns the directory the index covers
+func (x *FileIndex) Root() string {
+	return x.root
+}
+
+// Listed returns the files the index was created with, or nil if it walks the root
+func (x *FileIndex) Listed() []string {
+	return x.listed
+}
+
+// Files returns every entry below the root that is not a directory, in walk
+// order, and the entries that could not be read. Only an unreadable root is an
+// error, so the rest of a repository can still be scanned.
+func (x *FileIndex) Files() ([]IndexedFile, []AnalysisError, error) {
+	x.once.Do(x.walk)
+	return x.files, x.errors, x.err
+}
+
+// Find returns the paths of the indexed files with one of the extensions
+func (x *FileIndex) Find(extensions []string) ([]string, []AnalysisError, error) {
+	files, walkErrors, err := x.Files()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var paths []string
+	for _, file := range files {
+		if HasExtension(file.Path, extensions) {
+			paths = append(paths, file.Path)
+		}
+	}
+	return paths, walkErrors, nil
+}
+
+// walk fills the index
+func (x *FileIndex) walk() {
+	if x.listed != nil {
+		x.list()
+		return
+	}
+	x.err = filepath.Walk(x.root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			if path == x.root {
+				return err
+			}
+			x.errors = append(x.errors, AnalysisError{Path: path, Reason: err.Error()})
+			return nil
+		}
+		if info.IsDir() {
+			return nil
+		}
+		x.files = append(x.files, IndexedFile{Path: path, Ext: filepath.Ext(path), Size: info.Size()})
+		return nil
+	})
+}
+
+// list fills the index from the provided files
+func (x *FileIndex) list() {
+	if _, err := os.Stat(x.root); err != nil {
+		x.err = err
+		return
+	}
+	for _, file := range x.listed {
+		path := file
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(x.root, path)
+		}
+		info, err := os.Stat(path)
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			x.errors = append(x.errors, AnalysisError{Path: path, Reason: err.Error()})
+			continue
+		}
+		if info.IsDir() {
+			continue
+		}
+		x.files = append(x.files, IndexedFile{Path: path, Ext: filepath.Ext(path), Size: info.Size()})
+	}
+}
+
+// FileIndex returns the index attached to the repository by the engine, or a
+// new index of its path when there is none
+func (r Repository) FileIndex() *FileIndex {
+	if r.Files != nil && r.Files.Root() == r.Path {
+		return r.Files
+	}
+	return NewFileIndex(r.Path)
+}
+
+// FileIndex returns the index attached by the engine when it covers root, or a
+// new index of root
+func (c AnalyzerConfig) FileIndex(root string) *FileIndex {
+	if c.Index != nil && c.Index.Root() == root {
+		return c.Index
+	}
+	return NewFileIndex(root)
+}
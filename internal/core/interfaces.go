@@ -15,6 +15,19 @@ type Checker interface {
 	SupportsRepository(repo Repository) bool
 }
 
+// Cache stores values for a limited time
+type Cache interface {
+	Get(key string) (interface{}, bool)
+	Set(key string, value interface{}, ttl time.Duration)
+	Delete(key string)
+	Clear()
+}
+
+// CheckerDescriber is implemented by checkers that can describe what they verify
+type CheckerDescriber interface {
+	Metadata() CheckerMetadata
+}
+
 // Analyzer represents a language-specific analyzer interface
 type Analyzer interface {
 	Name() string
@@ -102,15 +115,44 @@ type RepositoryResult struct {
 	Repository     Repository      `json:"repository"`
 	CheckResults   []CheckResult   `json:"check_results"`
 	AnalysisResult *AnalysisResult `json:"analysis_result,omitempty"`
+	// Aggregate totals the analysis of every detected language with an analyzer
+	Aggregate *AnalysisAggregate `json:"aggregate,omitempty"`
+	// Languages is the number of source files per detected language
+	Languages      map[string]int  `json:"languages,omitempty"`
 	Status         HealthStatus    `json:"status"`
 	Score          int             `json:"score"`
 	MaxScore       int             `json:"max_score"`
+	CategoryScores []CategoryScore `json:"category_scores,omitempty"`
 	StartTime      time.Time       `json:"start_time"`
 	EndTime        time.Time       `json:"end_time"`
 	Duration       time.Duration   `json:"duration"`
 	Error          string          `json:"error,omitempty"`
+	// Subprojects are the separately checked projects of a monorepo. Their check
+	// results are also listed in CheckResults, with file paths relative to the
+	// repository, and the repository's score is the average of theirs.
+	Subprojects []SubprojectResult `json:"subprojects,omitempty"`
 }
 
+// SubprojectResult is the health of one project in a monorepo
+type SubprojectResult struct {
+	Name           string          `json:"name"`
+	Path           string          `json:"path"` // relative to the repository root
+	Status         HealthStatus    `json:"status"`
+	Score          int             `json:"score"`
+	CategoryScores []CategoryScore `json:"category_scores,omitempty"`
+}
+
+// CategoryScore represents the aggregated score of all checks in a category
+type CategoryScore struct {
+	Category string  `json:"category"`
+	Score    int     `json:"score"` // percentage of the category's max score
+	Weight   float64 `json:"weight"`
+}
+
+// DefaultCategoryWeight is the scoring weight of a category without a
+// configured one
+const DefaultCategoryWeight = 1.0
+
 // Summary represents a summary of check results
 type Summary struct {
 	TotalRepositories int                        `json:"total_repositories"`
@@ -168,8 +210,40 @@ type WorkflowSummary struct {
 	FailedRepos     int                  `json:"failed_repos"`
 	AverageScore    int                  `json:"average_score"`
 	TotalIssues     int                  `json:"total_issues"`
+	ErroredChecks   int                  `json:"errored_checks"`
 	StatusCounts    map[HealthStatus]int `json:"status_counts"`
 	SeverityCounts  map[Severity]int     `json:"severity_counts"`
+	Baseline        *BaselineSummary     `json:"baseline,omitempty"`
+	// Ranking lists every repository from the highest score to the lowest
+	Ranking []RepositoryRank `json:"ranking,omitempty"`
+	// Worst lists the lowest-scoring repositories, lowest first
+	Worst []RepositoryRank `json:"worst,omitempty"`
+	// CheckerTimings lists the time taken by each checker, slowest first
+	CheckerTimings []CheckerTiming `json:"checker_timings,omitempty"`
+}
+
+// CheckerTiming is the time a checker took across the repositories of a run
+type CheckerTiming struct {
+	ID         string        `json:"id"`
+	Name       string        `json:"name"`
+	Runs       int           `json:"runs"`
+	Total      time.Duration `json:"total"`
+	Max        time.Duration `json:"max"`
+	Subprocess time.Duration `json:"subprocess"` // Time spent in external commands
+}
+
+// RepositoryRank is a repository's place in the score ranking of a run
+type RepositoryRank struct {
+	Rank   int          `json:"rank"` // 1 is the healthiest repository
+	Name   string       `json:"name"`
+	Score  int          `json:"score"` // 0-100
+	Status HealthStatus `json:"status"`
+}
+
+// BaselineSummary records how findings compared against a baseline file
+type BaselineSummary struct {
+	KnownFindings int `json:"known_findings"`
+	NewFindings   int `json:"new_findings"`
 }
 
 // Orchestrator represents the orchestration engine interface
@@ -0,0 +1,41 @@
+package core
+
+import (
+	"context"
+	"sync"
+)
+
+// runStoreKey is the context key of a RunStore
+type runStoreKey struct{}
+
+// RunStore holds values that checkers share for the duration of one engine
+// run, such as caches of network lookups, so that they do not outlive the run
+// in long-running processes. It is safe for concurrent use.
+type RunStore struct {
+	mu     sync.Mutex
+	values map[string]interface{}
+}
+
+// WithRunStore returns a context carrying a new, empty RunStore
+func WithRunStore(ctx context.Context) context.Context {
+	return context.WithValue(ctx, runStoreKey{}, &RunStore{values: make(map[string]interface{})})
+}
+
+// RunStoreFrom returns the context's RunStore, or nil if it has none
+func RunStoreFrom(ctx context.Context) *RunStore {
+	store, _ := ctx.Value(runStoreKey{}).(*RunStore)
+	return store
+}
+
+// LoadOrCreate returns the value stored under key, storing the result of
+// create first if there is none
+func (s *RunStore) LoadOrCreate(key string, create func() interface{}) interface{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if value, ok := s.values[key]; ok {
+		return value
+	}
+	value := create()
+	s.values[key] = value
+	return value
+}
@@ -1,6 +1,12 @@
 package core
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
 	"time"
 )
 
@@ -59,9 +65,30 @@ type FunctionInfo struct {
 	File       string `json:"file"`
 	Language   string `json:"language"`
 	Line       int    `json:"line"`
+	EndLine    int    `json:"end_line,omitempty"` // 0 when the analyzer could not find the end
 	Complexity int    `json:"complexity"`
 }
 
+// Lines returns the number of source lines the function spans, or 0 when its
+// end is unknown
+func (f FunctionInfo) Lines() int {
+	if f.EndLine < f.Line {
+		return 0
+	}
+	return f.EndLine - f.Line + 1
+}
+
+// SortFunctions orders functions by file and line, so that results gathered
+// from files analyzed concurrently do not depend on scheduling
+func SortFunctions(functions []FunctionInfo) {
+	sort.SliceStable(functions, func(i, k int) bool {
+		if functions[i].File != functions[k].File {
+			return functions[i].File < functions[k].File
+		}
+		return functions[i].Line < functions[k].Line
+	})
+}
+
 // ClassInfo represents information about a class
 type ClassInfo struct {
 	Name     string         `json:"name"`
@@ -97,6 +124,62 @@ type CheckerConfig struct {
 	Categories []string               `yaml:"categories" json:"categories"`
 	Options    map[string]interface{} `yaml:"options" json:"options"`
 	Exclusions []string               `yaml:"exclusions" json:"exclusions"`
+
+	// Disabled is set when the configuration file sets enabled: false, which
+	// turns off even a checker that is enabled by default. An unset enabled
+	// field cannot be told apart from false once decoded.
+	Disabled bool `yaml:"-" json:"-"`
+}
+
+// Merge returns the configuration with the fields set in override applied:
+// a set severity, timeout, categories or exclusions replaces this one's,
+// options are replaced per key, and override can enable or disable the checker
+func (c CheckerConfig) Merge(override CheckerConfig) CheckerConfig {
+	merged := c
+	if override.Enabled {
+		merged.Enabled = true
+		merged.Disabled = false
+	}
+	if override.Disabled {
+		merged.Enabled = false
+		merged.Disabled = true
+	}
+	if override.Severity != "" {
+		merged.Severity = override.Severity
+	}
+	if override.Timeout > 0 {
+		merged.Timeout = override.Timeout
+	}
+	if len(override.Categories) > 0 {
+		merged.Categories = override.Categories
+	}
+	if len(override.Exclusions) > 0 {
+		merged.Exclusions = override.Exclusions
+	}
+	if len(override.Options) > 0 {
+		merged.Options = make(map[string]interface{}, len(c.Options)+len(override.Options))
+		for key, value := range c.Options {
+			merged.Options[key] = value
+		}
+		for key, value := range override.Options {
+			merged.Options[key] = value
+		}
+	}
+	return merged
+}
+
+// CheckerMetadata describes what a checker verifies and how it can be configured
+type CheckerMetadata struct {
+	Description   string          `json:"description"`
+	Options       []CheckerOption `json:"options,omitempty"`
+	RequiredTools []string        `json:"required_tools,omitempty"`
+}
+
+// CheckerOption describes a checker option accepted under options in the configuration
+type CheckerOption struct {
+	Name        string      `json:"name"`
+	Default     interface{} `json:"default"`
+	Description string      `json:"description"`
 }
 
 // AnalyzerConfig represents configuration for an analyzer
@@ -108,6 +191,72 @@ type AnalyzerConfig struct {
 	FunctionLevel     bool                   `yaml:"function_level" json:"function_level"`
 	Categories        []string               `yaml:"categories" json:"categories"`
 	Options           map[string]interface{} `yaml:"options" json:"options"`
+	// IncludeTests analyzes test files, which the analyzer's test patterns
+	// otherwise exclude
+	IncludeTests bool `yaml:"include_tests" json:"include_tests"`
+	// IncludeGenerated analyzes files whose header marks them as generated
+	// code, which are otherwise skipped
+	IncludeGenerated bool `yaml:"include_generated" json:"include_generated"`
+	// IncludeFiles restricts analysis to the listed files when non-nil.
+	// It is set at runtime (e.g. by --since) rather than from configuration.
+	IncludeFiles []string `yaml:"-" json:"include_files,omitempty"`
+	// Concurrency bounds how many files are analyzed in parallel; zero uses GOMAXPROCS.
+	// It is set at runtime from the engine's max_concurrency.
+	Concurrency int `yaml:"-" json:"-"`
+	// Index is the repository's shared file index; nil makes analyzers walk
+	// the repository themselves. It is set at runtime by the engine.
+	Index *FileIndex `yaml:"-" json:"-"`
+}
+
+// FilterFiles returns the subset of files allowed by IncludeFiles.
+// A nil IncludeFiles allows every file; an empty one allows none.
+func (c AnalyzerConfig) FilterFiles(files []string) []string {
+	if c.IncludeFiles == nil {
+		return files
+	}
+
+	allowed := make(map[string]bool, len(c.IncludeFiles))
+	for _, file := range c.IncludeFiles {
+		allowed[filepath.Clean(file)] = true
+	}
+
+	var filtered []string
+	for _, file := range files {
+		if allowed[filepath.Clean(file)] {
+			filtered = append(filtered, file)
+		}
+	}
+	return filtered
+}
+
+// Extensions returns the configured file extensions, or defaults when none are configured
+func (c AnalyzerConfig) Extensions(defaults []string) []string {
+	if len(c.FileExtensions) > 0 {
+		return c.FileExtensions
+	}
+	return defaults
+}
+
+// Excludes returns an analyzer's default exclude patterns, its test file
+// patterns unless IncludeTests is set, and the configured ExcludePatterns
+func (c AnalyzerConfig) Excludes(defaults, tests []string) []string {
+	excludes := make([]string, 0, len(defaults)+len(tests)+len(c.ExcludePatterns))
+	excludes = append(excludes, defaults...)
+	if !c.IncludeTests {
+		excludes = append(excludes, tests...)
+	}
+	return append(excludes, c.ExcludePatterns...)
+}
+
+// HasExtension reports whether path ends with one of the extensions. Suffixes
+// are compared, so multi-part extensions such as .d.ts work.
+func HasExtension(path string, extensions []string) bool {
+	for _, ext := range extensions {
+		if strings.HasSuffix(path, ext) {
+			return true
+		}
+	}
+	return false
 }
 
 // ReporterConfig represents configuration for a reporter
@@ -127,6 +276,84 @@ type EngineConfig struct {
 	CacheEnabled   bool          `yaml:"cache_enabled" json:"cache_enabled"`
 	CacheTTL       time.Duration `yaml:"cache_ttl" json:"cache_ttl"`
 	Parallel       bool          `yaml:"parallel" json:"parallel"`
+	Scoring        ScoringConfig `yaml:"scoring" json:"scoring"`
+	// MaxExternalProcesses limits how many checkers that run heavyweight external
+	// tools (dependencies and security) execute at once across all repositories; 0 means no limit
+	MaxExternalProcesses int `yaml:"max_external_processes" json:"max_external_processes"`
+	// Subprojects checks the projects of a monorepo separately
+	Subprojects SubprojectConfig `yaml:"subprojects" json:"subprojects"`
+	// OnMissingTool is the policy for external tools that are not installed:
+	// warn (default), skip or error. A checker's on_missing_tool option overrides it.
+	OnMissingTool string `yaml:"on_missing_tool" json:"on_missing_tool,omitempty"`
+}
+
+// SubprojectConfig enables checking each sub-project of a monorepo, i.e. each
+// directory below the repository root that contains a manifest, separately
+type SubprojectConfig struct {
+	Enabled   bool     `yaml:"enabled" json:"enabled"`
+	Manifests []string `yaml:"manifests" json:"manifests"` // file names marking a sub-project; empty uses the defaults
+	MaxDepth  int      `yaml:"max_depth" json:"max_depth"` // deepest directory level searched; 0 uses the default
+}
+
+// Scoring models selectable in ScoringConfig
+const (
+	// ScoringModelChecker keeps the score each checker reports (default)
+	ScoringModelChecker = "checker"
+	// ScoringModelBinary scores a check 0 if it has any issue and 100 otherwise
+	ScoringModelBinary = "binary"
+	// ScoringModelGraded scores a check 100 minus a penalty per issue by severity
+	ScoringModelGraded = "graded"
+)
+
+// DefaultSeverityPenalties are the graded model's per-issue penalties
+var DefaultSeverityPenalties = map[Severity]int{
+	SeverityCritical: 50,
+	SeverityHigh:     25,
+	SeverityMedium:   10,
+	SeverityLow:      5,
+}
+
+// ScoringConfig selects how the engine scores check results
+type ScoringConfig struct {
+	Model     string         `yaml:"model" json:"model"`
+	Penalties map[string]int `yaml:"penalties" json:"penalties"` // per-issue penalty by severity for the graded model
+}
+
+// Validate rejects unknown scoring models, severities and negative penalties
+func (c ScoringConfig) Validate() error {
+	switch c.Model {
+	case "", ScoringModelChecker, ScoringModelBinary, ScoringModelGraded:
+	default:
+		return fmt.Errorf("invalid scoring model %q (allowed: checker, binary, graded)", c.Model)
+	}
+	for severity, penalty := range c.Penalties {
+		parsed, err := ParseSeverity(severity)
+		if err != nil {
+			return fmt.Errorf("scoring penalties: %w", err)
+		}
+		if parsed.Advisory() {
+			return fmt.Errorf("scoring penalties: %s issues are never scored", parsed)
+		}
+		if penalty < 0 {
+			return fmt.Errorf("scoring penalty for %s must not be negative", severity)
+		}
+	}
+	return nil
+}
+
+// SeverityPenalties returns the graded model's penalties, with configured values
+// replacing the defaults
+func (c ScoringConfig) SeverityPenalties() map[Severity]int {
+	penalties := make(map[Severity]int, len(DefaultSeverityPenalties))
+	for severity, penalty := range DefaultSeverityPenalties {
+		penalties[severity] = penalty
+	}
+	for name, penalty := range c.Penalties {
+		if severity, err := ParseSeverity(name); err == nil {
+			penalties[severity] = penalty
+		}
+	}
+	return penalties
 }
 
 // Repository represents a repository to be analyzed
@@ -139,6 +366,9 @@ type Repository struct {
 	Language  string            `yaml:"language" json:"language"`
 	Framework string            `yaml:"framework" json:"framework"`
 	Metadata  map[string]string `yaml:"metadata" json:"metadata"`
+	// Files is the file index shared by the analyzers and checkers of a run.
+	// It is set at runtime by the engine; use FileIndex to read it.
+	Files *FileIndex `yaml:"-" json:"-"`
 }
 
 // RepositoryContext provides context for repository operations
@@ -146,6 +376,9 @@ type RepositoryContext struct {
 	Repository Repository        `json:"repository"`
 	Config     Config            `json:"config"`
 	Metadata   map[string]string `json:"metadata"`
+	// Analysis is the repository's code analysis, or nil if it was not analyzed.
+	// The engine analyzes a repository before running its checkers.
+	Analysis *AnalysisResult `json:"-"`
 }
 
 // CheckResult represents the result of a health check
@@ -162,8 +395,45 @@ type CheckResult struct {
 	Metrics    map[string]interface{} `json:"metrics"`
 	Metadata   map[string]string      `json:"metadata"`
 	Duration   time.Duration          `json:"duration"`
-	Timestamp  time.Time              `json:"timestamp"`
-	Error      string                 `json:"error,omitempty"`
+	// SubprocessDuration is the part of Duration spent running external commands
+	SubprocessDuration time.Duration `json:"subprocess_duration,omitempty"`
+	Timestamp          time.Time     `json:"timestamp"`
+	Errors             []CheckError  `json:"errors,omitempty"`
+}
+
+// MetadataSkipped is the CheckResult metadata key set to "true" when the check
+// did not run, e.g. because its tool is not installed under the skip policy
+const MetadataSkipped = "skipped"
+
+// Scored reports whether the result takes part in scoring: errored and
+// skipped checks did not check anything, so there is nothing to score
+func (r CheckResult) Scored() bool {
+	return r.Status != StatusErrored && r.Metadata[MetadataSkipped] != "true"
+}
+
+// CheckError records why a checker could not run, as opposed to an issue it found
+type CheckError struct {
+	Type    string `json:"type"`
+	Message string `json:"message"`
+}
+
+// ErroredResult returns the result of a checker that failed with err instead of
+// completing its check. It is left out of scoring.
+func ErroredResult(id, name, category, repository string, err error) CheckResult {
+	errorType := "execution_error"
+	if errors.Is(err, context.DeadlineExceeded) {
+		errorType = "timeout"
+	}
+
+	return CheckResult{
+		ID:         id,
+		Name:       name,
+		Category:   category,
+		Repository: repository,
+		Status:     StatusErrored,
+		Timestamp:  time.Now(),
+		Errors:     []CheckError{{Type: errorType, Message: err.Error()}},
+	}
 }
 
 // AnalysisResult represents the result of code analysis
@@ -177,6 +447,67 @@ type AnalysisResult struct {
 	Files             map[string]*FileAnalysis `json:"files"`
 	Patterns          []PatternMatch           `json:"patterns"`
 	Metrics           map[string]interface{}   `json:"metrics"`
+	Errors            []AnalysisError          `json:"errors,omitempty"`
+}
+
+// RecomputeTotals updates the totals and complexity metrics of an analysis
+// from its files and functions, e.g. after some of them were dropped
+func (r *AnalysisResult) RecomputeTotals() {
+	totalComplexity, maxComplexity, maxFunctionLines := 0, 0, 0
+	for _, fn := range r.Functions {
+		totalComplexity += fn.Complexity
+		maxComplexity = max(maxComplexity, fn.Complexity)
+		maxFunctionLines = max(maxFunctionLines, fn.Lines())
+	}
+	totalLines := 0
+	for _, file := range r.Files {
+		totalLines += file.Lines
+	}
+
+	r.TotalFiles = len(r.Files)
+	r.TotalLines = totalLines
+	r.TotalFunctions = len(r.Functions)
+	r.AverageComplexity = 0
+	if len(r.Functions) > 0 {
+		r.AverageComplexity = float64(totalComplexity) / float64(len(r.Functions))
+	}
+
+	if r.Metrics == nil {
+		r.Metrics = make(map[string]interface{})
+	}
+	r.Metrics["total_files"] = r.TotalFiles
+	r.Metrics["total_functions"] = r.TotalFunctions
+	r.Metrics["total_complexity"] = totalComplexity
+	r.Metrics["max_complexity"] = maxComplexity
+	r.Metrics["max_function_lines"] = maxFunctionLines
+	r.Metrics["average_complexity"] = r.AverageComplexity
+}
+
+// AnalysisAggregate summarizes the analysis of every language in a repository
+type AnalysisAggregate struct {
+	TotalFiles     int `json:"total_files"`
+	TotalLines     int `json:"total_lines"`
+	TotalFunctions int `json:"total_functions"`
+	// AverageComplexity is weighted by the number of functions of each language
+	AverageComplexity float64           `json:"average_complexity"`
+	MaxComplexity     int               `json:"max_complexity"`
+	Languages         []LanguageMetrics `json:"languages"`
+}
+
+// LanguageMetrics are the analysis totals of one language
+type LanguageMetrics struct {
+	Language          string  `json:"language"`
+	Files             int     `json:"files"`
+	Lines             int     `json:"lines"`
+	Functions         int     `json:"functions"`
+	AverageComplexity float64 `json:"average_complexity"`
+	MaxComplexity     int     `json:"max_complexity"`
+}
+
+// AnalysisError represents a file that could not be analyzed
+type AnalysisError struct {
+	Path   string `json:"path"`
+	Reason string `json:"reason"`
 }
 
 // HealthStatus represents the health status of a check
@@ -186,6 +517,7 @@ const (
 	StatusHealthy  HealthStatus = "healthy"
 	StatusWarning  HealthStatus = "warning"
 	StatusCritical HealthStatus = "critical"
+	StatusErrored  HealthStatus = "errored" // The checker could not run
 	StatusUnknown  HealthStatus = "unknown"
 )
 
@@ -193,12 +525,126 @@ const (
 type Severity string
 
 const (
+	SeverityInfo     Severity = "info" // Advisory; reported but never scored
 	SeverityLow      Severity = "low"
 	SeverityMedium   Severity = "medium"
 	SeverityHigh     Severity = "high"
 	SeverityCritical Severity = "critical"
 )
 
+// ParseSeverity converts a configured severity name (case-insensitive) into a Severity
+func ParseSeverity(value string) (Severity, error) {
+	severity := Severity(strings.ToLower(strings.TrimSpace(value)))
+	switch severity {
+	case SeverityInfo, SeverityLow, SeverityMedium, SeverityHigh, SeverityCritical:
+		return severity, nil
+	default:
+		return "", fmt.Errorf("invalid severity %q (allowed: info, low, medium, high, critical)", value)
+	}
+}
+
+// Advisory reports whether issues of this severity are informational: they
+// appear in reports but never lower a score, a status or the exit code
+func (s Severity) Advisory() bool {
+	return s == SeverityInfo
+}
+
+// FailOn is the lowest check status that fails a run
+type FailOn string
+
+// Fail-on levels
+const (
+	FailOnWarning  FailOn = "warning"
+	FailOnCritical FailOn = "critical"
+	FailOnNever    FailOn = "never"
+)
+
+// ParseFailOn converts a configured fail-on level (case-insensitive) into a FailOn
+func ParseFailOn(value string) (FailOn, error) {
+	level := FailOn(strings.ToLower(strings.TrimSpace(value)))
+	switch level {
+	case FailOnWarning, FailOnCritical, FailOnNever:
+		return level, nil
+	default:
+		return "", fmt.Errorf("invalid fail_on %q (allowed: warning, critical, never)", value)
+	}
+}
+
+// Fails reports whether a check with the given status fails the run at this
+// level. Errored checks fail at every level but never.
+func (f FailOn) Fails(status HealthStatus) bool {
+	switch f {
+	case FailOnNever:
+		return false
+	case FailOnWarning:
+		return status == StatusWarning || status == StatusCritical || status == StatusErrored
+	default:
+		return status == StatusCritical || status == StatusErrored
+	}
+}
+
+// ScoreGate is the minimum scores repositories need for a run to pass
+type ScoreGate struct {
+	// MinScore is the overall score every repository needs; 0 disables it
+	MinScore int
+	// Repositories replaces MinScore for the named repositories
+	Repositories map[string]int
+	// Categories is the score every repository needs in the named categories
+	Categories map[string]int
+}
+
+// MinScoreFor returns the overall score a repository needs, or 0 for none
+func (g ScoreGate) MinScoreFor(repository string) int {
+	if minScore, ok := g.Repositories[repository]; ok {
+		return minScore
+	}
+	return g.MinScore
+}
+
+// ExitCodes maps the outcomes of a health run to process exit codes
+type ExitCodes struct {
+	// Healthy is for a run with nothing to report
+	Healthy int
+	// Warning is for a run that passes with warnings in some repository
+	Warning int
+	// Critical is for a run that fails on findings or falls short of a minimum score
+	Critical int
+	// Errored is for a run with checks that could not run
+	Errored int
+}
+
+// DefaultExitCodes are the documented exit codes; warnings alone pass the run
+var DefaultExitCodes = ExitCodes{Healthy: 0, Warning: 0, Critical: 2, Errored: 3}
+
+// MissingToolPolicy is how a checker treats an external tool that is not installed
+type MissingToolPolicy string
+
+// Missing tool policies
+const (
+	MissingToolWarn  MissingToolPolicy = "warn"  // Report the missing tool as an issue or warning (default)
+	MissingToolSkip  MissingToolPolicy = "skip"  // Leave out what the tool would have checked
+	MissingToolError MissingToolPolicy = "error" // Report the check as errored
+)
+
+// ParseMissingToolPolicy converts a configured on_missing_tool policy (case-insensitive) into a MissingToolPolicy
+func ParseMissingToolPolicy(value string) (MissingToolPolicy, error) {
+	policy := MissingToolPolicy(strings.ToLower(strings.TrimSpace(value)))
+	switch policy {
+	case MissingToolWarn, MissingToolSkip, MissingToolError:
+		return policy, nil
+	default:
+		return "", fmt.Errorf("invalid on_missing_tool %q (allowed: warn, skip, error)", value)
+	}
+}
+
+// IsToolNotAvailable reports whether an issue or warning type reports a tool
+// that is not installed. Checkers name these types with a "_not_available"
+// suffix, such as "npm_not_available", so the on_missing_tool policy can be
+// applied to them.
+func IsToolNotAvailable(issueType string) bool {
+	return strings.HasSuffix(issueType, "_not_available")
+}
+
 // Issue represents a health check issue
 type Issue struct {
 	Type        string                 `json:"type"`
@@ -221,9 +667,10 @@ type Warning struct {
 
 // Location represents a location in code
 type Location struct {
-	File   string `json:"file"`
-	Line   int    `json:"line"`
-	Column int    `json:"column,omitempty"`
+	File    string `json:"file"`
+	Line    int    `json:"line"`
+	Column  int    `json:"column,omitempty"`
+	EndLine int    `json:"end_line,omitempty"` // Last line of a span such as a function, when known
 }
 
 // Field represents a structured logging field
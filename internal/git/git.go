@@ -7,6 +7,9 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
+	"regexp"
+	"strconv"
+	"strings"
 
 	"github.com/codcod/repos/internal/config"
 	"github.com/codcod/repos/internal/util"
@@ -84,6 +87,92 @@ func HasChanges(dir string) (bool, error) {
 	return len(output) > 0, nil
 }
 
+// LineRange is an inclusive range of line numbers in a file
+type LineRange struct {
+	Start int
+	End   int
+}
+
+// hunkHeaderPattern matches the header of a unified diff hunk, capturing the
+// start and length of its lines in the new file
+var hunkHeaderPattern = regexp.MustCompile(`^@@ -\d+(?:,\d+)? \+(\d+)(?:,(\d+))? @@`)
+
+// checkRef rejects refs that git would read as options
+func checkRef(ref string) error {
+	if ref == "" || strings.HasPrefix(ref, "-") {
+		return fmt.Errorf("invalid git ref %q", ref)
+	}
+	return nil
+}
+
+// ChangedFiles returns the files changed between ref and HEAD, relative to the repository root.
+// Deleted files are not included.
+func ChangedFiles(dir string, ref string) ([]string, error) {
+	if err := checkRef(ref); err != nil {
+		return nil, err
+	}
+	output, err := RunGitCommand(dir, "diff", "--name-only", "--diff-filter=ACMR", ref+"...HEAD", "--")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list files changed since %s: %w", ref, err)
+	}
+
+	files := []string{}
+	for _, line := range strings.Split(string(output), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			files = append(files, line)
+		}
+	}
+	return files, nil
+}
+
+// ChangedLines returns the lines added or modified between ref and HEAD in each
+// file, relative to the repository root. Where lines were only removed, the
+// lines around the removal count as changed.
+func ChangedLines(dir string, ref string) (map[string][]LineRange, error) {
+	if err := checkRef(ref); err != nil {
+		return nil, err
+	}
+	output, err := RunGitCommand(dir, "diff", "-U0", "--no-color", "--no-ext-diff", "--diff-filter=ACMR", ref+"...HEAD", "--")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list lines changed since %s: %w", ref, err)
+	}
+
+	changed := make(map[string][]LineRange)
+	file := ""
+	for _, line := range strings.Split(string(output), "\n") {
+		if strings.HasPrefix(line, "+++ ") {
+			file = strings.TrimPrefix(strings.TrimPrefix(line, "+++ "), "b/")
+			continue
+		}
+		match := hunkHeaderPattern.FindStringSubmatch(line)
+		if match == nil || file == "" {
+			continue
+		}
+
+		start, _ := strconv.Atoi(match[1])
+		count := 1
+		if match[2] != "" {
+			count, _ = strconv.Atoi(match[2])
+		}
+		lines := LineRange{Start: start, End: start + count - 1}
+		if count == 0 {
+			// Removed lines sit between start and the line after it
+			lines = LineRange{Start: max(start, 1), End: start + 1}
+		}
+		changed[file] = append(changed[file], lines)
+	}
+	return changed, nil
+}
+
+// HeadCommit returns the commit hash HEAD points to
+func HeadCommit(dir string) (string, error) {
+	output, err := RunGitCommand(dir, "rev-parse", "HEAD")
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve HEAD: %w", err)
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
 // BranchExists checks if a branch exists in the repository
 func BranchExists(dir string, branch string) bool {
 	cmd := exec.Command("git", "rev-parse", "--verify", branch)
@@ -395,3 +395,97 @@ func BenchmarkBranchExists(b *testing.B) {
 		BranchExists(tmpDir, "main")
 	}
 }
+
+func TestChangedFiles(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	if err := exec.Command("git", "init", tmpDir).Run(); err != nil {
+		t.Skip("git not available, skipping test")
+	}
+	_ = exec.Command("git", "-C", tmpDir, "config", "user.email", "test@example.com").Run()
+	_ = exec.Command("git", "-C", tmpDir, "config", "user.name", "Test User").Run()
+
+	// Initial commit that the diff is based on
+	_ = os.WriteFile(filepath.Join(tmpDir, "old.go"), []byte("package main"), 0644)
+	_ = os.WriteFile(filepath.Join(tmpDir, "gone.go"), []byte("package main"), 0644)
+	_ = exec.Command("git", "-C", tmpDir, "add", "-A").Run()
+	if err := exec.Command("git", "-C", tmpDir, "commit", "-m", "initial").Run(); err != nil {
+		t.Fatalf("Failed to commit: %v", err)
+	}
+	_ = exec.Command("git", "-C", tmpDir, "tag", "base").Run()
+
+	// Add, modify and delete files
+	_ = os.MkdirAll(filepath.Join(tmpDir, "pkg"), 0755)
+	_ = os.WriteFile(filepath.Join(tmpDir, "pkg", "new.go"), []byte("package pkg"), 0644)
+	_ = os.WriteFile(filepath.Join(tmpDir, "old.go"), []byte("package main\n\nfunc main() {}"), 0644)
+	_ = os.Remove(filepath.Join(tmpDir, "gone.go"))
+	_ = exec.Command("git", "-C", tmpDir, "add", "-A").Run()
+	if err := exec.Command("git", "-C", tmpDir, "commit", "-m", "change").Run(); err != nil {
+		t.Fatalf("Failed to commit: %v", err)
+	}
+
+	files, err := ChangedFiles(tmpDir, "base")
+	if err != nil {
+		t.Fatalf("ChangedFiles() error = %v", err)
+	}
+
+	got := strings.Join(files, ",")
+	if got != "old.go,pkg/new.go" {
+		t.Errorf("ChangedFiles() = %v, want [old.go pkg/new.go] (deleted files excluded)", files)
+	}
+
+	// Unknown refs should surface an error
+	if _, err := ChangedFiles(tmpDir, "does-not-exist"); err == nil {
+		t.Error("ChangedFiles() should fail for an unknown ref")
+	}
+
+	// Refs that git would read as options are rejected
+	if _, err := ChangedFiles(tmpDir, "--output=/tmp/x"); err == nil {
+		t.Error("ChangedFiles() should reject a ref starting with -")
+	}
+}
+
+func TestChangedLines(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	if err := exec.Command("git", "init", tmpDir).Run(); err != nil {
+		t.Skip("git not available, skipping test")
+	}
+	_ = exec.Command("git", "-C", tmpDir, "config", "user.email", "test@example.com").Run()
+	_ = exec.Command("git", "-C", tmpDir, "config", "user.name", "Test User").Run()
+
+	commit := func(content, message string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(tmpDir, "main.go"), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		_ = exec.Command("git", "-C", tmpDir, "add", "-A").Run()
+		if err := exec.Command("git", "-C", tmpDir, "commit", "-m", message).Run(); err != nil {
+			t.Fatalf("Failed to commit: %v", err)
+		}
+	}
+	commit("line 1\nline 2\nline 3\nline 4\nline 5\nline 6\n", "initial")
+	_ = exec.Command("git", "-C", tmpDir, "tag", "base").Run()
+	commit("line 1\nline 2 changed\nline 3\nline 4\nline 6\nline 7\nline 8\n", "change")
+
+	changed, err := ChangedLines(tmpDir, "base")
+	if err != nil {
+		t.Fatalf("ChangedLines() error = %v", err)
+	}
+
+	// Line 2 modified, line 5 removed after line 4, lines 6-7 added
+	expected := []LineRange{{Start: 2, End: 2}, {Start: 4, End: 5}, {Start: 6, End: 7}}
+	got := changed["main.go"]
+	if len(got) != len(expected) {
+		t.Fatalf("ChangedLines() = %v, want %v", got, expected)
+	}
+	for i := range expected {
+		if got[i] != expected[i] {
+			t.Errorf("ChangedLines()[%d] = %v, want %v", i, got[i], expected[i])
+		}
+	}
+
+	if _, err := ChangedLines(tmpDir, "-p"); err == nil {
+		t.Error("ChangedLines() should reject a ref starting with -")
+	}
+}
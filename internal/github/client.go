@@ -0,0 +1,385 @@
+package github
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultBaseURL is the REST API of github.com
+const DefaultBaseURL = "https://api.github.com"
+
+const (
+	defaultMaxRetries = 3
+	defaultBackoff    = time.Second
+	defaultMaxWait    = time.Minute
+	defaultTimeout    = 30 * time.Second
+	// pageSize is the most items GitHub returns per page
+	pageSize = 100
+)
+
+// ErrRateLimited is returned when the rate limit is still exceeded after the
+// retries, or would only reset after longer than the client is willing to wait
+var ErrRateLimited = errors.New("GitHub API rate limit exceeded")
+
+// nextLinkPattern finds the next page in a Link header
+var nextLinkPattern = regexp.MustCompile(`<([^>]+)>\s*;\s*rel="next"`)
+
+// APIError is a response GitHub answered with an unexpected status
+type APIError struct {
+	StatusCode int
+	Message    string
+}
+
+// Error implements the error interface
+func (e *APIError) Error() string {
+	if e.Message == "" {
+		return fmt.Sprintf("GitHub API returned status %d", e.StatusCode)
+	}
+	return fmt.Sprintf("GitHub API returned status %d: %s", e.StatusCode, e.Message)
+}
+
+// IsNotFound reports whether err is a 404 from the GitHub API, which GitHub
+// also answers for private repositories the token cannot see
+func IsNotFound(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound
+}
+
+// ClientOptions configures a Client. Zero values select the defaults.
+type ClientOptions struct {
+	// BaseURL is the REST API root; GitHub Enterprise Server uses
+	// https://<host>/api/v3. Default: GITHUB_API_URL, else DefaultBaseURL.
+	BaseURL string
+	// Token authenticates requests; without one only public data is visible
+	// and the rate limit is far lower
+	Token string
+	// MaxRetries is how often a rate-limited or failed request is retried
+	// (default 3; negative disables retries)
+	MaxRetries int
+	// Backoff is the wait before the first retry, doubled for each further one,
+	// when GitHub does not say how long to wait (default 1s)
+	Backoff time.Duration
+	// MaxWait is the longest single wait for a rate limit to reset; a longer
+	// one fails with ErrRateLimited instead (default 1m)
+	MaxWait time.Duration
+	// Timeout bounds each request (default 30s)
+	Timeout time.Duration
+}
+
+// Client is a GitHub REST API client shared by every feature that talks to
+// GitHub. It follows pagination, waits out rate limits and transient failures
+// with exponential backoff, and revalidates repeated GETs with their ETag, as
+// GitHub does not count 304 responses against the rate limit. A Client is safe
+// for concurrent use.
+type Client struct {
+	baseURL    string
+	token      string
+	http       *http.Client
+	maxRetries int
+	backoff    time.Duration
+	maxWait    time.Duration
+
+	mu    sync.Mutex
+	etags map[string]cachedResponse
+}
+
+// cachedResponse is the last response to a GET, revalidated with its ETag
+type cachedResponse struct {
+	etag   string
+	body   []byte
+	header http.Header
+}
+
+// response is a successful API response
+type response struct {
+	body   []byte
+	header http.Header
+}
+
+// NewClient creates a GitHub API client
+func NewClient(options ClientOptions) *Client {
+	if options.BaseURL == "" {
+		options.BaseURL = os.Getenv("GITHUB_API_URL")
+	}
+	if options.BaseURL == "" {
+		options.BaseURL = DefaultBaseURL
+	}
+	if options.MaxRetries == 0 {
+		options.MaxRetries = defaultMaxRetries
+	}
+	if options.Backoff == 0 {
+		options.Backoff = defaultBackoff
+	}
+	if options.MaxWait == 0 {
+		options.MaxWait = defaultMaxWait
+	}
+	if options.Timeout == 0 {
+		options.Timeout = defaultTimeout
+	}
+
+	return &Client{
+		baseURL:    strings.TrimSuffix(options.BaseURL, "/"),
+		token:      options.Token,
+		http:       &http.Client{Timeout: options.Timeout},
+		maxRetries: max(options.MaxRetries, 0),
+		backoff:    options.Backoff,
+		maxWait:    options.MaxWait,
+		etags:      make(map[string]cachedResponse),
+	}
+}
+
+// BaseURL returns the REST API root the client talks to
+func (c *Client) BaseURL() string {
+	return c.baseURL
+}
+
+// Get fetches path, relative to the base URL, and decodes the JSON response into out
+func (c *Client) Get(ctx context.Context, path string, out interface{}) error {
+	resp, err := c.do(ctx, http.MethodGet, c.baseURL+path, nil)
+	if err != nil {
+		return err
+	}
+	return decode(resp.body, out)
+}
+
+// GetAll fetches every page of a list endpoint and decodes the items into
+// out, which must point to a slice
+func (c *Client) GetAll(ctx context.Context, path string, out interface{}) error {
+	endpoint, err := url.Parse(c.baseURL + path)
+	if err != nil {
+		return err
+	}
+	query := endpoint.Query()
+	if query.Get("per_page") == "" {
+		query.Set("per_page", strconv.Itoa(pageSize))
+		endpoint.RawQuery = query.Encode()
+	}
+
+	var items []json.RawMessage
+	for next := endpoint.String(); next != ""; {
+		resp, err := c.do(ctx, http.MethodGet, next, nil)
+		if err != nil {
+			return err
+		}
+		var page []json.RawMessage
+		if err := json.Unmarshal(resp.body, &page); err != nil {
+			return fmt.Errorf("decoding GitHub API response: %w", err)
+		}
+		items = append(items, page...)
+		next = nextPage(resp.header.Get("Link"))
+	}
+
+	data, err := json.Marshal(items)
+	if err != nil {
+		return err
+	}
+	return decode(data, out)
+}
+
+// Post sends body as JSON to path and decodes the JSON response into out, if given
+func (c *Client) Post(ctx context.Context, path string, body, out interface{}) error {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	resp, err := c.do(ctx, http.MethodPost, c.baseURL+path, data)
+	if err != nil {
+		return err
+	}
+	return decode(resp.body, out)
+}
+
+// do sends a request, retrying it while it is rate limited and, for GETs,
+// while the request fails or GitHub answers with a server error. Requests that
+// change data are not retried after a failure, as they may have taken effect.
+func (c *Client) do(ctx context.Context, method, endpoint string, body []byte) (*response, error) {
+	for attempt := 0; ; attempt++ {
+		resp, retry, wait, err := c.send(ctx, method, endpoint, body)
+		if err == nil {
+			return resp, nil
+		}
+		if !c.shouldRetry(method, attempt, retry, err) {
+			return nil, err
+		}
+
+		if wait < 0 {
+			wait = c.backoff << attempt
+		}
+		if wait > c.maxWait {
+			return nil, err
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(wait):
+		}
+	}
+}
+
+// shouldRetry reports whether a failed attempt may be retried: the failure is
+// retryable, retries are left, and the request is a GET or was rate limited
+func (c *Client) shouldRetry(method string, attempt int, retry bool, err error) bool {
+	return retry && attempt < c.maxRetries && (method == http.MethodGet || errors.Is(err, ErrRateLimited))
+}
+
+// send performs one request. On failure it reports whether the request may be
+// retried and how long GitHub asked to wait first, or -1 to back off.
+func (c *Client) send(ctx context.Context, method, endpoint string, body []byte) (*response, bool, time.Duration, error) {
+	req, err := c.newRequest(ctx, method, endpoint, body)
+	if err != nil {
+		return nil, false, 0, err
+	}
+
+	cached, revalidate := c.cached(method, endpoint)
+	if revalidate {
+		req.Header.Set("If-None-Match", cached.etag)
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, ctx.Err() == nil, -1, fmt.Errorf("GitHub request failed: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, true, -1, fmt.Errorf("reading GitHub response: %w", err)
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusNotModified && revalidate:
+		return &response{body: cached.body, header: cached.header}, false, 0, nil
+	case resp.StatusCode < 300:
+		if etag := resp.Header.Get("ETag"); method == http.MethodGet && etag != "" {
+			c.store(endpoint, cachedResponse{etag: etag, body: data, header: resp.Header})
+		}
+		return &response{body: data, header: resp.Header}, false, 0, nil
+	}
+
+	retry, wait, err := failedResponse(resp, data)
+	return nil, retry, wait, err
+}
+
+// newRequest builds an authenticated API request with an optional JSON body
+func (c *Client) newRequest(ctx context.Context, method, endpoint string, body []byte) (*http.Request, error) {
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, reader)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/vnd.github.v3+json")
+	if c.token != "" {
+		req.Header.Set("Authorization", "token "+c.token)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	return req, nil
+}
+
+// failedResponse returns the error for an unsuccessful response, whether the
+// request may be retried, and how long to wait first or -1 to back off
+func failedResponse(resp *http.Response, data []byte) (bool, time.Duration, error) {
+	apiErr := &APIError{StatusCode: resp.StatusCode, Message: errorMessage(data)}
+	if wait, limited := rateLimitWait(resp, apiErr.Message); limited {
+		return true, wait, fmt.Errorf("%w: %s", ErrRateLimited, apiErr.Error())
+	}
+	if resp.StatusCode >= 500 {
+		return true, retryAfter(resp.Header), apiErr
+	}
+	return false, 0, apiErr
+}
+
+// cached returns the cached response to revalidate a GET with
+func (c *Client) cached(method, endpoint string) (cachedResponse, bool) {
+	if method != http.MethodGet {
+		return cachedResponse{}, false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	cached, ok := c.etags[endpoint]
+	return cached, ok
+}
+
+// store records a response to revalidate later GETs of the endpoint with
+func (c *Client) store(endpoint string, cached cachedResponse) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.etags[endpoint] = cached
+}
+
+// rateLimitWait reports whether a response is rate limited and how long to
+// wait before retrying it, or -1 to back off. GitHub answers 429 or 403 for
+// both the primary limit, with X-RateLimit-Remaining at 0 until
+// X-RateLimit-Reset, and secondary limits, usually with a Retry-After.
+func rateLimitWait(resp *http.Response, message string) (time.Duration, bool) {
+	if resp.StatusCode != http.StatusForbidden && resp.StatusCode != http.StatusTooManyRequests {
+		return 0, false
+	}
+	if wait := retryAfter(resp.Header); wait >= 0 {
+		return wait, true
+	}
+	if resp.Header.Get("X-RateLimit-Remaining") == "0" {
+		if reset, err := strconv.ParseInt(resp.Header.Get("X-RateLimit-Reset"), 10, 64); err == nil {
+			return max(time.Until(time.Unix(reset, 0)), 0), true
+		}
+		return -1, true
+	}
+	if resp.StatusCode == http.StatusTooManyRequests || strings.Contains(strings.ToLower(message), "rate limit") {
+		return -1, true
+	}
+	return 0, false
+}
+
+// retryAfter returns the wait a Retry-After header asks for in seconds, or -1 without one
+func retryAfter(header http.Header) time.Duration {
+	seconds, err := strconv.Atoi(header.Get("Retry-After"))
+	if err != nil || seconds < 0 {
+		return -1
+	}
+	return time.Duration(seconds) * time.Second
+}
+
+// nextPage returns the URL of the next page named in a Link header, or ""
+func nextPage(link string) string {
+	if match := nextLinkPattern.FindStringSubmatch(link); match != nil {
+		return match[1]
+	}
+	return ""
+}
+
+// errorMessage returns the message of a GitHub error response
+func errorMessage(data []byte) string {
+	var body struct {
+		Message string `json:"message"`
+	}
+	if json.Unmarshal(data, &body) == nil {
+		return body.Message
+	}
+	return ""
+}
+
+// decode decodes a JSON response into out, if given
+func decode(data []byte, out interface{}) error {
+	if out == nil || len(data) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(data, out); err != nil {
+		return fmt.Errorf("decoding GitHub API response: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,146 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+// countingServer serves handler and counts the requests it answers
+func countingServer(t *testing.T, handler http.HandlerFunc) (*httptest.Server, func() int) {
+	t.Helper()
+	var mu sync.Mutex
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requests++
+		mu.Unlock()
+		handler(w, r)
+	}))
+	t.Cleanup(server.Close)
+	return server, func() int {
+		mu.Lock()
+		defer mu.Unlock()
+		return requests
+	}
+}
+
+func TestClient_GetAllFollowsPagination(t *testing.T) {
+	var server *httptest.Server
+	server, _ = countingServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("per_page") != "100" {
+			t.Errorf("Expected the largest page size, got %s", r.URL.RawQuery)
+		}
+		if r.URL.Query().Get("page") == "2" {
+			_, _ = w.Write([]byte(`[{"number":3}]`))
+			return
+		}
+		w.Header().Set("Link", fmt.Sprintf(`<%s/items?per_page=100&page=2>; rel="next", <%s/items?per_page=100&page=2>; rel="last"`, server.URL, server.URL))
+		_, _ = w.Write([]byte(`[{"number":1},{"number":2}]`))
+	})
+
+	var items []struct {
+		Number int `json:"number"`
+	}
+	client := NewClient(ClientOptions{BaseURL: server.URL})
+	if err := client.GetAll(context.Background(), "/items", &items); err != nil {
+		t.Fatalf("GetAll failed: %v", err)
+	}
+	if len(items) != 3 || items[2].Number != 3 {
+		t.Errorf("Expected the items of both pages, got %+v", items)
+	}
+}
+
+func TestClient_RevalidatesWithETag(t *testing.T) {
+	server, requests := countingServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		_, _ = w.Write([]byte(`{"protected":true}`))
+	})
+
+	client := NewClient(ClientOptions{BaseURL: server.URL})
+	for i := 0; i < 2; i++ {
+		var branch struct {
+			Protected bool `json:"protected"`
+		}
+		if err := client.Get(context.Background(), "/branch", &branch); err != nil {
+			t.Fatalf("Get failed: %v", err)
+		}
+		if !branch.Protected {
+			t.Errorf("Expected request %d to decode the cached response", i+1)
+		}
+	}
+	if requests() != 2 {
+		t.Errorf("Expected two requests, got %d", requests())
+	}
+}
+
+func TestClient_RetriesRateLimit(t *testing.T) {
+	server, requests := countingServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet && r.URL.Path == "/limited" {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"message":"You have exceeded a secondary rate limit."}`))
+			return
+		}
+		w.Header().Set("X-RateLimit-Remaining", "0")
+		w.Header().Set("X-RateLimit-Reset", fmt.Sprint(time.Now().Add(time.Hour).Unix()))
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`{"message":"API rate limit exceeded"}`))
+	})
+	client := NewClient(ClientOptions{BaseURL: server.URL, MaxRetries: 2, Backoff: time.Millisecond})
+
+	if err := client.Get(context.Background(), "/limited", nil); !errors.Is(err, ErrRateLimited) {
+		t.Errorf("Expected ErrRateLimited, got %v", err)
+	}
+	if requests() != 3 {
+		t.Errorf("Expected the request and two retries, got %d requests", requests())
+	}
+
+	// A reset an hour away is longer than the client waits
+	if err := client.Get(context.Background(), "/exhausted", nil); !errors.Is(err, ErrRateLimited) {
+		t.Errorf("Expected ErrRateLimited, got %v", err)
+	}
+	if requests() != 4 {
+		t.Errorf("Expected no retry when the reset is too far away, got %d requests", requests()-3)
+	}
+}
+
+func TestClient_RetriesServerErrorsForGetOnly(t *testing.T) {
+	server, requests := countingServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	})
+	client := NewClient(ClientOptions{BaseURL: server.URL, MaxRetries: 2, Backoff: time.Millisecond})
+
+	var apiErr *APIError
+	if err := client.Get(context.Background(), "/repos/o/r", nil); !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusBadGateway {
+		t.Errorf("Expected a 502 APIError, got %v", err)
+	}
+	if requests() != 3 {
+		t.Errorf("Expected a failing GET to be retried twice, got %d requests", requests())
+	}
+
+	if err := client.Post(context.Background(), "/repos/o/r/issues", map[string]string{"title": "x"}, nil); err == nil {
+		t.Error("Expected POST to fail")
+	}
+	if requests() != 4 {
+		t.Errorf("Expected a failing POST not to be retried, got %d requests", requests()-3)
+	}
+}
+
+func TestIsNotFound(t *testing.T) {
+	if !IsNotFound(fmt.Errorf("lookup: %w", &APIError{StatusCode: http.StatusNotFound})) {
+		t.Error("Expected a wrapped 404 to be not found")
+	}
+	if IsNotFound(&APIError{StatusCode: http.StatusForbidden}) || IsNotFound(errors.New("404")) {
+		t.Error("Expected only 404 API errors to be not found")
+	}
+}
@@ -3,10 +3,8 @@
 package github
 
 import (
-	"bytes"
-	"encoding/json"
+	"context"
 	"fmt"
-	"net/http"
 	"os"
 
 	"github.com/codcod/repos/internal/config"
@@ -23,6 +21,7 @@ type PROptions struct {
 	CommitMsg  string
 	Draft      bool
 	Token      string // GitHub API token
+	APIURL     string // GitHub API base URL, for GitHub Enterprise (default: GITHUB_API_URL or api.github.com)
 	CreateOnly bool   // Only create PR, don't make changes
 }
 
@@ -169,9 +168,6 @@ var createGitHubPullRequest createGitHubPullRequestFunc = createGitHubPullReques
 
 // createGitHubPullRequestImpl creates a pull request via the GitHub API
 func createGitHubPullRequestImpl(owner, repo string, options PROptions, baseBranch string) error {
-	// GitHub API endpoint
-	url := fmt.Sprintf("https://api.github.com/repos/%s/%s/pulls", owner, repo)
-
 	// Check if token is provided
 	if options.Token == "" {
 		options.Token = os.Getenv("GITHUB_TOKEN")
@@ -189,46 +185,15 @@ func createGitHubPullRequestImpl(owner, repo string, options PROptions, baseBran
 		"draft": options.Draft,
 	}
 
-	jsonData, err := json.Marshal(data)
-	if err != nil {
-		return err
+	client := NewClient(ClientOptions{BaseURL: options.APIURL, Token: options.Token})
+	var prResponse struct {
+		HTMLURL string `json:"html_url"`
 	}
-
-	// Create HTTP request
-	req, err := http.NewRequest("POST", url, bytes.NewBuffer(jsonData))
-	if err != nil {
-		return err
-	}
-
-	// Set headers
-	req.Header.Set("Accept", "application/vnd.github.v3+json")
-	req.Header.Set("Authorization", "token "+options.Token)
-	req.Header.Set("Content-Type", "application/json")
-
-	// Send request
-	client := &http.Client{}
-	resp, err := client.Do(req)
-	if err != nil {
-		return err
-	}
-	defer func() { _ = resp.Body.Close() }()
-
-	// Check response
-	if resp.StatusCode != http.StatusCreated {
-		var errorResponse map[string]interface{}
-		if err := json.NewDecoder(resp.Body).Decode(&errorResponse); err != nil {
-			return fmt.Errorf("failed to create PR, status: %d", resp.StatusCode)
-		}
-		return fmt.Errorf("failed to create PR: %v", errorResponse)
-	}
-
-	// Decode response
-	var prResponse map[string]interface{}
-	if err := json.NewDecoder(resp.Body).Decode(&prResponse); err != nil {
-		return err
+	if err := client.Post(context.Background(), fmt.Sprintf("/repos/%s/%s/pulls", owner, repo), data, &prResponse); err != nil {
+		return fmt.Errorf("failed to create PR: %w", err)
 	}
 
 	// Print PR URL
-	fmt.Printf("Pull request created: %s\n", prResponse["html_url"])
+	fmt.Printf("Pull request created: %s\n", prResponse.HTMLURL)
 	return nil
 }
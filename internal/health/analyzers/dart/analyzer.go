@@ -0,0 +1,223 @@
+package dart_analyzer
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/codcod/repos/internal/core"
+	"github.com/codcod/repos/internal/health/analyzers/language"
+	"github.com/codcod/repos/internal/health/analyzers/parallel"
+)
+
+// DartAnalyzer implements language-specific analysis for Dart and Flutter code
+type DartAnalyzer struct {
+	name         string
+	language     string
+	extensions   []string
+	excludes     []string
+	testPatterns []string
+	filesystem   core.FileSystem
+	logger       core.Logger
+}
+
+// NewDartAnalyzer creates a new Dart language analyzer
+func NewDartAnalyzer(fs core.FileSystem, logger core.Logger) *DartAnalyzer {
+	return &DartAnalyzer{
+		name:         "dart-analyzer",
+		language:     "dart",
+		extensions:   []string{".dart"},
+		excludes:     []string{".dart_tool/", "build/", ".git/", ".pub-cache/"},
+		testPatterns: []string{"*_test.dart"},
+		filesystem:   fs,
+		logger:       logger,
+	}
+}
+
+// Name returns the analyzer name
+func (d *DartAnalyzer) Name() string {
+	return d.name
+}
+
+// Language returns the supported language
+func (d *DartAnalyzer) Language() string {
+	return d.language
+}
+
+// SupportedExtensions returns supported file extensions
+func (d *DartAnalyzer) SupportedExtensions() []string {
+	return d.extensions
+}
+
+// CanAnalyze checks if the analyzer can process the given repository
+func (d *DartAnalyzer) CanAnalyze(repo core.Repository) bool {
+	files, _, err := d.findDartFiles(repo.FileIndex(), d.extensions, d.excludes)
+	return err == nil && len(files) > 0
+}
+
+// Analyze performs language-specific analysis on the repository
+func (d *DartAnalyzer) Analyze(ctx context.Context, repoPath string, config core.AnalyzerConfig) (*core.AnalysisResult, error) {
+	d.logger.Info("Starting Dart analysis", core.Field{Key: "repo", Value: repoPath})
+
+	result := &core.AnalysisResult{
+		Language:  d.language,
+		Files:     make(map[string]*core.FileAnalysis),
+		Functions: []core.FunctionInfo{},
+		Metrics:   make(map[string]interface{}),
+	}
+
+	files, walkErrors, err := d.findDartFiles(config.FileIndex(repoPath), config.Extensions(d.extensions), config.Excludes(d.excludes, d.testPatterns))
+	if err != nil {
+		return nil, err
+	}
+	result.Errors = append(result.Errors, walkErrors...)
+
+	// Restrict to explicitly included files (e.g. changed files only)
+	files = config.FilterFiles(files)
+
+	// Skip generated code unless it is explicitly included
+	generatedSkipped := 0
+	if !config.IncludeGenerated {
+		files, generatedSkipped = language.SkipGenerated(files)
+	}
+
+	// Imports of the package itself are local, like relative imports
+	packageName := readPackageName(filepath.Join(repoPath, "pubspec.yaml"))
+	fileResults, err := parallel.AnalyzeFiles(ctx, files, config.Concurrency, func(filePath string) (*core.FileAnalysis, error) {
+		return d.analyzeFile(filePath, packageName)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	totalComplexity := 0
+	totalClasses := 0
+	maxComplexity := 0
+	maxFunctionLines := 0
+	binarySkipped := 0
+
+	for _, fileResult := range fileResults {
+		file, fileAnalysis := fileResult.Path, fileResult.Analysis
+		if errors.Is(fileResult.Err, language.ErrBinaryFile) {
+			binarySkipped++
+			continue
+		}
+		if fileResult.Err != nil {
+			d.logger.Warn("Failed to analyze file",
+				core.Field{Key: "file", Value: file},
+				core.Field{Key: "error", Value: fileResult.Err.Error()})
+			result.Errors = append(result.Errors, core.AnalysisError{Path: file, Reason: fileResult.Err.Error()})
+			continue
+		}
+
+		result.Files[file] = fileAnalysis
+		totalClasses += len(fileAnalysis.Classes)
+		for _, fn := range fileAnalysis.Functions {
+			result.Functions = append(result.Functions, fn)
+			totalComplexity += fn.Complexity
+			if fn.Complexity > maxComplexity {
+				maxComplexity = fn.Complexity
+			}
+			maxFunctionLines = max(maxFunctionLines, fn.Lines())
+		}
+	}
+
+	// Order functions by file and line so output does not depend on scheduling
+	core.SortFunctions(result.Functions)
+
+	avgComplexity := 0.0
+	if len(result.Functions) > 0 {
+		avgComplexity = float64(totalComplexity) / float64(len(result.Functions))
+	}
+
+	result.Metrics["total_files"] = len(result.Files)
+	result.Metrics["binary_files_skipped"] = binarySkipped
+	result.Metrics["generated_files_skipped"] = generatedSkipped
+	result.Metrics["total_classes"] = totalClasses
+	result.Metrics["total_functions"] = len(result.Functions)
+	result.Metrics["total_complexity"] = totalComplexity
+	result.Metrics["max_complexity"] = maxComplexity
+	result.Metrics["max_function_lines"] = maxFunctionLines
+	result.Metrics["average_complexity"] = avgComplexity
+
+	d.logger.Info("Dart analysis completed",
+		core.Field{Key: "files", Value: len(result.Files)},
+		core.Field{Key: "functions", Value: len(result.Functions)})
+
+	return result, nil
+}
+
+// findDartFiles finds all Dart source files in the repository
+func (d *DartAnalyzer) findDartFiles(index *core.FileIndex, extensions, excludes []string) ([]string, []core.AnalysisError, error) {
+	paths, walkErrors, err := index.Find(extensions)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var dartFiles []string
+	for _, path := range paths {
+		relPath, _ := filepath.Rel(index.Root(), path)
+		relPath = filepath.ToSlash(relPath)
+		if !language.Excluded(relPath, excludes) {
+			dartFiles = append(dartFiles, path)
+		}
+	}
+
+	return dartFiles, walkErrors, nil
+}
+
+// analyzeFile analyzes a single Dart file
+func (d *DartAnalyzer) analyzeFile(filePath, packageName string) (*core.FileAnalysis, error) {
+	content, err := language.ReadText(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	parser := &dartParser{language: d.language, filePath: filePath, packageName: packageName}
+	functions, classes, imports, fileComplexity := parser.parse(content)
+
+	analysis := &core.FileAnalysis{
+		Path:       filePath,
+		Language:   d.language,
+		Lines:      strings.Count(content, "\n"),
+		Functions:  functions,
+		Classes:    classes,
+		Imports:    imports,
+		Complexity: fileComplexity,
+		Metrics:    make(map[string]interface{}),
+	}
+
+	analysis.Metrics["function_count"] = len(functions)
+	analysis.Metrics["class_count"] = len(classes)
+	analysis.Metrics["import_count"] = len(imports)
+	if len(functions) > 0 {
+		totalComplexity := 0
+		for _, fn := range functions {
+			totalComplexity += fn.Complexity
+		}
+		analysis.Metrics["average_complexity"] = float64(totalComplexity) / float64(len(functions))
+	}
+
+	return analysis, nil
+}
+
+// readPackageName returns the name declared at the top level of pubspec.yaml,
+// or "" if there is none
+func readPackageName(pubspecPath string) string {
+	file, err := os.Open(pubspecPath) //nolint:gosec // Path is within the repository
+	if err != nil {
+		return ""
+	}
+	defer func() { _ = file.Close() }()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		if name, ok := strings.CutPrefix(scanner.Text(), "name:"); ok {
+			return strings.Trim(strings.TrimSpace(name), `"'`)
+		}
+	}
+	return ""
+}
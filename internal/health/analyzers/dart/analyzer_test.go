@@ -0,0 +1,181 @@
+package dart_analyzer
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/codcod/repos/internal/core"
+)
+
+type testLogger struct{}
+
+func (testLogger) Debug(string, ...core.Field) {}
+func (testLogger) Info(string, ...core.Field)  {}
+func (testLogger) Warn(string, ...core.Field)  {}
+func (testLogger) Error(string, ...core.Field) {}
+func (testLogger) Fatal(string, ...core.Field) {}
+
+const counterSource = `import 'dart:async';
+import 'package:flutter/material.dart' as m;
+import 'package:shop/models/item.dart';
+import '../utils.dart';
+export 'src/cart.dart';
+
+/* A block comment mentioning if and for { */
+int add(int a, int b) => a + b;
+
+@immutable
+class Cart extends ChangeNotifier {
+  Cart(this.items) : assert(items != null);
+
+  final List<Item> items;
+
+  int get count => items.length;
+
+  // if this comment counted, complexity would be off
+  int total({bool discounted = false}) {
+    var sum = 0;
+    for (final item in items) {
+      if (item.price > 0 && !item.free) {
+        sum += item.price;
+      } else if (item.coupon?.valid ?? false) {
+        sum -= 1;
+      }
+    }
+    items.forEach((item) {
+      if (item.free) print('free { item');
+    });
+    return sum;
+  }
+
+  String label(Item item) {
+    switch (item.kind) {
+      case Kind.a:
+        return 'a';
+      case Kind.b:
+        return "b ${item.name ?? 'x'}";
+      default:
+        return '';
+    }
+  }
+
+  Future<void> load() async {
+    var i = 0;
+    do {
+      i++;
+    } while (i < 3);
+    try {
+      await fetch();
+    } catch (e) {
+      rethrow;
+    }
+  }
+}
+
+String describe(Shape shape) => switch (shape) {
+  Square(length: var l) => 'square $l',
+  Circle() => 'circle',
+};
+`
+
+func TestDartParser(t *testing.T) {
+	parser := &dartParser{language: "dart", filePath: "cart.dart", packageName: "shop"}
+	functions, classes, imports, fileComplexity := parser.parse(counterSource)
+
+	expected := []struct {
+		name       string
+		line       int
+		endLine    int
+		complexity int
+	}{
+		{"add", 8, 8, 1},
+		{"count", 16, 16, 1},
+		// for, if, &&, else if, ?., ??, and the if in the closure
+		{"total", 19, 32, 8},
+		// the two cases; the ?? inside the string interpolation is not code
+		{"label", 34, 43, 3},
+		// do without its while, and catch
+		{"load", 45, 55, 3},
+		{"describe", 58, 61, 1},
+	}
+	if len(functions) != len(expected) {
+		t.Fatalf("Expected %d functions, got %+v", len(expected), functions)
+	}
+	for i, want := range expected {
+		got := functions[i]
+		if got.Name != want.name || got.Line != want.line || got.EndLine != want.endLine || got.Complexity != want.complexity {
+			t.Errorf("Expected %s at lines %d-%d with complexity %d, got %s at lines %d-%d with complexity %d",
+				want.name, want.line, want.endLine, want.complexity, got.Name, got.Line, got.EndLine, got.Complexity)
+		}
+	}
+
+	if len(classes) != 1 || classes[0].Name != "Cart" || classes[0].Line != 11 || len(classes[0].Methods) != 4 {
+		t.Errorf("Unexpected classes: %+v", classes)
+	}
+	if fileComplexity != 12 {
+		t.Errorf("Expected file complexity 12, got %d", fileComplexity)
+	}
+
+	expectedImports := []struct {
+		path, name, alias string
+		local             bool
+	}{
+		{"dart:async", "async", "", false},
+		{"package:flutter/material.dart", "material", "m", false},
+		{"package:shop/models/item.dart", "item", "", true},
+		{"../utils.dart", "utils", "", true},
+		{"src/cart.dart", "cart", "", true},
+	}
+	if len(imports) != len(expectedImports) {
+		t.Fatalf("Expected %d imports, got %+v", len(expectedImports), imports)
+	}
+	for i, want := range expectedImports {
+		got := imports[i]
+		if got.Path != want.path || got.Name != want.name || got.Alias != want.alias || got.IsLocal != want.local {
+			t.Errorf("Unexpected import %d: %+v", i, got)
+		}
+	}
+}
+
+func TestDartAnalyzer_Analyze(t *testing.T) {
+	repoPath := t.TempDir()
+	files := map[string]string{
+		"pubspec.yaml":                 "name: shop\ndependencies:\n  flutter:\n    sdk: flutter\n",
+		"lib/cart.dart":                counterSource,
+		"lib/main.dart":                "import 'package:shop/cart.dart';\n\nvoid main() {\n  runApp(App());\n}\n",
+		"test/cart_test.dart":          "void main() {\n  test('x', () {});\n}\n",
+		".dart_tool/build/entry.dart":  "void generated() {}\n",
+		"build/app/intermediates.dart": "void built() {}\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(repoPath, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	analyzer := NewDartAnalyzer(nil, testLogger{})
+	if !analyzer.CanAnalyze(core.Repository{Path: repoPath}) {
+		t.Fatal("Expected repository with Dart files to be analyzable")
+	}
+
+	result, err := analyzer.Analyze(context.Background(), repoPath, core.AnalyzerConfig{})
+	if err != nil {
+		t.Fatalf("Analyze failed: %v", err)
+	}
+	if len(result.Files) != 2 {
+		t.Errorf("Expected lib/cart.dart and lib/main.dart, got %d files", len(result.Files))
+	}
+	if result.Metrics["total_functions"] != 7 || result.Metrics["total_classes"] != 1 {
+		t.Errorf("Unexpected metrics: %v", result.Metrics)
+	}
+	main := result.Files[filepath.Join(repoPath, "lib", "main.dart")]
+	if main == nil || len(main.Imports) != 1 || !main.Imports[0].IsLocal {
+		t.Errorf("Expected the package's own import to be local, got %+v", main)
+	}
+}
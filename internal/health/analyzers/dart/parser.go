@@ -0,0 +1,475 @@
+package dart_analyzer
+
+import (
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/codcod/repos/internal/core"
+)
+
+var (
+	// importPattern matches import and export directives with an optional prefix
+	importPattern = regexp.MustCompile(`^\s*(?:import|export)\s+['"]([^'"]+)['"](?:\s+(?:deferred\s+)?as\s+([A-Za-z_$][\w$]*))?`)
+
+	// functionPattern matches the end of a function, method, constructor or
+	// operator header: the name, type parameters, parameter list, body modifier
+	// and a constructor's initializer list
+	functionPattern = regexp.MustCompile(`(?:^|[^\w$.])(operator\s*[^\s\w(]+|[A-Za-z_$][\w$]*(?:\.[A-Za-z_$][\w$]*)?)\s*(?:<[^()]*>)?\s*\([^()]*(?:\([^()]*\)[^()]*)*\)\s*(?:async\*?|sync\*)?\s*(?::.*)?$`)
+
+	// getterPattern matches the end of a getter header, which has no parameter list
+	getterPattern = regexp.MustCompile(`\bget\s+([A-Za-z_$][\w$]*)\s*(?:async\*?|sync\*)?\s*$`)
+
+	// classPattern matches a class, mixin, extension or enum declaration header
+	// after any modifiers such as abstract, sealed or base
+	classPattern = regexp.MustCompile(`^(?:[a-z]+\s+)*?(?:class|mixin|enum|extension(?:\s+type)?)\b\s*([A-Za-z_$][\w$]*)?`)
+
+	// annotationPattern matches metadata such as @override or @Deprecated("")
+	annotationPattern = regexp.MustCompile(`@[A-Za-z_$][\w$.]*(?:\s*\([^()]*\))?`)
+
+	// switchPattern matches a switch statement or expression header
+	switchPattern = regexp.MustCompile(`\bswitch\s*\(.*\)\s*$`)
+
+	// doPattern matches the header of a do-while body
+	doPattern = regexp.MustCompile(`\bdo\s*$`)
+
+	// decisionPattern matches the keywords that add a branch
+	decisionPattern = regexp.MustCompile(`\b(?:if|for|while|do|case|catch)\b`)
+
+	// doWhilePattern matches the condition that ends a do-while loop, which the do already counted
+	doWhilePattern = regexp.MustCompile(`^\s*while\b`)
+)
+
+// statementKeywords look like function names in front of parentheses but open ordinary blocks
+var statementKeywords = map[string]bool{
+	"if": true, "for": true, "while": true, "switch": true, "catch": true, "return": true,
+	"assert": true, "await": true, "yield": true, "throw": true, "new": true, "const": true,
+}
+
+// scopeKind tells what a brace opened
+type scopeKind int
+
+const (
+	scopeBlock    scopeKind = iota // statements, closures and collection literals
+	scopeClass                     // class, mixin, extension or enum body
+	scopeFunction                  // function, method or constructor body
+	scopeSwitch                    // switch body, whose pattern arms are not functions
+	scopeDo                        // do-while body
+)
+
+// dartFunction is a function whose body is being parsed
+type dartFunction struct {
+	info  core.FunctionInfo
+	class *core.ClassInfo // Enclosing class of a method
+	depth int             // Scope depth of the body
+	arrow bool            // The body is a => expression ending at a ';'
+}
+
+// dartParser finds the functions, classes and imports of a Dart file and
+// attributes each decision point to the innermost function containing it.
+// Closures are part of their enclosing function.
+type dartParser struct {
+	language    string
+	filePath    string
+	packageName string
+
+	scopes    []scopeKind
+	classes   []*core.ClassInfo // Open class for each class scope, nil for other scopes
+	open      []*dartFunction
+	functions []core.FunctionInfo
+	imports   []core.ImportInfo
+	classList []*core.ClassInfo
+
+	// header is the code since the last ';', '{' or '}', which decides what the
+	// next '{' or '=>' opens; headerLine is the line it started on
+	header     strings.Builder
+	headerLine int
+	// segment is the code since the last scope change, counted towards the innermost function
+	segment strings.Builder
+	// nesting counts open parentheses and brackets; braces inside them are
+	// closures, named parameters or literals rather than scopes
+	nesting  int
+	skipDo   bool // The next segment may be the while of a do-while loop
+	fileCost int
+
+	blockComments int    // Depth of nested /* */ comments
+	multiline     string // Delimiter of the open multi-line string
+}
+
+// parse returns the functions, classes, imports and complexity of a Dart file
+func (p *dartParser) parse(content string) ([]core.FunctionInfo, []core.ClassInfo, []core.ImportInfo, int) {
+	p.fileCost = 1
+	lines := strings.Split(content, "\n")
+	for i, line := range lines {
+		lineNum := i + 1
+		if p.blockComments == 0 && p.multiline == "" {
+			if match := importPattern.FindStringSubmatch(line); match != nil {
+				p.imports = append(p.imports, p.importInfo(match[1], match[2], lineNum))
+				continue
+			}
+		}
+		p.scanLine(p.stripLine(line), lineNum)
+	}
+
+	// Close what unbalanced braces left open
+	p.flushSegment()
+	for len(p.open) > 0 {
+		p.closeFunction(len(lines))
+	}
+
+	sort.SliceStable(p.functions, func(i, k int) bool { return p.functions[i].Line < p.functions[k].Line })
+	classes := make([]core.ClassInfo, 0, len(p.classList))
+	for _, class := range p.classList {
+		classes = append(classes, *class)
+	}
+	return p.functions, classes, p.imports, p.fileCost
+}
+
+// importInfo describes an import or export directive. Imports of the package
+// itself and relative paths are local; dart: and other packages are not.
+func (p *dartParser) importInfo(uri, prefix string, lineNum int) core.ImportInfo {
+	local := !strings.Contains(uri, ":")
+	if p.packageName != "" && strings.HasPrefix(uri, "package:"+p.packageName+"/") {
+		local = true
+	}
+	name := strings.TrimSuffix(path.Base(uri), ".dart")
+	if rest, ok := strings.CutPrefix(uri, "dart:"); ok {
+		name = rest
+	}
+	return core.ImportInfo{Name: name, Path: uri, Alias: prefix, Line: lineNum, IsLocal: local}
+}
+
+// scanLine feeds one line of comment- and literal-free code through the scope stack
+func (p *dartParser) scanLine(code string, lineNum int) {
+	for i := 0; i < len(code); i++ {
+		if p.skipIndentation(code[i], lineNum) {
+			continue // Indentation does not start a header
+		}
+		if p.scanStructure(code, i, lineNum) {
+			p.header.Reset()
+			continue
+		}
+		p.segment.WriteByte(code[i])
+		p.header.WriteByte(code[i])
+	}
+
+	p.flushSegment()
+	// Annotations on lines of their own are not part of the declaration's position
+	if p.currentHeader() == "" {
+		p.header.Reset()
+		return
+	}
+	p.header.WriteByte(' ')
+}
+
+// skipIndentation reports whether ch is whitespace before any header, and
+// records the line a header starts on
+func (p *dartParser) skipIndentation(ch byte, lineNum int) bool {
+	if ch == ' ' || ch == '\t' {
+		return p.header.Len() == 0
+	}
+	if p.header.Len() == 0 {
+		p.headerLine = lineNum
+	}
+	return false
+}
+
+// scanStructure handles the character of code at i that opens or closes a
+// scope, ends a statement or starts an expression body. It reports whether the
+// character ended the pending header.
+func (p *dartParser) scanStructure(code string, i, lineNum int) bool {
+	if p.trackNesting(code[i]) {
+		return false
+	}
+
+	switch ch := code[i]; {
+	case ch == '{':
+		p.flushSegment()
+		p.openScope()
+		return true
+	case ch == '}':
+		p.flushSegment()
+		p.closeScope(lineNum)
+		return true
+	case ch == ';':
+		p.flushSegment()
+		if p.arrowEndsHere() {
+			p.closeFunction(lineNum)
+		}
+		return true
+	case ch == '=' && i+1 < len(code) && code[i+1] == '>':
+		p.flushSegment()
+		p.openArrow()
+	}
+	return false
+}
+
+// trackNesting follows parentheses and brackets, and reports whether ch is one
+// of them or inside them. Braces and semicolons of closures in arguments stay
+// part of the statement.
+func (p *dartParser) trackNesting(ch byte) bool {
+	switch {
+	case ch == '(' || ch == '[':
+		p.nesting++
+	case ch == ')' || ch == ']':
+		p.nesting = max(p.nesting-1, 0)
+	default:
+		return p.nesting > 0
+	}
+	return true
+}
+
+// arrowEndsHere reports whether the innermost open function is an expression
+// body of the current scope, which ends with the statement
+func (p *dartParser) arrowEndsHere() bool {
+	n := len(p.open)
+	return n > 0 && p.open[n-1].arrow && p.open[n-1].depth == len(p.scopes)
+}
+
+// currentHeader returns the pending header without annotations
+func (p *dartParser) currentHeader() string {
+	return strings.TrimSpace(annotationPattern.ReplaceAllString(p.header.String(), ""))
+}
+
+// openScope pushes the scope a '{' opens, deciding from the header what it is
+func (p *dartParser) openScope() {
+	header := p.currentHeader()
+	kind := scopeBlock
+	var class *core.ClassInfo
+
+	match := classPattern.FindStringSubmatch(header)
+	switch {
+	case match != nil && p.declaresFunctions():
+		kind = scopeClass
+		class = &core.ClassInfo{Name: match[1], File: p.filePath, Language: p.language, Line: p.headerLine}
+		p.classList = append(p.classList, class)
+	case switchPattern.MatchString(header):
+		kind = scopeSwitch
+	case doPattern.MatchString(header):
+		kind = scopeDo
+	default:
+		if name := p.functionName(header); name != "" {
+			kind = scopeFunction
+			p.open = append(p.open, &dartFunction{
+				info:  p.newFunction(name, p.headerLine),
+				class: p.enclosingClass(),
+				depth: len(p.scopes) + 1,
+			})
+		}
+	}
+
+	p.scopes = append(p.scopes, kind)
+	p.classes = append(p.classes, class)
+}
+
+// openArrow starts a function whose body is the => expression that follows
+func (p *dartParser) openArrow() {
+	if n := len(p.open); n > 0 && p.open[n-1].arrow {
+		return // A closure within an expression body
+	}
+	header := p.currentHeader()
+	name := p.functionName(header)
+	if name == "" {
+		if match := getterPattern.FindStringSubmatch(header); match != nil && p.declaresFunctions() {
+			name = match[1]
+		}
+	}
+	if name == "" {
+		return
+	}
+	p.open = append(p.open, &dartFunction{
+		info:  p.newFunction(name, p.headerLine),
+		class: p.enclosingClass(),
+		depth: len(p.scopes),
+		arrow: true,
+	})
+}
+
+// closeScope pops the scope a '}' closes, ending the function it is the body of
+func (p *dartParser) closeScope(lineNum int) {
+	if len(p.scopes) == 0 {
+		return
+	}
+	// An expression body cut short by the end of its enclosing scope ends here
+	for p.arrowEndsHere() {
+		p.closeFunction(lineNum)
+	}
+
+	kind := p.scopes[len(p.scopes)-1]
+	if kind == scopeFunction && len(p.open) > 0 && p.open[len(p.open)-1].depth == len(p.scopes) {
+		p.closeFunction(lineNum)
+	}
+	p.skipDo = kind == scopeDo
+	p.scopes = p.scopes[:len(p.scopes)-1]
+	p.classes = p.classes[:len(p.classes)-1]
+}
+
+// closeFunction ends the innermost open function at lineNum
+func (p *dartParser) closeFunction(lineNum int) {
+	fn := p.open[len(p.open)-1]
+	p.open = p.open[:len(p.open)-1]
+	fn.info.EndLine = lineNum
+	p.functions = append(p.functions, fn.info)
+	if fn.class != nil {
+		fn.class.Methods = append(fn.class.Methods, fn.info)
+	}
+}
+
+// newFunction starts the information of a function declared at lineNum
+func (p *dartParser) newFunction(name string, lineNum int) core.FunctionInfo {
+	return core.FunctionInfo{
+		Name:       name,
+		File:       p.filePath,
+		Language:   p.language,
+		Line:       lineNum,
+		EndLine:    lineNum,
+		Complexity: 1,
+	}
+}
+
+// functionName returns the name a header declares a function with, or "" if
+// it does not declare one, such as a control statement or a closure
+func (p *dartParser) functionName(header string) string {
+	if !p.declaresFunctions() {
+		return ""
+	}
+	match := functionPattern.FindStringSubmatch(header)
+	if match == nil || statementKeywords[match[1]] {
+		return ""
+	}
+	return match[1]
+}
+
+// declaresFunctions reports whether the current scope can declare functions;
+// the arms of switch expressions look like calls followed by =>
+func (p *dartParser) declaresFunctions() bool {
+	return len(p.scopes) == 0 || p.scopes[len(p.scopes)-1] != scopeSwitch
+}
+
+// enclosingClass returns the class whose body is the current scope, if any
+func (p *dartParser) enclosingClass() *core.ClassInfo {
+	if len(p.classes) == 0 {
+		return nil
+	}
+	return p.classes[len(p.classes)-1]
+}
+
+// flushSegment adds the complexity of the pending code to the innermost
+// function and to the file
+func (p *dartParser) flushSegment() {
+	segment := p.segment.String()
+	p.segment.Reset()
+	if strings.TrimSpace(segment) == "" {
+		return
+	}
+
+	complexity := len(decisionPattern.FindAllStringIndex(segment, -1))
+	if p.skipDo && doWhilePattern.MatchString(segment) {
+		complexity--
+	}
+	p.skipDo = false
+	complexity += strings.Count(segment, "&&") + strings.Count(segment, "||")
+	complexity += strings.Count(segment, "??") + strings.Count(segment, "?.")
+
+	p.fileCost += complexity
+	if len(p.open) > 0 {
+		p.open[len(p.open)-1].info.Complexity += complexity
+	}
+}
+
+// stripLine removes comments and the contents of string literals, including
+// interpolations, so that keywords, operators and braces inside them are not
+// counted. Block comments and multi-line strings carry over to later lines.
+func (p *dartParser) stripLine(line string) string {
+	var code strings.Builder
+	for i := 0; i < len(line); i++ {
+		switch {
+		case p.blockComments > 0:
+			i = p.skipBlockComment(line, i)
+		case p.multiline != "":
+			i = p.skipMultiline(line, i)
+		case strings.HasPrefix(line[i:], "//"):
+			return code.String()
+		case strings.HasPrefix(line[i:], "/*"):
+			p.blockComments++
+			i++
+		case strings.HasPrefix(line[i:], `'''`) || strings.HasPrefix(line[i:], `"""`):
+			p.multiline = line[i : i+3]
+			code.WriteString(`""`)
+			i += 2
+		case line[i] == '\'' || line[i] == '"':
+			i = skipString(line, i)
+			code.WriteString(`""`)
+		default:
+			code.WriteByte(line[i])
+		}
+	}
+	return code.String()
+}
+
+// skipBlockComment skips the character at i inside a block comment, following
+// nested comments, and returns the index of the last character handled
+func (p *dartParser) skipBlockComment(line string, i int) int {
+	if strings.HasPrefix(line[i:], "*/") {
+		p.blockComments--
+		i++
+	} else if strings.HasPrefix(line[i:], "/*") {
+		p.blockComments++
+		i++
+	}
+	return i
+}
+
+// skipMultiline skips the character at i inside a multi-line string, ending
+// the string at its closing quotes, and returns the index of the last
+// character handled
+func (p *dartParser) skipMultiline(line string, i int) int {
+	if strings.HasPrefix(line[i:], p.multiline) {
+		p.multiline = ""
+		i += 2
+	} else if line[i] == '\\' {
+		i++
+	}
+	return i
+}
+
+// skipString returns the index of the quote closing the string that starts at
+// start, skipping escapes and ${...} interpolations unless it is a raw string
+// such as r'...'. An unterminated string ends with the line.
+func skipString(line string, start int) int {
+	quote := line[start]
+	raw := start > 0 && line[start-1] == 'r'
+	for i := start + 1; i < len(line); i++ {
+		switch {
+		case raw:
+			if line[i] == quote {
+				return i
+			}
+		case line[i] == '\\':
+			i++
+		case strings.HasPrefix(line[i:], "${"):
+			i = skipInterpolation(line, i+1)
+		case line[i] == quote:
+			return i
+		}
+	}
+	return len(line)
+}
+
+// skipInterpolation returns the index of the brace closing the one at start,
+// or the end of the line when it continues on the next one
+func skipInterpolation(line string, start int) int {
+	depth := 0
+	for i := start; i < len(line); i++ {
+		if line[i] == '{' {
+			depth++
+		} else if line[i] == '}' {
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return len(line)
+}
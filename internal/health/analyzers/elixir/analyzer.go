@@ -0,0 +1,229 @@
+package elixir_analyzer
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/codcod/repos/internal/core"
+	"github.com/codcod/repos/internal/health/analyzers/language"
+	"github.com/codcod/repos/internal/health/analyzers/parallel"
+)
+
+// appPattern matches the application name in the project of mix.exs
+var appPattern = regexp.MustCompile(`\bapp:\s*:([a-z_]\w*)`)
+
+// ElixirAnalyzer implements language-specific analysis for Elixir code
+type ElixirAnalyzer struct {
+	name         string
+	language     string
+	extensions   []string
+	excludes     []string
+	testPatterns []string
+	filesystem   core.FileSystem
+	logger       core.Logger
+}
+
+// NewElixirAnalyzer creates a new Elixir language analyzer
+func NewElixirAnalyzer(fs core.FileSystem, logger core.Logger) *ElixirAnalyzer {
+	return &ElixirAnalyzer{
+		name:         "elixir-analyzer",
+		language:     "elixir",
+		extensions:   []string{".ex", ".exs"},
+		excludes:     []string{"_build/", "deps/", ".git/", ".elixir_ls/"},
+		testPatterns: []string{"*_test.exs"},
+		filesystem:   fs,
+		logger:       logger,
+	}
+}
+
+// Name returns the analyzer name
+func (e *ElixirAnalyzer) Name() string {
+	return e.name
+}
+
+// Language returns the supported language
+func (e *ElixirAnalyzer) Language() string {
+	return e.language
+}
+
+// SupportedExtensions returns supported file extensions
+func (e *ElixirAnalyzer) SupportedExtensions() []string {
+	return e.extensions
+}
+
+// CanAnalyze checks if the analyzer can process the given repository
+func (e *ElixirAnalyzer) CanAnalyze(repo core.Repository) bool {
+	files, _, err := e.findElixirFiles(repo.FileIndex(), e.extensions, e.excludes)
+	return err == nil && len(files) > 0
+}
+
+// Analyze performs language-specific analysis on the repository
+func (e *ElixirAnalyzer) Analyze(ctx context.Context, repoPath string, config core.AnalyzerConfig) (*core.AnalysisResult, error) {
+	e.logger.Info("Starting Elixir analysis", core.Field{Key: "repo", Value: repoPath})
+
+	result := &core.AnalysisResult{
+		Language:  e.language,
+		Files:     make(map[string]*core.FileAnalysis),
+		Functions: []core.FunctionInfo{},
+		Metrics:   make(map[string]interface{}),
+	}
+
+	files, walkErrors, err := e.findElixirFiles(config.FileIndex(repoPath), config.Extensions(e.extensions), config.Excludes(e.excludes, e.testPatterns))
+	if err != nil {
+		return nil, err
+	}
+	result.Errors = append(result.Errors, walkErrors...)
+
+	// Restrict to explicitly included files (e.g. changed files only)
+	files = config.FilterFiles(files)
+
+	// Skip generated code unless it is explicitly included
+	generatedSkipped := 0
+	if !config.IncludeGenerated {
+		files, generatedSkipped = language.SkipGenerated(files)
+	}
+
+	// Modules of the application itself are local imports
+	appModules := readAppModules(filepath.Join(repoPath, "mix.exs"))
+	fileResults, err := parallel.AnalyzeFiles(ctx, files, config.Concurrency, func(filePath string) (*core.FileAnalysis, error) {
+		return e.analyzeFile(filePath, appModules)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	totalComplexity := 0
+	totalModules := 0
+	maxComplexity := 0
+	maxFunctionLines := 0
+	binarySkipped := 0
+
+	for _, fileResult := range fileResults {
+		file, fileAnalysis := fileResult.Path, fileResult.Analysis
+		if errors.Is(fileResult.Err, language.ErrBinaryFile) {
+			binarySkipped++
+			continue
+		}
+		if fileResult.Err != nil {
+			e.logger.Warn("Failed to analyze file",
+				core.Field{Key: "file", Value: file},
+				core.Field{Key: "error", Value: fileResult.Err.Error()})
+			result.Errors = append(result.Errors, core.AnalysisError{Path: file, Reason: fileResult.Err.Error()})
+			continue
+		}
+
+		result.Files[file] = fileAnalysis
+		totalModules += len(fileAnalysis.Classes)
+		for _, fn := range fileAnalysis.Functions {
+			result.Functions = append(result.Functions, fn)
+			totalComplexity += fn.Complexity
+			if fn.Complexity > maxComplexity {
+				maxComplexity = fn.Complexity
+			}
+			maxFunctionLines = max(maxFunctionLines, fn.Lines())
+		}
+	}
+
+	core.SortFunctions(result.Functions)
+
+	avgComplexity := 0.0
+	if len(result.Functions) > 0 {
+		avgComplexity = float64(totalComplexity) / float64(len(result.Functions))
+	}
+
+	result.Metrics["total_files"] = len(result.Files)
+	result.Metrics["binary_files_skipped"] = binarySkipped
+	result.Metrics["generated_files_skipped"] = generatedSkipped
+	result.Metrics["total_modules"] = totalModules
+	result.Metrics["total_functions"] = len(result.Functions)
+	result.Metrics["total_complexity"] = totalComplexity
+	result.Metrics["max_complexity"] = maxComplexity
+	result.Metrics["max_function_lines"] = maxFunctionLines
+	result.Metrics["average_complexity"] = avgComplexity
+
+	e.logger.Info("Elixir analysis completed",
+		core.Field{Key: "files", Value: len(result.Files)},
+		core.Field{Key: "functions", Value: len(result.Functions)})
+
+	return result, nil
+}
+
+// findElixirFiles finds all Elixir source and script files in the repository
+func (e *ElixirAnalyzer) findElixirFiles(index *core.FileIndex, extensions, excludes []string) ([]string, []core.AnalysisError, error) {
+	paths, walkErrors, err := index.Find(extensions)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var elixirFiles []string
+	for _, path := range paths {
+		relPath, _ := filepath.Rel(index.Root(), path)
+		relPath = filepath.ToSlash(relPath)
+		if !language.Excluded(relPath, excludes) {
+			elixirFiles = append(elixirFiles, path)
+		}
+	}
+
+	return elixirFiles, walkErrors, nil
+}
+
+// analyzeFile analyzes a single Elixir file
+func (e *ElixirAnalyzer) analyzeFile(filePath string, appModules []string) (*core.FileAnalysis, error) {
+	content, err := language.ReadText(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	parser := &elixirParser{language: e.language, filePath: filePath, appModules: appModules}
+	functions, modules, imports, fileComplexity := parser.parse(content)
+
+	analysis := &core.FileAnalysis{
+		Path:       filePath,
+		Language:   e.language,
+		Lines:      strings.Count(content, "\n"),
+		Functions:  functions,
+		Classes:    modules,
+		Imports:    imports,
+		Complexity: fileComplexity,
+		Metrics:    make(map[string]interface{}),
+	}
+
+	analysis.Metrics["function_count"] = len(functions)
+	analysis.Metrics["module_count"] = len(modules)
+	analysis.Metrics["import_count"] = len(imports)
+	if len(functions) > 0 {
+		totalComplexity := 0
+		for _, fn := range functions {
+			totalComplexity += fn.Complexity
+		}
+		analysis.Metrics["average_complexity"] = float64(totalComplexity) / float64(len(functions))
+	}
+
+	return analysis, nil
+}
+
+// readAppModules returns the top-level modules of the application named in
+// mix.exs, such as Shop and, for Phoenix, ShopWeb for app: :shop, or nil if
+// there is none
+func readAppModules(mixPath string) []string {
+	content, err := os.ReadFile(mixPath) //nolint:gosec // Path is within the repository
+	if err != nil {
+		return nil
+	}
+	match := appPattern.FindSubmatch(content)
+	if match == nil {
+		return nil
+	}
+
+	var module strings.Builder
+	for _, part := range strings.Split(string(match[1]), "_") {
+		if part != "" {
+			module.WriteString(strings.ToUpper(part[:1]) + part[1:])
+		}
+	}
+	return []string{module.String(), module.String() + "Web"}
+}
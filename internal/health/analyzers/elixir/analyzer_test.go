@@ -0,0 +1,159 @@
+package elixir_analyzer
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/codcod/repos/internal/core"
+)
+
+type testLogger struct{}
+
+func (testLogger) Debug(string, ...core.Field) {}
+func (testLogger) Info(string, ...core.Field)  {}
+func (testLogger) Warn(string, ...core.Field)  {}
+func (testLogger) Error(string, ...core.Field) {}
+func (testLogger) Fatal(string, ...core.Field) {}
+
+const cartSource = `defmodule Shop.Cart do
+  @moduledoc """
+  Cart functions; if and case in docs do not count
+  """
+  import Ecto.Query, only: [from: 2]
+  alias Shop.{Item, Coupon}
+  alias Shop.Repo, as: R
+  require Logger
+  use GenServer
+
+  def total(items, discount \\ 0)
+
+  def total([], _discount), do: 0
+
+  def total(items, discount) when is_list(items) and discount > 0 do
+    for item <- items, reduce: 0 do
+      acc -> if item.free, do: acc, else: acc + item.price
+    end
+  end
+
+  def total(items, _discount), do: Enum.sum(items)
+
+  defp label(item) do
+    case item.kind do
+      :a -> "a # not a comment"
+      :b when item.sale or item.new -> ~s(b -> end)
+      _ -> ""
+    end
+  end
+
+  def check(x) do
+    cond do
+      x > 10 && x < 20 -> :mid
+      x > 0 || x == -1 -> :low
+      true -> :none
+    end
+  end
+
+  def load(id) do
+    with {:ok, item} <- fetch(id),
+         {:ok, _} <- validate(item) do
+      Enum.map([item], fn i -> i end)
+    end
+  rescue
+    e in RuntimeError -> {:error, e}
+  end
+end
+`
+
+func TestElixirParser(t *testing.T) {
+	parser := &elixirParser{language: "elixir", filePath: "cart.ex", appModules: []string{"Shop", "ShopWeb"}}
+	functions, modules, imports, fileComplexity := parser.parse(cartSource)
+
+	expected := []struct {
+		name       string
+		line       int
+		endLine    int
+		complexity int
+	}{
+		// Three clauses with bodies; and in the guard, for and if
+		{"total", 13, 21, 6},
+		// Two clauses besides the catch-all, and or in a guard; the sigil is not code
+		{"label", 23, 29, 4},
+		// Two clauses besides true, && and ||
+		{"check", 31, 37, 5},
+		// with and rescue; the arrows of fn and rescue are not case clauses
+		{"load", 39, 46, 3},
+	}
+	if len(functions) != len(expected) {
+		t.Fatalf("Expected %d functions, got %+v", len(expected), functions)
+	}
+	for i, want := range expected {
+		got := functions[i]
+		if got.Name != want.name || got.Line != want.line || got.EndLine != want.endLine || got.Complexity != want.complexity {
+			t.Errorf("Expected %s at lines %d-%d with complexity %d, got %s at lines %d-%d with complexity %d",
+				want.name, want.line, want.endLine, want.complexity, got.Name, got.Line, got.EndLine, got.Complexity)
+		}
+	}
+
+	if len(modules) != 1 || modules[0].Name != "Shop.Cart" || modules[0].Line != 1 || len(modules[0].Methods) != 4 {
+		t.Errorf("Unexpected modules: %+v", modules)
+	}
+	if fileComplexity != 15 {
+		t.Errorf("Expected file complexity 15, got %d", fileComplexity)
+	}
+
+	expectedImports := []core.ImportInfo{
+		{Name: "Query", Path: "Ecto.Query", Line: 5},
+		{Name: "Item", Path: "Shop.Item", Line: 6, IsLocal: true},
+		{Name: "Coupon", Path: "Shop.Coupon", Line: 6, IsLocal: true},
+		{Name: "Repo", Path: "Shop.Repo", Alias: "R", Line: 7, IsLocal: true},
+		{Name: "Logger", Path: "Logger", Line: 8},
+		{Name: "GenServer", Path: "GenServer", Line: 9},
+	}
+	if !reflect.DeepEqual(imports, expectedImports) {
+		t.Errorf("Expected imports %+v, got %+v", expectedImports, imports)
+	}
+}
+
+func TestElixirAnalyzer_Analyze(t *testing.T) {
+	repoPath := t.TempDir()
+	files := map[string]string{
+		"mix.exs":                  "defmodule Shop.MixProject do\n  use Mix.Project\n\n  def project do\n    [app: :shop, version: \"0.1.0\"]\n  end\nend\n",
+		"lib/shop/cart.ex":         cartSource,
+		"lib/shop_web/router.ex":   "defmodule ShopWeb.Router do\n  use ShopWeb, :router\nend\n",
+		"test/shop/cart_test.exs":  "defmodule Shop.CartTest do\n  use ExUnit.Case\nend\n",
+		"deps/phoenix/lib/x.ex":    "defmodule Phoenix.X do\n  def x, do: 1\nend\n",
+		"_build/dev/lib/shop/y.ex": "defmodule Y do\n  def y, do: 1\nend\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(repoPath, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	analyzer := NewElixirAnalyzer(nil, testLogger{})
+	if !analyzer.CanAnalyze(core.Repository{Path: repoPath}) {
+		t.Fatal("Expected repository with Elixir files to be analyzable")
+	}
+
+	result, err := analyzer.Analyze(context.Background(), repoPath, core.AnalyzerConfig{})
+	if err != nil {
+		t.Fatalf("Analyze failed: %v", err)
+	}
+	if len(result.Files) != 3 {
+		t.Errorf("Expected mix.exs, lib/shop/cart.ex and lib/shop_web/router.ex, got %d files", len(result.Files))
+	}
+	if result.Metrics["total_functions"] != 5 || result.Metrics["total_modules"] != 3 {
+		t.Errorf("Unexpected metrics: %v", result.Metrics)
+	}
+	router := result.Files[filepath.Join(repoPath, "lib", "shop_web", "router.ex")]
+	if router == nil || len(router.Imports) != 1 || !router.Imports[0].IsLocal {
+		t.Errorf("Expected the Phoenix web module to be local, got %+v", router)
+	}
+}
@@ -0,0 +1,481 @@
+package elixir_analyzer
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/codcod/repos/internal/core"
+)
+
+var (
+	// modulePattern matches a defmodule header and the module name
+	modulePattern = regexp.MustCompile(`^\s*defmodule\s+([A-Z][\w.]*)`)
+
+	// defPattern matches a def or defp clause and the function name
+	defPattern = regexp.MustCompile(`^\s*defp?\s+([a-z_]\w*[?!]?)`)
+
+	// importPattern matches import, alias, require and use directives, with the
+	// braces of a multi-alias such as alias Shop.{Cart, Item}
+	importPattern = regexp.MustCompile(`^\s*(import|alias|require|use)\s+((?:__MODULE__|[A-Z]\w*|:[a-z_]\w*)(?:\.[A-Z]\w*)*)(?:\.\{([^}]*)\})?(.*)$`)
+
+	// asPattern matches the as: option of an alias
+	asPattern = regexp.MustCompile(`\bas:\s*([A-Z]\w*)`)
+
+	// tokenPattern matches the keywords that open or close blocks or add
+	// branches, clause arrows and boolean operators
+	tokenPattern = regexp.MustCompile(`\b(?:do|end|fn|if|unless|case|cond|for|with|rescue|catch|and|or)\b|->|&&|\|\|`)
+
+	// defaultClausePattern matches the catch-all clauses of case and cond,
+	// which do not add a branch
+	defaultClausePattern = regexp.MustCompile(`^\s*(?:_\w*|true)\s*->$`)
+)
+
+// blockKind tells what a do or fn opened
+type blockKind int
+
+const (
+	blockPlain    blockKind = iota // if, for, with, try, quote, test and other blocks
+	blockModule                    // defmodule body
+	blockFunction                  // body of a def or defp clause
+	blockClauses                   // case or cond, whose clauses are branches
+	blockFn                        // anonymous function
+)
+
+// block is an open do ... end or fn ... end block
+type block struct {
+	kind   blockKind
+	fn     *elixirFunction // Function of a clause body
+	module *core.ClassInfo // Module of a defmodule body
+}
+
+// elixirFunction is a function, whose clauses of the same name in a module
+// are grouped into one
+type elixirFunction struct {
+	info   core.FunctionInfo
+	module *core.ClassInfo
+}
+
+// functionKey identifies a function by its module and name
+type functionKey struct {
+	module *core.ClassInfo
+	name   string
+}
+
+// pendingClause is a def or defp whose do has not been reached yet
+type pendingClause struct {
+	name string
+	line int
+	cost int // Branches of the head, such as and in a guard
+}
+
+// elixirParser finds the modules, functions and imports of an Elixir file by
+// following its do ... end blocks, and attributes each decision point to the
+// innermost function clause containing it. Anonymous functions are part of
+// their enclosing function.
+type elixirParser struct {
+	language   string
+	filePath   string
+	appModules []string // Top-level modules of the project, whose imports are local
+
+	blocks    []block
+	pending   blockKind       // What the next do opens
+	module    *core.ClassInfo // Module whose do has not been reached yet
+	clause    *pendingClause
+	inline    *elixirFunction // Clause with a do: body, ending with its line
+	functions map[functionKey]*elixirFunction
+	order     []*elixirFunction
+	classList []*core.ClassInfo
+	imports   []core.ImportInfo
+	fileCost  int
+
+	closer string // Delimiter that ends the open multi-line string or sigil
+}
+
+// parse returns the functions, modules, imports and complexity of an Elixir file
+func (p *elixirParser) parse(content string) ([]core.FunctionInfo, []core.ClassInfo, []core.ImportInfo, int) {
+	p.fileCost = 1
+	p.functions = make(map[functionKey]*elixirFunction)
+
+	lines := strings.Split(content, "\n")
+	for i, line := range lines {
+		lineNum := i + 1
+		if p.closer == "" {
+			if match := importPattern.FindStringSubmatch(line); match != nil {
+				p.imports = append(p.imports, p.importInfos(match, lineNum)...)
+				continue
+			}
+		}
+		p.scanLine(p.stripLine(line), lineNum)
+	}
+
+	// Close what unbalanced blocks left open
+	for len(p.blocks) > 0 {
+		p.closeBlock(len(lines))
+	}
+
+	functions := make([]core.FunctionInfo, 0, len(p.order))
+	for _, fn := range p.order {
+		functions = append(functions, fn.info)
+		if fn.module != nil {
+			fn.module.Methods = append(fn.module.Methods, fn.info)
+		}
+	}
+	classes := make([]core.ClassInfo, 0, len(p.classList))
+	for _, class := range p.classList {
+		classes = append(classes, *class)
+	}
+	return functions, classes, p.imports, p.fileCost
+}
+
+// importInfos describes a directive, one import per module of a multi-alias.
+// Modules of the project itself and of the current module are local; Erlang
+// modules and dependencies are not.
+func (p *elixirParser) importInfos(match []string, lineNum int) []core.ImportInfo {
+	modules := []string{match[2]}
+	if match[3] != "" {
+		modules = modules[:0]
+		for _, name := range strings.Split(match[3], ",") {
+			if name = strings.TrimSpace(name); name != "" {
+				modules = append(modules, match[2]+"."+name)
+			}
+		}
+	}
+
+	alias := ""
+	if as := asPattern.FindStringSubmatch(match[4]); as != nil && match[1] == "alias" {
+		alias = as[1]
+	}
+
+	imports := make([]core.ImportInfo, 0, len(modules))
+	for _, module := range modules {
+		imports = append(imports, core.ImportInfo{
+			Name:    module[strings.LastIndex(module, ".")+1:],
+			Path:    module,
+			Alias:   alias,
+			Line:    lineNum,
+			IsLocal: p.isLocal(module),
+		})
+	}
+	return imports
+}
+
+// isLocal reports whether a module belongs to the project
+func (p *elixirParser) isLocal(module string) bool {
+	if strings.HasPrefix(module, "__MODULE__") {
+		return true
+	}
+	top, _, _ := strings.Cut(module, ".")
+	for _, appModule := range p.appModules {
+		if top == appModule {
+			return true
+		}
+	}
+	return false
+}
+
+// scanLine follows the blocks, clauses and decision points of one line of
+// comment- and literal-free code
+func (p *elixirParser) scanLine(code string, lineNum int) {
+	if match := modulePattern.FindStringSubmatch(code); match != nil {
+		p.module = &core.ClassInfo{Name: match[1], File: p.filePath, Language: p.language, Line: lineNum}
+		p.pending = blockModule
+	} else if match := defPattern.FindStringSubmatch(code); match != nil {
+		// A previous head without a do declared default arguments only
+		p.clause = &pendingClause{name: match[1], line: lineNum}
+		p.pending = blockFunction
+	}
+
+	for _, loc := range tokenPattern.FindAllStringIndex(code, -1) {
+		p.scanToken(code, loc, lineNum)
+	}
+
+	if p.inline != nil {
+		p.inline.info.EndLine = max(p.inline.info.EndLine, lineNum)
+		p.inline = nil
+	}
+}
+
+// scanToken handles the keyword or operator of code at loc
+func (p *elixirParser) scanToken(code string, loc []int, lineNum int) {
+	token := code[loc[0]:loc[1]]
+	before, after := code[:loc[0]], code[loc[1]:]
+	if isFieldOrAtom(before) {
+		return // A field access or an atom such as :end
+	}
+	if isKeywordKey(after) {
+		if token == "do" {
+			p.openInline(lineNum)
+		}
+		return // A keyword such as else: or if:
+	}
+
+	switch token {
+	case "do":
+		p.openBlock(lineNum)
+	case "fn":
+		p.blocks = append(p.blocks, block{kind: blockFn})
+	case "end":
+		p.closeBlock(lineNum)
+		// A head without a do before it, as in a protocol, has no body
+		p.pending, p.clause, p.module = blockPlain, nil, nil
+	case "case", "cond":
+		p.pending = blockClauses
+	case "->":
+		if p.isBranchClause(code[:loc[1]]) {
+			p.addCost(1)
+		}
+	default:
+		// if, unless, for, with, rescue, catch and boolean operators
+		p.addCost(1)
+	}
+}
+
+// isFieldOrAtom reports whether a token preceded by before is a field access or an atom
+func isFieldOrAtom(before string) bool {
+	return strings.HasSuffix(before, ".") || (strings.HasSuffix(before, ":") && !strings.HasSuffix(before, "::"))
+}
+
+// isKeywordKey reports whether a token followed by after is a keyword key such as else:
+func isKeywordKey(after string) bool {
+	return strings.HasPrefix(after, ":") && !strings.HasPrefix(after, "::")
+}
+
+// isBranchClause reports whether the -> ending code starts a clause of a case
+// or cond other than the default one
+func (p *elixirParser) isBranchClause(code string) bool {
+	n := len(p.blocks)
+	return n > 0 && p.blocks[n-1].kind == blockClauses && !defaultClausePattern.MatchString(code)
+}
+
+// openBlock pushes the block a do opens, as announced by the code before it
+func (p *elixirParser) openBlock(lineNum int) {
+	kind := p.pending
+	p.pending = blockPlain
+
+	b := block{kind: kind}
+	switch {
+	case kind == blockModule && p.module != nil:
+		b.module = p.module
+		p.classList = append(p.classList, p.module)
+		p.module = nil
+	case kind == blockFunction && p.clause != nil:
+		b.fn = p.startClause(lineNum)
+	case kind == blockModule || kind == blockFunction:
+		b.kind = blockPlain
+	}
+	p.blocks = append(p.blocks, b)
+}
+
+// openInline starts the clause of a def with a do: body
+func (p *elixirParser) openInline(lineNum int) {
+	if p.pending == blockFunction && p.clause != nil {
+		p.inline = p.startClause(lineNum)
+	}
+	p.pending = blockPlain
+}
+
+// closeBlock pops the block an end closes, ending the clause it is the body of
+func (p *elixirParser) closeBlock(lineNum int) {
+	n := len(p.blocks)
+	if n == 0 {
+		return
+	}
+	if fn := p.blocks[n-1].fn; fn != nil {
+		fn.info.EndLine = max(fn.info.EndLine, lineNum)
+	}
+	p.blocks = p.blocks[:n-1]
+}
+
+// startClause adds the pending clause to its function. Each clause after the
+// first is another branch of the function.
+func (p *elixirParser) startClause(lineNum int) *elixirFunction {
+	clause := p.clause
+	p.clause = nil
+
+	module := p.enclosingModule()
+	key := functionKey{module: module, name: clause.name}
+	fn, ok := p.functions[key]
+	if ok {
+		fn.info.Complexity++
+		p.fileCost++
+	} else {
+		fn = &elixirFunction{
+			info: core.FunctionInfo{
+				Name:       clause.name,
+				File:       p.filePath,
+				Language:   p.language,
+				Line:       clause.line,
+				EndLine:    lineNum,
+				Complexity: 1,
+			},
+			module: module,
+		}
+		p.functions[key] = fn
+		p.order = append(p.order, fn)
+	}
+	fn.info.Complexity += clause.cost
+	return fn
+}
+
+// addCost adds decision points to the innermost function and to the file
+func (p *elixirParser) addCost(cost int) {
+	p.fileCost += cost
+	switch {
+	case p.inline != nil:
+		p.inline.info.Complexity += cost
+	case p.clause != nil && p.pending == blockFunction:
+		p.clause.cost += cost
+	default:
+		if fn := p.enclosingFunction(); fn != nil {
+			fn.info.Complexity += cost
+		}
+	}
+}
+
+// enclosingFunction returns the function of the innermost open clause body
+func (p *elixirParser) enclosingFunction() *elixirFunction {
+	for i := len(p.blocks) - 1; i >= 0; i-- {
+		if p.blocks[i].fn != nil {
+			return p.blocks[i].fn
+		}
+	}
+	return nil
+}
+
+// enclosingModule returns the innermost open module
+func (p *elixirParser) enclosingModule() *core.ClassInfo {
+	for i := len(p.blocks) - 1; i >= 0; i-- {
+		if p.blocks[i].module != nil {
+			return p.blocks[i].module
+		}
+	}
+	return nil
+}
+
+// sigilClosers maps the opening delimiter of a sigil to its closing one
+var sigilClosers = map[byte]string{
+	'(': ")", '[': "]", '{': "}", '<': ">", '/': "/", '|': "|", '"': `"`, '\'': "'",
+}
+
+// stripLine removes comments and the contents of strings, charlists, sigils
+// and character literals, so that keywords and operators inside them are not
+// counted. Heredocs and other strings spanning lines carry over to later lines.
+func (p *elixirParser) stripLine(line string) string {
+	var code strings.Builder
+	for i := 0; i < len(line); i++ {
+		switch {
+		case p.closer != "":
+			i = p.skipStringContent(line, i, &code)
+		case line[i] == '#':
+			return code.String()
+		case line[i] == '"' || line[i] == '\'':
+			i = p.startString(line, i)
+		case isSigilStart(line, i):
+			i = p.startSigil(line, i, &code)
+		case isCharLiteral(line, i):
+			i = skipCharLiteral(line, i)
+			code.WriteString("0")
+		default:
+			code.WriteByte(line[i])
+		}
+	}
+	return code.String()
+}
+
+// skipStringContent skips the character at i inside a string, ending the
+// string at its closer, and returns the index of the last character handled
+func (p *elixirParser) skipStringContent(line string, i int, code *strings.Builder) int {
+	switch {
+	case strings.HasPrefix(line[i:], p.closer):
+		i += len(p.closer) - 1
+		p.closer = ""
+		code.WriteString(`""`)
+	case line[i] == '\\':
+		i++
+	case strings.HasPrefix(line[i:], "#{"):
+		i = skipInterpolation(line, i)
+	}
+	return i
+}
+
+// startString starts the string, charlist or heredoc whose quote is at i and
+// returns the index of its last opening quote
+func (p *elixirParser) startString(line string, i int) int {
+	if strings.HasPrefix(line[i:], `"""`) || strings.HasPrefix(line[i:], `'''`) {
+		p.closer = line[i : i+3]
+		return i + 2
+	}
+	p.closer = line[i : i+1]
+	return i
+}
+
+// isSigilStart reports whether a sigil such as ~r/.../ starts at i
+func isSigilStart(line string, i int) bool {
+	return line[i] == '~' && i+2 < len(line) && isLetter(line[i+1])
+}
+
+// startSigil starts the sigil at i and returns the index of its opening
+// delimiter. A ~ not followed by a delimiter is kept as code.
+func (p *elixirParser) startSigil(line string, i int, code *strings.Builder) int {
+	j := i + 1
+	for j < len(line) && isLetter(line[j]) {
+		j++
+	}
+	if j >= len(line) {
+		code.WriteString(line[i:])
+		return len(line)
+	}
+	if strings.HasPrefix(line[j:], `"""`) || strings.HasPrefix(line[j:], `'''`) {
+		p.closer = line[j : j+3]
+		return j + 2
+	}
+	if closer, ok := sigilClosers[line[j]]; ok {
+		p.closer = closer
+		return j
+	}
+	code.WriteByte(line[i])
+	return i
+}
+
+// isCharLiteral reports whether a character literal such as ?# or ?\n starts at i
+func isCharLiteral(line string, i int) bool {
+	return line[i] == '?' && i+1 < len(line) && (i == 0 || !isWordChar(line[i-1]))
+}
+
+// skipCharLiteral returns the index of the last character of the character
+// literal at i
+func skipCharLiteral(line string, i int) int {
+	i++
+	if line[i] == '\\' && i+1 < len(line) {
+		i++
+	}
+	return i
+}
+
+// skipInterpolation returns the index of the brace closing the #{ at start,
+// or the end of the line when it continues on the next one
+func skipInterpolation(line string, start int) int {
+	depth := 0
+	for i := start + 1; i < len(line); i++ {
+		switch line[i] {
+		case '{':
+			depth++
+		case '}':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return len(line)
+}
+
+// isLetter reports whether ch is an ASCII letter
+func isLetter(ch byte) bool {
+	return ch >= 'a' && ch <= 'z' || ch >= 'A' && ch <= 'Z'
+}
+
+// isWordChar reports whether ch can be part of an identifier
+func isWordChar(ch byte) bool {
+	return isLetter(ch) || ch >= '0' && ch <= '9' || ch == '_' || ch == '?' || ch == '!' || ch == ')' || ch == ']' || ch == '}'
+}
@@ -2,35 +2,40 @@ package go_analyzer
 
 import (
 	"context"
+	"errors"
 	"go/ast"
 	"go/parser"
 	"go/token"
-	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 
 	"github.com/codcod/repos/internal/core"
+	"github.com/codcod/repos/internal/health/analyzers/language"
+	"github.com/codcod/repos/internal/health/analyzers/parallel"
 )
 
 // GoAnalyzer implements language-specific analysis for Go code
 type GoAnalyzer struct {
-	name       string
-	language   string
-	extensions []string
-	excludes   []string
-	filesystem core.FileSystem
-	logger     core.Logger
+	name         string
+	language     string
+	extensions   []string
+	excludes     []string
+	testPatterns []string
+	filesystem   core.FileSystem
+	logger       core.Logger
 }
 
 // NewGoAnalyzer creates a new Go language analyzer
 func NewGoAnalyzer(fs core.FileSystem, logger core.Logger) *GoAnalyzer {
 	return &GoAnalyzer{
-		name:       "go-analyzer",
-		language:   "go",
-		extensions: []string{".go"},
-		excludes:   []string{"vendor/", "_test.go", ".git/"},
-		filesystem: fs,
-		logger:     logger,
+		name:         "go-analyzer",
+		language:     "go",
+		extensions:   []string{".go"},
+		excludes:     []string{"vendor/", ".git/"},
+		testPatterns: []string{"*_test.go"},
+		filesystem:   fs,
+		logger:       logger,
 	}
 }
 
@@ -52,7 +57,7 @@ func (g *GoAnalyzer) SupportedExtensions() []string {
 // CanAnalyze checks if the analyzer can process the given repository
 func (g *GoAnalyzer) CanAnalyze(repo core.Repository) bool {
 	// Check if repository has Go files
-	return g.hasGoFiles(repo.Path)
+	return g.hasGoFiles(repo.FileIndex())
 }
 
 // Analyze performs language-specific analysis on the repository
@@ -67,26 +72,44 @@ func (g *GoAnalyzer) Analyze(ctx context.Context, repoPath string, config core.A
 	}
 
 	// Find Go files
-	files, err := g.findGoFiles(repoPath)
+	files, walkErrors, err := g.findGoFiles(config.FileIndex(repoPath), config.Extensions(g.extensions), config.Excludes(g.excludes, g.testPatterns))
 	if err != nil {
 		return nil, err
 	}
+	result.Errors = append(result.Errors, walkErrors...)
+
+	// Restrict to explicitly included files (e.g. changed files only)
+	files = config.FilterFiles(files)
+
+	// Skip generated code unless it is explicitly included
+	generatedSkipped := 0
+	if !config.IncludeGenerated {
+		files, generatedSkipped = language.SkipGenerated(files)
+	}
 
 	totalComplexity := 0
 	totalFunctions := 0
 	maxComplexity := 0
+	maxFunctionLines := 0
+	binarySkipped := 0
 
-	// Analyze each file
-	for _, file := range files {
-		if ctx.Err() != nil {
-			return nil, ctx.Err()
-		}
+	// Analyze files on a bounded worker pool; results come back in file order
+	fileResults, err := parallel.AnalyzeFiles(ctx, files, config.Concurrency, g.analyzeFile)
+	if err != nil {
+		return nil, err
+	}
 
-		fileAnalysis, err := g.analyzeFile(file)
-		if err != nil {
+	for _, fileResult := range fileResults {
+		file, fileAnalysis := fileResult.Path, fileResult.Analysis
+		if errors.Is(fileResult.Err, language.ErrBinaryFile) {
+			binarySkipped++
+			continue
+		}
+		if fileResult.Err != nil {
 			g.logger.Warn("Failed to analyze file",
 				core.Field{Key: "file", Value: file},
-				core.Field{Key: "error", Value: err.Error()})
+				core.Field{Key: "error", Value: fileResult.Err.Error()})
+			result.Errors = append(result.Errors, core.AnalysisError{Path: file, Reason: fileResult.Err.Error()})
 			continue
 		}
 
@@ -97,12 +120,14 @@ func (g *GoAnalyzer) Analyze(ctx context.Context, repoPath string, config core.A
 			result.Functions = append(result.Functions, fn)
 			totalFunctions++
 			totalComplexity += fn.Complexity
-			if fn.Complexity > maxComplexity {
-				maxComplexity = fn.Complexity
-			}
+			maxComplexity = max(maxComplexity, fn.Complexity)
+			maxFunctionLines = max(maxFunctionLines, fn.Lines())
 		}
 	}
 
+	// Order functions by file and line so output does not depend on scheduling
+	core.SortFunctions(result.Functions)
+
 	// Calculate metrics
 	avgComplexity := 0.0
 	if totalFunctions > 0 {
@@ -110,11 +135,19 @@ func (g *GoAnalyzer) Analyze(ctx context.Context, repoPath string, config core.A
 	}
 
 	result.Metrics["total_files"] = len(result.Files)
+	result.Metrics["binary_files_skipped"] = binarySkipped
+	result.Metrics["generated_files_skipped"] = generatedSkipped
 	result.Metrics["total_functions"] = totalFunctions
 	result.Metrics["total_complexity"] = totalComplexity
 	result.Metrics["max_complexity"] = maxComplexity
+	result.Metrics["max_function_lines"] = maxFunctionLines
 	result.Metrics["average_complexity"] = avgComplexity
 
+	// Import cycle detection is opt-in since it needs a go.mod at the repository root
+	if detect, _ := config.Options["detect_import_cycles"].(bool); detect {
+		g.detectImportCycles(repoPath, result)
+	}
+
 	g.logger.Info("Go analysis completed",
 		core.Field{Key: "files", Value: len(result.Files)},
 		core.Field{Key: "functions", Value: totalFunctions})
@@ -122,51 +155,60 @@ func (g *GoAnalyzer) Analyze(ctx context.Context, repoPath string, config core.A
 	return result, nil
 }
 
-// hasGoFiles checks if the repository contains Go files
-func (g *GoAnalyzer) hasGoFiles(repoPath string) bool {
-	files, err := g.findGoFiles(repoPath)
+// detectImportCycles records the module's package import cycles in the result metrics
+func (g *GoAnalyzer) detectImportCycles(repoPath string, result *core.AnalysisResult) {
+	graph, err := BuildImportGraph(repoPath)
+	if err != nil {
+		g.logger.Warn("Failed to build import graph",
+			core.Field{Key: "path", Value: repoPath},
+			core.Field{Key: "error", Value: err.Error()})
+		return
+	}
+
+	cycles := graph.FindImportCycles()
+	paths := make([]string, 0, len(cycles))
+	for _, cycle := range cycles {
+		paths = append(paths, strings.Join(cycle, " -> "))
+	}
+	result.Metrics["import_cycles"] = len(cycles)
+	result.Metrics["import_cycle_paths"] = paths
+}
+
+// hasGoFiles checks if the repository contains Go files other than tests
+func (g *GoAnalyzer) hasGoFiles(index *core.FileIndex) bool {
+	files, _, err := g.findGoFiles(index, g.extensions, slices.Concat(g.excludes, g.testPatterns))
 	return err == nil && len(files) > 0
 }
 
 // findGoFiles finds all Go source files in the repository
-func (g *GoAnalyzer) findGoFiles(repoPath string) ([]string, error) {
-	var goFiles []string
-
-	err := filepath.Walk(repoPath, func(path string, info os.FileInfo, err error) error {
-		if err != nil {
-			return err
-		}
-
-		// Skip directories
-		if info.IsDir() {
-			return nil
-		}
-
-		// Check if it's a Go file
-		if !strings.HasSuffix(path, ".go") {
-			return nil
-		}
+func (g *GoAnalyzer) findGoFiles(index *core.FileIndex, extensions, excludes []string) ([]string, []core.AnalysisError, error) {
+	paths, walkErrors, err := index.Find(extensions)
+	if err != nil {
+		return nil, nil, err
+	}
 
+	var goFiles []string
+	for _, path := range paths {
 		// Skip excluded patterns
-		relPath, _ := filepath.Rel(repoPath, path)
-		for _, exclude := range g.excludes {
-			if strings.Contains(relPath, exclude) {
-				return nil
-			}
+		relPath, _ := filepath.Rel(index.Root(), path)
+		if !language.Excluded(relPath, excludes) {
+			goFiles = append(goFiles, path)
 		}
+	}
 
-		goFiles = append(goFiles, path)
-		return nil
-	})
-
-	return goFiles, err
+	return goFiles, walkErrors, nil
 }
 
 // analyzeFile analyzes a single Go file
 func (g *GoAnalyzer) analyzeFile(filePath string) (*core.FileAnalysis, error) {
+	content, err := language.ReadText(filePath)
+	if err != nil {
+		return nil, err
+	}
+
 	// Parse the Go file
 	fset := token.NewFileSet()
-	node, err := parser.ParseFile(fset, filePath, nil, parser.ParseComments)
+	node, err := parser.ParseFile(fset, filePath, content, parser.ParseComments)
 	if err != nil {
 		return nil, err
 	}
@@ -211,6 +253,7 @@ func (g *GoAnalyzer) analyzeFunctionDecl(fn *ast.FuncDecl, fset *token.FileSet)
 		Name:       fn.Name.Name,
 		File:       pos.Filename,
 		Line:       pos.Line,
+		EndLine:    fset.Position(fn.End()).Line,
 		Complexity: 1, // Base complexity
 		Language:   g.language,
 	}
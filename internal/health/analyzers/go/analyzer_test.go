@@ -2,8 +2,10 @@ package go_analyzer
 
 import (
 	"context"
+	"fmt"
 	"os"
 	"path/filepath"
+	"runtime"
 	"testing"
 
 	"github.com/codcod/repos/internal/core"
@@ -228,6 +230,9 @@ func complexFunc(x int) int {
 		if _, exists := expectedFunctions[fn.Name]; exists {
 			expectedFunctions[fn.Name] = true
 		}
+		if fn.Name == "complexFunc" && (fn.Line != 16 || fn.Lines() != 15) {
+			t.Errorf("Expected complexFunc to span lines 16-30, got %d-%d", fn.Line, fn.EndLine)
+		}
 	}
 
 	for name, found := range expectedFunctions {
@@ -245,6 +250,10 @@ func complexFunc(x int) int {
 		t.Errorf("Expected total_functions to be 3, got %v", result.Metrics["total_functions"])
 	}
 
+	if result.Metrics["max_function_lines"] != 15 {
+		t.Errorf("Expected max_function_lines to be 15, got %v", result.Metrics["max_function_lines"])
+	}
+
 	// Check that complexity was calculated
 	if result.Metrics["total_complexity"] == nil {
 		t.Error("Expected total_complexity to be set")
@@ -450,3 +459,273 @@ func VendorFunc() {}`), 0644)
 		t.Error("Expected vendor file to be excluded")
 	}
 }
+
+func TestGoAnalyzer_ConfiguredExcludePatterns(t *testing.T) {
+	analyzer := NewGoAnalyzer(filesystem.NewOSFileSystem(), &MockLogger{})
+
+	tempDir := t.TempDir()
+	files := map[string]string{
+		"main.go":             "package main\nfunc main() {}\n",
+		"api/service.pb.go":   "package api\nfunc Generated() {}\n",
+		"internal/mocks/m.go": "package mocks\nfunc Mock() {}\n",
+		"vendor/dep/dep.go":   "package dep\nfunc Dep() {}\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(tempDir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Configured patterns are applied on top of the defaults, which still exclude vendor/
+	result, err := analyzer.Analyze(context.Background(), tempDir, core.AnalyzerConfig{
+		ExcludePatterns: []string{"*.pb.go", "internal/mocks/"},
+	})
+	if err != nil {
+		t.Fatalf("Analyze failed: %v", err)
+	}
+	if len(result.Files) != 1 {
+		t.Errorf("Expected only main.go to be analyzed, got %d files", len(result.Files))
+	}
+	if _, exists := result.Files[filepath.Join(tempDir, "main.go")]; !exists {
+		t.Error("Expected main.go to be analyzed")
+	}
+}
+
+func TestGoAnalyzer_IncludeTests(t *testing.T) {
+	analyzer := NewGoAnalyzer(filesystem.NewOSFileSystem(), &MockLogger{})
+
+	tempDir := t.TempDir()
+	files := map[string]string{
+		"main.go":      "package main\nfunc main() {}\n",
+		"main_test.go": "package main\nfunc TestMain() {}\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(tempDir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for includeTests, expected := range map[bool]int{false: 1, true: 2} {
+		result, err := analyzer.Analyze(context.Background(), tempDir, core.AnalyzerConfig{IncludeTests: includeTests})
+		if err != nil {
+			t.Fatalf("Analyze failed: %v", err)
+		}
+		if len(result.Files) != expected {
+			t.Errorf("Expected %d files with include_tests %t, got %d", expected, includeTests, len(result.Files))
+		}
+	}
+}
+
+func TestGoAnalyzer_SkipsGeneratedFiles(t *testing.T) {
+	analyzer := NewGoAnalyzer(filesystem.NewOSFileSystem(), &MockLogger{})
+
+	tempDir := t.TempDir()
+	files := map[string]string{
+		"main.go":   "package main\nfunc main() {}\n",
+		"api.pb.go": "// Code generated by protoc-gen-go. DO NOT EDIT.\n\npackage main\nfunc Generated() {}\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(tempDir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for includeGenerated, expected := range map[bool]int{false: 1, true: 2} {
+		result, err := analyzer.Analyze(context.Background(), tempDir, core.AnalyzerConfig{IncludeGenerated: includeGenerated})
+		if err != nil {
+			t.Fatalf("Analyze failed: %v", err)
+		}
+		if len(result.Files) != expected {
+			t.Errorf("Expected %d files with include_generated %t, got %d", expected, includeGenerated, len(result.Files))
+		}
+		if skipped := result.Metrics["generated_files_skipped"]; skipped != 2-expected {
+			t.Errorf("Expected %d generated files skipped, got %v", 2-expected, skipped)
+		}
+	}
+}
+
+func TestGoAnalyzer_CollectsFileErrors(t *testing.T) {
+	logger := &MockLogger{}
+	fs := filesystem.NewOSFileSystem()
+	analyzer := NewGoAnalyzer(fs, logger)
+
+	// Create a temporary directory
+	tempDir, err := os.MkdirTemp("", "go-analyzer-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	// Create a valid Go file
+	goFile := filepath.Join(tempDir, "main.go")
+	err = os.WriteFile(goFile, []byte(`package main
+func main() {}`), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Create a Go file that cannot be parsed
+	brokenFile := filepath.Join(tempDir, "broken.go")
+	err = os.WriteFile(brokenFile, []byte(`package main
+func broken( {`), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := analyzer.Analyze(context.Background(), tempDir, core.AnalyzerConfig{})
+	if err != nil {
+		t.Fatalf("Analyze should succeed on partial failures, got %v", err)
+	}
+
+	if len(result.Files) != 1 {
+		t.Errorf("Expected 1 analyzed file, got %d", len(result.Files))
+	}
+
+	if len(result.Errors) != 1 {
+		t.Fatalf("Expected 1 analysis error, got %d", len(result.Errors))
+	}
+
+	if result.Errors[0].Path != brokenFile {
+		t.Errorf("Expected error for %s, got %s", brokenFile, result.Errors[0].Path)
+	}
+
+	if result.Errors[0].Reason == "" {
+		t.Error("Expected error reason to be set")
+	}
+}
+
+func TestGoAnalyzer_IncludeFiles(t *testing.T) {
+	logger := &MockLogger{}
+	fs := filesystem.NewOSFileSystem()
+	analyzer := NewGoAnalyzer(fs, logger)
+
+	tempDir := t.TempDir()
+
+	changedFile := filepath.Join(tempDir, "changed.go")
+	if err := os.WriteFile(changedFile, []byte(`package main
+func changed() {}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	unchangedFile := filepath.Join(tempDir, "unchanged.go")
+	if err := os.WriteFile(unchangedFile, []byte(`package main
+func unchanged() {}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+
+	// Only the included file should be analyzed
+	result, err := analyzer.Analyze(ctx, tempDir, core.AnalyzerConfig{IncludeFiles: []string{changedFile}})
+	if err != nil {
+		t.Fatalf("Analyze failed: %v", err)
+	}
+	if len(result.Files) != 1 {
+		t.Fatalf("Expected 1 file, got %d", len(result.Files))
+	}
+	if _, exists := result.Files[changedFile]; !exists {
+		t.Error("Expected changed.go to be analyzed")
+	}
+
+	// An empty include list means nothing changed, so nothing is analyzed
+	result, err = analyzer.Analyze(ctx, tempDir, core.AnalyzerConfig{IncludeFiles: []string{}})
+	if err != nil {
+		t.Fatalf("Analyze failed: %v", err)
+	}
+	if len(result.Files) != 0 {
+		t.Errorf("Expected no files, got %d", len(result.Files))
+	}
+}
+
+func TestGoAnalyzer_ParallelResultsAreOrdered(t *testing.T) {
+	analyzer := NewGoAnalyzer(filesystem.NewOSFileSystem(), &MockLogger{})
+	tempDir := writeSyntheticRepo(t, 50)
+
+	sequential, err := analyzer.Analyze(context.Background(), tempDir, core.AnalyzerConfig{Concurrency: 1})
+	if err != nil {
+		t.Fatalf("Sequential analysis failed: %v", err)
+	}
+	concurrent, err := analyzer.Analyze(context.Background(), tempDir, core.AnalyzerConfig{Concurrency: 8})
+	if err != nil {
+		t.Fatalf("Parallel analysis failed: %v", err)
+	}
+
+	if len(concurrent.Functions) != len(sequential.Functions) {
+		t.Fatalf("Expected %d functions, got %d", len(sequential.Functions), len(concurrent.Functions))
+	}
+	for i := range sequential.Functions {
+		if concurrent.Functions[i] != sequential.Functions[i] {
+			t.Fatalf("Function %d differs: %+v vs %+v", i, concurrent.Functions[i], sequential.Functions[i])
+		}
+	}
+}
+
+// writeSyntheticRepo creates a repository with the given number of Go files
+func writeSyntheticRepo(tb testing.TB, files int) string {
+	tb.Helper()
+	dir := tb.TempDir()
+	for i := 0; i < files; i++ {
+		content := fmt.Sprintf(`package pkg%d
+
+func Process%d(items []int) int {
+	total := 0
+	for _, item := range items {
+		if item%%2 == 0 && item > 10 {
+			total += item
+		} else if item < 0 {
+			total--
+		}
+	}
+	return total
+}
+
+func Classify%d(x int) string {
+	switch {
+	case x > 100:
+		return "large"
+	case x > 10:
+		return "medium"
+	default:
+		return "small"
+	}
+}
+`, i, i, i)
+		pkgDir := filepath.Join(dir, fmt.Sprintf("pkg%d", i))
+		if err := os.MkdirAll(pkgDir, 0755); err != nil {
+			tb.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(pkgDir, "file.go"), []byte(content), 0644); err != nil {
+			tb.Fatal(err)
+		}
+	}
+	return dir
+}
+
+// BenchmarkGoAnalyzer_Analyze compares sequential and parallel analysis of a 1k-file repository
+func BenchmarkGoAnalyzer_Analyze(b *testing.B) {
+	analyzer := NewGoAnalyzer(filesystem.NewOSFileSystem(), &MockLogger{})
+	repoPath := writeSyntheticRepo(b, 1000)
+
+	benchmarks := []struct {
+		name    string
+		workers int
+	}{
+		{"sequential", 1},
+		{fmt.Sprintf("parallel-%d", runtime.GOMAXPROCS(0)), 0},
+	}
+
+	for _, bm := range benchmarks {
+		b.Run(bm.name, func(b *testing.B) {
+			config := core.AnalyzerConfig{Concurrency: bm.workers}
+			for i := 0; i < b.N; i++ {
+				if _, err := analyzer.Analyze(context.Background(), repoPath, config); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
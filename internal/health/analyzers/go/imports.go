@@ -0,0 +1,233 @@
+package go_analyzer
+
+import (
+	"bufio"
+	"fmt"
+	"go/parser"
+	"go/token"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ImportGraph maps each package import path in a module to the module packages it imports
+type ImportGraph map[string][]string
+
+// BuildImportGraph parses the import blocks of every non-test Go file in the module
+// rooted at repoPath. Only imports of packages within the module are recorded,
+// since a cycle cannot leave the module.
+func BuildImportGraph(repoPath string) (ImportGraph, error) {
+	modulePath, err := readModulePath(filepath.Join(repoPath, "go.mod"))
+	if err != nil {
+		return nil, err
+	}
+
+	imports := make(map[string]map[string]bool)
+	fset := token.NewFileSet()
+
+	err = walkModuleSources(repoPath, func(filePath string) {
+		file, err := parser.ParseFile(fset, filePath, nil, parser.ImportsOnly)
+		if err != nil {
+			return // Unparsable files are reported by the complexity analysis
+		}
+
+		pkg := packageImportPath(modulePath, repoPath, filePath)
+		if imports[pkg] == nil {
+			imports[pkg] = make(map[string]bool)
+		}
+
+		for _, spec := range file.Imports {
+			importPath, err := strconv.Unquote(spec.Path.Value)
+			if err != nil {
+				continue
+			}
+			if inModule(importPath, modulePath) {
+				imports[pkg][importPath] = true
+			}
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	graph := make(ImportGraph, len(imports))
+	for pkg, deps := range imports {
+		graph[pkg] = make([]string, 0, len(deps))
+		for dep := range deps {
+			graph[pkg] = append(graph[pkg], dep)
+		}
+		sort.Strings(graph[pkg])
+	}
+	return graph, nil
+}
+
+// walkModuleSources calls fn for every non-test Go file in the module, skipping
+// vendor, testdata and hidden or underscore-prefixed directories like the go tool does
+func walkModuleSources(repoPath string, fn func(filePath string)) error {
+	return filepath.Walk(repoPath, func(filePath string, info os.FileInfo, err error) error {
+		if err != nil {
+			return nil // Skip unreadable entries
+		}
+
+		name := info.Name()
+		if info.IsDir() {
+			if filePath != repoPath && (name == "vendor" || name == "testdata" ||
+				strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_")) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if strings.HasSuffix(name, ".go") && !strings.HasSuffix(name, "_test.go") {
+			fn(filePath)
+		}
+		return nil
+	})
+}
+
+// packageImportPath returns the import path of the package containing filePath
+func packageImportPath(modulePath, repoPath, filePath string) string {
+	relDir, _ := filepath.Rel(repoPath, filepath.Dir(filePath))
+	if relDir == "." {
+		return modulePath
+	}
+	return path.Join(modulePath, filepath.ToSlash(relDir))
+}
+
+// inModule reports whether importPath names a package of the module
+func inModule(importPath, modulePath string) bool {
+	return importPath == modulePath || strings.HasPrefix(importPath, modulePath+"/")
+}
+
+// FindImportCycles returns one import cycle per strongly connected group of packages.
+// Each cycle starts and ends with the same package, e.g. [a b c a].
+func (g ImportGraph) FindImportCycles() [][]string {
+	packages := make([]string, 0, len(g))
+	for pkg := range g {
+		packages = append(packages, pkg)
+	}
+	sort.Strings(packages)
+
+	var cycles [][]string
+	for _, component := range g.stronglyConnectedComponents(packages) {
+		if len(component) < 2 {
+			continue
+		}
+		if cycle := g.cycleWithin(component); cycle != nil {
+			cycles = append(cycles, cycle)
+		}
+	}
+	return cycles
+}
+
+// stronglyConnectedComponents implements Tarjan's algorithm
+func (g ImportGraph) stronglyConnectedComponents(packages []string) [][]string {
+	index := 0
+	indices := make(map[string]int)
+	lowLinks := make(map[string]int)
+	onStack := make(map[string]bool)
+	var stack []string
+	var components [][]string
+
+	var visit func(pkg string)
+	visit = func(pkg string) {
+		indices[pkg] = index
+		lowLinks[pkg] = index
+		index++
+		stack = append(stack, pkg)
+		onStack[pkg] = true
+
+		for _, dep := range g[pkg] {
+			if _, visited := indices[dep]; !visited {
+				visit(dep)
+				lowLinks[pkg] = min(lowLinks[pkg], lowLinks[dep])
+			} else if onStack[dep] {
+				lowLinks[pkg] = min(lowLinks[pkg], indices[dep])
+			}
+		}
+
+		if lowLinks[pkg] == indices[pkg] {
+			var component []string
+			for {
+				top := stack[len(stack)-1]
+				stack = stack[:len(stack)-1]
+				onStack[top] = false
+				component = append(component, top)
+				if top == pkg {
+					break
+				}
+			}
+			sort.Strings(component)
+			components = append(components, component)
+		}
+	}
+
+	for _, pkg := range packages {
+		if _, visited := indices[pkg]; !visited {
+			visit(pkg)
+		}
+	}
+	return components
+}
+
+// cycleWithin finds the shortest cycle through the first package of a strongly connected component
+func (g ImportGraph) cycleWithin(component []string) []string {
+	members := make(map[string]bool, len(component))
+	for _, pkg := range component {
+		members[pkg] = true
+	}
+
+	start := component[0]
+	parent := map[string]string{start: ""}
+	queue := []string{start}
+	for len(queue) > 0 {
+		pkg := queue[0]
+		queue = queue[1:]
+
+		for _, dep := range g[pkg] {
+			if dep == start {
+				// Walk back to the start to build the cycle
+				cycle := []string{start}
+				for node := pkg; node != start; node = parent[node] {
+					cycle = append(cycle, node)
+				}
+				cycle = append(cycle, start)
+				for i, j := 1, len(cycle)-2; i < j; i, j = i+1, j-1 {
+					cycle[i], cycle[j] = cycle[j], cycle[i]
+				}
+				return cycle
+			}
+			if _, seen := parent[dep]; !seen && members[dep] {
+				parent[dep] = pkg
+				queue = append(queue, dep)
+			}
+		}
+	}
+	return nil
+}
+
+// readModulePath returns the module path declared in a go.mod file
+func readModulePath(goModPath string) (string, error) {
+	file, err := os.Open(goModPath) //nolint:gosec // Path is within the repository
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = file.Close() }()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(line, "module") {
+			modulePath := strings.Trim(strings.TrimSpace(strings.TrimPrefix(line, "module")), `"`)
+			if modulePath != "" {
+				return modulePath, nil
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return "", err
+	}
+	return "", fmt.Errorf("no module directive in %s", goModPath)
+}
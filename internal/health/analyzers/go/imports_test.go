@@ -0,0 +1,59 @@
+package go_analyzer
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func writeModule(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestImportGraph_FindImportCycles(t *testing.T) {
+	repoPath := writeModule(t, map[string]string{
+		"go.mod":      "module example.com/app\n\ngo 1.24\n",
+		"main.go":     "package main\n\nimport (\n\t\"fmt\"\n\t\"example.com/app/a\"\n)\n\nfunc main() { fmt.Println(a.X) }\n",
+		"a/a.go":      "package a\n\nimport \"example.com/app/b\"\n\nvar X = b.Y\n",
+		"b/b.go":      "package b\n\nimport \"example.com/app/c\"\n\nvar Y = c.Z\n",
+		"c/c.go":      "package c\n\nimport \"example.com/app/a\"\n\nvar Z = a.X\n",
+		"c/c_test.go": "package c\n\nimport \"example.com/app/d\"\n",
+		"d/d.go":      "package d\n\nimport \"example.com/app/c\"\n\nvar W = c.Z\n",
+	})
+
+	graph, err := BuildImportGraph(repoPath)
+	if err != nil {
+		t.Fatalf("BuildImportGraph failed: %v", err)
+	}
+
+	if deps := graph["example.com/app"]; !reflect.DeepEqual(deps, []string{"example.com/app/a"}) {
+		t.Errorf("Expected only module imports for the root package, got %v", deps)
+	}
+
+	// d imports c, but c only imports d from a test file, so d is not part of a cycle
+	cycles := graph.FindImportCycles()
+	expected := [][]string{{"example.com/app/a", "example.com/app/b", "example.com/app/c", "example.com/app/a"}}
+	if !reflect.DeepEqual(cycles, expected) {
+		t.Errorf("Expected cycles %v, got %v", expected, cycles)
+	}
+}
+
+func TestBuildImportGraph_MissingGoMod(t *testing.T) {
+	repoPath := writeModule(t, map[string]string{"main.go": "package main\n"})
+
+	if _, err := BuildImportGraph(repoPath); err == nil {
+		t.Error("Expected an error without go.mod")
+	}
+}
@@ -0,0 +1,222 @@
+package go_analyzer
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+)
+
+// UnusedExport is an exported package-level identifier that no other code in the
+// module references
+type UnusedExport struct {
+	Package string // import path of the declaring package
+	Name    string
+	Kind    string // func, type, var or const
+	File    string
+	Line    int
+}
+
+// moduleFile is a parsed source file and the import path of its package
+type moduleFile struct {
+	pkg  string
+	file *ast.File
+}
+
+// FindUnusedExports reports exported functions, types, variables and constants in
+// the module rooted at repoPath that are never referenced from non-test code in the
+// module. Methods and struct fields are not considered, main packages are skipped,
+// and references are matched by name only, so symbols reached through reflection,
+// plugins or other modules are reported too.
+func FindUnusedExports(repoPath string) ([]UnusedExport, error) {
+	modulePath, err := readModulePath(filepath.Join(repoPath, "go.mod"))
+	if err != nil {
+		return nil, err
+	}
+
+	fset := token.NewFileSet()
+	var files []moduleFile
+	packageNames := make(map[string]string)
+
+	err = walkModuleSources(repoPath, func(filePath string) {
+		file, err := parser.ParseFile(fset, filePath, nil, parser.SkipObjectResolution)
+		if err != nil {
+			return // Unparsable files are reported by the complexity analysis
+		}
+		pkg := packageImportPath(modulePath, repoPath, filePath)
+		packageNames[pkg] = file.Name.Name
+		files = append(files, moduleFile{pkg: pkg, file: file})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	collector := &exportCollector{fset: fset, declarations: make(map[*ast.Ident]bool)}
+	for _, mf := range files {
+		collector.collect(mf)
+	}
+
+	used := make(map[string]bool)
+	for _, mf := range files {
+		markFileReferences(mf, modulePath, packageNames, collector.declarations, used)
+	}
+
+	var unused []UnusedExport
+	for _, export := range collector.exports {
+		if !used[export.Package+"."+export.Name] {
+			unused = append(unused, export)
+		}
+	}
+
+	sort.Slice(unused, func(i, j int) bool {
+		if unused[i].File != unused[j].File {
+			return unused[i].File < unused[j].File
+		}
+		return unused[i].Line < unused[j].Line
+	})
+	return unused, nil
+}
+
+// exportCollector gathers the exported package-level declarations of module files
+type exportCollector struct {
+	fset *token.FileSet
+	// Identifiers that name declarations rather than refer to them
+	declarations map[*ast.Ident]bool
+	exports      []UnusedExport
+}
+
+// collect records the declarations of a file and, outside main packages, its exports
+func (c *exportCollector) collect(mf moduleFile) {
+	markDeclarations(mf.file, c.declarations)
+	if mf.file.Name.Name == "main" {
+		return
+	}
+
+	for _, decl := range mf.file.Decls {
+		switch decl := decl.(type) {
+		case *ast.FuncDecl:
+			if decl.Recv == nil {
+				c.add(mf.pkg, decl.Name, "func")
+			}
+		case *ast.GenDecl:
+			c.addGenDecl(mf.pkg, decl)
+		}
+	}
+}
+
+// addGenDecl records the types, variables and constants of a declaration
+func (c *exportCollector) addGenDecl(pkg string, decl *ast.GenDecl) {
+	for _, spec := range decl.Specs {
+		switch spec := spec.(type) {
+		case *ast.TypeSpec:
+			c.add(pkg, spec.Name, "type")
+		case *ast.ValueSpec:
+			kind := "var"
+			if decl.Tok == token.CONST {
+				kind = "const"
+			}
+			for _, name := range spec.Names {
+				c.add(pkg, name, kind)
+			}
+		}
+	}
+}
+
+// add marks a package-level identifier as a declaration and records it when exported
+func (c *exportCollector) add(pkg string, ident *ast.Ident, kind string) {
+	c.declarations[ident] = true
+	if !ident.IsExported() {
+		return
+	}
+	position := c.fset.Position(ident.Pos())
+	c.exports = append(c.exports, UnusedExport{
+		Package: pkg,
+		Name:    ident.Name,
+		Kind:    kind,
+		File:    position.Filename,
+		Line:    position.Line,
+	})
+}
+
+// markDeclarations marks the names of fields, parameters and functions in a
+// file, which declare rather than refer to symbols
+func markDeclarations(file *ast.File, declarations map[*ast.Ident]bool) {
+	ast.Inspect(file, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.Field:
+			for _, name := range n.Names {
+				declarations[name] = true
+			}
+		case *ast.FuncDecl:
+			declarations[n.Name] = true
+		}
+		return true
+	})
+}
+
+// markFileReferences records every module symbol the file refers to, either
+// unqualified within its own package or qualified through an import
+func markFileReferences(mf moduleFile, modulePath string, packageNames map[string]string, declarations map[*ast.Ident]bool, used map[string]bool) {
+	imports, dotImports := moduleImports(mf, modulePath, packageNames)
+
+	var visit func(n ast.Node) bool
+	visit = func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.SelectorExpr:
+			if ident, ok := n.X.(*ast.Ident); ok {
+				if importPath, ok := imports[ident.Name]; ok {
+					used[importPath+"."+n.Sel.Name] = true
+					return false
+				}
+			}
+			// The selected name is a field or method, so only the operand can
+			// refer to a package-level symbol
+			ast.Inspect(n.X, visit)
+			return false
+		case *ast.Ident:
+			if declarations[n] || !n.IsExported() {
+				return true
+			}
+			used[mf.pkg+"."+n.Name] = true
+			for _, importPath := range dotImports {
+				used[importPath+"."+n.Name] = true
+			}
+		}
+		return true
+	}
+	ast.Inspect(mf.file, visit)
+}
+
+// moduleImports returns the module packages a file imports by name, and those
+// it dot-imports
+func moduleImports(mf moduleFile, modulePath string, packageNames map[string]string) (map[string]string, []string) {
+	imports := make(map[string]string)
+	var dotImports []string
+
+	for _, spec := range mf.file.Imports {
+		importPath, err := strconv.Unquote(spec.Path.Value)
+		if err != nil || !inModule(importPath, modulePath) {
+			continue
+		}
+
+		name, known := packageNames[importPath]
+		if !known {
+			name = path.Base(importPath)
+		}
+		if spec.Name != nil {
+			name = spec.Name.Name
+		}
+
+		switch name {
+		case "_":
+		case ".":
+			dotImports = append(dotImports, importPath)
+		default:
+			imports[name] = importPath
+		}
+	}
+	return imports, dotImports
+}
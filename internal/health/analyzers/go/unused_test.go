@@ -0,0 +1,56 @@
+package go_analyzer
+
+import (
+	"testing"
+)
+
+func TestFindUnusedExports(t *testing.T) {
+	repoPath := writeModule(t, map[string]string{
+		"go.mod":  "module example.com/app\n\ngo 1.24\n",
+		"main.go": "package main\n\nimport (\n\tstore \"example.com/app/storage\"\n\t. \"example.com/app/util\"\n)\n\nfunc main() { store.Open(); Helper() }\n\nfunc Exported() {}\n",
+		"storage/storage.go": "package storage\n\n" +
+			"type DB struct{ Name string }\n\n" +
+			"func Open() *DB { return &DB{Name: Default} }\n\n" +
+			"const Default = \"db\"\n\n" +
+			"func (d *DB) Close() {}\n\n" +
+			"func Unused() {}\n\n" +
+			"var (\n\tVersion = 1\n\tinternal = 2\n)\n",
+		"storage/storage_test.go": "package storage\n\nfunc TestOnly() { Unused() }\n",
+		"util/util.go":            "package util\n\nfunc Helper() {}\n\ntype Name string\n",
+	})
+
+	unused, err := FindUnusedExports(repoPath)
+	if err != nil {
+		t.Fatalf("FindUnusedExports failed: %v", err)
+	}
+
+	var names []string
+	for _, export := range unused {
+		names = append(names, export.Package+"."+export.Name+":"+export.Kind)
+	}
+
+	// DB.Name is a field, not a reference to util.Name
+	expected := []string{
+		"example.com/app/storage.Unused:func",
+		"example.com/app/storage.Version:var",
+		"example.com/app/util.Name:type",
+	}
+	if len(names) != len(expected) {
+		t.Fatalf("Expected %v, got %v", expected, names)
+	}
+	for i := range expected {
+		if names[i] != expected[i] {
+			t.Errorf("Expected %v, got %v", expected, names)
+			break
+		}
+	}
+	if unused[0].Line != 11 {
+		t.Errorf("Expected Unused on line 11, got %d", unused[0].Line)
+	}
+}
+
+func TestFindUnusedExports_RequiresGoMod(t *testing.T) {
+	if _, err := FindUnusedExports(t.TempDir()); err == nil {
+		t.Error("Expected error without go.mod")
+	}
+}
@@ -2,33 +2,36 @@ package java_analyzer
 
 import (
 	"context"
-	"os"
+	"errors"
 	"path/filepath"
-	"regexp"
 	"strings"
 
 	"github.com/codcod/repos/internal/core"
+	"github.com/codcod/repos/internal/health/analyzers/language"
+	"github.com/codcod/repos/internal/health/analyzers/parallel"
 )
 
 // JavaAnalyzer implements language-specific analysis for Java code
 type JavaAnalyzer struct {
-	name       string
-	language   string
-	extensions []string
-	excludes   []string
-	filesystem core.FileSystem
-	logger     core.Logger
+	name         string
+	language     string
+	extensions   []string
+	excludes     []string
+	testPatterns []string
+	filesystem   core.FileSystem
+	logger       core.Logger
 }
 
 // NewJavaAnalyzer creates a new Java language analyzer
 func NewJavaAnalyzer(fs core.FileSystem, logger core.Logger) *JavaAnalyzer {
 	return &JavaAnalyzer{
-		name:       "java-analyzer",
-		language:   "java",
-		extensions: []string{".java"},
-		excludes:   []string{"target/", "build/", ".git/", "bin/", "out/"},
-		filesystem: fs,
-		logger:     logger,
+		name:         "java-analyzer",
+		language:     "java",
+		extensions:   []string{".java"},
+		excludes:     []string{"target/", "build/", ".git/", "bin/", "out/"},
+		testPatterns: []string{"*Test.java", "*Tests.java", "*IT.java"},
+		filesystem:   fs,
+		logger:       logger,
 	}
 }
 
@@ -50,7 +53,7 @@ func (j *JavaAnalyzer) SupportedExtensions() []string {
 // CanAnalyze checks if the analyzer can process the given repository
 func (j *JavaAnalyzer) CanAnalyze(repo core.Repository) bool {
 	// Check if repository has Java files
-	return j.hasJavaFiles(repo.Path)
+	return j.hasJavaFiles(repo.FileIndex())
 }
 
 // Analyze performs language-specific analysis on the repository
@@ -65,27 +68,45 @@ func (j *JavaAnalyzer) Analyze(ctx context.Context, repoPath string, config core
 	}
 
 	// Find Java files
-	files, err := j.findJavaFiles(repoPath)
+	files, walkErrors, err := j.findJavaFiles(config.FileIndex(repoPath), config.Extensions(j.extensions), config.Excludes(j.excludes, j.testPatterns))
 	if err != nil {
 		return nil, err
 	}
+	result.Errors = append(result.Errors, walkErrors...)
+
+	// Restrict to explicitly included files (e.g. changed files only)
+	files = config.FilterFiles(files)
+
+	// Skip generated code unless it is explicitly included
+	generatedSkipped := 0
+	if !config.IncludeGenerated {
+		files, generatedSkipped = language.SkipGenerated(files)
+	}
 
 	totalComplexity := 0
 	totalFunctions := 0
 	totalClasses := 0
 	maxComplexity := 0
+	maxFunctionLines := 0
+	binarySkipped := 0
 
-	// Analyze each file
-	for _, file := range files {
-		if ctx.Err() != nil {
-			return nil, ctx.Err()
-		}
+	// Analyze files on a bounded worker pool; results come back in file order
+	fileResults, err := parallel.AnalyzeFiles(ctx, files, config.Concurrency, j.analyzeFile)
+	if err != nil {
+		return nil, err
+	}
 
-		fileAnalysis, err := j.analyzeFile(file)
-		if err != nil {
+	for _, fileResult := range fileResults {
+		file, fileAnalysis := fileResult.Path, fileResult.Analysis
+		if errors.Is(fileResult.Err, language.ErrBinaryFile) {
+			binarySkipped++
+			continue
+		}
+		if fileResult.Err != nil {
 			j.logger.Warn("Failed to analyze file",
 				core.Field{Key: "file", Value: file},
-				core.Field{Key: "error", Value: err.Error()})
+				core.Field{Key: "error", Value: fileResult.Err.Error()})
+			result.Errors = append(result.Errors, core.AnalysisError{Path: file, Reason: fileResult.Err.Error()})
 			continue
 		}
 
@@ -99,11 +120,15 @@ func (j *JavaAnalyzer) Analyze(ctx context.Context, repoPath string, config core
 			if fn.Complexity > maxComplexity {
 				maxComplexity = fn.Complexity
 			}
+			maxFunctionLines = max(maxFunctionLines, fn.Lines())
 		}
 
 		totalClasses += len(fileAnalysis.Classes)
 	}
 
+	// Order functions by file and line so output does not depend on scheduling
+	core.SortFunctions(result.Functions)
+
 	// Calculate metrics
 	avgComplexity := 0.0
 	if totalFunctions > 0 {
@@ -111,10 +136,13 @@ func (j *JavaAnalyzer) Analyze(ctx context.Context, repoPath string, config core
 	}
 
 	result.Metrics["total_files"] = len(result.Files)
+	result.Metrics["binary_files_skipped"] = binarySkipped
+	result.Metrics["generated_files_skipped"] = generatedSkipped
 	result.Metrics["total_classes"] = totalClasses
 	result.Metrics["total_functions"] = totalFunctions
 	result.Metrics["total_complexity"] = totalComplexity
 	result.Metrics["max_complexity"] = maxComplexity
+	result.Metrics["max_function_lines"] = maxFunctionLines
 	result.Metrics["average_complexity"] = avgComplexity
 
 	j.logger.Info("Java analysis completed",
@@ -126,48 +154,33 @@ func (j *JavaAnalyzer) Analyze(ctx context.Context, repoPath string, config core
 }
 
 // hasJavaFiles checks if the repository contains Java files
-func (j *JavaAnalyzer) hasJavaFiles(repoPath string) bool {
-	files, err := j.findJavaFiles(repoPath)
+func (j *JavaAnalyzer) hasJavaFiles(index *core.FileIndex) bool {
+	files, _, err := j.findJavaFiles(index, j.extensions, j.excludes)
 	return err == nil && len(files) > 0
 }
 
 // findJavaFiles finds all Java source files in the repository
-func (j *JavaAnalyzer) findJavaFiles(repoPath string) ([]string, error) {
-	var javaFiles []string
-
-	err := filepath.Walk(repoPath, func(path string, info os.FileInfo, err error) error {
-		if err != nil {
-			return err
-		}
-
-		// Skip directories
-		if info.IsDir() {
-			return nil
-		}
-
-		// Check if it's a Java file
-		if !strings.HasSuffix(path, ".java") {
-			return nil
-		}
+func (j *JavaAnalyzer) findJavaFiles(index *core.FileIndex, extensions, excludes []string) ([]string, []core.AnalysisError, error) {
+	paths, walkErrors, err := index.Find(extensions)
+	if err != nil {
+		return nil, nil, err
+	}
 
+	var javaFiles []string
+	for _, path := range paths {
 		// Skip excluded patterns
-		relPath, _ := filepath.Rel(repoPath, path)
-		for _, exclude := range j.excludes {
-			if strings.Contains(relPath, exclude) {
-				return nil
-			}
+		relPath, _ := filepath.Rel(index.Root(), path)
+		if !language.Excluded(relPath, excludes) {
+			javaFiles = append(javaFiles, path)
 		}
+	}
 
-		javaFiles = append(javaFiles, path)
-		return nil
-	})
-
-	return javaFiles, err
+	return javaFiles, walkErrors, nil
 }
 
 // analyzeFile analyzes a single Java file
 func (j *JavaAnalyzer) analyzeFile(filePath string) (*core.FileAnalysis, error) {
-	content, err := os.ReadFile(filePath) //nolint:gosec // File path is from repository analysis
+	content, err := language.ReadText(filePath)
 	if err != nil {
 		return nil, err
 	}
@@ -182,7 +195,7 @@ func (j *JavaAnalyzer) analyzeFile(filePath string) (*core.FileAnalysis, error)
 	}
 
 	// Parse the file
-	functions, classes, imports := j.parseFile(string(content), filePath)
+	functions, classes, imports := j.parseFile(content, filePath)
 	analysis.Functions = functions
 	analysis.Classes = classes
 	analysis.Imports = imports
@@ -204,142 +217,9 @@ func (j *JavaAnalyzer) analyzeFile(filePath string) (*core.FileAnalysis, error)
 }
 
 // parseFile parses a Java file to extract classes, methods, and imports
-//
-//nolint:gocyclo // Complex parsing logic for Java language requires high cyclomatic complexity
 func (j *JavaAnalyzer) parseFile(content, filePath string) ([]core.FunctionInfo, []core.ClassInfo, []core.ImportInfo) {
-	var functions []core.FunctionInfo
-	var classes []core.ClassInfo
-	var imports []core.ImportInfo
-
-	lines := strings.Split(content, "\n")
-	inClass := false
-	inMethod := false
-	var currentClass *core.ClassInfo
-	var currentMethod *core.FunctionInfo
-	braceLevel := 0
-
-	// Regex patterns
-	classPattern := regexp.MustCompile(`^\s*(?:public|private|protected)?\s*(?:abstract|final)?\s*class\s+([a-zA-Z_][a-zA-Z0-9_]*)\s*(?:extends\s+([a-zA-Z_][a-zA-Z0-9_]*))?\s*(?:implements\s+[a-zA-Z0-9_,\s<>]+)?\s*\{?`)
-	methodPattern := regexp.MustCompile(`^\s*(?:public|private|protected)?\s*(?:static)?\s*(?:final)?\s*(?:abstract)?\s*[a-zA-Z_<>[\]]+\s+([a-zA-Z_][a-zA-Z0-9_]*)\s*\([^)]*\)\s*(?:throws\s+[a-zA-Z0-9_,\s]+)?\s*\{?`)
-	importPattern := regexp.MustCompile(`^\s*import\s+(?:static\s+)?([a-zA-Z_][a-zA-Z0-9_.*]+)\s*;`)
-
-	for i, line := range lines {
-		lineNum := i + 1
-		trimmedLine := strings.TrimSpace(line)
-
-		// Skip empty lines and comments
-		if trimmedLine == "" || strings.HasPrefix(trimmedLine, "//") || strings.HasPrefix(trimmedLine, "/*") || strings.HasPrefix(trimmedLine, "*") {
-			continue
-		}
-
-		// Count braces to track nesting
-		braceLevel += strings.Count(line, "{") - strings.Count(line, "}")
-
-		// Check for imports
-		if matches := importPattern.FindStringSubmatch(line); matches != nil {
-			importPath := matches[1]
-			parts := strings.Split(importPath, ".")
-			name := parts[len(parts)-1]
-
-			importInfo := core.ImportInfo{
-				Name:    name,
-				Path:    importPath,
-				Line:    lineNum,
-				IsLocal: !strings.Contains(importPath, "."),
-			}
-
-			imports = append(imports, importInfo)
-		}
-
-		// Check for class definitions
-		if matches := classPattern.FindStringSubmatch(line); matches != nil {
-			className := matches[1]
-
-			// If we were already in a class, finalize the previous one
-			if inClass && currentClass != nil {
-				classes = append(classes, *currentClass)
-			}
-
-			// Start new class
-			currentClass = &core.ClassInfo{
-				Name:     className,
-				File:     filePath,
-				Line:     lineNum,
-				Language: j.language,
-				Methods:  []core.FunctionInfo{},
-				Fields:   []core.FieldInfo{},
-			}
-
-			inClass = true
-		}
-
-		// Check for method definitions
-		if matches := methodPattern.FindStringSubmatch(line); matches != nil {
-			methodName := matches[1]
-
-			// Skip constructors and getters/setters for complexity (they're usually simple)
-			isConstructor := currentClass != nil && methodName == currentClass.Name
-			isGetterSetter := strings.HasPrefix(methodName, "get") || strings.HasPrefix(methodName, "set") || strings.HasPrefix(methodName, "is")
-
-			// If we were already in a method, finalize the previous one
-			if inMethod && currentMethod != nil {
-				functions = append(functions, *currentMethod)
-				if currentClass != nil {
-					currentClass.Methods = append(currentClass.Methods, *currentMethod)
-				}
-			}
-
-			// Start new method
-			currentMethod = &core.FunctionInfo{
-				Name:       methodName,
-				File:       filePath,
-				Line:       lineNum,
-				Complexity: 1, // Base complexity
-				Language:   j.language,
-			}
-
-			// Constructors and simple getters/setters get lower base complexity
-			if isConstructor || isGetterSetter {
-				currentMethod.Complexity = 1
-			}
-
-			inMethod = true
-		} else if inMethod && currentMethod != nil {
-			// We're inside a method, calculate complexity
-			currentMethod.Complexity += j.calculateLineComplexity(trimmedLine)
-		}
-
-		// Check if method or class ended
-		if braceLevel == 0 && (inMethod || inClass) {
-			if inMethod && currentMethod != nil {
-				functions = append(functions, *currentMethod)
-				if currentClass != nil {
-					currentClass.Methods = append(currentClass.Methods, *currentMethod)
-				}
-				inMethod = false
-				currentMethod = nil
-			}
-
-			if inClass && currentClass != nil && !inMethod {
-				classes = append(classes, *currentClass)
-				inClass = false
-				currentClass = nil
-			}
-		}
-	}
-
-	// Don't forget the last method/class if the file ends while in them
-	if inMethod && currentMethod != nil {
-		functions = append(functions, *currentMethod)
-		if currentClass != nil {
-			currentClass.Methods = append(currentClass.Methods, *currentMethod)
-		}
-	}
-	if inClass && currentClass != nil {
-		classes = append(classes, *currentClass)
-	}
-
-	return functions, classes, imports
+	parser := &javaParser{analyzer: j, filePath: filePath}
+	return parser.parse(content)
 }
 
 // calculateLineComplexity calculates complexity contribution of a single line
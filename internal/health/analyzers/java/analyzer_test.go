@@ -0,0 +1,161 @@
+package java_analyzer
+
+import (
+	"testing"
+
+	"github.com/codcod/repos/internal/core"
+)
+
+type testLogger struct{}
+
+func (testLogger) Debug(string, ...core.Field) {}
+func (testLogger) Info(string, ...core.Field)  {}
+func (testLogger) Warn(string, ...core.Field)  {}
+func (testLogger) Error(string, ...core.Field) {}
+func (testLogger) Fatal(string, ...core.Field) {}
+
+// nestedClassesFixture has an inner class, a local anonymous class, a field
+// initialized with an anonymous class and lambdas with block bodies
+const nestedClassesFixture = `package com.example;
+
+import java.util.List;
+import java.util.concurrent.Executor;
+
+public class Scheduler {
+    private final Comparator<Job> byPriority = new Comparator<Job>() {
+        @Override
+        public int compare(Job a, Job b) {
+            if (a.priority() > b.priority()) {
+                return 1;
+            }
+            return 0;
+        }
+    };
+
+    public void schedule(List<Job> jobs, Executor executor) {
+        for (Job job : jobs) {
+            executor.execute(new Runnable() {
+                @Override
+                public void run() {
+                    if (job.ready() && job.valid()) {
+                        job.start();
+                    }
+                }
+            });
+        }
+        jobs.stream().peek(job -> {
+            if (job.finished()) {
+                log("done {");
+            }
+        });
+    }
+
+    /* a comment with a brace { and if (x) */
+    static class Worker {
+        private final String name;
+
+        Worker(String name) {
+            this.name = name;
+        }
+
+        public String describe(int level)
+                throws IllegalStateException {
+            switch (level) {
+                case 1:
+                    return "low }";
+                case 2:
+                    return "high";
+                default:
+                    throw new IllegalStateException();
+            }
+        }
+    }
+
+    private int count() {
+        return 0;
+    }
+}
+`
+
+func TestJavaAnalyzer_ParseFileNestedClasses(t *testing.T) {
+	analyzer := NewJavaAnalyzer(nil, testLogger{})
+	functions, classes, imports := analyzer.parseFile(nestedClassesFixture, "Scheduler.java")
+
+	expected := []struct {
+		name       string
+		line       int
+		endLine    int
+		complexity int
+	}{
+		{"compare", 9, 14, 2},   // anonymous class in a field initializer
+		{"schedule", 17, 33, 4}, // for loop plus the lambda's if, not the anonymous class's methods
+		{"run", 21, 25, 3},      // method of the anonymous Runnable
+		{"Worker", 39, 41, 1},   // constructor of the inner class
+		{"describe", 43, 53, 3}, // signature spanning two lines
+		{"count", 56, 58, 1},
+	}
+	if len(functions) != len(expected) {
+		t.Fatalf("Expected %d functions, got %d: %+v", len(expected), len(functions), functions)
+	}
+	for i, want := range expected {
+		got := functions[i]
+		if got.Name != want.name || got.Line != want.line || got.Complexity != want.complexity {
+			t.Errorf("Function %d: expected %s at line %d with complexity %d, got %s at line %d with complexity %d",
+				i, want.name, want.line, want.complexity, got.Name, got.Line, got.Complexity)
+		}
+		if got.EndLine != want.endLine {
+			t.Errorf("Function %d: expected %s to end at line %d, got %d", i, want.name, want.endLine, got.EndLine)
+		}
+	}
+
+	if len(classes) != 2 || classes[0].Name != "Scheduler" || classes[1].Name != "Worker" {
+		t.Fatalf("Expected classes Scheduler and Worker, got %+v", classes)
+	}
+	methodNames := func(class core.ClassInfo) []string {
+		var names []string
+		for _, method := range class.Methods {
+			names = append(names, method.Name)
+		}
+		return names
+	}
+	if names := methodNames(classes[0]); len(names) != 2 || names[0] != "schedule" || names[1] != "count" {
+		t.Errorf("Expected Scheduler methods schedule and count, got %v", names)
+	}
+	if names := methodNames(classes[1]); len(names) != 2 || names[0] != "Worker" || names[1] != "describe" {
+		t.Errorf("Expected Worker methods Worker and describe, got %v", names)
+	}
+
+	if len(imports) != 2 || imports[1].Name != "Executor" {
+		t.Errorf("Expected 2 imports, got %+v", imports)
+	}
+}
+
+// enumFixture has enum constants with bodies, which are not methods
+const enumFixture = `public enum Shape {
+    CIRCLE {
+        double area(double r) { return Math.PI * r * r; }
+    },
+    SQUARE("square") {
+        double area(double s) { return s * s; }
+    };
+
+    Shape() {}
+    Shape(String label) {}
+}
+`
+
+func TestJavaAnalyzer_ParseFileEnumConstantBodies(t *testing.T) {
+	analyzer := NewJavaAnalyzer(nil, testLogger{})
+	functions, classes, _ := analyzer.parseFile(enumFixture, "Shape.java")
+
+	var names []string
+	for _, fn := range functions {
+		names = append(names, fn.Name)
+	}
+	if len(names) != 2 || names[0] != "Shape" || names[1] != "Shape" {
+		t.Errorf("Expected only the two constructors, got %v", names)
+	}
+	if len(classes) != 1 || classes[0].Name != "Shape" {
+		t.Errorf("Expected enum Shape, got %+v", classes)
+	}
+}
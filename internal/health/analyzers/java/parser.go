@@ -0,0 +1,397 @@
+package java_analyzer
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/codcod/repos/internal/core"
+)
+
+var (
+	importPattern = regexp.MustCompile(`^\s*import\s+(?:static\s+)?([a-zA-Z_][a-zA-Z0-9_.*]+)\s*;`)
+
+	// classDeclPattern matches a class, interface, enum or record declaration header
+	classDeclPattern = regexp.MustCompile(`(?:^|[\s@])(?:class|interface|enum|record)\s+([A-Za-z_$][\w$]*)`)
+
+	// anonymousClassPattern matches the instance creation that precedes an anonymous class body
+	anonymousClassPattern = regexp.MustCompile(`\bnew\s+[A-Za-z_$][\w$.]*\s*(?:<[^(]*>)?\s*$`)
+
+	// annotationPattern matches annotations, with simple arguments, before a declaration
+	annotationPattern = regexp.MustCompile(`@[A-Za-z_$][\w$.]*(?:\s*\([^()]*\))?`)
+
+	// throwsPattern matches a trailing throws clause
+	throwsPattern = regexp.MustCompile(`\)\s*throws\s+[\w$.,\s<>]+$`)
+
+	// trailingIdentPattern matches the identifier at the end of a string
+	trailingIdentPattern = regexp.MustCompile(`([A-Za-z_$][\w$]*)\s*$`)
+)
+
+// statementKeywords look like method names in front of parentheses but open ordinary blocks
+var statementKeywords = map[string]bool{
+	"if": true, "for": true, "while": true, "switch": true, "catch": true,
+	"synchronized": true, "try": true, "return": true, "new": true, "else": true,
+}
+
+// scopeKind tells what a brace opened
+type scopeKind int
+
+const (
+	scopeBlock  scopeKind = iota // statements, lambdas, initializers and array literals
+	scopeClass                   // class, interface, enum, record or anonymous class body
+	scopeMethod                  // method or constructor body
+)
+
+// scope is an open brace on the parser's stack
+type scope struct {
+	kind   scopeKind
+	class  *core.ClassInfo    // nil for anonymous classes and non-class scopes
+	method *core.FunctionInfo // set for method scopes only
+}
+
+// javaParser attributes Java source lines to the methods that contain them. It
+// keeps a stack of open scopes so that methods of inner and anonymous classes
+// are separate functions and lambda bodies stay part of their enclosing method.
+type javaParser struct {
+	analyzer *JavaAnalyzer
+	filePath string
+
+	stack     []scope
+	functions []core.FunctionInfo
+	classes   []core.ClassInfo
+	imports   []core.ImportInfo
+
+	// header is the code since the last ';', '{' or '}', which decides what the
+	// next '{' opens; headerLines records the header offset at which each line starts
+	header      strings.Builder
+	headerLines []headerLine
+
+	// segment is the code since the last scope change, counted towards the innermost method
+	segment strings.Builder
+
+	inBlockComment bool
+	inTextBlock    bool
+}
+
+// headerLine maps a header offset to its source line
+type headerLine struct {
+	offset int
+	line   int
+}
+
+// parse extracts the methods, classes and imports of a Java file
+func (p *javaParser) parse(content string) ([]core.FunctionInfo, []core.ClassInfo, []core.ImportInfo) {
+	lines := strings.Split(content, "\n")
+	for i, line := range lines {
+		lineNum := i + 1
+
+		if !p.inBlockComment && !p.inTextBlock {
+			if matches := importPattern.FindStringSubmatch(line); matches != nil {
+				importPath := matches[1]
+				parts := strings.Split(importPath, ".")
+				p.imports = append(p.imports, core.ImportInfo{
+					Name:    parts[len(parts)-1],
+					Path:    importPath,
+					Line:    lineNum,
+					IsLocal: !strings.Contains(importPath, "."),
+				})
+				continue
+			}
+		}
+
+		p.scanLine(p.stripLine(line), lineNum)
+	}
+
+	// Close scopes left open by unbalanced braces
+	for len(p.stack) > 0 {
+		p.closeScope(len(lines))
+	}
+
+	sort.SliceStable(p.functions, func(i, k int) bool { return p.functions[i].Line < p.functions[k].Line })
+	sort.SliceStable(p.classes, func(i, k int) bool { return p.classes[i].Line < p.classes[k].Line })
+	return p.functions, p.classes, p.imports
+}
+
+// scanLine feeds one line of comment- and literal-free code through the scope stack
+func (p *javaParser) scanLine(code string, lineNum int) {
+	p.headerLines = append(p.headerLines, headerLine{offset: p.header.Len(), line: lineNum})
+
+	for i := 0; i < len(code); i++ {
+		switch ch := code[i]; ch {
+		case '{':
+			p.flushSegment()
+			p.openScope()
+			p.resetHeader(lineNum)
+		case '}':
+			p.flushSegment()
+			if len(p.stack) > 0 {
+				p.closeScope(lineNum)
+			}
+			p.resetHeader(lineNum)
+		case ';':
+			p.segment.WriteByte(ch)
+			p.resetHeader(lineNum)
+		default:
+			p.segment.WriteByte(ch)
+			p.header.WriteByte(ch)
+		}
+	}
+
+	p.flushSegment()
+	p.header.WriteByte(' ')
+}
+
+// resetHeader starts a new header after a statement or scope boundary
+func (p *javaParser) resetHeader(lineNum int) {
+	p.header.Reset()
+	p.headerLines = append(p.headerLines[:0], headerLine{offset: 0, line: lineNum})
+}
+
+// flushSegment adds the complexity of the pending code to the innermost method
+func (p *javaParser) flushSegment() {
+	if p.segment.Len() == 0 {
+		return
+	}
+	if method := p.innermostMethod(); method != nil {
+		method.Complexity += p.analyzer.calculateLineComplexity(p.segment.String())
+	}
+	p.segment.Reset()
+}
+
+// openScope pushes the scope opened by a '{', classifying it from the header
+func (p *javaParser) openScope() {
+	header := p.header.String()
+	text := strings.TrimSpace(header)
+
+	if matches := classDeclPattern.FindStringSubmatchIndex(text); matches != nil {
+		p.stack = append(p.stack, scope{kind: scopeClass, class: &core.ClassInfo{
+			Name:     text[matches[2]:matches[3]],
+			File:     p.filePath,
+			Line:     p.lineAt(strings.Index(header, text) + matches[2]),
+			Language: p.analyzer.language,
+			Methods:  []core.FunctionInfo{},
+			Fields:   []core.FieldInfo{},
+		}})
+		return
+	}
+
+	if isAnonymousClass(text) {
+		p.stack = append(p.stack, scope{kind: scopeClass})
+		return
+	}
+
+	if enclosing := p.top(); enclosing != nil && enclosing.kind == scopeClass {
+		className := ""
+		if enclosing.class != nil {
+			className = enclosing.class.Name
+		}
+		if name, offset, ok := methodDeclaration(text, className); ok {
+			p.stack = append(p.stack, scope{kind: scopeMethod, method: &core.FunctionInfo{
+				Name:       name,
+				File:       p.filePath,
+				Line:       p.lineAt(strings.Index(header, text) + offset),
+				Complexity: 1, // Base complexity
+				Language:   p.analyzer.language,
+			}})
+			return
+		}
+	}
+
+	p.stack = append(p.stack, scope{kind: scopeBlock})
+}
+
+// closeScope pops the innermost scope, which ends on lineNum, and records the
+// method or class it closed
+func (p *javaParser) closeScope(lineNum int) {
+	closed := p.stack[len(p.stack)-1]
+	p.stack = p.stack[:len(p.stack)-1]
+
+	switch closed.kind {
+	case scopeMethod:
+		closed.method.EndLine = lineNum
+		p.functions = append(p.functions, *closed.method)
+		if class := p.innermostClass(); class != nil {
+			class.Methods = append(class.Methods, *closed.method)
+		}
+	case scopeClass:
+		if closed.class != nil {
+			p.classes = append(p.classes, *closed.class)
+		}
+	}
+}
+
+// top returns the innermost open scope
+func (p *javaParser) top() *scope {
+	if len(p.stack) == 0 {
+		return nil
+	}
+	return &p.stack[len(p.stack)-1]
+}
+
+// innermostMethod returns the method whose body contains the current position,
+// stopping at a class boundary so that class-level code belongs to no method
+func (p *javaParser) innermostMethod() *core.FunctionInfo {
+	for i := len(p.stack) - 1; i >= 0; i-- {
+		switch p.stack[i].kind {
+		case scopeMethod:
+			return p.stack[i].method
+		case scopeClass:
+			return nil
+		}
+	}
+	return nil
+}
+
+// innermostClass returns the declared class that directly contains the current
+// position, or nil inside an anonymous class
+func (p *javaParser) innermostClass() *core.ClassInfo {
+	for i := len(p.stack) - 1; i >= 0; i-- {
+		if p.stack[i].kind == scopeClass {
+			return p.stack[i].class
+		}
+	}
+	return nil
+}
+
+// lineAt returns the source line of a header offset
+func (p *javaParser) lineAt(offset int) int {
+	line := 0
+	for _, start := range p.headerLines {
+		if start.offset > offset {
+			break
+		}
+		line = start.line
+	}
+	return line
+}
+
+// stripLine removes comments and the contents of string, character and text
+// block literals, carrying block comment and text block state across lines
+func (p *javaParser) stripLine(line string) string {
+	var code strings.Builder
+
+	for i := 0; i < len(line); i++ {
+		switch {
+		case p.inBlockComment || p.inTextBlock:
+			i = p.skipOpenLiteral(line, i, &code)
+		case strings.HasPrefix(line[i:], "//"):
+			return code.String()
+		case strings.HasPrefix(line[i:], "/*"):
+			p.inBlockComment = true
+			i++
+		case strings.HasPrefix(line[i:], `"""`):
+			p.inTextBlock = true
+			i += 2
+		case line[i] == '"' || line[i] == '\'':
+			quote := line[i]
+			i = skipQuoted(line, i)
+			code.WriteByte(quote)
+			code.WriteByte(quote)
+		default:
+			code.WriteByte(line[i])
+		}
+	}
+
+	return code.String()
+}
+
+// skipOpenLiteral skips the character at i inside a block comment or text
+// block carried over from an earlier line, ending it at its closing delimiter,
+// and returns the index of the last character handled
+func (p *javaParser) skipOpenLiteral(line string, i int, code *strings.Builder) int {
+	if p.inBlockComment {
+		if strings.HasPrefix(line[i:], "*/") {
+			p.inBlockComment = false
+			code.WriteByte(' ')
+			i++
+		}
+		return i
+	}
+	if strings.HasPrefix(line[i:], `"""`) {
+		p.inTextBlock = false
+		code.WriteString(`""`)
+		i += 2
+	}
+	return i
+}
+
+// skipQuoted returns the index of the quote closing the string or character
+// literal that starts at start, or the end of the line when it is unterminated
+func skipQuoted(line string, start int) int {
+	quote := line[start]
+	i := start + 1
+	for i < len(line) && line[i] != quote {
+		if line[i] == '\\' {
+			i++ // Skip escaped character
+		}
+		i++
+	}
+	return i
+}
+
+// isAnonymousClass reports whether a header ends with an instance creation,
+// e.g. "executor.submit(new Runnable()", whose body is an anonymous class
+func isAnonymousClass(text string) bool {
+	open := matchingParen(text)
+	return open >= 0 && anonymousClassPattern.MatchString(text[:open])
+}
+
+// methodDeclaration returns the name of the method declared by a header and its
+// offset in the header. Constructors without modifiers are recognized by the
+// name of their class.
+func methodDeclaration(text, className string) (string, int, bool) {
+	signature := text
+	if loc := throwsPattern.FindStringIndex(signature); loc != nil {
+		signature = signature[:loc[0]+1]
+	}
+
+	open := matchingParen(signature)
+	if open < 0 {
+		return "", 0, false
+	}
+	loc := trailingIdentPattern.FindStringSubmatchIndex(signature[:open])
+	if loc == nil {
+		return "", 0, false
+	}
+	name := signature[loc[2]:loc[3]]
+	if statementKeywords[name] {
+		return "", 0, false
+	}
+
+	// A method needs a return type or modifier in front of its name; enum
+	// constants with bodies and other expressions don't have one
+	prefix := strings.TrimSpace(annotationPattern.ReplaceAllString(signature[:loc[2]], ""))
+	if prefix == "" {
+		return name, loc[2], name == className
+	}
+	if last := prefix[len(prefix)-1]; last != '>' && last != ']' && !isIdentByte(last) {
+		return "", 0, false
+	}
+
+	return name, loc[2], true
+}
+
+// isIdentByte reports whether b can be part of a Java identifier
+func isIdentByte(b byte) bool {
+	return b == '_' || b == '$' || (b >= 'a' && b <= 'z') || (b >= 'A' && b <= 'Z') || (b >= '0' && b <= '9')
+}
+
+// matchingParen returns the index of the '(' matching a trailing ')', or -1
+func matchingParen(text string) int {
+	if !strings.HasSuffix(text, ")") {
+		return -1
+	}
+	depth := 0
+	for i := len(text) - 1; i >= 0; i-- {
+		switch text[i] {
+		case ')':
+			depth++
+		case '(':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
@@ -2,33 +2,37 @@ package javascript_analyzer
 
 import (
 	"context"
-	"os"
+	"errors"
 	"path/filepath"
 	"regexp"
 	"strings"
 
 	"github.com/codcod/repos/internal/core"
+	"github.com/codcod/repos/internal/health/analyzers/language"
+	"github.com/codcod/repos/internal/health/analyzers/parallel"
 )
 
 // JavaScriptAnalyzer implements language-specific analysis for JavaScript/TypeScript code
 type JavaScriptAnalyzer struct {
-	name       string
-	language   string
-	extensions []string
-	excludes   []string
-	filesystem core.FileSystem
-	logger     core.Logger
+	name         string
+	language     string
+	extensions   []string
+	excludes     []string
+	testPatterns []string
+	filesystem   core.FileSystem
+	logger       core.Logger
 }
 
 // NewJavaScriptAnalyzer creates a new JavaScript/TypeScript language analyzer
 func NewJavaScriptAnalyzer(fs core.FileSystem, logger core.Logger) *JavaScriptAnalyzer {
 	return &JavaScriptAnalyzer{
-		name:       "javascript-analyzer",
-		language:   "javascript",
-		extensions: []string{".js", ".jsx", ".ts", ".tsx", ".mjs", ".cjs"},
-		excludes:   []string{"node_modules/", "dist/", "build/", ".git/", "coverage/", ".next/"},
-		filesystem: fs,
-		logger:     logger,
+		name:         "javascript-analyzer",
+		language:     "javascript",
+		extensions:   []string{".js", ".jsx", ".ts", ".tsx", ".mjs", ".cjs"},
+		excludes:     []string{"node_modules/", "dist/", "build/", ".git/", "coverage/", ".next/"},
+		testPatterns: []string{"*.test.*", "*.spec.*", "__tests__/"},
+		filesystem:   fs,
+		logger:       logger,
 	}
 }
 
@@ -50,7 +54,7 @@ func (js *JavaScriptAnalyzer) SupportedExtensions() []string {
 // CanAnalyze checks if the analyzer can process the given repository
 func (js *JavaScriptAnalyzer) CanAnalyze(repo core.Repository) bool {
 	// Check if repository has JavaScript/TypeScript files
-	return js.hasJavaScriptFiles(repo.Path)
+	return js.hasJavaScriptFiles(repo.FileIndex())
 }
 
 // Analyze performs language-specific analysis on the repository
@@ -65,35 +69,53 @@ func (js *JavaScriptAnalyzer) Analyze(ctx context.Context, repoPath string, conf
 	}
 
 	// Find JavaScript/TypeScript files
-	files, err := js.findJavaScriptFiles(repoPath)
+	files, walkErrors, err := js.findJavaScriptFiles(config.FileIndex(repoPath), config.Extensions(js.extensions), config.Excludes(js.excludes, js.testPatterns))
 	if err != nil {
 		return nil, err
 	}
+	result.Errors = append(result.Errors, walkErrors...)
+
+	// Restrict to explicitly included files (e.g. changed files only)
+	files = config.FilterFiles(files)
+
+	// Skip generated code unless it is explicitly included
+	generatedSkipped := 0
+	if !config.IncludeGenerated {
+		files, generatedSkipped = language.SkipGenerated(files)
+	}
 
 	totalComplexity := 0
 	totalFunctions := 0
 	maxComplexity := 0
+	maxFunctionLines := 0
 	jsFiles := 0
 	tsFiles := 0
+	binarySkipped := 0
 
-	// Analyze each file
-	for _, file := range files {
-		if ctx.Err() != nil {
-			return nil, ctx.Err()
-		}
+	// Analyze files on a bounded worker pool; results come back in file order
+	fileResults, err := parallel.AnalyzeFiles(ctx, files, config.Concurrency, js.analyzeFile)
+	if err != nil {
+		return nil, err
+	}
 
-		fileAnalysis, err := js.analyzeFile(file)
-		if err != nil {
+	for _, fileResult := range fileResults {
+		file, fileAnalysis := fileResult.Path, fileResult.Analysis
+		if errors.Is(fileResult.Err, language.ErrBinaryFile) {
+			binarySkipped++
+			continue
+		}
+		if fileResult.Err != nil {
 			js.logger.Warn("Failed to analyze file",
 				core.Field{Key: "file", Value: file},
-				core.Field{Key: "error", Value: err.Error()})
+				core.Field{Key: "error", Value: fileResult.Err.Error()})
+			result.Errors = append(result.Errors, core.AnalysisError{Path: file, Reason: fileResult.Err.Error()})
 			continue
 		}
 
 		result.Files[file] = fileAnalysis
 
 		// Count file types
-		if strings.HasSuffix(file, ".ts") || strings.HasSuffix(file, ".tsx") {
+		if isTypeScriptFile(file) {
 			tsFiles++
 		} else {
 			jsFiles++
@@ -104,12 +126,14 @@ func (js *JavaScriptAnalyzer) Analyze(ctx context.Context, repoPath string, conf
 			result.Functions = append(result.Functions, fn)
 			totalFunctions++
 			totalComplexity += fn.Complexity
-			if fn.Complexity > maxComplexity {
-				maxComplexity = fn.Complexity
-			}
+			maxComplexity = max(maxComplexity, fn.Complexity)
+			maxFunctionLines = max(maxFunctionLines, fn.Lines())
 		}
 	}
 
+	// Order functions by file and line so output does not depend on scheduling
+	core.SortFunctions(result.Functions)
+
 	// Calculate metrics
 	avgComplexity := 0.0
 	if totalFunctions > 0 {
@@ -117,11 +141,14 @@ func (js *JavaScriptAnalyzer) Analyze(ctx context.Context, repoPath string, conf
 	}
 
 	result.Metrics["total_files"] = len(result.Files)
+	result.Metrics["binary_files_skipped"] = binarySkipped
+	result.Metrics["generated_files_skipped"] = generatedSkipped
 	result.Metrics["js_files"] = jsFiles
 	result.Metrics["ts_files"] = tsFiles
 	result.Metrics["total_functions"] = totalFunctions
 	result.Metrics["total_complexity"] = totalComplexity
 	result.Metrics["max_complexity"] = maxComplexity
+	result.Metrics["max_function_lines"] = maxFunctionLines
 	result.Metrics["average_complexity"] = avgComplexity
 
 	js.logger.Info("JavaScript/TypeScript analysis completed",
@@ -133,56 +160,39 @@ func (js *JavaScriptAnalyzer) Analyze(ctx context.Context, repoPath string, conf
 	return result, nil
 }
 
+// isTypeScriptFile reports whether a file is TypeScript rather than JavaScript
+func isTypeScriptFile(file string) bool {
+	return strings.HasSuffix(file, ".ts") || strings.HasSuffix(file, ".tsx")
+}
+
 // hasJavaScriptFiles checks if the repository contains JavaScript/TypeScript files
-func (js *JavaScriptAnalyzer) hasJavaScriptFiles(repoPath string) bool {
-	files, err := js.findJavaScriptFiles(repoPath)
+func (js *JavaScriptAnalyzer) hasJavaScriptFiles(index *core.FileIndex) bool {
+	files, _, err := js.findJavaScriptFiles(index, js.extensions, js.excludes)
 	return err == nil && len(files) > 0
 }
 
 // findJavaScriptFiles finds all JavaScript/TypeScript source files in the repository
-func (js *JavaScriptAnalyzer) findJavaScriptFiles(repoPath string) ([]string, error) {
-	var jsFiles []string
-
-	err := filepath.Walk(repoPath, func(path string, info os.FileInfo, err error) error {
-		if err != nil {
-			return err
-		}
-
-		// Skip directories
-		if info.IsDir() {
-			return nil
-		}
-
-		// Check if it's a JavaScript/TypeScript file
-		isJSFile := false
-		for _, ext := range js.extensions {
-			if strings.HasSuffix(path, ext) {
-				isJSFile = true
-				break
-			}
-		}
-		if !isJSFile {
-			return nil
-		}
+func (js *JavaScriptAnalyzer) findJavaScriptFiles(index *core.FileIndex, extensions, excludes []string) ([]string, []core.AnalysisError, error) {
+	paths, walkErrors, err := index.Find(extensions)
+	if err != nil {
+		return nil, nil, err
+	}
 
+	var jsFiles []string
+	for _, path := range paths {
 		// Skip excluded patterns
-		relPath, _ := filepath.Rel(repoPath, path)
-		for _, exclude := range js.excludes {
-			if strings.Contains(relPath, exclude) {
-				return nil
-			}
+		relPath, _ := filepath.Rel(index.Root(), path)
+		if !language.Excluded(relPath, excludes) {
+			jsFiles = append(jsFiles, path)
 		}
+	}
 
-		jsFiles = append(jsFiles, path)
-		return nil
-	})
-
-	return jsFiles, err
+	return jsFiles, walkErrors, nil
 }
 
 // analyzeFile analyzes a single JavaScript/TypeScript file
 func (js *JavaScriptAnalyzer) analyzeFile(filePath string) (*core.FileAnalysis, error) {
-	content, err := os.ReadFile(filePath) //nolint:gosec // File path is from repository analysis
+	content, err := language.ReadText(filePath)
 	if err != nil {
 		return nil, err
 	}
@@ -202,7 +212,7 @@ func (js *JavaScriptAnalyzer) analyzeFile(filePath string) (*core.FileAnalysis,
 	}
 
 	// Parse the file
-	functions, imports := js.parseFile(string(content), filePath, language)
+	functions, imports := js.parseFile(content, filePath, language)
 	analysis.Functions = functions
 	analysis.Imports = imports
 
@@ -350,6 +360,10 @@ func (js *JavaScriptAnalyzer) parseFile(content, filePath, language string) ([]c
 			currentFunction.Complexity += js.calculateLineComplexity(trimmedLine)
 		}
 
+		if currentFunction != nil {
+			currentFunction.EndLine = lineNum
+		}
+
 		// Check if function ended (simplified heuristic)
 		if inFunction && braceLevel == 0 && currentFunction != nil {
 			functions = append(functions, *currentFunction)
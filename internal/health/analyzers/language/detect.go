@@ -0,0 +1,180 @@
+// Package language infers the primary programming language of a repository
+// from the extensions of its source files
+package language
+
+import (
+	"io/fs"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// extensionLanguages maps source file extensions to language names. Names match
+// the analyzer languages so that a detected language selects its analyzer;
+// TypeScript counts as javascript because the JavaScript analyzer handles it.
+// Markup, data and configuration files are not counted, as in GitHub Linguist.
+var extensionLanguages = map[string]string{
+	".go":    "go",
+	".py":    "python",
+	".pyi":   "python",
+	".js":    "javascript",
+	".jsx":   "javascript",
+	".mjs":   "javascript",
+	".cjs":   "javascript",
+	".ts":    "javascript",
+	".tsx":   "javascript",
+	".java":  "java",
+	".sh":    "shell",
+	".bash":  "shell",
+	".zsh":   "shell",
+	".rs":    "rust",
+	".c":     "c",
+	".h":     "c",
+	".cc":    "cpp",
+	".cpp":   "cpp",
+	".cxx":   "cpp",
+	".hpp":   "cpp",
+	".cs":    "csharp",
+	".rb":    "ruby",
+	".php":   "php",
+	".kt":    "kotlin",
+	".kts":   "kotlin",
+	".scala": "scala",
+	".swift": "swift",
+	".dart":  "dart",
+	".ex":    "elixir",
+	".exs":   "elixir",
+}
+
+// vendoredDirs are dependency and build output directories that never count
+// towards a repository's language
+var vendoredDirs = map[string]bool{
+	"node_modules": true, "vendor": true, "third_party": true,
+	"target": true, "build": true, "dist": true,
+	"venv": true, "env": true, "__pycache__": true,
+}
+
+// ForFile returns the language of a source file by its extension, or "" if it
+// is not a source file
+func ForFile(name string) string {
+	return extensionLanguages[strings.ToLower(path.Ext(name))]
+}
+
+// SkipDir reports whether Detect skips a directory of this name: hidden and
+// vendored directories
+func SkipDir(name string) bool {
+	return strings.HasPrefix(name, ".") || vendoredDirs[name]
+}
+
+// Breakdown is the number of source files per language
+type Breakdown map[string]int
+
+// Primary returns the language with the most files, or "" if no source files
+// were found. Ties go to the alphabetically first language.
+func (b Breakdown) Primary() string {
+	primary := ""
+	for lang, count := range b {
+		if count > b[primary] || (count == b[primary] && count > 0 && lang < primary) {
+			primary = lang
+		}
+	}
+	return primary
+}
+
+// Detect counts the source files under repoPath by language. Hidden and
+// vendored directories are skipped, as is anything matching an exclude pattern.
+// A pattern matches a file or directory by its path relative to repoPath or by
+// its name; a trailing slash is ignored, so "vendor/" excludes the directory.
+func Detect(repoPath string, excludes []string) (Breakdown, error) {
+	breakdown := make(Breakdown)
+
+	err := filepath.WalkDir(repoPath, func(filePath string, d fs.DirEntry, err error) error {
+		if filePath == repoPath {
+			return err
+		}
+		if err != nil {
+			return nil // Continue walking
+		}
+
+		rel, err := filepath.Rel(repoPath, filePath)
+		if err != nil {
+			return nil
+		}
+		rel = filepath.ToSlash(rel)
+
+		if d.IsDir() {
+			if SkipDir(d.Name()) || Excluded(rel, excludes) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !d.Type().IsRegular() || Excluded(rel, excludes) {
+			return nil
+		}
+
+		if lang := ForFile(rel); lang != "" {
+			breakdown[lang]++
+		}
+		return nil
+	})
+
+	return breakdown, err
+}
+
+// DetectFiles counts the given files by language, as Detect does for a tree
+// it walks. Paths may be absolute or relative to repoPath; files in hidden and
+// vendored directories or matching an exclude pattern are not counted.
+func DetectFiles(repoPath string, files []string, excludes []string) Breakdown {
+	breakdown := make(Breakdown)
+	for _, file := range files {
+		rel := file
+		if filepath.IsAbs(file) {
+			var err error
+			if rel, err = filepath.Rel(repoPath, file); err != nil {
+				continue
+			}
+		}
+		rel = path.Clean(filepath.ToSlash(rel))
+		if strings.HasPrefix(rel, "../") || Excluded(rel, excludes) || inSkippedDir(rel) {
+			continue
+		}
+		if lang := ForFile(rel); lang != "" {
+			breakdown[lang]++
+		}
+	}
+	return breakdown
+}
+
+// inSkippedDir reports whether a relative path is below a directory Detect skips
+func inSkippedDir(rel string) bool {
+	for dir := path.Dir(rel); dir != "." && dir != "/"; dir = path.Dir(dir) {
+		if SkipDir(path.Base(dir)) {
+			return true
+		}
+	}
+	return false
+}
+
+// Excluded reports whether a path relative to the repository matches any of
+// the exclude patterns. A pattern is a path.Match glob compared with the path
+// and each of its parent directories, both in full and by name, so "vendor/"
+// excludes every vendor directory, "src/gen" one directory and "*.pb.go" files
+// by name. A trailing slash is ignored.
+func Excluded(rel string, patterns []string) bool {
+	rel = path.Clean(filepath.ToSlash(rel))
+	for _, pattern := range patterns {
+		pattern = strings.TrimSuffix(pattern, "/")
+		if pattern == "" {
+			continue
+		}
+		for dir := rel; dir != "." && dir != "/"; dir = path.Dir(dir) {
+			if matched, _ := path.Match(pattern, dir); matched {
+				return true
+			}
+			if matched, _ := path.Match(pattern, path.Base(dir)); matched {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,107 @@
+package language
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func writeFiles(t *testing.T, root string, names ...string) {
+	t.Helper()
+	for _, name := range names {
+		path := filepath.Join(root, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte("x"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestDetect(t *testing.T) {
+	root := t.TempDir()
+	writeFiles(t, root,
+		"main.go", "cmd/tool/main.go",
+		"web/app.ts", "web/index.js", "web/legacy/old.js", "web/legacy/older.js",
+		"scripts/build.sh",
+		"README.md", "config.yaml",
+		"node_modules/lib/index.js", "vendor/dep/dep.go", ".github/scripts/ci.sh",
+	)
+
+	breakdown, err := Detect(root, nil)
+	if err != nil {
+		t.Fatalf("Detect failed: %v", err)
+	}
+	expected := Breakdown{"go": 2, "javascript": 4, "shell": 1}
+	if !reflect.DeepEqual(breakdown, expected) {
+		t.Errorf("Expected %v, got %v", expected, breakdown)
+	}
+	if breakdown.Primary() != "javascript" {
+		t.Errorf("Expected javascript, got %s", breakdown.Primary())
+	}
+
+	// Excluding the legacy directory and shell scripts ties go and javascript
+	breakdown, err = Detect(root, []string{"web/legacy/", "*.sh"})
+	if err != nil {
+		t.Fatalf("Detect failed: %v", err)
+	}
+	expected = Breakdown{"go": 2, "javascript": 2}
+	if !reflect.DeepEqual(breakdown, expected) {
+		t.Errorf("Expected %v, got %v", expected, breakdown)
+	}
+	if breakdown.Primary() != "go" {
+		t.Errorf("Expected ties to go to go, got %s", breakdown.Primary())
+	}
+}
+
+func TestDetectFiles(t *testing.T) {
+	root := t.TempDir()
+	files := []string{
+		"main.go", filepath.Join(root, "cmd", "tool", "main.go"), "web/app.ts",
+		"README.md", "vendor/dep/dep.go", ".github/scripts/ci.sh", "gen/api.pb.go", "../outside.go",
+	}
+
+	breakdown := DetectFiles(root, files, []string{"*.pb.go"})
+	expected := Breakdown{"go": 2, "javascript": 1}
+	if !reflect.DeepEqual(breakdown, expected) {
+		t.Errorf("Expected %v, got %v", expected, breakdown)
+	}
+}
+
+func TestDetect_NoSourceFiles(t *testing.T) {
+	root := t.TempDir()
+	writeFiles(t, root, "README.md")
+
+	breakdown, err := Detect(root, nil)
+	if err != nil {
+		t.Fatalf("Detect failed: %v", err)
+	}
+	if primary := breakdown.Primary(); primary != "" {
+		t.Errorf("Expected no language, got %s", primary)
+	}
+
+	if _, err := Detect(filepath.Join(root, "missing"), nil); err == nil {
+		t.Error("Expected an error for a missing repository")
+	}
+}
+
+func TestExcluded(t *testing.T) {
+	patterns := []string{"node_modules/", "src/gen", "*.pb.go", "*_test.go"}
+	tests := map[string]bool{
+		"node_modules/lib/index.js":     true,
+		"web/node_modules/lib/index.js": true,
+		"src/gen/types.go":              true,
+		"api/v1/service.pb.go":          true,
+		"pkg/handler_test.go":           true,
+		"pkg/handler.go":                false,
+		"lib/src/gen/types.go":          false,
+		"my_node_modules/index.js":      false,
+	}
+	for rel, expected := range tests {
+		if got := Excluded(rel, patterns); got != expected {
+			t.Errorf("%q: expected %v, got %v", rel, expected, got)
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package language
+
+import (
+	"io"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// generatedHeaderLength is the number of leading bytes searched for a
+// generated-code marker; generators put the marker in the file header
+const generatedHeaderLength = 4 * 1024
+
+var (
+	// commentLinePattern matches lines that start with a comment in the
+	// languages the analyzers support
+	commentLinePattern = regexp.MustCompile(`^\s*(//|#|/\*|\*|--|<!--)`)
+	// generatedAnnotationPattern matches Java's @Generated annotation
+	generatedAnnotationPattern = regexp.MustCompile(`^\s*@(javax\.annotation\.(processing\.)?)?Generated\b`)
+)
+
+// IsGenerated reports whether a file header marks the file as generated code:
+// a comment containing both "generated" and "DO NOT EDIT", like Go's
+// "// Code generated ... DO NOT EDIT." and protoc's Python header, the
+// "@generated" marker used by many JavaScript, Java and Python generators,
+// .NET's "<auto-generated>", or Java's @Generated annotation
+func IsGenerated(header string) bool {
+	for _, line := range strings.Split(header, "\n") {
+		if generatedAnnotationPattern.MatchString(line) {
+			return true
+		}
+		if !commentLinePattern.MatchString(line) {
+			continue
+		}
+		lower := strings.ToLower(line)
+		if strings.Contains(lower, "@generated") || strings.Contains(lower, "<auto-generated") {
+			return true
+		}
+		if strings.Contains(lower, "generated") && strings.Contains(lower, "do not edit") {
+			return true
+		}
+	}
+	return false
+}
+
+// SkipGenerated returns the files whose header does not mark them as
+// generated, and how many were skipped. Files that cannot be read are kept so
+// that the analyzer reports the error.
+func SkipGenerated(files []string) ([]string, int) {
+	kept := make([]string, 0, len(files))
+	for _, file := range files {
+		if generated, err := fileIsGenerated(file); err == nil && generated {
+			continue
+		}
+		kept = append(kept, file)
+	}
+	return kept, len(files) - len(kept)
+}
+
+// fileIsGenerated reads the header of a file and checks it for a generated-code marker
+func fileIsGenerated(path string) (bool, error) {
+	file, err := os.Open(path) //nolint:gosec // Callers pass files found within the repository
+	if err != nil {
+		return false, err
+	}
+	defer func() { _ = file.Close() }()
+
+	header, err := io.ReadAll(io.LimitReader(file, generatedHeaderLength))
+	if err != nil {
+		return false, err
+	}
+	return IsGenerated(string(header)), nil
+}
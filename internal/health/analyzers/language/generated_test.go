@@ -0,0 +1,58 @@
+package language
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestIsGenerated(t *testing.T) {
+	tests := []struct {
+		name   string
+		header string
+		want   bool
+	}{
+		{"go", "// Code generated by protoc-gen-go. DO NOT EDIT.\n\npackage api\n", true},
+		{"go after license", "// Copyright 2024\n\n// Code generated by mockgen. DO NOT EDIT.\npackage mocks\n", true},
+		{"python protoc", "# -*- coding: utf-8 -*-\n# Generated by the protocol buffer compiler.  DO NOT EDIT!\n", true},
+		{"javascript", "/**\n * @generated\n */\n'use strict';\n", true},
+		{"csharp", "// <auto-generated>\n//     This code was generated by a tool.\n", true},
+		{"java annotation", "package api;\n\n@javax.annotation.processing.Generated(\"protoc\")\npublic class Api {}\n", true},
+		{"handwritten", "package main\n\n// Generated reports are written to out/\nfunc main() {}\n", false},
+		{"marker in code", "package main\n\nconst header = \"// Code generated by x. DO NOT EDIT.\"\n", false},
+		{"do not edit only", "# DO NOT EDIT: managed by ansible\n", false},
+	}
+
+	for _, tt := range tests {
+		if got := IsGenerated(tt.header); got != tt.want {
+			t.Errorf("%s: IsGenerated() = %t, want %t", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestSkipGenerated(t *testing.T) {
+	root := t.TempDir()
+	files := map[string]string{
+		"main.go":     "package main\n",
+		"zz_gen.go":   "// Code generated by controller-gen. DO NOT EDIT.\npackage main\n",
+		"missing.go":  "",
+		"bindata.py":  "# @generated by bindata\n",
+		"handmade.py": "x = 1\n",
+	}
+	var paths []string
+	for name, content := range files {
+		path := filepath.Join(root, name)
+		paths = append(paths, path)
+		if name == "missing.go" {
+			continue // Unreadable files are kept for the analyzer to report
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	kept, skipped := SkipGenerated(paths)
+	if skipped != 2 || len(kept) != 3 {
+		t.Errorf("Expected 2 generated files skipped and 3 kept, got %d skipped and %v kept", skipped, kept)
+	}
+}
@@ -0,0 +1,41 @@
+package language
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"strings"
+	"unicode/utf8"
+)
+
+// ErrBinaryFile is returned by ReadText for files whose content looks binary
+var ErrBinaryFile = errors.New("binary file")
+
+// sniffLength is the number of leading bytes inspected by IsBinary
+const sniffLength = 8 * 1024
+
+// IsBinary reports whether content looks binary: it has a NUL byte in its
+// first 8KB, like git's own heuristic
+func IsBinary(data []byte) bool {
+	if len(data) > sniffLength {
+		data = data[:sniffLength]
+	}
+	return bytes.IndexByte(data, 0) >= 0
+}
+
+// ReadText reads a source file for line-based scanning. Files that look binary
+// return ErrBinaryFile. Invalid UTF-8 sequences are replaced with U+FFFD, which
+// keeps newlines, and therefore line numbers, where they were.
+func ReadText(path string) (string, error) {
+	data, err := os.ReadFile(path) //nolint:gosec // Callers pass files found within the repository
+	if err != nil {
+		return "", err
+	}
+	if IsBinary(data) {
+		return "", ErrBinaryFile
+	}
+	if !utf8.Valid(data) {
+		return strings.ToValidUTF8(string(data), string(utf8.RuneError)), nil
+	}
+	return string(data), nil
+}
@@ -0,0 +1,41 @@
+package language
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestReadText(t *testing.T) {
+	root := t.TempDir()
+	files := map[string]string{
+		"binary.go":  "package main\x00\x01\x02",
+		"latin1.py":  "# caf\xe9\nx = 1  # TODO\n",
+		"late_nul.c": strings.Repeat("a", sniffLength) + "\x00",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(root, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if _, err := ReadText(filepath.Join(root, "binary.go")); !errors.Is(err, ErrBinaryFile) {
+		t.Errorf("Expected ErrBinaryFile for a NUL byte, got %v", err)
+	}
+
+	content, err := ReadText(filepath.Join(root, "latin1.py"))
+	if err != nil {
+		t.Fatalf("ReadText failed: %v", err)
+	}
+	lines := strings.Split(content, "\n")
+	if len(lines) != 3 || lines[0] != "# caf�" || lines[1] != "x = 1  # TODO" {
+		t.Errorf("Expected invalid UTF-8 replaced in place, got %q", lines)
+	}
+
+	// Only the first 8KB are sniffed
+	if _, err := ReadText(filepath.Join(root, "late_nul.c")); err != nil {
+		t.Errorf("Expected a NUL byte after 8KB to be ignored, got %v", err)
+	}
+}
@@ -0,0 +1,63 @@
+// Package parallel runs per-file analysis on a bounded pool of workers.
+package parallel
+
+import (
+	"context"
+	"runtime"
+	"sync"
+
+	"github.com/codcod/repos/internal/core"
+)
+
+// FileResult holds the outcome of analyzing a single file
+type FileResult struct {
+	Path     string
+	Analysis *core.FileAnalysis
+	Err      error
+}
+
+// AnalyzeFiles analyzes files on up to workers goroutines and returns the results
+// in the same order as files, so callers can merge them deterministically.
+// A workers value of zero or less uses GOMAXPROCS. It stops handing out files once
+// ctx is cancelled and returns ctx.Err() in that case.
+func AnalyzeFiles(ctx context.Context, files []string, workers int, analyze func(path string) (*core.FileAnalysis, error)) ([]FileResult, error) {
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	if workers > len(files) {
+		workers = len(files)
+	}
+
+	results := make([]FileResult, len(files))
+	indexes := make(chan int)
+
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				// Each worker writes only its own slots, so no locking is needed
+				analysis, err := analyze(files[i])
+				results[i] = FileResult{Path: files[i], Analysis: analysis, Err: err}
+			}
+		}()
+	}
+
+	for i := range files {
+		if ctx.Err() != nil {
+			break
+		}
+		select {
+		case indexes <- i:
+		case <-ctx.Done():
+		}
+	}
+	close(indexes)
+	wg.Wait()
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
@@ -0,0 +1,66 @@
+package parallel
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+
+	"github.com/codcod/repos/internal/core"
+)
+
+func TestAnalyzeFiles_PreservesOrder(t *testing.T) {
+	files := make([]string, 100)
+	for i := range files {
+		files[i] = fmt.Sprintf("file%03d.go", i)
+	}
+
+	results, err := AnalyzeFiles(context.Background(), files, 8, func(path string) (*core.FileAnalysis, error) {
+		if path == "file042.go" {
+			return nil, errors.New("parse error")
+		}
+		return &core.FileAnalysis{Path: path}, nil
+	})
+	if err != nil {
+		t.Fatalf("AnalyzeFiles failed: %v", err)
+	}
+
+	if len(results) != len(files) {
+		t.Fatalf("Expected %d results, got %d", len(files), len(results))
+	}
+	for i, result := range results {
+		if result.Path != files[i] {
+			t.Fatalf("Result %d is for %s, want %s", i, result.Path, files[i])
+		}
+		if i == 42 {
+			if result.Err == nil {
+				t.Error("Expected the error for file042.go to be preserved")
+			}
+			continue
+		}
+		if result.Err != nil || result.Analysis.Path != files[i] {
+			t.Errorf("Unexpected result for %s: %+v", files[i], result)
+		}
+	}
+}
+
+func TestAnalyzeFiles_StopsOnCancel(t *testing.T) {
+	files := make([]string, 1000)
+	ctx, cancel := context.WithCancel(context.Background())
+
+	var analyzed int32
+	_, err := AnalyzeFiles(ctx, files, 2, func(path string) (*core.FileAnalysis, error) {
+		if atomic.AddInt32(&analyzed, 1) == 10 {
+			cancel()
+		}
+		return &core.FileAnalysis{}, nil
+	})
+
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+	if n := atomic.LoadInt32(&analyzed); n >= int32(len(files)) {
+		t.Errorf("Expected analysis to stop early, analyzed %d files", n)
+	}
+}
@@ -2,33 +2,37 @@ package python_analyzer
 
 import (
 	"context"
-	"os"
+	"errors"
 	"path/filepath"
 	"regexp"
 	"strings"
 
 	"github.com/codcod/repos/internal/core"
+	"github.com/codcod/repos/internal/health/analyzers/language"
+	"github.com/codcod/repos/internal/health/analyzers/parallel"
 )
 
 // PythonAnalyzer implements language-specific analysis for Python code
 type PythonAnalyzer struct {
-	name       string
-	language   string
-	extensions []string
-	excludes   []string
-	filesystem core.FileSystem
-	logger     core.Logger
+	name         string
+	language     string
+	extensions   []string
+	excludes     []string
+	testPatterns []string
+	filesystem   core.FileSystem
+	logger       core.Logger
 }
 
 // NewPythonAnalyzer creates a new Python language analyzer
 func NewPythonAnalyzer(fs core.FileSystem, logger core.Logger) *PythonAnalyzer {
 	return &PythonAnalyzer{
-		name:       "python-analyzer",
-		language:   "python",
-		extensions: []string{".py"},
-		excludes:   []string{".venv/", "__pycache__/", ".git/", "venv/", "env/", ".pytest_cache/"},
-		filesystem: fs,
-		logger:     logger,
+		name:         "python-analyzer",
+		language:     "python",
+		extensions:   []string{".py"},
+		excludes:     []string{".venv/", "__pycache__/", ".git/", "venv/", "env/", ".pytest_cache/"},
+		testPatterns: []string{"test_*.py", "*_test.py", "conftest.py"},
+		filesystem:   fs,
+		logger:       logger,
 	}
 }
 
@@ -50,7 +54,7 @@ func (p *PythonAnalyzer) SupportedExtensions() []string {
 // CanAnalyze checks if the analyzer can process the given repository
 func (p *PythonAnalyzer) CanAnalyze(repo core.Repository) bool {
 	// Check if repository has Python files
-	return p.hasPythonFiles(repo.Path)
+	return p.hasPythonFiles(repo.FileIndex())
 }
 
 // Analyze performs language-specific analysis on the repository
@@ -65,26 +69,44 @@ func (p *PythonAnalyzer) Analyze(ctx context.Context, repoPath string, config co
 	}
 
 	// Find Python files
-	files, err := p.findPythonFiles(repoPath)
+	files, walkErrors, err := p.findPythonFiles(config.FileIndex(repoPath), config.Extensions(p.extensions), config.Excludes(p.excludes, p.testPatterns))
 	if err != nil {
 		return nil, err
 	}
+	result.Errors = append(result.Errors, walkErrors...)
+
+	// Restrict to explicitly included files (e.g. changed files only)
+	files = config.FilterFiles(files)
+
+	// Skip generated code unless it is explicitly included
+	generatedSkipped := 0
+	if !config.IncludeGenerated {
+		files, generatedSkipped = language.SkipGenerated(files)
+	}
 
 	totalComplexity := 0
 	totalFunctions := 0
 	maxComplexity := 0
+	maxFunctionLines := 0
+	binarySkipped := 0
 
-	// Analyze each file
-	for _, file := range files {
-		if ctx.Err() != nil {
-			return nil, ctx.Err()
-		}
+	// Analyze files on a bounded worker pool; results come back in file order
+	fileResults, err := parallel.AnalyzeFiles(ctx, files, config.Concurrency, p.analyzeFile)
+	if err != nil {
+		return nil, err
+	}
 
-		fileAnalysis, err := p.analyzeFile(file)
-		if err != nil {
+	for _, fileResult := range fileResults {
+		file, fileAnalysis := fileResult.Path, fileResult.Analysis
+		if errors.Is(fileResult.Err, language.ErrBinaryFile) {
+			binarySkipped++
+			continue
+		}
+		if fileResult.Err != nil {
 			p.logger.Warn("Failed to analyze file",
 				core.Field{Key: "file", Value: file},
-				core.Field{Key: "error", Value: err.Error()})
+				core.Field{Key: "error", Value: fileResult.Err.Error()})
+			result.Errors = append(result.Errors, core.AnalysisError{Path: file, Reason: fileResult.Err.Error()})
 			continue
 		}
 
@@ -98,9 +120,13 @@ func (p *PythonAnalyzer) Analyze(ctx context.Context, repoPath string, config co
 			if fn.Complexity > maxComplexity {
 				maxComplexity = fn.Complexity
 			}
+			maxFunctionLines = max(maxFunctionLines, fn.Lines())
 		}
 	}
 
+	// Order functions by file and line so output does not depend on scheduling
+	core.SortFunctions(result.Functions)
+
 	// Calculate metrics
 	avgComplexity := 0.0
 	if totalFunctions > 0 {
@@ -108,9 +134,12 @@ func (p *PythonAnalyzer) Analyze(ctx context.Context, repoPath string, config co
 	}
 
 	result.Metrics["total_files"] = len(result.Files)
+	result.Metrics["binary_files_skipped"] = binarySkipped
+	result.Metrics["generated_files_skipped"] = generatedSkipped
 	result.Metrics["total_functions"] = totalFunctions
 	result.Metrics["total_complexity"] = totalComplexity
 	result.Metrics["max_complexity"] = maxComplexity
+	result.Metrics["max_function_lines"] = maxFunctionLines
 	result.Metrics["average_complexity"] = avgComplexity
 
 	p.logger.Info("Python analysis completed",
@@ -121,48 +150,33 @@ func (p *PythonAnalyzer) Analyze(ctx context.Context, repoPath string, config co
 }
 
 // hasPythonFiles checks if the repository contains Python files
-func (p *PythonAnalyzer) hasPythonFiles(repoPath string) bool {
-	files, err := p.findPythonFiles(repoPath)
+func (p *PythonAnalyzer) hasPythonFiles(index *core.FileIndex) bool {
+	files, _, err := p.findPythonFiles(index, p.extensions, p.excludes)
 	return err == nil && len(files) > 0
 }
 
 // findPythonFiles finds all Python source files in the repository
-func (p *PythonAnalyzer) findPythonFiles(repoPath string) ([]string, error) {
-	var pythonFiles []string
-
-	err := filepath.Walk(repoPath, func(path string, info os.FileInfo, err error) error {
-		if err != nil {
-			return err
-		}
-
-		// Skip directories
-		if info.IsDir() {
-			return nil
-		}
-
-		// Check if it's a Python file
-		if !strings.HasSuffix(path, ".py") {
-			return nil
-		}
+func (p *PythonAnalyzer) findPythonFiles(index *core.FileIndex, extensions, excludes []string) ([]string, []core.AnalysisError, error) {
+	paths, walkErrors, err := index.Find(extensions)
+	if err != nil {
+		return nil, nil, err
+	}
 
+	var pythonFiles []string
+	for _, path := range paths {
 		// Skip excluded patterns
-		relPath, _ := filepath.Rel(repoPath, path)
-		for _, exclude := range p.excludes {
-			if strings.Contains(relPath, exclude) {
-				return nil
-			}
+		relPath, _ := filepath.Rel(index.Root(), path)
+		if !language.Excluded(relPath, excludes) {
+			pythonFiles = append(pythonFiles, path)
 		}
+	}
 
-		pythonFiles = append(pythonFiles, path)
-		return nil
-	})
-
-	return pythonFiles, err
+	return pythonFiles, walkErrors, nil
 }
 
 // analyzeFile analyzes a single Python file
 func (p *PythonAnalyzer) analyzeFile(filePath string) (*core.FileAnalysis, error) {
-	content, err := os.ReadFile(filePath) //nolint:gosec // File path is from repository analysis
+	content, err := language.ReadText(filePath)
 	if err != nil {
 		return nil, err
 	}
@@ -176,7 +190,7 @@ func (p *PythonAnalyzer) analyzeFile(filePath string) (*core.FileAnalysis, error
 	}
 
 	// Analyze functions and imports
-	functions, imports := p.parseFile(string(content), filePath)
+	functions, imports := p.parseFile(content, filePath)
 	analysis.Functions = functions
 	analysis.Imports = imports
 
@@ -267,6 +281,7 @@ func (p *PythonAnalyzer) parseFile(content, filePath string) ([]core.FunctionInf
 				Name:       functionName,
 				File:       filePath,
 				Line:       lineNum,
+				EndLine:    lineNum,
 				Complexity: 1, // Base complexity
 				Language:   p.language,
 			}
@@ -283,6 +298,7 @@ func (p *PythonAnalyzer) parseFile(content, filePath string) ([]core.FunctionInf
 			} else {
 				// Still inside function, calculate complexity
 				currentFunction.Complexity += p.calculateLineComplexity(trimmedLine)
+				currentFunction.EndLine = lineNum
 			}
 		}
 
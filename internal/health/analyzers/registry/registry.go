@@ -5,10 +5,13 @@ import (
 	"fmt"
 
 	"github.com/codcod/repos/internal/core"
+	dart_analyzer "github.com/codcod/repos/internal/health/analyzers/dart"
+	elixir_analyzer "github.com/codcod/repos/internal/health/analyzers/elixir"
 	golang "github.com/codcod/repos/internal/health/analyzers/go"
 	java_analyzer "github.com/codcod/repos/internal/health/analyzers/java"
 	javascript_analyzer "github.com/codcod/repos/internal/health/analyzers/javascript"
 	python_analyzer "github.com/codcod/repos/internal/health/analyzers/python"
+	shell_analyzer "github.com/codcod/repos/internal/health/analyzers/shell"
 )
 
 // Registry manages language analyzers
@@ -100,6 +103,15 @@ func (r *Registry) GetSupportedLanguages() []string {
 	return languages
 }
 
+// DefaultExtensions returns the built-in file extensions of each analyzer by language
+func (r *Registry) DefaultExtensions() map[string][]string {
+	extensions := make(map[string][]string, len(r.analyzers))
+	for language, analyzer := range r.analyzers {
+		extensions[language] = analyzer.SupportedExtensions()
+	}
+	return extensions
+}
+
 // BaseAnalyzer provides common functionality for analyzers
 type BaseAnalyzer struct {
 	language      string
@@ -162,6 +174,9 @@ func NewRegistryWithStandardAnalyzers(fs core.FileSystem, logger core.Logger) *R
 	registry.Register(python_analyzer.NewPythonAnalyzer(fs, logger))
 	registry.Register(java_analyzer.NewJavaAnalyzer(fs, logger))
 	registry.Register(javascript_analyzer.NewJavaScriptAnalyzer(fs, logger))
+	registry.Register(shell_analyzer.NewShellAnalyzer(fs, logger))
+	registry.Register(dart_analyzer.NewDartAnalyzer(fs, logger))
+	registry.Register(elixir_analyzer.NewElixirAnalyzer(fs, logger))
 
 	return registry
 }
@@ -0,0 +1,499 @@
+package shell_analyzer
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/codcod/repos/internal/core"
+	"github.com/codcod/repos/internal/health/analyzers/language"
+	"github.com/codcod/repos/internal/health/analyzers/parallel"
+)
+
+var (
+	// functionPattern matches "name() {" and "function name {" / "function name() {"
+	functionPattern = regexp.MustCompile(`^\s*(?:function\s+([A-Za-z_][\w:.-]*)\s*(?:\(\s*\))?|([A-Za-z_][\w:.-]*)\s*\(\s*\))\s*(\{)?`)
+	// heredocPattern matches the start of a here-document, but not a <<< here-string,
+	// and captures its delimiter
+	heredocPattern = regexp.MustCompile(`(?:^|[^<])<<-?\s*['"]?([A-Za-z_][A-Za-z0-9_]*)['"]?`)
+	// keywordPattern matches the decision keywords that start a command
+	keywordPattern = regexp.MustCompile(`(?:^|[;&|({]\s*|\bthen\s+|\bdo\s+|\belse\s+)(if|elif|for|while|until|select)\b`)
+	// casePattern matches the start of a case statement
+	casePattern = regexp.MustCompile(`(?:^|[;&|(]\s*)case\b.*\bin\b`)
+	// esacPattern matches the end of a case statement
+	esacPattern = regexp.MustCompile(`(?:^|[;\s])esac\b`)
+	// caseArmPattern matches a case arm such as "start|run)" or "(stop)"
+	caseArmPattern = regexp.MustCompile(`^\(?\s*[^()]+\)`)
+	// setPattern matches set builtin invocations
+	setPattern = regexp.MustCompile(`(?:^|[;&|]\s*)set\s+(.*)`)
+	// parameterPattern matches parameter expansions such as ${name:-default}
+	parameterPattern = regexp.MustCompile(`\$\{[^}]*\}`)
+	// stringPattern matches single- and double-quoted strings on one line
+	stringPattern = regexp.MustCompile(`'[^']*'|"(?:[^"\\]|\\.)*"`)
+)
+
+// ShellAnalyzer implements language-specific analysis for shell scripts
+type ShellAnalyzer struct {
+	name         string
+	language     string
+	extensions   []string
+	excludes     []string
+	testPatterns []string
+	filesystem   core.FileSystem
+	logger       core.Logger
+}
+
+// NewShellAnalyzer creates a new shell script analyzer
+func NewShellAnalyzer(fs core.FileSystem, logger core.Logger) *ShellAnalyzer {
+	return &ShellAnalyzer{
+		name:         "shell-analyzer",
+		language:     "shell",
+		extensions:   []string{".sh", ".bash"},
+		excludes:     []string{".git/", "node_modules/", "vendor/"},
+		testPatterns: []string{"test_*.sh", "*_test.sh"},
+		filesystem:   fs,
+		logger:       logger,
+	}
+}
+
+// Name returns the analyzer name
+func (s *ShellAnalyzer) Name() string {
+	return s.name
+}
+
+// Language returns the supported language
+func (s *ShellAnalyzer) Language() string {
+	return s.language
+}
+
+// SupportedExtensions returns supported file extensions
+func (s *ShellAnalyzer) SupportedExtensions() []string {
+	return s.extensions
+}
+
+// CanAnalyze checks if the analyzer can process the given repository
+func (s *ShellAnalyzer) CanAnalyze(repo core.Repository) bool {
+	files, _, err := s.findShellFiles(repo.FileIndex(), s.extensions, s.excludes)
+	return err == nil && len(files) > 0
+}
+
+// Analyze performs language-specific analysis on the repository
+func (s *ShellAnalyzer) Analyze(ctx context.Context, repoPath string, config core.AnalyzerConfig) (*core.AnalysisResult, error) {
+	s.logger.Info("Starting shell analysis", core.Field{Key: "repo", Value: repoPath})
+
+	result := &core.AnalysisResult{
+		Language:  s.language,
+		Files:     make(map[string]*core.FileAnalysis),
+		Functions: []core.FunctionInfo{},
+		Metrics:   make(map[string]interface{}),
+	}
+
+	files, walkErrors, err := s.findShellFiles(config.FileIndex(repoPath), config.Extensions(s.extensions), config.Excludes(s.excludes, s.testPatterns))
+	if err != nil {
+		return nil, err
+	}
+	result.Errors = append(result.Errors, walkErrors...)
+
+	// Restrict to explicitly included files (e.g. changed files only)
+	files = config.FilterFiles(files)
+
+	// Skip generated code unless it is explicitly included
+	generatedSkipped := 0
+	if !config.IncludeGenerated {
+		files, generatedSkipped = language.SkipGenerated(files)
+	}
+
+	fileResults, err := parallel.AnalyzeFiles(ctx, files, config.Concurrency, s.analyzeFile)
+	if err != nil {
+		return nil, err
+	}
+
+	binarySkipped := 0
+	var withoutStrictMode []string
+
+	for _, fileResult := range fileResults {
+		file, fileAnalysis := fileResult.Path, fileResult.Analysis
+		if errors.Is(fileResult.Err, language.ErrBinaryFile) {
+			binarySkipped++
+			continue
+		}
+		if fileResult.Err != nil {
+			s.logger.Warn("Failed to analyze file",
+				core.Field{Key: "file", Value: file},
+				core.Field{Key: "error", Value: fileResult.Err.Error()})
+			result.Errors = append(result.Errors, core.AnalysisError{Path: file, Reason: fileResult.Err.Error()})
+			continue
+		}
+
+		result.Files[file] = fileAnalysis
+		if strict, _ := fileAnalysis.Metrics["strict_mode"].(bool); !strict {
+			relPath, _ := filepath.Rel(repoPath, file)
+			withoutStrictMode = append(withoutStrictMode, relPath)
+		}
+
+		result.Functions = append(result.Functions, fileAnalysis.Functions...)
+	}
+
+	core.SortFunctions(result.Functions)
+	sort.Strings(withoutStrictMode)
+
+	result.Metrics["total_files"] = len(result.Files)
+	result.Metrics["binary_files_skipped"] = binarySkipped
+	result.Metrics["generated_files_skipped"] = generatedSkipped
+	recordFunctionMetrics(result)
+	result.Metrics["files_without_strict_mode"] = len(withoutStrictMode)
+	if len(withoutStrictMode) > 0 {
+		result.Metrics["strict_mode_warning"] = "missing 'set -euo pipefail' in: " + strings.Join(withoutStrictMode, ", ")
+	}
+
+	s.logger.Info("Shell analysis completed",
+		core.Field{Key: "files", Value: len(result.Files)},
+		core.Field{Key: "functions", Value: len(result.Functions)})
+
+	return result, nil
+}
+
+// recordFunctionMetrics records the function count, total, maximum and
+// average complexity and the longest function of the result
+func recordFunctionMetrics(result *core.AnalysisResult) {
+	totalComplexity, maxComplexity, maxFunctionLines := 0, 0, 0
+	for _, fn := range result.Functions {
+		totalComplexity += fn.Complexity
+		maxComplexity = max(maxComplexity, fn.Complexity)
+		maxFunctionLines = max(maxFunctionLines, fn.Lines())
+	}
+
+	avgComplexity := 0.0
+	if len(result.Functions) > 0 {
+		avgComplexity = float64(totalComplexity) / float64(len(result.Functions))
+	}
+
+	result.Metrics["total_functions"] = len(result.Functions)
+	result.Metrics["total_complexity"] = totalComplexity
+	result.Metrics["max_complexity"] = maxComplexity
+	result.Metrics["max_function_lines"] = maxFunctionLines
+	result.Metrics["average_complexity"] = avgComplexity
+}
+
+// findShellFiles finds all shell scripts in the repository
+func (s *ShellAnalyzer) findShellFiles(index *core.FileIndex, extensions, excludes []string) ([]string, []core.AnalysisError, error) {
+	paths, walkErrors, err := index.Find(extensions)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var shellFiles []string
+	for _, path := range paths {
+		relPath, _ := filepath.Rel(index.Root(), path)
+		relPath = filepath.ToSlash(relPath)
+		if !language.Excluded(relPath, excludes) {
+			shellFiles = append(shellFiles, path)
+		}
+	}
+
+	return shellFiles, walkErrors, nil
+}
+
+// analyzeFile analyzes a single shell script
+func (s *ShellAnalyzer) analyzeFile(filePath string) (*core.FileAnalysis, error) {
+	content, err := language.ReadText(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	functions, fileComplexity, strict := s.parseFile(content, filePath)
+
+	analysis := &core.FileAnalysis{
+		Path:       filePath,
+		Language:   s.language,
+		Lines:      strings.Count(content, "\n"),
+		Functions:  functions,
+		Imports:    []core.ImportInfo{},
+		Complexity: fileComplexity,
+		Metrics:    make(map[string]interface{}),
+	}
+
+	analysis.Metrics["function_count"] = len(functions)
+	analysis.Metrics["strict_mode"] = strict
+	if len(functions) > 0 {
+		totalComplexity := 0
+		for _, fn := range functions {
+			totalComplexity += fn.Complexity
+		}
+		analysis.Metrics["average_complexity"] = float64(totalComplexity) / float64(len(functions))
+	}
+
+	return analysis, nil
+}
+
+// shellFunction tracks a function while its body is being parsed
+type shellFunction struct {
+	info     core.FunctionInfo
+	depth    int  // brace depth outside the function body
+	open     bool // the body has started
+	subshell bool // the body is a ( ... ) subshell rather than a { ... } group
+}
+
+// opensAt reports whether a brace reaching depth opens the function's body
+func (fn *shellFunction) opensAt(depth int) bool {
+	return !fn.open && !fn.subshell && depth == fn.depth+1
+}
+
+// closesAt reports whether a brace returning to depth closes the function's body
+func (fn *shellFunction) closesAt(depth int) bool {
+	return fn.open && !fn.subshell && depth == fn.depth
+}
+
+// shellParser holds the state of parsing a shell script line by line
+type shellParser struct {
+	filePath       string
+	language       string
+	functions      []core.FunctionInfo
+	stack          []*shellFunction
+	fileComplexity int
+	depth          int    // brace depth
+	caseDepth      int    // nesting of case statements
+	heredoc        string // delimiter of the here-document being skipped
+	flags          map[string]bool
+}
+
+// parseFile extracts functions and their complexity from a shell script. It returns
+// the functions, the complexity of the whole file and whether the script enables
+// strict mode (errexit, nounset and pipefail).
+func (s *ShellAnalyzer) parseFile(content, filePath string) ([]core.FunctionInfo, int, bool) {
+	p := &shellParser{
+		filePath:       filePath,
+		language:       s.language,
+		fileComplexity: 1,
+		flags:          make(map[string]bool),
+	}
+	for i, rawLine := range strings.Split(content, "\n") {
+		p.parseLine(i, rawLine)
+	}
+
+	// Functions left open at the end of the file are still reported
+	for _, fn := range p.stack {
+		p.functions = append(p.functions, fn.info)
+	}
+	functions := p.functions
+	sort.SliceStable(functions, func(i, k int) bool { return functions[i].Line < functions[k].Line })
+
+	strict := p.flags["errexit"] && p.flags["nounset"] && p.flags["pipefail"]
+	return functions, p.fileComplexity, strict
+}
+
+// parseLine parses line i of the script
+func (p *shellParser) parseLine(i int, rawLine string) {
+	// Every open function, including enclosing ones, spans this line
+	for _, fn := range p.stack {
+		fn.info.EndLine = i + 1
+	}
+
+	if p.heredoc != "" {
+		if strings.TrimSpace(rawLine) == p.heredoc {
+			p.heredoc = ""
+		}
+		return
+	}
+
+	line := stripShellLine(rawLine)
+	if line == "" {
+		return
+	}
+	p.recordDirectives(rawLine, line)
+	p.trackFunctionStart(i, line)
+
+	complexity := p.lineComplexity(line)
+	p.fileComplexity += complexity
+	if fn := p.current(); fn != nil {
+		fn.info.Complexity += complexity
+	}
+
+	// Subshell bodies end at a line holding only the closing parenthesis
+	if fn := p.current(); fn != nil && fn.subshell && strings.TrimSpace(line) == ")" {
+		p.closeFunction()
+		return
+	}
+
+	p.trackBraces(line)
+}
+
+// recordDirectives records a here-document started on the line and the shell
+// options it sets
+func (p *shellParser) recordDirectives(rawLine, line string) {
+	// Quoted delimiters such as <<'EOF' are matched before strings are stripped
+	if match := heredocPattern.FindStringSubmatch(rawLine); match != nil && strings.Contains(line, "<<") {
+		p.heredoc = match[1]
+	}
+
+	for _, match := range setPattern.FindAllStringSubmatch(line, -1) {
+		recordSetFlags(match[1], p.flags)
+	}
+}
+
+// trackFunctionStart starts a function defined on line i, or opens the
+// subshell body of the current function
+func (p *shellParser) trackFunctionStart(i int, line string) {
+	match := functionPattern.FindStringSubmatch(line)
+	if match == nil || isKeyword(match[2]) {
+		p.openSubshellBody(line)
+		return
+	}
+
+	// A function whose body never opened with a brace, such as one
+	// defined with a subshell body, ends where the next one starts
+	if fn := p.current(); fn != nil && !fn.open {
+		fn.info.EndLine = i
+		p.closeFunction()
+	}
+	name := match[1]
+	if name == "" {
+		name = match[2]
+	}
+	fn := &shellFunction{
+		info: core.FunctionInfo{
+			Name:       name,
+			File:       p.filePath,
+			Line:       i + 1,
+			EndLine:    i + 1,
+			Complexity: 1,
+			Language:   p.language,
+		},
+		depth: p.depth,
+	}
+	if match[3] == "" && strings.HasPrefix(strings.TrimSpace(line[len(match[0]):]), "(") {
+		fn.open = true
+		fn.subshell = true
+	}
+	p.stack = append(p.stack, fn)
+}
+
+// openSubshellBody opens the body of a function defined on an earlier line
+// when the line starts it with a subshell
+func (p *shellParser) openSubshellBody(line string) {
+	if fn := p.current(); fn != nil && !fn.open && strings.HasPrefix(strings.TrimSpace(line), "(") {
+		fn.open = true
+		fn.subshell = true
+	}
+}
+
+// lineComplexity counts the decision points of a line: branching keywords,
+// && and || operators, and case arms other than the default one
+func (p *shellParser) lineComplexity(line string) int {
+	complexity := len(keywordPattern.FindAllStringIndex(line, -1))
+	complexity += strings.Count(line, "&&") + strings.Count(line, "||")
+
+	trimmed := strings.TrimSpace(line)
+	if casePattern.MatchString(line) {
+		p.caseDepth++
+		// The first arm may follow "in" on the same line
+		_, rest, _ := strings.Cut(trimmed, " in ")
+		trimmed = strings.TrimSpace(rest)
+	}
+	if p.caseDepth > 0 && isCaseArm(trimmed) {
+		complexity++
+	}
+	if p.caseDepth > 0 && esacPattern.MatchString(line) {
+		p.caseDepth--
+	}
+	return complexity
+}
+
+// isCaseArm reports whether a line inside a case statement starts an arm
+// other than the default *) one
+func isCaseArm(trimmed string) bool {
+	if trimmed == "" || strings.HasPrefix(trimmed, "esac") || !caseArmPattern.MatchString(trimmed) {
+		return false
+	}
+	arm := strings.TrimSpace(strings.TrimPrefix(trimmed, "("))
+	return !strings.HasPrefix(arm, "*)")
+}
+
+// trackBraces follows brace groups to find where function bodies open and end
+func (p *shellParser) trackBraces(line string) {
+	for _, ch := range line {
+		switch ch {
+		case '{':
+			p.depth++
+			if fn := p.current(); fn != nil && fn.opensAt(p.depth) {
+				fn.open = true
+			}
+		case '}':
+			p.depth--
+			if fn := p.current(); fn != nil && fn.closesAt(p.depth) {
+				p.closeFunction()
+			}
+		}
+	}
+}
+
+// current returns the innermost open function, or nil outside functions
+func (p *shellParser) current() *shellFunction {
+	if len(p.stack) == 0 {
+		return nil
+	}
+	return p.stack[len(p.stack)-1]
+}
+
+// closeFunction ends the innermost open function
+func (p *shellParser) closeFunction() {
+	p.functions = append(p.functions, p.current().info)
+	p.stack = p.stack[:len(p.stack)-1]
+}
+
+// stripShellLine removes comments, quoted strings and parameter expansions so
+// that keywords, operators and braces inside them are not counted
+func stripShellLine(line string) string {
+	line = parameterPattern.ReplaceAllString(line, "$v")
+	line = stringPattern.ReplaceAllString(line, `""`)
+
+	for i, ch := range line {
+		if ch != '#' {
+			continue
+		}
+		if i == 0 || line[i-1] == ' ' || line[i-1] == '\t' || line[i-1] == ';' {
+			line = line[:i]
+			break
+		}
+	}
+	return strings.TrimSpace(line)
+}
+
+// recordSetFlags records the shell options enabled by a set invocation
+func recordSetFlags(args string, flags map[string]bool) {
+	fields := strings.Fields(args)
+	for i := 0; i < len(fields); i++ {
+		field := fields[i]
+		switch {
+		case field == "-o" && i+1 < len(fields):
+			flags[fields[i+1]] = true
+			i++
+		case strings.HasPrefix(field, "-") && !strings.HasPrefix(field, "--"):
+			opts := strings.TrimPrefix(field, "-")
+			if strings.Contains(opts, "e") {
+				flags["errexit"] = true
+			}
+			if strings.Contains(opts, "u") {
+				flags["nounset"] = true
+			}
+			// -o may be combined with other flags, as in "set -euo pipefail"
+			if strings.HasSuffix(opts, "o") && i+1 < len(fields) {
+				flags[fields[i+1]] = true
+				i++
+			}
+		}
+	}
+}
+
+// isKeyword reports whether name is a shell keyword that looks like a function
+// definition when followed by parentheses, such as a subshell after "if"
+func isKeyword(name string) bool {
+	switch name {
+	case "if", "elif", "then", "else", "fi", "for", "while", "until", "do", "done", "case", "esac", "in", "select":
+		return true
+	}
+	return false
+}
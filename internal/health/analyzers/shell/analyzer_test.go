@@ -0,0 +1,165 @@
+package shell_analyzer
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/codcod/repos/internal/core"
+)
+
+type testLogger struct{}
+
+func (testLogger) Debug(string, ...core.Field) {}
+func (testLogger) Info(string, ...core.Field)  {}
+func (testLogger) Warn(string, ...core.Field)  {}
+func (testLogger) Error(string, ...core.Field) {}
+func (testLogger) Fatal(string, ...core.Field) {}
+
+const deployScript = `#!/usr/bin/env bash
+set -euo pipefail
+
+# if this comment mentioned for loops it would not count
+usage() {
+  echo "usage: deploy [start|stop] && more"
+}
+
+function deploy {
+  local target="${1:-prod}"
+  if [[ -z "$target" ]] || [[ "$target" == "none" ]]; then
+    usage
+  elif [ "$target" = "dev" ]; then
+    for host in a b; do
+      ssh "$host" true && echo ok
+    done
+  fi
+  case "$target" in
+    start|run) echo start ;;
+    stop) echo stop ;;
+    *) echo other ;;
+  esac
+  cat <<'END'
+if while until
+END
+}
+
+cleanup() (
+  rm -rf /tmp/x
+)
+
+while read -r line; do
+  deploy "$line"
+done
+`
+
+func TestShellAnalyzer_ParseFile(t *testing.T) {
+	analyzer := NewShellAnalyzer(nil, testLogger{})
+	functions, fileComplexity, strict := analyzer.parseFile(deployScript, "deploy.sh")
+
+	expected := []struct {
+		name       string
+		line       int
+		endLine    int
+		complexity int
+	}{
+		{"usage", 5, 7, 1},
+		// if, ||, elif, for, &&, and the start|run and stop case arms
+		{"deploy", 9, 26, 8},
+		{"cleanup", 28, 30, 1},
+	}
+	if len(functions) != len(expected) {
+		t.Fatalf("Expected %d functions, got %+v", len(expected), functions)
+	}
+	for i, want := range expected {
+		got := functions[i]
+		if got.Name != want.name || got.Line != want.line || got.Complexity != want.complexity {
+			t.Errorf("Expected %s at line %d with complexity %d, got %s at line %d with complexity %d",
+				want.name, want.line, want.complexity, got.Name, got.Line, got.Complexity)
+		}
+		if got.EndLine != want.endLine {
+			t.Errorf("Expected %s to end at line %d, got %d", want.name, want.endLine, got.EndLine)
+		}
+	}
+
+	// Base 1, the seven deploy decision points and the top-level while loop
+	if fileComplexity != 9 {
+		t.Errorf("Expected file complexity 9, got %d", fileComplexity)
+	}
+	if !strict {
+		t.Error("Expected set -euo pipefail to enable strict mode")
+	}
+}
+
+func TestRecordSetFlags(t *testing.T) {
+	tests := []struct {
+		lines  []string
+		strict bool
+	}{
+		{[]string{"-euo pipefail"}, true},
+		{[]string{"-e", "-u", "-o pipefail"}, true},
+		{[]string{"-o errexit -o nounset -o pipefail"}, true},
+		{[]string{"-eu"}, false},
+		{[]string{"-x"}, false},
+	}
+	for _, tt := range tests {
+		flags := make(map[string]bool)
+		for _, line := range tt.lines {
+			recordSetFlags(line, flags)
+		}
+		if strict := flags["errexit"] && flags["nounset"] && flags["pipefail"]; strict != tt.strict {
+			t.Errorf("set %v: expected strict=%v, got %v", tt.lines, tt.strict, strict)
+		}
+	}
+}
+
+func TestShellAnalyzer_Analyze(t *testing.T) {
+	repoPath := t.TempDir()
+	files := map[string]string{
+		"scripts/deploy.sh":           deployScript,
+		"build.bash":                  "#!/bin/bash\nbuild() {\n  make || exit 1\n}\n",
+		"node_modules/pkg/install.sh": "#!/bin/sh\ninstall() { true; }\n",
+		"scripts/notes.txt":           "not a script\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(repoPath, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	analyzer := NewShellAnalyzer(nil, testLogger{})
+	if !analyzer.CanAnalyze(core.Repository{Path: repoPath}) {
+		t.Fatal("Expected repository with shell scripts to be analyzable")
+	}
+
+	result, err := analyzer.Analyze(context.Background(), repoPath, core.AnalyzerConfig{})
+	if err != nil {
+		t.Fatalf("Analyze failed: %v", err)
+	}
+
+	if len(result.Files) != 2 {
+		t.Errorf("Expected node_modules to be excluded, got files %v", result.Files)
+	}
+	if result.Metrics["total_functions"] != 4 {
+		t.Errorf("Expected 4 functions, got %v", result.Metrics["total_functions"])
+	}
+	if result.Metrics["files_without_strict_mode"] != 1 {
+		t.Errorf("Expected build.bash to lack strict mode, got %v", result.Metrics)
+	}
+	if result.Metrics["strict_mode_warning"] != "missing 'set -euo pipefail' in: build.bash" {
+		t.Errorf("Unexpected strict mode warning: %v", result.Metrics["strict_mode_warning"])
+	}
+
+	// Configured extensions replace the defaults
+	result, err = analyzer.Analyze(context.Background(), repoPath, core.AnalyzerConfig{FileExtensions: []string{".bash"}})
+	if err != nil {
+		t.Fatalf("Analyze failed: %v", err)
+	}
+	if _, ok := result.Files[filepath.Join(repoPath, "build.bash")]; len(result.Files) != 1 || !ok {
+		t.Errorf("Expected only build.bash with configured extensions, got files %v", result.Files)
+	}
+}
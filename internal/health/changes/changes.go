@@ -0,0 +1,109 @@
+// Package changes restricts analysis results to the lines changed since a git
+// ref, so that complexity reports cover only the code a change touches.
+package changes
+
+import (
+	"path/filepath"
+	"sort"
+
+	"github.com/codcod/repos/internal/core"
+	"github.com/codcod/repos/internal/git"
+)
+
+// FilterChangedFunctions keeps only the functions of result that overlap the
+// changed lines of their file, as returned by git.ChangedLines for the
+// repository at repoPath. A function whose end is unknown is taken to extend to
+// the next function in its file. The functions of each file and the totals and
+// complexity metrics are updated to match. It returns how many functions were
+// dropped.
+func FilterChangedFunctions(result *core.AnalysisResult, repoPath string, changed map[string][]git.LineRange) int {
+	if result == nil {
+		return 0
+	}
+
+	// Analyzers report the files they discover under the repository path
+	byPath := make(map[string][]git.LineRange, len(changed))
+	for file, lines := range changed {
+		byPath[filepath.Join(repoPath, filepath.FromSlash(file))] = lines
+	}
+
+	starts := make(map[string][]int)
+	for _, fn := range result.Functions {
+		starts[fn.File] = append(starts[fn.File], fn.Line)
+	}
+	for _, lines := range starts {
+		sort.Ints(lines)
+	}
+	isChanged := func(fn core.FunctionInfo) bool {
+		end := fn.EndLine
+		if end < fn.Line {
+			end = nextFunctionStart(starts[fn.File], fn.Line) - 1
+		}
+		return overlapsAny(byPath[filepath.Clean(fn.File)], fn.Line, end)
+	}
+
+	kept := keepFunctions(result.Functions, isChanged)
+	dropped := len(result.Functions) - len(kept)
+	result.Functions = kept
+	for path, file := range result.Files {
+		if file != nil {
+			result.Files[path] = filterFile(file, isChanged)
+		}
+	}
+	result.RecomputeTotals()
+	return dropped
+}
+
+// keepFunctions returns the functions keep reports true for, in a new slice
+func keepFunctions(functions []core.FunctionInfo, keep func(core.FunctionInfo) bool) []core.FunctionInfo {
+	kept := make([]core.FunctionInfo, 0, len(functions))
+	for _, fn := range functions {
+		if keep(fn) {
+			kept = append(kept, fn)
+		}
+	}
+	return kept
+}
+
+// filterFile returns a copy of a file's analysis with only the functions keep
+// reports true for, and its function count and average complexity updated.
+// The file itself is left unchanged, as it may be shared with a cache.
+func filterFile(file *core.FileAnalysis, keep func(core.FunctionInfo) bool) *core.FileAnalysis {
+	filtered := *file
+	filtered.Functions = keepFunctions(file.Functions, keep)
+	filtered.Metrics = make(map[string]interface{}, len(file.Metrics))
+	for key, value := range file.Metrics {
+		filtered.Metrics[key] = value
+	}
+
+	totalComplexity := 0
+	for _, fn := range filtered.Functions {
+		totalComplexity += fn.Complexity
+	}
+	filtered.Metrics["function_count"] = len(filtered.Functions)
+	delete(filtered.Metrics, "average_complexity")
+	if len(filtered.Functions) > 0 {
+		filtered.Metrics["average_complexity"] = float64(totalComplexity) / float64(len(filtered.Functions))
+	}
+	return &filtered
+}
+
+// nextFunctionStart returns the first start after line, or the largest int if
+// there is none
+func nextFunctionStart(starts []int, line int) int {
+	i := sort.SearchInts(starts, line+1)
+	if i == len(starts) {
+		return int(^uint(0) >> 1)
+	}
+	return starts[i]
+}
+
+// overlapsAny reports whether any range overlaps the lines from start to end
+func overlapsAny(ranges []git.LineRange, start, end int) bool {
+	for _, r := range ranges {
+		if r.Start <= end && r.End >= start {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,60 @@
+package changes
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/codcod/repos/internal/core"
+	"github.com/codcod/repos/internal/git"
+)
+
+func TestFilterChangedFunctions(t *testing.T) {
+	repoPath := "/src/app"
+	file := filepath.Join(repoPath, "main.go")
+	other := filepath.Join(repoPath, "util.go")
+	functions := []core.FunctionInfo{
+		{Name: "unchanged", File: file, Line: 1, EndLine: 9, Complexity: 20},
+		{Name: "changed", File: file, Line: 10, EndLine: 20, Complexity: 4},
+		{Name: "endUnknown", File: file, Line: 30, Complexity: 8},
+		{Name: "next", File: file, Line: 50, EndLine: 60, Complexity: 20},
+		{Name: "otherFile", File: other, Line: 10, EndLine: 20, Complexity: 20},
+	}
+	mainFile := &core.FileAnalysis{Path: file, Lines: 60, Functions: functions[:4],
+		Metrics: map[string]interface{}{"function_count": 4, "average_complexity": 13.0}}
+	result := &core.AnalysisResult{
+		Functions: functions,
+		Files: map[string]*core.FileAnalysis{
+			file:  mainFile,
+			other: {Path: other, Lines: 20, Functions: functions[4:], Metrics: map[string]interface{}{"function_count": 1, "average_complexity": 20.0}},
+		},
+		Metrics: map[string]interface{}{"total_functions": 5, "average_complexity": 16.4},
+	}
+	changed := map[string][]git.LineRange{
+		"main.go": {{Start: 15, End: 15}, {Start: 40, End: 41}},
+	}
+
+	if dropped := FilterChangedFunctions(result, repoPath, changed); dropped != 3 {
+		t.Errorf("Expected 3 functions dropped, got %d", dropped)
+	}
+	var names []string
+	for _, fn := range result.Functions {
+		names = append(names, fn.Name)
+	}
+	if len(names) != 2 || names[0] != "changed" || names[1] != "endUnknown" {
+		t.Errorf("Expected only the changed functions to be kept, got %v", names)
+	}
+
+	if result.TotalFunctions != 2 || result.AverageComplexity != 6 || result.Metrics["total_functions"] != 2 || result.Metrics["max_complexity"] != 8 {
+		t.Errorf("Expected totals of the kept functions, got %d functions, average %v, metrics %v",
+			result.TotalFunctions, result.AverageComplexity, result.Metrics)
+	}
+	if got := result.Files[file]; len(got.Functions) != 2 || got.Metrics["function_count"] != 2 || got.Metrics["average_complexity"] != 6.0 {
+		t.Errorf("Expected the file to list only its changed functions, got %+v", got)
+	}
+	if got := result.Files[other]; len(got.Functions) != 0 || got.Metrics["function_count"] != 0 || got.Metrics["average_complexity"] != nil {
+		t.Errorf("Expected no functions left in the unchanged file, got %+v", got)
+	}
+	if len(mainFile.Functions) != 4 || mainFile.Metrics["function_count"] != 4 {
+		t.Error("Expected the original file analysis to be left unchanged")
+	}
+}
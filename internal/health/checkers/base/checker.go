@@ -2,6 +2,8 @@ package base
 
 import (
 	"context"
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/codcod/repos/internal/core"
@@ -54,22 +56,9 @@ func (c *BaseChecker) Execute(ctx context.Context, repoCtx core.RepositoryContex
 
 	result, err := checkFn()
 	if err != nil {
-		return core.CheckResult{
-			ID:         c.id,
-			Name:       c.name,
-			Category:   c.category,
-			Status:     core.StatusCritical,
-			Duration:   time.Since(start),
-			Timestamp:  time.Now(),
-			Repository: repoCtx.Repository.Name,
-			Issues: []core.Issue{
-				{
-					Type:     "execution_error",
-					Severity: core.SeverityCritical,
-					Message:  err.Error(),
-				},
-			},
-		}, nil // Return nil error as we've handled it in the result
+		result = core.ErroredResult(c.id, c.name, c.category, repoCtx.Repository.Name, err)
+		result.Duration = time.Since(start)
+		return result, nil // Return nil error as we've handled it in the result
 	}
 
 	// Fill in common fields
@@ -79,10 +68,149 @@ func (c *BaseChecker) Execute(ctx context.Context, repoCtx core.RepositoryContex
 	result.Duration = time.Since(start)
 	result.Timestamp = time.Now()
 	result.Repository = repoCtx.Repository.Name
+	if err := c.applyMissingToolPolicy(repoCtx, &result); err != nil {
+		result = core.ErroredResult(c.id, c.name, c.category, repoCtx.Repository.Name, err)
+		result.Errors[0].Type = "tool_not_available"
+		result.Duration = time.Since(start)
+		return result, nil
+	}
+	c.applyConfiguredSeverity(repoCtx, &result)
 
 	return result, nil
 }
 
+// MissingToolPolicy returns the on_missing_tool policy for this checker: its own
+// option, else the engine's setting, else warn
+func (c *BaseChecker) MissingToolPolicy(repoCtx core.RepositoryContext) core.MissingToolPolicy {
+	value := c.StringOption(repoCtx, "on_missing_tool", "")
+	if value == "" && repoCtx.Config != nil {
+		value = repoCtx.Config.GetEngineConfig().OnMissingTool
+	}
+	if policy, err := core.ParseMissingToolPolicy(value); err == nil {
+		return policy
+	}
+	return core.MissingToolWarn // Invalid policies are rejected when the configuration is loaded
+}
+
+// applyMissingToolPolicy applies the on_missing_tool policy to the issues and
+// warnings reporting tools that are not installed. With skip they are removed,
+// and a check left without issues is healthy but marked skipped with a maximum
+// score of 0, so that a check that did not run does not count towards scores;
+// with error the check is reported as errored.
+func (c *BaseChecker) applyMissingToolPolicy(repoCtx core.RepositoryContext, result *core.CheckResult) error {
+	missing := missingTools(result)
+	if len(missing) == 0 {
+		return nil
+	}
+
+	switch c.MissingToolPolicy(repoCtx) {
+	case core.MissingToolError:
+		return fmt.Errorf("required tool not installed: %s", strings.Join(missing, "; "))
+	case core.MissingToolSkip:
+		skipMissingTools(result, len(missing))
+	}
+	return nil
+}
+
+// missingTools returns the messages of the issues and warnings reporting tools
+// that are not installed
+func missingTools(result *core.CheckResult) []string {
+	var missing []string
+	for _, issue := range result.Issues {
+		if core.IsToolNotAvailable(issue.Type) {
+			missing = append(missing, issue.Message)
+		}
+	}
+	for _, warning := range result.Warnings {
+		if core.IsToolNotAvailable(warning.Type) {
+			missing = append(missing, warning.Message)
+		}
+	}
+	return missing
+}
+
+// skipMissingTools removes the issues and warnings reporting tools that are not
+// installed, marking a check they alone failed as skipped
+func skipMissingTools(result *core.CheckResult, skipped int) {
+	issues := result.Issues[:0]
+	for _, issue := range result.Issues {
+		if !core.IsToolNotAvailable(issue.Type) {
+			issues = append(issues, issue)
+		}
+	}
+	result.Issues = issues
+	warnings := result.Warnings[:0]
+	for _, warning := range result.Warnings {
+		if !core.IsToolNotAvailable(warning.Type) {
+			warnings = append(warnings, warning)
+		}
+	}
+	result.Warnings = warnings
+	if result.Metrics == nil {
+		result.Metrics = make(map[string]interface{})
+	}
+	result.Metrics["tools_skipped"] = skipped
+
+	if len(result.Issues) == 0 && failing(result.Status) {
+		result.Status = core.StatusHealthy
+		result.Score = 0
+		result.MaxScore = 0
+		if result.Metadata == nil {
+			result.Metadata = make(map[string]string)
+		}
+		result.Metadata[core.MetadataSkipped] = "true"
+	}
+}
+
+// failing reports whether a status is a warning or critical
+func failing(status core.HealthStatus) bool {
+	return status == core.StatusWarning || status == core.StatusCritical
+}
+
+// applyConfiguredSeverity applies a severity set for this checker in the configuration:
+// every issue takes that severity, and a failing check is reported as critical for
+// high or critical severities and as a warning otherwise. With the info severity
+// the check is reported as healthy with its full score.
+func (c *BaseChecker) applyConfiguredSeverity(repoCtx core.RepositoryContext, result *core.CheckResult) {
+	if repoCtx.Config == nil {
+		return
+	}
+	config, exists := repoCtx.Config.GetCheckerConfig(c.id)
+	if !exists || config.Severity == "" {
+		return
+	}
+	severity, err := core.ParseSeverity(config.Severity)
+	if err != nil {
+		return // Rejected when the configuration is loaded
+	}
+
+	for i := range result.Issues {
+		result.Issues[i].Severity = severity
+	}
+
+	// Advisory checks report their issues without failing or losing score
+	if severity.Advisory() {
+		if failing(result.Status) {
+			result.Status = core.StatusHealthy
+		}
+		result.Score = result.MaxScore
+		return
+	}
+
+	if failing(result.Status) {
+		result.Status = severityStatus(severity)
+	}
+}
+
+// severityStatus returns the status of a check failing with issues of the
+// given severity: critical for high or critical severities, a warning otherwise
+func severityStatus(severity core.Severity) core.HealthStatus {
+	if severity == core.SeverityHigh || severity == core.SeverityCritical {
+		return core.StatusCritical
+	}
+	return core.StatusWarning
+}
+
 // SupportsRepository checks if this checker supports the given repository
 func (c *BaseChecker) SupportsRepository(repo core.Repository) bool {
 	// Default implementation - can be overridden
@@ -142,6 +270,13 @@ func (b *ResultBuilder) AddIssue(issue core.Issue) *ResultBuilder {
 	return b
 }
 
+// AddInfo adds an advisory issue, which leaves the status and score unchanged
+func (b *ResultBuilder) AddInfo(issue core.Issue) *ResultBuilder {
+	issue.Severity = core.SeverityInfo
+	b.result.Issues = append(b.result.Issues, issue)
+	return b
+}
+
 // AddWarning adds a warning
 func (b *ResultBuilder) AddWarning(warning core.Warning) *ResultBuilder {
 	b.result.Warnings = append(b.result.Warnings, warning)
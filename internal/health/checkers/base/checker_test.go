@@ -139,26 +139,26 @@ func TestBaseChecker_Execute_Error(t *testing.T) {
 		t.Fatalf("Execute() should not return error, got: %v", err)
 	}
 
-	// Verify error is handled in result
-	if result.Status != core.StatusCritical {
-		t.Errorf("Expected status critical for failed check, got %s", result.Status)
+	// Verify the error is reported separately from issues
+	if result.Status != core.StatusErrored {
+		t.Errorf("Expected status errored for failed check, got %s", result.Status)
 	}
 
-	if len(result.Issues) == 0 {
-		t.Error("Expected issues to be present for failed check")
+	if len(result.Issues) != 0 {
+		t.Errorf("Expected no issues for failed check, got %v", result.Issues)
 	}
 
-	issue := result.Issues[0]
-	if issue.Type != "execution_error" {
-		t.Errorf("Expected issue type 'execution_error', got %s", issue.Type)
+	if len(result.Errors) != 1 {
+		t.Fatalf("Expected one error for failed check, got %v", result.Errors)
 	}
 
-	if issue.Severity != core.SeverityCritical {
-		t.Errorf("Expected severity critical, got %s", issue.Severity)
+	checkErr := result.Errors[0]
+	if checkErr.Type != "execution_error" {
+		t.Errorf("Expected error type 'execution_error', got %s", checkErr.Type)
 	}
 
-	if issue.Message != expectedError.Error() {
-		t.Errorf("Expected error message '%s', got %s", expectedError.Error(), issue.Message)
+	if checkErr.Message != expectedError.Error() {
+		t.Errorf("Expected error message '%s', got %s", expectedError.Error(), checkErr.Message)
 	}
 }
 
@@ -232,6 +232,19 @@ func TestResultBuilder_AddIssue(t *testing.T) {
 	}
 }
 
+func TestResultBuilder_AddInfo(t *testing.T) {
+	result := NewResultBuilder("test", "Test", "test").
+		AddInfo(NewIssue("hint", core.SeverityHigh, "Consider adding a CODEOWNERS file")).
+		Build()
+
+	if len(result.Issues) != 1 || result.Issues[0].Severity != core.SeverityInfo {
+		t.Fatalf("Expected one info issue, got %+v", result.Issues)
+	}
+	if result.Status != core.StatusHealthy || result.Score != 100 {
+		t.Errorf("Expected info issues to leave the check healthy, got %s %d", result.Status, result.Score)
+	}
+}
+
 func TestResultBuilder_AddWarning(t *testing.T) {
 	builder := NewResultBuilder("test", "Test", "test")
 
@@ -314,3 +327,127 @@ func TestResultBuilder_ChainedCalls(t *testing.T) {
 		t.Error("Chained metric not set correctly")
 	}
 }
+
+type severityConfig struct {
+	core.Config
+	severity string
+}
+
+func (c severityConfig) GetCheckerConfig(checkerID string) (core.CheckerConfig, bool) {
+	return core.CheckerConfig{Enabled: true, Severity: c.severity}, true
+}
+
+func TestBaseChecker_Execute_ConfiguredSeverity(t *testing.T) {
+	checker := NewBaseChecker("license-check", "License", "compliance", core.CheckerConfig{Enabled: true, Severity: "medium"})
+	check := func() (core.CheckResult, error) {
+		return NewResultBuilder("license-check", "License", "compliance").
+			WithStatus(core.StatusWarning).
+			WithScore(80, 100).
+			AddIssue(NewIssue("missing_license", core.SeverityMedium, "No LICENSE file")).
+			Build(), nil
+	}
+
+	tests := []struct {
+		severity         string
+		expectedSeverity core.Severity
+		expectedStatus   core.HealthStatus
+		expectedScore    int
+	}{
+		{"", core.SeverityMedium, core.StatusWarning, 80},
+		{"critical", core.SeverityCritical, core.StatusCritical, 80},
+		{"HIGH", core.SeverityHigh, core.StatusCritical, 80},
+		{"low", core.SeverityLow, core.StatusWarning, 80},
+		{"info", core.SeverityInfo, core.StatusHealthy, 100},
+	}
+
+	for _, tt := range tests {
+		repoCtx := core.RepositoryContext{Config: severityConfig{severity: tt.severity}}
+		result, err := checker.Execute(context.Background(), repoCtx, check)
+		if err != nil {
+			t.Fatalf("Execute failed: %v", err)
+		}
+		if result.Issues[0].Severity != tt.expectedSeverity {
+			t.Errorf("severity %q: expected issue severity %s, got %s", tt.severity, tt.expectedSeverity, result.Issues[0].Severity)
+		}
+		if result.Status != tt.expectedStatus {
+			t.Errorf("severity %q: expected status %s, got %s", tt.severity, tt.expectedStatus, result.Status)
+		}
+		if result.Score != tt.expectedScore {
+			t.Errorf("severity %q: expected score %d, got %d", tt.severity, tt.expectedScore, result.Score)
+		}
+	}
+}
+
+type missingToolConfig struct {
+	core.Config
+	engine  string
+	checker string
+}
+
+func (c missingToolConfig) GetCheckerConfig(checkerID string) (core.CheckerConfig, bool) {
+	options := map[string]interface{}{}
+	if c.checker != "" {
+		options["on_missing_tool"] = c.checker
+	}
+	return core.CheckerConfig{Enabled: true, Options: options}, true
+}
+
+func (c missingToolConfig) GetEngineConfig() core.EngineConfig {
+	return core.EngineConfig{OnMissingTool: c.engine}
+}
+
+func TestBaseChecker_Execute_MissingToolPolicy(t *testing.T) {
+	checker := NewBaseChecker("dependency-outdated", "Outdated", "dependencies", core.CheckerConfig{Enabled: true})
+	missingOnly := func() (core.CheckResult, error) {
+		return NewResultBuilder("dependency-outdated", "Outdated", "dependencies").
+			WithStatus(core.StatusWarning).
+			WithScore(0, 100).
+			AddIssue(NewIssue("maven_not_available", core.SeverityMedium, "Maven not available for dependency checking")).
+			Build(), nil
+	}
+	withFindings := func() (core.CheckResult, error) {
+		return NewResultBuilder("dependency-outdated", "Outdated", "dependencies").
+			WithStatus(core.StatusWarning).
+			WithScore(70, 100).
+			AddIssue(NewIssue("outdated_dependency", core.SeverityLow, "lodash is outdated")).
+			AddWarning(core.Warning{Type: "pip_audit_not_available", Message: "pip-audit not installed"}).
+			Build(), nil
+	}
+
+	tests := []struct {
+		name     string
+		config   missingToolConfig
+		check    func() (core.CheckResult, error)
+		status   core.HealthStatus
+		score    int
+		issues   int
+		warnings int
+	}{
+		{name: "warn by default", check: missingOnly, status: core.StatusWarning, score: 0, issues: 1},
+		{name: "skip from the engine", config: missingToolConfig{engine: "skip"}, check: missingOnly, status: core.StatusHealthy, score: 0},
+		{name: "checker overrides the engine", config: missingToolConfig{engine: "skip", checker: "warn"}, check: missingOnly, status: core.StatusWarning, issues: 1},
+		{name: "skip keeps other findings", config: missingToolConfig{checker: "skip"}, check: withFindings, status: core.StatusWarning, score: 70, issues: 1},
+		{name: "error", config: missingToolConfig{engine: "error"}, check: withFindings, status: core.StatusErrored},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := checker.Execute(context.Background(), core.RepositoryContext{Config: tt.config}, tt.check)
+			if err != nil {
+				t.Fatalf("Execute failed: %v", err)
+			}
+			if result.Status != tt.status || result.Score != tt.score {
+				t.Errorf("Expected %s with score %d, got %s with %d", tt.status, tt.score, result.Status, result.Score)
+			}
+			if len(result.Issues) != tt.issues || len(result.Warnings) != tt.warnings {
+				t.Errorf("Expected %d issues and %d warnings, got %+v and %+v", tt.issues, tt.warnings, result.Issues, result.Warnings)
+			}
+			if skipped := tt.issues == 0 && tt.status == core.StatusHealthy; skipped && (result.MaxScore != 0 || result.Scored()) {
+				t.Errorf("Expected a skipped check to be left out of scoring, got %d/%d", result.Score, result.MaxScore)
+			}
+			if tt.status == core.StatusErrored && (len(result.Errors) != 1 || result.Errors[0].Type != "tool_not_available") {
+				t.Errorf("Expected a tool_not_available error, got %+v", result.Errors)
+			}
+		})
+	}
+}
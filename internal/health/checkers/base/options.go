@@ -0,0 +1,124 @@
+package base
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/codcod/repos/internal/core"
+	"gopkg.in/yaml.v3"
+)
+
+// option looks up a checker-specific option from the repository configuration
+func (c *BaseChecker) option(repoCtx core.RepositoryContext, key string) (interface{}, bool) {
+	if repoCtx.Config == nil {
+		return nil, false
+	}
+
+	config, exists := repoCtx.Config.GetCheckerConfig(c.id)
+	if !exists || config.Options == nil {
+		return nil, false
+	}
+
+	value, exists := config.Options[key]
+	return value, exists
+}
+
+// IntOption returns an integer option configured for this checker, or defaultValue if unset
+func (c *BaseChecker) IntOption(repoCtx core.RepositoryContext, key string, defaultValue int) int {
+	value, exists := c.option(repoCtx, key)
+	if !exists {
+		return defaultValue
+	}
+
+	// YAML and JSON decoders produce different numeric types
+	switch v := value.(type) {
+	case int:
+		return v
+	case int64:
+		return int(v)
+	case float64:
+		return int(v)
+	default:
+		return defaultValue
+	}
+}
+
+// StringOption returns a string option configured for this checker, or defaultValue if unset or empty
+func (c *BaseChecker) StringOption(repoCtx core.RepositoryContext, key, defaultValue string) string {
+	value, exists := c.option(repoCtx, key)
+	if !exists {
+		return defaultValue
+	}
+	if s, ok := value.(string); ok && s != "" {
+		return s
+	}
+	return defaultValue
+}
+
+// StringSliceOption returns a list of strings configured for this checker, or nil if unset.
+// Non-string entries are ignored.
+func (c *BaseChecker) StringSliceOption(repoCtx core.RepositoryContext, key string) []string {
+	value, exists := c.option(repoCtx, key)
+	if !exists {
+		return nil
+	}
+
+	switch v := value.(type) {
+	case []string:
+		return v
+	case []interface{}:
+		values := make([]string, 0, len(v))
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				values = append(values, s)
+			}
+		}
+		return values
+	case string:
+		return []string{v}
+	default:
+		return nil
+	}
+}
+
+// BoolOption returns a boolean option configured for this checker, or defaultValue if unset
+func (c *BaseChecker) BoolOption(repoCtx core.RepositoryContext, key string, defaultValue bool) bool {
+	value, exists := c.option(repoCtx, key)
+	if !exists {
+		return defaultValue
+	}
+	if b, ok := value.(bool); ok {
+		return b
+	}
+	return defaultValue
+}
+
+// DecodeOption decodes a structured option configured for this checker, such as a
+// list of mappings, into target, which must be a pointer. It reports whether the
+// option was set.
+func (c *BaseChecker) DecodeOption(repoCtx core.RepositoryContext, key string, target interface{}) (bool, error) {
+	value, exists := c.option(repoCtx, key)
+	if !exists {
+		return false, nil
+	}
+
+	// Options are decoded generically, so encode the value again to decode it into target
+	data, err := yaml.Marshal(value)
+	if err == nil {
+		err = yaml.Unmarshal(data, target)
+	}
+	if err != nil {
+		return true, fmt.Errorf("option %s: %w", key, err)
+	}
+	return true, nil
+}
+
+// Timeout returns the timeout configured for this checker, or its default timeout if unset
+func (c *BaseChecker) Timeout(repoCtx core.RepositoryContext) time.Duration {
+	if repoCtx.Config != nil {
+		if config, exists := repoCtx.Config.GetCheckerConfig(c.id); exists && config.Timeout > 0 {
+			return config.Timeout
+		}
+	}
+	return c.config.Timeout
+}
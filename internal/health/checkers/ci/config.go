@@ -36,6 +36,13 @@ func NewCIConfigChecker() *CIConfigChecker {
 	}
 }
 
+// Metadata describes what the checker verifies
+func (*CIConfigChecker) Metadata() core.CheckerMetadata {
+	return core.CheckerMetadata{
+		Description: "Checks for CI/CD configuration (GitHub Actions, GitLab CI, Jenkins and others) and whether it runs build and test steps.",
+	}
+}
+
 // Check performs the CI configuration check
 func (c *CIConfigChecker) Check(ctx context.Context, repoCtx core.RepositoryContext) (core.CheckResult, error) {
 	return c.Execute(ctx, repoCtx, func() (core.CheckResult, error) {
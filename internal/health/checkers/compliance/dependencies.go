@@ -0,0 +1,447 @@
+package compliance
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/codcod/repos/internal/core"
+	"github.com/codcod/repos/internal/health/checkers/base"
+	"github.com/codcod/repos/internal/platform/commands"
+)
+
+// maxLicenseExamples is the number of dependencies named in each issue
+const maxLicenseExamples = 5
+
+// copyleftCompatibility lists, for each strong copyleft license, the project
+// licenses a dependency under it may be combined with
+var copyleftCompatibility = map[string][]string{
+	"GPL-2.0":  {"GPL-2.0"},
+	"GPL-3.0":  {"GPL-3.0", "AGPL-3.0"},
+	"AGPL-3.0": {"AGPL-3.0", "GPL-3.0"},
+}
+
+// permissiveIntoGPL2 are the permissive licenses that GPL-2.0 projects cannot
+// include, because of their patent terms
+var permissiveIntoGPL2 = map[string]bool{"Apache-2.0": true}
+
+// dependencyLicense is the license a licensing tool reported for a dependency
+type dependencyLicense struct {
+	Name    string
+	License string
+}
+
+// licenseTool enumerates the dependency licenses of one ecosystem
+type licenseTool struct {
+	ecosystem string
+	manifest  string // File whose presence selects the ecosystem
+	command   string
+	list      func(ctx context.Context, repoPath string) ([]dependencyLicense, error)
+}
+
+// DependencyLicenseChecker checks that the licenses of a repository's
+// dependencies are known, accepted and compatible with the project's license,
+// using go-licenses for Go modules and license-checker for npm packages
+type DependencyLicenseChecker struct {
+	*base.BaseChecker
+	executor commands.CommandExecutor
+}
+
+// NewDependencyLicenseChecker creates a new dependency license checker
+func NewDependencyLicenseChecker(executor commands.CommandExecutor) *DependencyLicenseChecker {
+	config := core.CheckerConfig{
+		Enabled:    true,
+		Severity:   "medium",
+		Timeout:    2 * time.Minute,
+		Categories: []string{"compliance"},
+	}
+
+	return &DependencyLicenseChecker{
+		BaseChecker: base.NewBaseChecker(
+			"dependency-licenses",
+			"Dependency Licenses",
+			"compliance",
+			config,
+		),
+		executor: executor,
+	}
+}
+
+// Metadata describes what the checker verifies
+func (*DependencyLicenseChecker) Metadata() core.CheckerMetadata {
+	return core.CheckerMetadata{
+		Description: "Lists dependency licenses with go-licenses (Go) and license-checker (npm) when they are installed, " +
+			"and reports dependencies whose license is unknown, missing from accepted_licenses, or incompatible " +
+			"with the project's own license, such as GPL dependencies of an MIT project.",
+		Options: []core.CheckerOption{
+			{Name: "accepted_licenses", Default: "[]", Description: "SPDX identifiers dependencies may use; empty accepts any license compatible with the project's"},
+		},
+		RequiredTools: []string{"go-licenses", "license-checker"},
+	}
+}
+
+// Check performs the dependency license check
+func (c *DependencyLicenseChecker) Check(ctx context.Context, repoCtx core.RepositoryContext) (core.CheckResult, error) {
+	return c.Execute(ctx, repoCtx, func() (core.CheckResult, error) {
+		return c.checkDependencyLicenses(ctx, repoCtx), nil
+	})
+}
+
+// tools returns the licensing tools this checker knows
+func (c *DependencyLicenseChecker) tools() []licenseTool {
+	return []licenseTool{
+		{ecosystem: "go", manifest: "go.mod", command: "go-licenses", list: c.goLicenses},
+		{ecosystem: "node", manifest: "package.json", command: "license-checker", list: c.npmLicenses},
+	}
+}
+
+// checkDependencyLicenses performs the actual dependency license check
+func (c *DependencyLicenseChecker) checkDependencyLicenses(ctx context.Context, repoCtx core.RepositoryContext) core.CheckResult {
+	builder := base.NewResultBuilder(c.ID(), c.Name(), c.Category())
+	repoPath := repoCtx.Repository.Path
+
+	accepted := make(map[string]bool)
+	for _, license := range c.StringSliceOption(repoCtx, "accepted_licenses") {
+		accepted[normalizeLicense(license)] = true
+	}
+	project := projectLicense(repoPath)
+	builder.AddMetric("project_license", project)
+
+	dependencies := c.listDependencyLicenses(ctx, repoPath, builder)
+	unknown, forbidden := classifyLicenses(dependencies, accepted, project)
+
+	builder.AddMetric("dependencies_scanned", len(dependencies))
+	builder.AddMetric("unknown_licenses", len(unknown))
+	builder.AddMetric("forbidden_licenses", len(forbidden))
+
+	score := 100
+	if len(forbidden) > 0 {
+		reason := "are not accepted"
+		if project != "unknown" {
+			reason = fmt.Sprintf("are not accepted or conflict with the project's %s license", project)
+		}
+		issue := base.NewIssueWithSuggestion(
+			"forbidden_dependency_licenses",
+			core.SeverityHigh,
+			fmt.Sprintf("%d dependencies have licenses that %s, e.g. %s", len(forbidden), reason, licenseExamples(forbidden)),
+			"Replace these dependencies, or add their licenses to accepted_licenses after a legal review",
+		)
+		issue.Context["count"] = len(forbidden)
+		issue.Context["examples"] = exampleNames(forbidden)
+		builder.AddIssue(issue)
+		score -= 50
+	}
+	if len(unknown) > 0 {
+		issue := base.NewIssueWithSuggestion(
+			"unknown_dependency_licenses",
+			core.SeverityMedium,
+			fmt.Sprintf("%d dependencies have no recognizable license, e.g. %s", len(unknown), licenseExamples(unknown)),
+			"Check the licenses of these dependencies by hand",
+		)
+		issue.Context["count"] = len(unknown)
+		issue.Context["examples"] = exampleNames(unknown)
+		builder.AddIssue(issue)
+		score -= 20
+	}
+
+	switch {
+	case len(forbidden) > 0:
+		builder.WithStatus(core.StatusCritical)
+	case len(unknown) > 0:
+		builder.WithStatus(core.StatusWarning)
+	default:
+		builder.WithStatus(core.StatusHealthy)
+	}
+	builder.WithScore(score, 100)
+	return builder.Build()
+}
+
+// listDependencyLicenses lists the dependency licenses of every ecosystem with
+// a manifest in the repository, warning about tools that are missing or fail
+func (c *DependencyLicenseChecker) listDependencyLicenses(ctx context.Context, repoPath string, builder *base.ResultBuilder) []dependencyLicense {
+	var dependencies []dependencyLicense
+	for _, tool := range c.tools() {
+		if _, err := os.Stat(filepath.Join(repoPath, tool.manifest)); err != nil {
+			continue
+		}
+		if result := c.executor.Execute(ctx, "which", tool.command); result.Error != nil {
+			builder.AddWarning(core.Warning{
+				Type:    "license_tool_not_available",
+				Message: fmt.Sprintf("%s not installed; %s dependency licenses were not checked", tool.command, tool.ecosystem),
+			})
+			continue
+		}
+		listed, err := tool.list(ctx, repoPath)
+		if err != nil {
+			builder.AddWarning(core.Warning{
+				Type:    "license_tool_error",
+				Message: fmt.Sprintf("Unable to list %s dependency licenses: %v", tool.ecosystem, err),
+			})
+			continue
+		}
+		dependencies = append(dependencies, listed...)
+	}
+	return dependencies
+}
+
+// classifyLicenses returns the dependencies without a recognizable license and
+// those whose license is neither accepted nor compatible with the project's
+func classifyLicenses(dependencies []dependencyLicense, accepted map[string]bool, project string) ([]dependencyLicense, []dependencyLicense) {
+	var unknown, forbidden []dependencyLicense
+	for _, dep := range dependencies {
+		alternatives := licenseAlternatives(dep.License)
+		if len(alternatives) == 0 {
+			unknown = append(unknown, dep)
+			continue
+		}
+		if !anyAlternativeAllowed(alternatives, accepted, project) {
+			forbidden = append(forbidden, dep)
+		}
+	}
+	return unknown, forbidden
+}
+
+// goLicenses lists Go module licenses with 'go-licenses csv', which prints one
+// module,url,license line per module
+func (c *DependencyLicenseChecker) goLicenses(ctx context.Context, repoPath string) ([]dependencyLicense, error) {
+	// go-licenses exits non-zero when it cannot classify a license, so parse the output regardless
+	result := c.executor.ExecuteInDir(ctx, repoPath, "go-licenses", "csv", "./...")
+	if strings.TrimSpace(result.Stdout) == "" && result.Error != nil {
+		return nil, fmt.Errorf("go-licenses failed: %w", result.Error)
+	}
+	return parseGoLicenses(result.Stdout, modulePath(repoPath))
+}
+
+// parseGoLicenses parses the output of go-licenses csv. The packages of the
+// repository's own module are left out.
+func parseGoLicenses(output, module string) ([]dependencyLicense, error) {
+	reader := csv.NewReader(strings.NewReader(output))
+	reader.FieldsPerRecord = -1
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("parsing go-licenses output: %w", err)
+	}
+
+	var dependencies []dependencyLicense
+	for _, record := range records {
+		if len(record) < 2 || (module != "" && (record[0] == module || strings.HasPrefix(record[0], module+"/"))) {
+			continue
+		}
+		dependencies = append(dependencies, dependencyLicense{Name: record[0], License: record[len(record)-1]})
+	}
+	return dependencies, nil
+}
+
+// npmLicenses lists npm package licenses with 'license-checker --json --production'
+func (c *DependencyLicenseChecker) npmLicenses(ctx context.Context, repoPath string) ([]dependencyLicense, error) {
+	result := c.executor.ExecuteInDir(ctx, repoPath, "license-checker", "--json", "--production")
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return parseLicenseChecker(result.Stdout, rootPackage(repoPath))
+}
+
+// parseLicenseChecker parses the output of license-checker --json, an object
+// keyed by name@version whose licenses are a string or a list. The root
+// package, which license-checker includes, is left out.
+func parseLicenseChecker(output, root string) ([]dependencyLicense, error) {
+	var entries map[string]struct {
+		Licenses interface{} `json:"licenses"`
+	}
+	if err := json.Unmarshal([]byte(output), &entries); err != nil {
+		return nil, fmt.Errorf("parsing license-checker output: %w", err)
+	}
+
+	keys := make([]string, 0, len(entries))
+	for key := range entries {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var dependencies []dependencyLicense
+	for _, key := range keys {
+		if root != "" && key == root {
+			continue
+		}
+		var license string
+		switch licenses := entries[key].Licenses.(type) {
+		case string:
+			license = licenses
+		case []interface{}:
+			var names []string
+			for _, name := range licenses {
+				if s, ok := name.(string); ok {
+					names = append(names, s)
+				}
+			}
+			license = strings.Join(names, " OR ")
+		}
+		dependencies = append(dependencies, dependencyLicense{Name: key, License: license})
+	}
+	return dependencies, nil
+}
+
+// modulePath returns the module path declared in the repository's go.mod, or ""
+func modulePath(repoPath string) string {
+	data, err := os.ReadFile(filepath.Join(repoPath, "go.mod")) //nolint:gosec // Path is within the repository
+	if err != nil {
+		return ""
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		if fields := strings.Fields(line); len(fields) == 2 && fields[0] == "module" {
+			return strings.Trim(fields[1], `"`)
+		}
+	}
+	return ""
+}
+
+// rootPackage returns the name@version of the repository's package.json, or ""
+func rootPackage(repoPath string) string {
+	data, err := os.ReadFile(filepath.Join(repoPath, "package.json")) //nolint:gosec // Path is within the repository
+	if err != nil {
+		return ""
+	}
+	var pkg struct {
+		Name    string `json:"name"`
+		Version string `json:"version"`
+	}
+	if json.Unmarshal(data, &pkg) != nil || pkg.Name == "" {
+		return ""
+	}
+	return pkg.Name + "@" + pkg.Version
+}
+
+// projectLicense identifies the repository's own license the way the
+// license-check checker does, or returns "unknown"
+func projectLicense(repoPath string) string {
+	checker := &LicenseChecker{}
+	file := checker.selectMainLicense(checker.findLicenseFiles(repoPath))
+	if file == "" {
+		return "unknown"
+	}
+	content, err := os.ReadFile(filepath.Join(repoPath, file)) //nolint:gosec // License file path is from repository analysis
+	if err != nil {
+		return "unknown"
+	}
+	license, _ := checker.detectLicenseType(strings.ToLower(string(content)))
+	return license
+}
+
+// licenseAlternatives splits a license expression such as "(MIT OR Apache-2.0)"
+// into the licenses a dependency may be used under. Each alternative lists the
+// licenses that all apply. Unknown and proprietary licenses yield nothing.
+func licenseAlternatives(expression string) [][]string {
+	expression = strings.NewReplacer("(", " ", ")", " ").Replace(expression)
+
+	var alternatives [][]string
+	for _, alternative := range strings.Split(expression, " OR ") {
+		var licenses []string
+		for _, license := range strings.Split(alternative, " AND ") {
+			license = normalizeLicense(license)
+			if license == "" || license == "UNKNOWN" || license == "UNLICENSED" || strings.HasPrefix(license, "CUSTOM") {
+				licenses = nil
+				break
+			}
+			licenses = append(licenses, license)
+		}
+		if len(licenses) > 0 {
+			alternatives = append(alternatives, licenses)
+		}
+	}
+	return alternatives
+}
+
+// normalizeLicense reduces an SPDX identifier to the form compared against:
+// license-checker's guess marker and the -only and -or-later suffixes are
+// removed, and unknown markers are upper-cased
+func normalizeLicense(license string) string {
+	license = strings.TrimSuffix(strings.TrimSpace(license), "*")
+	license = strings.TrimSuffix(license, "+")
+	license = strings.TrimSuffix(license, "-only")
+	license = strings.TrimSuffix(license, "-or-later")
+	switch upper := strings.ToUpper(license); {
+	case upper == "UNKNOWN", upper == "UNLICENSED", strings.HasPrefix(upper, "CUSTOM"):
+		return upper
+	}
+	return license
+}
+
+// anyAlternativeAllowed reports whether the dependency may be used under one
+// of its license alternatives
+func anyAlternativeAllowed(alternatives [][]string, accepted map[string]bool, project string) bool {
+	for _, licenses := range alternatives {
+		allowed := true
+		for _, license := range licenses {
+			if (len(accepted) > 0 && !accepted[license]) || conflictsWithProject(license, project) {
+				allowed = false
+				break
+			}
+		}
+		if allowed {
+			return true
+		}
+	}
+	return false
+}
+
+// conflictsWithProject reports whether a dependency license cannot be combined
+// with the project license. An unknown project license conflicts with nothing.
+func conflictsWithProject(license, project string) bool {
+	if project == "unknown" {
+		return false
+	}
+	if compatible, copyleft := copyleftCompatibility[license]; copyleft {
+		for _, allowed := range compatible {
+			if allowed == project {
+				return false
+			}
+		}
+		return true
+	}
+	return project == "GPL-2.0" && permissiveIntoGPL2[license]
+}
+
+// licenseExamples names the first few dependencies with their licenses
+func licenseExamples(dependencies []dependencyLicense) string {
+	var examples []string
+	for i, dep := range dependencies {
+		if i >= maxLicenseExamples {
+			examples = append(examples, fmt.Sprintf("and %d more", len(dependencies)-maxLicenseExamples))
+			break
+		}
+		license := dep.License
+		if license == "" {
+			license = "none"
+		}
+		examples = append(examples, fmt.Sprintf("%s (%s)", dep.Name, license))
+	}
+	return strings.Join(examples, ", ")
+}
+
+// exampleNames returns the names of the first few dependencies
+func exampleNames(dependencies []dependencyLicense) []string {
+	var names []string
+	for i, dep := range dependencies {
+		if i >= maxLicenseExamples {
+			break
+		}
+		names = append(names, dep.Name)
+	}
+	return names
+}
+
+// SupportsRepository checks if the repository has Go modules or npm packages
+func (c *DependencyLicenseChecker) SupportsRepository(repo core.Repository) bool {
+	for _, tool := range c.tools() {
+		if _, err := os.Stat(filepath.Join(repo.Path, tool.manifest)); err == nil {
+			return true
+		}
+	}
+	return false
+}
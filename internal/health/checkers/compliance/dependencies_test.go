@@ -0,0 +1,144 @@
+package compliance
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/codcod/repos/internal/core"
+	"github.com/codcod/repos/internal/platform/commands"
+)
+
+// dependencyLicenseConfig provides options for the dependency-licenses checker
+type dependencyLicenseConfig struct {
+	options map[string]interface{}
+}
+
+func (c dependencyLicenseConfig) GetCheckerConfig(string) (core.CheckerConfig, bool) {
+	return core.CheckerConfig{Enabled: true, Options: c.options}, true
+}
+func (dependencyLicenseConfig) GetAnalyzerConfig(string) (core.AnalyzerConfig, bool) {
+	return core.AnalyzerConfig{}, false
+}
+func (dependencyLicenseConfig) GetReporterConfig(string) (core.ReporterConfig, bool) {
+	return core.ReporterConfig{}, false
+}
+func (dependencyLicenseConfig) GetEngineConfig() core.EngineConfig { return core.EngineConfig{} }
+
+const mitLicense = `MIT License
+
+Copyright (c) 2024 Example
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software. The above copyright notice and this permission notice shall
+be included in all copies or substantial portions of the Software.
+`
+
+func writeRepoFiles(t *testing.T, files map[string]string) string {
+	t.Helper()
+	root := t.TempDir()
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(root, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return root
+}
+
+func TestDependencyLicenseChecker(t *testing.T) {
+	repoPath := writeRepoFiles(t, map[string]string{
+		"LICENSE":      mitLicense,
+		"go.mod":       "module example.com/app\n\ngo 1.24\n",
+		"package.json": `{"name": "app", "version": "1.0.0"}`,
+	})
+
+	executor := commands.NewMockCommandExecutor()
+	executor.SetResponse("go-licenses csv ./...", commands.CommandResult{
+		ExitCode: 1,
+		Error:    errors.New("exit status 1"),
+		Stdout: "example.com/app,https://example.com/app,MIT\n" +
+			"github.com/spf13/cobra,https://github.com/spf13/cobra/blob/main/LICENSE.txt,Apache-2.0\n" +
+			"github.com/example/gpl,https://github.com/example/gpl/blob/main/COPYING,GPL-3.0\n" +
+			"github.com/example/mystery,Unknown,Unknown\n",
+	})
+	executor.SetResponse("license-checker --json --production", commands.CommandResult{Stdout: `{
+		"app@1.0.0": {"licenses": "UNLICENSED"},
+		"dual@2.0.0": {"licenses": "(GPL-3.0-only OR MIT)"},
+		"left-pad@1.3.0": {"licenses": "WTFPL"},
+		"lodash@4.17.21": {"licenses": "MIT"}
+	}`})
+
+	result, err := NewDependencyLicenseChecker(executor).Check(context.Background(), core.RepositoryContext{
+		Repository: core.Repository{Name: "app", Path: repoPath},
+		Config: dependencyLicenseConfig{options: map[string]interface{}{
+			"accepted_licenses": []interface{}{"MIT", "Apache-2.0", "BSD-3-Clause", "GPL-3.0"},
+		}},
+	})
+	if err != nil {
+		t.Fatalf("Check failed: %v", err)
+	}
+
+	if result.Status != core.StatusCritical {
+		t.Errorf("Expected critical status, got %s", result.Status)
+	}
+	if result.Metrics["project_license"] != "MIT" || result.Metrics["dependencies_scanned"] != 6 ||
+		result.Metrics["forbidden_licenses"] != 2 || result.Metrics["unknown_licenses"] != 1 {
+		t.Errorf("Unexpected metrics: %v", result.Metrics)
+	}
+	if len(result.Issues) != 2 {
+		t.Fatalf("Expected forbidden and unknown license issues, got %+v", result.Issues)
+	}
+
+	// GPL-3.0 is accepted but conflicts with the MIT project; WTFPL is not accepted
+	forbidden := result.Issues[0]
+	examples, _ := forbidden.Context["examples"].([]string)
+	if forbidden.Type != "forbidden_dependency_licenses" || len(examples) != 2 ||
+		examples[0] != "github.com/example/gpl" || examples[1] != "left-pad@1.3.0" {
+		t.Errorf("Unexpected forbidden license issue: %+v", forbidden)
+	}
+	if unknown := result.Issues[1]; unknown.Type != "unknown_dependency_licenses" || unknown.Context["count"] != 1 {
+		t.Errorf("Unexpected unknown license issue: %+v", unknown)
+	}
+}
+
+func TestDependencyLicenseChecker_ToolsMissing(t *testing.T) {
+	repoPath := writeRepoFiles(t, map[string]string{"go.mod": "module example.com/app\n"})
+
+	executor := commands.NewMockCommandExecutor()
+	executor.SetResponse("which go-licenses", commands.CommandResult{ExitCode: 1, Error: errors.New("exit status 1")})
+
+	result, err := NewDependencyLicenseChecker(executor).Check(context.Background(), core.RepositoryContext{
+		Repository: core.Repository{Name: "app", Path: repoPath},
+	})
+	if err != nil {
+		t.Fatalf("Check failed: %v", err)
+	}
+	if result.Status != core.StatusHealthy || len(result.Issues) != 0 {
+		t.Errorf("Expected a healthy result without issues, got %s: %+v", result.Status, result.Issues)
+	}
+	if len(result.Warnings) != 1 || result.Warnings[0].Type != "license_tool_not_available" {
+		t.Errorf("Expected a missing tool warning, got %+v", result.Warnings)
+	}
+}
+
+func TestConflictsWithProject(t *testing.T) {
+	tests := []struct {
+		license, project string
+		expected         bool
+	}{
+		{"GPL-3.0", "MIT", true},
+		{"GPL-3.0", "GPL-3.0", false},
+		{"AGPL-3.0", "GPL-2.0", true},
+		{"Apache-2.0", "GPL-2.0", true},
+		{"Apache-2.0", "GPL-3.0", false},
+		{"MIT", "Apache-2.0", false},
+		{"GPL-2.0", "unknown", false},
+	}
+	for _, tt := range tests {
+		if got := conflictsWithProject(tt.license, tt.project); got != tt.expected {
+			t.Errorf("%s in a %s project: expected %v, got %v", tt.license, tt.project, tt.expected, got)
+		}
+	}
+}
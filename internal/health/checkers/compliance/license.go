@@ -36,6 +36,13 @@ func NewLicenseChecker() *LicenseChecker {
 	}
 }
 
+// Metadata describes what the checker verifies
+func (*LicenseChecker) Metadata() core.CheckerMetadata {
+	return core.CheckerMetadata{
+		Description: "Checks for a LICENSE file and identifies the license it contains.",
+	}
+}
+
 // Check performs the license check
 func (c *LicenseChecker) Check(ctx context.Context, repoCtx core.RepositoryContext) (core.CheckResult, error) {
 	return c.Execute(ctx, repoCtx, func() (core.CheckResult, error) {
@@ -0,0 +1,276 @@
+// Package custom provides checkers defined in the health configuration rather
+// than in code
+package custom
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/codcod/repos/internal/core"
+	"github.com/codcod/repos/internal/health/checkers/base"
+	healthconfig "github.com/codcod/repos/internal/health/config"
+	"github.com/codcod/repos/internal/platform/commands"
+)
+
+// defaultCategory is the category of custom checkers that don't name one
+const defaultCategory = "custom"
+
+// statusScores are the scores of outputs that report a status without a score
+var statusScores = map[core.HealthStatus]int{
+	core.StatusHealthy:  100,
+	core.StatusWarning:  60,
+	core.StatusCritical: 20,
+}
+
+// Output is the JSON document a custom checker command may print to stdout:
+//
+//	{
+//	  "status": "warning",
+//	  "score": 75,
+//	  "issues": [
+//	    {"type": "todo", "severity": "low", "message": "...", "file": "main.go", "line": 3, "suggestion": "..."}
+//	  ],
+//	  "metrics": {"todos": 4}
+//	}
+//
+// Every field is optional. Without a status the exit code decides it: 0 is
+// healthy, 1 is warning and anything else is critical. Without a score the
+// status decides it: 100 for healthy, 60 for warning and 20 for critical.
+type Output struct {
+	Status  string                 `json:"status"`
+	Score   *int                   `json:"score"`
+	Issues  []OutputIssue          `json:"issues"`
+	Metrics map[string]interface{} `json:"metrics"`
+}
+
+// OutputIssue is an issue reported by a custom checker command. The file is
+// relative to the repository and the severity defaults to medium.
+type OutputIssue struct {
+	Type       string `json:"type"`
+	Severity   string `json:"severity"`
+	Message    string `json:"message"`
+	File       string `json:"file"`
+	Line       int    `json:"line"`
+	Column     int    `json:"column"`
+	Suggestion string `json:"suggestion"`
+}
+
+// CommandChecker runs a configured command in the repository directory and
+// maps its exit code and JSON output into a check result
+type CommandChecker struct {
+	*base.BaseChecker
+	executor commands.CommandExecutor
+	command  string
+	args     []string
+}
+
+// NewCommandChecker creates a checker for a custom checker definition. It
+// fails when the definition is incomplete or its command cannot be found;
+// commands given as a path relative to the repository are resolved when they run.
+func NewCommandChecker(definition healthconfig.CustomCheckerConfig, executor commands.CommandExecutor) (*CommandChecker, error) {
+	if definition.ID == "" {
+		return nil, fmt.Errorf("custom checker has no id")
+	}
+	if definition.Command == "" {
+		return nil, fmt.Errorf("custom checker '%s' has no command", definition.ID)
+	}
+	if err := validateCommand(definition.Command, executor); err != nil {
+		return nil, fmt.Errorf("custom checker '%s': %w", definition.ID, err)
+	}
+
+	name := definition.Name
+	if name == "" {
+		name = definition.ID
+	}
+	category := definition.Category
+	if category == "" {
+		category = defaultCategory
+	}
+
+	config := core.CheckerConfig{
+		Enabled:    true,
+		Severity:   "medium",
+		Timeout:    2 * time.Minute,
+		Categories: []string{category},
+		Options:    definition.Config,
+	}
+
+	return &CommandChecker{
+		BaseChecker: base.NewBaseChecker(definition.ID, name, category, config),
+		executor:    executor,
+		command:     definition.Command,
+		args:        definition.Args,
+	}, nil
+}
+
+// validateCommand checks that a command on the PATH or given as an absolute
+// path exists
+func validateCommand(command string, executor commands.CommandExecutor) error {
+	switch {
+	case filepath.IsAbs(command):
+		if _, err := os.Stat(command); err != nil {
+			return fmt.Errorf("command %s not found", command)
+		}
+	case strings.ContainsRune(command, '/') || strings.ContainsRune(command, filepath.Separator):
+		// Relative to the repository being checked
+	default:
+		if !commands.CommandExists(executor, command) {
+			return fmt.Errorf("command %s not found in PATH", command)
+		}
+	}
+	return nil
+}
+
+// Metadata describes what the checker verifies
+func (c *CommandChecker) Metadata() core.CheckerMetadata {
+	return core.CheckerMetadata{
+		Description: fmt.Sprintf("Custom checker that runs '%s' in the repository directory and reports its JSON output.",
+			strings.TrimSpace(c.command+" "+strings.Join(c.args, " "))),
+		RequiredTools: []string{c.command},
+	}
+}
+
+// Check runs the command
+func (c *CommandChecker) Check(ctx context.Context, repoCtx core.RepositoryContext) (core.CheckResult, error) {
+	return c.Execute(ctx, repoCtx, func() (core.CheckResult, error) {
+		return c.runCommand(ctx, repoCtx)
+	})
+}
+
+// runCommand runs the command and converts its output
+func (c *CommandChecker) runCommand(ctx context.Context, repoCtx core.RepositoryContext) (core.CheckResult, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.Timeout(repoCtx))
+	defer cancel()
+
+	result := c.executor.ExecuteInDir(ctx, repoCtx.Repository.Path, c.command, c.args...)
+	if ctx.Err() != nil {
+		return core.CheckResult{}, fmt.Errorf("%s did not finish: %w", c.command, ctx.Err())
+	}
+	if result.Error != nil && result.ExitCode < 0 {
+		return core.CheckResult{}, fmt.Errorf("unable to run %s: %w", c.command, result.Error)
+	}
+
+	output, err := ParseOutput(result.Stdout)
+	if err != nil {
+		return core.CheckResult{}, fmt.Errorf("%s: %w", c.command, err)
+	}
+	return c.buildResult(output, result), nil
+}
+
+// ParseOutput parses the JSON output of a custom checker command. Empty or
+// non-JSON output yields an empty Output, so plain commands are judged by
+// their exit code alone.
+func ParseOutput(stdout string) (*Output, error) {
+	trimmed := strings.TrimSpace(stdout)
+	if !strings.HasPrefix(trimmed, "{") {
+		return &Output{}, nil
+	}
+
+	var output Output
+	if err := json.Unmarshal([]byte(trimmed), &output); err != nil {
+		return nil, fmt.Errorf("invalid JSON output: %w", err)
+	}
+	if output.Status != "" {
+		if _, ok := statusScores[core.HealthStatus(output.Status)]; !ok {
+			return nil, fmt.Errorf("invalid status %q (allowed: healthy, warning, critical)", output.Status)
+		}
+	}
+	for i, issue := range output.Issues {
+		if issue.Severity == "" {
+			continue
+		}
+		if _, err := core.ParseSeverity(issue.Severity); err != nil {
+			return nil, fmt.Errorf("issue %d: %w", i, err)
+		}
+	}
+	return &output, nil
+}
+
+// buildResult converts a command's output and exit code into a check result
+func (c *CommandChecker) buildResult(output *Output, result commands.CommandResult) core.CheckResult {
+	builder := base.NewResultBuilder(c.ID(), c.Name(), c.Category())
+	builder.AddMetadata("command", c.command)
+	builder.AddMetric("exit_code", result.ExitCode)
+
+	status := core.HealthStatus(output.Status)
+	if status == "" {
+		status = exitCodeStatus(result.ExitCode)
+	}
+
+	for _, reported := range output.Issues {
+		builder.AddIssue(reported.issue())
+	}
+
+	// A failing command without reported issues explains itself through its output
+	if len(output.Issues) == 0 && status != core.StatusHealthy {
+		message := firstLine(result.Stderr)
+		if message == "" {
+			message = firstLine(result.Stdout)
+		}
+		if message == "" || strings.HasPrefix(message, "{") {
+			message = fmt.Sprintf("%s exited with code %d", c.command, result.ExitCode)
+		}
+		builder.AddIssue(base.NewIssue("custom_check_failed", core.SeverityMedium, message))
+	}
+
+	for key, value := range output.Metrics {
+		builder.AddMetric(key, value)
+	}
+
+	score := statusScores[status]
+	if output.Score != nil {
+		score = min(max(*output.Score, 0), 100)
+	}
+	builder.WithStatus(status)
+	builder.WithScore(score, 100)
+	return builder.Build()
+}
+
+// issue converts a reported issue, whose severity was validated when the
+// output was parsed
+func (o OutputIssue) issue() core.Issue {
+	severity := core.SeverityMedium
+	if o.Severity != "" {
+		severity, _ = core.ParseSeverity(o.Severity)
+	}
+	issueType := o.Type
+	if issueType == "" {
+		issueType = "custom_finding"
+	}
+
+	issue := base.NewIssueWithLocation(issueType, severity, o.Message,
+		filepath.ToSlash(o.File), o.Line, o.Column)
+	if o.File == "" {
+		issue = base.NewIssue(issueType, severity, o.Message)
+	}
+	issue.Suggestion = o.Suggestion
+	return issue
+}
+
+// exitCodeStatus maps an exit code to a status: 0 is healthy, 1 is warning
+// and anything else is critical
+func exitCodeStatus(exitCode int) core.HealthStatus {
+	switch exitCode {
+	case 0:
+		return core.StatusHealthy
+	case 1:
+		return core.StatusWarning
+	default:
+		return core.StatusCritical
+	}
+}
+
+// firstLine returns the first non-empty line of output
+func firstLine(output string) string {
+	for _, line := range strings.Split(output, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			return line
+		}
+	}
+	return ""
+}
@@ -0,0 +1,138 @@
+package custom
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/codcod/repos/internal/core"
+	healthconfig "github.com/codcod/repos/internal/health/config"
+	"github.com/codcod/repos/internal/platform/commands"
+)
+
+func TestCommandChecker_JSONOutput(t *testing.T) {
+	executor := commands.NewMockCommandExecutor()
+	executor.SetResponse("org-check --strict", commands.CommandResult{
+		ExitCode: 1,
+		Stdout: `{"status":"warning","score":75,"metrics":{"todos":4},"issues":[` +
+			`{"type":"missing_owner","severity":"high","message":"No CODEOWNERS entry","file":"src/app.go","line":3,"suggestion":"Add an owner"},` +
+			`{"message":"Unlabelled finding"}]}`,
+		Error: os.ErrInvalid,
+	})
+
+	checker, err := NewCommandChecker(healthconfig.CustomCheckerConfig{
+		ID:       "org-check",
+		Name:     "Org Check",
+		Category: "compliance",
+		Command:  "org-check",
+		Args:     []string{"--strict"},
+	}, executor)
+	if err != nil {
+		t.Fatalf("NewCommandChecker failed: %v", err)
+	}
+
+	repo := core.Repository{Name: "app", Path: t.TempDir()}
+	result, err := checker.Check(context.Background(), core.RepositoryContext{Repository: repo})
+	if err != nil {
+		t.Fatalf("Check failed: %v", err)
+	}
+
+	if result.ID != "org-check" || result.Category != "compliance" {
+		t.Errorf("Unexpected checker identity %s/%s", result.ID, result.Category)
+	}
+	if result.Status != core.StatusWarning || result.Score != 75 {
+		t.Errorf("Expected warning with score 75, got %s with %d", result.Status, result.Score)
+	}
+	if result.Metrics["todos"] != float64(4) {
+		t.Errorf("Expected todos metric 4, got %v", result.Metrics["todos"])
+	}
+	if len(result.Issues) != 2 {
+		t.Fatalf("Expected two issues, got %d", len(result.Issues))
+	}
+
+	owner := result.Issues[0]
+	if owner.Type != "missing_owner" || owner.Severity != core.SeverityHigh || owner.Suggestion != "Add an owner" {
+		t.Errorf("Unexpected issue: %+v", owner)
+	}
+	if owner.Location == nil || owner.Location.File != "src/app.go" || owner.Location.Line != 3 {
+		t.Errorf("Unexpected issue location: %+v", owner.Location)
+	}
+
+	unlabelled := result.Issues[1]
+	if unlabelled.Type != "custom_finding" || unlabelled.Severity != core.SeverityMedium || unlabelled.Location != nil {
+		t.Errorf("Expected defaults for an issue without type, severity or file, got %+v", unlabelled)
+	}
+}
+
+func TestCommandChecker_ExitCodes(t *testing.T) {
+	tests := []struct {
+		name     string
+		result   commands.CommandResult
+		expected core.HealthStatus
+		score    int
+		issues   int
+	}{
+		{"success", commands.CommandResult{Stdout: "all good\n"}, core.StatusHealthy, 100, 0},
+		{"warning", commands.CommandResult{ExitCode: 1, Stderr: "2 owners missing\n", Error: os.ErrInvalid}, core.StatusWarning, 60, 1},
+		{"failure", commands.CommandResult{ExitCode: 3, Error: os.ErrInvalid}, core.StatusCritical, 20, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			executor := commands.NewMockCommandExecutor()
+			executor.SetResponse("check.sh", tt.result)
+
+			checker, err := NewCommandChecker(healthconfig.CustomCheckerConfig{ID: "check", Command: "check.sh"}, executor)
+			if err != nil {
+				t.Fatalf("NewCommandChecker failed: %v", err)
+			}
+			if checker.Category() != "custom" || checker.Name() != "check" {
+				t.Errorf("Expected default name and category, got %s/%s", checker.Name(), checker.Category())
+			}
+
+			repo := core.Repository{Name: "app", Path: t.TempDir()}
+			result, err := checker.Check(context.Background(), core.RepositoryContext{Repository: repo})
+			if err != nil {
+				t.Fatalf("Check failed: %v", err)
+			}
+			if result.Status != tt.expected || result.Score != tt.score {
+				t.Errorf("Expected %s with score %d, got %s with %d", tt.expected, tt.score, result.Status, result.Score)
+			}
+			if len(result.Issues) != tt.issues {
+				t.Fatalf("Expected %d issues, got %d", tt.issues, len(result.Issues))
+			}
+			if tt.name == "warning" && result.Issues[0].Message != "2 owners missing" {
+				t.Errorf("Expected the first line of stderr as message, got %q", result.Issues[0].Message)
+			}
+		})
+	}
+}
+
+func TestCommandChecker_Errors(t *testing.T) {
+	executor := commands.NewMockCommandExecutor()
+	executor.SetResponse("which missing-tool", commands.CommandResult{ExitCode: 1, Error: os.ErrNotExist})
+	executor.SetResponse("bad-json", commands.CommandResult{Stdout: `{"status": "fine"}`})
+
+	if _, err := NewCommandChecker(healthconfig.CustomCheckerConfig{ID: "missing", Command: "missing-tool"}, executor); err == nil {
+		t.Error("Expected an error for a command that is not in PATH")
+	}
+	if _, err := NewCommandChecker(healthconfig.CustomCheckerConfig{ID: "abs", Command: "/nonexistent/check"}, executor); err == nil {
+		t.Error("Expected an error for an absolute command that does not exist")
+	}
+	if _, err := NewCommandChecker(healthconfig.CustomCheckerConfig{ID: "rel", Command: "./scripts/check.sh"}, executor); err != nil {
+		t.Errorf("Expected commands relative to the repository to be accepted, got %v", err)
+	}
+
+	checker, err := NewCommandChecker(healthconfig.CustomCheckerConfig{ID: "bad", Command: "bad-json"}, executor)
+	if err != nil {
+		t.Fatalf("NewCommandChecker failed: %v", err)
+	}
+	repo := core.Repository{Name: "app", Path: t.TempDir()}
+	result, err := checker.Check(context.Background(), core.RepositoryContext{Repository: repo})
+	if err != nil {
+		t.Fatalf("Check failed: %v", err)
+	}
+	if result.Status != core.StatusErrored {
+		t.Errorf("Expected an invalid status to error the check, got %s", result.Status)
+	}
+}
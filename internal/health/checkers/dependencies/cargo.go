@@ -0,0 +1,255 @@
+package dependencies
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/codcod/repos/internal/core"
+	"github.com/codcod/repos/internal/health/checkers/base"
+)
+
+// cargoManifest holds the parts of Cargo.toml relevant to dependency checking
+type cargoManifest struct {
+	Dependencies int
+	IsBinary     bool
+}
+
+// cargoOutdatedReport mirrors the JSON output of 'cargo outdated --format json'
+type cargoOutdatedReport struct {
+	Dependencies []struct {
+		Name    string `json:"name"`
+		Project string `json:"project"`
+		Latest  string `json:"latest"`
+	} `json:"dependencies"`
+}
+
+// cargoAuditReport mirrors the JSON output of 'cargo audit --json'
+type cargoAuditReport struct {
+	Vulnerabilities struct {
+		Count int `json:"count"`
+		List  []struct {
+			Advisory struct {
+				ID      string `json:"id"`
+				Package string `json:"package"`
+				Title   string `json:"title"`
+			} `json:"advisory"`
+		} `json:"list"`
+	} `json:"vulnerabilities"`
+}
+
+// checkCargoToml checks Rust crate dependencies
+func (c *OutdatedChecker) checkCargoToml(ctx context.Context, repoPath string, builder *base.ResultBuilder) (core.CheckResult, error) {
+	builder.AddMetric("project_type", "rust")
+
+	manifest, err := parseCargoManifest(repoPath)
+	if err != nil {
+		builder.WithStatus(core.StatusWarning)
+		builder.WithScore(60, 100)
+		builder.AddWarning(core.Warning{
+			Type:    "cargo_manifest_error",
+			Message: fmt.Sprintf("Unable to read Cargo.toml: %v", err),
+		})
+		return builder.Build(), nil
+	}
+	builder.AddMetric("dependency_count", manifest.Dependencies)
+	builder.AddMetric("binary_crate", manifest.IsBinary)
+
+	score := 100
+	status := core.StatusHealthy
+
+	// A missing Cargo.lock is reported by the lockfile policy
+	lockedPackages, hasLockfile := countCargoLockPackages(repoPath)
+	builder.AddMetric("has_lockfile", hasLockfile)
+	if hasLockfile {
+		builder.AddMetric("locked_packages", lockedPackages)
+	}
+
+	// Check if cargo is available
+	result := c.executor.Execute(ctx, "which", "cargo")
+	if result.Error != nil {
+		builder.WithStatus(core.StatusWarning)
+		builder.WithScore(score, 100)
+		builder.AddIssue(base.NewIssueWithSuggestion(
+			"cargo_not_available",
+			core.SeverityMedium,
+			"cargo not available for dependency checking",
+			"Install the Rust toolchain to enable dependency checking",
+		))
+		return builder.Build(), nil
+	}
+
+	// Prefer cargo-outdated; fall back to counting direct dependencies with cargo tree
+	result = c.executor.ExecuteInDir(ctx, repoPath, "cargo", "outdated", "--root-deps-only", "--format", "json")
+	if result.Error == nil {
+		outdated := parseCargoOutdated(result.Stdout)
+		builder.AddMetric("outdated_dependencies", len(outdated))
+
+		if len(outdated) > 0 {
+			score -= 30
+			status = core.StatusWarning
+			builder.AddIssue(base.NewIssueWithSuggestion(
+				"outdated_cargo_dependencies",
+				core.SeverityMedium,
+				fmt.Sprintf("Found %d outdated crates", len(outdated)),
+				"Run 'cargo update' or bump versions in Cargo.toml",
+			))
+			addOutdatedMetrics(builder, outdated)
+		}
+	} else {
+		result = c.executor.ExecuteInDir(ctx, repoPath, "cargo", "tree", "--depth", "1", "--prefix", "none")
+		if result.Error == nil {
+			builder.AddMetric("dependency_count", countCargoTreeDependencies(result.Stdout))
+		}
+		builder.AddWarning(core.Warning{
+			Type:    "cargo_outdated_not_available",
+			Message: "cargo-outdated not installed; outdated crates were not checked",
+		})
+	}
+
+	if c.addCargoAdvisories(ctx, repoPath, builder) {
+		score -= 40
+		status = core.StatusCritical
+	}
+
+	builder.WithStatus(status)
+	builder.WithScore(max(score, 0), 100)
+
+	return builder.Build(), nil
+}
+
+// addCargoAdvisories surfaces security advisories when cargo-audit is
+// installed, and reports whether any were found
+func (c *OutdatedChecker) addCargoAdvisories(ctx context.Context, repoPath string, builder *base.ResultBuilder) bool {
+	result := c.executor.ExecuteInDir(ctx, repoPath, "cargo", "audit", "--version")
+	if result.Error != nil {
+		return false
+	}
+
+	// cargo audit exits non-zero when vulnerabilities are found, so parse the output regardless
+	result = c.executor.ExecuteInDir(ctx, repoPath, "cargo", "audit", "--json")
+	advisories, err := parseCargoAudit(result.Stdout)
+	if err != nil {
+		builder.AddWarning(core.Warning{
+			Type:    "cargo_audit_error",
+			Message: fmt.Sprintf("Unable to parse cargo audit output: %v", err),
+		})
+		return false
+	}
+
+	builder.AddMetric("advisories", len(advisories))
+	if len(advisories) == 0 {
+		return false
+	}
+	builder.AddIssue(base.NewIssueWithSuggestion(
+		"cargo_security_advisories",
+		core.SeverityHigh,
+		fmt.Sprintf("Found %d security advisories: %s", len(advisories), strings.Join(advisories, ", ")),
+		"Run 'cargo audit' for details and upgrade the affected crates",
+	))
+	return true
+}
+
+// cargoBinPattern finds a [[bin]] target in Cargo.toml
+var cargoBinPattern = regexp.MustCompile(`(?m)^\s*\[\[bin\]\]`)
+
+// parseCargoManifest counts declared dependencies and determines the crate kind.
+// A crate depended on in several sections, e.g. as a dependency and a build
+// dependency, is counted once.
+func parseCargoManifest(repoPath string) (cargoManifest, error) {
+	var manifest cargoManifest
+
+	content, err := os.ReadFile(filepath.Join(repoPath, "Cargo.toml")) //nolint:gosec // Path is within the repository
+	if err != nil {
+		return manifest, err
+	}
+	dependencies, err := parseCargoDependencies(string(content))
+	if err != nil {
+		return manifest, err
+	}
+	manifest.Dependencies = len(dependencies)
+	manifest.IsBinary = cargoBinPattern.Match(content)
+
+	if _, err := os.Stat(filepath.Join(repoPath, "src", "main.rs")); err == nil {
+		manifest.IsBinary = true
+	}
+
+	return manifest, nil
+}
+
+// isCargoDependencySection reports whether a table name declares dependencies,
+// including target-specific tables such as target.'cfg(unix)'.dependencies
+func isCargoDependencySection(section string) bool {
+	for _, name := range []string{"dependencies", "dev-dependencies", "build-dependencies"} {
+		if section == name || section == "workspace."+name ||
+			(strings.HasPrefix(section, "target.") && strings.HasSuffix(section, "."+name)) {
+			return true
+		}
+	}
+	return false
+}
+
+// countCargoLockPackages counts the packages pinned in Cargo.lock
+func countCargoLockPackages(repoPath string) (int, bool) {
+	content, err := os.ReadFile(filepath.Join(repoPath, "Cargo.lock")) //nolint:gosec // Path is within the repository
+	if err != nil {
+		return 0, false
+	}
+	return strings.Count(string(content), "[[package]]"), true
+}
+
+// parseCargoOutdated returns the crates whose latest version differs from the one in use
+func parseCargoOutdated(output string) []string {
+	var outdated []string
+
+	// cargo outdated prints one JSON document per workspace member
+	decoder := json.NewDecoder(strings.NewReader(output))
+	for {
+		var report cargoOutdatedReport
+		if err := decoder.Decode(&report); err != nil {
+			break
+		}
+		for _, dep := range report.Dependencies {
+			if dep.Latest != "" && dep.Latest != "---" && dep.Latest != dep.Project {
+				outdated = append(outdated, fmt.Sprintf("%s (current: %s, latest: %s)", dep.Name, dep.Project, dep.Latest))
+			}
+		}
+	}
+
+	return outdated
+}
+
+// countCargoTreeDependencies counts direct dependencies in 'cargo tree --depth 1 --prefix none' output
+func countCargoTreeDependencies(output string) int {
+	seen := make(map[string]bool)
+	lines := strings.Split(strings.TrimSpace(output), "\n")
+
+	// The first line is the root crate itself
+	for i, line := range lines {
+		fields := strings.Fields(line)
+		if i == 0 || len(fields) == 0 || strings.HasPrefix(line, "[") {
+			continue
+		}
+		seen[fields[0]] = true
+	}
+
+	return len(seen)
+}
+
+// parseCargoAudit returns the advisory IDs reported by cargo audit
+func parseCargoAudit(output string) ([]string, error) {
+	var report cargoAuditReport
+	if err := json.Unmarshal([]byte(output), &report); err != nil {
+		return nil, err
+	}
+
+	advisories := make([]string, 0, len(report.Vulnerabilities.List))
+	for _, vuln := range report.Vulnerabilities.List {
+		advisories = append(advisories, fmt.Sprintf("%s (%s)", vuln.Advisory.ID, vuln.Advisory.Package))
+	}
+	return advisories, nil
+}
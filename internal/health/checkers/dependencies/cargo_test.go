@@ -0,0 +1,149 @@
+package dependencies
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/codcod/repos/internal/core"
+	"github.com/codcod/repos/internal/platform/commands"
+)
+
+const testCargoToml = `[package]
+name = "tool"
+version = "0.1.0"
+
+[dependencies]
+serde = { version = "1.0", features = ["derive"] }
+anyhow = "1"
+
+[dependencies.tokio]
+version = "1"
+features = ["full"]
+default-features = false
+
+[dev-dependencies]
+tempfile = "3"
+`
+
+func writeCargoFixture(t *testing.T, withLockfile bool) string {
+	t.Helper()
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "src"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	files := map[string]string{
+		"Cargo.toml":  testCargoToml,
+		"src/main.rs": "fn main() {}\n",
+	}
+	if withLockfile {
+		files["Cargo.lock"] = "version = 3\n\n[[package]]\nname = \"anyhow\"\n\n[[package]]\nname = \"serde\"\n"
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestParseCargoManifest(t *testing.T) {
+	manifest, err := parseCargoManifest(writeCargoFixture(t, false))
+	if err != nil {
+		t.Fatalf("parseCargoManifest failed: %v", err)
+	}
+	if manifest.Dependencies != 4 {
+		t.Errorf("Expected 4 dependencies, got %d", manifest.Dependencies)
+	}
+	if !manifest.IsBinary {
+		t.Error("Expected crate with src/main.rs to be a binary crate")
+	}
+}
+
+func TestCheckCargoToml(t *testing.T) {
+	repoPath := writeCargoFixture(t, false)
+
+	executor := commands.NewMockCommandExecutor()
+	executor.SetResponse("cargo outdated --root-deps-only --format json", commands.CommandResult{
+		Stdout: `{"crate_name":"tool","dependencies":[` +
+			`{"name":"serde","project":"1.0.100","latest":"1.0.200"},` +
+			`{"name":"anyhow","project":"1.0.80","latest":"1.0.80"}]}`,
+	})
+	executor.SetResponse("cargo audit --json", commands.CommandResult{
+		ExitCode: 1,
+		Stdout:   `{"vulnerabilities":{"found":true,"count":1,"list":[{"advisory":{"id":"RUSTSEC-2024-0001","package":"serde"}}]}}`,
+	})
+
+	checker := NewOutdatedChecker(executor)
+	result, err := checker.Check(context.Background(), core.RepositoryContext{
+		Repository: core.Repository{Name: "tool", Path: repoPath},
+	})
+	if err != nil {
+		t.Fatalf("Check failed: %v", err)
+	}
+
+	if result.Status != core.StatusCritical {
+		t.Errorf("Expected critical status, got %s", result.Status)
+	}
+	for metric, want := range map[string]interface{}{
+		"project_type":          "rust",
+		"dependency_count":      4,
+		"outdated_dependencies": 1,
+		"advisories":            1,
+		"has_lockfile":          false,
+	} {
+		if result.Metrics[metric] != want {
+			t.Errorf("Expected metric %s = %v, got %v", metric, want, result.Metrics[metric])
+		}
+	}
+
+	issueTypes := make(map[string]bool)
+	for _, issue := range result.Issues {
+		issueTypes[issue.Type] = true
+	}
+	for _, want := range []string{"missing_lockfile", "outdated_cargo_dependencies", "cargo_security_advisories"} {
+		if !issueTypes[want] {
+			t.Errorf("Expected issue %s, got %v", want, result.Issues)
+		}
+	}
+}
+
+func TestCheckCargoToml_WithoutCargoOutdated(t *testing.T) {
+	repoPath := writeCargoFixture(t, true)
+
+	executor := commands.NewMockCommandExecutor()
+	executor.SetResponse("cargo outdated --root-deps-only --format json", commands.CommandResult{
+		ExitCode: 101,
+		Error:    errors.New("no such command: `outdated`"),
+	})
+	executor.SetResponse("cargo tree --depth 1 --prefix none", commands.CommandResult{
+		Stdout: "tool v0.1.0 (/src/tool)\nanyhow v1.0.80\nserde v1.0.200\ntokio v1.0.0\n\n[dev-dependencies]\ntempfile v3.10.0\n",
+	})
+	executor.SetResponse("cargo audit --version", commands.CommandResult{
+		ExitCode: 101,
+		Error:    errors.New("no such command: `audit`"),
+	})
+
+	checker := NewOutdatedChecker(executor)
+	result, err := checker.Check(context.Background(), core.RepositoryContext{
+		Repository: core.Repository{Name: "tool", Path: repoPath},
+	})
+	if err != nil {
+		t.Fatalf("Check failed: %v", err)
+	}
+
+	if result.Status != core.StatusHealthy {
+		t.Errorf("Expected healthy status, got %s", result.Status)
+	}
+	if result.Metrics["dependency_count"] != 4 {
+		t.Errorf("Expected 4 dependencies from cargo tree, got %v", result.Metrics["dependency_count"])
+	}
+	if result.Metrics["locked_packages"] != 2 {
+		t.Errorf("Expected 2 locked packages, got %v", result.Metrics["locked_packages"])
+	}
+	if len(result.Warnings) != 1 || result.Warnings[0].Type != "cargo_outdated_not_available" {
+		t.Errorf("Expected cargo_outdated_not_available warning, got %v", result.Warnings)
+	}
+}
@@ -0,0 +1,211 @@
+package dependencies
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/codcod/repos/internal/core"
+	"github.com/codcod/repos/internal/health/checkers/base"
+)
+
+// composerManifest holds the parts of composer.json relevant to dependency checking
+type composerManifest struct {
+	Require    map[string]string `json:"require"`
+	RequireDev map[string]string `json:"require-dev"`
+}
+
+// composerLock holds the packages pinned in composer.lock
+type composerLock struct {
+	Packages    []json.RawMessage `json:"packages"`
+	PackagesDev []json.RawMessage `json:"packages-dev"`
+}
+
+// composerOutdatedReport mirrors the JSON output of 'composer outdated --format=json'
+type composerOutdatedReport struct {
+	Installed []struct {
+		Name         string `json:"name"`
+		Version      string `json:"version"`
+		Latest       string `json:"latest"`
+		LatestStatus string `json:"latest-status"`
+		// false, true, or the name of the suggested replacement package
+		Abandoned interface{} `json:"abandoned"`
+	} `json:"installed"`
+}
+
+// checkComposer checks PHP Composer dependencies
+func (c *OutdatedChecker) checkComposer(ctx context.Context, repoPath string, builder *base.ResultBuilder) (core.CheckResult, error) {
+	builder.AddMetric("project_type", "php")
+
+	manifest, err := parseComposerManifest(repoPath)
+	if err != nil {
+		builder.WithStatus(core.StatusWarning)
+		builder.WithScore(60, 100)
+		builder.AddWarning(core.Warning{
+			Type:    "composer_manifest_error",
+			Message: fmt.Sprintf("Unable to read composer.json: %v", err),
+		})
+		return builder.Build(), nil
+	}
+	builder.AddMetric("dependency_count", countComposerPackages(manifest.Require))
+	builder.AddMetric("dev_dependency_count", countComposerPackages(manifest.RequireDev))
+
+	score := 100
+	status := core.StatusHealthy
+
+	// A missing composer.lock is reported by the lockfile policy
+	lockedPackages, hasLockfile := countComposerLockPackages(repoPath)
+	builder.AddMetric("has_lockfile", hasLockfile)
+	if hasLockfile {
+		builder.AddMetric("locked_packages", lockedPackages)
+	}
+
+	// Check if composer is available
+	result := c.executor.Execute(ctx, "which", "composer")
+	if result.Error != nil {
+		builder.WithStatus(core.StatusWarning)
+		builder.WithScore(score, 100)
+		builder.AddIssue(base.NewIssueWithSuggestion(
+			"composer_not_available",
+			core.SeverityMedium,
+			"composer not available for dependency checking",
+			"Install Composer to enable dependency checking",
+		))
+		return builder.Build(), nil
+	}
+
+	result = c.executor.ExecuteInDir(ctx, repoPath, "composer", "outdated", "--direct", "--format=json")
+	if result.Error != nil {
+		builder.WithStatus(core.StatusWarning)
+		builder.WithScore(score, 100)
+		builder.AddWarning(core.Warning{
+			Type:    "composer_command_error",
+			Message: fmt.Sprintf("Unable to check Composer dependencies: %v", result.Error),
+		})
+		return builder.Build(), nil
+	}
+
+	outdated, abandoned, err := parseComposerOutdated(result.Stdout)
+	if err != nil {
+		builder.WithStatus(core.StatusWarning)
+		builder.WithScore(score, 100)
+		builder.AddWarning(core.Warning{
+			Type:    "composer_output_error",
+			Message: fmt.Sprintf("Unable to parse composer outdated output: %v", err),
+		})
+		return builder.Build(), nil
+	}
+
+	builder.AddMetric("outdated_dependencies", len(outdated))
+	if len(outdated) > 0 {
+		score -= 30
+		status = core.StatusWarning
+		builder.AddIssue(base.NewIssueWithSuggestion(
+			"outdated_composer_dependencies",
+			core.SeverityMedium,
+			fmt.Sprintf("Found %d outdated direct Composer dependencies", len(outdated)),
+			"Run 'composer update' or bump version constraints in composer.json",
+		))
+		addOutdatedMetrics(builder, outdated)
+	}
+
+	builder.AddMetric("abandoned_packages", len(abandoned))
+	for _, pkg := range abandoned {
+		builder.AddWarning(core.Warning{
+			Type:    "abandoned_composer_package",
+			Message: fmt.Sprintf("Package %s is abandoned", pkg),
+		})
+	}
+
+	if status == core.StatusHealthy && len(abandoned) > 0 {
+		status = core.StatusWarning
+	}
+	builder.WithStatus(status)
+	builder.WithScore(max(score, 0), 100)
+
+	return builder.Build(), nil
+}
+
+// parseComposerManifest reads the requirements declared in composer.json
+func parseComposerManifest(repoPath string) (composerManifest, error) {
+	var manifest composerManifest
+
+	data, err := os.ReadFile(filepath.Join(repoPath, "composer.json")) //nolint:gosec // Path is within the repository
+	if err != nil {
+		return manifest, err
+	}
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return manifest, err
+	}
+	return manifest, nil
+}
+
+// countComposerPackages counts required packages, skipping the PHP version and
+// extension requirements Composer treats as platform packages
+func countComposerPackages(require map[string]string) int {
+	count := 0
+	for name := range require {
+		if !composerPlatformPackage(name) {
+			count++
+		}
+	}
+	return count
+}
+
+// composerPlatformPackage reports whether a requirement names PHP, an extension
+// or another platform package rather than an installable one
+func composerPlatformPackage(name string) bool {
+	return name == "php" || strings.HasPrefix(name, "php-") || strings.HasPrefix(name, "ext-") ||
+		strings.HasPrefix(name, "lib-") || name == "composer-plugin-api"
+}
+
+// countComposerLockPackages counts the packages pinned in composer.lock
+func countComposerLockPackages(repoPath string) (int, bool) {
+	data, err := os.ReadFile(filepath.Join(repoPath, "composer.lock")) //nolint:gosec // Path is within the repository
+	if err != nil {
+		return 0, false
+	}
+
+	var lock composerLock
+	if err := json.Unmarshal(data, &lock); err != nil {
+		return 0, true
+	}
+	return len(lock.Packages) + len(lock.PackagesDev), true
+}
+
+// parseComposerOutdated returns the packages with a newer version available and
+// the packages Composer reports as abandoned, with their suggested replacement
+func parseComposerOutdated(output string) (outdated, abandoned []string, err error) {
+	if strings.TrimSpace(output) == "" {
+		return nil, nil, nil
+	}
+
+	var report composerOutdatedReport
+	if err := json.Unmarshal([]byte(output), &report); err != nil {
+		return nil, nil, err
+	}
+
+	for _, pkg := range report.Installed {
+		if pkg.LatestStatus != "" && pkg.LatestStatus != "up-to-date" {
+			outdated = append(outdated, fmt.Sprintf("%s (current: %s, latest: %s)", pkg.Name, pkg.Version, pkg.Latest))
+		}
+
+		switch replacement := pkg.Abandoned.(type) {
+		case bool:
+			if replacement {
+				abandoned = append(abandoned, pkg.Name)
+			}
+		case string:
+			if replacement != "" {
+				abandoned = append(abandoned, fmt.Sprintf("%s (use %s instead)", pkg.Name, replacement))
+			}
+		}
+	}
+
+	sort.Strings(abandoned)
+	return outdated, abandoned, nil
+}
@@ -0,0 +1,114 @@
+package dependencies
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/codcod/repos/internal/core"
+	"github.com/codcod/repos/internal/platform/commands"
+)
+
+const testComposerJSON = `{
+  "name": "acme/shop",
+  "type": "project",
+  "require": {
+    "php": "^8.2",
+    "ext-json": "*",
+    "monolog/monolog": "^2.0",
+    "guzzlehttp/guzzle": "^7.0",
+    "swiftmailer/swiftmailer": "^6.0"
+  },
+  "require-dev": {
+    "phpunit/phpunit": "^10.0"
+  }
+}`
+
+const testComposerOutdated = `{"installed":[
+  {"name":"guzzlehttp/guzzle","direct-dependency":true,"version":"7.8.0","latest":"7.8.0","latest-status":"up-to-date","abandoned":false},
+  {"name":"monolog/monolog","direct-dependency":true,"version":"2.9.1","latest":"3.5.0","latest-status":"update-possible","abandoned":false},
+  {"name":"swiftmailer/swiftmailer","direct-dependency":true,"version":"6.3.0","latest":"6.3.0","latest-status":"up-to-date","abandoned":"symfony/mailer"}
+]}`
+
+func writeComposerFixture(t *testing.T, withLockfile bool) string {
+	t.Helper()
+	dir := t.TempDir()
+	files := map[string]string{"composer.json": testComposerJSON}
+	if withLockfile {
+		files["composer.lock"] = `{"packages":[{"name":"monolog/monolog"},{"name":"psr/log"}],"packages-dev":[{"name":"phpunit/phpunit"}]}`
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestCheckComposer(t *testing.T) {
+	repoPath := writeComposerFixture(t, true)
+
+	executor := commands.NewMockCommandExecutor()
+	executor.SetResponse("composer outdated --direct --format=json", commands.CommandResult{Stdout: testComposerOutdated})
+
+	result, err := NewOutdatedChecker(executor).Check(context.Background(), core.RepositoryContext{
+		Repository: core.Repository{Name: "shop", Path: repoPath},
+	})
+	if err != nil {
+		t.Fatalf("Check failed: %v", err)
+	}
+
+	if result.Status != core.StatusWarning {
+		t.Errorf("Expected warning status, got %s", result.Status)
+	}
+	for metric, want := range map[string]interface{}{
+		"project_type":          "php",
+		"dependency_count":      3,
+		"dev_dependency_count":  1,
+		"has_lockfile":          true,
+		"locked_packages":       3,
+		"outdated_dependencies": 1,
+		"abandoned_packages":    1,
+		"outdated_0":            "monolog/monolog (current: 2.9.1, latest: 3.5.0)",
+	} {
+		if result.Metrics[metric] != want {
+			t.Errorf("Expected metric %s = %v, got %v", metric, want, result.Metrics[metric])
+		}
+	}
+
+	if len(result.Warnings) != 1 || result.Warnings[0].Message != "Package swiftmailer/swiftmailer (use symfony/mailer instead) is abandoned" {
+		t.Errorf("Expected an abandoned package warning, got %v", result.Warnings)
+	}
+	if len(result.Issues) != 1 || result.Issues[0].Type != "outdated_composer_dependencies" {
+		t.Errorf("Expected one outdated dependencies issue, got %v", result.Issues)
+	}
+}
+
+func TestCheckComposer_MissingLockfileWithoutComposer(t *testing.T) {
+	repoPath := writeComposerFixture(t, false)
+
+	executor := commands.NewMockCommandExecutor()
+	executor.SetResponse("which composer", commands.CommandResult{ExitCode: 1, Error: errors.New("exit status 1")})
+
+	result, err := NewOutdatedChecker(executor).Check(context.Background(), core.RepositoryContext{
+		Repository: core.Repository{Name: "shop", Path: repoPath},
+	})
+	if err != nil {
+		t.Fatalf("Check failed: %v", err)
+	}
+
+	issueTypes := make(map[string]bool)
+	for _, issue := range result.Issues {
+		issueTypes[issue.Type] = true
+	}
+	for _, want := range []string{"missing_lockfile", "composer_not_available"} {
+		if !issueTypes[want] {
+			t.Errorf("Expected issue %s, got %v", want, result.Issues)
+		}
+	}
+	if result.Score != 80 {
+		t.Errorf("Expected score 80, got %d", result.Score)
+	}
+}
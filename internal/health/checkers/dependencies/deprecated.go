@@ -0,0 +1,228 @@
+package dependencies
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/codcod/repos/internal/core"
+	"github.com/codcod/repos/internal/health/checkers/base"
+)
+
+// maxDeprecationIssues is the number of deprecated dependencies reported as issues
+const maxDeprecationIssues = 10
+
+// deprecatedDependency is a dependency its maintainers deprecated, or a
+// dependency version they retracted
+type deprecatedDependency struct {
+	Name      string
+	Version   string
+	Retracted bool
+	Message   string
+}
+
+// checkDeprecations adds an issue for each dependency the ecosystem reports as
+// deprecated or retracted. These are kept apart from outdated dependencies,
+// since updating does not help when the package itself is abandoned.
+func (c *OutdatedChecker) checkDeprecations(ctx context.Context, repoPath string, ecosystem dependencyEcosystem, result core.CheckResult) core.CheckResult {
+	deprecated, err := ecosystem.deprecated(c, ctx, repoPath)
+	if err != nil {
+		result.Warnings = append(result.Warnings, core.Warning{
+			Type:    "dependency_deprecation_error",
+			Message: fmt.Sprintf("Unable to check %s dependencies for deprecations: %v", ecosystem.name, err),
+		})
+		return result
+	}
+	return applyDeprecations(result, deprecated)
+}
+
+// applyDeprecations records the deprecated dependencies in the result. Any
+// deprecation makes a healthy ecosystem a warning.
+func applyDeprecations(result core.CheckResult, deprecated []deprecatedDependency) core.CheckResult {
+	if result.Metrics == nil {
+		result.Metrics = make(map[string]interface{})
+	}
+	result.Metrics["deprecated_dependencies"] = len(deprecated)
+	if len(deprecated) == 0 {
+		return result
+	}
+
+	for i, dep := range deprecated {
+		if i >= maxDeprecationIssues {
+			result.Metrics["additional_deprecated_dependencies"] = len(deprecated) - maxDeprecationIssues
+			break
+		}
+		result.Issues = append(result.Issues, deprecationIssue(dep))
+	}
+
+	if result.Status == core.StatusHealthy || result.Status == "" {
+		result.Status = core.StatusWarning
+	}
+	if result.MaxScore == 0 {
+		result.MaxScore = 100
+	}
+	if limit := result.MaxScore * 80 / 100; result.Score > limit {
+		result.Score = limit
+	}
+	return result
+}
+
+// deprecationIssue describes a deprecated or retracted dependency
+func deprecationIssue(dep deprecatedDependency) core.Issue {
+	issueType, state, suggestion := "deprecated_dependency", "deprecated", fmt.Sprintf("Replace %s with the alternative its maintainers recommend", dep.Name)
+	if dep.Retracted {
+		issueType, state, suggestion = "retracted_dependency", "retracted", fmt.Sprintf("Move %s to a version that is not retracted", dep.Name)
+	}
+	message := fmt.Sprintf("%s %s is %s", dep.Name, dep.Version, state)
+	if dep.Message != "" {
+		message += ": " + dep.Message
+	}
+	issue := base.NewIssueWithSuggestion(issueType, core.SeverityMedium, message, suggestion)
+	issue.Context["dependency"] = dep.Name
+	issue.Context["version"] = dep.Version
+	issue.Context["message"] = dep.Message
+	return issue
+}
+
+// deprecatedGoModules lists the Go modules whose latest go.mod has a
+// "// Deprecated:" comment and those required at a retracted version
+func (c *OutdatedChecker) deprecatedGoModules(ctx context.Context, repoPath string) ([]deprecatedDependency, error) {
+	result := c.executor.ExecuteInDir(ctx, repoPath, "go", "list", "-m", "-u", "-json", "all")
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return parseDeprecatedGoModules(result.Stdout)
+}
+
+// parseDeprecatedGoModules parses the Deprecated and Retracted fields printed
+// by go list -m -u -json
+func parseDeprecatedGoModules(output string) ([]deprecatedDependency, error) {
+	var deprecated []deprecatedDependency
+	decoder := json.NewDecoder(strings.NewReader(output))
+	for {
+		var module goModule
+		if err := decoder.Decode(&module); errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return nil, fmt.Errorf("parsing go list output: %w", err)
+		}
+		if module.Main {
+			continue
+		}
+		if module.Deprecated != "" {
+			deprecated = append(deprecated, deprecatedDependency{Name: module.Path, Version: module.Version, Message: module.Deprecated})
+		}
+		if len(module.Retracted) > 0 {
+			deprecated = append(deprecated, deprecatedDependency{
+				Name:      module.Path,
+				Version:   module.Version,
+				Retracted: true,
+				Message:   strings.Join(module.Retracted, "; "),
+			})
+		}
+	}
+	return deprecated, nil
+}
+
+// deprecatedNpmPackages asks the registry, with 'npm view', whether the locked
+// version of each direct dependency is deprecated. Dependencies not in
+// package-lock.json are skipped, since their version is not known.
+func (c *OutdatedChecker) deprecatedNpmPackages(ctx context.Context, repoPath string) ([]deprecatedDependency, error) {
+	versions, err := lockedNpmVersions(repoPath)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(versions))
+	for name := range versions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var deprecated []deprecatedDependency
+	for _, name := range names {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		result := c.executor.ExecuteInDir(ctx, repoPath, "npm", "view", name+"@"+versions[name], "deprecated")
+		if result.Error != nil {
+			continue // Unpublished or private packages cannot be looked up
+		}
+		if message := strings.TrimSpace(result.Stdout); message != "" {
+			deprecated = append(deprecated, deprecatedDependency{Name: name, Version: versions[name], Message: message})
+		}
+	}
+	return deprecated, nil
+}
+
+// lockedNpmVersions returns the versions package-lock.json pins for the
+// dependencies and devDependencies declared in package.json. Both the
+// "packages" map of lockfile version 2 and 3 and the "dependencies" map of
+// version 1 are read.
+func lockedNpmVersions(repoPath string) (map[string]string, error) {
+	var manifest struct {
+		Dependencies    map[string]string `json:"dependencies"`
+		DevDependencies map[string]string `json:"devDependencies"`
+	}
+	data, err := os.ReadFile(filepath.Join(repoPath, "package.json")) //nolint:gosec // Path is within the repository
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("parsing package.json: %w", err)
+	}
+
+	lock, err := readNpmLock(repoPath)
+	if lock == nil || err != nil {
+		return nil, err
+	}
+
+	versions := make(map[string]string)
+	for _, declared := range []map[string]string{manifest.Dependencies, manifest.DevDependencies} {
+		for name := range declared {
+			if version := lock.version(name); version != "" {
+				versions[name] = version
+			}
+		}
+	}
+	return versions, nil
+}
+
+// npmLock holds the pinned versions of package-lock.json
+type npmLock struct {
+	Packages map[string]struct {
+		Version string `json:"version"`
+	} `json:"packages"`
+	Dependencies map[string]struct {
+		Version string `json:"version"`
+	} `json:"dependencies"`
+}
+
+// readNpmLock reads package-lock.json, or returns nil when there is none
+func readNpmLock(repoPath string) (*npmLock, error) {
+	data, err := os.ReadFile(filepath.Join(repoPath, "package-lock.json")) //nolint:gosec // Path is within the repository
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	var lock npmLock
+	if err := json.Unmarshal(data, &lock); err != nil {
+		return nil, fmt.Errorf("parsing package-lock.json: %w", err)
+	}
+	return &lock, nil
+}
+
+// version returns the version the lockfile pins a dependency at, or "" if none
+func (l *npmLock) version(name string) string {
+	if pkg := l.Packages["node_modules/"+name]; pkg.Version != "" {
+		return pkg.Version
+	}
+	return l.Dependencies[name].Version
+}
@@ -0,0 +1,413 @@
+package dependencies
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/codcod/repos/internal/platform/commands"
+)
+
+// Kinds of dependency changes between two refs
+const (
+	DependencyAdded   = "added"
+	DependencyRemoved = "removed"
+	DependencyUpdated = "updated"
+)
+
+// DependencyChange is a dependency added, removed or given another version
+// between two refs. Versions are compared as the manifest declares them, so a
+// range such as ^1.2.0 is reported as written.
+type DependencyChange struct {
+	Name   string `json:"name"`
+	Change string `json:"change"`
+	From   string `json:"from,omitempty"`
+	To     string `json:"to,omitempty"`
+}
+
+// ManifestDrift lists the dependency changes of one manifest. Added and Removed
+// are set when the manifest exists at only one of the refs.
+type ManifestDrift struct {
+	File      string             `json:"file"`
+	Ecosystem string             `json:"ecosystem"`
+	Added     bool               `json:"added,omitempty"`
+	Removed   bool               `json:"removed,omitempty"`
+	Changes   []DependencyChange `json:"changes"`
+}
+
+// DependencyDrift lists the dependency changes of a repository between two refs
+type DependencyDrift struct {
+	Repository string          `json:"repository"`
+	From       string          `json:"from"`
+	To         string          `json:"to"`
+	Manifests  []ManifestDrift `json:"manifests"`
+}
+
+// driftParsers read the dependencies and their declared versions from the
+// manifests whose changes can be reported
+var driftParsers = map[string]func(content string) (map[string]string, error){
+	"go.mod":           parseGoModRequirements,
+	"package.json":     parsePackageJSONDependencies,
+	"requirements.txt": parseRequirementsTxt,
+	"Cargo.toml":       parseCargoDependencies,
+	"composer.json":    parseComposerRequirements,
+}
+
+// DriftManifests are the manifests DiffDependencies compares
+var DriftManifests = func() []DependencyManifest {
+	var manifests []DependencyManifest
+	for _, manifest := range DefaultManifests {
+		if driftParsers[manifest.File] != nil {
+			manifests = append(manifests, manifest)
+		}
+	}
+	return manifests
+}()
+
+// DiffDependencies compares the dependencies declared in the manifests of the
+// repository at two git refs, read with 'git show <ref>:<file>'. A manifest
+// missing at one ref has all its dependencies reported as added or removed;
+// manifests missing at both refs or without changes are left out.
+func DiffDependencies(ctx context.Context, executor commands.CommandExecutor, repoPath, from, to string) (DependencyDrift, error) {
+	drift := DependencyDrift{From: from, To: to, Manifests: []ManifestDrift{}}
+	if err := verifyRefs(ctx, executor, repoPath, from, to); err != nil {
+		return drift, err
+	}
+
+	for _, manifest := range DriftManifests {
+		parse := driftParsers[manifest.File]
+		before, existedBefore, err := manifestAt(ctx, executor, repoPath, from, manifest.File, parse)
+		if err != nil {
+			return drift, err
+		}
+		after, existsAfter, err := manifestAt(ctx, executor, repoPath, to, manifest.File, parse)
+		if err != nil {
+			return drift, err
+		}
+		if !existedBefore && !existsAfter {
+			continue
+		}
+
+		changes := diffDependencyVersions(before, after)
+		if existedBefore == existsAfter && len(changes) == 0 {
+			continue
+		}
+		drift.Manifests = append(drift.Manifests, ManifestDrift{
+			File:      manifest.File,
+			Ecosystem: manifest.Ecosystem,
+			Added:     !existedBefore,
+			Removed:   !existsAfter,
+			Changes:   changes,
+		})
+	}
+	return drift, nil
+}
+
+// verifyRefs returns an error naming the first ref that is not a commit
+func verifyRefs(ctx context.Context, executor commands.CommandExecutor, repoPath string, refs ...string) error {
+	for _, ref := range refs {
+		result := executor.ExecuteInDir(ctx, repoPath, "git", "rev-parse", "--verify", "--quiet", ref+"^{commit}")
+		if result.ExitCode != 0 || result.Error != nil {
+			return fmt.Errorf("unknown git ref %q", ref)
+		}
+	}
+	return nil
+}
+
+// manifestAt reads and parses a manifest at a ref, reporting whether it exists there
+func manifestAt(ctx context.Context, executor commands.CommandExecutor, repoPath, ref, file string, parse func(string) (map[string]string, error)) (map[string]string, bool, error) {
+	result := executor.ExecuteInDir(ctx, repoPath, "git", "show", ref+":"+file)
+	if result.ExitCode != 0 || result.Error != nil {
+		// The refs are known to exist, so the file is missing at this one
+		return nil, false, nil
+	}
+	dependencies, err := parse(result.Stdout)
+	if err != nil {
+		return nil, true, fmt.Errorf("failed to parse %s at %s: %w", file, ref, err)
+	}
+	return dependencies, true, nil
+}
+
+// diffDependencyVersions compares two sets of dependencies, ordered by name
+func diffDependencyVersions(before, after map[string]string) []DependencyChange {
+	changes := []DependencyChange{}
+	for name, version := range after {
+		old, existed := before[name]
+		switch {
+		case !existed:
+			changes = append(changes, DependencyChange{Name: name, Change: DependencyAdded, To: version})
+		case old != version:
+			changes = append(changes, DependencyChange{Name: name, Change: DependencyUpdated, From: old, To: version})
+		}
+	}
+	for name, version := range before {
+		if _, exists := after[name]; !exists {
+			changes = append(changes, DependencyChange{Name: name, Change: DependencyRemoved, From: version})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Name < changes[j].Name })
+	return changes
+}
+
+// Write prints the changes per manifest as a release-notes style list
+func (d DependencyDrift) Write(w io.Writer) error {
+	var err error
+	printf := func(format string, args ...interface{}) {
+		if err == nil {
+			_, err = fmt.Fprintf(w, format, args...)
+		}
+	}
+
+	printf("%s: %s..%s\n", d.Repository, d.From, d.To)
+	if len(d.Manifests) == 0 {
+		printf("  No dependency changes\n")
+	}
+	for _, manifest := range d.Manifests {
+		note := ""
+		switch {
+		case manifest.Added:
+			note = fmt.Sprintf(" (not present at %s)", d.From)
+		case manifest.Removed:
+			note = fmt.Sprintf(" (not present at %s)", d.To)
+		}
+		printf("  %s [%s]%s\n", manifest.File, manifest.Ecosystem, note)
+		for _, change := range manifest.Changes {
+			switch change.Change {
+			case DependencyAdded:
+				printf("    + %s %s\n", change.Name, change.To)
+			case DependencyRemoved:
+				printf("    - %s %s\n", change.Name, change.From)
+			default:
+				printf("    ~ %s %s -> %s\n", change.Name, change.From, change.To)
+			}
+		}
+	}
+	return err
+}
+
+// parseGoModRequirements reads the required modules of go.mod, in single-line
+// and block form
+func parseGoModRequirements(content string) (map[string]string, error) {
+	requirements := make(map[string]string)
+	inBlock := false
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	for scanner.Scan() {
+		line, _, _ := strings.Cut(scanner.Text(), "//")
+		fields := strings.Fields(line)
+		switch {
+		case len(fields) == 0:
+			continue
+		case inBlock && fields[0] == ")":
+			inBlock = false
+			continue
+		case opensRequireBlock(fields):
+			inBlock = true
+			continue
+		case fields[0] == "require":
+			fields = fields[1:]
+		case !inBlock:
+			continue
+		}
+		if len(fields) >= 2 {
+			requirements[fields[0]] = fields[1]
+		}
+	}
+	return requirements, scanner.Err()
+}
+
+// opensRequireBlock reports whether the fields of a go.mod line are "require ("
+func opensRequireBlock(fields []string) bool {
+	return len(fields) == 2 && fields[0] == "require" && fields[1] == "("
+}
+
+// parsePackageJSONDependencies reads the runtime and development dependencies of package.json
+func parsePackageJSONDependencies(content string) (map[string]string, error) {
+	var manifest struct {
+		Dependencies    map[string]string `json:"dependencies"`
+		DevDependencies map[string]string `json:"devDependencies"`
+	}
+	if err := json.Unmarshal([]byte(content), &manifest); err != nil {
+		return nil, err
+	}
+
+	dependencies := make(map[string]string, len(manifest.Dependencies)+len(manifest.DevDependencies))
+	for name, version := range manifest.DevDependencies {
+		dependencies[name] = version
+	}
+	for name, version := range manifest.Dependencies {
+		dependencies[name] = version
+	}
+	return dependencies, nil
+}
+
+// requirementPattern splits a requirements.txt line into the package name and its version specifier
+var requirementPattern = regexp.MustCompile(`^([A-Za-z0-9][A-Za-z0-9._-]*)(?:\[[^\]]*\])?\s*(.*)$`)
+
+// parseRequirementsTxt reads the packages of requirements.txt, skipping options
+// such as -r and -e. Names are normalized as pip does.
+func parseRequirementsTxt(content string) (map[string]string, error) {
+	requirements := make(map[string]string)
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	for scanner.Scan() {
+		line, _, _ := strings.Cut(scanner.Text(), "#")
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "-") {
+			continue
+		}
+		line, _, _ = strings.Cut(line, ";")
+		match := requirementPattern.FindStringSubmatch(strings.TrimSpace(line))
+		if match == nil {
+			continue
+		}
+		name := strings.ToLower(strings.NewReplacer("_", "-", ".", "-").Replace(match[1]))
+		requirements[name] = strings.ReplaceAll(match[2], " ", "")
+	}
+	return requirements, scanner.Err()
+}
+
+// cargoVersionPattern finds the version in an inline table such as { version = "1.0", features = [...] }
+var cargoVersionPattern = regexp.MustCompile(`\bversion\s*=\s*"([^"]*)"`)
+
+// parseCargoDependencies reads the dependencies of Cargo.toml, including those
+// declared as tables such as [dependencies.serde] and with dotted keys such as
+// serde.version. Dependencies without a version, such as path dependencies, are
+// recorded with an empty one. Lines continuing a multi-line array or inline
+// table are skipped, so keys inside them are not taken for dependencies.
+func parseCargoDependencies(content string) (map[string]string, error) {
+	dependencies := make(map[string]string)
+	inDependencies, table, depth := false, "", 0
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if depth > 0 {
+			depth += cargoNesting(line)
+			continue
+		}
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		if strings.HasPrefix(line, "[") {
+			inDependencies, table = cargoDependencyTable(strings.Trim(line, "[] "))
+			if table != "" {
+				dependencies[table] = ""
+			}
+			continue
+		}
+
+		key, value, found := strings.Cut(line, "=")
+		if !found {
+			continue
+		}
+		value = strings.TrimSpace(value)
+		depth = cargoNesting(value)
+		if inDependencies || table != "" {
+			recordCargoDependency(dependencies, table, strings.TrimSpace(key), value)
+		}
+	}
+	return dependencies, scanner.Err()
+}
+
+// cargoDependencyTable reports whether a table lists dependencies, such as
+// [dependencies], or declares a single one, such as [dependencies.serde], in
+// which case its name is returned
+func cargoDependencyTable(section string) (bool, string) {
+	if isCargoDependencySection(section) {
+		return true, ""
+	}
+	if dot := strings.LastIndex(section, "."); dot > 0 && isCargoDependencySection(section[:dot]) {
+		return false, strings.Trim(section[dot+1:], `"'`)
+	}
+	return false, ""
+}
+
+// recordCargoDependency records a key of a dependency table: a dependency
+// in a table that lists them, or the version of the dependency a table declares
+func recordCargoDependency(dependencies map[string]string, table, key, value string) {
+	if table != "" {
+		if key == "version" {
+			dependencies[table] = cargoVersion(value)
+		}
+		return
+	}
+
+	name, field, dotted := strings.Cut(key, ".")
+	name = strings.Trim(strings.TrimSpace(name), `"'`)
+	if !dotted {
+		dependencies[name] = cargoVersion(value)
+		return
+	}
+	if strings.TrimSpace(field) == "version" {
+		dependencies[name] = cargoVersion(value)
+	} else if _, exists := dependencies[name]; !exists {
+		dependencies[name] = ""
+	}
+}
+
+// cargoVersion returns the version of a dependency value, either a version
+// string or an inline table with a version key, or "" if it has none
+func cargoVersion(value string) string {
+	if strings.HasPrefix(value, `"`) {
+		version, _, _ := strings.Cut(value[1:], `"`)
+		return version
+	}
+	if match := cargoVersionPattern.FindStringSubmatch(value); match != nil {
+		return match[1]
+	}
+	return ""
+}
+
+// cargoNesting returns how many arrays and inline tables a line opens minus
+// how many it closes, ignoring brackets in strings and comments
+func cargoNesting(line string) int {
+	depth, inString := 0, false
+	for i := 0; i < len(line); i++ {
+		c := line[i]
+		if inString {
+			if c == '\\' {
+				i++
+			} else if c == '"' {
+				inString = false
+			}
+			continue
+		}
+		switch c {
+		case '"':
+			inString = true
+		case '#':
+			return depth
+		case '[', '{':
+			depth++
+		case ']', '}':
+			depth--
+		}
+	}
+	return depth
+}
+
+// parseComposerRequirements reads the packages required by composer.json,
+// skipping PHP and its extensions
+func parseComposerRequirements(content string) (map[string]string, error) {
+	var manifest struct {
+		Require    map[string]string `json:"require"`
+		RequireDev map[string]string `json:"require-dev"`
+	}
+	if err := json.Unmarshal([]byte(content), &manifest); err != nil {
+		return nil, err
+	}
+
+	requirements := make(map[string]string)
+	for _, require := range []map[string]string{manifest.RequireDev, manifest.Require} {
+		for name, version := range require {
+			if !composerPlatformPackage(name) {
+				requirements[name] = version
+			}
+		}
+	}
+	return requirements, nil
+}
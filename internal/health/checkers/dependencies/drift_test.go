@@ -0,0 +1,127 @@
+package dependencies
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/codcod/repos/internal/platform/commands"
+)
+
+// driftTestExecutor serves the given manifests, keyed by ref:file; every other
+// manifest is missing at both refs
+func driftTestExecutor(files map[string]string) *commands.MockCommandExecutor {
+	executor := commands.NewMockCommandExecutor()
+	for _, ref := range []string{"v1.0.0", "HEAD"} {
+		for _, manifest := range DriftManifests {
+			executor.SetResponse("git show "+ref+":"+manifest.File, commands.CommandResult{
+				ExitCode: 128,
+				Stderr:   "fatal: path '" + manifest.File + "' does not exist in '" + ref + "'",
+			})
+		}
+	}
+	for path, content := range files {
+		executor.SetResponse("git show "+path, commands.CommandResult{Stdout: content})
+	}
+	return executor
+}
+
+func TestDiffDependencies(t *testing.T) {
+	executor := driftTestExecutor(map[string]string{
+		"v1.0.0:go.mod":     "module example.com/app\n\nrequire (\n\tgithub.com/pkg/errors v0.9.0\n\tgolang.org/x/sync v0.5.0 // indirect\n)\n",
+		"HEAD:go.mod":       "module example.com/app\n\nrequire (\n\tgithub.com/pkg/errors v0.9.1\n\tgithub.com/spf13/cobra v1.8.0\n)\n\nreplace github.com/pkg/errors => ../errors\n",
+		"HEAD:package.json": `{"dependencies":{"left-pad":"^1.3.0"}}`,
+	})
+
+	drift, err := DiffDependencies(context.Background(), executor, "/repo", "v1.0.0", "HEAD")
+	if err != nil {
+		t.Fatalf("DiffDependencies failed: %v", err)
+	}
+	if len(drift.Manifests) != 2 {
+		t.Fatalf("Expected go.mod and package.json, got %+v", drift.Manifests)
+	}
+
+	goMod := drift.Manifests[0]
+	expected := []DependencyChange{
+		{Name: "github.com/pkg/errors", Change: DependencyUpdated, From: "v0.9.0", To: "v0.9.1"},
+		{Name: "github.com/spf13/cobra", Change: DependencyAdded, To: "v1.8.0"},
+		{Name: "golang.org/x/sync", Change: DependencyRemoved, From: "v0.5.0"},
+	}
+	if goMod.File != "go.mod" || goMod.Added || goMod.Removed || !reflect.DeepEqual(goMod.Changes, expected) {
+		t.Errorf("Unexpected go.mod drift: %+v", goMod)
+	}
+
+	// package.json did not exist at the older ref
+	packageJSON := drift.Manifests[1]
+	if !packageJSON.Added || packageJSON.Ecosystem != "node" || len(packageJSON.Changes) != 1 || packageJSON.Changes[0].Change != DependencyAdded {
+		t.Errorf("Expected package.json to be added with its dependency, got %+v", packageJSON)
+	}
+
+	var out strings.Builder
+	drift.Repository = "app"
+	if err := drift.Write(&out); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"app: v1.0.0..HEAD", "~ github.com/pkg/errors v0.9.0 -> v0.9.1", "package.json [node] (not present at v1.0.0)", "+ left-pad ^1.3.0"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("Expected output to contain %q, got:\n%s", want, out.String())
+		}
+	}
+}
+
+func TestDiffDependencies_UnknownRef(t *testing.T) {
+	executor := driftTestExecutor(nil)
+	executor.SetResponse("git rev-parse --verify --quiet v9^{commit}", commands.CommandResult{ExitCode: 1})
+
+	if _, err := DiffDependencies(context.Background(), executor, "/repo", "v9", "HEAD"); err == nil || !strings.Contains(err.Error(), `"v9"`) {
+		t.Errorf("Expected an unknown ref error, got %v", err)
+	}
+}
+
+func TestParseDriftManifests(t *testing.T) {
+	tests := []struct {
+		name     string
+		parse    func(string) (map[string]string, error)
+		content  string
+		expected map[string]string
+	}{
+		{
+			name:     "requirements.txt",
+			parse:    parseRequirementsTxt,
+			content:  "-r base.txt\nDjango==4.2  # web\nrequests[socks] >= 2.31; python_version > '3.8'\nsix\n",
+			expected: map[string]string{"django": "==4.2", "requests": ">=2.31", "six": ""},
+		},
+		{
+			name:     "Cargo.toml",
+			parse:    parseCargoDependencies,
+			content:  "[package]\nversion = \"0.1.0\"\n\n[dependencies]\nserde = { version = \"1.0\", features = [\"derive\"] }\nanyhow = \"1\"\nlocal = { path = \"../local\" }\n\n[dependencies.tokio]\nversion = \"1.35\"\n",
+			expected: map[string]string{"serde": "1.0", "anyhow": "1", "local": "", "tokio": "1.35"},
+		},
+		{
+			name:  "Cargo.toml with multi-line values and dotted keys",
+			parse: parseCargoDependencies,
+			content: "[dependencies]\nclap = { version = \"4\", features = [\n  \"derive\",\n  \"env\",\n] }\nrand.version = \"0.8\"\nrand.default-features = false\n\n" +
+				"[dependencies.tokio]\nversion = \"1.35\"\nfeatures = [\"full\"]\n\n[target.'cfg(unix)'.dependencies]\nlibc = \"0.2\" # unix only\n",
+			expected: map[string]string{"clap": "4", "rand": "0.8", "tokio": "1.35", "libc": "0.2"},
+		},
+		{
+			name:     "composer.json",
+			parse:    parseComposerRequirements,
+			content:  `{"require":{"php":">=8.1","ext-json":"*","monolog/monolog":"^3.0"},"require-dev":{"phpunit/phpunit":"^10"}}`,
+			expected: map[string]string{"monolog/monolog": "^3.0", "phpunit/phpunit": "^10"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.parse(tt.content)
+			if err != nil {
+				t.Fatalf("parse failed: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,99 @@
+package dependencies
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/codcod/repos/internal/core"
+	"github.com/codcod/repos/internal/health/checkers/base"
+)
+
+// applyLockfilePolicy adds a missing_lockfile issue for each manifest of an
+// ecosystem that expects one of its lockfiles but has none of them. Every
+// ecosystem, with or without a built-in handler, is held to the same policy:
+// each missing lockfile costs 20 points and makes a healthy ecosystem a warning.
+func (c *OutdatedChecker) applyLockfilePolicy(repoPath string, manifests []DependencyManifest, result core.CheckResult) core.CheckResult {
+	missing := 0
+	for _, manifest := range manifests {
+		if !lockfileExpected(repoPath, manifest) || c.anyExists(repoPath, manifest.Lockfiles) {
+			continue
+		}
+		missing++
+		issue := base.NewIssueWithLocation(
+			"missing_lockfile",
+			core.SeverityMedium,
+			fmt.Sprintf("%s has no lockfile (%s)", manifest.File, strings.Join(manifest.Lockfiles, ", ")),
+			manifest.File,
+			0,
+			0,
+		)
+		issue.Suggestion = "Commit the lockfile generated by the package manager so installs are reproducible"
+		issue.Context["lockfiles"] = manifest.Lockfiles
+		result.Issues = append(result.Issues, issue)
+	}
+
+	if result.Metrics == nil {
+		result.Metrics = make(map[string]interface{})
+	}
+	result.Metrics["missing_lockfiles"] = missing
+	if missing == 0 {
+		return result
+	}
+
+	result.Score = max(result.Score-20*missing, 0)
+	if result.Status == core.StatusHealthy {
+		result.Status = core.StatusWarning
+	}
+	return result
+}
+
+// lockfileExpected reports whether a manifest should have one of its lockfiles.
+// Manifests without lockfiles, such as requirements.txt, and manifests that
+// declare no dependencies have nothing to lock; Rust libraries leave
+// Cargo.lock to the binaries that depend on them.
+func lockfileExpected(repoPath string, manifest DependencyManifest) bool {
+	if len(manifest.Lockfiles) == 0 {
+		return false
+	}
+
+	switch manifest.File {
+	case "go.mod":
+		return manifestContains(repoPath, manifest.File, "require")
+	case "pyproject.toml":
+		return manifestContains(repoPath, manifest.File, "dependencies")
+	case "package.json":
+		return packageJSONDeclaresDependencies(repoPath)
+	case "Cargo.toml":
+		cargo, err := parseCargoManifest(repoPath)
+		return err != nil || cargo.IsBinary
+	}
+	return true
+}
+
+// manifestContains reports whether a manifest mentions a keyword; an unreadable
+// manifest is assumed to mention it so that its lockfile is still expected
+func manifestContains(repoPath, file, keyword string) bool {
+	content, err := os.ReadFile(filepath.Join(repoPath, file)) //nolint:gosec // Path is within the repository
+	return err != nil || strings.Contains(string(content), keyword)
+}
+
+// packageJSONDeclaresDependencies reports whether package.json declares any
+// installed dependencies; an unreadable package.json is assumed to
+func packageJSONDeclaresDependencies(repoPath string) bool {
+	data, err := os.ReadFile(filepath.Join(repoPath, "package.json")) //nolint:gosec // Path is within the repository
+	if err != nil {
+		return true
+	}
+	var pkg struct {
+		Dependencies         map[string]string `json:"dependencies"`
+		DevDependencies      map[string]string `json:"devDependencies"`
+		OptionalDependencies map[string]string `json:"optionalDependencies"`
+	}
+	if err := json.Unmarshal(data, &pkg); err != nil {
+		return true
+	}
+	return len(pkg.Dependencies)+len(pkg.DevDependencies)+len(pkg.OptionalDependencies) > 0
+}
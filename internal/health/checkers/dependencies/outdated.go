@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"time"
 
@@ -39,6 +40,25 @@ func NewOutdatedChecker(executor commands.CommandExecutor) *OutdatedChecker {
 	}
 }
 
+// Metadata describes what the checker verifies
+func (*OutdatedChecker) Metadata() core.CheckerMetadata {
+	return core.CheckerMetadata{
+		Description: "Reports outdated dependencies for every ecosystem found in the repository (Go, Node.js, Python, Maven, Gradle, Rust and PHP Composer). " +
+			"Python dependencies are also audited for vulnerabilities when pip-audit is installed. Only the tools for the ecosystems present are needed. " +
+			"Every ecosystem, including those registered with the manifests option, is checked for a lockfile next to its manifest (go.sum, package-lock.json, yarn.lock, poetry.lock, Cargo.lock and so on) " +
+			"unless the manifest declares no dependencies; Rust libraries need no Cargo.lock. " +
+			"Deprecated Go modules and npm packages, and retracted Go module versions, are reported separately from outdated ones.",
+		RequiredTools: []string{"go", "npm", "pip", "pip-audit", "mvn", "gradle", "cargo", "composer"},
+		Options: []core.CheckerOption{
+			{Name: "manifests", Default: []DependencyManifest{}, Description: "Additional dependency files as {file, ecosystem, lockfiles}; an entry for a built-in file replaces it"},
+			{Name: "max_major_behind", Default: 0, Description: "Major versions a Go or npm dependency may fall behind its latest release; 0 disables the check"},
+			{Name: "max_age_months", Default: 0, Description: "Months a Go dependency may fall behind its latest release, by release date; 0 disables the check"},
+			{Name: "check_deprecated", Default: "true", Description: "Report deprecated and retracted Go modules and deprecated npm packages; the npm check queries the registry once per direct dependency"},
+			{Name: "ecosystems", Default: []string{}, Description: "Only check these ecosystems, e.g. [go]; the others are skipped even when their manifests exist (repos health --ecosystem)"},
+		},
+	}
+}
+
 // Check performs the outdated dependencies check
 func (c *OutdatedChecker) Check(ctx context.Context, repoCtx core.RepositoryContext) (core.CheckResult, error) {
 	return c.Execute(ctx, repoCtx, func() (core.CheckResult, error) {
@@ -50,8 +70,13 @@ func (c *OutdatedChecker) Check(ctx context.Context, repoCtx core.RepositoryCont
 func (c *OutdatedChecker) checkOutdatedDependencies(ctx context.Context, repoCtx core.RepositoryContext) (core.CheckResult, error) {
 	builder := base.NewResultBuilder(c.ID(), c.Name(), c.Category())
 
+	manifests, err := c.manifests(repoCtx)
+	if err != nil {
+		return core.CheckResult{}, err
+	}
+
 	// Find dependency files
-	depFiles := c.findDependencyFiles(repoCtx.Repository.Path)
+	depFiles := c.findDependencyFiles(repoCtx.Repository.Path, manifests)
 	builder.AddMetric("dependency_files_found", len(depFiles))
 
 	if len(depFiles) == 0 {
@@ -62,70 +87,286 @@ func (c *OutdatedChecker) checkOutdatedDependencies(ctx context.Context, repoCtx
 	}
 
 	// Add found files to metrics
-	for i, file := range depFiles {
-		builder.AddMetric(fmt.Sprintf("dependency_file_%d", i), file)
+	for i, manifest := range depFiles {
+		builder.AddMetric(fmt.Sprintf("dependency_file_%d", i), manifest.File)
+	}
+
+	// Leave out the ecosystems not selected by the ecosystems option
+	depFiles, skipped := filterEcosystems(depFiles, c.StringSliceOption(repoCtx, "ecosystems"))
+	if len(skipped) > 0 {
+		builder.AddMetric("ecosystems_skipped", skipped)
+	}
+	if len(depFiles) == 0 {
+		builder.WithStatus(core.StatusHealthy)
+		builder.WithScore(100, 100)
+		builder.AddMetric("status", "no_selected_ecosystems")
+		return builder.Build(), nil
 	}
 
 	// Check dependencies by project type
 	return c.checkDependenciesByType(ctx, repoCtx, builder, depFiles)
 }
 
-// findDependencyFiles finds dependency files in the repository
-func (c *OutdatedChecker) findDependencyFiles(repoPath string) []string {
-	depFiles := []string{
-		"go.mod", "package.json", "requirements.txt", "pyproject.toml",
-		"Gemfile", "Cargo.toml", "pom.xml", "build.gradle", "build.gradle.kts",
-		"composer.json", "Package.swift",
+// DependencyManifest maps a dependency file in the repository root to its
+// ecosystem and the lockfiles, any one of which is expected next to it
+type DependencyManifest struct {
+	File      string   `yaml:"file" json:"file"`
+	Ecosystem string   `yaml:"ecosystem" json:"ecosystem"`
+	Lockfiles []string `yaml:"lockfiles,omitempty" json:"lockfiles,omitempty"`
+}
+
+// DefaultManifests are the dependency files detected without configuration
+var DefaultManifests = []DependencyManifest{
+	{File: "go.mod", Ecosystem: "go", Lockfiles: []string{"go.sum"}},
+	{File: "package.json", Ecosystem: "node", Lockfiles: []string{"package-lock.json", "npm-shrinkwrap.json", "yarn.lock", "pnpm-lock.yaml"}},
+	{File: "requirements.txt", Ecosystem: "python"},
+	{File: "pyproject.toml", Ecosystem: "python", Lockfiles: []string{"poetry.lock", "uv.lock", "pdm.lock"}},
+	{File: "Gemfile", Ecosystem: "ruby", Lockfiles: []string{"Gemfile.lock"}},
+	{File: "Cargo.toml", Ecosystem: "rust", Lockfiles: []string{"Cargo.lock"}},
+	{File: "pom.xml", Ecosystem: "maven"},
+	{File: "build.gradle", Ecosystem: "gradle", Lockfiles: []string{"gradle.lockfile"}},
+	{File: "build.gradle.kts", Ecosystem: "gradle", Lockfiles: []string{"gradle.lockfile"}},
+	{File: "composer.json", Ecosystem: "php", Lockfiles: []string{"composer.lock"}},
+	{File: "Package.swift", Ecosystem: "swift", Lockfiles: []string{"Package.resolved"}},
+}
+
+// manifests returns the default manifests with those configured in the manifests
+// option applied: an entry for a file already known replaces it, others are added
+func (c *OutdatedChecker) manifests(repoCtx core.RepositoryContext) ([]DependencyManifest, error) {
+	var configured []DependencyManifest
+	if _, err := c.DecodeOption(repoCtx, "manifests", &configured); err != nil {
+		return nil, err
+	}
+
+	manifests := append([]DependencyManifest{}, DefaultManifests...)
+	for i, manifest := range configured {
+		if manifest.File == "" || manifest.Ecosystem == "" {
+			return nil, fmt.Errorf("option manifests: entry %d needs both file and ecosystem", i)
+		}
+		replaced := false
+		for j := range manifests {
+			if manifests[j].File == manifest.File {
+				manifests[j] = manifest
+				replaced = true
+			}
+		}
+		if !replaced {
+			manifests = append(manifests, manifest)
+		}
+	}
+	return manifests, nil
+}
+
+// filterEcosystems keeps the manifests of the selected ecosystems and returns the
+// names of the others, in the order found. Without a selection every manifest is kept.
+func filterEcosystems(manifests []DependencyManifest, selected []string) ([]DependencyManifest, []string) {
+	if len(selected) == 0 {
+		return manifests, nil
 	}
-	var foundFiles []string
 
-	for _, file := range depFiles {
-		if _, err := os.Stat(filepath.Join(repoPath, file)); err == nil {
-			foundFiles = append(foundFiles, file)
+	keep := make(map[string]bool, len(selected))
+	for _, name := range selected {
+		keep[strings.ToLower(strings.TrimSpace(name))] = true
+	}
+
+	var kept []DependencyManifest
+	var skipped []string
+	for _, manifest := range manifests {
+		if keep[strings.ToLower(manifest.Ecosystem)] {
+			kept = append(kept, manifest)
+		} else if !slices.Contains(skipped, manifest.Ecosystem) {
+			skipped = append(skipped, manifest.Ecosystem)
 		}
 	}
-	return foundFiles
+	return kept, skipped
+}
+
+// findDependencyFiles returns the manifests present in the repository
+func (c *OutdatedChecker) findDependencyFiles(repoPath string, manifests []DependencyManifest) []DependencyManifest {
+	var found []DependencyManifest
+	for _, manifest := range manifests {
+		if _, err := os.Stat(filepath.Join(repoPath, manifest.File)); err == nil {
+			found = append(found, manifest)
+		}
+	}
+	return found
+}
+
+// dependencyEcosystem describes how to check one package ecosystem. Ecosystems
+// reporting the versions behind implement stale to support the age policy, and
+// those reporting deprecations implement deprecated.
+type dependencyEcosystem struct {
+	name       string
+	check      func(c *OutdatedChecker, ctx context.Context, repoPath string, builder *base.ResultBuilder) (core.CheckResult, error)
+	stale      func(c *OutdatedChecker, ctx context.Context, repoPath string) ([]staleDependency, error)
+	deprecated func(c *OutdatedChecker, ctx context.Context, repoPath string) ([]deprecatedDependency, error)
 }
 
-// checkDependenciesByType checks dependencies based on project type
-func (c *OutdatedChecker) checkDependenciesByType(ctx context.Context, repoCtx core.RepositoryContext, builder *base.ResultBuilder, foundFiles []string) (core.CheckResult, error) {
+// dependencyEcosystems lists the ecosystems with a built-in handler in reporting order
+var dependencyEcosystems = []dependencyEcosystem{
+	{"go", (*OutdatedChecker).checkGoMod, (*OutdatedChecker).staleGoModules, (*OutdatedChecker).deprecatedGoModules},
+	{"node", (*OutdatedChecker).checkPackageJSON, (*OutdatedChecker).staleNpmPackages, (*OutdatedChecker).deprecatedNpmPackages},
+	{"python", (*OutdatedChecker).checkPythonDependencies, nil, nil},
+	{"maven", (*OutdatedChecker).checkMavenPom, nil, nil},
+	{"gradle", (*OutdatedChecker).checkGradleBuild, nil, nil},
+	{"rust", (*OutdatedChecker).checkCargoToml, nil, nil},
+	{"php", (*OutdatedChecker).checkComposer, nil, nil},
+}
+
+// ecosystemResult holds the outcome of checking a single ecosystem
+type ecosystemResult struct {
+	name   string
+	result core.CheckResult
+}
+
+// checkDependenciesByType checks every ecosystem with a dependency file in the
+// repository and combines the results. Ecosystems with a built-in handler come
+// first; the others get the generic check in the order they were found. All of
+// them are checked for the lockfiles their manifests expect.
+func (c *OutdatedChecker) checkDependenciesByType(ctx context.Context, repoCtx core.RepositoryContext, builder *base.ResultBuilder, found []DependencyManifest) (core.CheckResult, error) {
 	repoPath := repoCtx.Repository.Path
+	policy := c.policy(repoCtx)
+	checkDeprecated := c.BoolOption(repoCtx, "check_deprecated", true)
+
+	byEcosystem, order := groupByEcosystem(found)
 
-	// Check Go dependencies
-	if c.contains(foundFiles, "go.mod") {
-		return c.checkGoMod(ctx, repoPath, builder)
+	var results []ecosystemResult
+	handled := make(map[string]bool)
+	for _, ecosystem := range dependencyEcosystems {
+		handled[ecosystem.name] = true
+		if len(byEcosystem[ecosystem.name]) == 0 {
+			continue
+		}
+		result, err := ecosystem.check(c, ctx, repoPath, base.NewResultBuilder(c.ID(), c.Name(), c.Category()))
+		if err != nil {
+			return core.CheckResult{}, fmt.Errorf("%s dependency check failed: %w", ecosystem.name, err)
+		}
+		if policy.enabled() && ecosystem.stale != nil {
+			result = c.checkAgePolicy(ctx, repoPath, ecosystem, policy, result)
+		}
+		if checkDeprecated && ecosystem.deprecated != nil {
+			result = c.checkDeprecations(ctx, repoPath, ecosystem, result)
+		}
+		result = c.applyLockfilePolicy(repoPath, byEcosystem[ecosystem.name], result)
+		results = append(results, ecosystemResult{name: ecosystem.name, result: result})
+	}
+	for _, name := range order {
+		if handled[name] {
+			continue
+		}
+		result := c.checkLockfiles(repoPath, name, byEcosystem[name], base.NewResultBuilder(c.ID(), c.Name(), c.Category()))
+		results = append(results, ecosystemResult{name: name, result: result})
 	}
 
-	// Check Node.js dependencies
-	if c.contains(foundFiles, "package.json") {
-		return c.checkPackageJSON(ctx, repoPath, builder)
+	return c.combineEcosystemResults(builder, results), nil
+}
+
+// groupByEcosystem groups manifests by ecosystem, returning the ecosystems in
+// the order they were found
+func groupByEcosystem(found []DependencyManifest) (map[string][]DependencyManifest, []string) {
+	byEcosystem := make(map[string][]DependencyManifest)
+	var order []string
+	for _, manifest := range found {
+		if _, seen := byEcosystem[manifest.Ecosystem]; !seen {
+			order = append(order, manifest.Ecosystem)
+		}
+		byEcosystem[manifest.Ecosystem] = append(byEcosystem[manifest.Ecosystem], manifest)
 	}
+	return byEcosystem, order
+}
 
-	// Check Python dependencies
-	if c.contains(foundFiles, "requirements.txt") || c.contains(foundFiles, "pyproject.toml") {
-		return c.checkPythonDependencies(ctx, repoPath, builder)
+// checkAgePolicy applies the age policy to an ecosystem result. The tool output
+// needed is only requested when a policy is configured.
+func (c *OutdatedChecker) checkAgePolicy(ctx context.Context, repoPath string, ecosystem dependencyEcosystem, policy agePolicy, result core.CheckResult) core.CheckResult {
+	stale, err := ecosystem.stale(c, ctx, repoPath)
+	if err != nil {
+		result.Warnings = append(result.Warnings, core.Warning{
+			Type:    "dependency_policy_error",
+			Message: fmt.Sprintf("Unable to check %s dependencies against the age policy: %v", ecosystem.name, err),
+		})
+		return result
 	}
+	return c.applyAgePolicy(result, policy, stale)
+}
+
+// checkLockfiles is the generic check for ecosystems without a built-in handler.
+// Outdated packages cannot be detected, so only the lockfile policy applies.
+func (c *OutdatedChecker) checkLockfiles(repoPath, ecosystem string, manifests []DependencyManifest, builder *base.ResultBuilder) core.CheckResult {
+	builder.AddMetric("project_type", ecosystem)
+	builder.AddMetric("status", "manifest_only")
+	builder.WithStatus(core.StatusHealthy)
+	builder.WithScore(100, 100)
+	return c.applyLockfilePolicy(repoPath, manifests, builder.Build())
+}
 
-	// Check Java dependencies
-	if c.contains(foundFiles, "pom.xml") {
-		return c.checkMavenPom(ctx, repoPath, builder)
+// anyExists reports whether any of the files exists in dir
+func (c *OutdatedChecker) anyExists(dir string, files []string) bool {
+	for _, file := range files {
+		if _, err := os.Stat(filepath.Join(dir, file)); err == nil {
+			return true
+		}
 	}
+	return false
+}
 
-	if c.contains(foundFiles, "build.gradle") || c.contains(foundFiles, "build.gradle.kts") {
-		return c.checkGradleBuild(ctx, repoPath, builder)
+// combineEcosystemResults merges per-ecosystem results into one. The overall status
+// and score are the worst of the ecosystems. Metrics of the first ecosystem keep their
+// original keys for compatibility; every ecosystem's metrics are also recorded with
+// the ecosystem name as a prefix, e.g. node_outdated_packages.
+func (c *OutdatedChecker) combineEcosystemResults(builder *base.ResultBuilder, results []ecosystemResult) core.CheckResult {
+	status := core.StatusHealthy
+	score, maxScore := 100, 100
+	statuses := make(map[string]string, len(results))
+	names := make([]string, 0, len(results))
+
+	for i, eco := range results {
+		names = append(names, eco.name)
+		statuses[eco.name] = string(eco.result.Status)
+		if statusRank(eco.result.Status) > statusRank(status) {
+			status = eco.result.Status
+		}
+		if i == 0 || eco.result.Score*maxScore < score*eco.result.MaxScore {
+			score, maxScore = eco.result.Score, eco.result.MaxScore
+		}
+
+		for key, value := range eco.result.Metrics {
+			if i == 0 {
+				builder.AddMetric(key, value)
+			}
+			builder.AddMetric(eco.name+"_"+key, value)
+		}
+		for _, issue := range eco.result.Issues {
+			if issue.Context == nil {
+				issue.Context = make(map[string]interface{})
+			}
+			issue.Context["ecosystem"] = eco.name
+			builder.AddIssue(issue)
+		}
+		for _, warning := range eco.result.Warnings {
+			builder.AddWarning(warning)
+		}
 	}
 
-	// Generic handling for unsupported types
-	builder.WithStatus(core.StatusWarning)
-	builder.WithScore(60, 100)
-	builder.AddIssue(base.NewIssueWithSuggestion(
-		"unsupported_dependency_type",
-		core.SeverityMedium,
-		fmt.Sprintf("Dependency checking not implemented for: %s", strings.Join(foundFiles, ", ")),
-		"Consider implementing dependency checking for this project type",
-	))
+	builder.AddMetric("ecosystems", names)
+	builder.AddMetric("ecosystem_statuses", statuses)
+	builder.WithStatus(status)
+	builder.WithScore(score, maxScore)
 
-	return builder.Build(), nil
+	return builder.Build()
+}
+
+// statusRank orders statuses from best to worst
+func statusRank(status core.HealthStatus) int {
+	switch status {
+	case core.StatusHealthy:
+		return 0
+	case core.StatusWarning:
+		return 1
+	case core.StatusCritical:
+		return 2
+	default:
+		return 1
+	}
 }
 
 // checkGoMod checks Go module dependencies
@@ -164,18 +405,24 @@ func (c *OutdatedChecker) checkGoMod(ctx context.Context, repoPath string, build
 		))
 
 		// Add details about outdated dependencies
-		for i, dep := range outdatedDeps {
-			if i >= 5 { // Limit to first 5
-				builder.AddMetric("additional_outdated", len(outdatedDeps)-5)
-				break
-			}
-			builder.AddMetric(fmt.Sprintf("outdated_%d", i), dep)
-		}
+		addOutdatedMetrics(builder, outdatedDeps)
 	}
 
 	return builder.Build(), nil
 }
 
+// addOutdatedMetrics lists the first five outdated dependencies as metrics,
+// with the number of others
+func addOutdatedMetrics(builder *base.ResultBuilder, outdated []string) {
+	for i, dep := range outdated {
+		if i >= 5 { // Limit to first 5
+			builder.AddMetric("additional_outdated", len(outdated)-5)
+			break
+		}
+		builder.AddMetric(fmt.Sprintf("outdated_%d", i), dep)
+	}
+}
+
 // parseGoListOutput parses go list -u -m all output for outdated dependencies
 func (c *OutdatedChecker) parseGoListOutput(output string) []string {
 	var outdated []string
@@ -254,10 +501,24 @@ func (c *OutdatedChecker) countNpmOutdated(jsonOutput string) int {
 	return count
 }
 
-// checkPythonDependencies checks Python dependencies
+// checkPythonDependencies checks Python dependencies for updates and, when
+// pip-audit is installed, for known vulnerabilities
 func (c *OutdatedChecker) checkPythonDependencies(ctx context.Context, repoPath string, builder *base.ResultBuilder) (core.CheckResult, error) {
 	builder.AddMetric("project_type", "python")
 
+	advisories := c.auditPythonDependencies(ctx, repoPath, builder)
+	result, err := c.checkPipOutdated(ctx, repoPath, builder)
+	if err != nil || advisories == 0 {
+		return result, err
+	}
+
+	result.Status = core.StatusCritical
+	result.Score = max(result.Score-40, 0)
+	return result, nil
+}
+
+// checkPipOutdated checks Python dependencies for updates with pip
+func (c *OutdatedChecker) checkPipOutdated(ctx context.Context, repoPath string, builder *base.ResultBuilder) (core.CheckResult, error) {
 	// Check if pip is available
 	result := c.executor.Execute(ctx, "which", "pip")
 	if result.Error != nil {
@@ -441,19 +702,8 @@ func (c *OutdatedChecker) checkGradleWrapper(ctx context.Context, repoPath strin
 	return builder.Build(), nil
 }
 
-// contains checks if a slice contains a string
-func (c *OutdatedChecker) contains(slice []string, item string) bool {
-	for _, s := range slice {
-		if s == item {
-			return true
-		}
-	}
-	return false
-}
-
-// SupportsRepository checks if this checker supports the repository
+// SupportsRepository reports true: manifests registered in the configuration are
+// only known when checking, and repositories without dependency files pass
 func (c *OutdatedChecker) SupportsRepository(repo core.Repository) bool {
-	// Check if there are any dependency files
-	depFiles := c.findDependencyFiles(repo.Path)
-	return len(depFiles) > 0
+	return true
 }
@@ -0,0 +1,369 @@
+package dependencies
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/codcod/repos/internal/core"
+	"github.com/codcod/repos/internal/platform/commands"
+)
+
+func TestOutdatedChecker_ChecksEveryEcosystem(t *testing.T) {
+	repoPath := t.TempDir()
+	for name, content := range map[string]string{
+		"go.mod":       "module example.com/app\n",
+		"package.json": `{"name":"app"}`,
+	} {
+		if err := os.WriteFile(filepath.Join(repoPath, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	executor := commands.NewMockCommandExecutor()
+	executor.SetResponse("go list -u -m all", commands.CommandResult{
+		Stdout: "example.com/app\ngithub.com/pkg/errors v0.9.0\n",
+	})
+	executor.SetResponse("npm outdated --json", commands.CommandResult{
+		ExitCode: 1,
+		Stdout:   `{"left-pad":{"current":"1.0.0","wanted":"1.3.0","latest":"1.3.0"}}`,
+	})
+
+	checker := NewOutdatedChecker(executor)
+	result, err := checker.Check(context.Background(), core.RepositoryContext{
+		Repository: core.Repository{Name: "app", Path: repoPath},
+	})
+	if err != nil {
+		t.Fatalf("Check failed: %v", err)
+	}
+
+	// The worst ecosystem determines the overall result
+	if result.Status != core.StatusWarning || result.Score != 70 {
+		t.Errorf("Expected warning with score 70, got %s with %d", result.Status, result.Score)
+	}
+
+	statuses, ok := result.Metrics["ecosystem_statuses"].(map[string]string)
+	if !ok || statuses["go"] != "healthy" || statuses["node"] != "warning" {
+		t.Errorf("Unexpected ecosystem statuses: %v", result.Metrics["ecosystem_statuses"])
+	}
+
+	// Unprefixed keys come from the first ecosystem, as before
+	for metric, want := range map[string]interface{}{
+		"project_type":           "go",
+		"outdated_dependencies":  0,
+		"go_project_type":        "go",
+		"node_project_type":      "node",
+		"node_outdated_packages": 1,
+		"dependency_files_found": 2,
+	} {
+		if result.Metrics[metric] != want {
+			t.Errorf("Expected metric %s = %v, got %v", metric, want, result.Metrics[metric])
+		}
+	}
+
+	if len(result.Issues) != 1 || result.Issues[0].Context["ecosystem"] != "node" {
+		t.Errorf("Expected one npm issue tagged with its ecosystem, got %+v", result.Issues)
+	}
+}
+
+// manifestTestConfig provides options for the dependencies-outdated checker
+type manifestTestConfig struct {
+	options map[string]interface{}
+}
+
+func (c manifestTestConfig) GetCheckerConfig(string) (core.CheckerConfig, bool) {
+	return core.CheckerConfig{Enabled: true, Options: c.options}, true
+}
+func (manifestTestConfig) GetAnalyzerConfig(string) (core.AnalyzerConfig, bool) {
+	return core.AnalyzerConfig{}, false
+}
+func (manifestTestConfig) GetReporterConfig(string) (core.ReporterConfig, bool) {
+	return core.ReporterConfig{}, false
+}
+func (manifestTestConfig) GetEngineConfig() core.EngineConfig { return core.EngineConfig{} }
+
+func TestOutdatedChecker_ConfiguredManifests(t *testing.T) {
+	repoPath := t.TempDir()
+	for _, name := range []string{"mix.exs", "pubspec.yaml", "pubspec.lock", "Gemfile", "Gemfile.lock"} {
+		if err := os.WriteFile(filepath.Join(repoPath, name), []byte("\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	config := manifestTestConfig{options: map[string]interface{}{
+		"manifests": []interface{}{
+			map[string]interface{}{"file": "mix.exs", "ecosystem": "elixir", "lockfiles": []interface{}{"mix.lock"}},
+			map[string]interface{}{"file": "pubspec.yaml", "ecosystem": "dart", "lockfiles": []interface{}{"pubspec.lock"}},
+		},
+	}}
+	checker := NewOutdatedChecker(commands.NewMockCommandExecutor())
+	result, err := checker.Check(context.Background(), core.RepositoryContext{
+		Repository: core.Repository{Name: "app", Path: repoPath},
+		Config:     config,
+	})
+	if err != nil {
+		t.Fatalf("Check failed: %v", err)
+	}
+
+	if result.Status != core.StatusWarning || result.Score != 80 {
+		t.Errorf("Expected warning with score 80, got %s with %d", result.Status, result.Score)
+	}
+	statuses, _ := result.Metrics["ecosystem_statuses"].(map[string]string)
+	expected := map[string]string{"ruby": "healthy", "elixir": "warning", "dart": "healthy"}
+	if len(statuses) != len(expected) {
+		t.Errorf("Expected statuses %v, got %v", expected, statuses)
+	}
+	for ecosystem, status := range expected {
+		if statuses[ecosystem] != status {
+			t.Errorf("Expected %s to be %s, got %v", ecosystem, status, statuses)
+		}
+	}
+	if len(result.Issues) != 1 || result.Issues[0].Type != "missing_lockfile" || result.Issues[0].Location.File != "mix.exs" {
+		t.Errorf("Expected a missing lockfile issue for mix.exs, got %+v", result.Issues)
+	}
+
+	config.options["manifests"] = []interface{}{map[string]interface{}{"file": "deno.json"}}
+	result, _ = checker.Check(context.Background(), core.RepositoryContext{
+		Repository: core.Repository{Name: "app", Path: repoPath},
+		Config:     config,
+	})
+	if result.Status != core.StatusErrored || len(result.Errors) != 1 || !strings.Contains(result.Errors[0].Message, "needs both file and ecosystem") {
+		t.Errorf("Expected an error for a manifest without an ecosystem, got %s %+v", result.Status, result.Errors)
+	}
+}
+
+func TestOutdatedChecker_EcosystemFilter(t *testing.T) {
+	repoPath := t.TempDir()
+	for name, content := range map[string]string{
+		"go.mod":       "module example.com/app\n",
+		"go.sum":       "\n",
+		"package.json": `{"name":"app"}`,
+	} {
+		if err := os.WriteFile(filepath.Join(repoPath, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	executor := commands.NewMockCommandExecutor()
+	executor.SetResponse("go list -u -m all", commands.CommandResult{Stdout: "example.com/app\n"})
+	executor.SetResponse("npm outdated --json", commands.CommandResult{
+		ExitCode: 1,
+		Stdout:   `{"left-pad":{"current":"1.0.0","wanted":"1.3.0","latest":"1.3.0"}}`,
+	})
+
+	config := manifestTestConfig{options: map[string]interface{}{"ecosystems": []interface{}{"Go"}}}
+	checker := NewOutdatedChecker(executor)
+	result, err := checker.Check(context.Background(), core.RepositoryContext{
+		Repository: core.Repository{Name: "app", Path: repoPath},
+		Config:     config,
+	})
+	if err != nil {
+		t.Fatalf("Check failed: %v", err)
+	}
+
+	if result.Status != core.StatusHealthy || len(result.Issues) != 0 {
+		t.Errorf("Expected the outdated npm package to be skipped, got %s with %+v", result.Status, result.Issues)
+	}
+	if names, _ := result.Metrics["ecosystems"].([]string); len(names) != 1 || names[0] != "go" {
+		t.Errorf("Expected only go to be checked, got %v", result.Metrics["ecosystems"])
+	}
+	if skipped, _ := result.Metrics["ecosystems_skipped"].([]string); len(skipped) != 1 || skipped[0] != "node" {
+		t.Errorf("Expected node to be reported as skipped, got %v", result.Metrics["ecosystems_skipped"])
+	}
+
+	config.options["ecosystems"] = []interface{}{"rust"}
+	result, _ = checker.Check(context.Background(), core.RepositoryContext{
+		Repository: core.Repository{Name: "app", Path: repoPath},
+		Config:     config,
+	})
+	if result.Status != core.StatusHealthy || result.Metrics["status"] != "no_selected_ecosystems" {
+		t.Errorf("Expected a healthy result with no selected ecosystem present, got %s %v", result.Status, result.Metrics["status"])
+	}
+	if skipped, _ := result.Metrics["ecosystems_skipped"].([]string); len(skipped) != 2 {
+		t.Errorf("Expected go and node to be skipped, got %v", result.Metrics["ecosystems_skipped"])
+	}
+}
+
+func TestOutdatedChecker_LockfilePolicy(t *testing.T) {
+	repoPath := t.TempDir()
+	for name, content := range map[string]string{
+		"go.mod":         "module example.com/app\n\nrequire github.com/pkg/errors v0.9.1\n",
+		"package.json":   `{"name":"app","dependencies":{"left-pad":"^1.3.0"}}`,
+		"yarn.lock":      "\n",
+		"pyproject.toml": "[tool.black]\nline-length = 100\n",
+		"Gemfile":        "gem 'rails'\n",
+	} {
+		if err := os.WriteFile(filepath.Join(repoPath, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	executor := commands.NewMockCommandExecutor()
+	executor.SetResponse("pip list --outdated", commands.CommandResult{})
+	result, err := NewOutdatedChecker(executor).Check(context.Background(), core.RepositoryContext{
+		Repository: core.Repository{Name: "app", Path: repoPath},
+		Config:     manifestTestConfig{options: map[string]interface{}{"check_deprecated": false}},
+	})
+	if err != nil {
+		t.Fatalf("Check failed: %v", err)
+	}
+
+	// go.mod and Gemfile lack their lockfiles; yarn.lock locks package.json and
+	// a pyproject.toml without dependencies has nothing to lock
+	var missing []string
+	for _, issue := range result.Issues {
+		if issue.Type == "missing_lockfile" {
+			missing = append(missing, issue.Message)
+		}
+	}
+	expected := []string{"go.mod has no lockfile (go.sum)", "Gemfile has no lockfile (Gemfile.lock)"}
+	if strings.Join(missing, "; ") != strings.Join(expected, "; ") {
+		t.Errorf("Expected missing lockfiles %v, got %v", expected, missing)
+	}
+
+	statuses, _ := result.Metrics["ecosystem_statuses"].(map[string]string)
+	if statuses["go"] != "warning" || statuses["node"] != "healthy" || statuses["python"] != "healthy" || statuses["ruby"] != "warning" {
+		t.Errorf("Unexpected ecosystem statuses: %v", statuses)
+	}
+	if result.Metrics["go_missing_lockfiles"] != 1 || result.Metrics["node_missing_lockfiles"] != 0 {
+		t.Errorf("Unexpected missing lockfile metrics: %v", result.Metrics)
+	}
+	if result.Score != 80 {
+		t.Errorf("Expected score 80, got %d", result.Score)
+	}
+}
+
+func TestOutdatedChecker_AgePolicy(t *testing.T) {
+	repoPath := t.TempDir()
+	for name, content := range map[string]string{
+		"go.mod":       "module example.com/app\n",
+		"package.json": `{"name":"app"}`,
+	} {
+		if err := os.WriteFile(filepath.Join(repoPath, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	executor := commands.NewMockCommandExecutor()
+	executor.SetResponse("go list -u -m all", commands.CommandResult{
+		Stdout: "example.com/app\ngithub.com/old/lib v1.0.0 [v1.9.0]\ngithub.com/new/lib v1.4.0 [v1.5.0]\n",
+	})
+	executor.SetResponse("go list -m -u -json all", commands.CommandResult{Stdout: `{"Path": "example.com/app", "Main": true}
+{"Path": "github.com/old/lib", "Version": "v1.0.0", "Time": "2023-01-10T00:00:00Z",
+ "Update": {"Path": "github.com/old/lib", "Version": "v1.9.0", "Time": "2024-03-10T00:00:00Z"}}
+{"Path": "github.com/new/lib", "Version": "v1.4.0", "Time": "2024-01-10T00:00:00Z",
+ "Update": {"Path": "github.com/new/lib", "Version": "v1.5.0", "Time": "2024-03-10T00:00:00Z"}}
+{"Path": "github.com/current/lib", "Version": "v2.0.0", "Time": "2024-01-10T00:00:00Z"}
+`})
+	executor.SetResponse("npm outdated --json", commands.CommandResult{
+		ExitCode: 1,
+		Stdout: `{"left-pad":{"current":"1.0.0","wanted":"1.3.0","latest":"4.0.0"},
+			"lodash":{"current":"4.17.0","wanted":"4.17.21","latest":"5.0.0"},
+			"react":{"current":"15.0.0","wanted":"15.7.0","latest":"18.2.0"},
+			"missing":{"wanted":"1.0.0","latest":"9.0.0"}}`,
+	})
+
+	checker := NewOutdatedChecker(executor)
+	config := manifestTestConfig{options: map[string]interface{}{"max_major_behind": 1, "max_age_months": 12}}
+	result, err := checker.Check(context.Background(), core.RepositoryContext{
+		Repository: core.Repository{Name: "app", Path: repoPath},
+		Config:     config,
+	})
+	if err != nil {
+		t.Fatalf("Check failed: %v", err)
+	}
+
+	if result.Status != core.StatusCritical || result.Score != 40 {
+		t.Errorf("Expected critical with score 40, got %s with %d", result.Status, result.Score)
+	}
+
+	var violations []string
+	for _, issue := range result.Issues {
+		if issue.Type != "dependency_policy_violation" {
+			continue
+		}
+		if issue.Severity != core.SeverityHigh {
+			t.Errorf("Expected high severity, got %s", issue.Severity)
+		}
+		violations = append(violations, issue.Context["dependency"].(string))
+	}
+	// Worst offenders first, within each ecosystem
+	expected := []string{"github.com/old/lib", "left-pad", "react"}
+	if strings.Join(violations, ",") != strings.Join(expected, ",") {
+		t.Errorf("Expected violations %v, got %v", expected, violations)
+	}
+	if result.Metrics["go_policy_violations"] != 1 || result.Metrics["node_policy_violations"] != 2 {
+		t.Errorf("Unexpected violation metrics: %v", result.Metrics)
+	}
+	if got := result.Issues[1].Message; got != "github.com/old/lib v1.0.0 is 14 months behind v1.9.0" {
+		t.Errorf("Unexpected message %q", got)
+	}
+}
+
+func TestOutdatedChecker_DeprecatedDependencies(t *testing.T) {
+	repoPath := t.TempDir()
+	for name, content := range map[string]string{
+		"go.mod":            "module example.com/app\n",
+		"package.json":      `{"name":"app","dependencies":{"request":"^2.88.0","lodash":"^4.17.0"},"devDependencies":{"unlocked":"1.0.0"}}`,
+		"package-lock.json": `{"lockfileVersion":3,"packages":{"":{},"node_modules/request":{"version":"2.88.2"},"node_modules/lodash":{"version":"4.17.21"}}}`,
+	} {
+		if err := os.WriteFile(filepath.Join(repoPath, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	executor := commands.NewMockCommandExecutor()
+	executor.SetResponse("go list -u -m all", commands.CommandResult{Stdout: "example.com/app\n"})
+	executor.SetResponse("go list -m -u -json all", commands.CommandResult{Stdout: `{"Path": "example.com/app", "Main": true}
+{"Path": "github.com/golang/protobuf", "Version": "v1.5.3", "Deprecated": "Use the \"google.golang.org/protobuf\" module instead."}
+{"Path": "example.com/broken", "Version": "v1.2.0", "Retracted": ["Published accidentally."]}
+{"Path": "github.com/fine/lib", "Version": "v1.0.0"}
+`})
+	executor.SetResponse("npm outdated --json", commands.CommandResult{Stdout: "{}"})
+	executor.SetResponse("npm view request@2.88.2 deprecated", commands.CommandResult{
+		Stdout: "request has been deprecated, see https://github.com/request/request/issues/3142\n",
+	})
+	executor.SetResponse("npm view lodash@4.17.21 deprecated", commands.CommandResult{Stdout: "\n"})
+
+	checker := NewOutdatedChecker(executor)
+	result, err := checker.Check(context.Background(), core.RepositoryContext{
+		Repository: core.Repository{Name: "app", Path: repoPath},
+	})
+	if err != nil {
+		t.Fatalf("Check failed: %v", err)
+	}
+
+	// Up-to-date but deprecated dependencies are a warning, not a policy violation
+	if result.Status != core.StatusWarning || result.Score != 80 {
+		t.Errorf("Expected warning with score 80, got %s with %d", result.Status, result.Score)
+	}
+	var found []string
+	for _, issue := range result.Issues {
+		if issue.Severity != core.SeverityMedium {
+			t.Errorf("Expected medium severity, got %s for %s", issue.Severity, issue.Message)
+		}
+		found = append(found, issue.Type+" "+issue.Message)
+	}
+	expected := []string{
+		`deprecated_dependency github.com/golang/protobuf v1.5.3 is deprecated: Use the "google.golang.org/protobuf" module instead.`,
+		"retracted_dependency example.com/broken v1.2.0 is retracted: Published accidentally.",
+		"deprecated_dependency request 2.88.2 is deprecated: request has been deprecated, see https://github.com/request/request/issues/3142",
+	}
+	if strings.Join(found, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("Expected issues\n%s\ngot\n%s", strings.Join(expected, "\n"), strings.Join(found, "\n"))
+	}
+	if result.Metrics["go_deprecated_dependencies"] != 2 || result.Metrics["node_deprecated_dependencies"] != 1 {
+		t.Errorf("Unexpected deprecation metrics: %v", result.Metrics)
+	}
+
+	// The step can be turned off
+	result, _ = checker.Check(context.Background(), core.RepositoryContext{
+		Repository: core.Repository{Name: "app", Path: repoPath},
+		Config:     manifestTestConfig{options: map[string]interface{}{"check_deprecated": false}},
+	})
+	if result.Status != core.StatusHealthy || len(result.Issues) != 0 {
+		t.Errorf("Expected no deprecation issues when disabled, got %s %+v", result.Status, result.Issues)
+	}
+}
@@ -0,0 +1,122 @@
+package dependencies
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/codcod/repos/internal/core"
+	"github.com/codcod/repos/internal/health/checkers/base"
+)
+
+// pipAuditDependency mirrors one dependency in the JSON output of 'pip-audit --format json'
+type pipAuditDependency struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+	Vulns   []struct {
+		ID          string   `json:"id"`
+		FixVersions []string `json:"fix_versions"`
+		Aliases     []string `json:"aliases"`
+	} `json:"vulns"`
+}
+
+// pipAuditAdvisory is one advisory affecting an installed package version
+type pipAuditAdvisory struct {
+	Package    string
+	Version    string
+	ID         string
+	FixVersion string // empty when no fixed version is known
+}
+
+// auditPythonDependencies runs pip-audit when it is installed and reports each
+// advisory as a high severity issue. It returns the number of advisories found.
+func (c *OutdatedChecker) auditPythonDependencies(ctx context.Context, repoPath string, builder *base.ResultBuilder) int {
+	if result := c.executor.Execute(ctx, "which", "pip-audit"); result.Error != nil {
+		builder.AddWarning(core.Warning{
+			Type:    "pip_audit_not_available",
+			Message: "pip-audit not installed; Python dependencies were not checked for vulnerabilities",
+		})
+		return 0
+	}
+
+	// Audit requirements.txt when present, otherwise the project in the repository root
+	args := []string{"--format", "json", "--progress-spinner", "off"}
+	auditedFile := "pyproject.toml"
+	if _, err := os.Stat(filepath.Join(repoPath, "requirements.txt")); err == nil {
+		auditedFile = "requirements.txt"
+		args = append(args, "-r", auditedFile)
+	} else {
+		args = append(args, ".")
+	}
+
+	// pip-audit exits non-zero when vulnerabilities are found, so parse the output regardless
+	result := c.executor.ExecuteInDir(ctx, repoPath, "pip-audit", args...)
+	advisories, err := parsePipAudit(result.Stdout)
+	if err != nil {
+		builder.AddWarning(core.Warning{
+			Type:    "pip_audit_error",
+			Message: fmt.Sprintf("Unable to run pip-audit: %v", err),
+		})
+		return 0
+	}
+
+	builder.AddMetric("vulnerability_advisories", len(advisories))
+	for _, advisory := range advisories {
+		message := fmt.Sprintf("%s %s is affected by %s", advisory.Package, advisory.Version, advisory.ID)
+		suggestion := fmt.Sprintf("No fixed version of %s is known yet; consider replacing it", advisory.Package)
+		if advisory.FixVersion != "" {
+			message += fmt.Sprintf(" (fixed in %s)", advisory.FixVersion)
+			suggestion = fmt.Sprintf("Upgrade %s to %s or later", advisory.Package, advisory.FixVersion)
+		}
+
+		issue := base.NewIssueWithSuggestion("python_vulnerability", core.SeverityHigh, message, suggestion)
+		issue.Location = &core.Location{File: auditedFile}
+		issue.Context["package"] = advisory.Package
+		issue.Context["version"] = advisory.Version
+		issue.Context["advisory"] = advisory.ID
+		if advisory.FixVersion != "" {
+			issue.Context["fix_version"] = advisory.FixVersion
+		}
+		builder.AddIssue(issue)
+	}
+	return len(advisories)
+}
+
+// parsePipAudit extracts advisories from pip-audit's JSON output, which is an
+// object with a dependencies list in current versions and a bare list in older ones
+func parsePipAudit(output string) ([]pipAuditAdvisory, error) {
+	output = strings.TrimSpace(output)
+	if output == "" {
+		return nil, fmt.Errorf("no output")
+	}
+
+	var dependencies []pipAuditDependency
+	if strings.HasPrefix(output, "[") {
+		if err := json.Unmarshal([]byte(output), &dependencies); err != nil {
+			return nil, fmt.Errorf("unable to parse pip-audit output: %w", err)
+		}
+	} else {
+		var report struct {
+			Dependencies []pipAuditDependency `json:"dependencies"`
+		}
+		if err := json.Unmarshal([]byte(output), &report); err != nil {
+			return nil, fmt.Errorf("unable to parse pip-audit output: %w", err)
+		}
+		dependencies = report.Dependencies
+	}
+
+	var advisories []pipAuditAdvisory
+	for _, dep := range dependencies {
+		for _, vuln := range dep.Vulns {
+			advisory := pipAuditAdvisory{Package: dep.Name, Version: dep.Version, ID: vuln.ID}
+			if len(vuln.FixVersions) > 0 {
+				advisory.FixVersion = vuln.FixVersions[0]
+			}
+			advisories = append(advisories, advisory)
+		}
+	}
+	return advisories, nil
+}
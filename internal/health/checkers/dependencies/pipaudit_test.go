@@ -0,0 +1,105 @@
+package dependencies
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/codcod/repos/internal/core"
+	"github.com/codcod/repos/internal/platform/commands"
+)
+
+func TestParsePipAudit(t *testing.T) {
+	current := `{"dependencies":[{"name":"flask","version":"0.5","vulns":[{"id":"PYSEC-2019-179","fix_versions":["1.0","1.0.1"]}]},` +
+		`{"name":"requests","version":"2.31.0","vulns":[]}],"fixes":[]}`
+	legacy := `[{"name":"jinja2","version":"2.4","vulns":[{"id":"PYSEC-2014-8","fix_versions":[]}]}]`
+
+	advisories, err := parsePipAudit(current)
+	if err != nil {
+		t.Fatalf("parsePipAudit failed: %v", err)
+	}
+	if len(advisories) != 1 || advisories[0] != (pipAuditAdvisory{Package: "flask", Version: "0.5", ID: "PYSEC-2019-179", FixVersion: "1.0"}) {
+		t.Errorf("Unexpected advisories: %+v", advisories)
+	}
+
+	advisories, err = parsePipAudit(legacy)
+	if err != nil {
+		t.Fatalf("parsePipAudit failed on the legacy format: %v", err)
+	}
+	if len(advisories) != 1 || advisories[0].Package != "jinja2" || advisories[0].FixVersion != "" {
+		t.Errorf("Unexpected legacy advisories: %+v", advisories)
+	}
+
+	if _, err := parsePipAudit("pip-audit: command failed"); err == nil {
+		t.Error("Expected an error for output that is not JSON")
+	}
+}
+
+func TestOutdatedChecker_AuditsPythonDependencies(t *testing.T) {
+	repoPath := t.TempDir()
+	if err := os.WriteFile(filepath.Join(repoPath, "requirements.txt"), []byte("flask==0.5\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	executor := commands.NewMockCommandExecutor()
+	executor.SetResponse("pip list --outdated", commands.CommandResult{})
+	executor.SetResponse("pip-audit --format json --progress-spinner off -r requirements.txt", commands.CommandResult{
+		ExitCode: 1,
+		Stdout:   `{"dependencies":[{"name":"flask","version":"0.5","vulns":[{"id":"PYSEC-2019-179","fix_versions":["1.0"]}]}]}`,
+		Error:    errors.New("exit status 1"),
+	})
+
+	checker := NewOutdatedChecker(executor)
+	result, err := checker.Check(context.Background(), core.RepositoryContext{
+		Repository: core.Repository{Name: "app", Path: repoPath},
+	})
+	if err != nil {
+		t.Fatalf("Check failed: %v", err)
+	}
+
+	if result.Status != core.StatusCritical {
+		t.Errorf("Expected critical status, got %s", result.Status)
+	}
+	if result.Metrics["vulnerability_advisories"] != 1 {
+		t.Errorf("Expected 1 advisory in metrics, got %v", result.Metrics["vulnerability_advisories"])
+	}
+	if len(result.Issues) != 1 {
+		t.Fatalf("Expected one vulnerability issue, got %+v", result.Issues)
+	}
+	issue := result.Issues[0]
+	if issue.Severity != core.SeverityHigh || issue.Context["package"] != "flask" || issue.Context["fix_version"] != "1.0" {
+		t.Errorf("Unexpected vulnerability issue: %+v", issue)
+	}
+}
+
+func TestOutdatedChecker_SkipsPipAuditWhenMissing(t *testing.T) {
+	repoPath := t.TempDir()
+	if err := os.WriteFile(filepath.Join(repoPath, "requirements.txt"), []byte("flask==3.0\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	executor := commands.NewMockCommandExecutor()
+	executor.SetResponse("which pip-audit", commands.CommandResult{ExitCode: 1, Error: errors.New("not found")})
+	executor.SetResponse("pip list --outdated", commands.CommandResult{})
+
+	checker := NewOutdatedChecker(executor)
+	result, err := checker.Check(context.Background(), core.RepositoryContext{
+		Repository: core.Repository{Name: "app", Path: repoPath},
+	})
+	if err != nil {
+		t.Fatalf("Check failed: %v", err)
+	}
+
+	if result.Status != core.StatusHealthy {
+		t.Errorf("Expected healthy status, got %s", result.Status)
+	}
+	found := false
+	for _, warning := range result.Warnings {
+		found = found || warning.Type == "pip_audit_not_available"
+	}
+	if !found {
+		t.Errorf("Expected a pip_audit_not_available warning, got %+v", result.Warnings)
+	}
+}
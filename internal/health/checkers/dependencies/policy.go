@@ -0,0 +1,269 @@
+package dependencies
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/codcod/repos/internal/core"
+	"github.com/codcod/repos/internal/health/checkers/base"
+)
+
+// maxPolicyIssues is the number of worst-offending dependencies reported as issues
+const maxPolicyIssues = 5
+
+// agePolicy limits how far dependencies may fall behind their latest release.
+// A zero limit disables that part of the policy.
+type agePolicy struct {
+	maxMajorBehind int
+	maxAgeMonths   int
+}
+
+// enabled reports whether any limit is configured
+func (p agePolicy) enabled() bool {
+	return p.maxMajorBehind > 0 || p.maxAgeMonths > 0
+}
+
+// staleDependency is a dependency with a newer release available
+type staleDependency struct {
+	Name         string
+	Current      string
+	Latest       string
+	MajorsBehind int
+	// Behind is the time between the current and the latest release, zero when
+	// the ecosystem does not report release dates
+	Behind time.Duration
+}
+
+// violates reports whether the dependency breaches the policy
+func (p agePolicy) violates(dep staleDependency) bool {
+	if p.maxMajorBehind > 0 && dep.MajorsBehind > p.maxMajorBehind {
+		return true
+	}
+	return p.maxAgeMonths > 0 && monthsBehind(dep.Behind) > p.maxAgeMonths
+}
+
+// policy reads the max_major_behind and max_age_months options
+func (c *OutdatedChecker) policy(repoCtx core.RepositoryContext) agePolicy {
+	return agePolicy{
+		maxMajorBehind: c.IntOption(repoCtx, "max_major_behind", 0),
+		maxAgeMonths:   c.IntOption(repoCtx, "max_age_months", 0),
+	}
+}
+
+// applyAgePolicy adds an issue for each of the worst dependencies breaching the
+// policy. Any breach makes the ecosystem critical.
+func (c *OutdatedChecker) applyAgePolicy(result core.CheckResult, policy agePolicy, stale []staleDependency) core.CheckResult {
+	var violations []staleDependency
+	for _, dep := range stale {
+		if policy.violates(dep) {
+			violations = append(violations, dep)
+		}
+	}
+	if result.Metrics == nil {
+		result.Metrics = make(map[string]interface{})
+	}
+	result.Metrics["policy_violations"] = len(violations)
+	if len(violations) == 0 {
+		return result
+	}
+
+	// Worst offenders first: most major versions behind, then longest behind
+	sort.SliceStable(violations, func(i, j int) bool {
+		if violations[i].MajorsBehind != violations[j].MajorsBehind {
+			return violations[i].MajorsBehind > violations[j].MajorsBehind
+		}
+		return violations[i].Behind > violations[j].Behind
+	})
+
+	for i, dep := range violations {
+		if i >= maxPolicyIssues {
+			result.Metrics["additional_policy_violations"] = len(violations) - maxPolicyIssues
+			break
+		}
+		result.Issues = append(result.Issues, policyIssue(dep))
+	}
+
+	result.Status = core.StatusCritical
+	if result.MaxScore == 0 {
+		result.MaxScore = 100
+	}
+	if limit := result.MaxScore * 40 / 100; result.Score > limit {
+		result.Score = limit
+	}
+	return result
+}
+
+// policyIssue describes a dependency breaching the age policy
+func policyIssue(dep staleDependency) core.Issue {
+	issue := base.NewIssueWithSuggestion(
+		"dependency_policy_violation",
+		core.SeverityHigh,
+		fmt.Sprintf("%s %s is %s behind %s", dep.Name, dep.Current, describeLag(dep), dep.Latest),
+		fmt.Sprintf("Upgrade %s to %s", dep.Name, dep.Latest),
+	)
+	issue.Context["dependency"] = dep.Name
+	issue.Context["current"] = dep.Current
+	issue.Context["latest"] = dep.Latest
+	issue.Context["majors_behind"] = dep.MajorsBehind
+	if dep.Behind > 0 {
+		issue.Context["months_behind"] = monthsBehind(dep.Behind)
+	}
+	return issue
+}
+
+// describeLag phrases how far a dependency is behind, e.g. "2 major versions"
+// or "14 months"
+func describeLag(dep staleDependency) string {
+	var parts []string
+	if dep.MajorsBehind == 1 {
+		parts = append(parts, "1 major version")
+	} else if dep.MajorsBehind > 1 {
+		parts = append(parts, fmt.Sprintf("%d major versions", dep.MajorsBehind))
+	}
+	if months := monthsBehind(dep.Behind); months == 1 {
+		parts = append(parts, "1 month")
+	} else if months > 1 {
+		parts = append(parts, fmt.Sprintf("%d months", months))
+	}
+	if len(parts) == 0 {
+		return "a release"
+	}
+	return strings.Join(parts, " and ")
+}
+
+// monthsBehind converts a duration to whole 30-day months
+func monthsBehind(d time.Duration) int {
+	return int(d / (30 * 24 * time.Hour))
+}
+
+// majorVersion returns the major component of a version such as v1.2.3, ^2.0.0
+// or 3, and false when it is not numeric
+func majorVersion(version string) (int, bool) {
+	version = strings.TrimLeft(version, "v^~=<> ")
+	if i := strings.IndexAny(version, ".-+"); i >= 0 {
+		version = version[:i]
+	}
+	major, err := strconv.Atoi(version)
+	return major, err == nil
+}
+
+// majorsBehind is the number of major versions between current and latest
+func majorsBehind(current, latest string) int {
+	from, ok1 := majorVersion(current)
+	to, ok2 := majorVersion(latest)
+	if !ok1 || !ok2 || to <= from {
+		return 0
+	}
+	return to - from
+}
+
+// goModule mirrors the fields of 'go list -m -u -json' used by the policy and
+// the deprecation check
+type goModule struct {
+	Path    string     `json:"Path"`
+	Version string     `json:"Version"`
+	Time    *time.Time `json:"Time"`
+	Main    bool       `json:"Main"`
+	Update  *struct {
+		Version string     `json:"Version"`
+		Time    *time.Time `json:"Time"`
+	} `json:"Update"`
+	Deprecated string   `json:"Deprecated"`
+	Retracted  []string `json:"Retracted"`
+}
+
+// staleGoModules lists Go modules with updates, with release dates from the
+// module proxy
+func (c *OutdatedChecker) staleGoModules(ctx context.Context, repoPath string) ([]staleDependency, error) {
+	result := c.executor.ExecuteInDir(ctx, repoPath, "go", "list", "-m", "-u", "-json", "all")
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return parseGoModules(result.Stdout)
+}
+
+// parseGoModules parses the concatenated JSON objects printed by go list -m -json
+func parseGoModules(output string) ([]staleDependency, error) {
+	var stale []staleDependency
+	decoder := json.NewDecoder(strings.NewReader(output))
+	for {
+		var module goModule
+		if err := decoder.Decode(&module); errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return nil, fmt.Errorf("parsing go list output: %w", err)
+		}
+		if module.Main || module.Update == nil {
+			continue
+		}
+		dep := staleDependency{
+			Name:         module.Path,
+			Current:      module.Version,
+			Latest:       module.Update.Version,
+			MajorsBehind: majorsBehind(module.Version, module.Update.Version),
+		}
+		if module.Time != nil && module.Update.Time != nil {
+			dep.Behind = module.Update.Time.Sub(*module.Time)
+		}
+		stale = append(stale, dep)
+	}
+	return stale, nil
+}
+
+// npmOutdatedEntry mirrors a package of 'npm outdated --json'
+type npmOutdatedEntry struct {
+	Current string `json:"current"`
+	Wanted  string `json:"wanted"`
+	Latest  string `json:"latest"`
+}
+
+// staleNpmPackages lists npm packages with updates. npm does not report release
+// dates, so only the major version policy applies.
+func (c *OutdatedChecker) staleNpmPackages(ctx context.Context, repoPath string) ([]staleDependency, error) {
+	// npm outdated exits with 1 when packages are outdated
+	result := c.executor.ExecuteInDir(ctx, repoPath, "npm", "outdated", "--json")
+	if result.ExitCode < 0 || result.ExitCode > 1 || (result.Error != nil && result.ExitCode == 0) {
+		return nil, fmt.Errorf("npm outdated failed: %v", result.Error)
+	}
+	return parseNpmOutdated(result.Stdout)
+}
+
+// parseNpmOutdated parses the output of npm outdated --json
+func parseNpmOutdated(output string) ([]staleDependency, error) {
+	output = strings.TrimSpace(output)
+	if output == "" {
+		return nil, nil
+	}
+	var entries map[string]npmOutdatedEntry
+	if err := json.Unmarshal([]byte(output), &entries); err != nil {
+		return nil, fmt.Errorf("parsing npm outdated output: %w", err)
+	}
+
+	names := make([]string, 0, len(entries))
+	for name := range entries {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var stale []staleDependency
+	for _, name := range names {
+		entry := entries[name]
+		if entry.Current == "" {
+			continue // Not installed
+		}
+		stale = append(stale, staleDependency{
+			Name:         name,
+			Current:      entry.Current,
+			Latest:       entry.Latest,
+			MajorsBehind: majorsBehind(entry.Current, entry.Latest),
+		})
+	}
+	return stale, nil
+}
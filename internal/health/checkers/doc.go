@@ -9,9 +9,11 @@ of repository health and quality standards.
   - base: Fundamental repository structure validation
   - ci: Continuous integration configuration checks
   - compliance: License and legal compliance validation
+  - custom: Checkers that run commands defined under extensions.custom_checkers
   - dependencies: Dependency management and security checks
   - docs: Documentation quality and completeness assessment
   - git: Git repository health and hygiene validation
+  - quality: Code quality signals such as technical debt markers and long functions
   - security: Security-focused validation and vulnerability detection
 
 # Architecture
@@ -78,6 +80,16 @@ Example checker implementation:
 		// Checker implementation
 	}
 
+Checks that don't need to live in this repository can instead be defined in
+the configuration as a command whose exit code and JSON output become the
+result; see custom.Output for the schema:
+
+	extensions:
+	  custom_checkers:
+	    - id: no-secrets
+	      category: security
+	      command: ./scripts/check-secrets.sh
+
 # Best Practices
 
 When implementing checkers:
@@ -0,0 +1,464 @@
+package docs
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"github.com/codcod/repos/internal/core"
+	"github.com/codcod/repos/internal/health/checkers/base"
+)
+
+const (
+	// DefaultExternalLinkTimeout bounds each external link request
+	DefaultExternalLinkTimeout = 10 * time.Second
+	// DefaultExternalLinkConcurrency caps concurrent external link requests
+	DefaultExternalLinkConcurrency = 4
+)
+
+var (
+	// inlineLinkPattern matches [text](target "title") and ![alt](target)
+	inlineLinkPattern = regexp.MustCompile(`!?\[[^\]]*\]\(\s*<?([^)\s>]+)>?(?:\s+["'(][^)]*)?\)`)
+	// referenceLinkPattern matches reference definitions such as [id]: target
+	referenceLinkPattern = regexp.MustCompile(`^\s{0,3}\[[^\]]+\]:\s*<?([^\s>]+)>?`)
+	// htmlAnchorPattern matches explicit HTML anchors such as <a name="x"> or id="x"
+	htmlAnchorPattern = regexp.MustCompile(`(?i)\b(?:name|id)\s*=\s*["']([^"']+)["']`)
+	inlineCodePattern = regexp.MustCompile("`[^`]*`")
+)
+
+// MarkdownLink is a link target found in a Markdown document
+type MarkdownLink struct {
+	Target string
+	Line   int
+}
+
+// LinkCheckResult summarizes the links checked in a README
+type LinkCheckResult struct {
+	Checked         int
+	ExternalChecked int
+	// ExternalSkipped counts external links excluded by the domain lists
+	ExternalSkipped int
+	Broken          []BrokenLink
+}
+
+// BrokenLink is a link whose target could not be resolved
+type BrokenLink struct {
+	MarkdownLink
+	Reason string
+}
+
+// ExtractMarkdownLinks returns the inline and reference link targets in content,
+// ignoring links inside fenced code blocks and inline code spans
+func ExtractMarkdownLinks(content string) []MarkdownLink {
+	var links []MarkdownLink
+	inFence := false
+
+	for i, line := range strings.Split(content, "\n") {
+		if strings.HasPrefix(strings.TrimSpace(line), CodeBlockMarker) {
+			inFence = !inFence
+			continue
+		}
+		if inFence {
+			continue
+		}
+
+		line = inlineCodePattern.ReplaceAllString(line, "")
+		for _, match := range inlineLinkPattern.FindAllStringSubmatch(line, -1) {
+			links = append(links, MarkdownLink{Target: match[1], Line: i + 1})
+		}
+		if match := referenceLinkPattern.FindStringSubmatch(line); match != nil {
+			links = append(links, MarkdownLink{Target: match[1], Line: i + 1})
+		}
+	}
+
+	return links
+}
+
+// markdownAnchors returns the anchors a Markdown renderer generates for the
+// document's headings, plus any explicit HTML anchors
+func markdownAnchors(content string) map[string]bool {
+	anchors := make(map[string]bool)
+	counts := make(map[string]int)
+	inFence := false
+
+	for _, line := range strings.Split(content, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, CodeBlockMarker) {
+			inFence = !inFence
+			continue
+		}
+		if inFence {
+			continue
+		}
+
+		for _, match := range htmlAnchorPattern.FindAllStringSubmatch(line, -1) {
+			anchors[match[1]] = true
+		}
+
+		if !strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+		heading := strings.TrimSpace(strings.TrimLeft(trimmed, "#"))
+		slug := headingSlug(heading)
+
+		// Repeated headings get -1, -2, ... suffixes like on GitHub
+		if n := counts[slug]; n > 0 {
+			anchors[fmt.Sprintf("%s-%d", slug, n)] = true
+		} else {
+			anchors[slug] = true
+		}
+		counts[slug]++
+	}
+
+	return anchors
+}
+
+// headingSlug converts a heading into a GitHub-style anchor
+func headingSlug(heading string) string {
+	heading = inlineLinkPattern.ReplaceAllStringFunc(heading, func(link string) string {
+		return link[strings.Index(link, "[")+1 : strings.Index(link, "]")]
+	})
+
+	var slug strings.Builder
+	for _, r := range strings.ToLower(heading) {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r) || r == '-' || r == '_':
+			slug.WriteRune(r)
+		case r == ' ':
+			slug.WriteRune('-')
+		}
+	}
+	return slug.String()
+}
+
+// checkReadmeLinks verifies the README's relative links and, if enabled, its external links
+func (c *ReadmeChecker) checkReadmeLinks(ctx context.Context, repoCtx core.RepositoryContext, readmeFile string, content string) LinkCheckResult {
+	var result LinkCheckResult
+	repoPath := repoCtx.Repository.Path
+	readmeDir := filepath.Dir(filepath.Join(repoPath, readmeFile))
+	anchorCache := map[string]map[string]bool{filepath.Join(repoPath, readmeFile): markdownAnchors(content)}
+
+	var external []MarkdownLink
+	for _, link := range ExtractMarkdownLinks(content) {
+		target := link.Target
+		switch {
+		case strings.HasPrefix(target, "http://") || strings.HasPrefix(target, "https://"):
+			external = append(external, link)
+			continue
+		case isOtherScheme(target):
+			continue // mailto:, ftp: and similar are not checked
+		}
+
+		result.Checked++
+		if reason := checkRelativeLink(repoPath, readmeDir, filepath.Join(repoPath, readmeFile), target, anchorCache); reason != "" {
+			result.Broken = append(result.Broken, BrokenLink{MarkdownLink: link, Reason: reason})
+		}
+	}
+
+	if c.BoolOption(repoCtx, "check_external_links", c.config.CheckExternalLinks) && len(external) > 0 {
+		c.checkReadmeExternalLinks(ctx, repoCtx, external, &result)
+	}
+
+	return result
+}
+
+// checkReadmeExternalLinks requests the external links whose domain is allowed
+// and adds the outcome to result
+func (c *ReadmeChecker) checkReadmeExternalLinks(ctx context.Context, repoCtx core.RepositoryContext, external []MarkdownLink, result *LinkCheckResult) {
+	options := externalLinkOptions{
+		Timeout:         time.Duration(c.IntOption(repoCtx, "external_link_timeout", int(c.config.ExternalLinkTimeout/time.Second))) * time.Second,
+		Concurrency:     c.IntOption(repoCtx, "external_link_concurrency", c.config.ExternalLinkConcurrency),
+		FollowRedirects: c.BoolOption(repoCtx, "follow_redirects", c.config.FollowRedirects),
+		Cache:           runLinkCache(ctx),
+	}
+	allowed := c.StringSliceOption(repoCtx, "external_link_allowed_domains")
+	if len(allowed) == 0 {
+		allowed = c.config.AllowedLinkDomains
+	}
+	denied := c.StringSliceOption(repoCtx, "external_link_denied_domains")
+	if len(denied) == 0 {
+		denied = c.config.DeniedLinkDomains
+	}
+
+	var requested []MarkdownLink
+	for _, link := range external {
+		if linkDomainAllowed(link.Target, allowed, denied) {
+			requested = append(requested, link)
+		} else {
+			result.ExternalSkipped++
+		}
+	}
+	broken := checkExternalLinks(ctx, requested, options)
+	result.Checked += len(requested)
+	result.ExternalChecked = len(requested)
+	result.Broken = append(result.Broken, broken...)
+}
+
+// isOtherScheme reports whether target uses a URL scheme other than http(s)
+func isOtherScheme(target string) bool {
+	colon := strings.Index(target, ":")
+	if colon <= 0 {
+		return false
+	}
+	slash := strings.IndexAny(target, "/#?")
+	return slash == -1 || colon < slash
+}
+
+// checkRelativeLink returns why a relative link is broken, or "" if it resolves.
+// Paths starting with / are resolved from the repository root.
+func checkRelativeLink(repoPath, baseDir, currentFile, target string, anchorCache map[string]map[string]bool) string {
+	pathPart, anchor, _ := strings.Cut(target, "#")
+	pathPart, _, _ = strings.Cut(pathPart, "?")
+	if decoded, err := url.PathUnescape(pathPart); err == nil {
+		pathPart = decoded
+	}
+
+	file := currentFile
+	if pathPart != "" {
+		file = resolveLinkPath(repoPath, baseDir, pathPart)
+		info, err := os.Stat(file)
+		if err != nil {
+			return fmt.Sprintf("%s does not exist", pathPart)
+		}
+		if info.IsDir() || anchor == "" {
+			return ""
+		}
+	}
+
+	if anchor == "" || !strings.EqualFold(filepath.Ext(file), ".md") {
+		return ""
+	}
+
+	return checkAnchor(file, pathPart, anchor, anchorCache)
+}
+
+// resolveLinkPath returns the file a link path names, resolving paths starting
+// with / from the repository root and others from baseDir
+func resolveLinkPath(repoPath, baseDir, pathPart string) string {
+	if strings.HasPrefix(pathPart, "/") {
+		return filepath.Join(repoPath, filepath.FromSlash(pathPart))
+	}
+	return filepath.Join(baseDir, filepath.FromSlash(pathPart))
+}
+
+// checkAnchor returns why an anchor is missing from a Markdown file, or "" if
+// it exists. The anchors of each file are read once.
+func checkAnchor(file, pathPart, anchor string, anchorCache map[string]map[string]bool) string {
+	anchors, cached := anchorCache[file]
+	if !cached {
+		//nolint:gosec // Reading Markdown files linked from the README
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return fmt.Sprintf("%s could not be read", pathPart)
+		}
+		anchors = markdownAnchors(string(data))
+		anchorCache[file] = anchors
+	}
+	if !anchors[strings.ToLower(anchor)] && !anchors[anchor] {
+		return fmt.Sprintf("anchor #%s not found", anchor)
+	}
+	return ""
+}
+
+// externalLinkOptions bounds the requests made for external links
+type externalLinkOptions struct {
+	Timeout     time.Duration
+	Concurrency int
+	// FollowRedirects judges a link by where it redirects to; otherwise any
+	// 3xx response counts as reachable
+	FollowRedirects bool
+	// Cache, if set, holds the outcome of URLs already requested in this run
+	Cache *linkCache
+}
+
+// linkCache remembers why each requested URL was broken ("" if it responded),
+// so that READMEs linking the same URL only request it once per run
+type linkCache struct {
+	mu      sync.Mutex
+	reasons map[string]string
+}
+
+// newLinkCache creates an empty link cache
+func newLinkCache() *linkCache {
+	return &linkCache{reasons: make(map[string]string)}
+}
+
+// linkCacheKey is the RunStore key of the run's link cache
+const linkCacheKey = "docs.link_cache"
+
+// runLinkCache returns the link cache of the engine run ctx belongs to, or a
+// cache for this check alone outside of a run, so that a long-running server
+// requests each URL again on its next run
+func runLinkCache(ctx context.Context) *linkCache {
+	store := core.RunStoreFrom(ctx)
+	if store == nil {
+		return newLinkCache()
+	}
+	return store.LoadOrCreate(linkCacheKey, func() interface{} { return newLinkCache() }).(*linkCache)
+}
+
+func (c *linkCache) get(key string) (string, bool) {
+	if c == nil {
+		return "", false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	reason, ok := c.reasons[key]
+	return reason, ok
+}
+
+func (c *linkCache) put(key, reason string) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.reasons[key] = reason
+}
+
+// linkDomainAllowed reports whether target's host may be requested. A domain
+// matches itself and its subdomains; denied domains win over allowed ones, and
+// an empty allow list allows every domain that is not denied.
+func linkDomainAllowed(target string, allowed, denied []string) bool {
+	parsed, err := url.Parse(target)
+	if err != nil {
+		return true // Reported as an invalid URL when requested
+	}
+	host := strings.ToLower(parsed.Hostname())
+	matches := func(domains []string) bool {
+		for _, domain := range domains {
+			domain = strings.ToLower(strings.TrimPrefix(strings.TrimSpace(domain), "."))
+			if domain != "" && (host == domain || strings.HasSuffix(host, "."+domain)) {
+				return true
+			}
+		}
+		return false
+	}
+	if matches(denied) {
+		return false
+	}
+	return len(allowed) == 0 || matches(allowed)
+}
+
+// checkExternalLinks requests each distinct URL with at most options.Concurrency
+// requests in flight
+func checkExternalLinks(ctx context.Context, links []MarkdownLink, options externalLinkOptions) []BrokenLink {
+	if options.Timeout <= 0 {
+		options.Timeout = DefaultExternalLinkTimeout
+	}
+	if options.Concurrency <= 0 {
+		options.Concurrency = DefaultExternalLinkConcurrency
+	}
+
+	client := &http.Client{Timeout: options.Timeout}
+	if !options.FollowRedirects {
+		client.CheckRedirect = func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		}
+	}
+	cacheKey := func(target string) string {
+		return fmt.Sprintf("%t %s", options.FollowRedirects, target)
+	}
+
+	reasons := make(map[string]string)
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	semaphore := make(chan struct{}, options.Concurrency)
+
+	for _, link := range links {
+		mu.Lock()
+		_, seen := reasons[link.Target]
+		reasons[link.Target] = ""
+		mu.Unlock()
+		if seen {
+			continue
+		}
+		if reason, cached := options.Cache.get(cacheKey(link.Target)); cached {
+			mu.Lock()
+			reasons[link.Target] = reason
+			mu.Unlock()
+			continue
+		}
+
+		wg.Add(1)
+		go func(target string) {
+			defer wg.Done()
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+
+			reason := requestLink(ctx, client, target)
+			if ctx.Err() == nil {
+				options.Cache.put(cacheKey(target), reason)
+			}
+			mu.Lock()
+			reasons[target] = reason
+			mu.Unlock()
+		}(link.Target)
+	}
+	wg.Wait()
+
+	var broken []BrokenLink
+	for _, link := range links {
+		if reason := reasons[link.Target]; reason != "" {
+			broken = append(broken, BrokenLink{MarkdownLink: link, Reason: reason})
+		}
+	}
+	return broken
+}
+
+// requestLink returns why an external link is broken, or "" if it responds.
+// Servers that reject HEAD requests are retried with GET.
+func requestLink(ctx context.Context, client *http.Client, target string) string {
+	var status int
+	for _, method := range []string{http.MethodHead, http.MethodGet} {
+		req, err := http.NewRequestWithContext(ctx, method, target, nil)
+		if err != nil {
+			return fmt.Sprintf("invalid URL: %v", err)
+		}
+		resp, err := client.Do(req)
+		if err != nil {
+			return fmt.Sprintf("request failed: %v", err)
+		}
+		_ = resp.Body.Close()
+
+		status = resp.StatusCode
+		if status < 400 {
+			return ""
+		}
+	}
+	return fmt.Sprintf("returned HTTP %d", status)
+}
+
+// addLinkResults records link metrics and reports broken links as issues.
+// It returns the score penalty for broken links.
+func addLinkResults(builder *base.ResultBuilder, readmeFile string, result LinkCheckResult) int {
+	builder.AddMetric("links_checked", result.Checked)
+	builder.AddMetric("external_links_checked", result.ExternalChecked)
+	if result.ExternalSkipped > 0 {
+		builder.AddMetric("external_links_skipped", result.ExternalSkipped)
+	}
+	builder.AddMetric("broken_links", len(result.Broken))
+
+	for _, link := range result.Broken {
+		issue := base.NewIssueWithLocation(
+			"broken_link",
+			core.SeverityMedium,
+			fmt.Sprintf("Broken link to %s: %s", link.Target, link.Reason),
+			readmeFile,
+			link.Line,
+			0,
+		)
+		issue.Suggestion = "Fix or remove the link"
+		builder.AddIssue(issue)
+	}
+
+	return min(len(result.Broken)*5, 20)
+}
@@ -0,0 +1,206 @@
+package docs
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/codcod/repos/internal/core"
+)
+
+type linkCheckConfig struct {
+	core.Config
+	options map[string]interface{}
+}
+
+func (c linkCheckConfig) GetCheckerConfig(checkerID string) (core.CheckerConfig, bool) {
+	return core.CheckerConfig{Enabled: true, Options: c.options}, true
+}
+
+func TestExtractMarkdownLinks(t *testing.T) {
+	content := "# Title\n" +
+		"See [docs](docs/guide.md \"Guide\") and ![logo](img/logo.png).\n" +
+		"Inline `[not](a-link.md)` code.\n" +
+		"```\n[also not](code.md)\n```\n" +
+		"[ref]: https://example.com/page\n"
+
+	links := ExtractMarkdownLinks(content)
+	expected := []MarkdownLink{
+		{Target: "docs/guide.md", Line: 2},
+		{Target: "img/logo.png", Line: 2},
+		{Target: "https://example.com/page", Line: 7},
+	}
+	if len(links) != len(expected) {
+		t.Fatalf("Expected %v, got %v", expected, links)
+	}
+	for i := range expected {
+		if links[i] != expected[i] {
+			t.Errorf("Expected %v, got %v", expected[i], links[i])
+		}
+	}
+}
+
+func TestMarkdownAnchors(t *testing.T) {
+	anchors := markdownAnchors("# Getting Started!\n## API (v2)\n## Usage\n## Usage\n<a name=\"custom\"></a>\n")
+	for _, anchor := range []string{"getting-started", "api-v2", "usage", "usage-1", "custom"} {
+		if !anchors[anchor] {
+			t.Errorf("Expected anchor %q in %v", anchor, anchors)
+		}
+	}
+}
+
+func TestReadmeChecker_CheckLinks(t *testing.T) {
+	repoPath := t.TempDir()
+	files := map[string]string{
+		"README.md": "# Project\n\n## Install\n\n" +
+			"[guide](docs/guide.md#setup) [install](#install) [missing](docs/missing.md)\n" +
+			"[bad anchor](#nowhere) [bad file anchor](docs/guide.md#other) [mail](mailto:a@b.c)\n" +
+			"[site](https://example.invalid/)\n",
+		"docs/guide.md": "# Setup\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(repoPath, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	repoCtx := core.RepositoryContext{
+		Repository: core.Repository{Name: "project", Path: repoPath},
+		Config:     linkCheckConfig{options: map[string]interface{}{"check_links": true}},
+	}
+	result, err := NewReadmeChecker().Check(context.Background(), repoCtx)
+	if err != nil {
+		t.Fatalf("Check failed: %v", err)
+	}
+
+	// External links are only counted when check_external_links is enabled
+	if result.Metrics["links_checked"] != 5 || result.Metrics["broken_links"] != 3 || result.Metrics["external_links_checked"] != 0 {
+		t.Errorf("Unexpected metrics: %v", result.Metrics)
+	}
+
+	var broken []string
+	for _, issue := range result.Issues {
+		if issue.Type == "broken_link" {
+			broken = append(broken, issue.Message)
+		}
+	}
+	if len(broken) != 3 {
+		t.Errorf("Expected 3 broken link issues, got %v", broken)
+	}
+}
+
+func TestCheckExternalLinks(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/ok":
+			w.WriteHeader(http.StatusOK)
+		case "/no-head":
+			if r.Method == http.MethodHead {
+				w.WriteHeader(http.StatusMethodNotAllowed)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	links := []MarkdownLink{
+		{Target: server.URL + "/ok", Line: 1},
+		{Target: server.URL + "/no-head", Line: 2},
+		{Target: server.URL + "/gone", Line: 3},
+		{Target: server.URL + "/gone", Line: 4},
+	}
+
+	broken := checkExternalLinks(context.Background(), links, externalLinkOptions{Timeout: time.Second, Concurrency: 2})
+	if len(broken) != 2 || broken[0].Line != 3 || broken[1].Line != 4 {
+		t.Fatalf("Expected both /gone links to be broken, got %+v", broken)
+	}
+	if broken[0].Reason != "returned HTTP 404" {
+		t.Errorf("Unexpected reason: %s", broken[0].Reason)
+	}
+}
+
+func TestCheckExternalLinks_RedirectsAndCache(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		switch r.URL.Path {
+		case "/moved":
+			http.Redirect(w, r, "/gone", http.StatusMovedPermanently)
+		case "/ok":
+			w.WriteHeader(http.StatusOK)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	links := []MarkdownLink{{Target: server.URL + "/moved", Line: 1}}
+	cache := newLinkCache()
+
+	// A redirect counts as reachable unless redirects are followed
+	if broken := checkExternalLinks(context.Background(), links, externalLinkOptions{Cache: cache}); len(broken) != 0 {
+		t.Errorf("Expected the redirect to be accepted, got %+v", broken)
+	}
+	broken := checkExternalLinks(context.Background(), links, externalLinkOptions{FollowRedirects: true, Cache: cache})
+	if len(broken) != 1 || broken[0].Reason != "returned HTTP 404" {
+		t.Errorf("Expected the redirect target to be reported, got %+v", broken)
+	}
+
+	// Both outcomes are cached for the rest of the run
+	before := requests.Load()
+	checkExternalLinks(context.Background(), links, externalLinkOptions{Cache: cache})
+	checkExternalLinks(context.Background(), links, externalLinkOptions{FollowRedirects: true, Cache: cache})
+	if requests.Load() != before {
+		t.Errorf("Expected cached links not to be requested again, got %d more requests", requests.Load()-before)
+	}
+}
+
+func TestRunLinkCache(t *testing.T) {
+	run := core.WithRunStore(context.Background())
+	runLinkCache(run).put("https://example.com", "returned HTTP 404")
+
+	if reason, ok := runLinkCache(run).get("https://example.com"); !ok || reason != "returned HTTP 404" {
+		t.Errorf("Expected the outcome to be cached for the run, got %q, %v", reason, ok)
+	}
+	if _, ok := runLinkCache(core.WithRunStore(context.Background())).get("https://example.com"); ok {
+		t.Error("Expected the next run to start with an empty cache")
+	}
+	if _, ok := runLinkCache(context.Background()).get("https://example.com"); ok {
+		t.Error("Expected a check outside of a run to start with an empty cache")
+	}
+}
+
+func TestLinkDomainAllowed(t *testing.T) {
+	tests := []struct {
+		target  string
+		allowed []string
+		denied  []string
+		want    bool
+	}{
+		{"https://example.com/a", nil, nil, true},
+		{"https://docs.example.com/a", []string{"example.com"}, nil, true},
+		{"https://example.org/a", []string{"example.com"}, nil, false},
+		{"https://notexample.com/a", []string{"example.com"}, nil, false},
+		{"http://localhost:8080/", nil, []string{"localhost"}, false},
+		{"https://internal.example.com/", []string{"example.com"}, []string{".internal.example.com"}, false},
+		{"https://EXAMPLE.com/", []string{"example.com"}, nil, true},
+	}
+
+	for _, tt := range tests {
+		if got := linkDomainAllowed(tt.target, tt.allowed, tt.denied); got != tt.want {
+			t.Errorf("linkDomainAllowed(%q, %v, %v) = %t, want %t", tt.target, tt.allowed, tt.denied, got, tt.want)
+		}
+	}
+}
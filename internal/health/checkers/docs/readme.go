@@ -61,6 +61,20 @@ type ReadmeCheckerConfig struct {
 	RequireLicenseInfo    bool
 	CustomBadgePatterns   []string
 	CustomLicenseKeywords []string
+
+	// CheckLinks verifies that relative links and anchors in the README resolve
+	CheckLinks bool
+	// CheckExternalLinks also requests http(s) links; it needs network access
+	CheckExternalLinks      bool
+	ExternalLinkTimeout     time.Duration
+	ExternalLinkConcurrency int
+	// FollowRedirects judges external links by their redirect target instead
+	// of accepting any 3xx response
+	FollowRedirects bool
+	// AllowedLinkDomains limits external requests to these domains and their
+	// subdomains; DeniedLinkDomains are never requested
+	AllowedLinkDomains []string
+	DeniedLinkDomains  []string
 }
 
 // ContentAnalyzer provides reusable content analysis methods
@@ -103,6 +117,9 @@ func DefaultConfig() *ReadmeCheckerConfig {
 		RequireBadges:       true,
 		RequireCodeExamples: true,
 		RequireLicenseInfo:  true,
+
+		ExternalLinkTimeout:     DefaultExternalLinkTimeout,
+		ExternalLinkConcurrency: DefaultExternalLinkConcurrency,
 	}
 }
 
@@ -170,15 +187,33 @@ func NewReadmeCheckerWithConfig(config *ReadmeCheckerConfig) *ReadmeChecker {
 	}
 }
 
+// Metadata describes what the checker verifies
+func (c *ReadmeChecker) Metadata() core.CheckerMetadata {
+	return core.CheckerMetadata{
+		Description: "Checks that a README exists and covers installation, usage, code examples and license information, and optionally that it has the required sections and that its links resolve.",
+		Options: []core.CheckerOption{
+			{Name: "required_sections", Default: []string{}, Description: "Headings the README must have, matched case-insensitively at any level"},
+			{Name: "min_sections", Default: 0, Description: "How many of required_sections must be present (0: all)"},
+			{Name: "check_links", Default: c.config.CheckLinks, Description: "Check relative links and anchors in the README"},
+			{Name: "check_external_links", Default: c.config.CheckExternalLinks, Description: "Also request http(s) links"},
+			{Name: "external_link_timeout", Default: int(c.config.ExternalLinkTimeout / time.Second), Description: "Seconds to wait for each external link"},
+			{Name: "external_link_concurrency", Default: c.config.ExternalLinkConcurrency, Description: "Maximum external link requests in flight"},
+			{Name: "external_link_allowed_domains", Default: c.config.AllowedLinkDomains, Description: "Only request external links on these domains and their subdomains (empty: all)"},
+			{Name: "external_link_denied_domains", Default: c.config.DeniedLinkDomains, Description: "Never request external links on these domains and their subdomains"},
+			{Name: "follow_redirects", Default: c.config.FollowRedirects, Description: "Follow redirects and judge external links by their target instead of accepting any 3xx response"},
+		},
+	}
+}
+
 // Check performs the README check
 func (c *ReadmeChecker) Check(ctx context.Context, repoCtx core.RepositoryContext) (core.CheckResult, error) {
 	return c.Execute(ctx, repoCtx, func() (core.CheckResult, error) {
-		return c.checkReadme(repoCtx)
+		return c.checkReadme(ctx, repoCtx)
 	})
 }
 
 // checkReadme performs the actual README check
-func (c *ReadmeChecker) checkReadme(repoCtx core.RepositoryContext) (core.CheckResult, error) {
+func (c *ReadmeChecker) checkReadme(ctx context.Context, repoCtx core.RepositoryContext) (core.CheckResult, error) {
 	builder := base.NewResultBuilder(c.ID(), c.Name(), c.Category())
 
 	// Look for README files
@@ -203,6 +238,20 @@ func (c *ReadmeChecker) checkReadme(repoCtx core.RepositoryContext) (core.CheckR
 
 	// Analyze README quality
 	score, issues, warnings := c.analyzeReadmeQuality(repoCtx.Repository.Path, mainReadme)
+
+	//nolint:gosec // This is intentional file reading for code analysis
+	if content, err := os.ReadFile(filepath.Join(repoCtx.Repository.Path, mainReadme)); err == nil {
+		// Require the configured sections
+		required := c.StringSliceOption(repoCtx, "required_sections")
+		minSections := c.IntOption(repoCtx, "min_sections", 0)
+		score = max(score-checkRequiredSections(builder, mainReadme, string(content), required, minSections), 0)
+
+		// Optionally verify the README's links
+		if c.BoolOption(repoCtx, "check_links", c.config.CheckLinks) {
+			links := c.checkReadmeLinks(ctx, repoCtx, mainReadme, string(content))
+			score = max(score-addLinkResults(builder, mainReadme, links), 0)
+		}
+	}
 	builder.WithScore(score, 100)
 
 	// Add issues and warnings
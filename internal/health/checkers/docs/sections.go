@@ -0,0 +1,110 @@
+package docs
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/codcod/repos/internal/core"
+	"github.com/codcod/repos/internal/health/checkers/base"
+)
+
+// sectionPenalty is the score deducted for each missing required section
+const sectionPenalty = 10
+
+// ExtractHeadings returns the text of every Markdown heading in content, at any
+// level: ATX headings ("## Usage") and setext headings underlined with = or -.
+// Headings inside fenced code blocks are ignored.
+func ExtractHeadings(content string) []string {
+	var headings []string
+	inFence := false
+	previous := ""
+
+	for _, line := range strings.Split(content, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, CodeBlockMarker) {
+			inFence = !inFence
+			previous = ""
+			continue
+		}
+		if inFence {
+			continue
+		}
+
+		switch {
+		case strings.HasPrefix(trimmed, "#"):
+			heading := strings.TrimSpace(strings.TrimRight(strings.TrimLeft(trimmed, "#"), "# "))
+			if heading != "" {
+				headings = append(headings, heading)
+			}
+			previous = ""
+			continue
+		case previous != "" && isSetextUnderline(trimmed):
+			headings = append(headings, previous)
+			previous = ""
+			continue
+		}
+		previous = trimmed
+	}
+
+	return headings
+}
+
+// isSetextUnderline reports whether a line underlines the previous line as a heading
+func isSetextUnderline(line string) bool {
+	if len(line) < 2 {
+		return false
+	}
+	return strings.Trim(line, "=") == "" || strings.Trim(line, "-") == ""
+}
+
+// MissingSections returns the required sections that no heading mentions.
+// Matching is case-insensitive, so "usage" matches "## Usage Examples".
+func MissingSections(headings, required []string) []string {
+	var missing []string
+	for _, section := range required {
+		name := strings.ToLower(strings.TrimSpace(section))
+		found := false
+		for _, heading := range headings {
+			if strings.Contains(strings.ToLower(heading), name) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			missing = append(missing, section)
+		}
+	}
+	return missing
+}
+
+// checkRequiredSections reports the required sections missing from the README
+// and returns the score penalty. With minSections set, only that many of the
+// required sections need to be present; otherwise all of them do.
+func checkRequiredSections(builder *base.ResultBuilder, readmeFile, content string, required []string, minSections int) int {
+	if len(required) == 0 {
+		return 0
+	}
+	if minSections <= 0 || minSections > len(required) {
+		minSections = len(required)
+	}
+
+	missing := MissingSections(ExtractHeadings(content), required)
+	present := len(required) - len(missing)
+	builder.AddMetric("required_sections_present", present)
+	if present >= minSections {
+		return 0
+	}
+
+	issue := base.NewIssueWithSuggestion(
+		"missing_readme_sections",
+		core.SeverityMedium,
+		fmt.Sprintf("README is missing required sections: %s (%d of %d present, %d required)",
+			strings.Join(missing, ", "), present, len(required), minSections),
+		fmt.Sprintf("Add headings for: %s", strings.Join(missing, ", ")),
+	)
+	issue.Location = &core.Location{File: readmeFile}
+	issue.Context["missing_sections"] = missing
+	builder.AddIssue(issue)
+
+	return sectionPenalty * (minSections - present)
+}
@@ -0,0 +1,64 @@
+package docs
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/codcod/repos/internal/core"
+)
+
+func TestExtractHeadings(t *testing.T) {
+	content := "Project\n=======\n\n### Installation ###\n\nSome text\n\nUsage Examples\n--------------\n" +
+		"```\n# not a heading\n```\n#### license\n"
+
+	expected := []string{"Project", "Installation", "Usage Examples", "license"}
+	if headings := ExtractHeadings(content); !reflect.DeepEqual(headings, expected) {
+		t.Errorf("Expected %v, got %v", expected, headings)
+	}
+}
+
+func TestReadmeChecker_RequiredSections(t *testing.T) {
+	repoPath := t.TempDir()
+	readme := "# Project\n\nA tool that does things for people who need them done.\n\n## INSTALLATION\n\nRun it.\n"
+	if err := os.WriteFile(filepath.Join(repoPath, "README.md"), []byte(readme), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name        string
+		minSections int
+		missing     []string
+	}{
+		{"all required", 0, []string{"Usage", "Contributing"}},
+		{"minimum met", 1, nil},
+		{"minimum not met", 2, []string{"Usage", "Contributing"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repoCtx := core.RepositoryContext{
+				Repository: core.Repository{Name: "project", Path: repoPath},
+				Config: linkCheckConfig{options: map[string]interface{}{
+					"required_sections": []interface{}{"installation", "Usage", "Contributing"},
+					"min_sections":      tt.minSections,
+				}},
+			}
+			result, err := NewReadmeChecker().Check(context.Background(), repoCtx)
+			if err != nil {
+				t.Fatalf("Check failed: %v", err)
+			}
+
+			var missing []string
+			for _, issue := range result.Issues {
+				if issue.Type == "missing_readme_sections" {
+					missing = issue.Context["missing_sections"].([]string)
+				}
+			}
+			if !reflect.DeepEqual(missing, tt.missing) {
+				t.Errorf("Expected missing sections %v, got %v", tt.missing, missing)
+			}
+		})
+	}
+}
@@ -0,0 +1,182 @@
+package git
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/codcod/repos/internal/core"
+	"github.com/codcod/repos/internal/health/checkers/base"
+	"github.com/codcod/repos/internal/platform/commands"
+)
+
+const (
+	defaultBusFactorMonths  = 12
+	defaultBusFactorCommits = 1000
+	defaultMaxAuthorShare   = 80
+	defaultMinCommits       = 10
+)
+
+// BusFactorChecker measures how concentrated the recent commits of a repository
+// are among its authors. A repository where one author makes nearly every
+// commit depends on that person, however healthy its code.
+type BusFactorChecker struct {
+	*base.BaseChecker
+	executor commands.CommandExecutor
+}
+
+// NewBusFactorChecker creates a new bus factor checker
+func NewBusFactorChecker(executor commands.CommandExecutor) *BusFactorChecker {
+	config := core.CheckerConfig{
+		Enabled:    true,
+		Severity:   "low",
+		Timeout:    time.Minute,
+		Categories: []string{"git"},
+	}
+
+	return &BusFactorChecker{
+		BaseChecker: base.NewBaseChecker(
+			"git-bus-factor",
+			"Bus Factor",
+			"git",
+			config,
+		),
+		executor: executor,
+	}
+}
+
+// Metadata describes what the checker verifies
+func (*BusFactorChecker) Metadata() core.CheckerMetadata {
+	return core.CheckerMetadata{
+		Description: "Counts the authors of the commits made in the last months and warns when a single author made more than max_author_share percent of them. " +
+			"The bus factor metric is the fewest authors who together made half of the commits. Authors are named as in .mailmap; merges are not counted.",
+		Options: []core.CheckerOption{
+			{Name: "months", Default: defaultBusFactorMonths, Description: "How many months of history to count"},
+			{Name: "max_commits", Default: defaultBusFactorCommits, Description: "Most recent commits to count at most"},
+			{Name: "max_author_share", Default: defaultMaxAuthorShare, Description: "Percentage of the commits one author may make before the check warns"},
+			{Name: "min_commits", Default: defaultMinCommits, Description: "Fewest commits needed to judge ownership; with fewer the check passes"},
+		},
+		RequiredTools: []string{"git"},
+	}
+}
+
+// Check performs the bus factor check
+func (c *BusFactorChecker) Check(ctx context.Context, repoCtx core.RepositoryContext) (core.CheckResult, error) {
+	return c.Execute(ctx, repoCtx, func() (core.CheckResult, error) {
+		return c.checkBusFactor(ctx, repoCtx)
+	})
+}
+
+// authorCommits is the number of commits an author made
+type authorCommits struct {
+	Name    string
+	Commits int
+}
+
+// checkBusFactor performs the actual bus factor check
+func (c *BusFactorChecker) checkBusFactor(ctx context.Context, repoCtx core.RepositoryContext) (core.CheckResult, error) {
+	builder := base.NewResultBuilder(c.ID(), c.Name(), c.Category())
+	months := c.IntOption(repoCtx, "months", defaultBusFactorMonths)
+	maxCommits := c.IntOption(repoCtx, "max_commits", defaultBusFactorCommits)
+	maxShare := c.IntOption(repoCtx, "max_author_share", defaultMaxAuthorShare)
+	minCommits := c.IntOption(repoCtx, "min_commits", defaultMinCommits)
+
+	result := c.executor.ExecuteInDir(ctx, repoCtx.Repository.Path, "git", "log", "--no-merges", "--format=%aN",
+		fmt.Sprintf("--since=%d.months.ago", months), fmt.Sprintf("--max-count=%d", maxCommits))
+	if result.Error != nil {
+		builder.WithStatus(core.StatusWarning)
+		builder.AddWarning(core.Warning{
+			Type:    "git_command_error",
+			Message: fmt.Sprintf("Unable to read commit authors: %v", result.Error),
+		})
+		return builder.Build(), nil
+	}
+
+	authors, total := countAuthors(result.Stdout)
+	builder.AddMetric("period_months", months)
+	builder.AddMetric("commits_analyzed", total)
+	builder.AddMetric("contributors", len(authors))
+	builder.AddMetric("bus_factor", busFactor(authors, total))
+
+	if total == 0 {
+		builder.WithStatus(core.StatusHealthy)
+		builder.WithScore(100, 100)
+		return builder.Build(), nil
+	}
+
+	top := authors[0]
+	share := math.Round(float64(top.Commits)*1000/float64(total)) / 10
+	builder.AddMetric("top_author", top.Name)
+	builder.AddMetric("top_author_share", share)
+
+	// A handful of commits says little about ownership
+	if total < minCommits || share <= float64(maxShare) {
+		builder.WithStatus(core.StatusHealthy)
+		builder.WithScore(100, 100)
+		return builder.Build(), nil
+	}
+
+	builder.WithStatus(core.StatusWarning)
+	builder.WithScore(max(100-int(share-float64(maxShare))*2, 50), 100)
+	issue := base.NewIssueWithSuggestion(
+		"single_author_dominance",
+		core.SeverityMedium,
+		fmt.Sprintf("%s made %.1f%% of the %d commits in the last %d months (limit %d%%)", top.Name, share, total, months, maxShare),
+		"Spread knowledge of the repository through reviews and pairing, and document who else can maintain it",
+	)
+	issue.Context["author"] = top.Name
+	issue.Context["share"] = share
+	issue.Context["contributors"] = len(authors)
+	builder.AddIssue(issue)
+
+	return builder.Build(), nil
+}
+
+// countAuthors counts the commits of each author in git log output with one
+// author name per line, most commits first
+func countAuthors(output string) ([]authorCommits, int) {
+	counts := make(map[string]int)
+	total := 0
+	for _, line := range strings.Split(output, "\n") {
+		name := strings.TrimSpace(line)
+		if name == "" {
+			continue
+		}
+		counts[name]++
+		total++
+	}
+
+	authors := make([]authorCommits, 0, len(counts))
+	for name, commits := range counts {
+		authors = append(authors, authorCommits{Name: name, Commits: commits})
+	}
+	sort.Slice(authors, func(i, j int) bool {
+		if authors[i].Commits != authors[j].Commits {
+			return authors[i].Commits > authors[j].Commits
+		}
+		return authors[i].Name < authors[j].Name
+	})
+	return authors, total
+}
+
+// busFactor returns the fewest authors who together made at least half of the
+// commits; authors must be sorted by commits, most first
+func busFactor(authors []authorCommits, total int) int {
+	covered := 0
+	for i, author := range authors {
+		covered += author.Commits
+		if covered*2 >= total {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+// SupportsRepository checks if this checker supports the repository
+func (c *BusFactorChecker) SupportsRepository(repo core.Repository) bool {
+	result := c.executor.ExecuteInDir(context.Background(), repo.Path, "git", "rev-parse", "--is-inside-work-tree")
+	return result.Error == nil && strings.TrimSpace(result.Stdout) == "true"
+}
@@ -0,0 +1,99 @@
+package git
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/codcod/repos/internal/core"
+	"github.com/codcod/repos/internal/platform/commands"
+)
+
+func TestBusFactorChecker(t *testing.T) {
+	tests := []struct {
+		name    string
+		stdout  string
+		options map[string]interface{}
+		status  core.HealthStatus
+		score   int
+		issues  int
+		metrics map[string]interface{}
+	}{
+		{
+			name:    "no recent commits",
+			stdout:  "",
+			status:  core.StatusHealthy,
+			score:   100,
+			metrics: map[string]interface{}{"commits_analyzed": 0, "contributors": 0, "bus_factor": 0},
+		},
+		{
+			name:   "shared ownership",
+			stdout: strings.Repeat("alice\n", 6) + strings.Repeat("bob\n", 4) + strings.Repeat("carol\n", 2),
+			status: core.StatusHealthy,
+			score:  100,
+			metrics: map[string]interface{}{
+				"commits_analyzed": 12, "contributors": 3, "bus_factor": 1, "top_author": "alice", "top_author_share": 50.0,
+			},
+		},
+		{
+			name:   "single author dominates",
+			stdout: strings.Repeat("alice\n", 19) + "bob\n",
+			status: core.StatusWarning,
+			score:  70,
+			issues: 1,
+			metrics: map[string]interface{}{
+				"commits_analyzed": 20, "contributors": 2, "top_author": "alice", "top_author_share": 95.0,
+			},
+		},
+		{
+			name:    "too few commits to judge",
+			stdout:  "alice\nalice\nalice\n",
+			status:  core.StatusHealthy,
+			score:   100,
+			metrics: map[string]interface{}{"commits_analyzed": 3, "top_author_share": 100.0},
+		},
+		{
+			name:    "configured threshold and period",
+			stdout:  strings.Repeat("alice\n", 7) + strings.Repeat("bob\n", 3),
+			options: map[string]interface{}{"max_author_share": 60, "months": 6},
+			status:  core.StatusWarning,
+			score:   80,
+			issues:  1,
+			metrics: map[string]interface{}{"period_months": 6, "bus_factor": 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			months := "12"
+			if tt.options["months"] != nil {
+				months = "6"
+			}
+			executor := commands.NewMockCommandExecutor()
+			executor.SetResponse("git log --no-merges --format=%aN --since="+months+".months.ago --max-count=1000",
+				commands.CommandResult{Stdout: tt.stdout})
+
+			result, err := NewBusFactorChecker(executor).Check(context.Background(), core.RepositoryContext{
+				Repository: core.Repository{Name: "repo", Path: t.TempDir()},
+				Config:     sizeTestConfig{options: tt.options},
+			})
+			if err != nil {
+				t.Fatalf("Check failed: %v", err)
+			}
+			if result.Status != tt.status || result.Score != tt.score {
+				t.Errorf("Expected %s with score %d, got %s with %d", tt.status, tt.score, result.Status, result.Score)
+			}
+			if len(result.Issues) != tt.issues {
+				t.Fatalf("Expected %d issues, got %+v", tt.issues, result.Issues)
+			}
+			if tt.issues > 0 && result.Issues[0].Type != "single_author_dominance" {
+				t.Errorf("Unexpected issue: %+v", result.Issues[0])
+			}
+			for name, expected := range tt.metrics {
+				if result.Metrics[name] != expected {
+					t.Errorf("Expected metric %s = %v, got %v", name, expected, result.Metrics[name])
+				}
+			}
+		})
+	}
+}
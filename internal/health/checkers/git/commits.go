@@ -38,6 +38,14 @@ func NewLastCommitChecker(executor commands.CommandExecutor) *LastCommitChecker
 	}
 }
 
+// Metadata describes what the checker verifies
+func (*LastCommitChecker) Metadata() core.CheckerMetadata {
+	return core.CheckerMetadata{
+		Description:   "Scores how recently the repository was committed to; repositories untouched for more than 90 days are reported as stale.",
+		RequiredTools: []string{"git"},
+	}
+}
+
 // Check performs the last commit check
 func (c *LastCommitChecker) Check(ctx context.Context, repoCtx core.RepositoryContext) (core.CheckResult, error) {
 	return c.Execute(ctx, repoCtx, func() (core.CheckResult, error) {
@@ -0,0 +1,180 @@
+package git
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/codcod/repos/internal/core"
+	"github.com/codcod/repos/internal/health/checkers/base"
+)
+
+// Hook frameworks reported by GitHooksChecker
+const (
+	HookFrameworkPreCommit = "pre-commit"
+	HookFrameworkHusky     = "husky"
+	HookFrameworkGitHooks  = "git-hooks"
+)
+
+// preCommitConfig mirrors the parts of .pre-commit-config.yaml we inspect
+type preCommitConfig struct {
+	Repos []struct {
+		Repo  string `yaml:"repo"`
+		Hooks []struct {
+			ID string `yaml:"id"`
+		} `yaml:"hooks"`
+	} `yaml:"repos"`
+}
+
+// GitHooksChecker checks whether the repository uses a git hook framework
+type GitHooksChecker struct {
+	*base.BaseChecker
+}
+
+// NewGitHooksChecker creates a new git hooks checker
+func NewGitHooksChecker() *GitHooksChecker {
+	config := core.CheckerConfig{
+		Enabled:    true,
+		Severity:   "low",
+		Timeout:    30 * time.Second,
+		Categories: []string{"git"},
+	}
+
+	return &GitHooksChecker{
+		BaseChecker: base.NewBaseChecker(
+			"git-hooks",
+			"Git Hooks",
+			"git",
+			config,
+		),
+	}
+}
+
+// Metadata describes what the checker verifies
+func (*GitHooksChecker) Metadata() core.CheckerMetadata {
+	return core.CheckerMetadata{
+		Description: "Checks that the repository uses a git hook framework (pre-commit or husky) or has scripts installed in .git/hooks.",
+	}
+}
+
+// Check performs the git hooks check
+func (c *GitHooksChecker) Check(ctx context.Context, repoCtx core.RepositoryContext) (core.CheckResult, error) {
+	return c.Execute(ctx, repoCtx, func() (core.CheckResult, error) {
+		return c.checkGitHooks(repoCtx)
+	})
+}
+
+// checkGitHooks performs the actual git hooks check
+func (c *GitHooksChecker) checkGitHooks(repoCtx core.RepositoryContext) (core.CheckResult, error) {
+	builder := base.NewResultBuilder(c.ID(), c.Name(), c.Category())
+	repoPath := repoCtx.Repository.Path
+
+	var frameworks []string
+	configInvalid := false
+
+	// pre-commit framework
+	configPath := filepath.Join(repoPath, ".pre-commit-config.yaml")
+	if _, err := os.Stat(configPath); err == nil {
+		frameworks = append(frameworks, HookFrameworkPreCommit)
+
+		hookCount, err := countPreCommitHooks(configPath)
+		if err != nil {
+			configInvalid = true
+			builder.AddIssue(base.NewIssueWithSuggestion(
+				"invalid_pre_commit_config",
+				core.SeverityMedium,
+				fmt.Sprintf("Unable to parse .pre-commit-config.yaml: %v", err),
+				"Run 'pre-commit validate-config' to find the problem",
+			))
+		} else {
+			builder.AddMetric("pre_commit_hooks", hookCount)
+			if hookCount == 0 {
+				builder.AddWarning(core.Warning{
+					Type:    "empty_pre_commit_config",
+					Message: ".pre-commit-config.yaml does not configure any hooks",
+				})
+			}
+		}
+	}
+
+	// Husky
+	if hooks := listHookScripts(filepath.Join(repoPath, ".husky")); hooks != nil {
+		frameworks = append(frameworks, HookFrameworkHusky)
+		builder.AddMetric("husky_hooks", len(hooks))
+	}
+
+	// Scripts installed directly in .git/hooks, ignoring the samples git creates
+	if hooks := listHookScripts(filepath.Join(repoPath, ".git", "hooks")); len(hooks) > 0 {
+		frameworks = append(frameworks, HookFrameworkGitHooks)
+		builder.AddMetric("git_hooks", strings.Join(hooks, ","))
+	}
+
+	builder.AddMetric("hook_frameworks", len(frameworks))
+
+	if len(frameworks) == 0 {
+		builder.WithStatus(core.StatusWarning)
+		builder.WithScore(60, 100)
+		builder.AddIssue(base.NewIssueWithSuggestion(
+			"no_git_hooks",
+			core.SeverityLow,
+			"No git hook framework found",
+			"Add a .pre-commit-config.yaml and run 'pre-commit install' to enforce checks before commit",
+		))
+		return builder.Build(), nil
+	}
+
+	builder.AddMetric("hook_framework", strings.Join(frameworks, ","))
+	if configInvalid {
+		builder.WithStatus(core.StatusWarning)
+		builder.WithScore(80, 100)
+	} else {
+		builder.WithStatus(core.StatusHealthy)
+		builder.WithScore(100, 100)
+	}
+
+	return builder.Build(), nil
+}
+
+// countPreCommitHooks returns the number of hooks configured in a .pre-commit-config.yaml
+func countPreCommitHooks(configPath string) (int, error) {
+	data, err := os.ReadFile(configPath) //nolint:gosec // Path is within the repository
+	if err != nil {
+		return 0, err
+	}
+
+	var config preCommitConfig
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return 0, err
+	}
+
+	count := 0
+	for _, repo := range config.Repos {
+		count += len(repo.Hooks)
+	}
+	return count, nil
+}
+
+// listHookScripts returns the hook scripts in a hooks directory, skipping git's
+// *.sample files and helper directories such as .husky/_. It returns nil when the
+// directory does not exist.
+func listHookScripts(dir string) []string {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil
+	}
+
+	hooks := []string{}
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || strings.HasSuffix(name, ".sample") || strings.HasPrefix(name, ".") {
+			continue
+		}
+		hooks = append(hooks, name)
+	}
+	return hooks
+}
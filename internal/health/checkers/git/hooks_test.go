@@ -0,0 +1,97 @@
+package git
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/codcod/repos/internal/core"
+)
+
+func writeHookFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0755); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestGitHooksChecker(t *testing.T) {
+	preCommit := `repos:
+  - repo: https://github.com/pre-commit/pre-commit-hooks
+    rev: v4.5.0
+    hooks:
+      - id: trailing-whitespace
+      - id: end-of-file-fixer
+  - repo: local
+    hooks:
+      - id: go-vet
+        name: go vet
+        entry: go vet ./...
+        language: system
+`
+
+	tests := []struct {
+		name           string
+		files          map[string]string
+		expectedStatus core.HealthStatus
+		expectedMetric map[string]interface{}
+	}{
+		{
+			name:           "no hooks",
+			files:          map[string]string{".git/hooks/pre-commit.sample": "#!/bin/sh\n"},
+			expectedStatus: core.StatusWarning,
+			expectedMetric: map[string]interface{}{"hook_frameworks": 0},
+		},
+		{
+			name:           "pre-commit",
+			files:          map[string]string{".pre-commit-config.yaml": preCommit},
+			expectedStatus: core.StatusHealthy,
+			expectedMetric: map[string]interface{}{"hook_framework": "pre-commit", "pre_commit_hooks": 3},
+		},
+		{
+			name: "husky and git hooks",
+			files: map[string]string{
+				".husky/pre-commit":   "npx lint-staged\n",
+				".husky/_/husky.sh":   "#!/bin/sh\n",
+				".git/hooks/pre-push": "#!/bin/sh\nmake test\n",
+			},
+			expectedStatus: core.StatusHealthy,
+			expectedMetric: map[string]interface{}{"hook_framework": "husky,git-hooks", "husky_hooks": 1, "git_hooks": "pre-push"},
+		},
+		{
+			name:           "invalid pre-commit config",
+			files:          map[string]string{".pre-commit-config.yaml": "repos: [unclosed\n"},
+			expectedStatus: core.StatusWarning,
+			expectedMetric: map[string]interface{}{"hook_framework": "pre-commit"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repoPath := t.TempDir()
+			for name, content := range tt.files {
+				writeHookFile(t, filepath.Join(repoPath, name), content)
+			}
+
+			result, err := NewGitHooksChecker().Check(context.Background(), core.RepositoryContext{
+				Repository: core.Repository{Name: "repo", Path: repoPath},
+			})
+			if err != nil {
+				t.Fatalf("Check failed: %v", err)
+			}
+
+			if result.Status != tt.expectedStatus {
+				t.Errorf("Expected status %s, got %s", tt.expectedStatus, result.Status)
+			}
+			for metric, want := range tt.expectedMetric {
+				if result.Metrics[metric] != want {
+					t.Errorf("Expected metric %s = %v, got %v", metric, want, result.Metrics[metric])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,184 @@
+package git
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/codcod/repos/internal/core"
+	"github.com/codcod/repos/internal/health/checkers/base"
+	"github.com/codcod/repos/internal/platform/commands"
+)
+
+const (
+	defaultRemote               = "origin"
+	defaultRemoteTimeoutSeconds = 10
+)
+
+// RemoteChecker checks that the repository's remote is configured and reachable
+// and that the checked-out branch still exists on it, which finds abandoned or
+// misconfigured clones
+type RemoteChecker struct {
+	*base.BaseChecker
+	executor commands.CommandExecutor
+}
+
+// NewRemoteChecker creates a new git remote checker
+func NewRemoteChecker(executor commands.CommandExecutor) *RemoteChecker {
+	config := core.CheckerConfig{
+		Enabled:    true,
+		Severity:   "medium",
+		Timeout:    30 * time.Second,
+		Categories: []string{"git"},
+	}
+
+	return &RemoteChecker{
+		BaseChecker: base.NewBaseChecker(
+			"git-remote",
+			"Git Remote",
+			"git",
+			config,
+		),
+		executor: executor,
+	}
+}
+
+// Metadata describes what the checker verifies
+func (*RemoteChecker) Metadata() core.CheckerMetadata {
+	return core.CheckerMetadata{
+		Description: "Runs 'git ls-remote --heads' against the configured remote to verify that it exists and is reachable, " +
+			"and warns when HEAD is detached, the branch has no upstream, or the branch no longer exists on the remote.",
+		Options: []core.CheckerOption{
+			{Name: "remote", Default: defaultRemote, Description: "Name of the remote to check"},
+			{Name: "timeout_seconds", Default: defaultRemoteTimeoutSeconds, Description: "Seconds to wait for the remote to answer"},
+		},
+		RequiredTools: []string{"git"},
+	}
+}
+
+// Check performs the git remote check
+func (c *RemoteChecker) Check(ctx context.Context, repoCtx core.RepositoryContext) (core.CheckResult, error) {
+	return c.Execute(ctx, repoCtx, func() (core.CheckResult, error) {
+		return c.checkRemote(ctx, repoCtx), nil
+	})
+}
+
+// checkRemote performs the actual git remote check
+func (c *RemoteChecker) checkRemote(ctx context.Context, repoCtx core.RepositoryContext) core.CheckResult {
+	builder := base.NewResultBuilder(c.ID(), c.Name(), c.Category())
+	repoPath := repoCtx.Repository.Path
+	remote := c.StringOption(repoCtx, "remote", defaultRemote)
+	timeout := time.Duration(c.IntOption(repoCtx, "timeout_seconds", defaultRemoteTimeoutSeconds)) * time.Second
+
+	builder.AddMetric("remote", remote)
+
+	if result := c.executor.ExecuteInDir(ctx, repoPath, "git", "remote", "get-url", remote); result.Error != nil {
+		builder.AddMetric("reachable", false)
+		builder.WithStatus(core.StatusWarning)
+		builder.WithScore(50, 100)
+		builder.AddIssue(base.NewIssueWithSuggestion(
+			"missing_remote",
+			core.SeverityMedium,
+			fmt.Sprintf("Remote '%s' is not configured", remote),
+			fmt.Sprintf("Add the remote with 'git remote add %s <url>'", remote),
+		))
+		return builder.Build()
+	}
+
+	lsCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	result := c.executor.ExecuteInDir(lsCtx, repoPath, "git", "ls-remote", "--heads", remote)
+	if result.Error != nil {
+		builder.AddMetric("reachable", false)
+		builder.WithStatus(core.StatusWarning)
+		builder.WithScore(40, 100)
+		issue := base.NewIssueWithSuggestion(
+			"remote_unreachable",
+			core.SeverityMedium,
+			fmt.Sprintf("Remote '%s' is not reachable: %v", remote, result.Error),
+			"Check the remote URL with 'git remote -v', your network and your credentials",
+		)
+		if stderr := strings.TrimSpace(result.Stderr); stderr != "" {
+			issue.Context["stderr"] = stderr
+		}
+		builder.AddIssue(issue)
+		return builder.Build()
+	}
+
+	heads := parseRemoteHeads(result.Stdout)
+	builder.AddMetric("reachable", true)
+	builder.AddMetric("remote_branches", len(heads))
+
+	score := 100
+	branchResult := c.executor.ExecuteInDir(ctx, repoPath, "git", "symbolic-ref", "--quiet", "--short", "HEAD")
+	branch := strings.TrimSpace(branchResult.Stdout)
+	if branchResult.Error != nil || branch == "" {
+		score -= 20
+		builder.AddIssue(base.NewIssueWithSuggestion(
+			"detached_head",
+			core.SeverityLow,
+			"HEAD is detached, so no branch tracks the remote",
+			"Check out a branch with 'git switch <branch>'",
+		))
+	} else {
+		builder.AddMetric("branch", branch)
+
+		// The branch is looked up on the remote under the name it tracks, if any
+		remoteBranch := branch
+		upstream := c.executor.ExecuteInDir(ctx, repoPath, "git", "rev-parse", "--abbrev-ref", branch+"@{upstream}")
+		if tracked := strings.TrimSpace(upstream.Stdout); upstream.Error == nil && strings.HasPrefix(tracked, remote+"/") {
+			remoteBranch = strings.TrimPrefix(tracked, remote+"/")
+			builder.AddMetric("upstream", tracked)
+		} else {
+			score -= 10
+			builder.AddIssue(base.NewIssueWithSuggestion(
+				"no_upstream",
+				core.SeverityLow,
+				fmt.Sprintf("Branch '%s' does not track a branch of '%s'", branch, remote),
+				fmt.Sprintf("Set the upstream with 'git branch --set-upstream-to=%s/%s'", remote, branch),
+			))
+		}
+
+		if !heads[remoteBranch] {
+			score -= 30
+			issue := base.NewIssueWithSuggestion(
+				"branch_missing_upstream",
+				core.SeverityMedium,
+				fmt.Sprintf("Branch '%s' does not exist on '%s'", remoteBranch, remote),
+				"Push the branch, or switch to a branch that exists on the remote if it was deleted",
+			)
+			issue.Context["branch"] = remoteBranch
+			builder.AddIssue(issue)
+		}
+	}
+
+	if score == 100 {
+		builder.WithStatus(core.StatusHealthy)
+	} else {
+		builder.WithStatus(core.StatusWarning)
+	}
+	builder.WithScore(score, 100)
+	return builder.Build()
+}
+
+// parseRemoteHeads returns the branch names listed by git ls-remote --heads
+func parseRemoteHeads(output string) map[string]bool {
+	heads := make(map[string]bool)
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			continue
+		}
+		if name, ok := strings.CutPrefix(fields[1], "refs/heads/"); ok {
+			heads[name] = true
+		}
+	}
+	return heads
+}
+
+// SupportsRepository checks if this checker supports the repository
+func (c *RemoteChecker) SupportsRepository(repo core.Repository) bool {
+	result := c.executor.ExecuteInDir(context.Background(), repo.Path, "git", "rev-parse", "--is-inside-work-tree")
+	return result.Error == nil && strings.TrimSpace(result.Stdout) == "true"
+}
@@ -0,0 +1,104 @@
+package git
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/codcod/repos/internal/core"
+	"github.com/codcod/repos/internal/platform/commands"
+)
+
+const remoteHeads = "1111111111111111111111111111111111111111\trefs/heads/main\n" +
+	"2222222222222222222222222222222222222222\trefs/heads/release/1.x\n"
+
+func TestRemoteChecker(t *testing.T) {
+	tests := []struct {
+		name      string
+		responses map[string]commands.CommandResult
+		status    core.HealthStatus
+		issues    []string
+	}{
+		{
+			name: "tracking branch on remote",
+			responses: map[string]commands.CommandResult{
+				"git ls-remote --heads origin":               {Stdout: remoteHeads},
+				"git symbolic-ref --quiet --short HEAD":      {Stdout: "main\n"},
+				"git rev-parse --abbrev-ref main@{upstream}": {Stdout: "origin/main\n"},
+			},
+			status: core.StatusHealthy,
+		},
+		{
+			name: "missing remote",
+			responses: map[string]commands.CommandResult{
+				"git remote get-url origin": {ExitCode: 2, Error: errors.New("exit status 2")},
+			},
+			status: core.StatusWarning,
+			issues: []string{"missing_remote"},
+		},
+		{
+			name: "unreachable remote",
+			responses: map[string]commands.CommandResult{
+				"git ls-remote --heads origin": {ExitCode: 128, Error: errors.New("exit status 128"), Stderr: "fatal: repository not found"},
+			},
+			status: core.StatusWarning,
+			issues: []string{"remote_unreachable"},
+		},
+		{
+			name: "detached head",
+			responses: map[string]commands.CommandResult{
+				"git ls-remote --heads origin":          {Stdout: remoteHeads},
+				"git symbolic-ref --quiet --short HEAD": {ExitCode: 1, Error: errors.New("exit status 1")},
+			},
+			status: core.StatusWarning,
+			issues: []string{"detached_head"},
+		},
+		{
+			name: "branch deleted upstream",
+			responses: map[string]commands.CommandResult{
+				"git ls-remote --heads origin":                  {Stdout: remoteHeads},
+				"git symbolic-ref --quiet --short HEAD":         {Stdout: "feature\n"},
+				"git rev-parse --abbrev-ref feature@{upstream}": {Stdout: "origin/feature\n"},
+			},
+			status: core.StatusWarning,
+			issues: []string{"branch_missing_upstream"},
+		},
+		{
+			name: "untracked branch",
+			responses: map[string]commands.CommandResult{
+				"git ls-remote --heads origin":               {Stdout: remoteHeads},
+				"git symbolic-ref --quiet --short HEAD":      {Stdout: "main\n"},
+				"git rev-parse --abbrev-ref main@{upstream}": {ExitCode: 128, Error: errors.New("exit status 128")},
+			},
+			status: core.StatusWarning,
+			issues: []string{"no_upstream"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			executor := commands.NewMockCommandExecutor()
+			for command, result := range tt.responses {
+				executor.SetResponse(command, result)
+			}
+
+			result, err := NewRemoteChecker(executor).Check(context.Background(), core.RepositoryContext{
+				Repository: core.Repository{Name: "repo", Path: t.TempDir()},
+			})
+			if err != nil {
+				t.Fatalf("Check failed: %v", err)
+			}
+			if result.Status != tt.status {
+				t.Errorf("Expected status %s, got %s", tt.status, result.Status)
+			}
+			if len(result.Issues) != len(tt.issues) {
+				t.Fatalf("Expected issues %v, got %+v", tt.issues, result.Issues)
+			}
+			for i, issueType := range tt.issues {
+				if result.Issues[i].Type != issueType {
+					t.Errorf("Issue %d: expected %s, got %s", i, issueType, result.Issues[i].Type)
+				}
+			}
+		})
+	}
+}
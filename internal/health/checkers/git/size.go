@@ -0,0 +1,323 @@
+package git
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/codcod/repos/internal/core"
+	"github.com/codcod/repos/internal/health/checkers/base"
+	"github.com/codcod/repos/internal/platform/commands"
+)
+
+const (
+	defaultMaxFileSizeMB = 5
+	defaultTopOffenders  = 10
+)
+
+// largeFile is a file over the size limit
+type largeFile struct {
+	Path string
+	Size int64
+}
+
+// GitSizeChecker finds large files that are not tracked by Git LFS, either
+// anywhere in history or, in working tree mode, only in the current checkout
+type GitSizeChecker struct {
+	*base.BaseChecker
+	executor commands.CommandExecutor
+}
+
+// NewGitSizeChecker creates a new repository size checker
+func NewGitSizeChecker(executor commands.CommandExecutor) *GitSizeChecker {
+	config := core.CheckerConfig{
+		Enabled:    true,
+		Severity:   "medium",
+		Timeout:    2 * time.Minute,
+		Categories: []string{"git"},
+	}
+
+	return &GitSizeChecker{
+		BaseChecker: base.NewBaseChecker(
+			"git-size",
+			"Repository Size",
+			"git",
+			config,
+		),
+		executor: executor,
+	}
+}
+
+// Metadata describes what the checker verifies
+func (*GitSizeChecker) Metadata() core.CheckerMetadata {
+	return core.CheckerMetadata{
+		Description: "Finds files larger than the size limit that are not covered by a Git LFS filter in .gitattributes. " +
+			"Scans every blob in history, or only tracked files in the working tree when working_tree_only is set.",
+		Options: []core.CheckerOption{
+			{Name: "max_file_size_mb", Default: strconv.Itoa(defaultMaxFileSizeMB), Description: "Size in megabytes above which a file is reported"},
+			{Name: "top_offenders", Default: strconv.Itoa(defaultTopOffenders), Description: "Number of largest files to report"},
+			{Name: "working_tree_only", Default: "false", Description: "Only check files in the current checkout (repos health --working-tree-only)"},
+		},
+		RequiredTools: []string{"git"},
+	}
+}
+
+// Check performs the repository size check
+func (c *GitSizeChecker) Check(ctx context.Context, repoCtx core.RepositoryContext) (core.CheckResult, error) {
+	return c.Execute(ctx, repoCtx, func() (core.CheckResult, error) {
+		return c.checkSize(ctx, repoCtx)
+	})
+}
+
+// checkSize performs the actual repository size check
+func (c *GitSizeChecker) checkSize(ctx context.Context, repoCtx core.RepositoryContext) (core.CheckResult, error) {
+	builder := base.NewResultBuilder(c.ID(), c.Name(), c.Category())
+	repoPath := repoCtx.Repository.Path
+
+	maxSize := int64(c.IntOption(repoCtx, "max_file_size_mb", defaultMaxFileSizeMB)) << 20
+	topOffenders := c.IntOption(repoCtx, "top_offenders", defaultTopOffenders)
+	workingTreeOnly := c.BoolOption(repoCtx, "working_tree_only", false)
+
+	var files []largeFile
+	var err error
+	if workingTreeOnly {
+		files, err = c.workingTreeFiles(ctx, repoPath)
+	} else {
+		files, err = c.historyFiles(ctx, repoPath)
+	}
+	if err != nil {
+		builder.WithStatus(core.StatusWarning)
+		builder.AddWarning(core.Warning{
+			Type:    "git_command_error",
+			Message: fmt.Sprintf("Unable to list repository files: %v", err),
+		})
+		return builder.Build(), nil
+	}
+
+	lfsPatterns := readLFSPatterns(filepath.Join(repoPath, ".gitattributes"))
+
+	offenders := largeFilesWithoutLFS(files, maxSize, lfsPatterns)
+
+	scope := "history"
+	if workingTreeOnly {
+		scope = "working_tree"
+	}
+	builder.AddMetric("scan_scope", scope)
+	builder.AddMetric("files_scanned", len(files))
+	builder.AddMetric("large_files", len(offenders))
+	builder.AddMetric("lfs_patterns", len(lfsPatterns))
+
+	if len(offenders) == 0 {
+		builder.WithStatus(core.StatusHealthy)
+		builder.WithScore(100, 100)
+		return builder.Build(), nil
+	}
+
+	if topOffenders > 0 && len(offenders) > topOffenders {
+		offenders = offenders[:topOffenders]
+	}
+	largest := make([]string, 0, len(offenders))
+	for _, file := range offenders {
+		largest = append(largest, fmt.Sprintf("%s (%s)", file.Path, formatFileSize(file.Size)))
+
+		issue := base.NewIssueWithSuggestion(
+			"large_file_without_lfs",
+			core.SeverityMedium,
+			fmt.Sprintf("%s is %s, over the %s limit", file.Path, formatFileSize(file.Size), formatFileSize(maxSize)),
+			fmt.Sprintf("Track it with 'git lfs track %q'%s", file.Path, rewriteHint(workingTreeOnly)),
+		)
+		issue.Location = &core.Location{File: file.Path}
+		builder.AddIssue(issue)
+	}
+	builder.AddMetric("largest_files", strings.Join(largest, ", "))
+
+	builder.WithStatus(core.StatusWarning)
+	builder.WithScore(max(100-10*len(offenders), 40), 100)
+	return builder.Build(), nil
+}
+
+// largeFilesWithoutLFS returns the files over maxSize that no LFS pattern
+// covers, largest first
+func largeFilesWithoutLFS(files []largeFile, maxSize int64, lfsPatterns []string) []largeFile {
+	var offenders []largeFile
+	for _, file := range files {
+		if file.Size > maxSize && !matchesAnyAttributePattern(file.Path, lfsPatterns) {
+			offenders = append(offenders, file)
+		}
+	}
+	sort.Slice(offenders, func(i, j int) bool {
+		if offenders[i].Size != offenders[j].Size {
+			return offenders[i].Size > offenders[j].Size
+		}
+		return offenders[i].Path < offenders[j].Path
+	})
+	return offenders
+}
+
+// rewriteHint points out that files already in history also need rewriting
+func rewriteHint(workingTreeOnly bool) string {
+	if workingTreeOnly {
+		return ""
+	}
+	return " and move existing history with 'git lfs migrate import'"
+}
+
+// workingTreeFiles returns the size of every tracked file in the checkout
+func (c *GitSizeChecker) workingTreeFiles(ctx context.Context, repoPath string) ([]largeFile, error) {
+	result := c.executor.ExecuteInDir(ctx, repoPath, "git", "ls-files", "-z")
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	var files []largeFile
+	for _, name := range strings.Split(result.Stdout, "\x00") {
+		if name == "" {
+			continue
+		}
+		info, err := os.Lstat(filepath.Join(repoPath, filepath.FromSlash(name)))
+		if err != nil || !info.Mode().IsRegular() {
+			continue // Deleted in the working tree, or a symlink or submodule
+		}
+		files = append(files, largeFile{Path: name, Size: info.Size()})
+	}
+	return files, nil
+}
+
+// historyFiles returns the largest blob ever committed at each path. Paths come
+// from 'git rev-list --objects --all' and sizes from 'git cat-file'.
+func (c *GitSizeChecker) historyFiles(ctx context.Context, repoPath string) ([]largeFile, error) {
+	objects := c.executor.ExecuteInDir(ctx, repoPath, "git", "rev-list", "--objects", "--all")
+	if objects.Error != nil {
+		return nil, objects.Error
+	}
+
+	paths := objectPaths(objects.Stdout)
+
+	sizes := c.executor.ExecuteInDir(ctx, repoPath, "git", "cat-file", "--batch-all-objects",
+		"--batch-check=%(objecttype) %(objectname) %(objectsize)")
+	if sizes.Error != nil {
+		return nil, sizes.Error
+	}
+
+	largest := largestBlobs(sizes.Stdout, paths)
+	files := make([]largeFile, 0, len(largest))
+	for name, size := range largest {
+		files = append(files, largeFile{Path: name, Size: size})
+	}
+	return files, nil
+}
+
+// objectPaths maps each object listed by 'git rev-list --objects' to the
+// first path it was seen at
+func objectPaths(output string) map[string]string {
+	paths := make(map[string]string)
+	scanner := bufio.NewScanner(strings.NewReader(output))
+	for scanner.Scan() {
+		hash, name, found := strings.Cut(scanner.Text(), " ")
+		if found && name != "" {
+			if _, seen := paths[hash]; !seen {
+				paths[hash] = name
+			}
+		}
+	}
+	return paths
+}
+
+// largestBlobs returns the size of the largest blob listed by 'git cat-file'
+// at each of paths
+func largestBlobs(output string, paths map[string]string) map[string]int64 {
+	largest := make(map[string]int64)
+	scanner := bufio.NewScanner(strings.NewReader(output))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 3 || fields[0] != "blob" {
+			continue
+		}
+		name, reachable := paths[fields[1]]
+		if !reachable {
+			continue
+		}
+		size, err := strconv.ParseInt(fields[2], 10, 64)
+		if err == nil && size > largest[name] {
+			largest[name] = size
+		}
+	}
+	return largest
+}
+
+// readLFSPatterns returns the .gitattributes patterns that use the LFS filter
+func readLFSPatterns(attributesPath string) []string {
+	data, err := os.ReadFile(attributesPath) //nolint:gosec // Path is within the repository
+	if err != nil {
+		return nil
+	}
+
+	var patterns []string
+	for _, line := range strings.Split(string(data), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 2 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+		for _, attr := range fields[1:] {
+			if attr == "filter=lfs" {
+				patterns = append(patterns, fields[0])
+				break
+			}
+		}
+	}
+	return patterns
+}
+
+// matchesAnyAttributePattern reports whether a slash-separated path matches a
+// .gitattributes pattern. Patterns without a slash match the file name at any
+// depth; others are anchored at the repository root, and a trailing /** matches
+// everything below a directory.
+func matchesAnyAttributePattern(name string, patterns []string) bool {
+	for _, pattern := range patterns {
+		if !strings.Contains(pattern, "/") {
+			if matched, _ := path.Match(pattern, path.Base(name)); matched {
+				return true
+			}
+			continue
+		}
+
+		pattern = strings.TrimPrefix(pattern, "/")
+		if dir, ok := strings.CutSuffix(pattern, "/**"); ok {
+			if strings.HasPrefix(name, dir+"/") {
+				return true
+			}
+			continue
+		}
+		if matched, _ := path.Match(pattern, name); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// formatFileSize renders a byte count in binary units
+func formatFileSize(size int64) string {
+	const unit = 1024
+	if size < unit {
+		return fmt.Sprintf("%d B", size)
+	}
+	value, exp := float64(size)/unit, 0
+	for value >= unit && exp < 3 {
+		value /= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", value, "KMGT"[exp])
+}
+
+// SupportsRepository checks if this checker supports the repository
+func (c *GitSizeChecker) SupportsRepository(repo core.Repository) bool {
+	result := c.executor.ExecuteInDir(context.Background(), repo.Path, "git", "rev-parse", "--is-inside-work-tree")
+	return result.Error == nil && strings.TrimSpace(result.Stdout) == "true"
+}
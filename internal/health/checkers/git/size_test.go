@@ -0,0 +1,129 @@
+package git
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/codcod/repos/internal/core"
+	"github.com/codcod/repos/internal/platform/commands"
+)
+
+// sizeTestConfig provides options for the git-size checker
+type sizeTestConfig struct {
+	options map[string]interface{}
+}
+
+func (c sizeTestConfig) GetCheckerConfig(string) (core.CheckerConfig, bool) {
+	return core.CheckerConfig{Enabled: true, Options: c.options}, true
+}
+func (sizeTestConfig) GetAnalyzerConfig(string) (core.AnalyzerConfig, bool) {
+	return core.AnalyzerConfig{}, false
+}
+func (sizeTestConfig) GetReporterConfig(string) (core.ReporterConfig, bool) {
+	return core.ReporterConfig{}, false
+}
+func (sizeTestConfig) GetEngineConfig() core.EngineConfig { return core.EngineConfig{} }
+
+func TestGitSizeChecker_History(t *testing.T) {
+	repoPath := t.TempDir()
+	writeHookFile(t, filepath.Join(repoPath, ".gitattributes"), "# media\n*.psd filter=lfs diff=lfs merge=lfs -text\nassets/** filter=lfs\n")
+
+	executor := commands.NewMockCommandExecutor()
+	executor.SetResponse("git rev-list --objects --all", commands.CommandResult{Stdout: strings.Join([]string{
+		"c0ffee",
+		"a1 data/dump.sql",
+		"a2 data/dump.sql",
+		"b1 design.psd",
+		"c1 assets/video.mp4",
+		"d1 main.go",
+		"e1 data",
+	}, "\n")})
+	executor.SetResponse("git cat-file --batch-all-objects --batch-check=%(objecttype) %(objectname) %(objectsize)", commands.CommandResult{Stdout: strings.Join([]string{
+		"commit c0ffee 250",
+		"blob a1 7340032",  // 7 MiB
+		"blob a2 12582912", // 12 MiB, a later version of the same path
+		"blob b1 52428800",
+		"blob c1 52428800",
+		"blob d1 2048",
+		"tree e1 120",
+		"blob f1 99999999", // unreachable
+	}, "\n")})
+
+	result, err := NewGitSizeChecker(executor).Check(context.Background(), core.RepositoryContext{
+		Repository: core.Repository{Name: "repo", Path: repoPath},
+		Config:     sizeTestConfig{},
+	})
+	if err != nil {
+		t.Fatalf("Check failed: %v", err)
+	}
+
+	if result.Status != core.StatusWarning {
+		t.Errorf("Expected warning status, got %s", result.Status)
+	}
+	if len(result.Issues) != 1 {
+		t.Fatalf("Expected only data/dump.sql to be reported, got %+v", result.Issues)
+	}
+	issue := result.Issues[0]
+	if issue.Location == nil || issue.Location.File != "data/dump.sql" || !strings.Contains(issue.Message, "12.0 MiB") {
+		t.Errorf("Expected the largest version of data/dump.sql, got %+v", issue)
+	}
+	if result.Metrics["files_scanned"] != 4 || result.Metrics["scan_scope"] != "history" {
+		t.Errorf("Unexpected metrics: %v", result.Metrics)
+	}
+}
+
+func TestGitSizeChecker_WorkingTreeOnly(t *testing.T) {
+	repoPath := t.TempDir()
+	writeHookFile(t, filepath.Join(repoPath, "big.bin"), strings.Repeat("x", 3<<20))
+	writeHookFile(t, filepath.Join(repoPath, "bigger.bin"), strings.Repeat("x", 4<<20))
+	writeHookFile(t, filepath.Join(repoPath, "small.txt"), "hello")
+
+	executor := commands.NewMockCommandExecutor()
+	executor.SetResponse("git ls-files -z", commands.CommandResult{Stdout: "big.bin\x00bigger.bin\x00small.txt\x00deleted.bin\x00"})
+
+	result, err := NewGitSizeChecker(executor).Check(context.Background(), core.RepositoryContext{
+		Repository: core.Repository{Name: "repo", Path: repoPath},
+		Config: sizeTestConfig{options: map[string]interface{}{
+			"working_tree_only": true,
+			"max_file_size_mb":  2,
+			"top_offenders":     1,
+		}},
+	})
+	if err != nil {
+		t.Fatalf("Check failed: %v", err)
+	}
+
+	for _, call := range executor.GetCalls() {
+		if len(call.Args) > 0 && call.Args[0] == "rev-list" {
+			t.Error("Expected working tree mode to skip the history scan")
+		}
+	}
+	if result.Metrics["large_files"] != 2 {
+		t.Errorf("Expected 2 large files, got %v", result.Metrics["large_files"])
+	}
+	if len(result.Issues) != 1 || result.Issues[0].Location.File != "bigger.bin" {
+		t.Errorf("Expected only the top offender to be reported, got %+v", result.Issues)
+	}
+	if result.Metrics["largest_files"] != "bigger.bin (4.0 MiB)" {
+		t.Errorf("Unexpected largest_files: %v", result.Metrics["largest_files"])
+	}
+}
+
+func TestMatchesAnyAttributePattern(t *testing.T) {
+	patterns := []string{"*.zip", "/docs/*.pdf", "media/**"}
+	tests := map[string]bool{
+		"release.zip":          true,
+		"nested/dir/a.zip":     true,
+		"docs/guide.pdf":       true,
+		"other/docs/guide.pdf": false,
+		"media/a/b/c.mov":      true,
+		"mediafile.mov":        false,
+	}
+	for name, expected := range tests {
+		if got := matchesAnyAttributePattern(name, patterns); got != expected {
+			t.Errorf("%s: expected %v, got %v", name, expected, got)
+		}
+	}
+}
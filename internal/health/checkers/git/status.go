@@ -39,6 +39,21 @@ func NewGitStatusChecker(executor commands.CommandExecutor) *GitStatusChecker {
 	}
 }
 
+// Metadata describes what the checker verifies
+func (*GitStatusChecker) Metadata() core.CheckerMetadata {
+	return core.CheckerMetadata{
+		Description: "Reports staged and unstaged changes and untracked files in the working tree, each with its own count and issue. " +
+			"Untracked files can be ignored, and staged and unstaged changes given different severities.",
+		Options: []core.CheckerOption{
+			{Name: "ignore_untracked", Default: false, Description: "Do not count untracked files, e.g. build artifacts missing from .gitignore"},
+			{Name: "report_ignored", Default: false, Description: "Also count files matched by .gitignore in the ignored_files metric; they never fail the check"},
+			{Name: "staged_severity", Default: string(core.SeverityMedium), Description: "Severity of staged changes (info makes them advisory)"},
+			{Name: "unstaged_severity", Default: string(core.SeverityMedium), Description: "Severity of unstaged changes (info makes them advisory)"},
+		},
+		RequiredTools: []string{"git"},
+	}
+}
+
 // Check performs the git status check
 func (c *GitStatusChecker) Check(ctx context.Context, repoCtx core.RepositoryContext) (core.CheckResult, error) {
 	return c.Execute(ctx, repoCtx, func() (core.CheckResult, error) {
@@ -64,8 +79,17 @@ func (c *GitStatusChecker) checkGitStatus(ctx context.Context, repoCtx core.Repo
 		return builder.Build(), nil
 	}
 
+	ignoreUntracked := c.BoolOption(repoCtx, "ignore_untracked", false)
+	reportIgnored := c.BoolOption(repoCtx, "report_ignored", false)
+	stagedSeverity := c.severityOption(repoCtx, builder, "staged_severity")
+	unstagedSeverity := c.severityOption(repoCtx, builder, "unstaged_severity")
+
 	// Check for uncommitted changes using git status --porcelain
-	result := c.executor.ExecuteInDir(ctx, repoCtx.Repository.Path, "git", "status", "--porcelain")
+	args := []string{"status", "--porcelain"}
+	if reportIgnored {
+		args = append(args, "--ignored")
+	}
+	result := c.executor.ExecuteInDir(ctx, repoCtx.Repository.Path, "git", args...)
 
 	if result.Error != nil {
 		builder.WithStatus(core.StatusWarning)
@@ -76,47 +100,171 @@ func (c *GitStatusChecker) checkGitStatus(ctx context.Context, repoCtx core.Repo
 		return builder.Build(), nil
 	}
 
-	// Parse git status output
-	lines := strings.Split(strings.TrimSpace(result.Stdout), "\n")
-	if len(lines) == 1 && lines[0] == "" {
+	// Parse git status output; a leading space is part of the first entry's status
+	files := c.parseGitStatus(strings.Split(strings.TrimRight(result.Stdout, "\n"), "\n"))
+
+	counted, counts := countGitFiles(files, ignoreUntracked)
+
+	builder.AddMetric("uncommitted_files", len(counted))
+	builder.AddMetric("staged_files", counts.staged)
+	builder.AddMetric("unstaged_files", counts.unstaged)
+	builder.AddMetric("untracked_files", counts.untracked)
+	if reportIgnored {
+		builder.AddMetric("ignored_files", counts.ignored)
+	}
+
+	if len(counted) == 0 {
 		// Clean status
 		builder.WithStatus(core.StatusHealthy)
 		builder.WithScore(100, 100)
-		builder.AddMetric("uncommitted_files", 0)
 		builder.AddMetric("status", "clean")
-	} else {
-		// Uncommitted changes found
-		builder.WithStatus(core.StatusWarning)
-		builder.WithScore(70, 100)
+		return builder.Build(), nil
+	}
+	builder.AddMetric("status", "dirty")
+
+	issues := counts.issues(stagedSeverity, unstagedSeverity, ignoreUntracked)
+	for _, issue := range issues {
+		builder.AddIssue(issue)
+	}
+	status, score := changeStatus(issues)
+	builder.WithStatus(status)
+	builder.WithScore(score, 100)
 
-		uncommittedFiles := c.parseGitStatus(lines)
-		builder.AddMetric("uncommitted_files", len(uncommittedFiles))
-		builder.AddMetric("status", "dirty")
+	// Add details about uncommitted files
+	for i, file := range counted {
+		if i >= 5 { // Limit to first 5 files to avoid too much output
+			builder.AddMetric(fmt.Sprintf("file_%d", i), fmt.Sprintf("... and %d more", len(counted)-5))
+			break
+		}
+		builder.AddMetric(fmt.Sprintf("file_%d", i), fmt.Sprintf("%s (%s)", file.Name, file.Status))
+	}
+
+	return builder.Build(), nil
+}
 
-		builder.AddIssue(base.NewIssueWithSuggestion(
+// statusCounts counts the files of each kind of change
+type statusCounts struct {
+	staged, unstaged, untracked, ignored int
+}
+
+// countGitFiles counts the files by kind of change and returns those that
+// make the repository dirty
+func countGitFiles(files []GitFile, ignoreUntracked bool) ([]GitFile, statusCounts) {
+	var counted []GitFile
+	var counts statusCounts
+	for _, file := range files {
+		switch {
+		case file.Ignored():
+			counts.ignored++
+			continue
+		case file.Untracked():
+			counts.untracked++
+			if ignoreUntracked {
+				continue
+			}
+		default:
+			if file.Staged() {
+				counts.staged++
+			}
+			if file.Unstaged() {
+				counts.unstaged++
+			}
+		}
+		counted = append(counted, file)
+	}
+	return counted, counts
+}
+
+// issues returns one issue per kind of change, so that teams can weigh them separately
+func (c statusCounts) issues(stagedSeverity, unstagedSeverity core.Severity, ignoreUntracked bool) []core.Issue {
+	var issues []core.Issue
+	if c.staged > 0 {
+		issues = append(issues, base.NewIssueWithSuggestion(
+			"staged_changes",
+			stagedSeverity,
+			fmt.Sprintf("Repository has %d staged changes", c.staged),
+			"Commit the staged changes with 'git commit', or unstage them with 'git restore --staged'",
+		))
+	}
+	if c.unstaged > 0 {
+		issues = append(issues, base.NewIssueWithSuggestion(
 			"uncommitted_changes",
-			core.SeverityMedium,
-			fmt.Sprintf("Repository has %d uncommitted changes", len(uncommittedFiles)),
+			unstagedSeverity,
+			fmt.Sprintf("Repository has %d uncommitted changes", c.unstaged),
 			"Review and commit changes with 'git add' and 'git commit', or stash them with 'git stash'",
 		))
+	}
+	if c.untracked > 0 && !ignoreUntracked {
+		issues = append(issues, base.NewIssueWithSuggestion(
+			"untracked_files",
+			core.SeverityMedium,
+			fmt.Sprintf("Repository has %d untracked files", c.untracked),
+			"Add the files with 'git add', list build artifacts in .gitignore, or set ignore_untracked",
+		))
+	}
+	return issues
+}
 
-		// Add details about uncommitted files
-		for i, file := range uncommittedFiles {
-			if i >= 5 { // Limit to first 5 files to avoid too much output
-				builder.AddMetric(fmt.Sprintf("file_%d", i), fmt.Sprintf("... and %d more", len(uncommittedFiles)-5))
-				break
+// changeStatus returns the status and score of a dirty repository from the
+// severities of its issues. Advisory issues do not lower either.
+func changeStatus(issues []core.Issue) (core.HealthStatus, int) {
+	status, score := core.StatusHealthy, 100
+	for _, issue := range issues {
+		switch {
+		case issue.Severity.Advisory():
+		case issue.Severity == core.SeverityHigh || issue.Severity == core.SeverityCritical:
+			status, score = core.StatusCritical, min(score, 40)
+		default:
+			if status != core.StatusCritical {
+				status = core.StatusWarning
 			}
-			builder.AddMetric(fmt.Sprintf("file_%d", i), fmt.Sprintf("%s (%s)", file.Name, file.Status))
+			score = min(score, 70)
 		}
 	}
+	return status, score
+}
 
-	return builder.Build(), nil
+// severityOption reads a severity option, warning about invalid values and
+// falling back to medium
+func (c *GitStatusChecker) severityOption(repoCtx core.RepositoryContext, builder *base.ResultBuilder, name string) core.Severity {
+	value := c.StringOption(repoCtx, name, string(core.SeverityMedium))
+	severity, err := core.ParseSeverity(value)
+	if err != nil {
+		builder.AddWarning(core.Warning{
+			Type:    "invalid_option",
+			Message: fmt.Sprintf("%s: %v; using medium", name, err),
+		})
+		return core.SeverityMedium
+	}
+	return severity
 }
 
 // GitFile represents a file with git status
 type GitFile struct {
 	Name   string
 	Status string
+	// Code is the two-letter porcelain status: index, then working tree
+	Code string
+}
+
+// Untracked reports whether the file is not tracked by git
+func (f GitFile) Untracked() bool {
+	return f.Code == "??"
+}
+
+// Ignored reports whether the file is matched by .gitignore
+func (f GitFile) Ignored() bool {
+	return f.Code == "!!"
+}
+
+// Staged reports whether the file has changes in the index
+func (f GitFile) Staged() bool {
+	return len(f.Code) == 2 && !strings.ContainsRune(" ?!", rune(f.Code[0]))
+}
+
+// Unstaged reports whether the file has changes in the working tree that are not staged
+func (f GitFile) Unstaged() bool {
+	return len(f.Code) == 2 && !strings.ContainsRune(" ?!", rune(f.Code[1]))
 }
 
 // parseGitStatus parses git status --porcelain output
@@ -135,6 +283,7 @@ func (c *GitStatusChecker) parseGitStatus(lines []string) []GitFile {
 		files = append(files, GitFile{
 			Name:   fileName,
 			Status: status,
+			Code:   statusCode,
 		})
 	}
 
@@ -148,6 +297,8 @@ func (c *GitStatusChecker) getStatusDescription(code string) string {
 	switch code {
 	case "??":
 		return "untracked"
+	case "!!":
+		return "ignored"
 	case " M":
 		return "modified"
 	case "M ":
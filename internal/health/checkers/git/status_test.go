@@ -0,0 +1,115 @@
+package git
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/codcod/repos/internal/core"
+	"github.com/codcod/repos/internal/platform/commands"
+)
+
+const porcelainStatus = " M main.go\n" +
+	"M  go.mod\n" +
+	"MM README.md\n" +
+	"A  new.go\n" +
+	"?? dist/app\n" +
+	"?? notes.txt\n"
+
+func TestGitStatusChecker(t *testing.T) {
+	tests := []struct {
+		name    string
+		stdout  string
+		options map[string]interface{}
+		status  core.HealthStatus
+		issues  []string
+		metrics map[string]interface{}
+	}{
+		{
+			name:   "clean",
+			stdout: "",
+			status: core.StatusHealthy,
+			metrics: map[string]interface{}{
+				"uncommitted_files": 0, "staged_files": 0, "unstaged_files": 0, "untracked_files": 0, "status": "clean",
+			},
+		},
+		{
+			name:   "every kind of change",
+			stdout: porcelainStatus,
+			status: core.StatusWarning,
+			issues: []string{"staged_changes", "uncommitted_changes", "untracked_files"},
+			metrics: map[string]interface{}{
+				"uncommitted_files": 6, "staged_files": 3, "unstaged_files": 2, "untracked_files": 2, "status": "dirty",
+			},
+		},
+		{
+			name:    "untracked files ignored",
+			stdout:  "?? dist/app\n",
+			options: map[string]interface{}{"ignore_untracked": true},
+			status:  core.StatusHealthy,
+			metrics: map[string]interface{}{"uncommitted_files": 0, "untracked_files": 1, "status": "clean"},
+		},
+		{
+			name:    "staged changes advisory",
+			stdout:  "M  go.mod\n?? notes.txt\n",
+			options: map[string]interface{}{"staged_severity": "info", "ignore_untracked": true},
+			status:  core.StatusHealthy,
+			issues:  []string{"staged_changes"},
+			metrics: map[string]interface{}{"uncommitted_files": 1, "staged_files": 1},
+		},
+		{
+			name:    "unstaged changes critical",
+			stdout:  porcelainStatus,
+			options: map[string]interface{}{"unstaged_severity": "high"},
+			status:  core.StatusCritical,
+			issues:  []string{"staged_changes", "uncommitted_changes", "untracked_files"},
+		},
+		{
+			name:    "ignored files reported",
+			stdout:  "!! build/\n!! .env\n",
+			options: map[string]interface{}{"report_ignored": true},
+			status:  core.StatusHealthy,
+			metrics: map[string]interface{}{"uncommitted_files": 0, "ignored_files": 2},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repoPath := t.TempDir()
+			if err := os.Mkdir(filepath.Join(repoPath, ".git"), 0755); err != nil {
+				t.Fatal(err)
+			}
+			command := "git status --porcelain"
+			if tt.options["report_ignored"] == true {
+				command += " --ignored"
+			}
+			executor := commands.NewMockCommandExecutor()
+			executor.SetResponse(command, commands.CommandResult{Stdout: tt.stdout})
+
+			result, err := NewGitStatusChecker(executor).Check(context.Background(), core.RepositoryContext{
+				Repository: core.Repository{Name: "repo", Path: repoPath},
+				Config:     sizeTestConfig{options: tt.options},
+			})
+			if err != nil {
+				t.Fatalf("Check failed: %v", err)
+			}
+			if result.Status != tt.status {
+				t.Errorf("Expected status %s, got %s", tt.status, result.Status)
+			}
+			if len(result.Issues) != len(tt.issues) {
+				t.Fatalf("Expected issues %v, got %+v", tt.issues, result.Issues)
+			}
+			for i, issueType := range tt.issues {
+				if result.Issues[i].Type != issueType {
+					t.Errorf("Issue %d: expected %s, got %s", i, issueType, result.Issues[i].Type)
+				}
+			}
+			for name, expected := range tt.metrics {
+				if result.Metrics[name] != expected {
+					t.Errorf("Expected metric %s = %v, got %v", name, expected, result.Metrics[name])
+				}
+			}
+		})
+	}
+}
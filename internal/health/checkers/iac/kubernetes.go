@@ -0,0 +1,400 @@
+package iac
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/codcod/repos/internal/core"
+	"github.com/codcod/repos/internal/health/checkers/base"
+)
+
+var (
+	// k8sSkipDirs are directories that never hold the repository's own manifests
+	k8sSkipDirs = map[string]bool{".git": true, "node_modules": true, "vendor": true}
+
+	// k8sRequiredFields lists the fields each kind must set, as paths below the
+	// document root. metadata.name is required of every kind.
+	k8sRequiredFields = map[string][]string{
+		"Deployment":  {"spec.selector.matchLabels", "spec.template.spec.containers"},
+		"StatefulSet": {"spec.selector.matchLabels", "spec.serviceName", "spec.template.spec.containers"},
+		"DaemonSet":   {"spec.selector.matchLabels", "spec.template.spec.containers"},
+		"ReplicaSet":  {"spec.selector.matchLabels", "spec.template.spec.containers"},
+		"Job":         {"spec.template.spec.containers"},
+		"CronJob":     {"spec.schedule", "spec.jobTemplate.spec.template.spec.containers"},
+		"Pod":         {"spec.containers"},
+		"Service":     {"spec.ports"},
+	}
+
+	// k8sPodSpecPaths is where each workload kind keeps its pod spec
+	k8sPodSpecPaths = map[string]string{
+		"Deployment":  "spec.template.spec",
+		"StatefulSet": "spec.template.spec",
+		"DaemonSet":   "spec.template.spec",
+		"ReplicaSet":  "spec.template.spec",
+		"Job":         "spec.template.spec",
+		"CronJob":     "spec.jobTemplate.spec.template.spec",
+		"Pod":         "spec",
+	}
+)
+
+// k8sFinding is one problem in a Kubernetes manifest document
+type k8sFinding struct {
+	Type       string
+	Severity   core.Severity
+	File       string
+	Line       int
+	Document   int
+	Kind       string
+	Name       string
+	Message    string
+	Suggestion string
+}
+
+// K8sManifestChecker validates Kubernetes manifests: required fields per kind,
+// container resource limits and requests, and images pinned to :latest
+type K8sManifestChecker struct {
+	*base.BaseChecker
+}
+
+// NewK8sManifestChecker creates a new Kubernetes manifest checker
+func NewK8sManifestChecker() *K8sManifestChecker {
+	config := core.CheckerConfig{
+		Enabled:    true,
+		Severity:   "medium",
+		Timeout:    30 * time.Second,
+		Categories: []string{"quality"},
+	}
+
+	return &K8sManifestChecker{
+		BaseChecker: base.NewBaseChecker(
+			"k8s-manifests",
+			"Kubernetes Manifests",
+			"quality",
+			config,
+		),
+	}
+}
+
+// Metadata describes what the checker verifies
+func (*K8sManifestChecker) Metadata() core.CheckerMetadata {
+	return core.CheckerMetadata{
+		Description: "Validates Kubernetes manifests in .yaml and .yml files: fields each kind requires, such as " +
+			"spec.selector.matchLabels for a Deployment, containers without resource limits or requests, and images " +
+			"using the :latest tag or no tag. YAML documents without apiVersion and kind are skipped, as are unrendered " +
+			"Helm templates. Each finding names the file, line and document index.",
+	}
+}
+
+// Check performs the Kubernetes manifest check
+func (c *K8sManifestChecker) Check(ctx context.Context, repoCtx core.RepositoryContext) (core.CheckResult, error) {
+	return c.Execute(ctx, repoCtx, func() (core.CheckResult, error) {
+		return c.checkManifests(repoCtx)
+	})
+}
+
+// checkManifests performs the actual Kubernetes manifest check
+func (c *K8sManifestChecker) checkManifests(repoCtx core.RepositoryContext) (core.CheckResult, error) {
+	builder := base.NewResultBuilder(c.ID(), c.Name(), c.Category())
+	repoPath := repoCtx.Repository.Path
+
+	files, err := findYAMLFiles(repoCtx.Repository.FileIndex())
+	if err != nil {
+		return core.CheckResult{}, err
+	}
+
+	var findings []k8sFinding
+	manifests, documents, skipped := 0, 0, 0
+	for _, file := range files {
+		relPath, err := filepath.Rel(repoPath, file)
+		if err != nil {
+			relPath = file
+		}
+		relPath = filepath.ToSlash(relPath)
+
+		fileFindings, fileDocuments, err := scanManifestFile(file, relPath)
+		if err != nil {
+			skipped++ // Unreadable, invalid or templated YAML
+			continue
+		}
+		if fileDocuments > 0 {
+			manifests++
+			documents += fileDocuments
+		}
+		findings = append(findings, fileFindings...)
+	}
+
+	sort.SliceStable(findings, func(i, j int) bool {
+		if findings[i].File != findings[j].File {
+			return findings[i].File < findings[j].File
+		}
+		return findings[i].Line < findings[j].Line
+	})
+
+	builder.AddMetric("manifest_files", manifests)
+	builder.AddMetric("kubernetes_documents", documents)
+	builder.AddMetric("skipped_files", skipped)
+	builder.AddMetric("manifest_findings", len(findings))
+
+	for _, finding := range findings {
+		issue := base.NewIssueWithLocation(finding.Type, finding.Severity, finding.Message, finding.File, finding.Line, 0)
+		issue.Suggestion = finding.Suggestion
+		issue.Context["document"] = finding.Document
+		issue.Context["kind"] = finding.Kind
+		if finding.Name != "" {
+			issue.Context["name"] = finding.Name
+		}
+		builder.AddIssue(issue)
+	}
+
+	if len(findings) == 0 {
+		builder.WithStatus(core.StatusHealthy)
+		builder.WithScore(100, 100)
+		return builder.Build(), nil
+	}
+	builder.WithStatus(core.StatusWarning)
+	builder.WithScore(max(100-5*len(findings), 40), 100)
+	return builder.Build(), nil
+}
+
+// scanManifestFile validates each Kubernetes document of a YAML file and
+// returns the findings and the number of Kubernetes documents. Helm templates
+// are returned as an error since they are not YAML until rendered.
+func scanManifestFile(path, relPath string) ([]k8sFinding, int, error) {
+	data, err := os.ReadFile(path) //nolint:gosec // File path is from repository analysis
+	if err != nil {
+		return nil, 0, err
+	}
+	if bytes.Contains(data, []byte("{{")) {
+		return nil, 0, errors.New("unrendered template")
+	}
+
+	var findings []k8sFinding
+	documents := 0
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	for index := 0; ; index++ {
+		var doc yaml.Node
+		if err := decoder.Decode(&doc); errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return nil, 0, err
+		}
+		if len(doc.Content) == 0 {
+			continue
+		}
+		root := doc.Content[0]
+		apiVersion, kind := scalarAt(root, "apiVersion"), scalarAt(root, "kind")
+		if apiVersion == nil || kind == nil || kind.Value == "" {
+			continue // Not a Kubernetes document
+		}
+		documents++
+		findings = append(findings, validateManifest(root, kind.Value, relPath, index)...)
+	}
+	return findings, documents, nil
+}
+
+// validateManifest checks one Kubernetes document
+func validateManifest(root *yaml.Node, kind, relPath string, index int) []k8sFinding {
+	doc := k8sDocument{root: root, kind: kind, relPath: relPath, index: index}
+	if node := scalarAt(root, "metadata.name"); node != nil {
+		doc.name = node.Value
+	}
+
+	var findings []k8sFinding
+	for _, field := range append([]string{"metadata.name"}, k8sRequiredFields[kind]...) {
+		if node := nodeAt(root, field); node == nil || isEmptyNode(node) {
+			findings = append(findings, doc.finding("k8s_missing_field", core.SeverityHigh, root.Line,
+				fmt.Sprintf("required field %s is missing", field),
+				fmt.Sprintf("Set %s", field)))
+		}
+	}
+	return append(findings, doc.validatePodSpec()...)
+}
+
+// k8sDocument is a Kubernetes document being validated
+type k8sDocument struct {
+	root    *yaml.Node
+	kind    string
+	name    string
+	relPath string
+	index   int
+}
+
+// finding reports a problem in the document
+func (d k8sDocument) finding(issueType string, severity core.Severity, line int, message, suggestion string) k8sFinding {
+	return k8sFinding{
+		Type:       issueType,
+		Severity:   severity,
+		File:       d.relPath,
+		Line:       line,
+		Document:   d.index,
+		Kind:       d.kind,
+		Name:       d.name,
+		Message:    fmt.Sprintf("%s (document %d): %s", describeObject(d.kind, d.name), d.index, message),
+		Suggestion: suggestion,
+	}
+}
+
+// validatePodSpec checks the containers of a workload's pod template
+func (d k8sDocument) validatePodSpec() []k8sFinding {
+	podSpecPath, ok := k8sPodSpecPaths[d.kind]
+	if !ok {
+		return nil
+	}
+	podSpec := nodeAt(d.root, podSpecPath)
+	if podSpec == nil {
+		return nil
+	}
+
+	var findings []k8sFinding
+	for _, list := range []string{"initContainers", "containers"} {
+		containers := nodeAt(podSpec, list)
+		if containers == nil || containers.Kind != yaml.SequenceNode {
+			continue
+		}
+		for _, container := range containers.Content {
+			findings = append(findings, d.validateContainer(container)...)
+		}
+	}
+	return findings
+}
+
+// validateContainer checks that a container pins its image and sets resource
+// limits and requests
+func (d k8sDocument) validateContainer(container *yaml.Node) []k8sFinding {
+	containerName := "unnamed"
+	if node := scalarAt(container, "name"); node != nil {
+		containerName = node.Value
+	}
+
+	var findings []k8sFinding
+	if image := scalarAt(container, "image"); image != nil && usesLatestImage(image.Value) {
+		findings = append(findings, d.finding("k8s_latest_image", core.SeverityMedium, image.Line,
+			fmt.Sprintf("container %q uses image %q, which is not pinned to a version", containerName, image.Value),
+			"Pin the image to a version tag or digest"))
+	}
+	for _, requirement := range []string{"limits", "requests"} {
+		if node := nodeAt(container, "resources."+requirement); node == nil || isEmptyNode(node) {
+			findings = append(findings, d.finding("k8s_missing_resource_"+requirement, core.SeverityMedium, container.Line,
+				fmt.Sprintf("container %q has no resource %s", containerName, requirement),
+				fmt.Sprintf("Set resources.%s.cpu and resources.%s.memory", requirement, requirement)))
+		}
+	}
+	return findings
+}
+
+// describeObject names a Kubernetes object, e.g. Deployment "web"
+func describeObject(kind, name string) string {
+	if name == "" {
+		return kind
+	}
+	return fmt.Sprintf("%s %q", kind, name)
+}
+
+// usesLatestImage reports whether an image reference resolves to :latest,
+// either explicitly or by having neither a tag nor a digest
+func usesLatestImage(image string) bool {
+	if strings.Contains(image, "@") {
+		return false // Pinned by digest
+	}
+	// A colon after the last slash is a tag; one before it is a registry port
+	lastSlash := strings.LastIndex(image, "/")
+	colon := strings.LastIndex(image, ":")
+	if colon <= lastSlash {
+		return true
+	}
+	return image[colon+1:] == "latest"
+}
+
+// nodeAt follows a dotted path of mapping keys from node
+func nodeAt(node *yaml.Node, path string) *yaml.Node {
+	for _, key := range strings.Split(path, ".") {
+		if node == nil || node.Kind != yaml.MappingNode {
+			return nil
+		}
+		var next *yaml.Node
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if node.Content[i].Value == key {
+				next = node.Content[i+1]
+				break
+			}
+		}
+		node = next
+	}
+	return node
+}
+
+// scalarAt returns the scalar at a dotted path, or nil when there is none
+func scalarAt(node *yaml.Node, path string) *yaml.Node {
+	node = nodeAt(node, path)
+	if node == nil || node.Kind != yaml.ScalarNode {
+		return nil
+	}
+	return node
+}
+
+// isEmptyNode reports whether a node is null or an empty scalar, mapping or sequence
+func isEmptyNode(node *yaml.Node) bool {
+	switch node.Kind {
+	case yaml.ScalarNode:
+		return node.Value == "" || node.Tag == "!!null"
+	case yaml.MappingNode, yaml.SequenceNode:
+		return len(node.Content) == 0
+	}
+	return false
+}
+
+// findYAMLFiles returns every .yaml and .yml file in the repository outside the
+// skipped directories
+func findYAMLFiles(index *core.FileIndex) ([]string, error) {
+	paths, _, err := index.Find([]string{".yaml", ".yml"})
+	if err != nil {
+		return nil, err
+	}
+
+	var files []string
+	for _, path := range paths {
+		if !inK8sSkipDir(index.Root(), path) {
+			files = append(files, path)
+		}
+	}
+	return files, nil
+}
+
+// inK8sSkipDir reports whether a file is below one of k8sSkipDirs
+func inK8sSkipDir(repoPath, path string) bool {
+	relDir, err := filepath.Rel(repoPath, filepath.Dir(path))
+	if err != nil {
+		return false
+	}
+	for _, dir := range strings.Split(filepath.ToSlash(relDir), "/") {
+		if k8sSkipDirs[dir] {
+			return true
+		}
+	}
+	return false
+}
+
+// SupportsRepository checks if the repository contains a YAML file with a
+// Kubernetes document
+func (c *K8sManifestChecker) SupportsRepository(repo core.Repository) bool {
+	files, err := findYAMLFiles(repo.FileIndex())
+	if err != nil {
+		return false
+	}
+	for _, file := range files {
+		if _, documents, err := scanManifestFile(file, file); err == nil && documents > 0 {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,126 @@
+package iac
+
+import (
+	"context"
+	"testing"
+
+	"github.com/codcod/repos/internal/core"
+)
+
+const k8sManifests = `apiVersion: v1
+kind: Service
+metadata:
+  name: web
+spec:
+  ports:
+    - port: 80
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+spec:
+  template:
+    spec:
+      containers:
+        - name: app
+          image: example.com:5000/team/web
+          resources:
+            limits:
+              memory: 256Mi
+            requests:
+              memory: 128Mi
+        - name: sidecar
+          image: envoyproxy/envoy:latest
+`
+
+const k8sHealthyDeployment = `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: api
+spec:
+  selector:
+    matchLabels:
+      app: api
+  template:
+    spec:
+      containers:
+        - name: api
+          image: ghcr.io/example/api:1.4.2
+          resources:
+            limits: {cpu: 500m, memory: 256Mi}
+            requests: {cpu: 100m, memory: 128Mi}
+`
+
+func TestK8sManifestChecker(t *testing.T) {
+	repoPath := writeTerraformFiles(t, map[string]string{
+		"deploy/web.yaml":                  k8sManifests,
+		"deploy/api.yml":                   k8sHealthyDeployment,
+		".github/workflows/ci.yml":         "name: ci\non: push\n",
+		"charts/web/Chart.yaml":            "apiVersion: v2\nname: web\nversion: 0.1.0\n",
+		"charts/web/templates/deploy.yaml": "apiVersion: apps/v1\nkind: Deployment\n{{- if .Values.enabled }}\n",
+	})
+
+	checker := NewK8sManifestChecker()
+	if !checker.SupportsRepository(core.Repository{Path: repoPath}) {
+		t.Fatal("Expected repository with Kubernetes manifests to be supported")
+	}
+
+	result, err := checker.Check(context.Background(), core.RepositoryContext{
+		Repository: core.Repository{Name: "web", Path: repoPath},
+	})
+	if err != nil {
+		t.Fatalf("Check failed: %v", err)
+	}
+
+	expected := []struct {
+		issueType string
+		line      int
+	}{
+		{"k8s_missing_field", 9},
+		{"k8s_latest_image", 18},
+		{"k8s_missing_resource_limits", 24},
+		{"k8s_missing_resource_requests", 24},
+		{"k8s_latest_image", 25},
+	}
+	if len(result.Issues) != len(expected) {
+		t.Fatalf("Expected %d issues, got %d: %+v", len(expected), len(result.Issues), result.Issues)
+	}
+	for i, want := range expected {
+		issue := result.Issues[i]
+		if issue.Type != want.issueType || issue.Location == nil || issue.Location.File != "deploy/web.yaml" ||
+			issue.Location.Line != want.line || issue.Context["document"] != 1 || issue.Context["kind"] != "Deployment" {
+			t.Errorf("Issue %d: expected %s at line %d of document 1, got %+v", i, want.issueType, want.line, issue)
+		}
+	}
+
+	if result.Metrics["manifest_files"] != 2 || result.Metrics["kubernetes_documents"] != 3 || result.Metrics["skipped_files"] != 1 {
+		t.Errorf("Unexpected metrics: %v", result.Metrics)
+	}
+}
+
+func TestK8sManifestChecker_IgnoresOtherYAML(t *testing.T) {
+	repoPath := writeTerraformFiles(t, map[string]string{
+		"docker-compose.yml": "services:\n  web:\n    image: nginx:latest\n",
+	})
+	if NewK8sManifestChecker().SupportsRepository(core.Repository{Path: repoPath}) {
+		t.Error("Expected repository without Kubernetes manifests to be unsupported")
+	}
+}
+
+func TestUsesLatestImage(t *testing.T) {
+	tests := map[string]bool{
+		"nginx":                         true,
+		"nginx:latest":                  true,
+		"registry:5000/team/app":        true,
+		"nginx:1.25":                    false,
+		"registry:5000/team/app:2.0":    false,
+		"nginx@sha256:0123456789abcdef": false,
+		"ghcr.io/example/api:latest":    true,
+	}
+	for image, expected := range tests {
+		if got := usesLatestImage(image); got != expected {
+			t.Errorf("%q: expected %v, got %v", image, expected, got)
+		}
+	}
+}
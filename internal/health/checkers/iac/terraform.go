@@ -0,0 +1,368 @@
+// Package iac provides checkers for infrastructure-as-code definitions
+package iac
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/codcod/repos/internal/core"
+	"github.com/codcod/repos/internal/health/checkers/base"
+)
+
+var (
+	// providerBlockPattern matches the opening of a provider block, e.g. provider "aws" {
+	providerBlockPattern = regexp.MustCompile(`^provider\s+"([^"]+)"\s*\{`)
+
+	// attributePattern matches an attribute assignment, capturing its name and value
+	attributePattern = regexp.MustCompile(`^([A-Za-z0-9_-]+)\s*=\s*(.*)$`)
+
+	// interpolationOnlyPattern matches a string that is nothing but one interpolation,
+	// e.g. "${var.region}", which Terraform 0.12 made unnecessary
+	interpolationOnlyPattern = regexp.MustCompile(`"\$\{([^"{}]+)\}"`)
+
+	// versionPattern matches the version of a single version constraint
+	versionPattern = regexp.MustCompile(`^(=|!=|>=|<=|>|<|~>)?\s*v?(\d+)(?:\.(\d+))?`)
+
+	// terraformSkipDirs are directories that never hold the repository's own configuration
+	terraformSkipDirs = map[string]bool{".terraform": true, ".git": true, "node_modules": true, "vendor": true}
+)
+
+// terraformFinding is one use of deprecated Terraform syntax
+type terraformFinding struct {
+	Type        string
+	File        string
+	Line        int
+	Message     string
+	Replacement string
+}
+
+// providerUse is a provider block and where it first appears in a module
+type providerUse struct {
+	File string
+	Line int
+}
+
+// terraformModule collects what the files of one directory declare
+type terraformModule struct {
+	providers         map[string]providerUse
+	requiredProviders map[string]bool
+}
+
+// TerraformChecker flags Terraform syntax that was deprecated by Terraform 0.12
+// and 0.13: providers without required_providers, legacy version constraints and
+// interpolation-only strings
+type TerraformChecker struct {
+	*base.BaseChecker
+}
+
+// NewTerraformChecker creates a new Terraform deprecated syntax checker
+func NewTerraformChecker() *TerraformChecker {
+	config := core.CheckerConfig{
+		Enabled:    true,
+		Severity:   "medium",
+		Timeout:    30 * time.Second,
+		Categories: []string{"quality"},
+	}
+
+	return &TerraformChecker{
+		BaseChecker: base.NewBaseChecker(
+			"terraform-deprecated",
+			"Terraform Deprecated Syntax",
+			"quality",
+			config,
+		),
+	}
+}
+
+// Metadata describes what the checker verifies
+func (*TerraformChecker) Metadata() core.CheckerMetadata {
+	return core.CheckerMetadata{
+		Description: "Flags deprecated Terraform syntax in .tf files: provider blocks without a required_providers entry, " +
+			"legacy string entries in required_providers, required_version constraints that allow Terraform below 0.13, " +
+			"and interpolation-only strings such as \"${var.name}\". Each finding names the file, line and replacement.",
+	}
+}
+
+// Check performs the Terraform deprecated syntax check
+func (c *TerraformChecker) Check(ctx context.Context, repoCtx core.RepositoryContext) (core.CheckResult, error) {
+	return c.Execute(ctx, repoCtx, func() (core.CheckResult, error) {
+		return c.checkTerraform(ctx, repoCtx)
+	})
+}
+
+// checkTerraform performs the actual Terraform check
+func (c *TerraformChecker) checkTerraform(ctx context.Context, repoCtx core.RepositoryContext) (core.CheckResult, error) {
+	builder := base.NewResultBuilder(c.ID(), c.Name(), c.Category())
+	repoPath := repoCtx.Repository.Path
+
+	files, err := findTerraformFiles(repoCtx.Repository.FileIndex())
+	if err != nil {
+		return core.CheckResult{}, err
+	}
+
+	// Terraform modules are directories, and required_providers applies to the whole module
+	modules := make(map[string]*terraformModule)
+	var findings []terraformFinding
+	for _, file := range files {
+		dir := filepath.Dir(file)
+		module, exists := modules[dir]
+		if !exists {
+			module = &terraformModule{providers: make(map[string]providerUse), requiredProviders: make(map[string]bool)}
+			modules[dir] = module
+		}
+
+		fileFindings, err := scanTerraformFile(repoPath, file, module)
+		if err != nil {
+			continue // Skip files that cannot be read
+		}
+		findings = append(findings, fileFindings...)
+	}
+	for _, module := range modules {
+		findings = append(findings, module.undeclaredProviders()...)
+	}
+
+	sort.Slice(findings, func(i, j int) bool {
+		if findings[i].File != findings[j].File {
+			return findings[i].File < findings[j].File
+		}
+		return findings[i].Line < findings[j].Line
+	})
+
+	builder.AddMetric("terraform_files", len(files))
+	builder.AddMetric("terraform_modules", len(modules))
+	builder.AddMetric("deprecated_syntax", len(findings))
+
+	for _, finding := range findings {
+		issue := base.NewIssueWithLocation(finding.Type, core.SeverityMedium, finding.Message, finding.File, finding.Line, 0)
+		issue.Suggestion = finding.Replacement
+		builder.AddIssue(issue)
+	}
+
+	if len(findings) == 0 {
+		builder.WithStatus(core.StatusHealthy)
+		builder.WithScore(100, 100)
+		return builder.Build(), nil
+	}
+	builder.WithStatus(core.StatusWarning)
+	builder.WithScore(max(100-5*len(findings), 40), 100)
+	return builder.Build(), nil
+}
+
+// undeclaredProviders reports providers configured without a required_providers entry
+func (m *terraformModule) undeclaredProviders() []terraformFinding {
+	var findings []terraformFinding
+	for name, use := range m.providers {
+		if m.requiredProviders[name] {
+			continue
+		}
+		findings = append(findings, terraformFinding{
+			Type:    "terraform_provider_not_required",
+			File:    use.File,
+			Line:    use.Line,
+			Message: fmt.Sprintf("provider %q is configured without a required_providers entry", name),
+			Replacement: fmt.Sprintf("terraform { required_providers { %s = { source = \"hashicorp/%s\", version = \"...\" } } }",
+				name, name),
+		})
+	}
+	return findings
+}
+
+// scanTerraformFile records the module's providers and returns the deprecated
+// syntax found line by line
+//
+//nolint:gocyclo // Line-based block tracking requires many branches
+func scanTerraformFile(repoPath, path string, module *terraformModule) ([]terraformFinding, error) {
+	file, err := os.Open(path) //nolint:gosec // File path is from repository analysis
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = file.Close() }()
+
+	relPath, err := filepath.Rel(repoPath, path)
+	if err != nil {
+		relPath = path
+	}
+	relPath = filepath.ToSlash(relPath)
+
+	var findings []terraformFinding
+	depth := 0
+	inTerraform, inRequiredProviders, inBlockComment := false, false, false
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+		line := strings.TrimSpace(scanner.Text())
+
+		if inBlockComment {
+			if strings.Contains(line, "*/") {
+				inBlockComment = false
+			}
+			continue
+		}
+		if strings.HasPrefix(line, "/*") {
+			inBlockComment = !strings.Contains(line, "*/")
+			continue
+		}
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "//") {
+			continue
+		}
+
+		switch {
+		case depth == 0 && strings.HasPrefix(line, "terraform") && strings.HasSuffix(line, "{"):
+			inTerraform = true
+		case depth == 0:
+			if match := providerBlockPattern.FindStringSubmatch(line); match != nil {
+				if _, seen := module.providers[match[1]]; !seen {
+					module.providers[match[1]] = providerUse{File: relPath, Line: lineNum}
+				}
+			}
+		case inTerraform && depth == 1 && strings.HasPrefix(line, "required_providers"):
+			inRequiredProviders = true
+		case inTerraform && depth == 1:
+			if match := attributePattern.FindStringSubmatch(line); match != nil && match[1] == "required_version" {
+				if constraint, err := strconv.Unquote(match[2]); err == nil && allowsPre013(constraint) {
+					findings = append(findings, terraformFinding{
+						Type:        "terraform_legacy_required_version",
+						File:        relPath,
+						Line:        lineNum,
+						Message:     fmt.Sprintf("required_version %q allows Terraform versions before 0.13", constraint),
+						Replacement: `required_version = ">= 0.13"`,
+					})
+				}
+			}
+		case inRequiredProviders && depth == 2:
+			if match := attributePattern.FindStringSubmatch(line); match != nil {
+				module.requiredProviders[match[1]] = true
+				if version, err := strconv.Unquote(match[2]); err == nil {
+					findings = append(findings, terraformFinding{
+						Type:        "terraform_legacy_provider_requirement",
+						File:        relPath,
+						Line:        lineNum,
+						Message:     fmt.Sprintf("required_providers entry %q uses the legacy version string syntax", match[1]),
+						Replacement: fmt.Sprintf("%s = { source = \"hashicorp/%s\", version = %q }", match[1], match[1], version),
+					})
+				}
+			}
+		}
+
+		for _, match := range interpolationOnlyPattern.FindAllStringSubmatch(line, -1) {
+			findings = append(findings, terraformFinding{
+				Type:        "terraform_interpolation_only",
+				File:        relPath,
+				Line:        lineNum,
+				Message:     fmt.Sprintf("interpolation-only expression %s is deprecated", match[0]),
+				Replacement: strings.TrimSpace(match[1]),
+			})
+		}
+
+		depth += braceDelta(line)
+		if depth < 0 {
+			depth = 0
+		}
+		if depth <= 1 {
+			inRequiredProviders = false
+		}
+		if depth == 0 {
+			inTerraform = false
+		}
+	}
+
+	return findings, scanner.Err()
+}
+
+// braceDelta returns the number of blocks a line opens minus the number it
+// closes, ignoring braces in strings and comments
+func braceDelta(line string) int {
+	delta := 0
+	inString := false
+	for i := 0; i < len(line); i++ {
+		ch := line[i]
+		if inString {
+			if ch == '\\' {
+				i++ // Skip escaped character
+			} else if ch == '"' {
+				inString = false
+			}
+			continue
+		}
+
+		switch {
+		case ch == '"':
+			inString = true
+		case ch == '#' || strings.HasPrefix(line[i:], "//"):
+			return delta
+		case ch == '{':
+			delta++
+		case ch == '}':
+			delta--
+		}
+	}
+	return delta
+}
+
+// allowsPre013 reports whether a version constraint's lower bound is below 0.13
+func allowsPre013(constraint string) bool {
+	for _, part := range strings.Split(constraint, ",") {
+		match := versionPattern.FindStringSubmatch(strings.TrimSpace(part))
+		if match == nil {
+			continue
+		}
+		switch match[1] {
+		case "<", "<=", "!=":
+			continue // Upper bounds and exclusions don't allow older versions
+		}
+		major, _ := strconv.Atoi(match[2])
+		minor, _ := strconv.Atoi(match[3])
+		if major == 0 && minor < 13 {
+			return true
+		}
+	}
+	return false
+}
+
+// findTerraformFiles returns every .tf file in the repository outside the
+// skipped directories
+func findTerraformFiles(index *core.FileIndex) ([]string, error) {
+	paths, _, err := index.Find([]string{".tf"})
+	if err != nil {
+		return nil, err
+	}
+
+	var files []string
+	for _, path := range paths {
+		if !inTerraformSkipDir(index.Root(), path) {
+			files = append(files, path)
+		}
+	}
+	return files, nil
+}
+
+// inTerraformSkipDir reports whether a file is below one of terraformSkipDirs
+func inTerraformSkipDir(repoPath, path string) bool {
+	relDir, err := filepath.Rel(repoPath, filepath.Dir(path))
+	if err != nil {
+		return false
+	}
+	for _, dir := range strings.Split(filepath.ToSlash(relDir), "/") {
+		if terraformSkipDirs[dir] {
+			return true
+		}
+	}
+	return false
+}
+
+// SupportsRepository checks if the repository contains Terraform configuration
+func (c *TerraformChecker) SupportsRepository(repo core.Repository) bool {
+	files, err := findTerraformFiles(repo.FileIndex())
+	return err == nil && len(files) > 0
+}
@@ -0,0 +1,144 @@
+package iac
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/codcod/repos/internal/core"
+)
+
+const legacyMainTF = `terraform {
+  required_version = "~> 0.12.29"
+
+  required_providers {
+    aws = "~> 2.70"
+  }
+}
+
+# provider "ignored" {}
+provider "aws" {
+  region = "${var.region}"
+}
+
+provider "google" {
+  project = "${var.project}-prod"
+}
+
+resource "aws_s3_bucket" "logs" {
+  bucket = "${local.bucket}"
+  tags   = { Name = "logs" }
+}
+`
+
+const modernMainTF = `terraform {
+  required_version = ">= 1.3.0, < 2.0.0"
+
+  required_providers {
+    aws = {
+      source  = "hashicorp/aws"
+      version = "~> 5.0"
+    }
+  }
+}
+
+provider "aws" {
+  region = var.region
+}
+`
+
+func writeTerraformFiles(t *testing.T, files map[string]string) string {
+	t.Helper()
+	root := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(root, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return root
+}
+
+func TestTerraformChecker(t *testing.T) {
+	repoPath := writeTerraformFiles(t, map[string]string{
+		"infra/main.tf":                     legacyMainTF,
+		"modern/main.tf":                    modernMainTF,
+		"infra/.terraform/modules/x/old.tf": `provider "null" {}`,
+	})
+
+	checker := NewTerraformChecker()
+	if !checker.SupportsRepository(core.Repository{Path: repoPath}) {
+		t.Fatal("Expected repository with .tf files to be supported")
+	}
+
+	result, err := checker.Check(context.Background(), core.RepositoryContext{
+		Repository: core.Repository{Name: "infra", Path: repoPath},
+	})
+	if err != nil {
+		t.Fatalf("Check failed: %v", err)
+	}
+
+	expected := []struct {
+		issueType   string
+		line        int
+		replacement string
+	}{
+		{"terraform_legacy_required_version", 2, `required_version = ">= 0.13"`},
+		{"terraform_legacy_provider_requirement", 5, `aws = { source = "hashicorp/aws", version = "~> 2.70" }`},
+		{"terraform_interpolation_only", 11, "var.region"},
+		{"terraform_provider_not_required", 14, `terraform { required_providers { google = { source = "hashicorp/google", version = "..." } } }`},
+		{"terraform_interpolation_only", 19, "local.bucket"},
+	}
+	if len(result.Issues) != len(expected) {
+		t.Fatalf("Expected %d issues, got %d: %+v", len(expected), len(result.Issues), result.Issues)
+	}
+	for i, want := range expected {
+		issue := result.Issues[i]
+		if issue.Type != want.issueType || issue.Location == nil || issue.Location.File != "infra/main.tf" ||
+			issue.Location.Line != want.line || issue.Suggestion != want.replacement {
+			t.Errorf("Issue %d: expected %s at line %d with %q, got %+v", i, want.issueType, want.line, want.replacement, issue)
+		}
+	}
+
+	if result.Status != core.StatusWarning || result.Metrics["terraform_files"] != 2 {
+		t.Errorf("Unexpected result: status %s, metrics %v", result.Status, result.Metrics)
+	}
+}
+
+func TestTerraformChecker_RequiredProvidersInOtherFile(t *testing.T) {
+	repoPath := writeTerraformFiles(t, map[string]string{
+		"versions.tf":  "terraform {\n  required_providers {\n    aws = {\n      source = \"hashicorp/aws\"\n    }\n  }\n}\n",
+		"providers.tf": "provider \"aws\" {\n  region = var.region\n}\n",
+	})
+
+	result, err := NewTerraformChecker().Check(context.Background(), core.RepositoryContext{
+		Repository: core.Repository{Name: "infra", Path: repoPath},
+	})
+	if err != nil {
+		t.Fatalf("Check failed: %v", err)
+	}
+	if result.Status != core.StatusHealthy || len(result.Issues) != 0 {
+		t.Errorf("Expected no findings, got %+v", result.Issues)
+	}
+}
+
+func TestAllowsPre013(t *testing.T) {
+	tests := map[string]bool{
+		"0.12.29":            true,
+		"~> 0.12":            true,
+		">= 0.11, < 0.14":    true,
+		">= 0.13":            false,
+		"~> 1.5":             false,
+		"< 0.12":             false,
+		">= 1.0.0, != 1.2.0": false,
+	}
+	for constraint, expected := range tests {
+		if got := allowsPre013(constraint); got != expected {
+			t.Errorf("%q: expected %v, got %v", constraint, expected, got)
+		}
+	}
+}
@@ -0,0 +1,226 @@
+package quality
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/codcod/repos/internal/core"
+	"github.com/codcod/repos/internal/health/checkers/base"
+	"github.com/codcod/repos/internal/platform/commands"
+)
+
+// maxBuildErrorLines is the number of compiler output lines reported per failed build
+const maxBuildErrorLines = 5
+
+// BuildCommand describes how to compile the projects of one language. A
+// language is built when its manifest exists in the repository root.
+type BuildCommand struct {
+	Language string   `yaml:"language" json:"language"`
+	Manifest string   `yaml:"manifest" json:"manifest"`
+	Command  []string `yaml:"command" json:"command"`
+	// TestCommand compiles the tests without running them; it runs when
+	// compile_tests is set
+	TestCommand []string `yaml:"test_command,omitempty" json:"test_command,omitempty"`
+}
+
+// DefaultBuildCommands are the build commands used without configuration
+var DefaultBuildCommands = []BuildCommand{
+	{Language: "go", Manifest: "go.mod", Command: []string{"go", "build", "./..."}, TestCommand: []string{"go", "test", "-run=^$", "./..."}},
+}
+
+// BuildChecker checks that the repository compiles by running the build command
+// of each language found, and reports compile errors as critical issues
+type BuildChecker struct {
+	*base.BaseChecker
+	executor commands.CommandExecutor
+}
+
+// NewBuildChecker creates a new build checker
+func NewBuildChecker(executor commands.CommandExecutor) *BuildChecker {
+	config := core.CheckerConfig{
+		Enabled:    true,
+		Severity:   "critical",
+		Timeout:    5 * time.Minute,
+		Categories: []string{"quality"},
+	}
+
+	return &BuildChecker{
+		BaseChecker: base.NewBaseChecker(
+			"build",
+			"Build",
+			"quality",
+			config,
+		),
+		executor: executor,
+	}
+}
+
+// Metadata describes what the checker verifies
+func (*BuildChecker) Metadata() core.CheckerMetadata {
+	return core.CheckerMetadata{
+		Description: "Compiles the repository with the build command of each language whose manifest is present " +
+			"('go build ./...' for Go modules) and reports a failed build as a critical issue with the first compiler errors. " +
+			"The build is bounded by the checker's timeout.",
+		Options: []core.CheckerOption{
+			{Name: "compile_tests", Default: "false", Description: "Also compile the tests, e.g. with 'go test -run=^$ ./...'"},
+			{Name: "commands", Default: []BuildCommand{}, Description: "Build commands as {language, manifest, command, test_command}; an entry for a built-in language replaces it"},
+		},
+		RequiredTools: []string{"go"},
+	}
+}
+
+// Check performs the build check
+func (c *BuildChecker) Check(ctx context.Context, repoCtx core.RepositoryContext) (core.CheckResult, error) {
+	return c.Execute(ctx, repoCtx, func() (core.CheckResult, error) {
+		return c.checkBuild(ctx, repoCtx)
+	})
+}
+
+// buildCommands returns the default build commands with those configured in
+// the commands option applied: an entry for a known language replaces it,
+// others are added
+func (c *BuildChecker) buildCommands(repoCtx core.RepositoryContext) ([]BuildCommand, error) {
+	var configured []BuildCommand
+	if _, err := c.DecodeOption(repoCtx, "commands", &configured); err != nil {
+		return nil, err
+	}
+
+	builds := append([]BuildCommand{}, DefaultBuildCommands...)
+	for i, build := range configured {
+		if build.Language == "" || build.Manifest == "" || len(build.Command) == 0 {
+			return nil, fmt.Errorf("option commands: entry %d needs language, manifest and command", i)
+		}
+		replaced := false
+		for j := range builds {
+			if builds[j].Language == build.Language {
+				builds[j] = build
+				replaced = true
+			}
+		}
+		if !replaced {
+			builds = append(builds, build)
+		}
+	}
+	return builds, nil
+}
+
+// checkBuild performs the actual build check
+func (c *BuildChecker) checkBuild(ctx context.Context, repoCtx core.RepositoryContext) (core.CheckResult, error) {
+	builder := base.NewResultBuilder(c.ID(), c.Name(), c.Category())
+	repoPath := repoCtx.Repository.Path
+
+	builds, err := c.buildCommands(repoCtx)
+	if err != nil {
+		return core.CheckResult{}, err
+	}
+
+	// Builds can take long on large projects, so bound them by the checker timeout
+	ctx, cancel := context.WithTimeout(ctx, c.Timeout(repoCtx))
+	defer cancel()
+
+	compileTests := c.BoolOption(repoCtx, "compile_tests", false)
+	var built []string
+	failed := 0
+	for _, build := range builds {
+		if _, err := os.Stat(filepath.Join(repoPath, build.Manifest)); err != nil {
+			continue
+		}
+		if !commands.CommandExists(c.executor, build.Command[0]) {
+			builder.AddWarning(core.Warning{
+				Type:    "tool_not_available",
+				Message: fmt.Sprintf("%s is not installed; the %s build was not checked", build.Command[0], build.Language),
+			})
+			continue
+		}
+
+		for _, step := range build.steps(compileTests) {
+			result := c.executor.ExecuteInDir(ctx, repoPath, step[0], step[1:]...)
+			if ctx.Err() != nil {
+				return core.CheckResult{}, fmt.Errorf("%s did not finish: %w", strings.Join(step, " "), ctx.Err())
+			}
+			if result.Error == nil {
+				continue
+			}
+
+			failed++
+			builder.AddIssue(buildFailedIssue(build, step, result))
+			// Tests cannot compile when the build does not
+			break
+		}
+		built = append(built, build.Language)
+	}
+
+	builder.AddMetric("languages_built", strings.Join(built, ", "))
+	builder.AddMetric("failed_builds", failed)
+
+	if failed > 0 {
+		builder.WithStatus(core.StatusCritical)
+		builder.WithScore(0, 100)
+	} else {
+		builder.WithStatus(core.StatusHealthy)
+		builder.WithScore(100, 100)
+	}
+	return builder.Build(), nil
+}
+
+// steps returns the commands to run for a build: the build itself and, when
+// tests are compiled too, the test compilation
+func (b BuildCommand) steps(compileTests bool) [][]string {
+	steps := [][]string{b.Command}
+	if compileTests && len(b.TestCommand) > 0 {
+		steps = append(steps, b.TestCommand)
+	}
+	return steps
+}
+
+// buildFailedIssue reports a failed build step with the first lines of its errors
+func buildFailedIssue(build BuildCommand, step []string, result commands.CommandResult) core.Issue {
+	command := strings.Join(step, " ")
+	errorLines := buildErrorLines(result)
+	message := fmt.Sprintf("%s failed: %v", command, result.Error)
+	if len(errorLines) > 0 {
+		message = fmt.Sprintf("%s failed: %s", command, strings.Join(errorLines, "; "))
+	}
+	issue := base.NewIssueWithSuggestion(
+		"build_failed",
+		core.SeverityCritical,
+		message,
+		fmt.Sprintf("Run '%s' locally and fix the compile errors", command),
+	)
+	issue.Context["language"] = build.Language
+	issue.Context["command"] = command
+	issue.Context["errors"] = errorLines
+	return issue
+}
+
+// buildErrorLines returns the first lines of a failed build's output, skipping
+// blank lines and the "# package" headers the Go toolchain prints
+func buildErrorLines(result commands.CommandResult) []string {
+	output := result.Stderr
+	if strings.TrimSpace(output) == "" {
+		output = result.Stdout
+	}
+
+	var lines []string
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "# ") {
+			continue
+		}
+		if len(lines) == maxBuildErrorLines {
+			break
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// SupportsRepository reports true, since build commands can be configured for
+// any language; repositories without a known manifest are reported healthy
+func (c *BuildChecker) SupportsRepository(repo core.Repository) bool {
+	return true
+}
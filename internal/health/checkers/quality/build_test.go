@@ -0,0 +1,121 @@
+package quality
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/codcod/repos/internal/core"
+	"github.com/codcod/repos/internal/platform/commands"
+)
+
+// buildTestConfig provides build checker options
+type buildTestConfig struct {
+	options map[string]interface{}
+}
+
+func (c *buildTestConfig) GetCheckerConfig(string) (core.CheckerConfig, bool) {
+	return core.CheckerConfig{Enabled: true, Options: c.options}, true
+}
+
+func (c *buildTestConfig) GetAnalyzerConfig(string) (core.AnalyzerConfig, bool) {
+	return core.AnalyzerConfig{}, false
+}
+
+func (c *buildTestConfig) GetReporterConfig(string) (core.ReporterConfig, bool) {
+	return core.ReporterConfig{}, false
+}
+
+func (c *buildTestConfig) GetEngineConfig() core.EngineConfig {
+	return core.EngineConfig{Timeout: time.Minute}
+}
+
+func TestBuildChecker(t *testing.T) {
+	repoPath := t.TempDir()
+	for _, name := range []string{"go.mod", "Cargo.toml"} {
+		if err := os.WriteFile(filepath.Join(repoPath, name), nil, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	executor := commands.NewMockCommandExecutor()
+	executor.SetResponse("go build ./...", commands.CommandResult{
+		ExitCode: 1,
+		Stderr:   "# example.com/app/pkg\npkg/x.go:3:2: undefined: missing\npkg/x.go:4:2: declared and not used: y\n",
+		Error:    errors.New("exit status 1"),
+	})
+	executor.SetResponse("cargo check", commands.CommandResult{})
+
+	repoCtx := core.RepositoryContext{
+		Repository: core.Repository{Name: "app", Path: repoPath},
+		Config: &buildTestConfig{options: map[string]interface{}{
+			"compile_tests": true,
+			"commands": []interface{}{
+				map[string]interface{}{"language": "rust", "manifest": "Cargo.toml", "command": []interface{}{"cargo", "check"}},
+			},
+		}},
+	}
+	result, err := NewBuildChecker(executor).Check(context.Background(), repoCtx)
+	if err != nil {
+		t.Fatalf("Check failed: %v", err)
+	}
+
+	if result.Status != core.StatusCritical || len(result.Issues) != 1 {
+		t.Fatalf("Expected one critical issue, got %s with %+v", result.Status, result.Issues)
+	}
+	issue := result.Issues[0]
+	if issue.Severity != core.SeverityCritical || issue.Context["command"] != "go build ./..." ||
+		!strings.Contains(issue.Message, "pkg/x.go:3:2: undefined: missing; pkg/x.go:4:2") {
+		t.Errorf("Unexpected issue: %+v", issue)
+	}
+	if result.Metrics["languages_built"] != "go, rust" || result.Metrics["failed_builds"] != 1 {
+		t.Errorf("Unexpected metrics: %v", result.Metrics)
+	}
+
+	// Tests are not compiled when the build fails
+	for _, call := range executor.GetCalls() {
+		if call.Command == "go" && len(call.Args) > 0 && call.Args[0] == "test" {
+			t.Errorf("Expected tests not to be compiled after a failed build, got %v", call.Args)
+		}
+	}
+}
+
+func TestBuildChecker_CompilesTests(t *testing.T) {
+	repoPath := t.TempDir()
+	if err := os.WriteFile(filepath.Join(repoPath, "go.mod"), nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	executor := commands.NewMockCommandExecutor()
+	executor.SetResponse("go build ./...", commands.CommandResult{})
+	executor.SetResponse("go test -run=^$ ./...", commands.CommandResult{
+		ExitCode: 1,
+		Stderr:   "x_test.go:9:1: syntax error\n",
+		Error:    errors.New("exit status 1"),
+	})
+
+	repoCtx := core.RepositoryContext{
+		Repository: core.Repository{Name: "app", Path: repoPath},
+		Config:     &buildTestConfig{options: map[string]interface{}{"compile_tests": true}},
+	}
+	result, err := NewBuildChecker(executor).Check(context.Background(), repoCtx)
+	if err != nil {
+		t.Fatalf("Check failed: %v", err)
+	}
+	if len(result.Issues) != 1 || result.Issues[0].Context["command"] != "go test -run=^$ ./..." {
+		t.Errorf("Expected the test compilation to fail, got %+v", result.Issues)
+	}
+
+	repoCtx.Config = nil
+	result, err = NewBuildChecker(executor).Check(context.Background(), repoCtx)
+	if err != nil {
+		t.Fatalf("Check failed: %v", err)
+	}
+	if result.Status != core.StatusHealthy || len(result.Issues) != 0 {
+		t.Errorf("Expected a healthy build without compile_tests, got %s with %+v", result.Status, result.Issues)
+	}
+}
@@ -0,0 +1,228 @@
+package quality
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/codcod/repos/internal/core"
+	"github.com/codcod/repos/internal/health/checkers/base"
+)
+
+// DefaultMaxComplexity is the highest cyclomatic complexity a function may have
+// before it is reported, matching the complexity report's default threshold
+const DefaultMaxComplexity = 10
+
+// ComplexityChecker reports functions whose cyclomatic complexity exceeds a
+// limit, each located at the function so that SARIF and JSON consumers can link
+// to it, and optionally files whose functions together exceed a limit. It uses
+// the functions found by the repository's code analysis.
+type ComplexityChecker struct {
+	*base.BaseChecker
+}
+
+// NewComplexityChecker creates a new cyclomatic complexity checker
+func NewComplexityChecker() *ComplexityChecker {
+	config := core.CheckerConfig{
+		Enabled:    true,
+		Severity:   "low",
+		Timeout:    30 * time.Second,
+		Categories: []string{"quality"},
+	}
+
+	return &ComplexityChecker{
+		BaseChecker: base.NewBaseChecker(
+			"cyclomatic-complexity",
+			"Cyclomatic Complexity",
+			"quality",
+			config,
+		),
+	}
+}
+
+// Metadata describes what the checker verifies
+func (*ComplexityChecker) Metadata() core.CheckerMetadata {
+	return core.CheckerMetadata{
+		Description: "Reports functions with a cyclomatic complexity above max_complexity, most complex first, " +
+			"with the file and line span of each function, using the functions found by code analysis. " +
+			"With max_file_complexity it also reports files whose functions' complexities sum to more than the limit. " +
+			"Generated files are left out of the analysis unless the analyzer's include_generated is set.",
+		Options: []core.CheckerOption{
+			{Name: "max_complexity", Default: DefaultMaxComplexity, Description: "Highest cyclomatic complexity a function may have"},
+			{Name: "max_file_complexity", Default: 0, Description: "Highest total cyclomatic complexity of a file's functions; 0 disables the file check"},
+		},
+	}
+}
+
+// Check performs the cyclomatic complexity check
+func (c *ComplexityChecker) Check(ctx context.Context, repoCtx core.RepositoryContext) (core.CheckResult, error) {
+	return c.Execute(ctx, repoCtx, func() (core.CheckResult, error) {
+		return c.checkComplexity(repoCtx), nil
+	})
+}
+
+// checkComplexity performs the actual cyclomatic complexity check
+func (c *ComplexityChecker) checkComplexity(repoCtx core.RepositoryContext) core.CheckResult {
+	builder := base.NewResultBuilder(c.ID(), c.Name(), c.Category())
+	maxComplexity := c.IntOption(repoCtx, "max_complexity", DefaultMaxComplexity)
+	maxFileComplexity := c.IntOption(repoCtx, "max_file_complexity", 0)
+
+	scan := scanComplexity(repoCtx, maxComplexity)
+	complex := scan.complex
+	complexFiles := complexFilesOver(scan.files, maxFileComplexity)
+
+	builder.AddMetric("functions_checked", scan.checked)
+	builder.AddMetric("max_complexity", scan.highest)
+	builder.AddMetric("complex_functions", len(complex))
+	if maxFileComplexity > 0 {
+		builder.AddMetric("complex_files", len(complexFiles))
+	}
+	if repoCtx.Analysis != nil {
+		if skipped, ok := repoCtx.Analysis.Metrics["generated_files_skipped"].(int); ok {
+			builder.AddMetric("generated_files_skipped", skipped)
+		}
+	}
+
+	if len(complex) == 0 && len(complexFiles) == 0 {
+		builder.WithStatus(core.StatusHealthy)
+		builder.WithScore(100, 100)
+		return builder.Build()
+	}
+
+	// Like long functions, complex ones are a maintenance cost, so the score never drops below half
+	builder.WithStatus(core.StatusWarning)
+	builder.WithScore(max(100-(len(complex)+len(complexFiles))*5, 50), 100)
+
+	sort.SliceStable(complex, func(i, j int) bool { return complex[i].Complexity > complex[j].Complexity })
+	for _, fn := range complex {
+		builder.AddIssue(complexFunctionIssue(fn, repoCtx.Repository.Path, maxComplexity))
+	}
+	for _, file := range complexFiles {
+		builder.AddIssue(complexFileIssue(file, repoCtx.Repository.Path, maxFileComplexity))
+	}
+
+	return builder.Build()
+}
+
+// complexityScan is the complexity of the analyzed functions of a repository
+type complexityScan struct {
+	complex []core.FunctionInfo // Functions over the limit
+	files   map[string]*fileComplexity
+	checked int
+	highest int
+}
+
+// scanComplexity sums the complexity of the repository's analyzed functions
+// per file and finds those over maxComplexity
+func scanComplexity(repoCtx core.RepositoryContext, maxComplexity int) complexityScan {
+	scan := complexityScan{files: make(map[string]*fileComplexity)}
+	if repoCtx.Analysis == nil {
+		return scan
+	}
+	for _, fn := range repoCtx.Analysis.Functions {
+		// Sub-projects of a monorepo share the repository's analysis
+		if !withinPath(fn.File, repoCtx.Repository.Path) {
+			continue
+		}
+		scan.checked++
+		scan.highest = max(scan.highest, fn.Complexity)
+		if fn.Complexity > maxComplexity {
+			scan.complex = append(scan.complex, fn)
+		}
+		if scan.files[fn.File] == nil {
+			scan.files[fn.File] = &fileComplexity{file: fn.File}
+		}
+		scan.files[fn.File].complexity += fn.Complexity
+		scan.files[fn.File].functions++
+	}
+	return scan
+}
+
+// complexFilesOver returns the files whose total complexity is over the limit,
+// most complex first, or none when the limit is not set
+func complexFilesOver(files map[string]*fileComplexity, maxFileComplexity int) []*fileComplexity {
+	if maxFileComplexity <= 0 {
+		return nil
+	}
+	var complexFiles []*fileComplexity
+	for _, file := range files {
+		if file.complexity > maxFileComplexity {
+			complexFiles = append(complexFiles, file)
+		}
+	}
+	sort.Slice(complexFiles, func(i, j int) bool {
+		if complexFiles[i].complexity != complexFiles[j].complexity {
+			return complexFiles[i].complexity > complexFiles[j].complexity
+		}
+		return complexFiles[i].file < complexFiles[j].file
+	})
+	return complexFiles
+}
+
+// complexFunctionIssue reports a function over the complexity limit; twice
+// the limit is a medium severity
+func complexFunctionIssue(fn core.FunctionInfo, repoPath string, maxComplexity int) core.Issue {
+	severity := core.SeverityLow
+	if fn.Complexity > 2*maxComplexity {
+		severity = core.SeverityMedium
+	}
+	relPath, err := filepath.Rel(repoPath, fn.File)
+	if err != nil {
+		relPath = fn.File
+	}
+	issue := base.NewIssueWithLocation(
+		"high_complexity",
+		severity,
+		fmt.Sprintf("Function '%s' has cyclomatic complexity %d (limit %d)", fn.Name, fn.Complexity, maxComplexity),
+		relPath,
+		fn.Line,
+		0,
+	)
+	if fn.EndLine >= fn.Line {
+		issue.Location.EndLine = fn.EndLine
+	}
+	issue.Suggestion = "Extract branches into smaller functions or simplify the control flow"
+	issue.Context["function"] = fn.Name
+	issue.Context["complexity"] = fn.Complexity
+	return issue
+}
+
+// complexFileIssue reports a file over the total complexity limit; twice the
+// limit is a medium severity
+func complexFileIssue(file *fileComplexity, repoPath string, maxFileComplexity int) core.Issue {
+	severity := core.SeverityLow
+	if file.complexity > 2*maxFileComplexity {
+		severity = core.SeverityMedium
+	}
+	relPath, err := filepath.Rel(repoPath, file.file)
+	if err != nil {
+		relPath = file.file
+	}
+	issue := base.NewIssueWithLocation(
+		"high_file_complexity",
+		severity,
+		fmt.Sprintf("File '%s' has total cyclomatic complexity %d across %d functions (limit %d)",
+			relPath, file.complexity, file.functions, maxFileComplexity),
+		relPath,
+		0,
+		0,
+	)
+	issue.Suggestion = "Split the file by responsibility or simplify its functions"
+	issue.Context["complexity"] = file.complexity
+	issue.Context["functions"] = file.functions
+	return issue
+}
+
+// fileComplexity is the summed cyclomatic complexity of a file's functions
+type fileComplexity struct {
+	file       string
+	complexity int
+	functions  int
+}
+
+// SupportsRepository reports true; repositories without analyzed functions pass
+func (c *ComplexityChecker) SupportsRepository(repo core.Repository) bool {
+	return true
+}
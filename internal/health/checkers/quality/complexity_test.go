@@ -0,0 +1,86 @@
+package quality
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/codcod/repos/internal/core"
+)
+
+func TestComplexityChecker(t *testing.T) {
+	repoPath := filepath.Join(t.TempDir(), "app")
+	analysis := &core.AnalysisResult{Functions: []core.FunctionInfo{
+		{Name: "simple", File: filepath.Join(repoPath, "main.go"), Line: 1, EndLine: 10, Complexity: 2},
+		{Name: "branchy", File: filepath.Join(repoPath, "main.go"), Line: 20, EndLine: 60, Complexity: 7},
+		{Name: "tangled", File: filepath.Join(repoPath, "pkg", "parse.go"), Line: 12, EndLine: 140, Complexity: 13},
+		{Name: "noend", File: filepath.Join(repoPath, "lib.py"), Line: 3, Complexity: 6},
+		{Name: "elsewhere", File: filepath.Join(filepath.Dir(repoPath), "other", "x.go"), Line: 1, Complexity: 50},
+	}}
+
+	checker := NewComplexityChecker()
+	result, err := checker.Check(context.Background(), core.RepositoryContext{
+		Repository: core.Repository{Name: "app", Path: repoPath},
+		Config:     unusedExportsConfig{options: map[string]interface{}{"max_complexity": 5}},
+		Analysis:   analysis,
+	})
+	if err != nil {
+		t.Fatalf("Check failed: %v", err)
+	}
+
+	if result.Status != core.StatusWarning || result.Score != 85 {
+		t.Errorf("Expected warning with score 85, got %s with %d", result.Status, result.Score)
+	}
+	if result.Metrics["functions_checked"] != 4 || result.Metrics["max_complexity"] != 13 {
+		t.Errorf("Unexpected metrics: %v", result.Metrics)
+	}
+	if len(result.Issues) != 3 {
+		t.Fatalf("Expected 3 issues, got %+v", result.Issues)
+	}
+
+	// Most complex first, located at the function's span; more than twice the limit is more severe
+	first := result.Issues[0]
+	if first.Location == nil || first.Location.File != filepath.Join("pkg", "parse.go") ||
+		first.Location.Line != 12 || first.Location.EndLine != 140 || first.Severity != core.SeverityMedium {
+		t.Errorf("Unexpected first issue: %+v %+v", first, first.Location)
+	}
+	if first.Message != "Function 'tangled' has cyclomatic complexity 13 (limit 5)" || first.Context["function"] != "tangled" {
+		t.Errorf("Unexpected first issue: %+v", first)
+	}
+	if last := result.Issues[2]; last.Location.Line != 3 || last.Location.EndLine != 0 || last.Severity != core.SeverityLow {
+		t.Errorf("Expected no end line when the analyzer did not find it, got %+v", last.Location)
+	}
+}
+
+func TestComplexityChecker_FileComplexity(t *testing.T) {
+	repoPath := t.TempDir()
+	analysis := &core.AnalysisResult{Functions: []core.FunctionInfo{
+		{Name: "a", File: filepath.Join(repoPath, "handlers.go"), Line: 1, Complexity: 8},
+		{Name: "b", File: filepath.Join(repoPath, "handlers.go"), Line: 20, Complexity: 9},
+		{Name: "c", File: filepath.Join(repoPath, "handlers.go"), Line: 40, Complexity: 7},
+		{Name: "d", File: filepath.Join(repoPath, "util.go"), Line: 1, Complexity: 9},
+	}}
+
+	checker := NewComplexityChecker()
+	result, err := checker.Check(context.Background(), core.RepositoryContext{
+		Repository: core.Repository{Name: "app", Path: repoPath},
+		Config:     unusedExportsConfig{options: map[string]interface{}{"max_file_complexity": 20}},
+		Analysis:   analysis,
+	})
+	if err != nil {
+		t.Fatalf("Check failed: %v", err)
+	}
+
+	// No function exceeds the default limit, but handlers.go does in total
+	if result.Metrics["complex_functions"] != 0 || result.Metrics["complex_files"] != 1 {
+		t.Errorf("Unexpected metrics: %v", result.Metrics)
+	}
+	if result.Status != core.StatusWarning || len(result.Issues) != 1 {
+		t.Fatalf("Expected one file issue, got %s %+v", result.Status, result.Issues)
+	}
+	issue := result.Issues[0]
+	if issue.Type != "high_file_complexity" || issue.Location.File != "handlers.go" ||
+		issue.Context["complexity"] != 24 || issue.Context["functions"] != 3 {
+		t.Errorf("Unexpected issue: %+v %+v", issue, issue.Location)
+	}
+}
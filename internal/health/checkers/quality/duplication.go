@@ -0,0 +1,423 @@
+package quality
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"math"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/codcod/repos/internal/core"
+	"github.com/codcod/repos/internal/health/analyzers/language"
+	"github.com/codcod/repos/internal/health/checkers/base"
+)
+
+const (
+	// DefaultMinDuplicateLines is the shortest block of identical lines reported as duplicated
+	DefaultMinDuplicateLines = 6
+	// DefaultMaxDuplicationPercent is the share of duplicated lines above which the check warns
+	DefaultMaxDuplicationPercent = 5.0
+	// maxDuplicationIssues is the number of duplicated blocks reported as issues
+	maxDuplicationIssues = 10
+)
+
+// commentPrefixes are the line comment markers per language. Lines starting
+// with one are left out of the comparison, as are blank lines.
+var commentPrefixes = map[string][]string{
+	"go":         {"//", "/*", "*"},
+	"java":       {"//", "/*", "*"},
+	"kotlin":     {"//", "/*", "*"},
+	"scala":      {"//", "/*", "*"},
+	"javascript": {"//", "/*", "*"},
+	"typescript": {"//", "/*", "*"},
+	"csharp":     {"//", "/*", "*"},
+	"swift":      {"//", "/*", "*"},
+	"dart":       {"//", "/*", "*"},
+	"rust":       {"//", "/*", "*"},
+	"c":          {"//", "/*", "*"},
+	"cpp":        {"//", "/*", "*"},
+	"php":        {"//", "/*", "*", "#"},
+	"python":     {"#"},
+	"ruby":       {"#"},
+	"elixir":     {"#"},
+	"shell":      {"#"},
+}
+
+// DuplicationChecker reports blocks of identical lines found in more than one
+// place. Lines are compared after trimming whitespace, and blank lines,
+// comments, imports and lines without any letter or digit, such as closing
+// braces, are left out, so a block is only reported when its code matches. It
+// compares the files found by the repository's code analysis.
+type DuplicationChecker struct {
+	*base.BaseChecker
+}
+
+// NewDuplicationChecker creates a new duplicate code checker
+func NewDuplicationChecker() *DuplicationChecker {
+	config := core.CheckerConfig{
+		Enabled:    true,
+		Severity:   "low",
+		Timeout:    60 * time.Second,
+		Categories: []string{"quality"},
+	}
+
+	return &DuplicationChecker{
+		BaseChecker: base.NewBaseChecker(
+			"code-duplication",
+			"Code Duplication",
+			"quality",
+			config,
+		),
+	}
+}
+
+// Metadata describes what the checker verifies
+func (*DuplicationChecker) Metadata() core.CheckerMetadata {
+	return core.CheckerMetadata{
+		Description: "Reports blocks of at least min_lines identical lines appearing in more than one place, using the files found by code analysis. " +
+			"Blank lines, comments and imports are ignored. The share of duplicated lines is reported as duplication_percentage.",
+		Options: []core.CheckerOption{
+			{Name: "min_lines", Default: DefaultMinDuplicateLines, Description: "Shortest block of identical lines reported, not counting blank lines and comments"},
+			{Name: "max_duplication_percent", Default: DefaultMaxDuplicationPercent, Description: "Share of duplicated lines above which the check warns; below it duplicates are advisory"},
+		},
+	}
+}
+
+// Check performs the duplicate code check
+func (c *DuplicationChecker) Check(ctx context.Context, repoCtx core.RepositoryContext) (core.CheckResult, error) {
+	return c.Execute(ctx, repoCtx, func() (core.CheckResult, error) {
+		return c.checkDuplication(ctx, repoCtx)
+	})
+}
+
+// sourceLine is a line kept for comparison
+type sourceLine struct {
+	text   string
+	number int // 1-based line number in the file
+}
+
+// sourceFile is a file prepared for comparison
+type sourceFile struct {
+	path   string
+	lines  []sourceLine
+	hashes []uint64 // hashes[i] covers lines[i : i+minLines]
+}
+
+// duplicateLocation is one place a duplicated block appears
+type duplicateLocation struct {
+	file  int // Index into the compared files
+	start int // Index of the first compared line
+}
+
+// duplicateBlock is a block of identical lines found in several places
+type duplicateBlock struct {
+	locations []duplicateLocation
+	length    int // Number of compared lines
+}
+
+// checkDuplication performs the actual duplicate code check
+func (c *DuplicationChecker) checkDuplication(ctx context.Context, repoCtx core.RepositoryContext) (core.CheckResult, error) {
+	builder := base.NewResultBuilder(c.ID(), c.Name(), c.Category())
+	minLines := max(c.IntOption(repoCtx, "min_lines", DefaultMinDuplicateLines), 2)
+	maxPercent := DefaultMaxDuplicationPercent
+	if _, err := c.DecodeOption(repoCtx, "max_duplication_percent", &maxPercent); err != nil {
+		return core.CheckResult{}, err
+	}
+
+	files, binarySkipped, err := loadSourceFiles(ctx, repoCtx, minLines)
+	if err != nil {
+		return core.CheckResult{}, err
+	}
+	blocks := findDuplicateBlocks(files, minLines)
+
+	totalLines := 0
+	for _, file := range files {
+		totalLines += len(file.lines)
+	}
+	duplicated := countDuplicatedLines(files, blocks)
+	percent := 0.0
+	if totalLines > 0 {
+		percent = math.Round(float64(duplicated)*1000/float64(totalLines)) / 10
+	}
+
+	builder.AddMetric("files_checked", len(files))
+	builder.AddMetric("lines_checked", totalLines)
+	builder.AddMetric("duplicated_lines", duplicated)
+	builder.AddMetric("duplicate_blocks", len(blocks))
+	builder.AddMetric("duplication_percentage", percent)
+	if binarySkipped > 0 {
+		builder.AddMetric("binary_files_skipped", binarySkipped)
+	}
+
+	if len(blocks) == 0 {
+		builder.WithStatus(core.StatusHealthy)
+		builder.WithScore(100, 100)
+		return builder.Build(), nil
+	}
+
+	// Some duplication is normal; only a share above the limit lowers the result
+	severity := core.SeverityInfo
+	if percent > maxPercent {
+		severity = core.SeverityLow
+		builder.WithStatus(core.StatusWarning)
+		builder.WithScore(max(100-int(percent), 50), 100)
+	} else {
+		builder.WithStatus(core.StatusHealthy)
+		builder.WithScore(100, 100)
+	}
+
+	sort.SliceStable(blocks, func(i, j int) bool { return blocks[i].length > blocks[j].length })
+	for i, block := range blocks {
+		if i >= maxDuplicationIssues {
+			builder.AddMetric("additional_duplicate_blocks", len(blocks)-maxDuplicationIssues)
+			break
+		}
+		builder.AddIssue(duplicateIssue(repoCtx.Repository.Path, files, block, severity))
+	}
+
+	return builder.Build(), nil
+}
+
+// duplicateIssue describes a duplicated block at its first location, listing the others
+func duplicateIssue(repoPath string, files []sourceFile, block duplicateBlock, severity core.Severity) core.Issue {
+	locations := make([]string, len(block.locations))
+	for i, location := range block.locations {
+		file := files[location.file]
+		relPath, err := filepath.Rel(repoPath, file.path)
+		if err != nil {
+			relPath = file.path
+		}
+		first := file.lines[location.start].number
+		last := file.lines[location.start+block.length-1].number
+		locations[i] = fmt.Sprintf("%s:%d-%d", relPath, first, last)
+	}
+
+	first := block.locations[0]
+	file := files[first.file]
+	relPath, err := filepath.Rel(repoPath, file.path)
+	if err != nil {
+		relPath = file.path
+	}
+	issue := base.NewIssueWithLocation(
+		"duplicate_code",
+		severity,
+		fmt.Sprintf("%d lines are duplicated in %d places: %s", block.length, len(locations), strings.Join(locations, ", ")),
+		relPath,
+		file.lines[first.start].number,
+		0,
+	)
+	issue.Location.EndLine = file.lines[first.start+block.length-1].number
+	issue.Suggestion = "Extract the shared code into a function or module"
+	issue.Context["lines"] = block.length
+	issue.Context["locations"] = locations
+	return issue
+}
+
+// loadSourceFiles reads the analyzed files of the repository, keeping the lines
+// worth comparing, and hashes each window of minLines of them
+func loadSourceFiles(ctx context.Context, repoCtx core.RepositoryContext, minLines int) ([]sourceFile, int, error) {
+	if repoCtx.Analysis == nil {
+		return nil, 0, nil
+	}
+
+	paths := make([]string, 0, len(repoCtx.Analysis.Files))
+	for path := range repoCtx.Analysis.Files {
+		// Sub-projects of a monorepo share the repository's analysis
+		if withinPath(path, repoCtx.Repository.Path) {
+			paths = append(paths, path)
+		}
+	}
+	sort.Strings(paths)
+
+	var files []sourceFile
+	binarySkipped := 0
+	for _, path := range paths {
+		if ctx.Err() != nil {
+			return nil, 0, ctx.Err()
+		}
+		content, err := language.ReadText(path)
+		if errors.Is(err, language.ErrBinaryFile) {
+			binarySkipped++
+			continue
+		} else if err != nil {
+			continue // Files removed since the analysis are skipped
+		}
+
+		lang := repoCtx.Analysis.Files[path].Language
+		if lang == "" {
+			lang = repoCtx.Analysis.Language
+		}
+		file := sourceFile{path: path, lines: comparableLines(content, lang)}
+		for i := 0; i+minLines <= len(file.lines); i++ {
+			file.hashes = append(file.hashes, hashLines(file.lines[i:i+minLines]))
+		}
+		files = append(files, file)
+	}
+	return files, binarySkipped, nil
+}
+
+// comparableLines returns the trimmed lines of a file that carry code, leaving
+// out blank lines, comments, imports and lines with only punctuation
+func comparableLines(content, lang string) []sourceLine {
+	prefixes := commentPrefixes[lang]
+	var lines []sourceLine
+	inImportBlock := false
+	for i, line := range strings.Split(content, "\n") {
+		text := strings.Join(strings.Fields(line), " ")
+		if inImportBlock {
+			inImportBlock = text != ")"
+			continue
+		}
+		if text == "import (" {
+			inImportBlock = true
+			continue
+		}
+		if text == "" || isImportLine(text) || !strings.ContainsFunc(text, isAlphanumeric) {
+			continue
+		}
+		comment := false
+		for _, prefix := range prefixes {
+			if strings.HasPrefix(text, prefix) {
+				comment = true
+				break
+			}
+		}
+		if !comment {
+			lines = append(lines, sourceLine{text: text, number: i + 1})
+		}
+	}
+	return lines
+}
+
+// isImportLine reports whether a line imports another module, which files
+// commonly share without duplicating any logic
+func isImportLine(text string) bool {
+	for _, prefix := range []string{"import ", "from ", "package ", "using ", "use ", "require ", "#include ", "alias "} {
+		if strings.HasPrefix(text, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// isAlphanumeric reports whether r is an ASCII letter or digit
+func isAlphanumeric(r rune) bool {
+	return r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9'
+}
+
+// hashLines hashes a window of lines
+func hashLines(lines []sourceLine) uint64 {
+	h := fnv.New64a()
+	for _, line := range lines {
+		_, _ = h.Write([]byte(line.text))
+		_, _ = h.Write([]byte{'\n'})
+	}
+	return h.Sum64()
+}
+
+// findDuplicateBlocks finds the windows of minLines lines that appear in more
+// than one place and extends each into the longest block shared by all of them
+func findDuplicateBlocks(files []sourceFile, minLines int) []duplicateBlock {
+	occurrences, order := windowOccurrences(files, minLines)
+
+	var blocks []duplicateBlock
+	for _, hash := range order {
+		locations := occurrences[hash]
+		if len(locations) < 2 || !identicalWindows(files, locations, minLines) {
+			continue
+		}
+		// Windows following a shared window at every location belong to a block found already
+		if sharedAt(files, locations, -1) {
+			continue
+		}
+		length := minLines
+		for offset := 1; sharedAt(files, locations, offset); offset++ {
+			length++
+		}
+		blocks = append(blocks, duplicateBlock{locations: locations, length: length})
+	}
+	return blocks
+}
+
+// windowOccurrences returns the locations of each window hash, and the hashes
+// in the order they first occur
+func windowOccurrences(files []sourceFile, minLines int) (map[uint64][]duplicateLocation, []uint64) {
+	occurrences := make(map[uint64][]duplicateLocation)
+	var order []uint64
+	for f, file := range files {
+		for i, hash := range file.hashes {
+			locations := occurrences[hash]
+			// Overlapping windows of the same file, as in a run of repeated lines, count once
+			if n := len(locations); n > 0 && locations[n-1].file == f && i < locations[n-1].start+minLines {
+				continue
+			}
+			if len(locations) == 0 {
+				order = append(order, hash)
+			}
+			occurrences[hash] = append(locations, duplicateLocation{file: f, start: i})
+		}
+	}
+	return occurrences, order
+}
+
+// identicalWindows guards against hash collisions by comparing the lines
+func identicalWindows(files []sourceFile, locations []duplicateLocation, minLines int) bool {
+	first := files[locations[0].file].lines[locations[0].start:]
+	for _, location := range locations[1:] {
+		other := files[location.file].lines[location.start:]
+		for i := 0; i < minLines; i++ {
+			if first[i].text != other[i].text {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// sharedAt reports whether every location has the same window at the given
+// offset from its start
+func sharedAt(files []sourceFile, locations []duplicateLocation, offset int) bool {
+	var hash uint64
+	for i, location := range locations {
+		hashes := files[location.file].hashes
+		index := location.start + offset
+		if index < 0 || index >= len(hashes) {
+			return false
+		}
+		if i == 0 {
+			hash = hashes[index]
+		} else if hashes[index] != hash {
+			return false
+		}
+	}
+	return true
+}
+
+// countDuplicatedLines counts the compared lines that are part of a duplicated block
+func countDuplicatedLines(files []sourceFile, blocks []duplicateBlock) int {
+	duplicated := make([][]bool, len(files))
+	for i, file := range files {
+		duplicated[i] = make([]bool, len(file.lines))
+	}
+	count := 0
+	for _, block := range blocks {
+		for _, location := range block.locations {
+			for i := location.start; i < location.start+block.length; i++ {
+				if !duplicated[location.file][i] {
+					duplicated[location.file][i] = true
+					count++
+				}
+			}
+		}
+	}
+	return count
+}
+
+// SupportsRepository reports true; repositories without analyzed files pass
+func (c *DuplicationChecker) SupportsRepository(repo core.Repository) bool {
+	return true
+}
@@ -0,0 +1,105 @@
+package quality
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/codcod/repos/internal/core"
+)
+
+const duplicatedBody = `	if len(items) == 0 {
+		return 0
+	}
+	total := 0
+	for _, item := range items {
+		if item.Price > 0 {
+			total += item.Price * item.Quantity
+		}
+	}
+	return total
+`
+
+func TestDuplicationChecker(t *testing.T) {
+	repoPath := t.TempDir()
+	files := map[string]string{
+		"orders.go": "package shop\n\nimport (\n\t\"fmt\"\n\t\"strings\"\n)\n\n" +
+			"func orderTotal(items []Item) int {\n" + duplicatedBody + "}\n",
+		"cart.go": "package shop\n\nimport (\n\t\"fmt\"\n\t\"strings\"\n)\n\n" +
+			"// cartTotal sums the cart\nfunc cartTotal(items []Item) int {\n\n" + duplicatedBody + "}\n",
+		"other.go": "package shop\n\nfunc other() int {\n\treturn 1\n}\n",
+	}
+	analysis := &core.AnalysisResult{Language: "go", Files: map[string]*core.FileAnalysis{}}
+	for name, content := range files {
+		path := filepath.Join(repoPath, name)
+		if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+		analysis.Files[path] = &core.FileAnalysis{Path: path, Language: "go"}
+	}
+
+	tests := []struct {
+		name     string
+		options  map[string]interface{}
+		status   core.HealthStatus
+		severity core.Severity
+		blocks   int
+	}{
+		{name: "duplication above the limit", status: core.StatusWarning, severity: core.SeverityLow, blocks: 1},
+		{name: "duplication within the limit", options: map[string]interface{}{"max_duplication_percent": 90}, status: core.StatusHealthy, severity: core.SeverityInfo, blocks: 1},
+		{name: "blocks shorter than min_lines", options: map[string]interface{}{"min_lines": 8}, status: core.StatusHealthy},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := NewDuplicationChecker().Check(context.Background(), core.RepositoryContext{
+				Repository: core.Repository{Name: "shop", Path: repoPath},
+				Config:     unusedExportsConfig{options: tt.options},
+				Analysis:   analysis,
+			})
+			if err != nil {
+				t.Fatalf("Check failed: %v", err)
+			}
+			if result.Status != tt.status {
+				t.Errorf("Expected status %s, got %s", tt.status, result.Status)
+			}
+			if result.Metrics["files_checked"] != 3 || result.Metrics["duplicate_blocks"] != tt.blocks {
+				t.Errorf("Unexpected metrics: %v", result.Metrics)
+			}
+			if len(result.Issues) != tt.blocks {
+				t.Fatalf("Expected %d issues, got %+v", tt.blocks, result.Issues)
+			}
+			if tt.blocks == 0 {
+				return
+			}
+
+			// The signatures differ and braces are not compared, so the block is the seven code lines of the body
+			issue := result.Issues[0]
+			if issue.Type != "duplicate_code" || issue.Severity != tt.severity {
+				t.Errorf("Unexpected issue: %+v", issue)
+			}
+			if issue.Location.File != "cart.go" || issue.Location.Line != 11 || issue.Location.EndLine != 20 {
+				t.Errorf("Unexpected location: %+v", issue.Location)
+			}
+			if issue.Context["lines"] != 7 {
+				t.Errorf("Expected a block of 7 compared lines, got %v", issue.Context["lines"])
+			}
+			locations, _ := issue.Context["locations"].([]string)
+			if len(locations) != 2 || locations[0] != "cart.go:11-20" || locations[1] != "orders.go:9-18" {
+				t.Errorf("Unexpected locations: %v", locations)
+			}
+			if result.Metrics["duplicated_lines"] != 14 || result.Metrics["duplication_percentage"] != 77.8 {
+				t.Errorf("Unexpected metrics: %v", result.Metrics)
+			}
+		})
+	}
+}
+
+func TestComparableLines(t *testing.T) {
+	content := "import os\n\n# comment\ndef f(x):\n    return  x + 1\n)\n"
+	lines := comparableLines(content, "python")
+	if len(lines) != 2 || lines[0].text != "def f(x):" || lines[1].text != "return x + 1" || lines[1].number != 5 {
+		t.Errorf("Unexpected lines: %+v", lines)
+	}
+}
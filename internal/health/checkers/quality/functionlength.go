@@ -0,0 +1,131 @@
+package quality
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/codcod/repos/internal/core"
+	"github.com/codcod/repos/internal/health/checkers/base"
+)
+
+// DefaultMaxFunctionLines is the longest a function may be before it is reported
+const DefaultMaxFunctionLines = 100
+
+// FunctionLengthChecker reports functions longer than a line limit. Length is a
+// separate signal from complexity: a long but flat function can still be hard to
+// read and change. It uses the functions found by the repository's code analysis.
+type FunctionLengthChecker struct {
+	*base.BaseChecker
+}
+
+// NewFunctionLengthChecker creates a new function length checker
+func NewFunctionLengthChecker() *FunctionLengthChecker {
+	config := core.CheckerConfig{
+		Enabled:    true,
+		Severity:   "low",
+		Timeout:    30 * time.Second,
+		Categories: []string{"quality"},
+	}
+
+	return &FunctionLengthChecker{
+		BaseChecker: base.NewBaseChecker(
+			"function-length",
+			"Function Length",
+			"quality",
+			config,
+		),
+	}
+}
+
+// Metadata describes what the checker verifies
+func (*FunctionLengthChecker) Metadata() core.CheckerMetadata {
+	return core.CheckerMetadata{
+		Description: "Reports functions spanning more lines than max_lines, longest first, using the functions found by code analysis.",
+		Options: []core.CheckerOption{
+			{Name: "max_lines", Default: DefaultMaxFunctionLines, Description: "Longest a function may be, in lines including its signature"},
+		},
+	}
+}
+
+// Check performs the function length check
+func (c *FunctionLengthChecker) Check(ctx context.Context, repoCtx core.RepositoryContext) (core.CheckResult, error) {
+	return c.Execute(ctx, repoCtx, func() (core.CheckResult, error) {
+		return c.checkFunctionLength(repoCtx), nil
+	})
+}
+
+// checkFunctionLength performs the actual function length check
+func (c *FunctionLengthChecker) checkFunctionLength(repoCtx core.RepositoryContext) core.CheckResult {
+	builder := base.NewResultBuilder(c.ID(), c.Name(), c.Category())
+	maxLines := c.IntOption(repoCtx, "max_lines", DefaultMaxFunctionLines)
+
+	var long []core.FunctionInfo
+	checked, longest := 0, 0
+	if repoCtx.Analysis != nil {
+		for _, fn := range repoCtx.Analysis.Functions {
+			// Sub-projects of a monorepo share the repository's analysis
+			if !withinPath(fn.File, repoCtx.Repository.Path) || fn.Lines() == 0 {
+				continue
+			}
+			checked++
+			longest = max(longest, fn.Lines())
+			if fn.Lines() > maxLines {
+				long = append(long, fn)
+			}
+		}
+	}
+
+	builder.AddMetric("functions_checked", checked)
+	builder.AddMetric("max_function_lines", longest)
+	builder.AddMetric("long_functions", len(long))
+
+	if len(long) == 0 {
+		builder.WithStatus(core.StatusHealthy)
+		builder.WithScore(100, 100)
+		return builder.Build()
+	}
+
+	// Long functions are a maintenance cost rather than a defect, so the score never drops below half
+	builder.WithStatus(core.StatusWarning)
+	builder.WithScore(max(100-len(long)*5, 50), 100)
+
+	sort.SliceStable(long, func(i, j int) bool { return long[i].Lines() > long[j].Lines() })
+	for _, fn := range long {
+		severity := core.SeverityLow
+		if fn.Lines() > 2*maxLines {
+			severity = core.SeverityMedium
+		}
+		relPath, err := filepath.Rel(repoCtx.Repository.Path, fn.File)
+		if err != nil {
+			relPath = fn.File
+		}
+		issue := base.NewIssueWithLocation(
+			"long_function",
+			severity,
+			fmt.Sprintf("Function '%s' is %d lines long (limit %d)", fn.Name, fn.Lines(), maxLines),
+			relPath,
+			fn.Line,
+			0,
+		)
+		issue.Suggestion = "Split it into smaller functions with descriptive names"
+		issue.Context["lines"] = fn.Lines()
+		builder.AddIssue(issue)
+	}
+
+	return builder.Build()
+}
+
+// withinPath reports whether file is inside the directory dir
+func withinPath(file, dir string) bool {
+	rel, err := filepath.Rel(dir, file)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+// SupportsRepository reports true; repositories without analyzed functions pass
+func (c *FunctionLengthChecker) SupportsRepository(repo core.Repository) bool {
+	return true
+}
@@ -0,0 +1,55 @@
+package quality
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/codcod/repos/internal/core"
+)
+
+func TestFunctionLengthChecker(t *testing.T) {
+	repoPath := filepath.Join(t.TempDir(), "app")
+	analysis := &core.AnalysisResult{Functions: []core.FunctionInfo{
+		{Name: "short", File: filepath.Join(repoPath, "main.go"), Line: 1, EndLine: 10},
+		{Name: "long", File: filepath.Join(repoPath, "main.go"), Line: 20, EndLine: 49},
+		{Name: "huge", File: filepath.Join(repoPath, "pkg", "big.go"), Line: 5, EndLine: 70},
+		{Name: "unknown", File: filepath.Join(repoPath, "main.go"), Line: 80},
+		{Name: "elsewhere", File: filepath.Join(filepath.Dir(repoPath), "other", "x.go"), Line: 1, EndLine: 500},
+	}}
+
+	checker := NewFunctionLengthChecker()
+	result, err := checker.Check(context.Background(), core.RepositoryContext{
+		Repository: core.Repository{Name: "app", Path: repoPath},
+		Config:     unusedExportsConfig{options: map[string]interface{}{"max_lines": 25}},
+		Analysis:   analysis,
+	})
+	if err != nil {
+		t.Fatalf("Check failed: %v", err)
+	}
+
+	if result.Status != core.StatusWarning || result.Score != 90 {
+		t.Errorf("Expected warning with score 90, got %s with %d", result.Status, result.Score)
+	}
+	if result.Metrics["functions_checked"] != 3 || result.Metrics["max_function_lines"] != 66 {
+		t.Errorf("Unexpected metrics: %v", result.Metrics)
+	}
+	if len(result.Issues) != 2 {
+		t.Fatalf("Expected 2 issues, got %+v", result.Issues)
+	}
+
+	// Longest first; more than twice the limit is more severe
+	first, second := result.Issues[0], result.Issues[1]
+	if first.Location.File != filepath.Join("pkg", "big.go") || first.Location.Line != 5 || first.Severity != core.SeverityMedium {
+		t.Errorf("Unexpected first issue: %+v", first)
+	}
+	if second.Message != "Function 'long' is 30 lines long (limit 25)" || second.Severity != core.SeverityLow {
+		t.Errorf("Unexpected second issue: %+v", second)
+	}
+
+	// Repositories without analysis pass
+	result, err = checker.Check(context.Background(), core.RepositoryContext{Repository: core.Repository{Path: repoPath}})
+	if err != nil || result.Status != core.StatusHealthy {
+		t.Errorf("Expected a healthy result without analysis, got %v, %v", result.Status, err)
+	}
+}
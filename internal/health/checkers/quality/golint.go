@@ -0,0 +1,278 @@
+package quality
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/codcod/repos/internal/core"
+	"github.com/codcod/repos/internal/health/checkers/base"
+	"github.com/codcod/repos/internal/platform/commands"
+)
+
+// maxReportedLintFindings limits how many lint findings are reported individually
+const maxReportedLintFindings = 50
+
+// vetLinePattern matches a go vet diagnostic, e.g. "./pkg/x.go:12:3: message"
+var vetLinePattern = regexp.MustCompile(`^(?:vet: )?(.+?\.go):(\d+)(?::(\d+))?: (.+)$`)
+
+// lintFinding is a single diagnostic reported by go vet or golangci-lint
+type lintFinding struct {
+	Linter   string
+	File     string
+	Line     int
+	Column   int
+	Message  string
+	Severity core.Severity
+}
+
+// golangciReport mirrors the JSON output of 'golangci-lint run --out-format json'
+type golangciReport struct {
+	Issues []struct {
+		FromLinter string `json:"FromLinter"`
+		Text       string `json:"Text"`
+		Severity   string `json:"Severity"`
+		Pos        struct {
+			Filename string `json:"Filename"`
+			Line     int    `json:"Line"`
+			Column   int    `json:"Column"`
+		} `json:"Pos"`
+	} `json:"Issues"`
+}
+
+// GoLintChecker runs go vet and golangci-lint on Go modules and reports their
+// findings. It is disabled by default; enable it under checkers.go-lint in the
+// health configuration.
+type GoLintChecker struct {
+	*base.BaseChecker
+	executor commands.CommandExecutor
+}
+
+// NewGoLintChecker creates a new Go lint checker
+func NewGoLintChecker(executor commands.CommandExecutor) *GoLintChecker {
+	config := core.CheckerConfig{
+		Enabled:    false,
+		Severity:   "medium",
+		Timeout:    5 * time.Minute,
+		Categories: []string{"quality"},
+	}
+
+	return &GoLintChecker{
+		BaseChecker: base.NewBaseChecker(
+			"go-lint",
+			"Go Lint",
+			"quality",
+			config,
+		),
+		executor: executor,
+	}
+}
+
+// Metadata describes what the checker verifies
+func (*GoLintChecker) Metadata() core.CheckerMetadata {
+	return core.CheckerMetadata{
+		Description: "Runs go vet and golangci-lint on Go modules and reports each finding with its file, line and linter. " +
+			"Tools that are not installed are skipped with a warning. Opt-in.",
+		Options: []core.CheckerOption{
+			{Name: "use_go_vet", Default: "true", Description: "Run 'go vet ./...'"},
+			{Name: "use_golangci_lint", Default: "true", Description: "Run 'golangci-lint run --out-format json'"},
+		},
+		RequiredTools: []string{"go", "golangci-lint"},
+	}
+}
+
+// Check performs the Go lint check
+func (c *GoLintChecker) Check(ctx context.Context, repoCtx core.RepositoryContext) (core.CheckResult, error) {
+	return c.Execute(ctx, repoCtx, func() (core.CheckResult, error) {
+		return c.checkGoLint(ctx, repoCtx)
+	})
+}
+
+// checkGoLint performs the actual Go lint check
+func (c *GoLintChecker) checkGoLint(ctx context.Context, repoCtx core.RepositoryContext) (core.CheckResult, error) {
+	builder := base.NewResultBuilder(c.ID(), c.Name(), c.Category())
+	repoPath := repoCtx.Repository.Path
+
+	// The linters can take long on large modules, so bound them by the checker timeout
+	ctx, cancel := context.WithTimeout(ctx, c.Timeout(repoCtx))
+	defer cancel()
+
+	type linter struct {
+		option string
+		tool   string
+		run    func(context.Context, string) ([]lintFinding, error)
+	}
+	linters := []linter{
+		{"use_go_vet", "go", c.runGoVet},
+		{"use_golangci_lint", "golangci-lint", c.runGolangciLint},
+	}
+
+	var findings []lintFinding
+	var ran []string
+	for _, l := range linters {
+		if !c.BoolOption(repoCtx, l.option, true) {
+			continue
+		}
+		if !commands.CommandExists(c.executor, l.tool) {
+			builder.AddWarning(core.Warning{
+				Type:    "tool_not_available",
+				Message: fmt.Sprintf("%s is not installed; skipping %s", l.tool, l.option),
+			})
+			continue
+		}
+
+		linterFindings, err := l.run(ctx, repoPath)
+		if err != nil {
+			if ctx.Err() != nil {
+				return core.CheckResult{}, fmt.Errorf("%s did not finish: %w", l.tool, ctx.Err())
+			}
+			builder.AddWarning(core.Warning{
+				Type:    "lint_command_error",
+				Message: fmt.Sprintf("Unable to run %s: %v", l.tool, err),
+			})
+			continue
+		}
+		ran = append(ran, l.tool)
+		findings = append(findings, linterFindings...)
+	}
+
+	builder.AddMetric("linters_run", strings.Join(ran, ", "))
+	builder.AddMetric("lint_findings", len(findings))
+
+	if len(findings) == 0 {
+		builder.WithStatus(core.StatusHealthy)
+		builder.WithScore(100, 100)
+		return builder.Build(), nil
+	}
+
+	addLintFindings(builder, findings)
+
+	score := max(100-5*len(findings), 0)
+	builder.WithScore(score, 100)
+	if score >= 50 {
+		builder.WithStatus(core.StatusWarning)
+	} else {
+		builder.WithStatus(core.StatusCritical)
+	}
+	return builder.Build(), nil
+}
+
+// addLintFindings reports the first findings as issues and counts them per linter
+func addLintFindings(builder *base.ResultBuilder, findings []lintFinding) {
+	byLinter := make(map[string]int)
+	for i, finding := range findings {
+		byLinter[finding.Linter]++
+		if i >= maxReportedLintFindings {
+			continue
+		}
+
+		issue := base.NewIssueWithLocation(
+			"lint_finding",
+			finding.Severity,
+			fmt.Sprintf("%s: %s", finding.Linter, finding.Message),
+			finding.File,
+			finding.Line,
+			finding.Column,
+		)
+		issue.Context["linter"] = finding.Linter
+		builder.AddIssue(issue)
+	}
+	for linterName, count := range byLinter {
+		builder.AddMetric("findings_"+linterName, count)
+	}
+	if len(findings) > maxReportedLintFindings {
+		builder.AddMetric("additional_findings", len(findings)-maxReportedLintFindings)
+	}
+}
+
+// runGoVet runs go vet, which prints its diagnostics to stderr and exits
+// non-zero when it finds any
+func (c *GoLintChecker) runGoVet(ctx context.Context, repoPath string) ([]lintFinding, error) {
+	result := c.executor.ExecuteInDir(ctx, repoPath, "go", "vet", "./...")
+	findings := parseGoVetOutput(result.Stderr)
+	if result.Error != nil && len(findings) == 0 {
+		return nil, fmt.Errorf("%w: %s", result.Error, strings.TrimSpace(result.Stderr))
+	}
+	return findings, nil
+}
+
+// runGolangciLint runs golangci-lint, which exits non-zero when it reports issues
+func (c *GoLintChecker) runGolangciLint(ctx context.Context, repoPath string) ([]lintFinding, error) {
+	result := c.executor.ExecuteInDir(ctx, repoPath, "golangci-lint", "run", "--out-format", "json")
+	findings, err := parseGolangciOutput(result.Stdout)
+	if err != nil {
+		if result.Error != nil {
+			return nil, fmt.Errorf("%w: %s", result.Error, strings.TrimSpace(result.Stderr))
+		}
+		return nil, err
+	}
+	return findings, nil
+}
+
+// parseGoVetOutput extracts diagnostics from go vet's output, skipping the
+// "# package" headers it prints before each package's findings
+func parseGoVetOutput(output string) []lintFinding {
+	var findings []lintFinding
+	for _, line := range strings.Split(output, "\n") {
+		match := vetLinePattern.FindStringSubmatch(strings.TrimSpace(line))
+		if match == nil {
+			continue
+		}
+		lineNum, _ := strconv.Atoi(match[2])
+		column, _ := strconv.Atoi(match[3])
+		findings = append(findings, lintFinding{
+			Linter:   "vet",
+			File:     strings.TrimPrefix(filepath.ToSlash(match[1]), "./"),
+			Line:     lineNum,
+			Column:   column,
+			Message:  match[4],
+			Severity: core.SeverityMedium,
+		})
+	}
+	return findings
+}
+
+// parseGolangciOutput extracts issues from golangci-lint's JSON output
+func parseGolangciOutput(output string) ([]lintFinding, error) {
+	var report golangciReport
+	if err := json.Unmarshal([]byte(output), &report); err != nil {
+		return nil, fmt.Errorf("unable to parse golangci-lint output: %w", err)
+	}
+
+	findings := make([]lintFinding, 0, len(report.Issues))
+	for _, issue := range report.Issues {
+		findings = append(findings, lintFinding{
+			Linter:   issue.FromLinter,
+			File:     filepath.ToSlash(issue.Pos.Filename),
+			Line:     issue.Pos.Line,
+			Column:   issue.Pos.Column,
+			Message:  issue.Text,
+			Severity: golangciSeverity(issue.Severity),
+		})
+	}
+	return findings, nil
+}
+
+// golangciSeverity maps a golangci-lint severity to an issue severity
+func golangciSeverity(severity string) core.Severity {
+	switch strings.ToLower(severity) {
+	case "error":
+		return core.SeverityHigh
+	case "info":
+		return core.SeverityLow
+	default:
+		return core.SeverityMedium
+	}
+}
+
+// SupportsRepository checks if the repository is a Go module
+func (c *GoLintChecker) SupportsRepository(repo core.Repository) bool {
+	_, err := os.Stat(filepath.Join(repo.Path, "go.mod"))
+	return err == nil
+}
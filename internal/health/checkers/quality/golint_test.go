@@ -0,0 +1,94 @@
+package quality
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/codcod/repos/internal/core"
+	"github.com/codcod/repos/internal/platform/commands"
+)
+
+func TestGoLintChecker(t *testing.T) {
+	repoPath := t.TempDir()
+	if err := os.WriteFile(filepath.Join(repoPath, "go.mod"), []byte("module example.com/lint\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	executor := commands.NewMockCommandExecutor()
+	executor.SetResponse("go vet ./...", commands.CommandResult{
+		ExitCode: 1,
+		Stderr:   "# example.com/lint/pkg\n./pkg/x.go:12:3: fmt.Printf format %d has arg s of wrong type string\n",
+		Error:    os.ErrInvalid,
+	})
+	executor.SetResponse("golangci-lint run --out-format json", commands.CommandResult{
+		ExitCode: 1,
+		Stdout: `{"Issues":[{"FromLinter":"errcheck","Text":"Error return value is not checked","Severity":"",` +
+			`"Pos":{"Filename":"main.go","Line":7,"Column":2}}]}`,
+		Error: os.ErrInvalid,
+	})
+
+	checker := NewGoLintChecker(executor)
+	if checker.Config().Enabled {
+		t.Error("Expected the Go lint checker to be opt-in")
+	}
+
+	repo := core.Repository{Name: "lint", Path: repoPath}
+	if !checker.SupportsRepository(repo) {
+		t.Fatal("Expected checker to support a Go module")
+	}
+
+	result, err := checker.Check(context.Background(), core.RepositoryContext{Repository: repo})
+	if err != nil {
+		t.Fatalf("Check failed: %v", err)
+	}
+
+	if result.Status != core.StatusWarning {
+		t.Errorf("Expected warning status, got %s", result.Status)
+	}
+	if len(result.Issues) != 2 {
+		t.Fatalf("Expected two lint issues, got %d", len(result.Issues))
+	}
+
+	vet := result.Issues[0]
+	if vet.Location == nil || vet.Location.File != "pkg/x.go" || vet.Location.Line != 12 || vet.Location.Column != 3 {
+		t.Errorf("Unexpected go vet location: %+v", vet.Location)
+	}
+	if vet.Context["linter"] != "vet" {
+		t.Errorf("Expected linter vet, got %v", vet.Context["linter"])
+	}
+
+	lint := result.Issues[1]
+	if lint.Location == nil || lint.Location.File != "main.go" || lint.Location.Line != 7 {
+		t.Errorf("Unexpected golangci-lint location: %+v", lint.Location)
+	}
+	if lint.Context["linter"] != "errcheck" {
+		t.Errorf("Expected linter errcheck, got %v", lint.Context["linter"])
+	}
+}
+
+func TestGoLintChecker_SkipsMissingTools(t *testing.T) {
+	executor := commands.NewMockCommandExecutor()
+	executor.SetResponse("which golangci-lint", commands.CommandResult{ExitCode: 1, Error: os.ErrNotExist})
+	executor.SetResponse("go vet ./...", commands.CommandResult{})
+
+	checker := NewGoLintChecker(executor)
+	repo := core.Repository{Name: "lint", Path: t.TempDir()}
+	result, err := checker.Check(context.Background(), core.RepositoryContext{Repository: repo})
+	if err != nil {
+		t.Fatalf("Check failed: %v", err)
+	}
+
+	if result.Status != core.StatusHealthy {
+		t.Errorf("Expected healthy status, got %s", result.Status)
+	}
+	if len(result.Warnings) != 1 || result.Warnings[0].Type != "tool_not_available" {
+		t.Errorf("Expected a tool_not_available warning, got %+v", result.Warnings)
+	}
+	for _, call := range executor.GetCalls() {
+		if call.Command == "golangci-lint" {
+			t.Error("Expected golangci-lint not to run when it is not installed")
+		}
+	}
+}
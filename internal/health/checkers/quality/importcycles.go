@@ -0,0 +1,97 @@
+package quality
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/codcod/repos/internal/core"
+	go_analyzer "github.com/codcod/repos/internal/health/analyzers/go"
+	"github.com/codcod/repos/internal/health/checkers/base"
+)
+
+// ImportCycleChecker reports package import cycles in Go modules.
+// It is disabled by default; enable it under checkers.go-import-cycles in the
+// health configuration.
+type ImportCycleChecker struct {
+	*base.BaseChecker
+}
+
+// NewImportCycleChecker creates a new Go import cycle checker
+func NewImportCycleChecker() *ImportCycleChecker {
+	config := core.CheckerConfig{
+		Enabled:    false,
+		Severity:   "high",
+		Timeout:    60 * time.Second,
+		Categories: []string{"quality"},
+	}
+
+	return &ImportCycleChecker{
+		BaseChecker: base.NewBaseChecker(
+			"go-import-cycles",
+			"Go Import Cycles",
+			"quality",
+			config,
+		),
+	}
+}
+
+// Metadata describes what the checker verifies
+func (*ImportCycleChecker) Metadata() core.CheckerMetadata {
+	return core.CheckerMetadata{
+		Description: "Reports package import cycles within Go modules. Opt-in.",
+	}
+}
+
+// Check performs the import cycle check
+func (c *ImportCycleChecker) Check(ctx context.Context, repoCtx core.RepositoryContext) (core.CheckResult, error) {
+	return c.Execute(ctx, repoCtx, func() (core.CheckResult, error) {
+		return c.checkImportCycles(repoCtx)
+	})
+}
+
+// checkImportCycles performs the actual import cycle check
+func (c *ImportCycleChecker) checkImportCycles(repoCtx core.RepositoryContext) (core.CheckResult, error) {
+	builder := base.NewResultBuilder(c.ID(), c.Name(), c.Category())
+
+	graph, err := go_analyzer.BuildImportGraph(repoCtx.Repository.Path)
+	if err != nil {
+		return core.CheckResult{}, fmt.Errorf("failed to build import graph: %w", err)
+	}
+
+	cycles := graph.FindImportCycles()
+	builder.AddMetric("packages", len(graph))
+	builder.AddMetric("import_cycles", len(cycles))
+
+	if len(cycles) == 0 {
+		builder.WithStatus(core.StatusHealthy)
+		builder.WithScore(100, 100)
+		return builder.Build(), nil
+	}
+
+	// Import cycles prevent the module from compiling
+	builder.WithStatus(core.StatusCritical)
+	builder.WithScore(0, 100)
+
+	for _, cycle := range cycles {
+		issue := base.NewIssueWithSuggestion(
+			"import_cycle",
+			core.SeverityHigh,
+			fmt.Sprintf("Import cycle: %s", strings.Join(cycle, " -> ")),
+			"Break the cycle by moving shared types into a separate package or inverting a dependency with an interface",
+		)
+		issue.Context["packages"] = cycle[:len(cycle)-1]
+		builder.AddIssue(issue)
+	}
+
+	return builder.Build(), nil
+}
+
+// SupportsRepository checks if the repository is a Go module
+func (c *ImportCycleChecker) SupportsRepository(repo core.Repository) bool {
+	_, err := os.Stat(filepath.Join(repo.Path, "go.mod"))
+	return err == nil
+}
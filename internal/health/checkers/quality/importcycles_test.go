@@ -0,0 +1,54 @@
+package quality
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/codcod/repos/internal/core"
+)
+
+func TestImportCycleChecker(t *testing.T) {
+	repoPath := t.TempDir()
+	files := map[string]string{
+		"go.mod": "module example.com/cyclic\n",
+		"x/x.go": "package x\n\nimport _ \"example.com/cyclic/y\"\n",
+		"y/y.go": "package y\n\nimport _ \"example.com/cyclic/x\"\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(repoPath, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	checker := NewImportCycleChecker()
+	if checker.Config().Enabled {
+		t.Error("Expected the import cycle checker to be opt-in")
+	}
+
+	repo := core.Repository{Name: "cyclic", Path: repoPath}
+	if !checker.SupportsRepository(repo) {
+		t.Fatal("Expected checker to support a Go module")
+	}
+
+	result, err := checker.Check(context.Background(), core.RepositoryContext{Repository: repo})
+	if err != nil {
+		t.Fatalf("Check failed: %v", err)
+	}
+
+	if result.Status != core.StatusCritical {
+		t.Errorf("Expected critical status, got %s", result.Status)
+	}
+	if len(result.Issues) != 1 {
+		t.Fatalf("Expected one cycle issue, got %d", len(result.Issues))
+	}
+	expected := "Import cycle: example.com/cyclic/x -> example.com/cyclic/y -> example.com/cyclic/x"
+	if result.Issues[0].Message != expected {
+		t.Errorf("Expected %q, got %q", expected, result.Issues[0].Message)
+	}
+}
@@ -0,0 +1,387 @@
+package quality
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/codcod/repos/internal/core"
+	"github.com/codcod/repos/internal/health/analyzers/language"
+	"github.com/codcod/repos/internal/health/checkers/base"
+)
+
+const (
+	// DefaultTechDebtThreshold is the number of markers tolerated before the check degrades
+	DefaultTechDebtThreshold = 50
+
+	// maxReportedOffenders limits how many files are reported individually
+	maxReportedOffenders = 5
+)
+
+var (
+	// markerPattern matches debt markers on word boundaries, with an optional
+	// reference in parentheses, e.g. TODO(jsmith) or FIXME(JIRA-123)
+	markerPattern = regexp.MustCompile(`\b(TODO|FIXME|HACK|XXX)\b(?:\(([^)]*)\))?:?\s*(.*)`)
+
+	// ticketPattern matches issue tracker references such as JIRA-123
+	ticketPattern = regexp.MustCompile(`\b[A-Z][A-Z0-9]+-\d+\b`)
+)
+
+// TechDebtCheckerConfig holds configuration for technical debt scanning
+type TechDebtCheckerConfig struct {
+	Threshold  int
+	Extensions []string
+	Excludes   []string
+}
+
+// DefaultTechDebtConfig returns the default configuration
+func DefaultTechDebtConfig() *TechDebtCheckerConfig {
+	return &TechDebtCheckerConfig{
+		Threshold: DefaultTechDebtThreshold,
+		Extensions: []string{
+			".go", ".py", ".js", ".jsx", ".ts", ".tsx", ".java", ".kt", ".scala",
+			".rb", ".rs", ".c", ".h", ".cpp", ".hpp", ".cs", ".php", ".swift", ".sh",
+		},
+		Excludes: []string{".git/", "vendor/", "node_modules/", "dist/", "build/", "target/", ".venv/", "venv/", "__pycache__/"},
+	}
+}
+
+// DebtMarker represents a single TODO/FIXME/HACK/XXX marker found in a comment
+type DebtMarker struct {
+	Type      string
+	File      string
+	Line      int
+	Reference string // author or ticket, if present
+	Text      string
+}
+
+// TechDebtChecker counts technical debt markers in source code comments
+type TechDebtChecker struct {
+	*base.BaseChecker
+	config *TechDebtCheckerConfig
+}
+
+// NewTechDebtChecker creates a new technical debt checker with default configuration
+func NewTechDebtChecker() *TechDebtChecker {
+	return NewTechDebtCheckerWithConfig(DefaultTechDebtConfig())
+}
+
+// NewTechDebtCheckerWithConfig creates a new technical debt checker with the provided configuration
+func NewTechDebtCheckerWithConfig(config *TechDebtCheckerConfig) *TechDebtChecker {
+	if config == nil {
+		config = DefaultTechDebtConfig()
+	}
+
+	checkerConfig := core.CheckerConfig{
+		Enabled:    true,
+		Severity:   "low",
+		Timeout:    30 * time.Second,
+		Categories: []string{"quality"},
+	}
+
+	return &TechDebtChecker{
+		BaseChecker: base.NewBaseChecker(
+			"tech-debt",
+			"Technical Debt Markers",
+			"quality",
+			checkerConfig,
+		),
+		config: config,
+	}
+}
+
+// Metadata describes what the checker verifies
+func (c *TechDebtChecker) Metadata() core.CheckerMetadata {
+	return core.CheckerMetadata{
+		Description: "Counts TODO, FIXME, HACK and XXX markers in source comments and reports the files with the most.",
+		Options: []core.CheckerOption{
+			{Name: "threshold", Default: c.config.Threshold, Description: "Number of markers tolerated before the check degrades"},
+		},
+	}
+}
+
+// Check performs the technical debt check
+func (c *TechDebtChecker) Check(ctx context.Context, repoCtx core.RepositoryContext) (core.CheckResult, error) {
+	return c.Execute(ctx, repoCtx, func() (core.CheckResult, error) {
+		return c.checkTechDebt(ctx, repoCtx)
+	})
+}
+
+// checkTechDebt performs the actual technical debt check
+func (c *TechDebtChecker) checkTechDebt(ctx context.Context, repoCtx core.RepositoryContext) (core.CheckResult, error) {
+	builder := base.NewResultBuilder(c.ID(), c.Name(), c.Category())
+	threshold := c.IntOption(repoCtx, "threshold", c.config.Threshold)
+
+	markers, binarySkipped, err := c.scanRepository(ctx, repoCtx.Repository.Path)
+	if err != nil {
+		return core.CheckResult{}, err
+	}
+	builder.AddMetric("binary_files_skipped", binarySkipped)
+
+	// Count markers by type
+	counts := map[string]int{"TODO": 0, "FIXME": 0, "HACK": 0, "XXX": 0}
+	withReference := 0
+	for _, marker := range markers {
+		counts[marker.Type]++
+		if marker.Reference != "" {
+			withReference++
+		}
+	}
+
+	total := len(markers)
+	builder.AddMetric("total_markers", total)
+	builder.AddMetric("todo_count", counts["TODO"])
+	builder.AddMetric("fixme_count", counts["FIXME"])
+	builder.AddMetric("hack_count", counts["HACK"])
+	builder.AddMetric("xxx_count", counts["XXX"])
+	builder.AddMetric("markers_with_reference", withReference)
+	builder.AddMetric("threshold", threshold)
+
+	if total <= threshold {
+		builder.WithStatus(core.StatusHealthy)
+		builder.WithScore(100, 100)
+		return builder.Build(), nil
+	}
+
+	// Degrade the score proportionally to how far the threshold is exceeded
+	score := 50
+	if threshold > 0 {
+		score = 100 - ((total-threshold)*50)/threshold
+	}
+	if score < 0 {
+		score = 0
+	}
+	builder.WithScore(score, 100)
+
+	if score >= 50 {
+		builder.WithStatus(core.StatusWarning)
+	} else {
+		builder.WithStatus(core.StatusCritical)
+	}
+
+	builder.AddIssue(base.NewIssueWithSuggestion(
+		"tech_debt_threshold_exceeded",
+		core.SeverityMedium,
+		fmt.Sprintf("Found %d technical debt markers (threshold %d): %d TODO, %d FIXME, %d HACK, %d XXX",
+			total, threshold, counts["TODO"], counts["FIXME"], counts["HACK"], counts["XXX"]),
+		"Resolve or convert outstanding TODO/FIXME markers into tracked issues",
+	))
+
+	// Report the worst offending files
+	for _, offender := range c.worstOffenders(markers, repoCtx.Repository.Path) {
+		builder.AddIssue(offender)
+	}
+
+	return builder.Build(), nil
+}
+
+// worstOffenders returns one issue per file with the most markers, pointing at the first marker
+func (c *TechDebtChecker) worstOffenders(markers []DebtMarker, repoPath string) []core.Issue {
+	byFile := make(map[string][]DebtMarker)
+	var files []string
+	for _, marker := range markers {
+		if _, exists := byFile[marker.File]; !exists {
+			files = append(files, marker.File)
+		}
+		byFile[marker.File] = append(byFile[marker.File], marker)
+	}
+
+	sort.SliceStable(files, func(i, j int) bool {
+		return len(byFile[files[i]]) > len(byFile[files[j]])
+	})
+
+	if len(files) > maxReportedOffenders {
+		files = files[:maxReportedOffenders]
+	}
+
+	issues := make([]core.Issue, 0, len(files))
+	for _, file := range files {
+		fileMarkers := byFile[file]
+		relPath, err := filepath.Rel(repoPath, file)
+		if err != nil {
+			relPath = file
+		}
+
+		issue := base.NewIssueWithLocation(
+			"tech_debt_hotspot",
+			core.SeverityLow,
+			fmt.Sprintf("%s contains %d technical debt markers", relPath, len(fileMarkers)),
+			relPath,
+			fileMarkers[0].Line,
+			0,
+		)
+		issue.Context["count"] = len(fileMarkers)
+		if ref := fileMarkers[0].Reference; ref != "" {
+			issue.Context["reference"] = ref
+		}
+		issues = append(issues, issue)
+	}
+
+	return issues
+}
+
+// scanRepository walks the repository and collects debt markers from source
+// files, returning the markers and the number of binary files skipped
+func (c *TechDebtChecker) scanRepository(ctx context.Context, repoPath string) ([]DebtMarker, int, error) {
+	var markers []DebtMarker
+	binarySkipped := 0
+
+	err := filepath.Walk(repoPath, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return nil // Skip unreadable entries
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		relPath, _ := filepath.Rel(repoPath, path)
+		if info.IsDir() {
+			for _, exclude := range c.config.Excludes {
+				if strings.HasPrefix(relPath+"/", exclude) || strings.Contains(relPath+"/", "/"+exclude) {
+					return filepath.SkipDir
+				}
+			}
+			return nil
+		}
+
+		if !c.isSourceFile(path) {
+			return nil
+		}
+
+		fileMarkers, err := c.scanFile(path)
+		if errors.Is(err, language.ErrBinaryFile) {
+			binarySkipped++
+			return nil
+		}
+		if err != nil {
+			return nil // Skip files that cannot be read
+		}
+		markers = append(markers, fileMarkers...)
+		return nil
+	})
+
+	return markers, binarySkipped, err
+}
+
+// isSourceFile checks whether the file has a supported source extension
+func (c *TechDebtChecker) isSourceFile(path string) bool {
+	ext := filepath.Ext(path)
+	for _, supported := range c.config.Extensions {
+		if ext == supported {
+			return true
+		}
+	}
+	return false
+}
+
+// scanFile collects debt markers from the comments of a single file. Binary
+// files return language.ErrBinaryFile.
+func (c *TechDebtChecker) scanFile(path string) ([]DebtMarker, error) {
+	content, err := language.ReadText(path)
+	if err != nil {
+		return nil, err
+	}
+
+	hashComments := usesHashComments(filepath.Ext(path))
+	var markers []DebtMarker
+	inBlockComment := false
+
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+
+		var comment string
+		comment, inBlockComment = extractComment(scanner.Text(), hashComments, inBlockComment)
+		if comment == "" {
+			continue
+		}
+
+		for _, match := range markerPattern.FindAllStringSubmatch(comment, -1) {
+			markers = append(markers, DebtMarker{
+				Type:      match[1],
+				File:      path,
+				Line:      lineNum,
+				Reference: markerReference(match[2], match[3]),
+				Text:      strings.TrimSpace(match[3]),
+			})
+		}
+	}
+
+	return markers, scanner.Err()
+}
+
+// markerReference extracts the author or ticket from a marker, e.g. TODO(jsmith) or FIXME: JIRA-123
+func markerReference(parenthesized, text string) string {
+	if ref := strings.TrimSpace(parenthesized); ref != "" {
+		return ref
+	}
+	return ticketPattern.FindString(text)
+}
+
+// usesHashComments reports whether the language uses # for line comments
+func usesHashComments(ext string) bool {
+	switch ext {
+	case ".py", ".rb", ".sh":
+		return true
+	default:
+		return false
+	}
+}
+
+// extractComment returns the comment text on a line, ignoring comment tokens inside
+// string literals. It tracks whether a /* */ block comment continues onto the next line.
+//
+//nolint:gocyclo // Character-level scanning requires many branches
+func extractComment(line string, hashComments, inBlockComment bool) (string, bool) {
+	var comment strings.Builder
+	var quote byte
+
+	for i := 0; i < len(line); i++ {
+		ch := line[i]
+
+		if inBlockComment {
+			if ch == '*' && i+1 < len(line) && line[i+1] == '/' {
+				inBlockComment = false
+				comment.WriteByte(' ')
+				i++
+				continue
+			}
+			comment.WriteByte(ch)
+			continue
+		}
+
+		if quote != 0 {
+			if ch == '\\' {
+				i++ // Skip escaped character
+			} else if ch == quote {
+				quote = 0
+			}
+			continue
+		}
+
+		switch {
+		case ch == '"' || ch == '\'' || ch == '`':
+			quote = ch
+		case hashComments && ch == '#':
+			comment.WriteString(line[i+1:])
+			return comment.String(), false
+		case !hashComments && ch == '/' && i+1 < len(line) && line[i+1] == '/':
+			comment.WriteString(line[i+2:])
+			return comment.String(), false
+		case !hashComments && ch == '/' && i+1 < len(line) && line[i+1] == '*':
+			inBlockComment = true
+			i++
+		}
+	}
+
+	return comment.String(), inBlockComment
+}
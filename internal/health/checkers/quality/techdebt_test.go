@@ -0,0 +1,171 @@
+package quality
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/codcod/repos/internal/core"
+)
+
+func TestExtractComment(t *testing.T) {
+	tests := []struct {
+		name          string
+		line          string
+		hashComments  bool
+		inBlock       bool
+		expected      string
+		expectedBlock bool
+	}{
+		{"line comment", `x := 1 // TODO: fix`, false, false, " TODO: fix", false},
+		{"marker in string", `s := "TODO: not a comment"`, false, false, "", false},
+		{"slashes in string", `url := "http://example.com" // FIXME`, false, false, " FIXME", false},
+		{"block comment start", `/* HACK start`, false, false, " HACK start", true},
+		{"block comment end", `still HACK */ code()`, false, true, "still HACK  ", false},
+		{"hash comment", `x = 1  # XXX later`, true, false, " XXX later", false},
+		{"hash in string", `x = "#TODO"`, true, false, "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			comment, inBlock := extractComment(tt.line, tt.hashComments, tt.inBlock)
+			if comment != tt.expected || inBlock != tt.expectedBlock {
+				t.Errorf("extractComment(%q) = (%q, %v), want (%q, %v)",
+					tt.line, comment, inBlock, tt.expected, tt.expectedBlock)
+			}
+		})
+	}
+}
+
+func TestTechDebtChecker_ScanFile(t *testing.T) {
+	tempDir := t.TempDir()
+	file := filepath.Join(tempDir, "main.go")
+	content := `package main
+
+// TODO(jsmith): refactor this
+// FIXME: JIRA-123 handle errors
+func main() {
+	msg := "TODO in a string is ignored"
+	todoList := 1 // TODOS is not a marker
+	_ = msg
+	_ = todoList
+	/* HACK: temporary
+	   XXX remove */
+}
+`
+	if err := os.WriteFile(file, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	checker := NewTechDebtChecker()
+	markers, err := checker.scanFile(file)
+	if err != nil {
+		t.Fatalf("scanFile failed: %v", err)
+	}
+
+	expected := []DebtMarker{
+		{Type: "TODO", Line: 3, Reference: "jsmith"},
+		{Type: "FIXME", Line: 4, Reference: "JIRA-123"},
+		{Type: "HACK", Line: 10},
+		{Type: "XXX", Line: 11},
+	}
+
+	if len(markers) != len(expected) {
+		t.Fatalf("Expected %d markers, got %d: %+v", len(expected), len(markers), markers)
+	}
+
+	for i, want := range expected {
+		got := markers[i]
+		if got.Type != want.Type || got.Line != want.Line || got.Reference != want.Reference {
+			t.Errorf("marker %d = %+v, want type=%s line=%d reference=%q",
+				i, got, want.Type, want.Line, want.Reference)
+		}
+	}
+}
+
+func TestTechDebtChecker_Threshold(t *testing.T) {
+	tempDir := t.TempDir()
+	content := "# TODO one\n# FIXME two\n# HACK three\n"
+	if err := os.WriteFile(filepath.Join(tempDir, "script.py"), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// Files in excluded directories are ignored
+	vendorDir := filepath.Join(tempDir, "vendor")
+	if err := os.MkdirAll(vendorDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(vendorDir, "lib.go"), []byte("// TODO vendored\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	repoCtx := core.RepositoryContext{
+		Repository: core.Repository{Name: "test-repo", Path: tempDir},
+	}
+
+	// Under the threshold
+	checker := NewTechDebtChecker()
+	result, err := checker.Check(context.Background(), repoCtx)
+	if err != nil {
+		t.Fatalf("Check failed: %v", err)
+	}
+	if result.Status != core.StatusHealthy {
+		t.Errorf("Expected healthy status, got %s", result.Status)
+	}
+	if result.Metrics["total_markers"] != 3 {
+		t.Errorf("Expected 3 markers, got %v", result.Metrics["total_markers"])
+	}
+
+	// Over the threshold
+	checker = NewTechDebtCheckerWithConfig(&TechDebtCheckerConfig{
+		Threshold:  2,
+		Extensions: []string{".py", ".go"},
+		Excludes:   []string{"vendor/"},
+	})
+	result, err = checker.Check(context.Background(), repoCtx)
+	if err != nil {
+		t.Fatalf("Check failed: %v", err)
+	}
+	if result.Status != core.StatusWarning {
+		t.Errorf("Expected warning status, got %s", result.Status)
+	}
+	if len(result.Issues) != 2 {
+		t.Fatalf("Expected threshold issue and one hotspot, got %d issues", len(result.Issues))
+	}
+
+	hotspot := result.Issues[1]
+	if hotspot.Location == nil || hotspot.Location.File != "script.py" || hotspot.Location.Line != 1 {
+		t.Errorf("Expected hotspot at script.py:1, got %+v", hotspot.Location)
+	}
+}
+
+func TestTechDebtChecker_SkipsBinaryFiles(t *testing.T) {
+	repoPath := t.TempDir()
+	files := map[string]string{
+		"main.go":   "package main\n\n// TODO: real marker\n",
+		"blob.go":   "// TODO\x00\x01 not source\n",
+		"legacy.py": "# caf\xe9\n# FIXME: encoding\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(repoPath, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	markers, binarySkipped, err := NewTechDebtChecker().scanRepository(context.Background(), repoPath)
+	if err != nil {
+		t.Fatalf("scanRepository failed: %v", err)
+	}
+	if binarySkipped != 1 {
+		t.Errorf("Expected 1 binary file skipped, got %d", binarySkipped)
+	}
+	if len(markers) != 2 {
+		t.Fatalf("Expected 2 markers, got %+v", markers)
+	}
+	for _, marker := range markers {
+		if filepath.Base(marker.File) == "legacy.py" && marker.Line != 2 {
+			t.Errorf("Expected the FIXME on line 2 despite invalid UTF-8, got %d", marker.Line)
+		}
+	}
+}
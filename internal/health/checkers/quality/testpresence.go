@@ -0,0 +1,293 @@
+package quality
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"math"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/codcod/repos/internal/core"
+	"github.com/codcod/repos/internal/health/analyzers/language"
+	"github.com/codcod/repos/internal/health/checkers/base"
+)
+
+// DefaultTestPatterns are the patterns identifying test files per language. A
+// pattern ending in a slash matches files under a directory: a single name such
+// as "tests/" matches that directory at any depth, a path such as
+// "src/test/java/" matches it at the repository root or below any directory.
+// Other patterns are matched against the file name.
+var DefaultTestPatterns = map[string][]string{
+	"go":         {"*_test.go"},
+	"python":     {"test_*.py", "*_test.py", "tests/", "test/"},
+	"javascript": {"*.test.*", "*.spec.*", "__tests__/", "test/", "tests/"},
+	"java":       {"src/test/java/", "*Test.java", "*Tests.java", "*IT.java"},
+	"kotlin":     {"src/test/kotlin/", "*Test.kt", "*Tests.kt"},
+	"scala":      {"src/test/scala/", "*Spec.scala", "*Test.scala"},
+	"rust":       {"tests/", "*_test.rs"},
+	"ruby":       {"*_spec.rb", "*_test.rb", "spec/", "test/"},
+	"php":        {"*Test.php", "tests/"},
+	"csharp":     {"*Tests.cs", "*Test.cs"},
+	"swift":      {"Tests/", "*Tests.swift"},
+	"dart":       {"*_test.dart", "test/"},
+	"elixir":     {"*_test.exs", "test/"},
+	"shell":      {"*_test.sh", "test/", "tests/"},
+	"c":          {"test_*.c", "*_test.c", "test/", "tests/"},
+	"cpp":        {"*_test.cpp", "*_test.cc", "test/", "tests/"},
+}
+
+// TestPresenceChecker reports languages of a repository that have source files
+// but no tests, and empty test directories
+type TestPresenceChecker struct {
+	*base.BaseChecker
+}
+
+// NewTestPresenceChecker creates a new test presence checker
+func NewTestPresenceChecker() *TestPresenceChecker {
+	config := core.CheckerConfig{
+		Enabled:    true,
+		Severity:   "medium",
+		Timeout:    30 * time.Second,
+		Categories: []string{"quality"},
+	}
+
+	return &TestPresenceChecker{
+		BaseChecker: base.NewBaseChecker("test-presence", "Test Presence", "quality", config),
+	}
+}
+
+// Metadata describes what the checker verifies
+func (c *TestPresenceChecker) Metadata() core.CheckerMetadata {
+	return core.CheckerMetadata{
+		Description: "Checks that each language with source files also has test files, such as *_test.go, tests/ or src/test/java, " +
+			"and reports empty test directories. The number of test files and the test-to-source ratio are reported as metrics.",
+		Options: []core.CheckerOption{
+			{Name: "patterns", Default: map[string][]string{}, Description: "Test file patterns per language; a configured language replaces its default patterns"},
+		},
+	}
+}
+
+// Check performs the test presence check
+func (c *TestPresenceChecker) Check(ctx context.Context, repoCtx core.RepositoryContext) (core.CheckResult, error) {
+	return c.Execute(ctx, repoCtx, func() (core.CheckResult, error) {
+		return c.checkTestPresence(ctx, repoCtx)
+	})
+}
+
+// testInventory counts the source and test files of a repository
+type testInventory struct {
+	sources   map[string]int // non-test source files per language
+	tests     map[string]int // test files per language
+	testDirs  map[string]int // files under each directory named by a pattern
+	dirOrders []string       // testDirs keys in walk order
+}
+
+// checkTestPresence performs the actual test presence check
+func (c *TestPresenceChecker) checkTestPresence(ctx context.Context, repoCtx core.RepositoryContext) (core.CheckResult, error) {
+	builder := base.NewResultBuilder(c.ID(), c.Name(), c.Category())
+
+	patterns, err := c.patterns(repoCtx)
+	if err != nil {
+		return core.CheckResult{}, err
+	}
+
+	inventory, err := scanTests(ctx, repoCtx.Repository.Path, patterns)
+	if err != nil {
+		return core.CheckResult{}, err
+	}
+
+	totalSources, totalTests, languages := inventory.totals()
+
+	ratio := 0.0
+	if totalSources > 0 {
+		ratio = math.Round(float64(totalTests)/float64(totalSources)*100) / 100
+	}
+	builder.AddMetric("test_files", totalTests)
+	builder.AddMetric("source_files", totalSources)
+	builder.AddMetric("test_ratio", ratio)
+
+	// Untested languages lower the score by their share of the source files
+	untested := 0
+	for _, lang := range languages {
+		sources := inventory.sources[lang]
+		if inventory.tests[lang] > 0 {
+			continue
+		}
+		untested += sources
+		builder.AddIssue(missingTestsIssue(lang, sources, patterns[lang]))
+	}
+	if untested > 0 {
+		builder.WithScore(100-untested*50/totalSources, 100)
+	}
+
+	for _, dir := range inventory.dirOrders {
+		if inventory.testDirs[dir] > 0 {
+			continue
+		}
+		builder.AddIssue(base.NewIssueWithLocation(
+			"empty_test_directory",
+			core.SeverityLow,
+			fmt.Sprintf("Test directory %s/ is empty", dir),
+			dir, 0, 0,
+		))
+	}
+
+	return builder.Build(), nil
+}
+
+// missingTestsIssue reports a language with source files but no tests
+func missingTestsIssue(lang string, sources int, patterns []string) core.Issue {
+	noun := "files"
+	if sources == 1 {
+		noun = "file"
+	}
+	issue := base.NewIssueWithSuggestion(
+		"missing_tests",
+		core.SeverityMedium,
+		fmt.Sprintf("No %s test files found for %d source %s", lang, sources, noun),
+		fmt.Sprintf("Add tests matching one of: %s", strings.Join(patterns, ", ")),
+	)
+	issue.Context["language"] = lang
+	return issue
+}
+
+// patterns returns the default test patterns with those configured in the
+// patterns option, which replace the defaults of their language
+func (c *TestPresenceChecker) patterns(repoCtx core.RepositoryContext) (map[string][]string, error) {
+	var configured map[string][]string
+	if _, err := c.DecodeOption(repoCtx, "patterns", &configured); err != nil {
+		return nil, err
+	}
+
+	patterns := make(map[string][]string, len(DefaultTestPatterns)+len(configured))
+	for lang, langPatterns := range DefaultTestPatterns {
+		patterns[lang] = langPatterns
+	}
+	for lang, langPatterns := range configured {
+		patterns[strings.ToLower(lang)] = langPatterns
+	}
+	return patterns, nil
+}
+
+// scanTests classifies the source files of a repository as tests or sources.
+// Only languages with patterns are counted.
+func scanTests(ctx context.Context, repoPath string, patterns map[string][]string) (*testInventory, error) {
+	inventory := &testInventory{
+		sources:  make(map[string]int),
+		tests:    make(map[string]int),
+		testDirs: make(map[string]int),
+	}
+
+	dirNames := testDirNames(patterns)
+
+	err := filepath.WalkDir(repoPath, func(filePath string, d fs.DirEntry, err error) error {
+		if filePath == repoPath {
+			return err
+		}
+		if err != nil {
+			return nil // Skip unreadable entries
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		rel, err := filepath.Rel(repoPath, filePath)
+		if err != nil {
+			return nil
+		}
+		rel = filepath.ToSlash(rel)
+
+		if d.IsDir() {
+			if language.SkipDir(d.Name()) {
+				return filepath.SkipDir
+			}
+			if dirNames[d.Name()] {
+				inventory.testDirs[rel] = 0
+				inventory.dirOrders = append(inventory.dirOrders, rel)
+			}
+			return nil
+		}
+
+		inventory.addFile(rel, patterns)
+		return nil
+	})
+
+	return inventory, err
+}
+
+// testDirNames returns the directories named by a single-name pattern, e.g. tests/
+func testDirNames(patterns map[string][]string) map[string]bool {
+	dirNames := make(map[string]bool)
+	for _, langPatterns := range patterns {
+		for _, pattern := range langPatterns {
+			if name, ok := strings.CutSuffix(pattern, "/"); ok && !strings.Contains(name, "/") {
+				dirNames[name] = true
+			}
+		}
+	}
+	return dirNames
+}
+
+// addFile counts a file in the test directories it is under, and as a test or
+// source of its language when the language has patterns
+func (inv *testInventory) addFile(rel string, patterns map[string][]string) {
+	for dir := path.Dir(rel); dir != "."; dir = path.Dir(dir) {
+		if _, ok := inv.testDirs[dir]; ok {
+			inv.testDirs[dir]++
+		}
+	}
+
+	lang := language.ForFile(rel)
+	if lang == "" || len(patterns[lang]) == 0 {
+		return
+	}
+	if isTestFile(rel, patterns[lang]) {
+		inv.tests[lang]++
+	} else {
+		inv.sources[lang]++
+	}
+}
+
+// totals returns the number of source and test files, and the languages with
+// sources in name order
+func (inv *testInventory) totals() (int, int, []string) {
+	totalSources, totalTests := 0, 0
+	var languages []string
+	for lang, count := range inv.sources {
+		totalSources += count
+		languages = append(languages, lang)
+	}
+	for _, count := range inv.tests {
+		totalTests += count
+	}
+	sort.Strings(languages)
+	return totalSources, totalTests, languages
+}
+
+// isTestFile reports whether a slash-separated relative path matches any test pattern
+func isTestFile(rel string, patterns []string) bool {
+	for _, pattern := range patterns {
+		if dir, ok := strings.CutSuffix(pattern, "/"); ok {
+			if strings.Contains(dir, "/") {
+				if strings.HasPrefix(rel, pattern) || strings.Contains(rel, "/"+pattern) {
+					return true
+				}
+				continue
+			}
+			for _, component := range strings.Split(path.Dir(rel), "/") {
+				if component == dir {
+					return true
+				}
+			}
+			continue
+		}
+		if matched, _ := path.Match(pattern, path.Base(rel)); matched {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,91 @@
+package quality
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/codcod/repos/internal/core"
+)
+
+func TestTestPresenceChecker(t *testing.T) {
+	repoPath := t.TempDir()
+	for _, name := range []string{
+		"main.go", "util.go", "util_test.go",
+		"scripts/gen.py", "scripts/lint.py", "scripts/check/test_lint.py",
+		"web/app.ts", "web/app.js",
+		"node_modules/lib/index.test.js",
+	} {
+		path := filepath.Join(repoPath, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte("x"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.MkdirAll(filepath.Join(repoPath, "web", "__tests__"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	checker := NewTestPresenceChecker()
+	repoCtx := core.RepositoryContext{
+		Repository: core.Repository{Name: "app", Path: repoPath},
+		Config:     unusedExportsConfig{options: map[string]interface{}{}},
+	}
+	result, err := checker.Check(context.Background(), repoCtx)
+	if err != nil {
+		t.Fatalf("Check failed: %v", err)
+	}
+
+	if result.Metrics["test_files"] != 2 || result.Metrics["source_files"] != 6 || result.Metrics["test_ratio"] != 0.33 {
+		t.Errorf("Unexpected metrics: %v", result.Metrics)
+	}
+	if result.Status != core.StatusWarning || result.Score != 84 || len(result.Issues) != 2 {
+		t.Fatalf("Expected untested javascript and an empty test directory, got %s %d %+v", result.Status, result.Score, result.Issues)
+	}
+	if issue := result.Issues[0]; issue.Type != "missing_tests" || issue.Context["language"] != "javascript" ||
+		issue.Message != "No javascript test files found for 2 source files" {
+		t.Errorf("Unexpected missing tests issue: %+v", issue)
+	}
+	if issue := result.Issues[1]; issue.Type != "empty_test_directory" || issue.Location.File != "web/__tests__" {
+		t.Errorf("Unexpected empty directory issue: %+v", issue)
+	}
+
+	// Configured patterns replace the defaults of their language
+	repoCtx.Config = unusedExportsConfig{options: map[string]interface{}{
+		"patterns": map[string]interface{}{"javascript": []interface{}{"web/"}},
+	}}
+	result, err = checker.Check(context.Background(), repoCtx)
+	if err != nil {
+		t.Fatalf("Check failed: %v", err)
+	}
+	if result.Metrics["test_files"] != 4 || result.Status != core.StatusHealthy || len(result.Issues) != 0 {
+		t.Errorf("Expected the web files to count as tests, got %s %v %+v", result.Status, result.Metrics, result.Issues)
+	}
+}
+
+func TestIsTestFile(t *testing.T) {
+	tests := []struct {
+		path     string
+		patterns []string
+		expected bool
+	}{
+		{"pkg/a_test.go", DefaultTestPatterns["go"], true},
+		{"pkg/a.go", DefaultTestPatterns["go"], false},
+		{"src/test/java/com/AppTest.java", DefaultTestPatterns["java"], true},
+		{"service/src/test/java/com/Helper.java", DefaultTestPatterns["java"], true},
+		{"src/main/java/com/App.java", DefaultTestPatterns["java"], false},
+		{"ui/__tests__/button.js", DefaultTestPatterns["javascript"], true},
+		{"ui/button.spec.ts", DefaultTestPatterns["javascript"], true},
+		{"latest/main.py", DefaultTestPatterns["python"], false},
+		{"tests/conftest.py", DefaultTestPatterns["python"], true},
+	}
+
+	for _, tt := range tests {
+		if got := isTestFile(tt.path, tt.patterns); got != tt.expected {
+			t.Errorf("isTestFile(%q) = %v, expected %v", tt.path, got, tt.expected)
+		}
+	}
+}
@@ -0,0 +1,129 @@
+package quality
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/codcod/repos/internal/core"
+	go_analyzer "github.com/codcod/repos/internal/health/analyzers/go"
+	"github.com/codcod/repos/internal/health/checkers/base"
+)
+
+// UnusedExportChecker reports exported Go identifiers that nothing in the module uses.
+// It is a heuristic and disabled by default; enable it under checkers.go-unused-exports
+// and list symbols used through reflection or plugins in the allowlist option.
+type UnusedExportChecker struct {
+	*base.BaseChecker
+}
+
+// NewUnusedExportChecker creates a new Go unused export checker
+func NewUnusedExportChecker() *UnusedExportChecker {
+	config := core.CheckerConfig{
+		Enabled:    false,
+		Severity:   "low",
+		Timeout:    60 * time.Second,
+		Categories: []string{"quality"},
+	}
+
+	return &UnusedExportChecker{
+		BaseChecker: base.NewBaseChecker(
+			"go-unused-exports",
+			"Go Unused Exports",
+			"quality",
+			config,
+		),
+	}
+}
+
+// Metadata describes what the checker verifies
+func (*UnusedExportChecker) Metadata() core.CheckerMetadata {
+	return core.CheckerMetadata{
+		Description: "Reports exported Go functions, types, variables and constants that nothing in the module references. Opt-in and heuristic.",
+		Options: []core.CheckerOption{
+			{Name: "allowlist", Default: []string{}, Description: "Names, packages, pkg.Name or pkg/... patterns to ignore"},
+		},
+	}
+}
+
+// Check performs the unused export check
+func (c *UnusedExportChecker) Check(ctx context.Context, repoCtx core.RepositoryContext) (core.CheckResult, error) {
+	return c.Execute(ctx, repoCtx, func() (core.CheckResult, error) {
+		return c.checkUnusedExports(repoCtx)
+	})
+}
+
+// checkUnusedExports performs the actual unused export check
+func (c *UnusedExportChecker) checkUnusedExports(repoCtx core.RepositoryContext) (core.CheckResult, error) {
+	builder := base.NewResultBuilder(c.ID(), c.Name(), c.Category())
+	allowlist := c.StringSliceOption(repoCtx, "allowlist")
+
+	exports, err := go_analyzer.FindUnusedExports(repoCtx.Repository.Path)
+	if err != nil {
+		return core.CheckResult{}, fmt.Errorf("failed to analyze exports: %w", err)
+	}
+
+	var unused []go_analyzer.UnusedExport
+	for _, export := range exports {
+		if !allowlisted(export, allowlist) {
+			unused = append(unused, export)
+		}
+	}
+
+	builder.AddMetric("unused_exports", len(unused))
+	builder.AddMetric("allowlisted_exports", len(exports)-len(unused))
+
+	if len(unused) == 0 {
+		builder.WithStatus(core.StatusHealthy)
+		builder.WithScore(100, 100)
+		return builder.Build(), nil
+	}
+
+	// Unused exports are a maintenance cost rather than a defect, so the score never drops below half
+	builder.WithStatus(core.StatusWarning)
+	builder.WithScore(max(100-len(unused)*2, 50), 100)
+
+	for _, export := range unused {
+		relPath, _ := filepath.Rel(repoCtx.Repository.Path, export.File)
+		issue := base.NewIssueWithLocation(
+			"unused_export",
+			core.SeverityLow,
+			fmt.Sprintf("Exported %s %s is not used within the module", export.Kind, export.Name),
+			relPath,
+			export.Line,
+			0,
+		)
+		issue.Suggestion = "Unexport or remove it, or add it to the allowlist if it is used via reflection or by other modules"
+		issue.Context["package"] = export.Package
+		builder.AddIssue(issue)
+	}
+
+	return builder.Build(), nil
+}
+
+// allowlisted reports whether an allowlist entry covers the export. Entries may name
+// a symbol (Name), a qualified symbol (example.com/mod/pkg.Name), a package
+// (example.com/mod/pkg) or a package tree (example.com/mod/pkg/...).
+func allowlisted(export go_analyzer.UnusedExport, allowlist []string) bool {
+	for _, entry := range allowlist {
+		switch {
+		case entry == export.Name, entry == export.Package, entry == export.Package+"."+export.Name:
+			return true
+		case strings.HasSuffix(entry, "/..."):
+			prefix := strings.TrimSuffix(entry, "/...")
+			if export.Package == prefix || strings.HasPrefix(export.Package, prefix+"/") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// SupportsRepository checks if the repository is a Go module
+func (c *UnusedExportChecker) SupportsRepository(repo core.Repository) bool {
+	_, err := os.Stat(filepath.Join(repo.Path, "go.mod"))
+	return err == nil
+}
@@ -0,0 +1,77 @@
+package quality
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/codcod/repos/internal/core"
+	go_analyzer "github.com/codcod/repos/internal/health/analyzers/go"
+)
+
+type unusedExportsConfig struct {
+	core.Config
+	options map[string]interface{}
+}
+
+func (c unusedExportsConfig) GetCheckerConfig(checkerID string) (core.CheckerConfig, bool) {
+	return core.CheckerConfig{Enabled: true, Options: c.options}, true
+}
+
+func TestUnusedExportChecker(t *testing.T) {
+	repoPath := t.TempDir()
+	files := map[string]string{
+		"go.mod":     "module example.com/mod\n",
+		"main.go":    "package main\n\nimport \"example.com/mod/lib\"\n\nfunc main() { lib.Used() }\n",
+		"lib/lib.go": "package lib\n\nfunc Used() {}\n\nfunc Stale() {}\n\nfunc ServeHTTP() {}\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(repoPath, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	checker := NewUnusedExportChecker()
+	if checker.Config().Enabled {
+		t.Error("Expected the unused export checker to be opt-in")
+	}
+
+	repoCtx := core.RepositoryContext{
+		Repository: core.Repository{Name: "mod", Path: repoPath},
+		Config:     unusedExportsConfig{options: map[string]interface{}{"allowlist": []interface{}{"ServeHTTP"}}},
+	}
+	result, err := checker.Check(context.Background(), repoCtx)
+	if err != nil {
+		t.Fatalf("Check failed: %v", err)
+	}
+
+	if result.Status != core.StatusWarning {
+		t.Errorf("Expected warning status, got %s", result.Status)
+	}
+	if result.Metrics["unused_exports"] != 1 || result.Metrics["allowlisted_exports"] != 1 {
+		t.Errorf("Unexpected metrics: %v", result.Metrics)
+	}
+	if len(result.Issues) != 1 || result.Issues[0].Location.File != filepath.Join("lib", "lib.go") {
+		t.Fatalf("Expected one issue in lib/lib.go, got %+v", result.Issues)
+	}
+}
+
+func TestAllowlisted(t *testing.T) {
+	export := go_analyzer.UnusedExport{Package: "example.com/mod/plugins/auth", Name: "Handler"}
+
+	for _, entry := range []string{"Handler", "example.com/mod/plugins/auth", "example.com/mod/plugins/auth.Handler", "example.com/mod/plugins/..."} {
+		if !allowlisted(export, []string{entry}) {
+			t.Errorf("Expected %q to allowlist the export", entry)
+		}
+	}
+	for _, entry := range []string{"Other", "example.com/mod/plugins", "example.com/mod/plug/..."} {
+		if allowlisted(export, []string{entry}) {
+			t.Errorf("Expected %q not to allowlist the export", entry)
+		}
+	}
+}
@@ -3,15 +3,21 @@ package registry
 import (
 	"context"
 	"fmt"
+	"sort"
+	"strings"
 	"sync"
 
 	"github.com/codcod/repos/internal/core"
 	"github.com/codcod/repos/internal/health/checkers/ci"
 	"github.com/codcod/repos/internal/health/checkers/compliance"
+	"github.com/codcod/repos/internal/health/checkers/custom"
 	"github.com/codcod/repos/internal/health/checkers/dependencies"
 	"github.com/codcod/repos/internal/health/checkers/docs"
 	"github.com/codcod/repos/internal/health/checkers/git"
+	"github.com/codcod/repos/internal/health/checkers/iac"
+	"github.com/codcod/repos/internal/health/checkers/quality"
 	"github.com/codcod/repos/internal/health/checkers/security"
+	healthconfig "github.com/codcod/repos/internal/health/config"
 	"github.com/codcod/repos/internal/platform/commands"
 )
 
@@ -38,22 +44,65 @@ func (r *CheckerRegistry) registerDefaultCheckers(executor commands.CommandExecu
 	// Git checkers
 	r.Register(git.NewGitStatusChecker(executor))
 	r.Register(git.NewLastCommitChecker(executor))
+	r.Register(git.NewBusFactorChecker(executor))
+	r.Register(git.NewGitHooksChecker())
+	r.Register(git.NewGitSizeChecker(executor))
+	r.Register(git.NewRemoteChecker(executor))
 
 	// Security checkers
 	r.Register(security.NewBranchProtectionChecker(executor))
 	r.Register(security.NewVulnerabilityChecker(executor))
+	r.Register(security.NewSecretsChecker(executor))
 
 	// Dependency checkers
 	r.Register(dependencies.NewOutdatedChecker(executor))
 
 	// Compliance checkers
 	r.Register(compliance.NewLicenseChecker())
+	r.Register(compliance.NewDependencyLicenseChecker(executor))
 
 	// CI/CD checkers
 	r.Register(ci.NewCIConfigChecker())
 
 	// Documentation checkers
 	r.Register(docs.NewReadmeChecker())
+
+	// Code quality checkers
+	r.Register(quality.NewTechDebtChecker())
+	r.Register(quality.NewFunctionLengthChecker())
+	r.Register(quality.NewDuplicationChecker())
+	r.Register(quality.NewComplexityChecker())
+	r.Register(quality.NewImportCycleChecker())
+	r.Register(quality.NewUnusedExportChecker())
+	r.Register(quality.NewGoLintChecker(executor))
+	r.Register(quality.NewBuildChecker(executor))
+	r.Register(quality.NewTestPresenceChecker())
+	r.Register(iac.NewTerraformChecker())
+	r.Register(iac.NewK8sManifestChecker())
+}
+
+// RegisterCustomCheckers registers the command-based checkers defined under
+// extensions.custom_checkers. It registers none of them if any definition is
+// invalid, its command cannot be found or its ID is already taken.
+func (r *CheckerRegistry) RegisterCustomCheckers(definitions []healthconfig.CustomCheckerConfig, executor commands.CommandExecutor) error {
+	checkers := make([]core.Checker, 0, len(definitions))
+	ids := make(map[string]bool, len(definitions))
+	for _, definition := range definitions {
+		checker, err := custom.NewCommandChecker(definition, executor)
+		if err != nil {
+			return err
+		}
+		if _, err := r.GetChecker(definition.ID); err == nil || ids[definition.ID] {
+			return fmt.Errorf("custom checker '%s': a checker with this ID is already registered", definition.ID)
+		}
+		ids[definition.ID] = true
+		checkers = append(checkers, checker)
+	}
+
+	for _, checker := range checkers {
+		r.Register(checker)
+	}
+	return nil
 }
 
 // Register adds a checker to the registry
@@ -85,6 +134,32 @@ func (r *CheckerRegistry) GetChecker(checkerID string) (core.Checker, error) {
 	return checker, nil
 }
 
+// ValidateCheckerIDs returns an error naming any ID that is not registered,
+// together with the list of valid IDs
+func (r *CheckerRegistry) ValidateCheckerIDs(checkerIDs []string) error {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var unknown []string
+	for _, id := range checkerIDs {
+		if _, exists := r.checkers[id]; !exists {
+			unknown = append(unknown, id)
+		}
+	}
+	if len(unknown) == 0 {
+		return nil
+	}
+
+	valid := make([]string, 0, len(r.checkers))
+	for id := range r.checkers {
+		valid = append(valid, id)
+	}
+	sort.Strings(valid)
+
+	return fmt.Errorf("unknown checker ID(s): %s (valid checker IDs: %s)",
+		strings.Join(unknown, ", "), strings.Join(valid, ", "))
+}
+
 // GetCheckers returns all registered checkers
 func (r *CheckerRegistry) GetCheckers() []core.Checker {
 	r.mu.RLock()
@@ -0,0 +1,68 @@
+package registry
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/codcod/repos/internal/core"
+	healthconfig "github.com/codcod/repos/internal/health/config"
+	"github.com/codcod/repos/internal/platform/commands"
+)
+
+func TestCheckerRegistry_ValidateCheckerIDs(t *testing.T) {
+	registry := NewCheckerRegistry(commands.NewMockCommandExecutor())
+
+	if err := registry.ValidateCheckerIDs([]string{"git-status", "readme-check"}); err != nil {
+		t.Errorf("Expected registered IDs to be valid, got %v", err)
+	}
+
+	err := registry.ValidateCheckerIDs([]string{"git-stats"})
+	if err == nil {
+		t.Fatal("Expected an error for an unknown checker ID")
+	}
+	if !strings.Contains(err.Error(), "git-stats") || !strings.Contains(err.Error(), "git-status") {
+		t.Errorf("Expected error to name the typo and list valid IDs, got %v", err)
+	}
+}
+
+func TestDefaultCheckers_DescribeThemselves(t *testing.T) {
+	registry := NewCheckerRegistry(commands.NewMockCommandExecutor())
+
+	for _, checker := range registry.GetCheckers() {
+		describer, ok := checker.(core.CheckerDescriber)
+		if !ok {
+			t.Errorf("Checker %s does not provide metadata", checker.ID())
+			continue
+		}
+		metadata := describer.Metadata()
+		if metadata.Description == "" {
+			t.Errorf("Checker %s has no description", checker.ID())
+		}
+		for _, option := range metadata.Options {
+			if option.Name == "" || option.Description == "" {
+				t.Errorf("Checker %s has an undocumented option %+v", checker.ID(), option)
+			}
+		}
+	}
+}
+
+func TestCheckerRegistry_RegisterCustomCheckers(t *testing.T) {
+	executor := commands.NewMockCommandExecutor()
+	registry := NewCheckerRegistry(executor)
+
+	definitions := []healthconfig.CustomCheckerConfig{{ID: "org-check", Command: "org-check"}}
+	if err := registry.RegisterCustomCheckers(definitions, executor); err != nil {
+		t.Fatalf("RegisterCustomCheckers failed: %v", err)
+	}
+	if _, err := registry.GetChecker("org-check"); err != nil {
+		t.Errorf("Expected the custom checker to be registered, got %v", err)
+	}
+
+	clash := []healthconfig.CustomCheckerConfig{{ID: "new-check", Command: "new"}, {ID: "git-status", Command: "git"}}
+	if err := registry.RegisterCustomCheckers(clash, executor); err == nil {
+		t.Error("Expected an error for a custom checker that reuses a built-in ID")
+	}
+	if _, err := registry.GetChecker("new-check"); err == nil {
+		t.Error("Expected no custom checker to be registered when one is invalid")
+	}
+}
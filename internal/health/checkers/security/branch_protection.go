@@ -2,21 +2,50 @@ package security
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net/http"
+	"net/url"
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/codcod/repos/internal/core"
+	"github.com/codcod/repos/internal/github"
 	"github.com/codcod/repos/internal/health/checkers/base"
+	"github.com/codcod/repos/internal/platform/cache"
 	"github.com/codcod/repos/internal/platform/commands"
 )
 
+const (
+	// protectionCacheTTL is how long a GitHub protection lookup is reused
+	protectionCacheTTL = 5 * time.Minute
+	// defaultRateLimitBackoff is the first wait before retrying a rate-limited
+	// lookup when the API does not say how long to wait
+	defaultRateLimitBackoff = 2 * time.Second
+)
+
+var (
+	// errGitHubRateLimited is returned when the GitHub API rate limit is still exceeded after the retries
+	errGitHubRateLimited = errors.New("GitHub API rate limit exceeded")
+	// errGitHubNotVisible is returned when GitHub does not find the repository
+	// or branch, as it also answers for private repositories without a token
+	errGitHubNotVisible = errors.New("repository or branch not found on GitHub; set github_token or GITHUB_TOKEN for private repositories")
+	// errNoPlatformAPI is returned for platforms whose protection settings are not looked up
+	errNoPlatformAPI = errors.New("branch protection lookup is not supported for this platform")
+)
+
 // BranchProtectionChecker checks if the main branch has protection enabled
 type BranchProtectionChecker struct {
 	*base.BaseChecker
-	executor commands.CommandExecutor
+	executor   commands.CommandExecutor
+	httpClient *http.Client
+	cache      core.Cache
+	backoff    time.Duration
+	// githubClients holds a *github.Client per API URL and token
+	githubClients sync.Map
 }
 
 // NewBranchProtectionChecker creates a new branch protection checker
@@ -35,7 +64,31 @@ func NewBranchProtectionChecker(executor commands.CommandExecutor) *BranchProtec
 			"security",
 			config,
 		),
-		executor: executor,
+		executor:   executor,
+		httpClient: &http.Client{Timeout: gitLabRequestTimeout},
+		cache:      cache.NewMemoryCache(),
+		backoff:    defaultRateLimitBackoff,
+	}
+}
+
+// Metadata describes what the checker verifies
+func (*BranchProtectionChecker) Metadata() core.CheckerMetadata {
+	return core.CheckerMetadata{
+		Description: "Checks that the default branch is protected on GitHub or GitLab and that changes reach it through merges rather than direct pushes. " +
+			"The platform is detected from the remote URL; GitHub and GitLab are checked through their APIs, " +
+			"and other platforms only by their local configuration. " +
+			"The has_github_protection and github_protection_status metrics report the protection on the platform named by the platform metric. " +
+			"The default branch is origin's HEAD, from 'git symbolic-ref refs/remotes/origin/HEAD' or 'git remote show origin', " +
+			"else the local HEAD or default_branch; the default_branch_method metric names the method used.",
+		Options: []core.CheckerOption{
+			{Name: "default_branch", Default: "", Description: "Default branch to use when neither the remote nor the local HEAD names one"},
+			{Name: "platform", Default: "auto", Description: "Hosting platform: auto (from the remote URL), github, gitlab or bitbucket"},
+			{Name: "github_url", Default: "", Description: "GitHub API base URL; remotes on its host are treated as GitHub Enterprise (default: GITHUB_API_URL, else https://api.github.com, or https://<remote host>/api/v3 for remotes whose host names GitHub)"},
+			{Name: "github_token", Default: "", Description: "GitHub token for private repositories, sent only to github.com and the github_url host (default: GITHUB_TOKEN, GH_TOKEN or the token gh is logged in with); other GitHub Enterprise hosts get the token gh is logged in to them with"},
+			{Name: "gitlab_url", Default: "", Description: "Base URL of a self-hosted GitLab; remotes on its host are treated as GitLab (default: https://gitlab.com)"},
+			{Name: "gitlab_token", Default: "", Description: "GitLab token with read_api scope, sent only to gitlab.com and the gitlab_url host (default: GITLAB_TOKEN environment variable)"},
+		},
+		RequiredTools: []string{"git"},
 	}
 }
 
@@ -63,7 +116,7 @@ func (c *BranchProtectionChecker) checkBranchProtection(ctx context.Context, rep
 	}
 
 	// Get the default branch
-	defaultBranch, err := c.getDefaultBranch(ctx, repoCtx.Repository.Path)
+	defaultBranch, method, err := c.getDefaultBranch(ctx, repoCtx)
 	if err != nil {
 		builder.WithStatus(core.StatusWarning)
 		builder.AddWarning(core.Warning{
@@ -71,17 +124,40 @@ func (c *BranchProtectionChecker) checkBranchProtection(ctx context.Context, rep
 			Message: fmt.Sprintf("Unable to determine default branch: %v", err),
 		})
 		defaultBranch = "main" // fallback
+		method = "fallback"
 	}
 
 	builder.AddMetric("default_branch", defaultBranch)
+	builder.AddMetric("default_branch_method", method)
 
 	// Check for local protection configuration
 	hasLocalConfig := c.checkLocalProtectionConfig(repoCtx.Repository.Path)
 	builder.AddMetric("has_local_config", hasLocalConfig)
 
-	// Check for GitHub CLI and protection
-	hasGitHubProtection, ghError := c.checkGitHubProtection(ctx, repoCtx.Repository.Path, defaultBranch)
-	builder.AddMetric("has_github_protection", hasGitHubProtection)
+	// Check protection on the hosting platform
+	location := c.detectPlatform(ctx, repoCtx)
+	platform := location.Platform
+	var hasPlatformProtection bool
+	var platformErr error
+	if platform == "" && location.Host == "" {
+		// Without a remote the repository is looked up on GitHub, as before platforms were detected
+		platform = PlatformGitHub
+	}
+	switch platform {
+	case PlatformGitHub:
+		hasPlatformProtection, platformErr = c.checkGitHubProtection(ctx, repoCtx, location, defaultBranch)
+	case PlatformGitLab:
+		hasPlatformProtection, platformErr = c.checkGitLabProtection(ctx, repoCtx, location, defaultBranch)
+	case PlatformBitbucket:
+		platformErr = errNoPlatformAPI
+	default:
+		// Remotes on unknown hosts are only looked up as GitHub Enterprise when
+		// github_url names their host, so nothing is sent to them otherwise
+		platform = PlatformUnknown
+		platformErr = errNoPlatformAPI
+	}
+	builder.AddMetric("platform", platform)
+	builder.AddMetric("has_github_protection", hasPlatformProtection)
 
 	// Check for common protection patterns
 	protectionIndicators := c.checkCommonProtectionPatterns(repoCtx.Repository.Path)
@@ -92,35 +168,62 @@ func (c *BranchProtectionChecker) checkBranchProtection(ctx context.Context, rep
 	builder.AddMetric("has_merge_patterns", hasMergePatterns)
 
 	// Evaluate overall protection status
-	c.evaluateProtectionStatus(builder, defaultBranch, hasLocalConfig, hasGitHubProtection, protectionIndicators, hasMergePatterns, ghError)
+	c.evaluateProtectionStatus(builder, platform, defaultBranch, hasLocalConfig, hasPlatformProtection, protectionIndicators, hasMergePatterns, platformErr)
 
 	return builder.Build(), nil
 }
 
-// getDefaultBranch attempts to determine the default branch
-func (c *BranchProtectionChecker) getDefaultBranch(ctx context.Context, repoPath string) (string, error) {
-	// Try to get default branch from remote
+// getDefaultBranch determines the default branch and the method that found it.
+// The remote's HEAD is preferred, as recorded by the last clone or fetch and
+// then as reported by the remote, since the checked-out branch of a CI
+// checkout or worktree need not be the default one. The local HEAD, the
+// default_branch option and finally well-known branch names are the fallbacks.
+func (c *BranchProtectionChecker) getDefaultBranch(ctx context.Context, repoCtx core.RepositoryContext) (string, string, error) {
+	repoPath := repoCtx.Repository.Path
+
 	result := c.executor.ExecuteInDir(ctx, repoPath, "git", "symbolic-ref", "refs/remotes/origin/HEAD")
-	if result.Error == nil && len(result.Stdout) > 0 {
-		branch := strings.TrimSpace(result.Stdout)
-		if strings.Contains(branch, "refs/remotes/origin/") {
-			branch = strings.TrimPrefix(branch, "refs/remotes/origin/")
-		}
-		if branch != "" {
-			return branch, nil
-		}
+	if branch := strings.TrimPrefix(strings.TrimSpace(result.Stdout), "refs/remotes/origin/"); result.Error == nil && branch != "" {
+		return branch, "origin_head", nil
 	}
 
-	// Fallback to checking common branch names
-	branches := []string{"main", "master", "develop"}
-	for _, branch := range branches {
+	result = c.executor.ExecuteInDir(ctx, repoPath, "git", "remote", "show", "origin")
+	if branch := parseRemoteHeadBranch(result.Stdout); result.Error == nil && branch != "" {
+		return branch, "remote_show", nil
+	}
+
+	// Fails with a detached HEAD; in a bare repository HEAD names the default branch
+	result = c.executor.ExecuteInDir(ctx, repoPath, "git", "symbolic-ref", "--quiet", "--short", "HEAD")
+	if branch := strings.TrimSpace(result.Stdout); result.Error == nil && branch != "" {
+		return branch, "local_head", nil
+	}
+
+	if branch := c.StringOption(repoCtx, "default_branch", ""); branch != "" {
+		return branch, "configured", nil
+	}
+
+	for _, branch := range []string{"main", "master", "develop"} {
 		result = c.executor.ExecuteInDir(ctx, repoPath, "git", "show-ref", "--verify", "--quiet", "refs/heads/"+branch)
 		if result.Error == nil {
-			return branch, nil
+			return branch, "common_name", nil
 		}
 	}
 
-	return "main", fmt.Errorf("unable to determine default branch")
+	return "main", "", fmt.Errorf("unable to determine default branch")
+}
+
+// parseRemoteHeadBranch returns the branch on the "HEAD branch:" line of 'git
+// remote show' output, or "" when the remote's HEAD is unknown
+func parseRemoteHeadBranch(output string) string {
+	for _, line := range strings.Split(output, "\n") {
+		if branch, ok := strings.CutPrefix(strings.TrimSpace(line), "HEAD branch:"); ok {
+			branch = strings.TrimSpace(branch)
+			if branch == "(unknown)" {
+				return ""
+			}
+			return branch
+		}
+	}
+	return ""
 }
 
 // checkLocalProtectionConfig checks for local branch protection configuration
@@ -140,27 +243,126 @@ func (c *BranchProtectionChecker) checkLocalProtectionConfig(repoPath string) bo
 	return false
 }
 
-// checkGitHubProtection checks GitHub branch protection via CLI
-func (c *BranchProtectionChecker) checkGitHubProtection(ctx context.Context, repoPath, defaultBranch string) (bool, error) {
-	// Check if GitHub CLI is available
-	result := c.executor.Execute(ctx, "which", "gh")
-	if result.Error != nil {
-		return false, fmt.Errorf("GitHub CLI not available")
+// checkGitHubProtection asks the GitHub API whether the default branch is
+// protected. Results are cached by owner/repo/branch; the shared client waits
+// out rate limits and revalidates repeated lookups with their ETag.
+func (c *BranchProtectionChecker) checkGitHubProtection(ctx context.Context, repoCtx core.RepositoryContext, location remoteLocation, defaultBranch string) (bool, error) {
+	if location.Path == "" {
+		return false, fmt.Errorf("unable to determine the GitHub repository from the remote URL")
+	}
+	cacheKey := "branch-protection:github:" + location.Host + "/" + strings.ToLower(location.Path) + "/" + defaultBranch
+	if cached, ok := c.cache.Get(cacheKey); ok {
+		return cached.(bool), nil
 	}
 
-	// Try to get branch protection info
-	result = c.executor.ExecuteInDir(ctx, repoPath, "gh", "api", fmt.Sprintf("repos/:owner/:repo/branches/%s/protection", defaultBranch))
-	if result.Error != nil {
-		return false, result.Error
+	// The branch endpoint reports protection without the admin rights the
+	// protection endpoint needs, and works unauthenticated for public repositories
+	var branch struct {
+		Protected bool `json:"protected"`
+	}
+	client := c.githubClient(ctx, repoCtx, location)
+	err := client.Get(ctx, fmt.Sprintf("/repos/%s/branches/%s", location.Path, url.PathEscape(defaultBranch)), &branch)
+	switch {
+	case errors.Is(err, github.ErrRateLimited):
+		return false, errGitHubRateLimited
+	case github.IsNotFound(err):
+		return false, errGitHubNotVisible
+	case err != nil:
+		return false, err
 	}
 
-	// If we get output and it's not a "not protected" message, assume protection exists
-	output := strings.TrimSpace(result.Stdout)
-	if len(output) > 0 && !strings.Contains(output, "Branch not protected") {
-		return true, nil
+	c.cache.Set(cacheKey, branch.Protected, protectionCacheTTL)
+	return branch.Protected, nil
+}
+
+// githubClient returns the GitHub API client for a repository's host. Clients
+// are shared by every repository with the same API and token so that they
+// share ETags.
+func (c *BranchProtectionChecker) githubClient(ctx context.Context, repoCtx core.RepositoryContext, location remoteLocation) *github.Client {
+	baseURL, token := c.githubEndpoint(ctx, repoCtx, location)
+
+	key := baseURL + "\x00" + token
+	if client, ok := c.githubClients.Load(key); ok {
+		return client.(*github.Client)
 	}
+	client, _ := c.githubClients.LoadOrStore(key, github.NewClient(github.ClientOptions{
+		BaseURL: baseURL,
+		Token:   token,
+		Backoff: c.backoff,
+	}))
+	return client.(*github.Client)
+}
 
-	return false, nil
+// githubEndpoint returns the API URL and token to look up a repository with.
+// github.com and the host of the configured API URL get the configured
+// token; the API of any other GitHub Enterprise host is only sent the token gh
+// is logged in to that host with, so that a remote cannot collect the token
+// meant for GitHub.
+func (c *BranchProtectionChecker) githubEndpoint(ctx context.Context, repoCtx core.RepositoryContext, location remoteLocation) (string, string) {
+	configured := c.githubAPIURL(repoCtx)
+	switch {
+	case location.Host == "" || location.Host == "github.com":
+		if configured == "" {
+			configured = github.DefaultBaseURL
+		}
+		return configured, c.githubToken(ctx, repoCtx, "github.com")
+	case githubURLNamesHost(configured, location.Host):
+		return configured, c.githubToken(ctx, repoCtx, location.Host)
+	default:
+		// GitHub Enterprise Server serves its API below /api/v3
+		return "https://" + location.Host + "/api/v3", c.ghToken(ctx, location.Host)
+	}
+}
+
+// githubAPIURL returns the configured GitHub API URL: the github_url option,
+// else GITHUB_API_URL, which GitHub Actions sets
+func (c *BranchProtectionChecker) githubAPIURL(repoCtx core.RepositoryContext) string {
+	if baseURL := c.StringOption(repoCtx, "github_url", ""); baseURL != "" {
+		return baseURL
+	}
+	return os.Getenv("GITHUB_API_URL")
+}
+
+// githubURLNamesHost reports whether an API URL belongs to a remote host,
+// either served by the host itself or by its api. subdomain
+func githubURLNamesHost(apiURL, host string) bool {
+	if apiURL == "" || host == "" {
+		return false
+	}
+	parsed, err := url.Parse(apiURL)
+	if err != nil {
+		return false
+	}
+	apiHost := strings.ToLower(parsed.Hostname())
+	return apiHost == host || apiHost == "api."+host
+}
+
+// githubToken returns the token to authenticate GitHub lookups with: the
+// github_token option, GITHUB_TOKEN, GH_TOKEN, or the token gh is logged in
+// with. Without one, only public repositories can be checked.
+func (c *BranchProtectionChecker) githubToken(ctx context.Context, repoCtx core.RepositoryContext, host string) string {
+	if token := c.StringOption(repoCtx, "github_token", ""); token != "" {
+		return token
+	}
+	for _, name := range []string{"GITHUB_TOKEN", "GH_TOKEN"} {
+		if token := os.Getenv(name); token != "" {
+			return token
+		}
+	}
+	return c.ghToken(ctx, host)
+}
+
+// ghToken returns the token gh is logged in to a host with, or "" without gh
+// or a login
+func (c *BranchProtectionChecker) ghToken(ctx context.Context, host string) string {
+	if result := c.executor.Execute(ctx, "which", "gh"); result.Error != nil {
+		return ""
+	}
+	result := c.executor.Execute(ctx, "gh", "auth", "token", "--hostname", host)
+	if result.Error != nil {
+		return ""
+	}
+	return strings.TrimSpace(result.Stdout)
 }
 
 // checkCommonProtectionPatterns checks for files that indicate protection awareness
@@ -173,6 +375,10 @@ func (c *BranchProtectionChecker) checkCommonProtectionPatterns(repoPath string)
 		".github/workflows/ci.yaml",
 		".github/workflows/test.yml",
 		".github/workflows/test.yaml",
+		".gitlab/CODEOWNERS",
+		".gitlab/merge_request_templates",
+		".gitlab-ci.yml",
+		"bitbucket-pipelines.yml",
 	}
 
 	for _, pattern := range protectionPatterns {
@@ -200,23 +406,60 @@ func (c *BranchProtectionChecker) checkMergePatterns(ctx context.Context, repoPa
 	return false
 }
 
-// evaluateProtectionStatus evaluates the overall protection status
-func (c *BranchProtectionChecker) evaluateProtectionStatus(builder *base.ResultBuilder, defaultBranch string, hasLocalConfig, hasGitHubProtection bool, protectionIndicators []string, hasMergePatterns bool, ghError error) {
+// reportPlatformStatus records why the platform's protection was not found:
+// the API was rate limited, unavailable or failed, or the branch is unprotected
+func reportPlatformStatus(builder *base.ResultBuilder, platform string, platformErr error) {
+	platformName := platformDisplayName(platform)
+	statusMetric := "github_protection_status"
+
+	if errors.Is(platformErr, errGitHubRateLimited) {
+		builder.AddWarning(core.Warning{
+			Type:    "github_rate_limited",
+			Message: "GitHub API rate limit exceeded; branch protection could not be verified. Try again later or set github_token or GITHUB_TOKEN for a higher limit",
+		})
+		builder.AddMetric(statusMetric, "rate_limited")
+	} else if errors.Is(platformErr, errGitLabRateLimited) {
+		builder.AddWarning(core.Warning{
+			Type:    "gitlab_rate_limited",
+			Message: "GitLab API rate limit exceeded; branch protection could not be verified. Try again later",
+		})
+		builder.AddMetric(statusMetric, "rate_limited")
+	} else if errors.Is(platformErr, errNoPlatformAPI) {
+		builder.AddWarning(core.Warning{
+			Type:    "platform_api_unavailable",
+			Message: fmt.Sprintf("Branch protection on %s cannot be looked up; only local configuration was checked", platformName),
+		})
+		builder.AddMetric(statusMetric, "unavailable")
+	} else if platformErr != nil {
+		warningType := "github_api_error"
+		if platform == PlatformGitLab {
+			warningType = "gitlab_api_error"
+		}
+		builder.AddWarning(core.Warning{
+			Type:    warningType,
+			Message: fmt.Sprintf("Unable to check %s protection: %v", platformName, platformErr),
+		})
+		builder.AddMetric(statusMetric, "unknown")
+	} else {
+		builder.AddMetric(statusMetric, "disabled")
+	}
+}
+
+// evaluateProtectionStatus evaluates the overall protection status. The
+// platform's protection is reported in the github_protection_status metric
+// whatever the platform, the name it had when only GitHub was checked.
+func (c *BranchProtectionChecker) evaluateProtectionStatus(builder *base.ResultBuilder, platform, defaultBranch string, hasLocalConfig, hasPlatformProtection bool, protectionIndicators []string, hasMergePatterns bool, platformErr error) {
 	score := 0
 	maxScore := 100
+	platformName := platformDisplayName(platform)
+	statusMetric := "github_protection_status"
 
-	// GitHub protection is the gold standard
-	if hasGitHubProtection {
+	// Platform protection is the gold standard
+	if hasPlatformProtection {
 		score += 50
-		builder.AddMetric("github_protection_status", "enabled")
-	} else if ghError != nil {
-		builder.AddWarning(core.Warning{
-			Type:    "github_cli_error",
-			Message: fmt.Sprintf("Unable to check GitHub protection: %v", ghError),
-		})
-		builder.AddMetric("github_protection_status", "unknown")
+		builder.AddMetric(statusMetric, "enabled")
 	} else {
-		builder.AddMetric("github_protection_status", "disabled")
+		reportPlatformStatus(builder, platform, platformErr)
 	}
 
 	// Local configuration files
@@ -257,7 +500,7 @@ func (c *BranchProtectionChecker) evaluateProtectionStatus(builder *base.ResultB
 			"incomplete_branch_protection",
 			core.SeverityMedium,
 			fmt.Sprintf("Branch protection for '%s' appears incomplete", defaultBranch),
-			"Consider enabling GitHub branch protection rules or adding local protection configuration",
+			fmt.Sprintf("Consider enabling %s branch protection rules or adding local protection configuration", platformName),
 		))
 	} else {
 		builder.WithStatus(core.StatusCritical)
@@ -265,14 +508,33 @@ func (c *BranchProtectionChecker) evaluateProtectionStatus(builder *base.ResultB
 			"no_branch_protection",
 			core.SeverityHigh,
 			fmt.Sprintf("No branch protection detected for '%s'", defaultBranch),
-			"Enable GitHub branch protection rules and add CODEOWNERS file for better security",
+			fmt.Sprintf("Enable %s branch protection rules and add CODEOWNERS file for better security", platformName),
 		))
 	}
 }
 
+// platformDisplayName returns the name of a hosting platform for messages
+func platformDisplayName(platform string) string {
+	switch platform {
+	case PlatformGitLab:
+		return "GitLab"
+	case PlatformBitbucket:
+		return "Bitbucket"
+	case PlatformUnknown:
+		return "the hosting platform"
+	default:
+		return "GitHub"
+	}
+}
+
 // isGitRepository checks if the path is a git repository
 func (c *BranchProtectionChecker) isGitRepository(path string) bool {
 	result := c.executor.ExecuteInDir(context.Background(), path, "git", "rev-parse", "--is-inside-work-tree")
+	if result.Error == nil && strings.TrimSpace(result.Stdout) == "true" {
+		return true
+	}
+	// Bare repositories have no work tree but still have branches to protect
+	result = c.executor.ExecuteInDir(context.Background(), path, "git", "rev-parse", "--is-bare-repository")
 	return result.Error == nil && strings.TrimSpace(result.Stdout) == "true"
 }
 
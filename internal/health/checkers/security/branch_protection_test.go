@@ -0,0 +1,394 @@
+package security
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/codcod/repos/internal/core"
+	"github.com/codcod/repos/internal/platform/commands"
+)
+
+func newProtectionTestChecker() (*BranchProtectionChecker, *commands.MockCommandExecutor) {
+	executor := commands.NewMockCommandExecutor()
+	executor.SetResponse("git rev-parse --is-inside-work-tree", commands.CommandResult{Stdout: "true\n"})
+	executor.SetResponse("git symbolic-ref refs/remotes/origin/HEAD", commands.CommandResult{Stdout: "refs/remotes/origin/main\n"})
+
+	checker := NewBranchProtectionChecker(executor)
+	checker.backoff = 0
+	return checker, executor
+}
+
+// newGitHubTestServer serves the GitHub branch API, counting the requests it answers
+func newGitHubTestServer(t *testing.T, handler http.HandlerFunc) (*httptest.Server, *int) {
+	t.Helper()
+	var mu sync.Mutex
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requests++
+		mu.Unlock()
+		handler(w, r)
+	}))
+	t.Cleanup(server.Close)
+	return server, &requests
+}
+
+func githubTestContext(serverURL, remote string) core.RepositoryContext {
+	return core.RepositoryContext{
+		Repository: core.Repository{Name: "repo", Path: "/tmp/repo", URL: remote},
+		Config: secretsTestConfig{options: map[string]interface{}{
+			"github_url":   serverURL,
+			"github_token": "secret",
+		}},
+	}
+}
+
+func TestBranchProtectionChecker_GitHub(t *testing.T) {
+	server, _ := newGitHubTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "token secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch r.URL.Path {
+		case "/repos/owner/protected/branches/main":
+			_, _ = w.Write([]byte(`{"name":"main","protected":true}`))
+		case "/repos/owner/open/branches/main":
+			_, _ = w.Write([]byte(`{"name":"main","protected":false}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message":"Not Found"}`))
+		}
+	})
+
+	tests := []struct {
+		repo    string
+		status  string
+		warning string
+	}{
+		{repo: "protected", status: "enabled"},
+		{repo: "open", status: "disabled"},
+		{repo: "private", status: "unknown", warning: "github_api_error"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.repo, func(t *testing.T) {
+			checker, _ := newProtectionTestChecker()
+			result, err := checker.Check(context.Background(), githubTestContext(server.URL, "git@github.com:owner/"+tt.repo+".git"))
+			if err != nil {
+				t.Fatalf("Check failed: %v", err)
+			}
+
+			if result.Metrics["platform"] != PlatformGitHub || result.Metrics["github_protection_status"] != tt.status {
+				t.Errorf("Expected GitHub protection %s, got %v", tt.status, result.Metrics)
+			}
+			if tt.warning != "" && (len(result.Warnings) != 1 || result.Warnings[0].Type != tt.warning) {
+				t.Errorf("Expected a %s warning, got %+v", tt.warning, result.Warnings)
+			}
+		})
+	}
+}
+
+func TestBranchProtectionChecker_CachesGitHubLookups(t *testing.T) {
+	server, requests := newGitHubTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"name":"main","protected":true}`))
+	})
+	checker, _ := newProtectionTestChecker()
+
+	repoCtx := githubTestContext(server.URL, "git@github.com:Owner/Repo.git")
+	for i := 0; i < 2; i++ {
+		result, err := checker.Check(context.Background(), repoCtx)
+		if err != nil {
+			t.Fatalf("Check failed: %v", err)
+		}
+		if result.Metrics["has_github_protection"] != true {
+			t.Errorf("Expected protection to be detected, got %v", result.Metrics)
+		}
+	}
+
+	if *requests != 1 {
+		t.Errorf("Expected one GitHub lookup for repeated checks, got %d", *requests)
+	}
+}
+
+func TestBranchProtectionChecker_ReportsRateLimit(t *testing.T) {
+	server, requests := newGitHubTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "0")
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`{"message":"You have exceeded a secondary rate limit."}`))
+	})
+	checker, _ := newProtectionTestChecker()
+
+	result, err := checker.Check(context.Background(), githubTestContext(server.URL, "https://github.com/owner/repo"))
+	if err != nil {
+		t.Fatalf("Check failed: %v", err)
+	}
+
+	if *requests != 4 {
+		t.Errorf("Expected the rate-limited lookup to be retried three times, got %d requests", *requests)
+	}
+	if result.Metrics["github_protection_status"] != "rate_limited" {
+		t.Errorf("Expected rate_limited status, got %v", result.Metrics["github_protection_status"])
+	}
+
+	found := false
+	for _, warning := range result.Warnings {
+		if warning.Type == "github_rate_limited" {
+			found = true
+		}
+		if warning.Type == "github_api_error" {
+			t.Errorf("Rate limiting should not be reported as a generic API error")
+		}
+	}
+	if !found {
+		t.Errorf("Expected a github_rate_limited warning, got %+v", result.Warnings)
+	}
+}
+
+func TestBranchProtectionChecker_DetectsDefaultBranch(t *testing.T) {
+	failed := commands.CommandResult{ExitCode: 1, Error: errors.New("exit status 1")}
+	tests := []struct {
+		name      string
+		responses map[string]commands.CommandResult
+		options   map[string]interface{}
+		branch    string
+		method    string
+	}{
+		{
+			name: "origin HEAD",
+			responses: map[string]commands.CommandResult{
+				"git symbolic-ref refs/remotes/origin/HEAD": {Stdout: "refs/remotes/origin/trunk\n"},
+			},
+			branch: "trunk",
+			method: "origin_head",
+		},
+		{
+			name: "remote show",
+			responses: map[string]commands.CommandResult{
+				"git symbolic-ref refs/remotes/origin/HEAD": failed,
+				"git remote show origin":                    {Stdout: "* remote origin\n  Fetch URL: git@github.com:owner/repo.git\n  HEAD branch: develop\n"},
+			},
+			branch: "develop",
+			method: "remote_show",
+		},
+		{
+			name: "local HEAD of a worktree",
+			responses: map[string]commands.CommandResult{
+				"git symbolic-ref refs/remotes/origin/HEAD": failed,
+				"git remote show origin":                    {Stdout: "* remote origin\n  HEAD branch: (unknown)\n"},
+				"git symbolic-ref --quiet --short HEAD":     {Stdout: "main\n"},
+			},
+			branch: "main",
+			method: "local_head",
+		},
+		{
+			name: "configured",
+			responses: map[string]commands.CommandResult{
+				"git symbolic-ref refs/remotes/origin/HEAD": failed,
+				"git remote show origin":                    failed,
+				"git symbolic-ref --quiet --short HEAD":     failed,
+			},
+			options: map[string]interface{}{"default_branch": "release"},
+			branch:  "release",
+			method:  "configured",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			executor := commands.NewMockCommandExecutor()
+			executor.SetResponse("git rev-parse --is-inside-work-tree", commands.CommandResult{Stdout: "true\n"})
+			for command, result := range tt.responses {
+				executor.SetResponse(command, result)
+			}
+			checker := NewBranchProtectionChecker(executor)
+			checker.backoff = 0
+
+			repoCtx := core.RepositoryContext{
+				Repository: core.Repository{Name: "repo", Path: "/tmp/repo"},
+				Config:     secretsTestConfig{options: tt.options},
+			}
+			result, err := checker.Check(context.Background(), repoCtx)
+			if err != nil {
+				t.Fatalf("Check failed: %v", err)
+			}
+			if result.Metrics["default_branch"] != tt.branch || result.Metrics["default_branch_method"] != tt.method {
+				t.Errorf("Expected %s via %s, got %v via %v", tt.branch, tt.method,
+					result.Metrics["default_branch"], result.Metrics["default_branch_method"])
+			}
+		})
+	}
+}
+
+func TestParseRemoteLocation(t *testing.T) {
+	tests := map[string]remoteLocation{
+		"git@gitlab.com:group/sub/project.git":            {Platform: PlatformGitLab, Host: "gitlab.com", Path: "group/sub/project"},
+		"https://gitlab.example.com/group/project":        {Platform: PlatformGitLab, Host: "gitlab.example.com", Path: "group/project"},
+		"ssh://git@git.example.com:2222/team/project.git": {Host: "git.example.com", Path: "team/project"},
+		"https://bitbucket.org/team/project.git":          {Platform: PlatformBitbucket, Host: "bitbucket.org", Path: "team/project"},
+		"git@github.com:owner/repo.git":                   {Platform: PlatformGitHub, Host: "github.com", Path: "owner/repo"},
+		"":                                                {},
+	}
+	for input, expected := range tests {
+		if got := parseRemoteLocation(input); got != expected {
+			t.Errorf("parseRemoteLocation(%q) = %+v, want %+v", input, got, expected)
+		}
+	}
+}
+
+func TestBranchProtectionChecker_GitLab(t *testing.T) {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.URL.EscapedPath())
+		if r.Header.Get("PRIVATE-TOKEN") != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch r.URL.EscapedPath() {
+		case "/api/v4/projects/group%2Fprotected/protected_branches/main":
+			_, _ = w.Write([]byte(`{"name":"main"}`))
+		case "/api/v4/projects/group%2Fopen":
+			_, _ = w.Write([]byte(`{"path_with_namespace":"group/open"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		project string
+		token   string
+		status  string
+		warning string
+	}{
+		{project: "protected", token: "secret", status: "enabled"},
+		{project: "open", token: "secret", status: "disabled"},
+		{project: "private", token: "wrong", status: "unknown", warning: "gitlab_api_error"},
+		{project: "hidden", token: "secret", status: "unknown", warning: "gitlab_api_error"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.project, func(t *testing.T) {
+			checker, _ := newProtectionTestChecker()
+			repoCtx := core.RepositoryContext{
+				Repository: core.Repository{Name: tt.project, Path: "/tmp/" + tt.project, URL: server.URL + "/group/" + tt.project + ".git"},
+				Config: secretsTestConfig{options: map[string]interface{}{
+					"platform":     "gitlab",
+					"gitlab_url":   server.URL,
+					"gitlab_token": tt.token,
+				}},
+			}
+			result, err := checker.Check(context.Background(), repoCtx)
+			if err != nil {
+				t.Fatalf("Check failed: %v", err)
+			}
+
+			// The protection keeps the metric names it had when only GitHub was checked
+			if result.Metrics["platform"] != PlatformGitLab || result.Metrics["github_protection_status"] != tt.status {
+				t.Errorf("Expected GitLab protection %s, got %v", tt.status, result.Metrics)
+			}
+			if tt.warning != "" && (len(result.Warnings) != 1 || result.Warnings[0].Type != tt.warning) {
+				t.Errorf("Expected a %s warning, got %+v", tt.warning, result.Warnings)
+			}
+		})
+	}
+}
+
+func TestBranchProtectionChecker_NoPlatformAPI(t *testing.T) {
+	checker, _ := newProtectionTestChecker()
+	repoCtx := core.RepositoryContext{Repository: core.Repository{
+		Name: "repo",
+		Path: "/tmp/repo",
+		URL:  "git@bitbucket.org:team/repo.git",
+	}}
+	result, err := checker.Check(context.Background(), repoCtx)
+	if err != nil {
+		t.Fatalf("Check failed: %v", err)
+	}
+
+	if result.Metrics["github_protection_status"] != "unavailable" {
+		t.Errorf("Expected only local configuration to be checked, got %v", result.Metrics)
+	}
+	if len(result.Warnings) != 1 || result.Warnings[0].Type != "platform_api_unavailable" {
+		t.Errorf("Expected a platform_api_unavailable warning, got %+v", result.Warnings)
+	}
+}
+
+func TestBranchProtectionChecker_DoesNotSendTokensToUnknownHosts(t *testing.T) {
+	server, requests := newGitHubTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"name":"main","protected":true}`))
+	})
+	t.Setenv("GITHUB_TOKEN", "ci-token")
+	t.Setenv("GITHUB_API_URL", server.URL)
+	checker, _ := newProtectionTestChecker()
+
+	repoCtx := core.RepositoryContext{Repository: core.Repository{Name: "repo", Path: "/tmp/repo", URL: "git@evil.example:owner/repo.git"}}
+	result, err := checker.Check(context.Background(), repoCtx)
+	if err != nil {
+		t.Fatalf("Check failed: %v", err)
+	}
+	if *requests != 0 {
+		t.Errorf("Expected a remote on an unknown host not to be looked up on GitHub, got %d requests", *requests)
+	}
+	if result.Metrics["platform"] != PlatformUnknown {
+		t.Errorf("Expected an unknown platform, got %v", result.Metrics["platform"])
+	}
+}
+
+func TestBranchProtectionChecker_GitHubEndpoint(t *testing.T) {
+	t.Setenv("GITHUB_TOKEN", "ci-token")
+	t.Setenv("GITHUB_API_URL", "")
+	checker, executor := newProtectionTestChecker()
+	executor.SetResponse("gh auth token --hostname github.example.com", commands.CommandResult{Stdout: "ghe-token\n"})
+
+	tests := []struct {
+		name    string
+		options map[string]interface{}
+		host    string
+		baseURL string
+		token   string
+	}{
+		{name: "github.com", host: "github.com", baseURL: "https://api.github.com", token: "ci-token"},
+		{name: "GitHub Enterprise", host: "github.example.com", baseURL: "https://github.example.com/api/v3", token: "ghe-token"},
+		{
+			name:    "configured GitHub Enterprise",
+			options: map[string]interface{}{"github_url": "https://git.example.com/api/v3"},
+			host:    "git.example.com",
+			baseURL: "https://git.example.com/api/v3",
+			token:   "ci-token",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repoCtx := core.RepositoryContext{Config: secretsTestConfig{options: tt.options}}
+			baseURL, token := checker.githubEndpoint(context.Background(), repoCtx, remoteLocation{Platform: PlatformGitHub, Host: tt.host, Path: "owner/repo"})
+			if baseURL != tt.baseURL || token != tt.token {
+				t.Errorf("Expected %s with %q, got %s with %q", tt.baseURL, tt.token, baseURL, token)
+			}
+		})
+	}
+}
+
+func TestBranchProtectionChecker_GitLabTokenOnlyForKnownHosts(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("Expected no request to a GitLab host other than gitlab_url, got %s", r.URL)
+	}))
+	defer server.Close()
+
+	checker, _ := newProtectionTestChecker()
+	repoCtx := core.RepositoryContext{
+		Repository: core.Repository{Name: "repo", Path: "/tmp/repo", URL: "git@gitlab.attacker.example:group/repo.git"},
+		Config: secretsTestConfig{options: map[string]interface{}{
+			"gitlab_url":   server.URL,
+			"gitlab_token": "secret",
+		}},
+	}
+	result, err := checker.Check(context.Background(), repoCtx)
+	if err != nil {
+		t.Fatalf("Check failed: %v", err)
+	}
+	if result.Metrics["github_protection_status"] != "unknown" {
+		t.Errorf("Expected the protection to be unknown, got %v", result.Metrics)
+	}
+}
@@ -0,0 +1,215 @@
+package security
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/codcod/repos/internal/core"
+)
+
+// Hosting platforms recognised from a repository's remote URL
+const (
+	PlatformGitHub    = "github"
+	PlatformGitLab    = "gitlab"
+	PlatformBitbucket = "bitbucket"
+	// PlatformUnknown is reported for remotes on hosts that are not recognised
+	PlatformUnknown = "unknown"
+)
+
+// gitLabRequestTimeout bounds each GitLab API request
+const gitLabRequestTimeout = 15 * time.Second
+
+var (
+	// errGitLabRateLimited is returned when the GitLab API rate limit is still exceeded after a retry
+	errGitLabRateLimited = errors.New("GitLab API rate limit exceeded")
+	// errGitLabNoToken is returned when no GitLab token is configured
+	errGitLabNoToken = errors.New("no GitLab token configured; set gitlab_token or GITLAB_TOKEN")
+	// errGitLabUntrustedHost is returned for GitLab hosts the token is not meant for
+	errGitLabUntrustedHost = errors.New("the GitLab token is only sent to gitlab.com and the gitlab_url host; set gitlab_url to check this host")
+	// errGitLabNotVisible is returned when the project is not visible to the token
+	errGitLabNotVisible = errors.New("project not found on GitLab; check that gitlab_token or GITLAB_TOKEN can read it")
+)
+
+// remoteLocation is where a repository is hosted
+type remoteLocation struct {
+	Platform string // PlatformGitHub, PlatformGitLab, PlatformBitbucket or ""
+	Host     string
+	Path     string // group/subgroup/project, without .git
+}
+
+// detectPlatform finds the hosting platform from the configured URL or the
+// origin remote. The platform option overrides the detection for self-hosted
+// instances whose host name does not name the platform, as do gitlab_url and
+// github_url for remotes on their host.
+func (c *BranchProtectionChecker) detectPlatform(ctx context.Context, repoCtx core.RepositoryContext) remoteLocation {
+	location := parseRemoteLocation(c.remoteURL(ctx, repoCtx))
+	if platform := strings.ToLower(c.StringOption(repoCtx, "platform", "auto")); platform != "" && platform != "auto" {
+		location.Platform = platform
+	} else if gitLabURL := c.StringOption(repoCtx, "gitlab_url", ""); gitLabURL != "" && location.Host != "" {
+		if parsed, err := url.Parse(gitLabURL); err == nil && strings.EqualFold(parsed.Hostname(), location.Host) {
+			location.Platform = PlatformGitLab
+		}
+	} else if location.Platform == "" && githubURLNamesHost(c.githubAPIURL(repoCtx), location.Host) {
+		location.Platform = PlatformGitHub
+	}
+	return location
+}
+
+// remoteURL returns the configured repository URL, or the origin remote when
+// none is configured
+func (c *BranchProtectionChecker) remoteURL(ctx context.Context, repoCtx core.RepositoryContext) string {
+	if repoCtx.Repository.URL != "" {
+		return repoCtx.Repository.URL
+	}
+	result := c.executor.ExecuteInDir(ctx, repoCtx.Repository.Path, "git", "remote", "get-url", "origin")
+	if result.Error != nil {
+		return ""
+	}
+	return strings.TrimSpace(result.Stdout)
+}
+
+// parseRemoteLocation extracts the host and project path from HTTPS, SSH and
+// scp-style remote URLs, and names the platform when the host does
+func parseRemoteLocation(remote string) remoteLocation {
+	var host, path string
+	if parsed, err := url.Parse(remote); err == nil && parsed.Host != "" {
+		host, path = parsed.Hostname(), parsed.Path
+	} else if at := strings.Index(remote, "@"); at >= 0 {
+		// scp-style: git@host:group/project.git
+		host, path, _ = strings.Cut(remote[at+1:], ":")
+	} else {
+		return remoteLocation{}
+	}
+
+	location := remoteLocation{
+		Host: strings.ToLower(host),
+		Path: strings.TrimSuffix(strings.Trim(path, "/"), ".git"),
+	}
+	switch {
+	case strings.Contains(location.Host, "github"):
+		location.Platform = PlatformGitHub
+	case strings.Contains(location.Host, "gitlab"):
+		location.Platform = PlatformGitLab
+	case strings.Contains(location.Host, "bitbucket"):
+		location.Platform = PlatformBitbucket
+	}
+	return location
+}
+
+// checkGitLabProtection asks the GitLab API whether the default branch is a
+// protected branch. Results are cached like GitHub's, and a rate-limited
+// request is retried once after a backoff.
+func (c *BranchProtectionChecker) checkGitLabProtection(ctx context.Context, repoCtx core.RepositoryContext, location remoteLocation, defaultBranch string) (bool, error) {
+	if location.Path == "" {
+		return false, fmt.Errorf("unable to determine the GitLab project from the remote URL")
+	}
+	cacheKey := "branch-protection:gitlab:" + location.Host + "/" + location.Path + "/" + defaultBranch
+	if cached, ok := c.cache.Get(cacheKey); ok {
+		return cached.(bool), nil
+	}
+
+	token := c.StringOption(repoCtx, "gitlab_token", "")
+	if token == "" {
+		token = os.Getenv("GITLAB_TOKEN")
+	}
+	if token == "" {
+		return false, errGitLabNoToken
+	}
+
+	baseURL, err := c.gitLabBaseURL(repoCtx, location.Host)
+	if err != nil {
+		return false, err
+	}
+	project := fmt.Sprintf("%s/api/v4/projects/%s", baseURL, url.PathEscape(location.Path))
+
+	protected, err := c.gitLabBranchProtected(ctx, project, defaultBranch, token)
+	if err != nil {
+		return false, err
+	}
+
+	c.cache.Set(cacheKey, protected, protectionCacheTTL)
+	return protected, nil
+}
+
+// gitLabBaseURL returns the API base URL for a remote host. The token is only
+// sent to gitlab.com and the configured instance, so that a remote on another
+// host cannot collect it.
+func (c *BranchProtectionChecker) gitLabBaseURL(repoCtx core.RepositoryContext, host string) (string, error) {
+	baseURL := strings.TrimSuffix(c.StringOption(repoCtx, "gitlab_url", ""), "/")
+	if parsed, err := url.Parse(baseURL); baseURL != "" && err == nil && strings.EqualFold(parsed.Hostname(), host) {
+		return baseURL, nil
+	}
+	if host != "gitlab.com" {
+		return "", errGitLabUntrustedHost
+	}
+	return "https://gitlab.com", nil
+}
+
+// gitLabBranchProtected reports whether the branch is a protected branch of the project
+func (c *BranchProtectionChecker) gitLabBranchProtected(ctx context.Context, project, branch, token string) (bool, error) {
+	status, err := c.getGitLab(ctx, project+"/protected_branches/"+url.PathEscape(branch), token)
+	if err == nil && status == http.StatusNotFound {
+		// The branch is not protected, unless the project itself is not visible to the token
+		status, err = c.getGitLab(ctx, project, token)
+		if err == nil && status == http.StatusOK {
+			status = http.StatusNotFound
+		} else if err == nil && status == http.StatusNotFound {
+			return false, errGitLabNotVisible
+		}
+	}
+	if err != nil {
+		return false, err
+	}
+
+	switch status {
+	case http.StatusOK:
+		return true, nil
+	case http.StatusNotFound:
+		return false, nil
+	default:
+		return false, fmt.Errorf("GitLab API returned HTTP %d", status)
+	}
+}
+
+// getGitLab requests an endpoint, retrying once after a backoff when rate limited
+func (c *BranchProtectionChecker) getGitLab(ctx context.Context, endpoint, token string) (int, error) {
+	status, err := c.requestGitLab(ctx, endpoint, token)
+	if err != nil || status != http.StatusTooManyRequests {
+		return status, err
+	}
+	select {
+	case <-ctx.Done():
+		return 0, ctx.Err()
+	case <-time.After(c.backoff):
+	}
+	status, err = c.requestGitLab(ctx, endpoint, token)
+	if err == nil && status == http.StatusTooManyRequests {
+		return 0, errGitLabRateLimited
+	}
+	return status, err
+}
+
+// requestGitLab performs an authenticated GET and returns the response status
+func (c *BranchProtectionChecker) requestGitLab(ctx context.Context, endpoint, token string) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("PRIVATE-TOKEN", token)
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("GitLab request failed: %w", err)
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	_ = resp.Body.Close()
+	return resp.StatusCode, nil
+}
@@ -0,0 +1,390 @@
+package security
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/codcod/repos/internal/core"
+	"github.com/codcod/repos/internal/health/analyzers/language"
+	"github.com/codcod/repos/internal/health/checkers/base"
+	"github.com/codcod/repos/internal/platform/commands"
+)
+
+const (
+	// defaultMaxCommits bounds the history scan, which reads every patch in range
+	defaultMaxCommits = 200
+	// maxSecretScanFileSize skips large working tree files, which are rarely hand-written config
+	maxSecretScanFileSize = 1 << 20
+)
+
+// secretPattern recognises one kind of credential
+type secretPattern struct {
+	name  string
+	regex *regexp.Regexp
+}
+
+// secretPatterns only match credentials with a distinctive format, so findings
+// are rarely false positives
+var secretPatterns = []secretPattern{
+	{"AWS access key", regexp.MustCompile(`\b(?:AKIA|ASIA)[0-9A-Z]{16}\b`)},
+	{"GitHub token", regexp.MustCompile(`\b(?:gh[pousr]_[A-Za-z0-9]{36,}|github_pat_[A-Za-z0-9_]{22,})\b`)},
+	{"Slack token", regexp.MustCompile(`\bxox[abposr]-[0-9A-Za-z-]{10,}`)},
+	{"Google API key", regexp.MustCompile(`\bAIza[0-9A-Za-z_-]{35}\b`)},
+	{"Stripe live key", regexp.MustCompile(`\b[rs]k_live_[0-9A-Za-z]{24,}\b`)},
+	{"private key", regexp.MustCompile(`-----BEGIN (?:RSA |EC |DSA |OPENSSH |PGP |ENCRYPTED )?PRIVATE KEY(?: BLOCK)?-----`)},
+}
+
+// secretFinding is a credential found in a file
+type secretFinding struct {
+	kind   string
+	value  string
+	file   string
+	line   int
+	commit string // Commit that added the line, for findings from history
+}
+
+// key identifies the same credential in the same file across scans
+func (f secretFinding) key() string {
+	return f.kind + "\x00" + f.file + "\x00" + f.value
+}
+
+// SecretsChecker finds credentials committed to the repository. It scans tracked
+// files in the working tree and, when scan_history is set, the patches of recent
+// commits, since removing a secret from HEAD leaves it in history.
+type SecretsChecker struct {
+	*base.BaseChecker
+	executor commands.CommandExecutor
+}
+
+// NewSecretsChecker creates a new committed secrets checker
+func NewSecretsChecker(executor commands.CommandExecutor) *SecretsChecker {
+	config := core.CheckerConfig{
+		Enabled:    true,
+		Severity:   "high",
+		Timeout:    2 * time.Minute,
+		Categories: []string{"security"},
+	}
+
+	return &SecretsChecker{
+		BaseChecker: base.NewBaseChecker(
+			"secrets",
+			"Committed Secrets",
+			"security",
+			config,
+		),
+		executor: executor,
+	}
+}
+
+// Metadata describes what the checker verifies
+func (*SecretsChecker) Metadata() core.CheckerMetadata {
+	return core.CheckerMetadata{
+		Description: "Finds credentials with a recognisable format (AWS, GitHub, Slack, Google and Stripe keys, and private keys) in tracked files. " +
+			"With scan_history it also reads the patches of the last max_commits commits and reports the commit that introduced each secret.",
+		Options: []core.CheckerOption{
+			{Name: "scan_history", Default: "false", Description: "Also scan commit history with 'git log -p' (repos health --scan-history)"},
+			{Name: "max_commits", Default: strconv.Itoa(defaultMaxCommits), Description: "Number of most recent commits scanned with scan_history"},
+		},
+		RequiredTools: []string{"git"},
+	}
+}
+
+// Check performs the committed secrets check
+func (c *SecretsChecker) Check(ctx context.Context, repoCtx core.RepositoryContext) (core.CheckResult, error) {
+	return c.Execute(ctx, repoCtx, func() (core.CheckResult, error) {
+		return c.checkSecrets(ctx, repoCtx)
+	})
+}
+
+// checkSecrets performs the actual committed secrets check
+func (c *SecretsChecker) checkSecrets(ctx context.Context, repoCtx core.RepositoryContext) (core.CheckResult, error) {
+	builder := base.NewResultBuilder(c.ID(), c.Name(), c.Category())
+	repoPath := repoCtx.Repository.Path
+
+	current, scanned, binarySkipped, err := c.scanWorkingTree(ctx, repoPath)
+	if err != nil {
+		builder.WithStatus(core.StatusWarning)
+		builder.AddWarning(core.Warning{
+			Type:    "git_command_error",
+			Message: fmt.Sprintf("Unable to list tracked files: %v", err),
+		})
+		return builder.Build(), nil
+	}
+	builder.AddMetric("files_scanned", scanned)
+	builder.AddMetric("binary_files_skipped", binarySkipped)
+
+	var history []secretFinding
+	if c.BoolOption(repoCtx, "scan_history", false) {
+		maxCommits := c.IntOption(repoCtx, "max_commits", defaultMaxCommits)
+		var commits int
+		history, commits, err = c.scanHistory(ctx, repoPath, maxCommits)
+		if err != nil {
+			builder.AddWarning(core.Warning{
+				Type:    "git_command_error",
+				Message: fmt.Sprintf("Unable to scan history: %v", err),
+			})
+		}
+		builder.AddMetric("commits_scanned", commits)
+	}
+
+	introduced, removed := splitHistory(current, history)
+
+	for _, finding := range current {
+		issue := base.NewIssueWithLocation(
+			"committed_secret",
+			core.SeverityHigh,
+			fmt.Sprintf("%s committed in %s (%s)", capitalize(finding.kind), finding.file, redact(finding.value)),
+			finding.file,
+			finding.line,
+			0,
+		)
+		issue.Suggestion = "Revoke the credential, remove it from the file and load it from the environment or a secret manager"
+		issue.Context["secret_type"] = finding.kind
+		if commit := introduced[finding.key()]; commit != "" {
+			issue.Context["commit"] = commit
+		}
+		builder.AddIssue(issue)
+	}
+	for _, finding := range removed {
+		issue := base.NewIssueWithLocation(
+			"secret_in_history",
+			core.SeverityHigh,
+			fmt.Sprintf("%s in %s was introduced in commit %s and remains in history (%s)",
+				capitalize(finding.kind), finding.file, shortCommit(finding.commit), redact(finding.value)),
+			finding.file,
+			finding.line,
+			0,
+		)
+		issue.Suggestion = "Revoke the credential; removing it from HEAD does not remove it from history"
+		issue.Context["secret_type"] = finding.kind
+		issue.Context["commit"] = finding.commit
+		builder.AddIssue(issue)
+	}
+
+	total := len(current) + len(removed)
+	builder.AddMetric("secrets_found", len(current))
+	builder.AddMetric("secrets_in_history", len(removed))
+
+	if total == 0 {
+		builder.WithStatus(core.StatusHealthy)
+		builder.WithScore(100, 100)
+		return builder.Build(), nil
+	}
+	builder.WithStatus(core.StatusCritical)
+	builder.WithScore(max(100-25*total, 0), 100)
+	return builder.Build(), nil
+}
+
+// splitHistory returns the commit that introduced each secret found in history,
+// and the secrets no longer in the working tree. A secret still in the working
+// tree is reported there, with the commit that added it.
+func splitHistory(current, history []secretFinding) (map[string]string, []secretFinding) {
+	introduced := make(map[string]string, len(history))
+	var removed []secretFinding
+	inTree := make(map[string]bool, len(current))
+	for _, finding := range current {
+		inTree[finding.key()] = true
+	}
+	for _, finding := range history {
+		introduced[finding.key()] = finding.commit
+		if !inTree[finding.key()] {
+			removed = append(removed, finding)
+		}
+	}
+	return introduced, removed
+}
+
+// scanWorkingTree scans every tracked file in the checkout, returning the
+// findings, the number of files read and the number of binary files skipped
+func (c *SecretsChecker) scanWorkingTree(ctx context.Context, repoPath string) ([]secretFinding, int, int, error) {
+	result := c.executor.ExecuteInDir(ctx, repoPath, "git", "ls-files", "-z")
+	if result.Error != nil {
+		return nil, 0, 0, result.Error
+	}
+
+	var findings []secretFinding
+	scanned, binarySkipped := 0, 0
+	for _, name := range strings.Split(result.Stdout, "\x00") {
+		if name == "" {
+			continue
+		}
+		path := filepath.Join(repoPath, filepath.FromSlash(name))
+		info, err := os.Lstat(path)
+		if err != nil || !info.Mode().IsRegular() || info.Size() > maxSecretScanFileSize {
+			continue
+		}
+		content, err := language.ReadText(path)
+		if errors.Is(err, language.ErrBinaryFile) {
+			binarySkipped++
+			continue
+		}
+		if err != nil {
+			continue // Unreadable
+		}
+		scanned++
+
+		for i, line := range strings.Split(content, "\n") {
+			findings = append(findings, matchSecrets(line, name, i+1, "")...)
+		}
+	}
+	return findings, scanned, binarySkipped, nil
+}
+
+// scanHistory scans the lines added by the last maxCommits commits on any ref.
+// Each secret is reported once, at the oldest commit in range that added it.
+func (c *SecretsChecker) scanHistory(ctx context.Context, repoPath string, maxCommits int) ([]secretFinding, int, error) {
+	result := c.executor.ExecuteInDir(ctx, repoPath, "git", "log", "--all", "-p", "-U0", "--no-color", "--no-ext-diff",
+		"--format=commit %H", fmt.Sprintf("--max-count=%d", maxCommits))
+	if result.Error != nil {
+		return nil, 0, result.Error
+	}
+
+	scan := &historyScan{oldest: make(map[string]secretFinding)}
+	scanner := bufio.NewScanner(strings.NewReader(result.Stdout))
+	scanner.Buffer(make([]byte, 0, 64*1024), 16<<20)
+	for scanner.Scan() {
+		scan.scanLine(scanner.Text())
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, scan.commits, err
+	}
+
+	findings := make([]secretFinding, 0, len(scan.order))
+	for _, key := range scan.order {
+		findings = append(findings, scan.oldest[key])
+	}
+	sort.SliceStable(findings, func(i, j int) bool {
+		if findings[i].file != findings[j].file {
+			return findings[i].file < findings[j].file
+		}
+		return findings[i].line < findings[j].line
+	})
+	return findings, scan.commits, nil
+}
+
+// historyScan tracks the position in a 'git log -p' listing
+type historyScan struct {
+	// git log lists the newest commit first, so later matches replace earlier ones
+	oldest   map[string]secretFinding
+	order    []string
+	commits  int
+	commit   string
+	file     string
+	line     int
+	inHeader bool
+}
+
+// scanLine scans one line of the listing
+func (s *historyScan) scanLine(text string) {
+	switch {
+	case strings.HasPrefix(text, "commit "):
+		s.commit = strings.TrimPrefix(text, "commit ")
+		s.commits++
+	case strings.HasPrefix(text, "diff --git "):
+		s.inHeader, s.file = true, ""
+	case strings.HasPrefix(text, "@@ "):
+		s.inHeader = false
+		s.line = hunkStart(text)
+	case s.inHeader:
+		if strings.HasPrefix(text, "+++ ") {
+			s.file = diffPath(strings.TrimPrefix(text, "+++ "))
+		}
+	case s.file != "" && strings.HasPrefix(text, "+"):
+		s.record(matchSecrets(text[1:], s.file, s.line, s.commit))
+		s.line++
+	case strings.HasPrefix(text, " "):
+		s.line++
+	}
+}
+
+// record keeps the oldest commit of each finding
+func (s *historyScan) record(findings []secretFinding) {
+	for _, finding := range findings {
+		if _, seen := s.oldest[finding.key()]; !seen {
+			s.order = append(s.order, finding.key())
+		}
+		s.oldest[finding.key()] = finding
+	}
+}
+
+// matchSecrets returns the secrets on one line of a file
+func matchSecrets(line, file string, lineNumber int, commit string) []secretFinding {
+	var findings []secretFinding
+	for _, pattern := range secretPatterns {
+		for _, value := range pattern.regex.FindAllString(line, -1) {
+			findings = append(findings, secretFinding{
+				kind:   pattern.name,
+				value:  value,
+				file:   file,
+				line:   lineNumber,
+				commit: commit,
+			})
+		}
+	}
+	return findings
+}
+
+// diffPath returns the repository path from a '+++' header, or "" for a deletion
+func diffPath(header string) string {
+	header = strings.Trim(header, "\"")
+	if header == "/dev/null" {
+		return ""
+	}
+	return strings.TrimPrefix(header, "b/")
+}
+
+// hunkStart returns the first line of the new file in a hunk header such as '@@ -3,0 +4,2 @@'
+func hunkStart(header string) int {
+	fields := strings.Fields(header)
+	if len(fields) < 3 || !strings.HasPrefix(fields[2], "+") {
+		return 0
+	}
+	start, _, _ := strings.Cut(fields[2][1:], ",")
+	line, err := strconv.Atoi(start)
+	if err != nil {
+		return 0
+	}
+	return line
+}
+
+// redact keeps enough of a secret to identify it without disclosing it
+func redact(value string) string {
+	if strings.HasPrefix(value, "-----") {
+		return strings.Trim(value, "-")
+	}
+	if len(value) <= 8 {
+		return strings.Repeat("*", len(value))
+	}
+	return value[:6] + strings.Repeat("*", 6)
+}
+
+// capitalize upper-cases the first letter of a secret kind for the start of a message
+func capitalize(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
+
+// shortCommit abbreviates a commit hash for messages
+func shortCommit(commit string) string {
+	if len(commit) > 12 {
+		return commit[:12]
+	}
+	return commit
+}
+
+// SupportsRepository checks if the repository is a Git work tree
+func (c *SecretsChecker) SupportsRepository(repo core.Repository) bool {
+	result := c.executor.ExecuteInDir(context.Background(), repo.Path, "git", "rev-parse", "--is-inside-work-tree")
+	return result.Error == nil && strings.TrimSpace(result.Stdout) == "true"
+}
@@ -39,6 +39,14 @@ func NewVulnerabilityChecker(executor commands.CommandExecutor) *VulnerabilityCh
 	}
 }
 
+// Metadata describes what the checker verifies
+func (*VulnerabilityChecker) Metadata() core.CheckerMetadata {
+	return core.CheckerMetadata{
+		Description:   "Scans dependencies for known vulnerabilities with the ecosystem's audit tool: govulncheck for Go, npm audit for Node.js and safety for Python.",
+		RequiredTools: []string{"govulncheck", "npm", "safety"},
+	}
+}
+
 // Check performs the vulnerability check
 func (c *VulnerabilityChecker) Check(ctx context.Context, repoCtx core.RepositoryContext) (core.CheckResult, error) {
 	return c.Execute(ctx, repoCtx, func() (core.CheckResult, error) {
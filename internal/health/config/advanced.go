@@ -2,7 +2,14 @@ package config
 
 import (
 	"fmt"
+	"io"
 	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
 
 	"gopkg.in/yaml.v3"
@@ -10,18 +17,38 @@ import (
 	"github.com/codcod/repos/internal/core"
 )
 
+// StdinConfigPath is the config path that reads configuration from stdin
+const StdinConfigPath = "-"
+
 // AdvancedConfig implements the Config interface with advanced features
 type AdvancedConfig struct {
-	Version    string                         `yaml:"version"`
-	Engine     core.EngineConfig              `yaml:"engine"`
-	Checkers   map[string]core.CheckerConfig  `yaml:"checkers"`
-	Analyzers  map[string]core.AnalyzerConfig `yaml:"analyzers"`
-	Reporters  map[string]core.ReporterConfig `yaml:"reporters"`
-	Categories map[string]CategoryConfig      `yaml:"categories"`
-	Overrides  []OverrideConfig               `yaml:"overrides"`
-	// Future use - extension points not yet implemented
-	// Extensions   ExtensionsConfig               `yaml:"extensions"`
-	// Integrations IntegrationsConfig             `yaml:"integrations"`
+	Version      string                         `yaml:"version"`
+	Engine       core.EngineConfig              `yaml:"engine"`
+	Checkers     map[string]core.CheckerConfig  `yaml:"checkers"`
+	Analyzers    map[string]core.AnalyzerConfig `yaml:"analyzers"`
+	Reporters    map[string]core.ReporterConfig `yaml:"reporters"`
+	Categories   map[string]CategoryConfig      `yaml:"categories"`
+	Overrides    []OverrideConfig               `yaml:"overrides"`
+	Integrations IntegrationsConfig             `yaml:"integrations"`
+	Extensions   ExtensionsConfig               `yaml:"extensions"`
+	// SkipCheckers lists, per repository tag, the checkers that are not run on
+	// repositories with that tag
+	SkipCheckers map[string][]string `yaml:"skip_checkers,omitempty"`
+	// MinScore is the overall score every repository needs for the run to
+	// pass; 0 disables the gate. Overrides can set it per repository.
+	MinScore int `yaml:"min_score,omitempty"`
+	// ExitCodes replaces the exit codes of the outcomes of a run
+	ExitCodes ExitCodesConfig `yaml:"exit_codes,omitempty"`
+}
+
+// ExitCodesConfig sets the exit code of each outcome of a run, for CI systems
+// that tell unstable builds from failed ones. Unset outcomes keep their
+// defaults: healthy 0, warning 0, critical 2 and errored 3.
+type ExitCodesConfig struct {
+	Healthy  *int `yaml:"healthy,omitempty"`
+	Warning  *int `yaml:"warning,omitempty"`
+	Critical *int `yaml:"critical,omitempty"`
+	Errored  *int `yaml:"errored,omitempty"`
 }
 
 // CategoryConfig defines configuration for a category of checks
@@ -33,6 +60,12 @@ type CategoryConfig struct {
 	Weight      float64                `yaml:"weight"`
 	Checkers    []string               `yaml:"checkers"`
 	Options     map[string]interface{} `yaml:"options"`
+	// FailOn is the lowest status of the category's checks that fails the run:
+	// warning, critical or never. Empty keeps the default, critical.
+	FailOn string `yaml:"fail_on,omitempty"`
+	// MinScore is the category score every repository needs for the run to
+	// pass; 0 disables it
+	MinScore int `yaml:"min_score,omitempty"`
 }
 
 // OverrideConfig defines conditional configuration overrides
@@ -42,13 +75,15 @@ type OverrideConfig struct {
 	Checkers   map[string]core.CheckerConfig  `yaml:"checkers"`
 	Analyzers  map[string]core.AnalyzerConfig `yaml:"analyzers"`
 	Engine     *core.EngineConfig             `yaml:"engine,omitempty"`
+	// MinScore replaces the overall score the matching repositories need
+	MinScore *int `yaml:"min_score,omitempty"`
 }
 
 // ConditionConfig defines conditions for applying overrides
 type ConditionConfig struct {
-	Type     string        `yaml:"type"` // "repository", "language", "tag", "path"
+	Type     string        `yaml:"type"` // "repository", "language", "tag", "path", "subpath"
 	Field    string        `yaml:"field"`
-	Operator string        `yaml:"operator"` // "equals", "contains", "matches", "in"
+	Operator string        `yaml:"operator"` // "equals", "contains", "matches", "in", "glob", "regex"
 	Value    interface{}   `yaml:"value"`
 	Values   []interface{} `yaml:"values,omitempty"`
 }
@@ -60,7 +95,8 @@ type ExtensionsConfig struct {
 	Plugins        []PluginConfig        `yaml:"plugins"`
 }
 
-// CustomCheckerConfig defines a custom checker
+// CustomCheckerConfig defines a checker that runs an external command in the
+// repository directory. Config holds the checker's default options.
 type CustomCheckerConfig struct {
 	ID       string                 `yaml:"id"`
 	Name     string                 `yaml:"name"`
@@ -70,12 +106,15 @@ type CustomCheckerConfig struct {
 	Config   map[string]interface{} `yaml:"config"`
 }
 
-// HookConfig defines execution hooks
+// HookConfig defines a command run in the repository directory when an event
+// fires. A failing hook is logged and ignored unless FailOnError is set.
 type HookConfig struct {
-	Name    string   `yaml:"name"`
-	Event   string   `yaml:"event"` // "pre_check", "post_check", "on_error"
-	Command string   `yaml:"command"`
-	Args    []string `yaml:"args"`
+	Name        string        `yaml:"name"`
+	Event       string        `yaml:"event"` // "pre_check", "post_check", "on_error"
+	Command     string        `yaml:"command"`
+	Args        []string      `yaml:"args"`
+	Timeout     time.Duration `yaml:"timeout"` // Default: 30s
+	FailOnError bool          `yaml:"fail_on_error"`
 }
 
 // PluginConfig defines plugin configuration
@@ -88,9 +127,10 @@ type PluginConfig struct {
 
 // IntegrationsConfig configures external integrations
 type IntegrationsConfig struct {
-	GitHub GitHubConfig `yaml:"github"`
-	Slack  SlackConfig  `yaml:"slack"`
-	JIRA   JIRAConfig   `yaml:"jira"`
+	GitHub  GitHubConfig  `yaml:"github"`
+	Slack   SlackConfig   `yaml:"slack"`
+	JIRA    JIRAConfig    `yaml:"jira"`
+	Tracing TracingConfig `yaml:"tracing"`
 }
 
 // GitHubConfig configures GitHub integration
@@ -112,11 +152,22 @@ type SlackConfig struct {
 
 // JIRAConfig configures JIRA integration
 type JIRAConfig struct {
-	Enabled  bool   `yaml:"enabled"`
-	BaseURL  string `yaml:"base_url"`
-	Username string `yaml:"username"`
-	APIToken string `yaml:"api_token"`
-	Project  string `yaml:"project"`
+	Enabled   bool          `yaml:"enabled"`
+	BaseURL   string        `yaml:"base_url"`
+	Username  string        `yaml:"username"`
+	APIToken  string        `yaml:"api_token"` // falls back to the JIRA_API_TOKEN environment variable
+	Project   string        `yaml:"project"`
+	IssueType string        `yaml:"issue_type"` // default: Bug
+	Timeout   time.Duration `yaml:"timeout"`    // default: 30s
+}
+
+// TracingConfig configures export of trace spans to an OTLP/HTTP collector.
+// Tracing is disabled unless an endpoint is set here or in the environment.
+type TracingConfig struct {
+	OTLPEndpoint string            `yaml:"otlp_endpoint"` // falls back to OTEL_EXPORTER_OTLP_ENDPOINT
+	ServiceName  string            `yaml:"service_name"`  // default: repos-health
+	Headers      map[string]string `yaml:"headers"`       // merged over OTEL_EXPORTER_OTLP_HEADERS
+	Timeout      time.Duration     `yaml:"timeout"`       // default: 10s
 }
 
 // LoadAdvancedConfig loads configuration from a YAML file with advanced features
@@ -126,8 +177,65 @@ func LoadAdvancedConfig(configPath string) (*AdvancedConfig, error) {
 		return nil, fmt.Errorf("failed to read config file: %w", err)
 	}
 
+	return parseAdvancedConfig(data, configPath)
+}
+
+// LoadAdvancedConfigs loads each configuration in order and merges the later ones
+// into the first using MergeConfig, so later files win. A path of "-" reads YAML
+// from stdin, which can be used only once.
+func LoadAdvancedConfigs(configPaths []string, stdin io.Reader) (*AdvancedConfig, error) {
+	if len(configPaths) == 0 {
+		return nil, fmt.Errorf("no config files given")
+	}
+
+	var merged *AdvancedConfig
+	readStdin := false
+	for _, configPath := range configPaths {
+		var data []byte
+		var err error
+		if configPath == StdinConfigPath {
+			if readStdin {
+				return nil, fmt.Errorf("config can be read from stdin only once")
+			}
+			readStdin = true
+			data, err = io.ReadAll(stdin)
+		} else {
+			data, err = os.ReadFile(configPath) //nolint:gosec // Config path is from user input
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read config file %s: %w", configPath, err)
+		}
+
+		config, err := parseAdvancedConfig(data, configPath)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", configPath, err)
+		}
+
+		if merged == nil {
+			merged = config
+		} else {
+			merged.MergeConfig(config)
+		}
+	}
+
+	if err := merged.validate(); err != nil {
+		return nil, fmt.Errorf("invalid configuration: %w", err)
+	}
+	return merged, nil
+}
+
+// parseAdvancedConfig parses YAML configuration read from path, resolving its
+// !include directives and applying defaults and validation
+func parseAdvancedConfig(data []byte, path string) (*AdvancedConfig, error) {
+	root, _, err := parseYAML(data, path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse config file: %w", err)
+	}
 	var config AdvancedConfig
-	if err := yaml.Unmarshal(data, &config); err != nil {
+	if err := root.Decode(&config); err != nil {
+		return nil, fmt.Errorf("failed to parse config file: %w", err)
+	}
+	if err := markDisabledCheckers(root, &config); err != nil {
 		return nil, fmt.Errorf("failed to parse config file: %w", err)
 	}
 
@@ -142,6 +250,46 @@ func LoadAdvancedConfig(configPath string) (*AdvancedConfig, error) {
 	return &config, nil
 }
 
+// checkerEnabledFields holds the enabled field of each checker, as a pointer
+// so that an explicit enabled: false can be told from leaving it out
+type checkerEnabledFields map[string]struct {
+	Enabled *bool `yaml:"enabled"`
+}
+
+// markDisabledCheckers sets Disabled on the checkers, at the top level and in
+// overrides, that set enabled: false in the document. The document is decoded
+// again rather than walked so that anchors, aliases and merge keys resolve.
+func markDisabledCheckers(root *yaml.Node, config *AdvancedConfig) error {
+	var fields struct {
+		Checkers  checkerEnabledFields `yaml:"checkers"`
+		Overrides []struct {
+			Checkers checkerEnabledFields `yaml:"checkers"`
+		} `yaml:"overrides"`
+	}
+	if err := root.Decode(&fields); err != nil {
+		return err
+	}
+
+	markDisabled(config.Checkers, fields.Checkers)
+	for i, override := range fields.Overrides {
+		if i < len(config.Overrides) {
+			markDisabled(config.Overrides[i].Checkers, override.Checkers)
+		}
+	}
+	return nil
+}
+
+// markDisabled sets Disabled on the checkers whose enabled field is set to false
+func markDisabled(checkers map[string]core.CheckerConfig, fields checkerEnabledFields) {
+	for id, field := range fields {
+		checkerConfig, exists := checkers[id]
+		if exists && field.Enabled != nil && !*field.Enabled {
+			checkerConfig.Disabled = true
+			checkers[id] = checkerConfig
+		}
+	}
+}
+
 // NewDefaultAdvancedConfig creates a default advanced configuration with sane defaults
 func NewDefaultAdvancedConfig() *AdvancedConfig {
 	config := &AdvancedConfig{
@@ -179,7 +327,19 @@ func NewDefaultAdvancedConfig() *AdvancedConfig {
 				Weight:      15,
 				Enabled:     true,
 			},
-			"docs": {
+			"dependencies": {
+				Name:        "Dependencies",
+				Description: "Dependency freshness, policy and lockfile checks",
+				Weight:      15,
+				Enabled:     true,
+			},
+			"git": {
+				Name:        "Git",
+				Description: "Repository state and history checks",
+				Weight:      10,
+				Enabled:     true,
+			},
+			"documentation": {
 				Name:        "Documentation",
 				Description: "Documentation completeness checks",
 				Weight:      10,
@@ -187,7 +347,7 @@ func NewDefaultAdvancedConfig() *AdvancedConfig {
 			},
 		},
 		Overrides: []OverrideConfig{},
-		// Extensions and Integrations will be added when implemented
+		// Integrations will be added when implemented
 	}
 
 	// Set defaults to ensure consistency
@@ -240,16 +400,276 @@ func (c *AdvancedConfig) setDefaults() {
 	}
 }
 
-// validate validates the configuration
+// validate validates the configuration, returning the first problem found
 func (c *AdvancedConfig) validate() error {
+	if problems := c.validationErrors(); len(problems) > 0 {
+		return problems[0].err
+	}
+	return nil
+}
+
+// configError is a validation problem and the YAML keys leading to the field it
+// was found in, e.g. checkers, license-check, severity. Sequence entries are
+// identified by their index.
+type configError struct {
+	path []string
+	err  error
+}
+
+// validationErrors returns every problem found in the configuration
+func (c *AdvancedConfig) validationErrors() []configError {
+	problems := checkerSeverityErrors([]string{"checkers"}, c.Checkers)
+	problems = append(problems, checkerMissingToolErrors([]string{"checkers"}, c.Checkers)...)
+	if err := c.Engine.Scoring.Validate(); err != nil {
+		problems = append(problems, configError{path: []string{"engine", "scoring"}, err: fmt.Errorf("engine: %w", err)})
+	}
+	if c.Engine.OnMissingTool != "" {
+		if _, err := core.ParseMissingToolPolicy(c.Engine.OnMissingTool); err != nil {
+			problems = append(problems, configError{path: []string{"engine", "on_missing_tool"}, err: fmt.Errorf("engine: %w", err)})
+		}
+	}
+
 	// Validate override conditions
-	for _, override := range c.Overrides {
+	for i, override := range c.Overrides {
+		index := strconv.Itoa(i)
 		if err := c.validateOverrideConditions(override); err != nil {
-			return fmt.Errorf("invalid override '%s': %w", override.Name, err)
+			problems = append(problems, configError{
+				path: []string{"overrides", index, "conditions"},
+				err:  fmt.Errorf("invalid override '%s': %w", override.Name, err),
+			})
+		}
+		overrideProblems := checkerSeverityErrors([]string{"overrides", index, "checkers"}, override.Checkers)
+		overrideProblems = append(overrideProblems, checkerMissingToolErrors([]string{"overrides", index, "checkers"}, override.Checkers)...)
+		for _, problem := range overrideProblems {
+			problem.err = fmt.Errorf("invalid override '%s': %w", override.Name, problem.err)
+			problems = append(problems, problem)
 		}
 	}
 
-	return nil
+	problems = append(problems, customCheckerErrors(c.Extensions.CustomCheckers)...)
+	problems = append(problems, categoryFailOnErrors(c.Categories)...)
+	problems = append(problems, minScoreErrors(c)...)
+	problems = append(problems, exitCodeErrors(c.ExitCodes)...)
+	problems = append(problems, analyzerExtensionErrors(c.Analyzers)...)
+	return append(problems, hookErrors(c.Extensions.Hooks)...)
+}
+
+// categoryFailOnErrors rejects unknown fail_on levels of categories
+func categoryFailOnErrors(categories map[string]CategoryConfig) []configError {
+	var problems []configError
+	for name, category := range categories {
+		if category.FailOn == "" {
+			continue
+		}
+		if _, err := core.ParseFailOn(category.FailOn); err != nil {
+			problems = append(problems, configError{
+				path: []string{"categories", name, "fail_on"},
+				err:  fmt.Errorf("category '%s': %w", name, err),
+			})
+		}
+	}
+	return problems
+}
+
+// minScoreErrors rejects minimum scores outside 0-100
+func minScoreErrors(c *AdvancedConfig) []configError {
+	var problems []configError
+	check := func(path []string, minScore int) {
+		if minScore < 0 || minScore > 100 {
+			problems = append(problems, configError{
+				path: path,
+				err:  fmt.Errorf("min_score %d is out of range (0-100)", minScore),
+			})
+		}
+	}
+
+	check([]string{"min_score"}, c.MinScore)
+	for name, category := range c.Categories {
+		check([]string{"categories", name, "min_score"}, category.MinScore)
+	}
+	for i, override := range c.Overrides {
+		if override.MinScore != nil {
+			check([]string{"overrides", strconv.Itoa(i), "min_score"}, *override.MinScore)
+		}
+	}
+	return problems
+}
+
+// exitCodeErrors rejects exit codes a process cannot return
+func exitCodeErrors(codes ExitCodesConfig) []configError {
+	var problems []configError
+	for _, code := range []struct {
+		name  string
+		value *int
+	}{
+		{"healthy", codes.Healthy},
+		{"warning", codes.Warning},
+		{"critical", codes.Critical},
+		{"errored", codes.Errored},
+	} {
+		if code.value != nil && (*code.value < 0 || *code.value > 255) {
+			problems = append(problems, configError{
+				path: []string{"exit_codes", code.name},
+				err:  fmt.Errorf("exit code %d for %s is out of range (0-255)", *code.value, code.name),
+			})
+		}
+	}
+	return problems
+}
+
+// analyzerExtensionErrors rejects file extensions that do not start with a dot
+func analyzerExtensionErrors(analyzers map[string]core.AnalyzerConfig) []configError {
+	var problems []configError
+	for language, analyzer := range analyzers {
+		for _, ext := range analyzer.FileExtensions {
+			if !strings.HasPrefix(ext, ".") || len(ext) < 2 || strings.ContainsAny(ext, `/\`) {
+				problems = append(problems, configError{
+					path: []string{"analyzers", language, "file_extensions"},
+					err:  fmt.Errorf("analyzer '%s': invalid file extension %q (expected e.g. \".vue\")", language, ext),
+				})
+			}
+		}
+	}
+	return problems
+}
+
+// ExtensionConflicts returns an error for each file extension that more than one
+// analyzer would process. defaults holds the built-in extensions of each analyzer
+// by language; configured file_extensions replace an analyzer's defaults.
+func ExtensionConflicts(analyzers map[string]core.AnalyzerConfig, defaults map[string][]string) []error {
+	claims := make(map[string][]string)
+	languages := make(map[string]bool, len(defaults)+len(analyzers))
+	for language := range defaults {
+		languages[language] = true
+	}
+	for language := range analyzers {
+		languages[language] = true
+	}
+	for language := range languages {
+		for _, ext := range analyzers[language].Extensions(defaults[language]) {
+			if !slices.Contains(claims[ext], language) {
+				claims[ext] = append(claims[ext], language)
+			}
+		}
+	}
+
+	var errs []error
+	for ext, claimants := range claims {
+		if len(claimants) > 1 {
+			sort.Strings(claimants)
+			errs = append(errs, fmt.Errorf("file extension %s is claimed by more than one analyzer: %s", ext, strings.Join(claimants, ", ")))
+		}
+	}
+	sort.Slice(errs, func(i, j int) bool { return errs[i].Error() < errs[j].Error() })
+	return errs
+}
+
+// hookErrors rejects hooks without a command or with an unknown event
+func hookErrors(hooks []HookConfig) []configError {
+	var problems []configError
+	for i, hook := range hooks {
+		path := []string{"extensions", "hooks", strconv.Itoa(i)}
+		name := hook.Name
+		if name == "" {
+			name = strconv.Itoa(i)
+		}
+		switch hook.Event {
+		case "pre_check", "post_check", "on_error":
+		default:
+			problems = append(problems, configError{
+				path: append(path, "event"),
+				err:  fmt.Errorf("hook '%s': invalid event %q (allowed: pre_check, post_check, on_error)", name, hook.Event),
+			})
+		}
+		if hook.Command == "" {
+			problems = append(problems, configError{
+				path: append(path, "command"),
+				err:  fmt.Errorf("hook '%s': command is required", name),
+			})
+		}
+		if hook.Timeout < 0 {
+			problems = append(problems, configError{
+				path: append(path, "timeout"),
+				err:  fmt.Errorf("hook '%s': timeout must not be negative", name),
+			})
+		}
+	}
+	return problems
+}
+
+// customCheckerErrors rejects custom checkers without an ID or command, and duplicate IDs
+func customCheckerErrors(checkers []CustomCheckerConfig) []configError {
+	var problems []configError
+	seen := make(map[string]bool)
+	for i, custom := range checkers {
+		path := []string{"extensions", "custom_checkers", strconv.Itoa(i)}
+		switch {
+		case custom.ID == "":
+			problems = append(problems, configError{path: path, err: fmt.Errorf("custom checker %d: id is required", i)})
+		case seen[custom.ID]:
+			problems = append(problems, configError{
+				path: append(path, "id"),
+				err:  fmt.Errorf("custom checker '%s' is defined more than once", custom.ID),
+			})
+		}
+		seen[custom.ID] = true
+		if custom.Command == "" {
+			problems = append(problems, configError{
+				path: append(path, "command"),
+				err:  fmt.Errorf("custom checker '%s': command is required", custom.ID),
+			})
+		}
+	}
+	return problems
+}
+
+// checkerSeverityErrors rejects checker severities outside the allowed set
+func checkerSeverityErrors(path []string, checkers map[string]core.CheckerConfig) []configError {
+	ids := make([]string, 0, len(checkers))
+	for id := range checkers {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	var problems []configError
+	for _, id := range ids {
+		severity := checkers[id].Severity
+		if severity == "" {
+			continue
+		}
+		if _, err := core.ParseSeverity(severity); err != nil {
+			problems = append(problems, configError{
+				path: append(append([]string{}, path...), id, "severity"),
+				err:  fmt.Errorf("checker '%s': %w", id, err),
+			})
+		}
+	}
+	return problems
+}
+
+// checkerMissingToolErrors rejects unknown on_missing_tool policies of checkers
+func checkerMissingToolErrors(path []string, checkers map[string]core.CheckerConfig) []configError {
+	ids := make([]string, 0, len(checkers))
+	for id := range checkers {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	var problems []configError
+	for _, id := range ids {
+		value, exists := checkers[id].Options["on_missing_tool"]
+		if !exists {
+			continue
+		}
+		policy, _ := value.(string)
+		if _, err := core.ParseMissingToolPolicy(policy); err != nil {
+			problems = append(problems, configError{
+				path: append(append([]string{}, path...), id, "options", "on_missing_tool"),
+				err:  fmt.Errorf("checker '%s': %w", id, err),
+			})
+		}
+	}
+	return problems
 }
 
 // validateOverrideConditions validates override conditions
@@ -259,6 +679,7 @@ func (c *AdvancedConfig) validateOverrideConditions(override OverrideConfig) err
 		"language":   true,
 		"tag":        true,
 		"path":       true,
+		"subpath":    true,
 	}
 
 	validOperators := map[string]bool{
@@ -266,6 +687,8 @@ func (c *AdvancedConfig) validateOverrideConditions(override OverrideConfig) err
 		"contains": true,
 		"matches":  true,
 		"in":       true,
+		"glob":     true,
+		"regex":    true,
 	}
 
 	for _, condition := range override.Conditions {
@@ -280,6 +703,14 @@ func (c *AdvancedConfig) validateOverrideConditions(override OverrideConfig) err
 		if condition.Operator == "in" && len(condition.Values) == 0 {
 			return fmt.Errorf("'in' operator requires 'values' field")
 		}
+
+		// "matches" predates regular expressions and is not compiled here so
+		// that configurations using it keep loading; invalid patterns never match
+		if condition.Operator == "regex" {
+			if _, err := regexp.Compile(fmt.Sprintf("%v", condition.Value)); err != nil {
+				return fmt.Errorf("invalid regular expression '%v': %w", condition.Value, err)
+			}
+		}
 	}
 
 	return nil
@@ -308,26 +739,138 @@ func (c *AdvancedConfig) GetEngineConfig() core.EngineConfig {
 	return c.Engine
 }
 
-// ApplyOverrides applies configuration overrides based on repository context
-func (c *AdvancedConfig) ApplyOverrides(repo core.Repository) error {
+// GetCategoryWeight returns the scoring weight of a category.
+// Disabled categories have a weight of 0 so they are left out of the overall score;
+// categories without a positive weight fall back to core.DefaultCategoryWeight.
+func (c *AdvancedConfig) GetCategoryWeight(category string) (float64, bool) {
+	categoryConfig, exists := c.Categories[category]
+	if !exists {
+		return 0, false
+	}
+	if !categoryConfig.Enabled {
+		return 0, true
+	}
+	if categoryConfig.Weight <= 0 {
+		return core.DefaultCategoryWeight, true
+	}
+	return categoryConfig.Weight, true
+}
+
+// SkippedCheckers returns the IDs of the checkers skip_checkers excludes for
+// the repository's tags
+func (c *AdvancedConfig) SkippedCheckers(repo core.Repository) map[string]bool {
+	skipped := make(map[string]bool)
+	for _, tag := range repo.Tags {
+		for _, id := range c.SkipCheckers[tag] {
+			skipped[id] = true
+		}
+	}
+	return skipped
+}
+
+// CategoryFailOn returns the fail_on level of each category that sets one
+func (c *AdvancedConfig) CategoryFailOn() map[string]core.FailOn {
+	levels := make(map[string]core.FailOn)
+	for name, category := range c.Categories {
+		if level, err := core.ParseFailOn(category.FailOn); err == nil {
+			levels[name] = level
+		}
+	}
+	return levels
+}
+
+// ScoreGate returns the minimum scores of a run over the given repositories.
+// A positive minScore, from --min-score, replaces the configured min_score;
+// the min_score of the last override matching a repository replaces both for
+// that repository.
+func (c *AdvancedConfig) ScoreGate(repos []core.Repository, minScore int) core.ScoreGate {
+	gate := core.ScoreGate{MinScore: c.MinScore}
+	if minScore > 0 {
+		gate.MinScore = minScore
+	}
+
+	for _, repo := range repos {
+		for _, override := range c.Overrides {
+			if override.MinScore == nil || !c.matchesConditions(override.Conditions, repo) {
+				continue
+			}
+			if gate.Repositories == nil {
+				gate.Repositories = make(map[string]int)
+			}
+			gate.Repositories[repo.Name] = *override.MinScore
+		}
+	}
+
+	for name, category := range c.Categories {
+		if category.MinScore <= 0 {
+			continue
+		}
+		if gate.Categories == nil {
+			gate.Categories = make(map[string]int)
+		}
+		gate.Categories[name] = category.MinScore
+	}
+	return gate
+}
+
+// OutcomeExitCodes returns the exit codes of the outcomes of a run, the
+// defaults with those configured in exit_codes applied
+func (c *AdvancedConfig) OutcomeExitCodes() core.ExitCodes {
+	codes := core.DefaultExitCodes
+	for _, code := range []struct {
+		configured *int
+		target     *int
+	}{
+		{c.ExitCodes.Healthy, &codes.Healthy},
+		{c.ExitCodes.Warning, &codes.Warning},
+		{c.ExitCodes.Critical, &codes.Critical},
+		{c.ExitCodes.Errored, &codes.Errored},
+	} {
+		if code.configured != nil {
+			*code.target = *code.configured
+		}
+	}
+	return codes
+}
+
+// ForRepository returns the configuration for one repository: a copy with the
+// checker, analyzer and engine settings of every matching override applied in
+// order. The receiver is not modified, so repositories checked concurrently
+// each get their own view.
+func (c *AdvancedConfig) ForRepository(repo core.Repository) core.Config {
+	var matching []OverrideConfig
 	for _, override := range c.Overrides {
 		if c.matchesConditions(override.Conditions, repo) {
-			// Apply override configurations
-			for checkerID, checkerConfig := range override.Checkers {
-				c.Checkers[checkerID] = checkerConfig
-			}
+			matching = append(matching, override)
+		}
+	}
+	if len(matching) == 0 {
+		return c
+	}
 
-			for language, analyzerConfig := range override.Analyzers {
-				c.Analyzers[language] = analyzerConfig
-			}
+	repoConfig := *c
+	repoConfig.Checkers = make(map[string]core.CheckerConfig, len(c.Checkers))
+	for id, checkerConfig := range c.Checkers {
+		repoConfig.Checkers[id] = checkerConfig
+	}
+	repoConfig.Analyzers = make(map[string]core.AnalyzerConfig, len(c.Analyzers))
+	for language, analyzerConfig := range c.Analyzers {
+		repoConfig.Analyzers[language] = analyzerConfig
+	}
 
-			if override.Engine != nil {
-				c.Engine = *override.Engine
-			}
+	for _, override := range matching {
+		for checkerID, checkerConfig := range override.Checkers {
+			repoConfig.Checkers[checkerID] = repoConfig.Checkers[checkerID].Merge(checkerConfig)
+		}
+		for language, analyzerConfig := range override.Analyzers {
+			repoConfig.Analyzers[language] = analyzerConfig
+		}
+		if override.Engine != nil {
+			repoConfig.Engine = *override.Engine
 		}
 	}
 
-	return nil
+	return &repoConfig
 }
 
 // matchesConditions checks if repository matches override conditions
@@ -362,7 +905,10 @@ func (c *AdvancedConfig) matchesCondition(condition ConditionConfig, repo core.R
 		// Handle tags separately since it's a slice
 		return c.matchesTagCondition(condition, repo.Tags)
 	case "path":
-		fieldValue = repo.Path
+		fieldValue = filepath.ToSlash(repo.Path)
+	case "subpath":
+		// Matches repositories containing files under the given glob
+		return containsMatchingFile(repo.Path, fmt.Sprintf("%v", condition.Value))
 	}
 
 	return c.evaluateCondition(condition, fieldValue)
@@ -392,10 +938,8 @@ func (c *AdvancedConfig) matchesTagCondition(condition ConditionConfig, tags []s
 		return false
 	case "in":
 		for _, tag := range tags {
-			for _, value := range condition.Values {
-				if tag == fmt.Sprintf("%v", value) {
-					return true
-				}
+			if condition.hasValue(tag) {
+				return true
 			}
 		}
 		return false
@@ -403,6 +947,16 @@ func (c *AdvancedConfig) matchesTagCondition(condition ConditionConfig, tags []s
 	return false
 }
 
+// hasValue reports whether s is one of the condition's values
+func (c ConditionConfig) hasValue(s string) bool {
+	for _, value := range c.Values {
+		if s == fmt.Sprintf("%v", value) {
+			return true
+		}
+	}
+	return false
+}
+
 // evaluateCondition evaluates a condition against a field value
 func (c *AdvancedConfig) evaluateCondition(condition ConditionConfig, fieldValue string) bool {
 	switch condition.Operator {
@@ -413,12 +967,12 @@ func (c *AdvancedConfig) evaluateCondition(condition ConditionConfig, fieldValue
 			len(fieldValue) >= len(fmt.Sprintf("%v", condition.Value)) &&
 			fieldValue == fmt.Sprintf("%v", condition.Value)
 	case "in":
-		for _, value := range condition.Values {
-			if fieldValue == fmt.Sprintf("%v", value) {
-				return true
-			}
-		}
-		return false
+		return condition.hasValue(fieldValue)
+	case "glob":
+		return matchesGlob(fmt.Sprintf("%v", condition.Value), fieldValue)
+	case "regex", "matches":
+		matched, err := regexp.MatchString(fmt.Sprintf("%v", condition.Value), fieldValue)
+		return err == nil && matched
 	}
 	return false
 }
@@ -437,7 +991,11 @@ func (c *AdvancedConfig) SaveConfig(configPath string) error {
 	return nil
 }
 
-// MergeConfig merges another configuration into this one
+// MergeConfig merges another configuration into this one. Checkers, analyzers,
+// reporters, categories and custom checkers are replaced per key, overrides and
+// hooks are appended, integrations are replaced when enabled in other, and
+// min_score is replaced when set in other. Version and engine settings are
+// kept from this configuration.
 func (c *AdvancedConfig) MergeConfig(other *AdvancedConfig) {
 	// Merge checkers
 	for id, config := range other.Checkers {
@@ -461,6 +1019,76 @@ func (c *AdvancedConfig) MergeConfig(other *AdvancedConfig) {
 
 	// Append overrides
 	c.Overrides = append(c.Overrides, other.Overrides...)
+
+	// Skipped checkers are added per tag
+	for tag, ids := range other.SkipCheckers {
+		if c.SkipCheckers == nil {
+			c.SkipCheckers = make(map[string][]string)
+		}
+		c.SkipCheckers[tag] = append(c.SkipCheckers[tag], ids...)
+	}
+
+	if other.MinScore != 0 {
+		c.MinScore = other.MinScore
+	}
+
+	// Exit codes are replaced per outcome
+	c.mergeExitCodes(other.ExitCodes)
+
+	// Enabled integrations replace existing ones
+	c.mergeIntegrations(other.Integrations)
+
+	// Hooks are appended
+	c.Extensions.Hooks = append(c.Extensions.Hooks, other.Extensions.Hooks...)
+
+	// Custom checkers are replaced by ID
+	c.mergeCustomCheckers(other.Extensions.CustomCheckers)
+}
+
+// mergeExitCodes replaces the exit codes set in other
+func (c *AdvancedConfig) mergeExitCodes(other ExitCodesConfig) {
+	for _, code := range []struct{ target, value **int }{
+		{&c.ExitCodes.Healthy, &other.Healthy},
+		{&c.ExitCodes.Warning, &other.Warning},
+		{&c.ExitCodes.Critical, &other.Critical},
+		{&c.ExitCodes.Errored, &other.Errored},
+	} {
+		if *code.value != nil {
+			*code.target = *code.value
+		}
+	}
+}
+
+// mergeIntegrations replaces the integrations enabled in other
+func (c *AdvancedConfig) mergeIntegrations(other IntegrationsConfig) {
+	if other.JIRA.Enabled {
+		c.Integrations.JIRA = other.JIRA
+	}
+	if other.GitHub.Enabled {
+		c.Integrations.GitHub = other.GitHub
+	}
+	if other.Slack.Enabled {
+		c.Integrations.Slack = other.Slack
+	}
+	if other.Tracing.OTLPEndpoint != "" {
+		c.Integrations.Tracing = other.Tracing
+	}
+}
+
+// mergeCustomCheckers replaces custom checkers by ID and appends new ones
+func (c *AdvancedConfig) mergeCustomCheckers(customs []CustomCheckerConfig) {
+	for _, custom := range customs {
+		replaced := false
+		for i := range c.Extensions.CustomCheckers {
+			if c.Extensions.CustomCheckers[i].ID == custom.ID {
+				c.Extensions.CustomCheckers[i] = custom
+				replaced = true
+			}
+		}
+		if !replaced {
+			c.Extensions.CustomCheckers = append(c.Extensions.CustomCheckers, custom)
+		}
+	}
 }
 
 // FilterByCategories creates a new AdvancedConfig with only checkers and analyzers
@@ -480,6 +1108,10 @@ func (c *AdvancedConfig) FilterByCategories(categories []string) *AdvancedConfig
 		Reporters:  c.Reporters,  // Copy reporters as-is
 		Categories: c.Categories, // Copy categories as-is
 		Overrides:  c.Overrides,  // Copy overrides as-is
+
+		Integrations: c.Integrations,
+		Extensions:   c.Extensions,
+		SkipCheckers: c.SkipCheckers,
 	}
 
 	// Create a set of target categories for efficient lookup
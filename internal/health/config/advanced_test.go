@@ -1,7 +1,12 @@
 package config
 
 import (
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+
+	"github.com/codcod/repos/internal/core"
 )
 
 func TestNewDefaultAdvancedConfig(t *testing.T) {
@@ -24,7 +29,7 @@ func TestNewDefaultAdvancedConfig(t *testing.T) {
 		t.Error("Categories should be initialized with default values")
 	}
 
-	expectedCategories := []string{"security", "quality", "compliance", "ci", "docs"}
+	expectedCategories := []string{"security", "quality", "compliance", "ci", "dependencies", "git", "documentation"}
 	for _, cat := range expectedCategories {
 		if _, exists := config.Categories[cat]; !exists {
 			t.Errorf("Expected category '%s' to exist", cat)
@@ -52,3 +57,516 @@ func TestLoadAdvancedConfigOrDefault(t *testing.T) {
 		t.Error("Default config should have categories")
 	}
 }
+
+func TestGetCategoryWeight(t *testing.T) {
+	config := NewDefaultAdvancedConfig()
+	config.Categories["git"] = CategoryConfig{Name: "Git", Enabled: false, Weight: 50}
+	config.Categories["custom"] = CategoryConfig{Name: "Custom", Enabled: true}
+
+	tests := []struct {
+		category       string
+		expectedWeight float64
+		expectedExists bool
+	}{
+		{"security", 30, true},
+		{"git", 0, true},      // disabled categories do not count
+		{"custom", 1.0, true}, // unset weight falls back to 1.0
+		{"unknown", 0, false},
+	}
+
+	for _, tt := range tests {
+		weight, exists := config.GetCategoryWeight(tt.category)
+		if weight != tt.expectedWeight || exists != tt.expectedExists {
+			t.Errorf("GetCategoryWeight(%q) = (%v, %v), want (%v, %v)",
+				tt.category, weight, exists, tt.expectedWeight, tt.expectedExists)
+		}
+	}
+}
+
+func TestPathConditions(t *testing.T) {
+	repo := core.Repository{Name: "payments-api", Path: "/src/monorepo/services/payments/api"}
+	config := NewDefaultAdvancedConfig()
+
+	tests := []struct {
+		name      string
+		condition ConditionConfig
+		expected  bool
+	}{
+		{"glob below directory", ConditionConfig{Type: "path", Operator: "glob", Value: "services/payments/**"}, true},
+		{"glob other directory", ConditionConfig{Type: "path", Operator: "glob", Value: "services/billing/**"}, false},
+		{"glob single segment", ConditionConfig{Type: "path", Operator: "glob", Value: "services/*"}, false},
+		{"absolute glob", ConditionConfig{Type: "path", Operator: "glob", Value: "/src/*/services/**"}, true},
+		{"regex", ConditionConfig{Type: "path", Operator: "regex", Value: `/services/(payments|billing)/`}, true},
+		{"regex no match", ConditionConfig{Type: "path", Operator: "regex", Value: `^/legacy/`}, false},
+		{"equals", ConditionConfig{Type: "path", Operator: "equals", Value: "/src/monorepo/services/payments/api"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := config.matchesCondition(tt.condition, repo); got != tt.expected {
+				t.Errorf("matchesCondition(%+v) = %v, want %v", tt.condition, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestSubpathCondition(t *testing.T) {
+	repoPath := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(repoPath, "deploy", "k8s"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(repoPath, "deploy", "k8s", "service.yaml"), []byte("kind: Service\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	repo := core.Repository{Name: "svc", Path: repoPath}
+	config := NewDefaultAdvancedConfig()
+
+	for pattern, expected := range map[string]bool{
+		"deploy/**/*.yaml": true,
+		"**/service.yaml":  true,
+		"*.yaml":           false,
+		"charts/**":        false,
+	} {
+		condition := ConditionConfig{Type: "subpath", Operator: "glob", Value: pattern}
+		if got := config.matchesCondition(condition, repo); got != expected {
+			t.Errorf("subpath %q = %v, want %v", pattern, got, expected)
+		}
+	}
+}
+
+func TestScoreGate(t *testing.T) {
+	legacyMin := 40
+	config := NewDefaultAdvancedConfig()
+	config.MinScore = 70
+	config.Categories = map[string]CategoryConfig{"security": {Enabled: true, MinScore: 80}, "documentation": {Enabled: true}}
+	config.Overrides = []OverrideConfig{{
+		Name:       "legacy",
+		Conditions: []ConditionConfig{{Type: "tag", Operator: "contains", Value: "legacy"}},
+		MinScore:   &legacyMin,
+	}}
+	repos := []core.Repository{{Name: "api"}, {Name: "old", Tags: []string{"legacy"}}}
+
+	gate := config.ScoreGate(repos, 0)
+	if gate.MinScoreFor("api") != 70 || gate.MinScoreFor("old") != 40 {
+		t.Errorf("Expected 70 for api and 40 for the legacy repo, got %+v", gate)
+	}
+	if len(gate.Categories) != 1 || gate.Categories["security"] != 80 {
+		t.Errorf("Expected only the security category to be gated, got %v", gate.Categories)
+	}
+
+	gate = config.ScoreGate(repos, 90)
+	if gate.MinScoreFor("api") != 90 || gate.MinScoreFor("old") != 40 {
+		t.Errorf("Expected --min-score to replace min_score but not the override, got %+v", gate)
+	}
+}
+
+func TestOutcomeExitCodes(t *testing.T) {
+	config := NewDefaultAdvancedConfig()
+	if codes := config.OutcomeExitCodes(); codes != core.DefaultExitCodes {
+		t.Errorf("Expected the default exit codes, got %+v", codes)
+	}
+
+	warning, errored := 1, 4
+	config.ExitCodes = ExitCodesConfig{Warning: &warning}
+	other := NewDefaultAdvancedConfig()
+	other.ExitCodes = ExitCodesConfig{Errored: &errored}
+	config.MergeConfig(other)
+
+	expected := core.ExitCodes{Healthy: 0, Warning: 1, Critical: 2, Errored: 4}
+	if codes := config.OutcomeExitCodes(); codes != expected {
+		t.Errorf("Expected %+v, got %+v", expected, codes)
+	}
+}
+
+func TestValidateOverrideConditions_InvalidRegex(t *testing.T) {
+	config := NewDefaultAdvancedConfig()
+	override := OverrideConfig{
+		Name:       "broken",
+		Conditions: []ConditionConfig{{Type: "path", Operator: "regex", Value: "services/("}},
+	}
+
+	if err := config.validateOverrideConditions(override); err == nil {
+		t.Error("Expected an error for an invalid regular expression")
+	}
+}
+
+func TestValidateOverrideConditions_MatchesKeepsLoading(t *testing.T) {
+	config := NewDefaultAdvancedConfig()
+	override := OverrideConfig{
+		Name:       "go-services",
+		Conditions: []ConditionConfig{{Type: "repository", Field: "name", Operator: "matches", Value: "*-service"}},
+	}
+
+	if err := config.validateOverrideConditions(override); err != nil {
+		t.Errorf("Expected a 'matches' condition that is not a regular expression to load, got %v", err)
+	}
+}
+
+func TestForRepository(t *testing.T) {
+	config := NewDefaultAdvancedConfig()
+	config.Checkers["vulnerability-scan"] = core.CheckerConfig{Severity: "medium", Options: map[string]interface{}{"tool": "trivy"}}
+	config.Overrides = []OverrideConfig{{
+		Name:       "payments",
+		Conditions: []ConditionConfig{{Type: "path", Operator: "glob", Value: "services/payments/**"}},
+		Checkers: map[string]core.CheckerConfig{
+			"vulnerability-scan": {Severity: "critical"},
+			"license-check":      {Enabled: true},
+		},
+	}}
+
+	payments := config.ForRepository(core.Repository{Name: "api", Path: "/src/services/payments/api"})
+	scan, _ := payments.GetCheckerConfig("vulnerability-scan")
+	if scan.Severity != "critical" || scan.Options["tool"] != "trivy" {
+		t.Errorf("Expected the override severity on top of the options, got %+v", scan)
+	}
+	if license, exists := payments.GetCheckerConfig("license-check"); !exists || !license.Enabled {
+		t.Errorf("Expected the override to enable license-check, got %+v", license)
+	}
+
+	billing := config.ForRepository(core.Repository{Name: "api", Path: "/src/services/billing/api"})
+	if scan, _ := billing.GetCheckerConfig("vulnerability-scan"); scan.Severity != "medium" {
+		t.Errorf("Expected no override for other paths, got %+v", scan)
+	}
+	if scan := config.Checkers["vulnerability-scan"]; scan.Severity != "medium" {
+		t.Errorf("Expected the shared configuration to be left unchanged, got %+v", scan)
+	}
+	if _, exists := config.Checkers["license-check"]; exists {
+		t.Error("Expected the override not to add checkers to the shared configuration")
+	}
+}
+
+func TestLoadAdvancedConfig_CheckerSeverity(t *testing.T) {
+	dir := t.TempDir()
+
+	valid := filepath.Join(dir, "valid.yaml")
+	if err := os.WriteFile(valid, []byte("checkers:\n  license-check:\n    enabled: true\n    severity: Critical\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadAdvancedConfig(valid); err != nil {
+		t.Errorf("Expected valid severity to load, got %v", err)
+	}
+
+	invalid := filepath.Join(dir, "invalid.yaml")
+	if err := os.WriteFile(invalid, []byte("overrides:\n  - name: strict\n    checkers:\n      ci-config:\n        severity: severe\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadAdvancedConfig(invalid); err == nil {
+		t.Error("Expected an error for an unknown severity")
+	}
+}
+
+func TestLoadAdvancedConfig_DisabledCheckers(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "health.yaml")
+	data := `checkers:
+  ci-config: &off
+    enabled: false
+  license-check:
+    severity: low
+  readme-check:
+    <<: *off
+    severity: low
+overrides:
+  - name: legacy
+    conditions:
+      - type: path
+        operator: glob
+        value: "legacy/**"
+    checkers:
+      git-status:
+        enabled: false
+      ci-config:
+        enabled: true
+`
+	if err := os.WriteFile(path, []byte(data), 0600); err != nil {
+		t.Fatal(err)
+	}
+	config, err := LoadAdvancedConfig(path)
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+
+	if ci := config.Checkers["ci-config"]; !ci.Disabled {
+		t.Errorf("Expected enabled: false to disable ci-config, got %+v", ci)
+	}
+	if readme := config.Checkers["readme-check"]; !readme.Disabled {
+		t.Errorf("Expected enabled: false from a merge key to disable readme-check, got %+v", readme)
+	}
+	if license := config.Checkers["license-check"]; license.Disabled {
+		t.Error("Expected a checker without enabled not to be disabled")
+	}
+
+	legacy := config.ForRepository(core.Repository{Name: "old", Path: "legacy/old"})
+	if gitStatus, _ := legacy.GetCheckerConfig("git-status"); !gitStatus.Disabled {
+		t.Errorf("Expected the override to disable git-status, got %+v", gitStatus)
+	}
+	if ci, _ := legacy.GetCheckerConfig("ci-config"); !ci.Enabled || ci.Disabled {
+		t.Errorf("Expected the override to enable ci-config again, got %+v", ci)
+	}
+}
+
+func TestLoadAdvancedConfig_OnMissingTool(t *testing.T) {
+	dir := t.TempDir()
+
+	valid := filepath.Join(dir, "valid.yaml")
+	if err := os.WriteFile(valid, []byte("engine:\n  on_missing_tool: skip\ncheckers:\n  golint:\n    options:\n      on_missing_tool: error\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	config, err := LoadAdvancedConfig(valid)
+	if err != nil {
+		t.Fatalf("Expected valid policies to load, got %v", err)
+	}
+	if config.Engine.OnMissingTool != "skip" {
+		t.Errorf("Expected the engine policy to be loaded, got %q", config.Engine.OnMissingTool)
+	}
+
+	for name, content := range map[string]string{
+		"engine.yaml":  "engine:\n  on_missing_tool: ignore\n",
+		"checker.yaml": "overrides:\n  - name: ci\n    checkers:\n      golint:\n        options:\n          on_missing_tool: quiet\n",
+	} {
+		invalid := filepath.Join(dir, name)
+		if err := os.WriteFile(invalid, []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := LoadAdvancedConfig(invalid); err == nil {
+			t.Errorf("%s: expected an error for an unknown policy", name)
+		}
+	}
+}
+
+func TestLoadAdvancedConfig_CustomCheckers(t *testing.T) {
+	dir := t.TempDir()
+
+	valid := filepath.Join(dir, "valid.yaml")
+	validYAML := "extensions:\n  custom_checkers:\n    - id: org-check\n      command: ./check.sh\n      args: [\"--json\"]\n" +
+		"checkers:\n  org-check:\n    enabled: false\n"
+	if err := os.WriteFile(valid, []byte(validYAML), 0600); err != nil {
+		t.Fatal(err)
+	}
+	config, err := LoadAdvancedConfig(valid)
+	if err != nil {
+		t.Fatalf("Expected custom checkers to load, got %v", err)
+	}
+	if len(config.Extensions.CustomCheckers) != 1 || config.Extensions.CustomCheckers[0].Args[0] != "--json" {
+		t.Errorf("Unexpected custom checkers: %+v", config.Extensions.CustomCheckers)
+	}
+
+	invalid := filepath.Join(dir, "invalid.yaml")
+	invalidYAML := "extensions:\n  custom_checkers:\n    - id: org-check\n      command: a\n    - id: org-check\n"
+	if err := os.WriteFile(invalid, []byte(invalidYAML), 0600); err != nil {
+		t.Fatal(err)
+	}
+	_, err = LoadAdvancedConfig(invalid)
+	if err == nil || !strings.Contains(err.Error(), "more than once") {
+		t.Errorf("Expected a duplicate ID error, got %v", err)
+	}
+}
+
+func TestLoadAdvancedConfig_Scoring(t *testing.T) {
+	dir := t.TempDir()
+
+	valid := filepath.Join(dir, "valid.yaml")
+	if err := os.WriteFile(valid, []byte("engine:\n  scoring:\n    model: graded\n    penalties:\n      high: 30\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	config, err := LoadAdvancedConfig(valid)
+	if err != nil {
+		t.Fatalf("Expected graded scoring to load, got %v", err)
+	}
+	penalties := config.GetEngineConfig().Scoring.SeverityPenalties()
+	if penalties[core.SeverityHigh] != 30 || penalties[core.SeverityCritical] != 50 {
+		t.Errorf("Expected configured high penalty and default critical penalty, got %v", penalties)
+	}
+
+	for name, content := range map[string]string{
+		"model.yaml":   "engine:\n  scoring:\n    model: linear\n",
+		"penalty.yaml": "engine:\n  scoring:\n    model: graded\n    penalties:\n      low: -5\n",
+	} {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := LoadAdvancedConfig(path); err == nil {
+			t.Errorf("Expected an error loading %s", name)
+		}
+	}
+}
+
+func TestLoadAdvancedConfigs_MergesInOrder(t *testing.T) {
+	dir := t.TempDir()
+	base := filepath.Join(dir, "base.yaml")
+	baseYAML := "engine:\n  max_concurrency: 8\n" +
+		"checkers:\n  license-check:\n    enabled: true\n    severity: low\n  ci-config:\n    enabled: true\n" +
+		"overrides:\n  - name: base\n"
+	if err := os.WriteFile(base, []byte(baseYAML), 0600); err != nil {
+		t.Fatal(err)
+	}
+	stdin := strings.NewReader("checkers:\n  license-check:\n    enabled: false\n    severity: high\noverrides:\n  - name: ci\n" +
+		"integrations:\n  github:\n    enabled: true\n    create_issues: true\n")
+
+	config, err := LoadAdvancedConfigs([]string{base, StdinConfigPath}, stdin)
+	if err != nil {
+		t.Fatalf("LoadAdvancedConfigs failed: %v", err)
+	}
+
+	if license := config.Checkers["license-check"]; license.Enabled || license.Severity != "high" {
+		t.Errorf("Expected the later file to replace license-check, got %+v", license)
+	}
+	if !config.Checkers["ci-config"].Enabled {
+		t.Error("Expected checkers only in the first file to be kept")
+	}
+	if len(config.Overrides) != 2 || config.Overrides[0].Name != "base" || config.Overrides[1].Name != "ci" {
+		t.Errorf("Expected overrides to be appended in order, got %+v", config.Overrides)
+	}
+	if config.Engine.MaxConcurrency != 8 {
+		t.Errorf("Expected engine settings from the first file, got %d", config.Engine.MaxConcurrency)
+	}
+	if github := config.Integrations.GitHub; !github.Enabled || !github.CreateIssues {
+		t.Errorf("Expected the later file to enable the GitHub integration, got %+v", github)
+	}
+}
+
+func TestLoadAdvancedConfigs_MergesMinScore(t *testing.T) {
+	dir := t.TempDir()
+	base := filepath.Join(dir, "base.yaml")
+	strict := filepath.Join(dir, "strict.yaml")
+	if err := os.WriteFile(base, []byte("min_score: 60\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(strict, []byte("min_score: 80\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	config, err := LoadAdvancedConfigs([]string{base, strict}, nil)
+	if err != nil {
+		t.Fatalf("LoadAdvancedConfigs failed: %v", err)
+	}
+	if config.MinScore != 80 {
+		t.Errorf("Expected the later file's min_score 80, got %d", config.MinScore)
+	}
+
+	empty := filepath.Join(dir, "empty.yaml")
+	if err := os.WriteFile(empty, []byte("checkers: {}\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	config, err = LoadAdvancedConfigs([]string{strict, empty}, nil)
+	if err != nil {
+		t.Fatalf("LoadAdvancedConfigs failed: %v", err)
+	}
+	if config.MinScore != 80 {
+		t.Errorf("Expected min_score to be kept when a later file leaves it out, got %d", config.MinScore)
+	}
+}
+
+func TestLoadAdvancedConfigs_Errors(t *testing.T) {
+	if _, err := LoadAdvancedConfigs([]string{"-", "-"}, strings.NewReader("")); err == nil {
+		t.Error("Expected an error when stdin is used twice")
+	}
+	if _, err := LoadAdvancedConfigs([]string{"-", filepath.Join(t.TempDir(), "missing.yaml")}, strings.NewReader("")); err == nil {
+		t.Error("Expected an error for a missing file")
+	}
+}
+
+func TestLoadAdvancedConfig_Include(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.Mkdir(filepath.Join(dir, "shared"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	files := map[string]string{
+		"health.yaml": "defaults: &strict\n  enabled: true\n  severity: high\n" +
+			"checkers: !include shared/checkers.yaml\n" +
+			"overrides:\n  - name: legacy\n    checkers:\n      ci-config:\n        <<: *strict\n        severity: low\n",
+		// Nested includes are relative to the file that contains them
+		"shared/checkers.yaml": "license-check: !include license.yaml\nci-config:\n  enabled: false\n",
+		"shared/license.yaml":  "enabled: true\nseverity: critical\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	config, err := LoadAdvancedConfig(filepath.Join(dir, "health.yaml"))
+	if err != nil {
+		t.Fatalf("Expected the included config to load, got %v", err)
+	}
+	if license := config.Checkers["license-check"]; !license.Enabled || license.Severity != "critical" {
+		t.Errorf("Expected license-check from the nested include, got %+v", license)
+	}
+	if config.Checkers["ci-config"].Enabled {
+		t.Error("Expected ci-config from the include to be disabled")
+	}
+	if ci := config.Overrides[0].Checkers["ci-config"]; !ci.Enabled || ci.Severity != "low" {
+		t.Errorf("Expected the merged anchor with its severity replaced, got %+v", ci)
+	}
+}
+
+func TestLoadAdvancedConfig_IncludeErrors(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"a.yaml":       "checkers: !include b.yaml\n",
+		"b.yaml":       "license-check: !include a.yaml\n",
+		"missing.yaml": "checkers: !include nowhere.yaml\n",
+		"empty.yaml":   "checkers: !include\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	expected := map[string]string{
+		"a.yaml":       "include cycle: a.yaml -> b.yaml -> a.yaml",
+		"missing.yaml": "line 1: !include nowhere.yaml",
+		"empty.yaml":   "!include needs a file path",
+	}
+	for name, want := range expected {
+		_, err := LoadAdvancedConfig(filepath.Join(dir, name))
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("%s: expected an error containing %q, got %v", name, want, err)
+		}
+	}
+}
+
+func TestExtensionConflicts(t *testing.T) {
+	defaults := map[string][]string{
+		"javascript": {".js", ".ts"},
+		"python":     {".py"},
+		"shell":      {".sh"},
+	}
+
+	// Replacing an analyzer's defaults frees its extensions for another one
+	analyzers := map[string]core.AnalyzerConfig{
+		"javascript": {FileExtensions: []string{".js", ".vue"}},
+		"python":     {FileExtensions: []string{".py", ".ts"}},
+	}
+	if errs := ExtensionConflicts(analyzers, defaults); len(errs) != 0 {
+		t.Errorf("Expected no conflicts, got %v", errs)
+	}
+
+	analyzers["shell"] = core.AnalyzerConfig{FileExtensions: []string{".vue", ".py"}}
+	errs := ExtensionConflicts(analyzers, defaults)
+	expected := []string{
+		"file extension .py is claimed by more than one analyzer: python, shell",
+		"file extension .vue is claimed by more than one analyzer: javascript, shell",
+	}
+	if len(errs) != len(expected) {
+		t.Fatalf("Expected %d conflicts, got %v", len(expected), errs)
+	}
+	for i, want := range expected {
+		if errs[i].Error() != want {
+			t.Errorf("Conflict %d: expected %q, got %q", i, want, errs[i])
+		}
+	}
+}
+
+func TestLoadAdvancedConfig_InvalidFileExtension(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "health.yaml")
+	if err := os.WriteFile(path, []byte("analyzers:\n  javascript:\n    file_extensions: [\".js\", \"vue\"]\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	_, err := LoadAdvancedConfig(path)
+	if err == nil || !strings.Contains(err.Error(), `invalid file extension "vue"`) {
+		t.Errorf("Expected an invalid extension error, got %v", err)
+	}
+}
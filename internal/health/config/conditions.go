@@ -0,0 +1,95 @@
+package config
+
+import (
+	"errors"
+	"io/fs"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// errMatchFound stops a directory walk once a matching file is found
+var errMatchFound = errors.New("match found")
+
+// globToRegexp converts a glob pattern to an anchored regular expression.
+// '*' and '?' do not cross directory separators, '**' matches any number of
+// directories. Relative patterns may match the trailing components of a path,
+// so "services/payments/**" matches "/src/monorepo/services/payments/api".
+func globToRegexp(pattern string) (*regexp.Regexp, error) {
+	var b strings.Builder
+	b.WriteString("^")
+	if !strings.HasPrefix(pattern, "/") && !strings.HasPrefix(pattern, "**") {
+		b.WriteString("(?:.*/)?")
+	}
+
+	for i := 0; i < len(pattern); i++ {
+		switch ch := pattern[i]; ch {
+		case '*':
+			if i+1 < len(pattern) && pattern[i+1] == '*' {
+				i++
+				if i+1 < len(pattern) && pattern[i+1] == '/' {
+					// "**/" matches zero or more directories
+					i++
+					b.WriteString("(?:.*/)?")
+				} else {
+					b.WriteString(".*")
+				}
+			} else {
+				b.WriteString("[^/]*")
+			}
+		case '?':
+			b.WriteString("[^/]")
+		default:
+			b.WriteString(regexp.QuoteMeta(string(ch)))
+		}
+	}
+
+	b.WriteString("$")
+	return regexp.Compile(b.String())
+}
+
+// matchesGlob reports whether a slash-separated path matches the glob pattern
+func matchesGlob(pattern, path string) bool {
+	re, err := globToRegexp(pattern)
+	if err != nil {
+		return false
+	}
+	return re.MatchString(path)
+}
+
+// containsMatchingFile reports whether the repository contains a file whose
+// path relative to the repository root matches the glob pattern
+func containsMatchingFile(repoPath, pattern string) bool {
+	if repoPath == "" || pattern == "" {
+		return false
+	}
+
+	// Patterns are anchored at the repository root
+	re, err := globToRegexp("/" + strings.TrimPrefix(pattern, "/"))
+	if err != nil {
+		return false
+	}
+
+	err = filepath.WalkDir(repoPath, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil // Skip unreadable entries
+		}
+		if d.IsDir() {
+			if d.Name() == ".git" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		relPath, err := filepath.Rel(repoPath, path)
+		if err != nil {
+			return nil
+		}
+		if re.MatchString("/" + filepath.ToSlash(relPath)) {
+			return errMatchFound
+		}
+		return nil
+	})
+
+	return errors.Is(err, errMatchFound)
+}
@@ -13,6 +13,10 @@
 //   - OverrideConfig: Conditional configuration overrides
 //   - ConfigValidator: Validates advanced configuration
 //
+// Configuration files may use YAML anchors and aliases, and an !include tag
+// that replaces a value with the contents of another file, resolved relative
+// to the including file.
+//
 // Example usage:
 //
 //	config := healthconfig.NewDefaultAdvancedConfig()
@@ -0,0 +1,115 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// IncludeTag marks a scalar naming another YAML file whose contents replace it,
+// e.g. `checkers: !include checkers.yaml`. Relative paths are resolved against
+// the directory of the including file.
+const IncludeTag = "!include"
+
+// includeResolver replaces !include nodes with the documents they name
+type includeResolver struct {
+	chain    []string // Files being included, outermost first, to detect cycles
+	included bool     // Whether any !include was resolved
+}
+
+// parseYAML parses a configuration document and resolves its !include
+// directives. path is the file the data was read from; includes in data read
+// from stdin are resolved against the working directory. It reports whether the
+// document included other files.
+func parseYAML(data []byte, path string) (*yaml.Node, bool, error) {
+	var root yaml.Node
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		return nil, false, err
+	}
+
+	resolver := &includeResolver{}
+	dir := "."
+	if path != "" && path != StdinConfigPath {
+		dir = filepath.Dir(path)
+		if abs, err := filepath.Abs(path); err == nil {
+			resolver.chain = []string{abs}
+		}
+	}
+	if err := resolver.resolve(&root, dir); err != nil {
+		return nil, false, err
+	}
+	return &root, resolver.included, nil
+}
+
+// resolve replaces the !include nodes under node, resolving relative paths against dir
+func (r *includeResolver) resolve(node *yaml.Node, dir string) error {
+	if node.Tag != IncludeTag {
+		for _, child := range node.Content {
+			if err := r.resolve(child, dir); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if node.Kind != yaml.ScalarNode || node.Value == "" {
+		return fmt.Errorf("line %d: %s needs a file path", node.Line, IncludeTag)
+	}
+	path := node.Value
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(dir, path)
+	}
+
+	included, err := r.load(path)
+	if err != nil {
+		return fmt.Errorf("line %d: %s %s: %w", node.Line, IncludeTag, node.Value, err)
+	}
+
+	// Keep the anchor so aliases of the include see the included content
+	anchor := node.Anchor
+	*node = *included
+	node.Anchor = anchor
+	r.included = true
+	return nil
+}
+
+// load parses an included file and resolves its own includes
+func (r *includeResolver) load(path string) (*yaml.Node, error) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+	for i, file := range r.chain {
+		if file == abs {
+			cycle := append(append([]string{}, r.chain[i:]...), abs)
+			for k := range cycle {
+				cycle[k] = filepath.Base(cycle[k])
+			}
+			return nil, fmt.Errorf("include cycle: %s", strings.Join(cycle, " -> "))
+		}
+	}
+
+	data, err := os.ReadFile(abs) //nolint:gosec // Included paths come from the user's config
+	if err != nil {
+		return nil, err
+	}
+	var document yaml.Node
+	if err := yaml.Unmarshal(data, &document); err != nil {
+		return nil, err
+	}
+
+	r.chain = append(r.chain, abs)
+	defer func() { r.chain = r.chain[:len(r.chain)-1] }()
+	if err := r.resolve(&document, filepath.Dir(abs)); err != nil {
+		return nil, err
+	}
+
+	// An empty file includes nothing
+	if len(document.Content) == 0 {
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!null"}, nil
+	}
+	return document.Content[0], nil
+}
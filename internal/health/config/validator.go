@@ -1,10 +1,19 @@
 package config
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
 	"strings"
 
+	"gopkg.in/yaml.v3"
+
 	"github.com/codcod/repos/internal/config"
+	"github.com/codcod/repos/internal/core"
 )
 
 // ConfigValidator provides validation for configuration files
@@ -44,18 +53,320 @@ func (v *ConfigValidator) AddRule(rule ValidationRule) {
 // Validate validates the configuration against all rules
 func (v *ConfigValidator) Validate(config *AdvancedConfig) error {
 	var errors []string
+	for _, err := range v.ValidateAll(config) {
+		errors = append(errors, err.Error())
+	}
+
+	if len(errors) > 0 {
+		return fmt.Errorf("configuration validation failed:\n  - %s", strings.Join(errors, "\n  - "))
+	}
+
+	return nil
+}
 
+// ValidateAll returns the error of every rule the configuration breaks
+func (v *ConfigValidator) ValidateAll(config *AdvancedConfig) []error {
+	var errs []error
 	for _, rule := range v.rules {
 		if err := rule.Validate(config); err != nil {
-			errors = append(errors, fmt.Sprintf("%s: %v", rule.GetDescription(), err))
+			errs = append(errs, fmt.Errorf("%s: %w", rule.GetDescription(), err))
 		}
 	}
+	return errs
+}
 
-	if len(errors) > 0 {
-		return fmt.Errorf("configuration validation failed:\n  - %s", strings.Join(errors, "\n  - "))
+// ValidationProblem is a problem found in a configuration file
+type ValidationProblem struct {
+	File    string
+	Line    int    // 0 when the problem cannot be tied to a line
+	Field   string // Path of the offending field, e.g. overrides[0].checkers.ci-config.severity
+	Message string
+}
+
+// String formats the problem as file:line: field: message
+func (p ValidationProblem) String() string {
+	location := p.File
+	if p.Line > 0 {
+		location = fmt.Sprintf("%s:%d", location, p.Line)
 	}
+	if p.Field != "" {
+		return fmt.Sprintf("%s: %s: %s", location, p.Field, p.Message)
+	}
+	return fmt.Sprintf("%s: %s", location, p.Message)
+}
 
-	return nil
+// yamlLinePattern extracts the line number from yaml.v3 error messages
+var yamlLinePattern = regexp.MustCompile(`^(?:yaml: )?line (\d+): (.*)$`)
+
+// ValidateConfigData checks a configuration file and reports every problem it
+// finds rather than stopping at the first: YAML syntax errors, unknown or
+// mistyped fields, invalid values, rule violations, rule violations once each
+// override is applied and, when knownCheckers or knownAnalyzers are not nil,
+// checker IDs and analyzer languages that do not exist, at the top level and
+// in overrides. Problems are ordered by line. !include directives are resolved
+// relative to file.
+func (v *ConfigValidator) ValidateConfigData(file string, data []byte, knownCheckers, knownAnalyzers []string) []ValidationProblem {
+	root, included, err := parseYAML(data, file)
+	if err != nil {
+		return []ValidationProblem{yamlProblem(file, err.Error())}
+	}
+
+	config, problems, ok := decodeForValidation(file, data, root, included)
+	if !ok {
+		return problems
+	}
+	collector := &problemCollector{file: file, root: root, problems: problems}
+
+	for _, problem := range config.validationErrors() {
+		collector.add(problem.path, problem.err.Error())
+	}
+	v.validateRules(config, collector)
+	if knownCheckers != nil {
+		collector.unknownCheckers(config, knownCheckers)
+	}
+	if knownAnalyzers != nil {
+		collector.unknownAnalyzers(config, knownAnalyzers)
+	}
+
+	sortProblems(collector.problems)
+	return collector.problems
+}
+
+// decodeForValidation decodes the document, reporting unknown and mistyped
+// fields as problems. It returns false when the document could not be decoded
+// at all, with the problem that stopped it.
+func decodeForValidation(file string, data []byte, root *yaml.Node, included bool) (*AdvancedConfig, []ValidationProblem, bool) {
+	// Unknown fields are only reported by a decoder, so a document with includes
+	// is encoded again with them resolved; its line numbers no longer match the file
+	if included {
+		var err error
+		if data, err = yaml.Marshal(root); err != nil {
+			return nil, []ValidationProblem{yamlProblem(file, err.Error())}, false
+		}
+	}
+
+	var problems []ValidationProblem
+	var config AdvancedConfig
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&config); err != nil && err != io.EOF {
+		var typeErr *yaml.TypeError
+		if !errors.As(err, &typeErr) {
+			return nil, []ValidationProblem{yamlProblem(file, err.Error())}, false
+		}
+		// The rest of the document was still decoded, so keep validating
+		for _, message := range typeErr.Errors {
+			problem := yamlProblem(file, message)
+			if included {
+				problem.Line = 0
+			}
+			problems = append(problems, problem)
+		}
+	}
+	config.setDefaults()
+	return &config, problems, true
+}
+
+// problemCollector gathers the problems of a document, locating each by the
+// path of the field it concerns
+type problemCollector struct {
+	file     string
+	root     *yaml.Node
+	problems []ValidationProblem
+}
+
+// add records a problem with the field at path
+func (c *problemCollector) add(path []string, message string) {
+	c.problems = append(c.problems, ValidationProblem{
+		File:    c.file,
+		Line:    nodeLine(c.root, path),
+		Field:   fieldName(path),
+		Message: message,
+	})
+}
+
+// validateRules applies the validation rules to the configuration, and again
+// with each override that replaces the engine settings applied
+func (v *ConfigValidator) validateRules(config *AdvancedConfig, collector *problemCollector) {
+	for _, err := range v.ValidateAll(config) {
+		collector.add(nil, err.Error())
+	}
+
+	for i, override := range config.Overrides {
+		if override.Engine == nil {
+			continue
+		}
+		resolved := *config
+		resolved.Engine = *override.Engine
+		for _, err := range v.ValidateAll(&resolved) {
+			collector.add([]string{"overrides", strconv.Itoa(i), "engine"},
+				fmt.Sprintf("with override '%s' applied: %v", override.Name, err))
+		}
+	}
+}
+
+// unknownCheckers reports checker IDs that are neither known nor custom
+// checkers, at the top level, in overrides and in skip_checkers
+func (c *problemCollector) unknownCheckers(config *AdvancedConfig, knownCheckers []string) {
+	known := make(map[string]bool, len(knownCheckers))
+	for _, id := range knownCheckers {
+		known[id] = true
+	}
+	for _, custom := range config.Extensions.CustomCheckers {
+		known[custom.ID] = true
+	}
+	unknown := func(path []string, id string) {
+		if !known[id] {
+			c.add(path, "unknown checker "+unknownIDMessage(id, known))
+		}
+	}
+
+	for id := range config.Checkers {
+		unknown([]string{"checkers", id}, id)
+	}
+	for i, override := range config.Overrides {
+		for id := range override.Checkers {
+			unknown([]string{"overrides", strconv.Itoa(i), "checkers", id}, id)
+		}
+	}
+	for tag, ids := range config.SkipCheckers {
+		for _, id := range ids {
+			unknown([]string{"skip_checkers", tag}, id)
+		}
+	}
+}
+
+// unknownAnalyzers reports analyzer languages that are not known, at the top
+// level and in overrides
+func (c *problemCollector) unknownAnalyzers(config *AdvancedConfig, knownAnalyzers []string) {
+	known := make(map[string]bool, len(knownAnalyzers))
+	for _, language := range knownAnalyzers {
+		known[language] = true
+	}
+	unknown := func(path []string, analyzers map[string]core.AnalyzerConfig) {
+		for language := range analyzers {
+			if !known[language] {
+				c.add(append(append([]string{}, path...), language), "unknown analyzer "+unknownIDMessage(language, known))
+			}
+		}
+	}
+
+	unknown([]string{"analyzers"}, config.Analyzers)
+	for i, override := range config.Overrides {
+		unknown([]string{"overrides", strconv.Itoa(i), "analyzers"}, override.Analyzers)
+	}
+}
+
+// sortProblems orders problems by line and then field, with problems that
+// have no line last
+func sortProblems(problems []ValidationProblem) {
+	sort.SliceStable(problems, func(i, j int) bool {
+		if (problems[i].Line == 0) != (problems[j].Line == 0) {
+			return problems[j].Line == 0 // Problems without a line come last
+		}
+		if problems[i].Line != problems[j].Line {
+			return problems[i].Line < problems[j].Line
+		}
+		return problems[i].Field < problems[j].Field
+	})
+}
+
+// unknownIDMessage quotes an unknown ID and suggests the known ID it is most
+// likely a typo of, e.g. "git-staus" (did you mean "git-status"?)
+func unknownIDMessage(id string, known map[string]bool) string {
+	best, bestDistance := "", 3 // Suggest only IDs at most two edits away
+	for candidate := range known {
+		distance := editDistance(id, candidate)
+		if distance < bestDistance || (distance == bestDistance && candidate < best) {
+			best, bestDistance = candidate, distance
+		}
+	}
+	if best == "" {
+		return fmt.Sprintf("%q", id)
+	}
+	return fmt.Sprintf("%q (did you mean %q?)", id, best)
+}
+
+// editDistance returns the Levenshtein distance between two strings
+func editDistance(a, b string) int {
+	previous := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		current := make([]int, len(b)+1)
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous = current
+	}
+	return previous[len(b)]
+}
+
+// yamlProblem turns a yaml.v3 error message into a problem, keeping its line number
+func yamlProblem(file, message string) ValidationProblem {
+	problem := ValidationProblem{File: file, Message: message}
+	if match := yamlLinePattern.FindStringSubmatch(message); match != nil {
+		problem.Line, _ = strconv.Atoi(match[1])
+		problem.Message = match[2]
+	}
+	return problem
+}
+
+// nodeLine returns the line of the deepest key along path that exists in the document
+func nodeLine(root *yaml.Node, path []string) int {
+	node := root
+	if node.Kind == yaml.DocumentNode && len(node.Content) > 0 {
+		node = node.Content[0]
+	}
+
+	line := 0
+	for _, key := range path {
+		next, keyLine := childNode(node, key)
+		if next == nil {
+			break
+		}
+		node, line = next, keyLine
+	}
+	return line
+}
+
+// childNode returns the value under a mapping key or sequence index, and its line
+func childNode(node *yaml.Node, key string) (*yaml.Node, int) {
+	switch node.Kind {
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if node.Content[i].Value == key {
+				return node.Content[i+1], node.Content[i].Line
+			}
+		}
+	case yaml.SequenceNode:
+		if index, err := strconv.Atoi(key); err == nil && index >= 0 && index < len(node.Content) {
+			return node.Content[index], node.Content[index].Line
+		}
+	}
+	return nil, 0
+}
+
+// fieldName renders a path as a field reference, e.g. overrides[0].checkers
+func fieldName(path []string) string {
+	var b strings.Builder
+	for _, key := range path {
+		if _, err := strconv.Atoi(key); err == nil {
+			fmt.Fprintf(&b, "[%s]", key)
+			continue
+		}
+		if b.Len() > 0 {
+			b.WriteString(".")
+		}
+		b.WriteString(key)
+	}
+	return b.String()
 }
 
 // ValidateBasicConfig validates a basic configuration
@@ -90,9 +401,29 @@ func (r *EngineValidationRule) Validate(config *AdvancedConfig) error {
 	if config.Engine.MaxConcurrency > 100 {
 		return fmt.Errorf("engine max_concurrency too high: %d (max: 100)", config.Engine.MaxConcurrency)
 	}
+	if config.Engine.MaxExternalProcesses < 0 {
+		return fmt.Errorf("engine max_external_processes must not be negative")
+	}
+	if config.Engine.Subprojects.MaxDepth < 0 {
+		return fmt.Errorf("engine subprojects max_depth must not be negative")
+	}
 	return nil
 }
 
 func (r *EngineValidationRule) GetDescription() string {
 	return "Engine Configuration Validation"
 }
+
+// AnalyzerExtensionRule rejects file extensions claimed by more than one analyzer.
+// Defaults holds the built-in extensions of each analyzer by language.
+type AnalyzerExtensionRule struct {
+	Defaults map[string][]string
+}
+
+func (r *AnalyzerExtensionRule) Validate(config *AdvancedConfig) error {
+	return errors.Join(ExtensionConflicts(config.Analyzers, r.Defaults)...)
+}
+
+func (r *AnalyzerExtensionRule) GetDescription() string {
+	return "Analyzer File Extensions"
+}
@@ -1,6 +1,9 @@
 package config
 
 import (
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/codcod/repos/internal/config"
@@ -126,3 +129,175 @@ func TestValidationRules(t *testing.T) {
 		}
 	})
 }
+
+func TestValidateConfigData_ReportsEveryProblem(t *testing.T) {
+	data := []byte(`engine:
+  max_concurrency: 4
+checkers:
+  license-check:
+    severity: severe
+    enabeld: true
+  no-such-checker:
+    enabled: true
+overrides:
+  - name: legacy
+    conditions:
+      - type: repo
+        operator: equals
+        value: x
+    engine:
+      timeout: 5m
+`)
+
+	problems := NewConfigValidator().ValidateConfigData("health.yaml", data, []string{"license-check"}, nil)
+
+	expected := []string{
+		"health.yaml:5: checkers.license-check.severity: checker 'license-check': invalid severity",
+		"health.yaml:6: field enabeld not found",
+		`health.yaml:7: checkers.no-such-checker: unknown checker "no-such-checker"`,
+		"health.yaml:11: overrides[0].conditions: invalid override 'legacy': invalid condition type 'repo'",
+		"health.yaml:15: overrides[0].engine: with override 'legacy' applied: Engine Configuration Validation: engine max_concurrency must be at least 1",
+	}
+	if len(problems) != len(expected) {
+		t.Fatalf("Expected %d problems, got %v", len(expected), problems)
+	}
+	for i, want := range expected {
+		if got := problems[i].String(); !strings.HasPrefix(got, want) {
+			t.Errorf("Problem %d: expected prefix %q, got %q", i, want, got)
+		}
+	}
+}
+
+func TestValidateConfigData_SyntaxError(t *testing.T) {
+	problems := NewConfigValidator().ValidateConfigData("health.yaml", []byte("checkers:\n  a: [\n"), nil, nil)
+	if len(problems) != 1 || problems[0].Line == 0 {
+		t.Fatalf("Expected one syntax error with a line number, got %v", problems)
+	}
+
+	if problems := NewConfigValidator().ValidateConfigData("empty.yaml", nil, nil, nil); len(problems) != 0 {
+		t.Errorf("Expected an empty file to be valid, got %v", problems)
+	}
+}
+
+func TestValidateConfigData_Include(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "checkers.yaml"), []byte("license-check:\n  enabeld: true\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	file := filepath.Join(dir, "health.yaml")
+
+	problems := NewConfigValidator().ValidateConfigData(file, []byte("checkers: !include checkers.yaml\n"), []string{"license-check"}, nil)
+	if len(problems) != 1 || !strings.Contains(problems[0].Message, "field enabeld not found") || problems[0].Line != 0 {
+		t.Errorf("Expected the unknown field from the include without a line, got %v", problems)
+	}
+
+	problems = NewConfigValidator().ValidateConfigData(file, []byte("engine:\n  timeout: 1m\nchecks: !include missing.yaml\n"), nil, nil)
+	if len(problems) != 1 || problems[0].Line != 3 {
+		t.Errorf("Expected the missing include on line 3, got %v", problems)
+	}
+}
+
+func TestValidateConfigData_CategoryFailOn(t *testing.T) {
+	data := []byte(`categories:
+  security:
+    fail_on: warning
+  documentation:
+    fail_on: always
+`)
+
+	problems := NewConfigValidator().ValidateConfigData("health.yaml", data, nil, nil)
+	if len(problems) != 1 {
+		t.Fatalf("Expected one problem, got %v", problems)
+	}
+	want := `health.yaml:5: categories.documentation.fail_on: category 'documentation': invalid fail_on "always"`
+	if got := problems[0].String(); !strings.HasPrefix(got, want) {
+		t.Errorf("Expected prefix %q, got %q", want, got)
+	}
+}
+
+func TestValidateConfigData_MinScore(t *testing.T) {
+	data := []byte(`min_score: 70
+categories:
+  security:
+    min_score: 120
+`)
+
+	problems := NewConfigValidator().ValidateConfigData("health.yaml", data, nil, nil)
+	if len(problems) != 1 {
+		t.Fatalf("Expected one problem, got %v", problems)
+	}
+	want := `health.yaml:4: categories.security.min_score: min_score 120 is out of range (0-100)`
+	if got := problems[0].String(); !strings.HasPrefix(got, want) {
+		t.Errorf("Expected prefix %q, got %q", want, got)
+	}
+}
+
+func TestValidateConfigData_ExitCodes(t *testing.T) {
+	data := []byte(`exit_codes:
+  warning: 1
+  errored: 300
+`)
+
+	problems := NewConfigValidator().ValidateConfigData("health.yaml", data, nil, nil)
+	if len(problems) != 1 {
+		t.Fatalf("Expected one problem, got %v", problems)
+	}
+	want := `health.yaml:3: exit_codes.errored: exit code 300 for errored is out of range (0-255)`
+	if got := problems[0].String(); !strings.HasPrefix(got, want) {
+		t.Errorf("Expected prefix %q, got %q", want, got)
+	}
+}
+
+func TestValidateConfigData_SkipCheckers(t *testing.T) {
+	data := []byte(`skip_checkers:
+  archived:
+    - branch-protection
+    - no-such-checker
+`)
+
+	problems := NewConfigValidator().ValidateConfigData("health.yaml", data, []string{"branch-protection"}, nil)
+	if len(problems) != 1 {
+		t.Fatalf("Expected one problem, got %v", problems)
+	}
+	want := `health.yaml:2: skip_checkers.archived: unknown checker "no-such-checker"`
+	if got := problems[0].String(); !strings.HasPrefix(got, want) {
+		t.Errorf("Expected prefix %q, got %q", want, got)
+	}
+}
+
+func TestValidateConfigData_UnknownOverrideKeys(t *testing.T) {
+	data := []byte(`analyzers:
+  golang:
+    enabled: true
+overrides:
+  - name: legacy
+    conditions:
+      - type: tag
+        operator: contains
+        value: legacy
+    checkers:
+      git-staus:
+        enabled: false
+    analyzers:
+      python:
+        enabled: false
+      cobol:
+        enabled: false
+`)
+
+	problems := NewConfigValidator().ValidateConfigData("health.yaml", data, []string{"git-status", "git-size"}, []string{"go", "python"})
+
+	expected := []string{
+		`health.yaml:2: analyzers.golang: unknown analyzer "golang"`,
+		`health.yaml:11: overrides[0].checkers.git-staus: unknown checker "git-staus" (did you mean "git-status"?)`,
+		`health.yaml:16: overrides[0].analyzers.cobol: unknown analyzer "cobol"`,
+	}
+	if len(problems) != len(expected) {
+		t.Fatalf("Expected %d problems, got %v", len(expected), problems)
+	}
+	for i, want := range expected {
+		if got := problems[i].String(); got != want {
+			t.Errorf("Problem %d: expected %q, got %q", i, want, got)
+		}
+	}
+}
@@ -104,6 +104,37 @@ func (rb *ResultBuilder) AddIssue(checkerName, issueType, message string, severi
 	return rb.AddCheckResult(result)
 }
 
+// AddInfo adds an advisory issue to a check result. Unlike AddIssue it never
+// changes the result's status; a new result is healthy with a full score.
+func (rb *ResultBuilder) AddInfo(checkerName, issueType, message string, location *core.Location) *ResultBuilder {
+	issue := core.Issue{
+		Type:     issueType,
+		Message:  message,
+		Severity: core.SeverityInfo,
+		Location: location,
+	}
+
+	// Find existing result or create new one
+	for i, result := range rb.results {
+		if result.Name == checkerName {
+			rb.results[i].Issues = append(rb.results[i].Issues, issue)
+			return rb
+		}
+	}
+
+	result := core.CheckResult{
+		Name:      checkerName,
+		Category:  "general",
+		Status:    core.StatusHealthy,
+		Score:     100,
+		MaxScore:  100,
+		Issues:    []core.Issue{issue},
+		Timestamp: time.Now(),
+	}
+
+	return rb.AddCheckResult(result)
+}
+
 // AddSuccessResult adds a successful check result
 func (rb *ResultBuilder) AddSuccessResult(checkerName, category string) *ResultBuilder {
 	result := core.CheckResult{
@@ -55,6 +55,19 @@ func TestResultBuilder(t *testing.T) {
 		}
 	})
 
+	t.Run("adding info issues", func(t *testing.T) {
+		builder := NewResultBuilder(repo)
+		builder.AddInfo("docs-checker", "hint", "Consider adding a CHANGELOG", nil)
+
+		result := builder.Build()
+		if result.Status != core.StatusHealthy || result.Score != 100 {
+			t.Errorf("Expected info issues not to affect the result, got %s %d", result.Status, result.Score)
+		}
+		if issues := result.CheckResults[0].Issues; len(issues) != 1 || issues[0].Severity != core.SeverityInfo {
+			t.Errorf("Expected one info issue, got %+v", issues)
+		}
+	})
+
 	t.Run("adding issues", func(t *testing.T) {
 		builder := NewResultBuilder(repo)
 		location := &core.Location{
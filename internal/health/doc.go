@@ -49,7 +49,7 @@ To perform a basic health check on repositories:
 	formatter.DisplayResults(*result)
 
 	// Exit with appropriate code
-	os.Exit(health.GetExitCode(*result))
+	os.Exit(health.GetExitCode(*result, health.ExitPolicy{}))
 
 # Analyzers
 
@@ -1,10 +1,12 @@
 package health
 
 import (
+	"context"
 	"time"
 
 	"github.com/codcod/repos/internal/core"
 	analyzer_registry "github.com/codcod/repos/internal/health/analyzers/registry"
+	"github.com/codcod/repos/internal/health/checkers/dependencies"
 	checker_registry "github.com/codcod/repos/internal/health/checkers/registry"
 	"github.com/codcod/repos/internal/health/orchestration"
 	"github.com/codcod/repos/internal/health/reporting"
@@ -18,8 +20,17 @@ type (
 	CheckerRegistry  = checker_registry.CheckerRegistry
 	Engine           = orchestration.Engine
 	Formatter        = reporting.Formatter
+	FormatterOption  = reporting.FormatterOption
+	ExitPolicy       = reporting.ExitPolicy
+	State            = orchestration.State
+	Input            = orchestration.Input
+	AnalysisCache    = orchestration.AnalysisCache
+	DependencyDrift  = dependencies.DependencyDrift
 )
 
+// DefaultTop is how many of the lowest-scoring repositories a summary highlights
+const DefaultTop = orchestration.DefaultTop
+
 // NewAnalyzerRegistry creates a new analyzer registry with all standard analyzers
 func NewAnalyzerRegistry(fs core.FileSystem, logger core.Logger) *AnalyzerRegistry {
 	return analyzer_registry.NewRegistryWithStandardAnalyzers(fs, logger)
@@ -40,6 +51,21 @@ func NewOrchestrationEngine(
 	return orchestration.NewEngine(checkerRegistry, analyzerRegistry, config, logger)
 }
 
+// LoadState reads a resume state file; a missing file is an empty state
+func LoadState(path string) (*State, error) {
+	return orchestration.LoadState(path)
+}
+
+// LoadInput reads a file listing the repositories to check and their files
+func LoadInput(path string) (*Input, error) {
+	return orchestration.LoadInput(path)
+}
+
+// NewAnalysisCache creates a cache of analysis results in dir for this tool version
+func NewAnalysisCache(dir, version string) *AnalysisCache {
+	return orchestration.NewAnalysisCache(dir, version)
+}
+
 // NewFileSystem creates a new OS filesystem implementation
 func NewFileSystem() core.FileSystem {
 	return filesystem.NewOSFileSystem()
@@ -51,13 +77,23 @@ func NewCommandExecutor(timeout time.Duration) commands.CommandExecutor {
 }
 
 // NewFormatter creates a new result formatter
-func NewFormatter(verbose bool) *Formatter {
-	return reporting.NewFormatter(verbose)
+func NewFormatter(verbose bool, opts ...FormatterOption) *Formatter {
+	return reporting.NewFormatter(verbose, opts...)
+}
+
+// Summarize aggregates the statuses, scores and issues of repository results
+func Summarize(results []core.RepositoryResult) core.WorkflowSummary {
+	return orchestration.Summarize(results)
+}
+
+// GetExitCode determines the exit code of a run under the exit policy
+func GetExitCode(result core.WorkflowResult, policy ExitPolicy) int {
+	return reporting.ExitCode(result, policy)
 }
 
-// GetExitCode determines the appropriate exit code based on results
-func GetExitCode(result core.WorkflowResult) int {
-	return reporting.ExitCode(result)
+// DiffDependencies compares the dependencies a repository declares at two git refs
+func DiffDependencies(ctx context.Context, executor commands.CommandExecutor, repoPath, from, to string) (DependencyDrift, error) {
+	return dependencies.DiffDependencies(ctx, executor, repoPath, from, to)
 }
 
 // HealthPackage provides a unified interface for all health analysis functionality
@@ -0,0 +1,161 @@
+// Package hooks runs the commands configured under extensions.hooks when the
+// engine reaches an event in a repository's health check.
+//
+// Hooks run in the repository directory with the environment of the health
+// command plus:
+//
+//	REPOS_HOOK_EVENT          pre_check, post_check or on_error
+//	REPOS_HOOK_NAME           The hook's name
+//	REPOS_REPOSITORY_NAME     Repository name
+//	REPOS_REPOSITORY_PATH     Repository path
+//	REPOS_REPOSITORY_URL      Repository URL
+//	REPOS_REPOSITORY_BRANCH   Configured branch
+//	REPOS_REPOSITORY_LANGUAGE Primary language
+//	REPOS_REPOSITORY_TAGS     Comma-separated tags
+//	REPOS_STATUS              Repository status (post_check only)
+//	REPOS_SCORE               Repository score (post_check only)
+//	REPOS_CHECKER_ID          ID of the checker that errored (on_error only)
+//	REPOS_CHECKER_ERROR       Its error message (on_error only)
+package hooks
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/codcod/repos/internal/core"
+	healthconfig "github.com/codcod/repos/internal/health/config"
+)
+
+// Event is a point in a repository's health check at which hooks run
+type Event string
+
+const (
+	// PreCheck fires before a repository's analysis and checks
+	PreCheck Event = "pre_check"
+	// PostCheck fires after a repository has been scored
+	PostCheck Event = "post_check"
+	// OnError fires once for each checker that errored
+	OnError Event = "on_error"
+)
+
+// DefaultTimeout bounds hooks that don't configure a timeout
+const DefaultTimeout = 30 * time.Second
+
+// Runner runs the configured hooks
+type Runner struct {
+	hooks  []healthconfig.HookConfig
+	logger core.Logger
+}
+
+// NewRunner creates a runner for the configured hooks
+func NewRunner(hooks []healthconfig.HookConfig, logger core.Logger) *Runner {
+	return &Runner{hooks: hooks, logger: logger}
+}
+
+// Has reports whether any hook is configured for the event
+func (r *Runner) Has(event Event) bool {
+	for _, hook := range r.hooks {
+		if Event(hook.Event) == event {
+			return true
+		}
+	}
+	return false
+}
+
+// Run runs the hooks for an event in order. vars are added to the repository
+// variables passed to each hook. A failing hook is logged and the next one
+// runs, unless it has fail_on_error set: then Run stops and returns its error.
+func (r *Runner) Run(ctx context.Context, event Event, repo core.Repository, vars map[string]string) error {
+	for _, hook := range r.hooks {
+		if Event(hook.Event) != event {
+			continue
+		}
+
+		err := r.runHook(ctx, hook, event, repo, vars)
+		if err == nil {
+			continue
+		}
+		r.logger.Warn("Hook failed",
+			core.String("hook", hookName(hook)),
+			core.String("event", string(event)),
+			core.String("repository", repo.Name),
+			core.Error("error", err))
+		if hook.FailOnError {
+			return fmt.Errorf("%s hook '%s' failed: %w", event, hookName(hook), err)
+		}
+	}
+	return nil
+}
+
+// runHook runs a single hook within its timeout
+func (r *Runner) runHook(ctx context.Context, hook healthconfig.HookConfig, event Event, repo core.Repository, vars map[string]string) error {
+	timeout := hook.Timeout
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, hook.Command, hook.Args...) //nolint:gosec // Hooks are configured by the user
+	cmd.Dir = repo.Path
+	cmd.Env = append(os.Environ(), Environment(event, hook, repo, vars)...)
+
+	var output bytes.Buffer
+	cmd.Stdout = &output
+	cmd.Stderr = &output
+
+	start := time.Now()
+	err := cmd.Run()
+	r.logger.Debug("Hook finished",
+		core.String("hook", hookName(hook)),
+		core.String("repository", repo.Name),
+		core.Duration("duration", time.Since(start)))
+
+	if ctx.Err() == context.DeadlineExceeded {
+		return fmt.Errorf("timed out after %s", timeout)
+	}
+	if err != nil {
+		if message := strings.TrimSpace(output.String()); message != "" {
+			return fmt.Errorf("%w: %s", err, message)
+		}
+		return err
+	}
+	return nil
+}
+
+// Environment returns the variables a hook receives, as KEY=value pairs
+func Environment(event Event, hook healthconfig.HookConfig, repo core.Repository, vars map[string]string) []string {
+	env := []string{
+		"REPOS_HOOK_EVENT=" + string(event),
+		"REPOS_HOOK_NAME=" + hookName(hook),
+		"REPOS_REPOSITORY_NAME=" + repo.Name,
+		"REPOS_REPOSITORY_PATH=" + repo.Path,
+		"REPOS_REPOSITORY_URL=" + repo.URL,
+		"REPOS_REPOSITORY_BRANCH=" + repo.Branch,
+		"REPOS_REPOSITORY_LANGUAGE=" + repo.Language,
+		"REPOS_REPOSITORY_TAGS=" + strings.Join(repo.Tags, ","),
+	}
+	keys := make([]string, 0, len(vars))
+	for key := range vars {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		env = append(env, key+"="+vars[key])
+	}
+	return env
+}
+
+// hookName returns the hook's name, falling back to its command
+func hookName(hook healthconfig.HookConfig) string {
+	if hook.Name != "" {
+		return hook.Name
+	}
+	return hook.Command
+}
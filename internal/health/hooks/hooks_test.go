@@ -0,0 +1,61 @@
+package hooks
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/codcod/repos/internal/core"
+	healthconfig "github.com/codcod/repos/internal/health/config"
+)
+
+type nopLogger struct{}
+
+func (nopLogger) Debug(string, ...core.Field) {}
+func (nopLogger) Info(string, ...core.Field)  {}
+func (nopLogger) Warn(string, ...core.Field)  {}
+func (nopLogger) Error(string, ...core.Field) {}
+func (nopLogger) Fatal(string, ...core.Field) {}
+
+func TestEnvironment(t *testing.T) {
+	repo := core.Repository{Name: "app", Path: "/src/app", Branch: "main", Language: "go", Tags: []string{"backend", "team-a"}}
+	env := Environment(PostCheck, healthconfig.HookConfig{Command: "notify"}, repo, map[string]string{"REPOS_SCORE": "80"})
+
+	joined := strings.Join(env, "\n")
+	for _, expected := range []string{
+		"REPOS_HOOK_EVENT=post_check",
+		"REPOS_HOOK_NAME=notify",
+		"REPOS_REPOSITORY_NAME=app",
+		"REPOS_REPOSITORY_PATH=/src/app",
+		"REPOS_REPOSITORY_TAGS=backend,team-a",
+		"REPOS_SCORE=80",
+	} {
+		if !strings.Contains(joined, expected) {
+			t.Errorf("Expected %s in hook environment:\n%s", expected, joined)
+		}
+	}
+}
+
+func TestRunner_Failures(t *testing.T) {
+	repo := core.Repository{Name: "app", Path: t.TempDir()}
+
+	lenient := NewRunner([]healthconfig.HookConfig{{Event: "pre_check", Command: "false"}}, nopLogger{})
+	if err := lenient.Run(context.Background(), PreCheck, repo, nil); err != nil {
+		t.Errorf("Expected a failing hook to be non-fatal by default, got %v", err)
+	}
+
+	strict := NewRunner([]healthconfig.HookConfig{
+		{Name: "slow", Event: "pre_check", Command: "sleep", Args: []string{"5"}, Timeout: 50 * time.Millisecond, FailOnError: true},
+	}, nopLogger{})
+	if !strict.Has(PreCheck) || strict.Has(OnError) {
+		t.Error("Expected Has to report configured events only")
+	}
+	err := strict.Run(context.Background(), PreCheck, repo, nil)
+	if err == nil || !strings.Contains(err.Error(), "timed out") {
+		t.Errorf("Expected a timeout error, got %v", err)
+	}
+	if err := strict.Run(context.Background(), PostCheck, repo, nil); err != nil {
+		t.Errorf("Expected no hooks to run for other events, got %v", err)
+	}
+}
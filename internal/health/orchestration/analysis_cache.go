@@ -0,0 +1,155 @@
+package orchestration
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/codcod/repos/internal/core"
+	"github.com/codcod/repos/internal/git"
+)
+
+// analysisCacheVersion is the format of the cache entries; entries written in
+// another format are ignored and replaced
+const analysisCacheVersion = 1
+
+// AnalysisCache persists analysis results in a directory so that later runs,
+// e.g. in CI with the directory restored, skip analyzing a repository whose
+// commit has not changed. Repositories with uncommitted changes or without a
+// commit are always analyzed. Each repository and language has one entry,
+// replaced when it is analyzed at another commit or with other settings.
+type AnalysisCache struct {
+	dir     string
+	version string
+	head    func(dir string) (string, error)
+	dirty   func(dir string) (bool, error)
+}
+
+// analysisCacheEntry is the file stored for a repository and language
+type analysisCacheEntry struct {
+	Version int                  `json:"version"`
+	Key     string               `json:"key"`
+	Result  *core.AnalysisResult `json:"result"`
+}
+
+// NewAnalysisCache creates a cache in dir. The tool version is part of every
+// key, so results of another version are analyzed again.
+func NewAnalysisCache(dir, version string) *AnalysisCache {
+	return &AnalysisCache{
+		dir:     dir,
+		version: version,
+		head:    git.HeadCommit,
+		dirty:   git.HasChanges,
+	}
+}
+
+// Key returns the key of a repository's analysis for a language and analyzer
+// configuration, or "" if the result must not be cached. The files of a listed
+// index are part of the key, so a partial analysis is never taken for a full one.
+func (c *AnalysisCache) Key(repo core.Repository, lang string, config core.AnalyzerConfig) string {
+	head, err := c.head(repo.Path)
+	if err != nil || head == "" {
+		return ""
+	}
+	if dirty, err := c.dirty(repo.Path); err != nil || dirty {
+		return ""
+	}
+
+	settings, err := json.Marshal(config)
+	if err != nil {
+		return ""
+	}
+	var listed []string
+	if config.Index != nil && config.Index.Listed() != nil {
+		listed = append([]string{"listed"}, config.Index.Listed()...)
+		sort.Strings(listed[1:])
+	}
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%d\x00%s\x00%s\x00%s\x00%s\x00%s", analysisCacheVersion, c.version, head, lang, settings,
+		strings.Join(listed, "\x00"))))
+	return hex.EncodeToString(sum[:])
+}
+
+// Lookup returns the cached result stored under key
+func (c *AnalysisCache) Lookup(repo core.Repository, lang, key string) (*core.AnalysisResult, bool) {
+	if key == "" {
+		return nil, false
+	}
+
+	data, err := os.ReadFile(c.path(repo, lang))
+	if err != nil {
+		return nil, false
+	}
+	var entry analysisCacheEntry
+	if err := json.Unmarshal(data, &entry); err != nil || entry.Version != analysisCacheVersion || entry.Key != key || entry.Result == nil {
+		return nil, false
+	}
+
+	normalizeMetrics(entry.Result.Metrics)
+	for _, file := range entry.Result.Files {
+		if file != nil {
+			normalizeMetrics(file.Metrics)
+		}
+	}
+	return entry.Result, true
+}
+
+// Store writes the result under key, replacing the repository's previous entry
+// for the language. The file is replaced atomically, so concurrent runs never
+// read a partial entry.
+func (c *AnalysisCache) Store(repo core.Repository, lang, key string, result *core.AnalysisResult) error {
+	if key == "" || result == nil {
+		return nil
+	}
+	if err := os.MkdirAll(c.dir, 0o750); err != nil {
+		return fmt.Errorf("failed to create analysis cache: %w", err)
+	}
+
+	data, err := json.Marshal(analysisCacheEntry{Version: analysisCacheVersion, Key: key, Result: result})
+	if err != nil {
+		return fmt.Errorf("failed to encode analysis: %w", err)
+	}
+	path := c.path(repo, lang)
+	tmp, err := os.CreateTemp(c.dir, filepath.Base(path)+".*")
+	if err != nil {
+		return fmt.Errorf("failed to write analysis cache: %w", err)
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("failed to write analysis cache: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write analysis cache: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to write analysis cache: %w", err)
+	}
+	return nil
+}
+
+// path is the entry file of a repository and language. It depends on the
+// repository's location, since analysis results hold absolute file paths.
+func (c *AnalysisCache) path(repo core.Repository, lang string) string {
+	location, err := filepath.Abs(repo.Path)
+	if err != nil {
+		location = repo.Path
+	}
+	sum := sha256.Sum256([]byte(location + "\x00" + lang))
+	return filepath.Join(c.dir, hex.EncodeToString(sum[:8])+".json")
+}
+
+// normalizeMetrics restores the whole numbers that JSON decodes as float64 to
+// int, the type analyzers record counts with
+func normalizeMetrics(metrics map[string]interface{}) {
+	for key, value := range metrics {
+		if f, ok := value.(float64); ok && f == math.Trunc(f) && math.Abs(f) < math.MaxInt32 {
+			metrics[key] = int(f)
+		}
+	}
+}
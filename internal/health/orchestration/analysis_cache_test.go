@@ -0,0 +1,136 @@
+package orchestration
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/codcod/repos/internal/core"
+)
+
+// countingAnalyzer counts the analyses it runs
+type countingAnalyzer struct {
+	stubAnalyzer
+	calls int
+}
+
+func (a *countingAnalyzer) Analyze(ctx context.Context, repoPath string, config core.AnalyzerConfig) (*core.AnalysisResult, error) {
+	a.calls++
+	return a.stubAnalyzer.Analyze(ctx, repoPath, config)
+}
+
+func TestEngine_AnalysisCache(t *testing.T) {
+	repoPath := t.TempDir()
+	if err := os.WriteFile(filepath.Join(repoPath, "main.go"), []byte("package main\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	cacheDir := filepath.Join(t.TempDir(), "cache")
+	head, dirty := "aaa", false
+	newCache := func(version string) *AnalysisCache {
+		cache := NewAnalysisCache(cacheDir, version)
+		cache.head = func(string) (string, error) { return head, nil }
+		cache.dirty = func(string) (bool, error) { return dirty, nil }
+		return cache
+	}
+
+	analyzer := &countingAnalyzer{stubAnalyzer: stubAnalyzer{language: "go", result: core.AnalysisResult{
+		Files:     map[string]*core.FileAnalysis{"main.go": {Lines: 1, Metrics: map[string]interface{}{"function_count": 1}}},
+		Functions: []core.FunctionInfo{{Name: "main", Complexity: 2}},
+		Metrics:   map[string]interface{}{"generated_files_skipped": 3, "average_complexity": 2.5},
+	}}}
+	analyzers := &mockAnalyzerRegistry{}
+	analyzers.Register(analyzer)
+
+	run := func(cache *AnalysisCache) *core.RepositoryResult {
+		t.Helper()
+		engine := NewEngine(&mockCheckerRegistry{}, analyzers, &mockConfig{}, &mockLogger{})
+		engine.SetAnalysisCache(cache)
+		result, err := engine.ExecuteHealthCheck(context.Background(), []core.Repository{{Name: "app", Path: repoPath, Language: "go"}})
+		if err != nil {
+			t.Fatalf("ExecuteHealthCheck failed: %v", err)
+		}
+		return &result.RepositoryResults[0]
+	}
+
+	run(newCache("1.0.0"))
+	cached := run(newCache("1.0.0"))
+	if analyzer.calls != 1 {
+		t.Fatalf("Expected the second run to use the cache, got %d analyses", analyzer.calls)
+	}
+	analysis := cached.AnalysisResult
+	if analysis == nil || len(analysis.Functions) != 1 || analysis.Functions[0].Complexity != 2 {
+		t.Fatalf("Expected the cached analysis, got %+v", analysis)
+	}
+	// Counts keep their type through the cache file
+	if skipped, ok := analysis.Metrics["generated_files_skipped"].(int); !ok || skipped != 3 {
+		t.Errorf("Expected an int metric, got %T %v", analysis.Metrics["generated_files_skipped"], analysis.Metrics["generated_files_skipped"])
+	}
+	if analysis.Metrics["average_complexity"] != 2.5 || analysis.Files["main.go"].Metrics["function_count"] != 1 {
+		t.Errorf("Unexpected cached metrics: %v", analysis.Metrics)
+	}
+
+	// A new commit, uncommitted changes or another version analyze again
+	head = "bbb"
+	run(newCache("1.0.0"))
+	dirty = true
+	run(newCache("1.0.0"))
+	dirty = false
+	run(newCache("1.1.0"))
+	if analyzer.calls != 4 {
+		t.Errorf("Expected 4 analyses, got %d", analyzer.calls)
+	}
+
+	// One entry per repository and language is kept
+	entries, err := os.ReadDir(cacheDir)
+	if err != nil || len(entries) != 1 {
+		t.Errorf("Expected a single cache entry, got %v (%v)", entries, err)
+	}
+}
+
+func TestAnalysisCache_IgnoresOtherFormats(t *testing.T) {
+	cache := NewAnalysisCache(t.TempDir(), "1.0.0")
+	cache.head = func(string) (string, error) { return "aaa", nil }
+	cache.dirty = func(string) (bool, error) { return false, nil }
+	repo := core.Repository{Name: "app", Path: "/src/app"}
+
+	key := cache.Key(repo, "go", core.AnalyzerConfig{})
+	if err := os.WriteFile(cache.path(repo, "go"), []byte(`{"version":0,"key":"`+key+`","result":{}}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := cache.Lookup(repo, "go", key); ok {
+		t.Error("Expected an entry of an older format to be ignored")
+	}
+
+	if err := cache.Store(repo, "go", key, &core.AnalysisResult{Language: "go"}); err != nil {
+		t.Fatalf("Store failed: %v", err)
+	}
+	if result, ok := cache.Lookup(repo, "go", key); !ok || result.Language != "go" {
+		t.Errorf("Expected the stored entry to replace it, got %+v", result)
+	}
+	if _, ok := cache.Lookup(repo, "go", cache.Key(repo, "go", core.AnalyzerConfig{IncludeTests: true})); ok {
+		t.Error("Expected other analyzer settings to miss the cache")
+	}
+}
+
+func TestAnalysisCache_KeyCoversListedFiles(t *testing.T) {
+	cache := NewAnalysisCache(t.TempDir(), "1.0.0")
+	cache.head = func(string) (string, error) { return "aaa", nil }
+	cache.dirty = func(string) (bool, error) { return false, nil }
+	repo := core.Repository{Name: "app", Path: "/src/app"}
+
+	full := cache.Key(repo, "go", core.AnalyzerConfig{Index: core.NewFileIndex(repo.Path)})
+	partial := cache.Key(repo, "go", core.AnalyzerConfig{Index: core.NewListedFileIndex(repo.Path, []string{"b.go", "a.go"})})
+	reordered := cache.Key(repo, "go", core.AnalyzerConfig{Index: core.NewListedFileIndex(repo.Path, []string{"a.go", "b.go"})})
+	other := cache.Key(repo, "go", core.AnalyzerConfig{Index: core.NewListedFileIndex(repo.Path, []string{"a.go"})})
+
+	if full == partial || partial == other {
+		t.Error("Expected analyses of other files to have other keys")
+	}
+	if partial != reordered {
+		t.Error("Expected the order of the listed files not to change the key")
+	}
+	if full != cache.Key(repo, "go", core.AnalyzerConfig{}) {
+		t.Error("Expected a walked index to keep the key of no index")
+	}
+}
@@ -48,6 +48,33 @@ Health checks can be configured through YAML:
 	        parallel: true
 	        timeout: "60s"
 
+# Scoring
+
+A repository's overall score is the weighted average of its category scores.
+Each category score is the percentage of the maximum score achieved by the
+checkers in that category, and is exposed on RepositoryResult.CategoryScores.
+Weights come from the categories section of the configuration. The defaults
+are security 30, quality 25, compliance 20, ci and dependencies 15, and git and
+documentation 10; any other category, such as that of a custom checker, gets 1:
+
+	categories:
+	  security:
+	    enabled: true
+	    weight: 30
+
+A disabled category gets a weight of 0: its checkers still run and their
+results are reported, but they do not contribute to the overall score, and the
+remaining weights are normalized among the enabled categories.
+
+# Concurrency
+
+The engine checks up to engine.max_concurrency repositories at a time, and runs
+up to as many checkers of each repository at a time. Checkers that spawn
+external tools additionally share the engine.max_external_processes limit
+across all repositories. Repository results keep the order of the input and
+check results are ordered by checker ID. A repository whose checks fail, panic
+or are cancelled is reported as errored without affecting the others.
+
 # Advanced Features
 
 The orchestration engine supports:
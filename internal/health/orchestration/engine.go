@@ -2,11 +2,24 @@ package orchestration
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
+	"sort"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/codcod/repos/internal/core"
+	"github.com/codcod/repos/internal/git"
+	"github.com/codcod/repos/internal/health/analyzers/language"
+	"github.com/codcod/repos/internal/health/changes"
+	"github.com/codcod/repos/internal/health/hooks"
+	"github.com/codcod/repos/internal/health/suppression"
+	"github.com/codcod/repos/internal/health/tracing"
+	"github.com/codcod/repos/internal/platform/commands"
 )
 
 // Engine orchestrates the execution of health checks across repositories
@@ -17,6 +30,46 @@ type Engine struct {
 	logger           core.Logger
 	maxConcurrency   int
 	timeout          time.Duration
+	analysisFiles    map[string][]string
+	changedLines     map[string]map[string][]git.LineRange
+	repositoryFiles  map[string][]string
+	progress         ProgressReporter
+	selection        *checkerSelection
+	tracer           tracing.Tracer
+	scoring          ScoringModel
+	externalSlots    chan struct{} // nil when external tool checkers are not limited
+	subprojects      core.SubprojectConfig
+	hooks            *hooks.Runner
+	top              int
+	state            *State
+	analysisCache    *AnalysisCache
+}
+
+// DefaultTop is how many of the lowest-scoring repositories a summary highlights
+const DefaultTop = 5
+
+// externalToolCategories are the checker categories that spawn heavyweight
+// external processes such as mvn, gradle or npm
+var externalToolCategories = map[string]bool{
+	"dependencies": true,
+	"security":     true,
+}
+
+// checkerSelection restricts a run to checkers in the given categories or with the given IDs
+type checkerSelection struct {
+	categories map[string]bool
+	ids        map[string]bool
+}
+
+// matches reports whether the checker was selected by category or by ID
+func (s *checkerSelection) matches(checker core.Checker) bool {
+	return s.categories[checker.Category()] || s.ids[checker.ID()]
+}
+
+// named reports whether the checker ID was selected with --checker. A nil
+// selection names no checkers.
+func (s *checkerSelection) named(id string) bool {
+	return s != nil && s.ids[id]
 }
 
 // NewEngine creates a new orchestration engine
@@ -28,13 +81,157 @@ func NewEngine(
 ) *Engine {
 	engineConfig := config.GetEngineConfig()
 
+	scoring, err := NewScoringModel(engineConfig.Scoring)
+	if err != nil {
+		logger.Warn("Invalid scoring model, keeping checker scores", core.Error("error", err))
+	}
+
+	maxConcurrency := engineConfig.MaxConcurrency
+	if maxConcurrency < 1 {
+		maxConcurrency = 1
+	}
+
+	var externalSlots chan struct{}
+	if engineConfig.MaxExternalProcesses > 0 {
+		externalSlots = make(chan struct{}, engineConfig.MaxExternalProcesses)
+	}
+
 	return &Engine{
 		checkerRegistry:  checkerRegistry,
 		analyzerRegistry: analyzerRegistry,
 		config:           config,
 		logger:           logger,
-		maxConcurrency:   engineConfig.MaxConcurrency,
+		maxConcurrency:   maxConcurrency,
 		timeout:          engineConfig.Timeout,
+		tracer:           tracing.Noop(),
+		scoring:          scoring,
+		externalSlots:    externalSlots,
+		subprojects:      engineConfig.Subprojects,
+		hooks:            hooks.NewRunner(nil, logger),
+		top:              DefaultTop,
+	}
+}
+
+// SetAnalysisFiles restricts analysis of the named repository to the given files.
+// Passing an empty slice skips analysis of every file in that repository.
+func (e *Engine) SetAnalysisFiles(repoName string, files []string) {
+	if e.analysisFiles == nil {
+		e.analysisFiles = make(map[string][]string)
+	}
+	if files == nil {
+		files = []string{}
+	}
+	e.analysisFiles[repoName] = files
+}
+
+// SetChangedLines restricts the functions reported by the analysis of the named
+// repository to those overlapping the given changed lines, as returned by
+// git.ChangedLines, so that thresholds only apply to changed functions
+func (e *Engine) SetChangedLines(repoName string, changed map[string][]git.LineRange) {
+	if e.changedLines == nil {
+		e.changedLines = make(map[string]map[string][]git.LineRange)
+	}
+	e.changedLines[repoName] = changed
+}
+
+// SetRepositoryFiles provides the files of the named repository, which is then
+// not walked: language detection, analyzers and checkers that list files see
+// only these. Paths may be absolute or relative to the repository.
+func (e *Engine) SetRepositoryFiles(repoName string, files []string) {
+	if e.repositoryFiles == nil {
+		e.repositoryFiles = make(map[string][]string)
+	}
+	if files == nil {
+		files = []string{}
+	}
+	e.repositoryFiles[repoName] = files
+}
+
+// fileIndex returns the index of a repository's files: those provided with
+// SetRepositoryFiles, or a walk of its path
+func (e *Engine) fileIndex(repo core.Repository) *core.FileIndex {
+	if files, ok := e.repositoryFiles[repo.Name]; ok {
+		return core.NewListedFileIndex(repo.Path, files)
+	}
+	return core.NewFileIndex(repo.Path)
+}
+
+// detectLanguages counts the source files of a repository by language, from
+// its provided files when there are any
+func (e *Engine) detectLanguages(repo core.Repository, index *core.FileIndex) (language.Breakdown, error) {
+	if _, ok := e.repositoryFiles[repo.Name]; !ok {
+		return language.Detect(repo.Path, e.languageExcludes())
+	}
+	files, _, err := index.Files()
+	if err != nil {
+		return nil, err
+	}
+	paths := make([]string, len(files))
+	for i, file := range files {
+		paths[i] = file.Path
+	}
+	return language.DetectFiles(repo.Path, paths, e.languageExcludes()), nil
+}
+
+// SetProgressReporter registers a reporter that is notified as each repository finishes.
+// Calls are serialized, so the reporter need not be safe for concurrent use.
+func (e *Engine) SetProgressReporter(reporter ProgressReporter) {
+	e.progress = reporter
+}
+
+// SetTracer records a span for the workflow, each repository and each checker run.
+// Passing nil restores the default no-op tracer.
+func (e *Engine) SetTracer(tracer tracing.Tracer) {
+	if tracer == nil {
+		tracer = tracing.Noop()
+	}
+	e.tracer = tracer
+}
+
+// SetHooks runs the runner's hooks before and after each repository's checks
+// and for each checker that errors. Passing nil removes all hooks.
+func (e *Engine) SetHooks(runner *hooks.Runner) {
+	if runner == nil {
+		runner = hooks.NewRunner(nil, e.logger)
+	}
+	e.hooks = runner
+}
+
+// SetState resumes from and records into the given state: repositories it
+// recorded at their current HEAD, configuration and checker selection are not
+// checked again, and each newly checked repository is recorded as it
+// finishes. Passing nil disables resuming.
+func (e *Engine) SetState(state *State) {
+	e.state = state
+}
+
+// SetAnalysisCache reuses the analysis results the cache holds for the
+// current commit of a repository and stores new ones. Passing nil disables it.
+func (e *Engine) SetAnalysisCache(cache *AnalysisCache) {
+	e.analysisCache = cache
+}
+
+// SetTop sets how many of the lowest-scoring repositories the summary lists
+// as the worst. Zero lists none.
+func (e *Engine) SetTop(n int) {
+	e.top = max(n, 0)
+}
+
+// SelectCheckers restricts the run to checkers in any of the categories plus the
+// checkers named by ID. Naming an opt-in checker by ID enables it for this run.
+// Passing no categories and no IDs removes the restriction.
+func (e *Engine) SelectCheckers(categories, checkerIDs []string) {
+	if len(categories) == 0 && len(checkerIDs) == 0 {
+		e.selection = nil
+		return
+	}
+
+	e.selection = &checkerSelection{categories: make(map[string]bool), ids: make(map[string]bool)}
+	for _, category := range categories {
+		e.selection.categories[category] = true
+	}
+	for _, id := range checkerIDs {
+		e.selection.ids[id] = true
 	}
 }
 
@@ -46,13 +243,18 @@ func (e *Engine) ExecuteHealthCheck(ctx context.Context, repos []core.Repository
 
 	startTime := time.Now()
 
-	// Create workflow context with timeout
-	workflowCtx, cancel := context.WithTimeout(ctx, e.timeout)
+	ctx, span := e.tracer.Start(ctx, "health.workflow", tracing.Int("repository_count", len(repos)))
+	defer span.End()
+
+	// Create workflow context with timeout and a store for values checkers
+	// share during this run only
+	workflowCtx, cancel := context.WithTimeout(core.WithRunStore(ctx), e.timeout)
 	defer cancel()
 
 	// Execute checks for all repositories
 	repoResults, err := e.executeRepositoryChecks(workflowCtx, repos)
 	if err != nil {
+		span.RecordError(err)
 		return nil, fmt.Errorf("failed to execute repository checks: %w", err)
 	}
 
@@ -65,6 +267,10 @@ func (e *Engine) ExecuteHealthCheck(ctx context.Context, repos []core.Repository
 		RepositoryResults: repoResults,
 		Summary:           e.generateSummary(repoResults),
 	}
+	span.SetAttributes(
+		tracing.Int("successful_repos", workflowResult.Summary.SuccessfulRepos),
+		tracing.Int("failed_repos", workflowResult.Summary.FailedRepos),
+		tracing.Duration("duration_ms", workflowResult.Duration))
 
 	e.logger.Info("Health check workflow completed",
 		core.Duration("duration", workflowResult.Duration),
@@ -74,7 +280,9 @@ func (e *Engine) ExecuteHealthCheck(ctx context.Context, repos []core.Repository
 	return workflowResult, nil
 }
 
-// executeRepositoryChecks runs checks for all repositories with concurrency control
+// executeRepositoryChecks runs checks for up to maxConcurrency repositories at
+// a time. Results keep the order of repos, and a repository that fails, panics or
+// is cancelled gets an errored result without affecting the others.
 //
 //nolint:unparam // error return kept for future extensibility
 func (e *Engine) executeRepositoryChecks(ctx context.Context, repos []core.Repository) ([]core.RepositoryResult, error) {
@@ -84,6 +292,7 @@ func (e *Engine) executeRepositoryChecks(ctx context.Context, repos []core.Repos
 	var wg sync.WaitGroup
 	var mu sync.Mutex
 	results := make([]core.RepositoryResult, len(repos))
+	completed := 0
 
 	// Process repositories concurrently
 	for i, repo := range repos {
@@ -92,14 +301,28 @@ func (e *Engine) executeRepositoryChecks(ctx context.Context, repos []core.Repos
 		go func(index int, repository core.Repository) {
 			defer wg.Done()
 
-			// Acquire semaphore
-			semaphore <- struct{}{}
-			defer func() { <-semaphore }()
-
-			result := e.executeRepositoryCheck(ctx, repository)
+			var result core.RepositoryResult
+			select {
+			case semaphore <- struct{}{}:
+				result = e.resumableRepositoryCheck(ctx, repository)
+				<-semaphore
+			case <-ctx.Done():
+				result = erroredRepositoryResult(repository, fmt.Errorf("not started: %w", ctx.Err()))
+			}
 
 			mu.Lock()
 			results[index] = result
+			completed++
+			if e.progress != nil {
+				e.progress.ReportProgress(ctx, Progress{
+					TotalRepos:      len(repos),
+					CompletedRepos:  completed,
+					PercentComplete: float64(completed) / float64(len(repos)) * 100,
+					CurrentStep:     repository.Name,
+					Status:          ProgressRepositoryCompleted,
+					Result:          &result,
+				})
+			}
 			mu.Unlock()
 		}(i, repo)
 	}
@@ -109,45 +332,155 @@ func (e *Engine) executeRepositoryChecks(ctx context.Context, repos []core.Repos
 	return results, nil // No errors in current implementation
 }
 
+// resumableRepositoryCheck returns the result recorded in the state for the
+// repository's current HEAD, configuration and checker selection, or checks
+// the repository and records its result
+func (e *Engine) resumableRepositoryCheck(ctx context.Context, repo core.Repository) core.RepositoryResult {
+	if e.state == nil {
+		return e.safeRepositoryCheck(ctx, repo)
+	}
+
+	key := e.state.Key(repo, e.configHash(repo))
+	if result, ok := e.state.Lookup(repo, key); ok {
+		e.logger.Info("Skipping repository recorded in state",
+			core.String("repository", repo.Name),
+			core.String("head", key.Head))
+		return result
+	}
+
+	result := e.safeRepositoryCheck(ctx, repo)
+	if err := e.state.Record(result, key); err != nil {
+		e.logger.Warn("Failed to record repository in state",
+			core.String("repository", repo.Name),
+			core.Error("error", err))
+	}
+	return result
+}
+
+// configHash returns a hash of the configuration a repository is checked with
+// and of the checker selection, or "" if the configuration cannot be encoded
+func (e *Engine) configHash(repo core.Repository) string {
+	config, err := json.Marshal(e.repositoryConfig(repo))
+	if err != nil {
+		return ""
+	}
+	var selected []string
+	if e.selection != nil {
+		for category := range e.selection.categories {
+			selected = append(selected, "category:"+category)
+		}
+		for id := range e.selection.ids {
+			selected = append(selected, "checker:"+id)
+		}
+		sort.Strings(selected)
+	}
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%s\x00%s", config, strings.Join(selected, "\x00"))))
+	return hex.EncodeToString(sum[:])
+}
+
+// safeRepositoryCheck runs executeRepositoryCheck, turning a panic into an errored result
+func (e *Engine) safeRepositoryCheck(ctx context.Context, repo core.Repository) (result core.RepositoryResult) {
+	defer func() {
+		if r := recover(); r != nil {
+			e.logger.Error("Repository check panicked",
+				core.String("repository", repo.Name),
+				core.String("panic", fmt.Sprint(r)))
+			result = erroredRepositoryResult(repo, fmt.Errorf("panic: %v", r))
+		}
+	}()
+	return e.executeRepositoryCheck(ctx, repo)
+}
+
+// erroredRepositoryResult is the result of a repository whose checks could not run
+func erroredRepositoryResult(repo core.Repository, err error) core.RepositoryResult {
+	now := time.Now()
+	return core.RepositoryResult{
+		Repository: repo,
+		Status:     core.StatusErrored,
+		Error:      err.Error(),
+		StartTime:  now,
+		EndTime:    now,
+	}
+}
+
 // executeRepositoryCheck runs all checks for a single repository
 func (e *Engine) executeRepositoryCheck(ctx context.Context, repo core.Repository) core.RepositoryResult {
 	e.logger.Debug("Starting repository check", core.String("repository", repo.Name))
 
+	ctx, span := e.tracer.Start(ctx, "health.repository", tracing.String("repository.name", repo.Name))
+	defer span.End()
+
 	startTime := time.Now()
+
+	// Detect languages so that untagged repositories still get analyzed
+	index := e.fileIndex(repo)
+	languages, err := e.detectLanguages(repo, index)
+	if err != nil {
+		e.logger.Warn("Language detection failed",
+			core.String("repository", repo.Name),
+			core.Error("error", err))
+	}
+	if repo.Language == "" {
+		repo.Language = languages.Primary()
+	}
+
 	result := core.RepositoryResult{
 		Repository: repo,
+		Languages:  languages,
 		StartTime:  startTime,
 		Status:     core.StatusHealthy,
 	}
 
-	// Create repository context
+	// Create repository context. Its copy of the repository carries the file
+	// index shared by the analyzers and checkers, which the result does not keep.
+	indexed := repo
+	indexed.Files = index
 	repoCtx := core.RepositoryContext{
-		Repository: repo,
-		Config:     e.config,
+		Repository: indexed,
+		Config:     e.repositoryConfig(repo),
 		// FileSystem and Cache would be injected from platforms
 	}
 
+	// A failing pre_check hook with fail_on_error skips the repository
+	if err := e.hooks.Run(ctx, hooks.PreCheck, repo, nil); err != nil {
+		result.Status = core.StatusCritical
+		result.Error = err.Error()
+		span.RecordError(err)
+		result.EndTime = time.Now()
+		result.Duration = result.EndTime.Sub(startTime)
+		return result
+	}
+
 	// Run analysis if language is detected
 	if repo.Language != "" {
-		analysisResult, err := e.runAnalysis(ctx, repoCtx)
+		analysisResult, err := e.runAnalysis(ctx, repoCtx, repo.Language)
 		if err != nil {
 			e.logger.Warn("Analysis failed",
 				core.String("repository", repo.Name),
 				core.Error("error", err))
 		} else {
 			result.AnalysisResult = analysisResult
+			repoCtx.Analysis = analysisResult
 		}
+		result.Aggregate = AggregateAnalyses(append([]*core.AnalysisResult{analysisResult},
+			e.runSecondaryAnalyses(ctx, repoCtx, languages)...))
 	}
 
 	// Get enabled checkers for this repository
-	checkerConfigs := e.getCheckerConfigs()
-	checkResults, err := e.runCheckers(ctx, repoCtx, checkerConfigs)
+	checkerConfigs := e.getCheckerConfigs(repoCtx.Config)
+	var checkResults []core.CheckResult
+	if paths := e.discoverSubprojects(repo); len(paths) > 0 {
+		checkResults, result.Subprojects, err = e.runSubprojectCheckers(ctx, repoCtx, paths, checkerConfigs)
+	} else {
+		checkResults, err = e.runCheckers(ctx, repoCtx, checkerConfigs)
+	}
 	if err != nil {
 		e.logger.Error("Checker execution failed",
 			core.String("repository", repo.Name),
 			core.Error("error", err))
 		result.Status = core.StatusCritical
 		result.Error = err.Error()
+		span.RecordError(err)
 	} else {
 		result.CheckResults = checkResults
 		result.Status = e.calculateOverallStatus(checkResults)
@@ -155,7 +488,20 @@ func (e *Engine) executeRepositoryCheck(ctx context.Context, repo core.Repositor
 
 	result.EndTime = time.Now()
 	result.Duration = result.EndTime.Sub(startTime)
-	result.Score = e.calculateScore(checkResults)
+	result.CategoryScores = e.calculateCategoryScores(checkResults)
+	result.Score = e.calculateScore(result.CategoryScores)
+	result.MaxScore = 100
+	if err := e.runResultHooks(ctx, repo, result); err != nil {
+		result.Status = core.StatusCritical
+		if result.Error == "" {
+			result.Error = err.Error()
+		}
+		span.RecordError(err)
+	}
+	span.SetAttributes(
+		tracing.String("repository.status", string(result.Status)),
+		tracing.Int("repository.score", result.Score),
+		tracing.Duration("duration_ms", result.Duration))
 
 	e.logger.Debug("Repository check completed",
 		core.String("repository", repo.Name),
@@ -166,72 +512,284 @@ func (e *Engine) executeRepositoryCheck(ctx context.Context, repo core.Repositor
 	return result
 }
 
-// runAnalysis executes language-specific analysis
-func (e *Engine) runAnalysis(ctx context.Context, repoCtx core.RepositoryContext) (*core.AnalysisResult, error) {
+// runResultHooks runs the on_error hooks for each errored checker and then the
+// post_check hooks, returning the first failure of a hook with fail_on_error
+func (e *Engine) runResultHooks(ctx context.Context, repo core.Repository, result core.RepositoryResult) error {
+	var fatal error
+	for _, checkResult := range result.CheckResults {
+		if checkResult.Status != core.StatusErrored || !e.hooks.Has(hooks.OnError) {
+			continue
+		}
+		message := ""
+		if len(checkResult.Errors) > 0 {
+			message = checkResult.Errors[0].Message
+		}
+		err := e.hooks.Run(ctx, hooks.OnError, repo, map[string]string{
+			"REPOS_CHECKER_ID":    checkResult.ID,
+			"REPOS_CHECKER_ERROR": message,
+		})
+		if fatal == nil {
+			fatal = err
+		}
+	}
+
+	err := e.hooks.Run(ctx, hooks.PostCheck, repo, map[string]string{
+		"REPOS_STATUS": string(result.Status),
+		"REPOS_SCORE":  strconv.Itoa(result.Score),
+	})
+	if fatal == nil {
+		fatal = err
+	}
+	return fatal
+}
+
+// languageExcludes collects the exclude patterns of every configured analyzer,
+// so that language detection ignores the same files analysis does
+func (e *Engine) languageExcludes() []string {
+	if e.analyzerRegistry == nil {
+		return nil
+	}
+
+	var excludes []string
+	for _, lang := range e.analyzerRegistry.GetSupportedLanguages() {
+		if configured, ok := e.config.GetAnalyzerConfig(lang); ok {
+			excludes = append(excludes, configured.ExcludePatterns...)
+		}
+	}
+	return excludes
+}
+
+// runSecondaryAnalyses analyzes the detected languages other than the
+// repository's own that have an analyzer, in name order. Languages whose
+// analysis fails are left out.
+func (e *Engine) runSecondaryAnalyses(ctx context.Context, repoCtx core.RepositoryContext, languages language.Breakdown) []*core.AnalysisResult {
+	if e.analyzerRegistry == nil {
+		return nil
+	}
+
+	supported := e.analyzerRegistry.GetSupportedLanguages()
+	sort.Strings(supported)
+	var results []*core.AnalysisResult
+	for _, lang := range supported {
+		if lang == repoCtx.Repository.Language || languages[lang] == 0 {
+			continue
+		}
+		analysisResult, err := e.runAnalysis(ctx, repoCtx, lang)
+		if err != nil {
+			e.logger.Warn("Analysis failed",
+				core.String("repository", repoCtx.Repository.Name),
+				core.String("language", lang),
+				core.Error("error", err))
+			continue
+		}
+		results = append(results, analysisResult)
+	}
+	return results
+}
+
+// runAnalysis executes the analysis of one language of a repository
+func (e *Engine) runAnalysis(ctx context.Context, repoCtx core.RepositoryContext, lang string) (*core.AnalysisResult, error) {
 	// Skip analysis if no analyzer registry available
 	if e.analyzerRegistry == nil {
 		return nil, fmt.Errorf("analyzer registry not available")
 	}
 
-	analyzer, err := e.analyzerRegistry.GetAnalyzer(repoCtx.Repository.Language)
+	analyzer, err := e.analyzerRegistry.GetAnalyzer(lang)
+	if err != nil {
+		return nil, fmt.Errorf("analyzer not found for language %s: %w", lang, err)
+	}
+
+	analyzerConfig := e.analyzerConfig(repoCtx, lang)
+	cacheKey := ""
+	if e.analysisCache != nil {
+		cacheKey = e.analysisCache.Key(repoCtx.Repository, lang, analyzerConfig)
+		if result, ok := e.analysisCache.Lookup(repoCtx.Repository, lang, cacheKey); ok {
+			e.logger.Debug("Using cached analysis",
+				core.String("repository", repoCtx.Repository.Name),
+				core.String("language", lang))
+			e.filterChangedFunctions(repoCtx.Repository, result)
+			return result, nil
+		}
+	}
+
+	result, err := analyzer.Analyze(ctx, repoCtx.Repository.Path, analyzerConfig)
 	if err != nil {
-		return nil, fmt.Errorf("analyzer not found for language %s: %w", repoCtx.Repository.Language, err)
+		return nil, err
+	}
+	suppression.NewIndex(repoCtx.Repository.Path).FilterFunctions(result)
+	if result.Language == "" {
+		result.Language = lang
 	}
 
-	return analyzer.Analyze(ctx, repoCtx.Repository.Path, core.AnalyzerConfig{
+	if e.analysisCache != nil {
+		if err := e.analysisCache.Store(repoCtx.Repository, lang, cacheKey, result); err != nil {
+			e.logger.Warn("Failed to cache analysis",
+				core.String("repository", repoCtx.Repository.Name),
+				core.Error("error", err))
+		}
+	}
+	e.filterChangedFunctions(repoCtx.Repository, result)
+	return result, nil
+}
+
+// analyzerConfig returns the configuration to analyze one language of a repository with
+func (e *Engine) analyzerConfig(repoCtx core.RepositoryContext, lang string) core.AnalyzerConfig {
+	analyzerConfig := core.AnalyzerConfig{
 		Enabled:           true,
 		ComplexityEnabled: true,
 		FunctionLevel:     true,
-	})
+		Concurrency:       e.maxConcurrency,
+		Index:             repoCtx.Repository.Files,
+	}
+	if configured, ok := repoCtx.Config.GetAnalyzerConfig(lang); ok {
+		analyzerConfig.Options = configured.Options
+		analyzerConfig.FileExtensions = configured.FileExtensions
+		analyzerConfig.ExcludePatterns = configured.ExcludePatterns
+		analyzerConfig.IncludeTests = configured.IncludeTests
+		analyzerConfig.IncludeGenerated = configured.IncludeGenerated
+	}
+	if files, ok := e.analysisFiles[repoCtx.Repository.Name]; ok {
+		analyzerConfig.IncludeFiles = files
+	}
+	return analyzerConfig
+}
+
+// filterChangedFunctions drops the unchanged functions from the analysis of a
+// repository with changed lines set. It runs after caching so that the cached
+// analysis does not depend on the ref compared with.
+func (e *Engine) filterChangedFunctions(repo core.Repository, result *core.AnalysisResult) {
+	if changed, ok := e.changedLines[repo.Name]; ok {
+		changes.FilterChangedFunctions(result, repo.Path, changed)
+	}
 }
 
 // runCheckers executes all enabled checkers for a repository
+func (e *Engine) runCheckers(ctx context.Context, repoCtx core.RepositoryContext, checkerConfigs map[string]core.CheckerConfig) ([]core.CheckResult, error) {
+	return e.runCheckerSet(ctx, repoCtx, e.getEnabledCheckers(repoCtx.Repository, checkerConfigs))
+}
+
+// runCheckerSet executes the given checkers for a repository, up to
+// maxConcurrency at a time. Results are in checker ID order.
 //
 //nolint:unparam // error return kept for future extensibility
-func (e *Engine) runCheckers(ctx context.Context, repoCtx core.RepositoryContext, checkerConfigs map[string]core.CheckerConfig) ([]core.CheckResult, error) {
-	// This would use the registry's RunAllEnabledCheckers method
-	// For now, we'll implement a simple version
+func (e *Engine) runCheckerSet(ctx context.Context, repoCtx core.RepositoryContext, enabledCheckers []core.Checker) ([]core.CheckResult, error) {
+	sort.Slice(enabledCheckers, func(i, j int) bool { return enabledCheckers[i].ID() < enabledCheckers[j].ID() })
 
-	enabledCheckers := e.getEnabledCheckers(repoCtx.Repository, checkerConfigs)
-	results := make([]core.CheckResult, 0, len(enabledCheckers))
+	results := make([]core.CheckResult, len(enabledCheckers))
+	semaphore := make(chan struct{}, e.maxConcurrency)
+	var wg sync.WaitGroup
 
-	for _, checker := range enabledCheckers {
-		result, err := checker.Check(ctx, repoCtx)
-		if err != nil {
-			e.logger.Warn("Checker failed",
-				core.String("checker", checker.ID()),
-				core.String("repository", repoCtx.Repository.Name),
-				core.Error("error", err))
+	for i, checker := range enabledCheckers {
+		wg.Add(1)
 
-			// Create error result
-			result = core.CheckResult{
-				ID:         checker.ID(),
-				Name:       checker.Name(),
-				Category:   checker.Category(),
-				Status:     core.StatusCritical,
-				Repository: repoCtx.Repository.Name,
-				Timestamp:  time.Now(),
-				Issues: []core.Issue{{
-					Type:     "execution_error",
-					Severity: core.SeverityCritical,
-					Message:  fmt.Sprintf("Checker execution failed: %v", err),
-				}},
-			}
-		}
+		go func(index int, checker core.Checker) {
+			defer wg.Done()
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+
+			results[index] = e.runTracedChecker(ctx, checker, repoCtx)
+		}(i, checker)
+	}
 
-		results = append(results, result)
+	wg.Wait()
+
+	// Drop findings acknowledged with an inline health:ignore comment, then score
+	suppressions := suppression.NewIndex(repoCtx.Repository.Path)
+	for i := range results {
+		suppressions.FilterIssues(&results[i])
+
+		if e.scoring != nil && results[i].Scored() {
+			e.scoring.Score(&results[i])
+		}
 	}
 
 	return results, nil // No errors in current implementation
 }
 
-// getEnabledCheckers returns checkers that are enabled and support the repository
+// runTracedChecker runs a checker in its own span, turning an error or panic
+// into an errored result, and records the time it spent in external commands
+func (e *Engine) runTracedChecker(ctx context.Context, checker core.Checker, repoCtx core.RepositoryContext) core.CheckResult {
+	checkerCtx, span := e.tracer.Start(ctx, "health.checker",
+		tracing.String("checker.id", checker.ID()),
+		tracing.String("checker.category", checker.Category()),
+		tracing.String("repository.name", repoCtx.Repository.Name))
+	checkerCtx, timer := commands.WithTimer(checkerCtx)
+	startTime := time.Now()
+
+	result, err := e.runChecker(checkerCtx, checker, repoCtx)
+	if err != nil {
+		span.RecordError(err)
+		e.logger.Warn("Checker failed",
+			core.String("checker", checker.ID()),
+			core.String("repository", repoCtx.Repository.Name),
+			core.Error("error", err))
+
+		result = core.ErroredResult(checker.ID(), checker.Name(), checker.Category(), repoCtx.Repository.Name, err)
+	}
+	if result.Duration == 0 {
+		result.Duration = time.Since(startTime)
+	}
+	result.SubprocessDuration = timer.Total()
+
+	span.SetAttributes(
+		tracing.String("checker.status", string(result.Status)),
+		tracing.Duration("checker.duration_ms", time.Since(startTime)))
+	span.End()
+
+	return result
+}
+
+// runChecker runs a checker, first waiting for a free external process slot if
+// the checker runs external tools and their number is limited
+func (e *Engine) runChecker(ctx context.Context, checker core.Checker, repoCtx core.RepositoryContext) (result core.CheckResult, err error) {
+	if e.externalSlots != nil && externalToolCategories[checker.Category()] {
+		select {
+		case e.externalSlots <- struct{}{}:
+			defer func() { <-e.externalSlots }()
+		case <-ctx.Done():
+			return core.CheckResult{}, fmt.Errorf("waiting for an external process slot: %w", ctx.Err())
+		}
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("checker panicked: %v", r)
+		}
+	}()
+	return checker.Check(ctx, repoCtx)
+}
+
+// getEnabledCheckers returns checkers that are enabled and support the
+// repository. Checkers the configuration skips for the repository are left out
+// before they are asked whether they support it.
 func (e *Engine) getEnabledCheckers(repo core.Repository, checkerConfigs map[string]core.CheckerConfig) []core.Checker {
+	enabledCheckers, _ := e.selectCheckers(repo, checkerConfigs)
+	return enabledCheckers
+}
+
+// selectCheckers splits the registered checkers into those that would run on
+// the repository and those that would not, with the reason for each
+func (e *Engine) selectCheckers(repo core.Repository, checkerConfigs map[string]core.CheckerConfig) ([]core.Checker, []ExcludedChecker) {
 	allCheckers := e.checkerRegistry.GetCheckers()
 	var enabledCheckers []core.Checker
+	var excluded []ExcludedChecker
+
+	var skipped map[string]bool
+	if provider, ok := e.config.(CheckerSkipProvider); ok {
+		skipped = provider.SkippedCheckers(repo)
+	}
 
 	for _, checker := range allCheckers {
+		if e.selection != nil && !e.selection.matches(checker) {
+			excluded = append(excluded, ExcludedChecker{Checker: checker, Reason: ReasonNotSelected})
+			continue
+		}
+		if skipped[checker.ID()] {
+			excluded = append(excluded, ExcludedChecker{Checker: checker, Reason: ReasonSkipped})
+			continue
+		}
 		if !checker.SupportsRepository(repo) {
+			excluded = append(excluded, ExcludedChecker{Checker: checker, Reason: ReasonUnsupported})
 			continue
 		}
 
@@ -241,23 +799,98 @@ func (e *Engine) getEnabledCheckers(repo core.Repository, checkerConfigs map[str
 			config = checker.Config()
 		}
 
-		if config.Enabled {
+		switch {
+		case config.Enabled:
 			enabledCheckers = append(enabledCheckers, checker)
+		case config.Disabled:
+			excluded = append(excluded, ExcludedChecker{Checker: checker, Reason: ReasonConfigDisabled})
+		default:
+			excluded = append(excluded, ExcludedChecker{Checker: checker, Reason: ReasonDisabled})
 		}
 	}
 
-	return enabledCheckers
+	return enabledCheckers, excluded
+}
+
+// Plan reports what ExecuteHealthCheck would run on the repository without
+// running it: the languages that would be analyzed and the checkers that would
+// run, with the registered checkers that would not. Checkers are listed by
+// category and ID.
+func (e *Engine) Plan(repo core.Repository) RepositoryPlan {
+	languages, err := e.detectLanguages(repo, e.fileIndex(repo))
+	if err != nil {
+		e.logger.Warn("Language detection failed",
+			core.String("repository", repo.Name),
+			core.Error("error", err))
+	}
+	if repo.Language == "" {
+		repo.Language = languages.Primary()
+	}
+
+	plan := RepositoryPlan{Repository: repo}
+	if e.analyzerRegistry != nil && repo.Language != "" {
+		if _, err := e.analyzerRegistry.GetAnalyzer(repo.Language); err == nil {
+			plan.Languages = append(plan.Languages, repo.Language)
+		}
+		supported := e.analyzerRegistry.GetSupportedLanguages()
+		sort.Strings(supported)
+		for _, lang := range supported {
+			if lang != repo.Language && languages[lang] > 0 {
+				plan.Languages = append(plan.Languages, lang)
+			}
+		}
+	}
+
+	plan.Checkers, plan.Excluded = e.selectCheckers(repo, e.getCheckerConfigs(e.repositoryConfig(repo)))
+	sort.Slice(plan.Checkers, func(i, j int) bool { return checkerLess(plan.Checkers[i], plan.Checkers[j]) })
+	sort.Slice(plan.Excluded, func(i, j int) bool { return checkerLess(plan.Excluded[i].Checker, plan.Excluded[j].Checker) })
+	return plan
 }
 
-// getCheckerConfigs retrieves checker configurations
-func (e *Engine) getCheckerConfigs() map[string]core.CheckerConfig {
+// checkerLess orders checkers by category, then ID
+func checkerLess(a, b core.Checker) bool {
+	if a.Category() != b.Category() {
+		return a.Category() < b.Category()
+	}
+	return a.ID() < b.ID()
+}
+
+// repositoryConfig returns the configuration to check the repository with,
+// with the overrides matching it applied when the configuration supports them
+func (e *Engine) repositoryConfig(repo core.Repository) core.Config {
+	if provider, ok := e.config.(RepositoryConfigProvider); ok {
+		return provider.ForRepository(repo)
+	}
+	return e.config
+}
+
+// getCheckerConfigs retrieves checker configurations from the repository's configuration
+func (e *Engine) getCheckerConfigs(config core.Config) map[string]core.CheckerConfig {
 	// Get all registered checkers and enable them with default config
 	allCheckers := e.checkerRegistry.GetCheckers()
 	configs := make(map[string]core.CheckerConfig)
 
 	for _, checker := range allCheckers {
-		// Get the checker's default config and enable it
 		defaultConfig := checker.Config()
+
+		// Opt-in checkers are disabled by default and only run when enabled in configuration
+		if !defaultConfig.Enabled {
+			if configured, exists := config.GetCheckerConfig(checker.ID()); exists && configured.Enabled {
+				configs[checker.ID()] = configured
+			} else if e.selection.named(checker.ID()) {
+				defaultConfig.Enabled = true
+				configs[checker.ID()] = defaultConfig
+			}
+			continue
+		}
+
+		// Checkers enabled by default run unless the configuration disables
+		// them explicitly or they are named with --checker
+		if configured, exists := config.GetCheckerConfig(checker.ID()); exists && configured.Disabled &&
+			!e.selection.named(checker.ID()) {
+			configs[checker.ID()] = configured
+			continue
+		}
 		defaultConfig.Enabled = true
 		configs[checker.ID()] = defaultConfig
 	}
@@ -272,12 +905,15 @@ func (e *Engine) calculateOverallStatus(results []core.CheckResult) core.HealthS
 	}
 
 	hasCritical := false
+	hasErrored := false
 	hasWarning := false
 
 	for _, result := range results {
 		switch result.Status {
 		case core.StatusCritical:
 			hasCritical = true
+		case core.StatusErrored:
+			hasErrored = true
 		case core.StatusWarning:
 			hasWarning = true
 		}
@@ -286,6 +922,9 @@ func (e *Engine) calculateOverallStatus(results []core.CheckResult) core.HealthS
 	if hasCritical {
 		return core.StatusCritical
 	}
+	if hasErrored {
+		return core.StatusErrored
+	}
 	if hasWarning {
 		return core.StatusWarning
 	}
@@ -293,32 +932,91 @@ func (e *Engine) calculateOverallStatus(results []core.CheckResult) core.HealthS
 	return core.StatusHealthy
 }
 
-// calculateScore calculates an overall score based on check results
-func (e *Engine) calculateScore(results []core.CheckResult) int {
-	if len(results) == 0 {
-		return 0
+// calculateCategoryScores groups check results by category and scores each category.
+// Categories with nothing to score, such as those whose checkers all errored or
+// were skipped, are left out. Categories are returned sorted by name so output is stable.
+func (e *Engine) calculateCategoryScores(results []core.CheckResult) []core.CategoryScore {
+	totals := make(map[string]int)
+	maxTotals := make(map[string]int)
+	for _, result := range results {
+		totals[result.Category] += result.Score
+		maxTotals[result.Category] += result.MaxScore
 	}
 
-	totalScore := 0
-	totalMaxScore := 0
+	categories := make([]string, 0, len(maxTotals))
+	for category, maxTotal := range maxTotals {
+		if maxTotal > 0 {
+			categories = append(categories, category)
+		}
+	}
+	sort.Strings(categories)
 
-	for _, result := range results {
-		totalScore += result.Score
-		totalMaxScore += result.MaxScore
+	scores := make([]core.CategoryScore, 0, len(categories))
+	for _, category := range categories {
+		score := min(max((totals[category]*100)/maxTotals[category], 0), 100)
+		scores = append(scores, core.CategoryScore{
+			Category: category,
+			Score:    score,
+			Weight:   e.getCategoryWeight(category),
+		})
+	}
+
+	return scores
+}
+
+// getCategoryWeight returns the configured weight for a category, defaulting to
+// core.DefaultCategoryWeight. A disabled category has a weight of 0 and
+// therefore does not affect the overall score.
+func (e *Engine) getCategoryWeight(category string) float64 {
+	provider, ok := e.config.(CategoryWeightProvider)
+	if !ok {
+		return core.DefaultCategoryWeight
+	}
+
+	weight, exists := provider.GetCategoryWeight(category)
+	if !exists {
+		return core.DefaultCategoryWeight
 	}
+	return weight
+}
+
+// calculateScore calculates the overall score as the weighted average of category scores
+func (e *Engine) calculateScore(categoryScores []core.CategoryScore) int {
+	totalWeight := 0.0
+	weightedScore := 0.0
 
-	if totalMaxScore == 0 {
+	for _, categoryScore := range categoryScores {
+		totalWeight += categoryScore.Weight
+		weightedScore += float64(categoryScore.Score) * categoryScore.Weight
+	}
+
+	if totalWeight == 0 {
 		return 0
 	}
 
-	return (totalScore * 100) / totalMaxScore
+	return int(weightedScore / totalWeight)
 }
 
 // generateSummary creates a summary of workflow results
 func (e *Engine) generateSummary(results []core.RepositoryResult) core.WorkflowSummary {
+	summary := Summarize(results)
+	summary.Worst = Worst(summary.Ranking, e.top)
+	return summary
+}
+
+// Summarize aggregates the statuses, scores and issues of repository results,
+// e.g. to summarize results collected from several runs
+func Summarize(results []core.RepositoryResult) core.WorkflowSummary {
 	summary := core.WorkflowSummary{
-		StatusCounts:   make(map[core.HealthStatus]int),
+		StatusCounts: map[core.HealthStatus]int{
+			core.StatusHealthy:  0,
+			core.StatusWarning:  0,
+			core.StatusCritical: 0,
+			core.StatusErrored:  0,
+		},
 		SeverityCounts: make(map[core.Severity]int),
+		Ranking:        Rank(results),
+		CheckerTimings: CheckerTimings(results),
 	}
 
 	totalScore := 0
@@ -336,6 +1034,9 @@ func (e *Engine) generateSummary(results []core.RepositoryResult) core.WorkflowS
 
 		// Aggregate issues
 		for _, checkResult := range result.CheckResults {
+			if checkResult.Status == core.StatusErrored {
+				summary.ErroredChecks++
+			}
 			summary.TotalIssues += len(checkResult.Issues)
 			for _, issue := range checkResult.Issues {
 				summary.SeverityCounts[issue.Severity]++
@@ -349,3 +1050,123 @@ func (e *Engine) generateSummary(results []core.RepositoryResult) core.WorkflowS
 
 	return summary
 }
+
+// CheckerTimings totals the duration of each checker over the repositories,
+// slowest first
+func CheckerTimings(results []core.RepositoryResult) []core.CheckerTiming {
+	byID := make(map[string]*core.CheckerTiming)
+	var timings []*core.CheckerTiming
+	for _, result := range results {
+		for _, checkResult := range result.CheckResults {
+			timing, exists := byID[checkResult.ID]
+			if !exists {
+				timing = &core.CheckerTiming{ID: checkResult.ID, Name: checkResult.Name}
+				byID[checkResult.ID] = timing
+				timings = append(timings, timing)
+			}
+			timing.Runs++
+			timing.Total += checkResult.Duration
+			timing.Max = max(timing.Max, checkResult.Duration)
+			timing.Subprocess += checkResult.SubprocessDuration
+		}
+	}
+
+	sorted := make([]core.CheckerTiming, 0, len(timings))
+	for _, timing := range timings {
+		sorted = append(sorted, *timing)
+	}
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].Total != sorted[j].Total {
+			return sorted[i].Total > sorted[j].Total
+		}
+		return sorted[i].ID < sorted[j].ID
+	})
+	return sorted
+}
+
+// AggregateAnalyses totals the analyses of the languages of a repository. The
+// average complexity is weighted by each language's number of functions. Nil
+// analyses are skipped; it returns nil if there are none.
+func AggregateAnalyses(analyses []*core.AnalysisResult) *core.AnalysisAggregate {
+	var aggregate *core.AnalysisAggregate
+	totalComplexity := 0
+	for _, analysis := range analyses {
+		if analysis == nil {
+			continue
+		}
+		if aggregate == nil {
+			aggregate = &core.AnalysisAggregate{}
+		}
+
+		metrics := core.LanguageMetrics{Language: analysis.Language, Files: len(analysis.Files)}
+		for _, file := range analysis.Files {
+			metrics.Lines += file.Lines
+		}
+		complexity := 0
+		for _, fn := range analysis.Functions {
+			complexity += fn.Complexity
+			metrics.MaxComplexity = max(metrics.MaxComplexity, fn.Complexity)
+		}
+		metrics.Functions = len(analysis.Functions)
+		if metrics.Functions > 0 {
+			metrics.AverageComplexity = float64(complexity) / float64(metrics.Functions)
+		}
+
+		aggregate.TotalFiles += metrics.Files
+		aggregate.TotalLines += metrics.Lines
+		aggregate.TotalFunctions += metrics.Functions
+		aggregate.MaxComplexity = max(aggregate.MaxComplexity, metrics.MaxComplexity)
+		aggregate.Languages = append(aggregate.Languages, metrics)
+		totalComplexity += complexity
+	}
+
+	if aggregate != nil && aggregate.TotalFunctions > 0 {
+		aggregate.AverageComplexity = float64(totalComplexity) / float64(aggregate.TotalFunctions)
+	}
+	return aggregate
+}
+
+// statusOrder ranks statuses from best to worst, breaking ties between equal scores
+var statusOrder = map[core.HealthStatus]int{
+	core.StatusHealthy:  0,
+	core.StatusWarning:  1,
+	core.StatusUnknown:  2,
+	core.StatusErrored:  3,
+	core.StatusCritical: 4,
+}
+
+// Rank orders repositories from the highest score to the lowest. Repositories
+// with equal scores are ordered by status, then by name.
+func Rank(results []core.RepositoryResult) []core.RepositoryRank {
+	ranking := make([]core.RepositoryRank, 0, len(results))
+	for _, result := range results {
+		ranking = append(ranking, core.RepositoryRank{
+			Name:   result.Repository.Name,
+			Score:  result.Score,
+			Status: result.Status,
+		})
+	}
+	sort.SliceStable(ranking, func(i, j int) bool {
+		if ranking[i].Score != ranking[j].Score {
+			return ranking[i].Score > ranking[j].Score
+		}
+		if statusOrder[ranking[i].Status] != statusOrder[ranking[j].Status] {
+			return statusOrder[ranking[i].Status] < statusOrder[ranking[j].Status]
+		}
+		return ranking[i].Name < ranking[j].Name
+	})
+	for i := range ranking {
+		ranking[i].Rank = i + 1
+	}
+	return ranking
+}
+
+// Worst returns the n lowest-ranked repositories of a ranking, lowest first
+func Worst(ranking []core.RepositoryRank, n int) []core.RepositoryRank {
+	n = max(min(n, len(ranking)), 0)
+	worst := make([]core.RepositoryRank, 0, n)
+	for i := len(ranking) - 1; i >= len(ranking)-n; i-- {
+		worst = append(worst, ranking[i])
+	}
+	return worst
+}
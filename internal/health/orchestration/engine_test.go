@@ -2,11 +2,21 @@ package orchestration
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/codcod/repos/internal/core"
+	healthconfig "github.com/codcod/repos/internal/health/config"
+	"github.com/codcod/repos/internal/health/hooks"
+	"github.com/codcod/repos/internal/health/tracing"
 )
 
 // Mock implementations for testing
@@ -190,27 +200,34 @@ func (m *mockConfig) GetEngineConfig() core.EngineConfig {
 }
 
 type mockLogger struct {
+	mu   sync.Mutex
 	logs []string
 }
 
+func (m *mockLogger) log(level, msg string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.logs = append(m.logs, fmt.Sprintf("%s: %s", level, msg))
+}
+
 func (m *mockLogger) Info(msg string, fields ...core.Field) {
-	m.logs = append(m.logs, fmt.Sprintf("INFO: %s", msg))
+	m.log("INFO", msg)
 }
 
 func (m *mockLogger) Debug(msg string, fields ...core.Field) {
-	m.logs = append(m.logs, fmt.Sprintf("DEBUG: %s", msg))
+	m.log("DEBUG", msg)
 }
 
 func (m *mockLogger) Warn(msg string, fields ...core.Field) {
-	m.logs = append(m.logs, fmt.Sprintf("WARN: %s", msg))
+	m.log("WARN", msg)
 }
 
 func (m *mockLogger) Error(msg string, fields ...core.Field) {
-	m.logs = append(m.logs, fmt.Sprintf("ERROR: %s", msg))
+	m.log("ERROR", msg)
 }
 
 func (m *mockLogger) Fatal(msg string, fields ...core.Field) {
-	m.logs = append(m.logs, fmt.Sprintf("FATAL: %s", msg))
+	m.log("FATAL", msg)
 }
 
 func TestNewEngine(t *testing.T) {
@@ -323,3 +340,1089 @@ func TestEngine_ExecuteHealthCheck_SingleRepo(t *testing.T) {
 		t.Errorf("Expected 1 successful repo, got %d", result.Summary.SuccessfulRepos)
 	}
 }
+
+type weightedMockConfig struct {
+	mockConfig
+	weights map[string]float64
+}
+
+func (m *weightedMockConfig) GetCategoryWeight(category string) (float64, bool) {
+	weight, exists := m.weights[category]
+	return weight, exists
+}
+
+func TestEngine_WeightedCategoryScoring(t *testing.T) {
+	newChecker := func(id, category string, score int) *mockChecker {
+		return &mockChecker{
+			id:       id,
+			name:     id,
+			category: category,
+			result: core.CheckResult{
+				ID:       id,
+				Category: category,
+				Status:   core.StatusHealthy,
+				Score:    score,
+				MaxScore: 100,
+			},
+		}
+	}
+
+	checkerRegistry := &mockCheckerRegistry{}
+	checkerRegistry.Register(newChecker("security-check", "security", 100))
+	checkerRegistry.Register(newChecker("docs-check", "documentation", 40))
+	checkerRegistry.Register(newChecker("git-check", "git", 0))
+
+	config := &weightedMockConfig{
+		weights: map[string]float64{
+			"security":      3,
+			"documentation": 1,
+			"git":           0, // disabled category
+		},
+	}
+
+	engine := NewEngine(checkerRegistry, &mockAnalyzerRegistry{}, config, &mockLogger{})
+	result, err := engine.ExecuteHealthCheck(context.Background(), []core.Repository{{Name: "repo", Path: "/path/to/repo"}})
+	if err != nil {
+		t.Fatalf("ExecuteHealthCheck failed: %v", err)
+	}
+
+	repoResult := result.RepositoryResults[0]
+
+	// (100*3 + 40*1 + 0*0) / (3 + 1 + 0) = 85
+	if repoResult.Score != 85 {
+		t.Errorf("Expected weighted score 85, got %d", repoResult.Score)
+	}
+
+	if len(repoResult.CategoryScores) != 3 {
+		t.Fatalf("Expected 3 category scores, got %d", len(repoResult.CategoryScores))
+	}
+
+	// Categories are sorted by name
+	expected := []core.CategoryScore{
+		{Category: "documentation", Score: 40, Weight: 1},
+		{Category: "git", Score: 0, Weight: 0},
+		{Category: "security", Score: 100, Weight: 3},
+	}
+	for i, want := range expected {
+		if repoResult.CategoryScores[i] != want {
+			t.Errorf("CategoryScores[%d] = %+v, want %+v", i, repoResult.CategoryScores[i], want)
+		}
+	}
+}
+
+type skippingMockConfig struct {
+	mockConfig
+	skip map[string][]string
+}
+
+func (m *skippingMockConfig) SkippedCheckers(repo core.Repository) map[string]bool {
+	skipped := make(map[string]bool)
+	for _, tag := range repo.Tags {
+		for _, id := range m.skip[tag] {
+			skipped[id] = true
+		}
+	}
+	return skipped
+}
+
+func TestEngine_SkipsCheckersByTag(t *testing.T) {
+	checkerRegistry := &mockCheckerRegistry{}
+	checkerRegistry.Register(&mockChecker{
+		id: "docs-check", name: "Docs", category: "documentation",
+		result: core.CheckResult{ID: "docs-check", Category: "documentation", Status: core.StatusHealthy, Score: 100, MaxScore: 100},
+	})
+	// Had it run, the skipped checker would add an errored result
+	checkerRegistry.Register(&mockChecker{
+		id: "branch-protection", name: "Branch Protection", category: "security",
+		err: errors.New("branch protection API unavailable"),
+	})
+
+	config := &skippingMockConfig{skip: map[string][]string{"archived": {"branch-protection"}}}
+	engine := NewEngine(checkerRegistry, &mockAnalyzerRegistry{}, config, &mockLogger{})
+	result, err := engine.ExecuteHealthCheck(context.Background(), []core.Repository{
+		{Name: "old", Path: "/path/to/old", Tags: []string{"archived"}},
+		{Name: "live", Path: "/path/to/live"},
+	})
+	if err != nil {
+		t.Fatalf("ExecuteHealthCheck failed: %v", err)
+	}
+
+	old, live := result.RepositoryResults[0], result.RepositoryResults[1]
+	if len(old.CheckResults) != 1 || old.CheckResults[0].ID != "docs-check" {
+		t.Errorf("Expected only docs-check on the archived repository, got %+v", old.CheckResults)
+	}
+	if old.Score != 100 || old.Status != core.StatusHealthy || len(old.CategoryScores) != 1 {
+		t.Errorf("Expected the skipped checker not to affect scoring, got %d %s %+v", old.Score, old.Status, old.CategoryScores)
+	}
+	if len(live.CheckResults) != 2 {
+		t.Errorf("Expected both checkers on the untagged repository, got %+v", live.CheckResults)
+	}
+}
+
+func TestEngine_PlanMatchesExecution(t *testing.T) {
+	checkerRegistry := &mockCheckerRegistry{}
+	for _, checker := range []*mockChecker{
+		{id: "git-status", category: "git", config: core.CheckerConfig{Enabled: true}},
+		{id: "branch-protection", category: "security", config: core.CheckerConfig{Enabled: true}},
+		{id: "secrets", category: "security", config: core.CheckerConfig{Enabled: true}},
+		{id: "go-lint", category: "quality", config: core.CheckerConfig{Enabled: false}},
+		{id: "readme-check", category: "documentation", config: core.CheckerConfig{Enabled: true}},
+	} {
+		checker.result = core.CheckResult{ID: checker.id, Category: checker.category, Status: core.StatusHealthy, Score: 100, MaxScore: 100}
+		checkerRegistry.Register(checker)
+	}
+
+	config := &skippingMockConfig{skip: map[string][]string{"archived": {"branch-protection"}}}
+	engine := NewEngine(checkerRegistry, &mockAnalyzerRegistry{}, config, &mockLogger{})
+	engine.SelectCheckers([]string{"git", "security"}, []string{"go-lint"})
+
+	repo := core.Repository{Name: "old", Path: t.TempDir(), Tags: []string{"archived"}}
+	plan := engine.Plan(repo)
+
+	var planned []string
+	for _, checker := range plan.Checkers {
+		planned = append(planned, checker.ID())
+	}
+	if got := strings.Join(planned, ","); got != "git-status,go-lint,secrets" {
+		t.Errorf("Expected git-status, go-lint and secrets to be planned, got %s", got)
+	}
+	reasons := make(map[string]string)
+	for _, excluded := range plan.Excluded {
+		reasons[excluded.Checker.ID()] = excluded.Reason
+	}
+	if reasons["branch-protection"] != ReasonSkipped || reasons["readme-check"] != ReasonNotSelected || len(reasons) != 2 {
+		t.Errorf("Unexpected exclusions: %v", reasons)
+	}
+
+	result, err := engine.ExecuteHealthCheck(context.Background(), []core.Repository{repo})
+	if err != nil {
+		t.Fatalf("ExecuteHealthCheck failed: %v", err)
+	}
+	if got := len(result.RepositoryResults[0].CheckResults); got != len(plan.Checkers) {
+		t.Errorf("Expected the %d planned checkers to run, got %d", len(plan.Checkers), got)
+	}
+}
+
+func TestEngine_CategoryWeightDefault(t *testing.T) {
+	engine := NewEngine(&mockCheckerRegistry{}, &mockAnalyzerRegistry{}, &mockConfig{}, &mockLogger{})
+
+	scores := engine.calculateCategoryScores([]core.CheckResult{
+		{Category: "git", Score: 50, MaxScore: 100},
+		{Category: "security", Score: 100, MaxScore: 100},
+	})
+
+	for _, score := range scores {
+		if score.Weight != 1.0 {
+			t.Errorf("Expected the default weight of 1.0 for %s, got %v", score.Category, score.Weight)
+		}
+	}
+
+	if got := engine.calculateScore(scores); got != 75 {
+		t.Errorf("Expected score 75, got %d", got)
+	}
+}
+
+type optInMockConfig struct {
+	mockConfig
+	checkers map[string]core.CheckerConfig
+}
+
+func (m *optInMockConfig) GetCheckerConfig(checkerID string) (core.CheckerConfig, bool) {
+	config, exists := m.checkers[checkerID]
+	return config, exists
+}
+
+func TestEngine_OptInCheckersRequireConfig(t *testing.T) {
+	checkerRegistry := &mockCheckerRegistry{}
+	checkerRegistry.Register(&mockChecker{
+		id:     "default-on",
+		config: core.CheckerConfig{Enabled: true},
+		result: core.CheckResult{ID: "default-on", Status: core.StatusHealthy, Score: 100, MaxScore: 100},
+	})
+	checkerRegistry.Register(&mockChecker{
+		id:     "opt-in",
+		config: core.CheckerConfig{Enabled: false},
+		result: core.CheckResult{ID: "opt-in", Status: core.StatusHealthy, Score: 100, MaxScore: 100},
+	})
+
+	repos := []core.Repository{{Name: "repo", Path: "/path/to/repo"}}
+
+	tests := []struct {
+		name     string
+		checkers map[string]core.CheckerConfig
+		expected int
+	}{
+		{"not configured", map[string]core.CheckerConfig{}, 1},
+		{"enabled in config", map[string]core.CheckerConfig{"opt-in": {Enabled: true}}, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := &optInMockConfig{checkers: tt.checkers}
+			engine := NewEngine(checkerRegistry, &mockAnalyzerRegistry{}, config, &mockLogger{})
+
+			result, err := engine.ExecuteHealthCheck(context.Background(), repos)
+			if err != nil {
+				t.Fatalf("ExecuteHealthCheck failed: %v", err)
+			}
+			if got := len(result.RepositoryResults[0].CheckResults); got != tt.expected {
+				t.Errorf("Expected %d checker results, got %d", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestEngine_DefaultCheckerDisabledInConfig(t *testing.T) {
+	checkerRegistry := &mockCheckerRegistry{}
+	checkerRegistry.Register(&mockChecker{
+		id:     "default-on",
+		config: core.CheckerConfig{Enabled: true},
+		result: core.CheckResult{ID: "default-on", Status: core.StatusHealthy, Score: 100, MaxScore: 100},
+	})
+
+	config := &optInMockConfig{checkers: map[string]core.CheckerConfig{"default-on": {Disabled: true}}}
+	engine := NewEngine(checkerRegistry, &mockAnalyzerRegistry{}, config, &mockLogger{})
+	repo := core.Repository{Name: "repo", Path: t.TempDir()}
+
+	plan := engine.Plan(repo)
+	if len(plan.Checkers) != 0 || len(plan.Excluded) != 1 || plan.Excluded[0].Reason != ReasonConfigDisabled {
+		t.Errorf("Expected default-on to be excluded as %q, got %+v", ReasonConfigDisabled, plan.Excluded)
+	}
+
+	// Naming the checker still runs it
+	engine.SelectCheckers(nil, []string{"default-on"})
+	if plan := engine.Plan(repo); len(plan.Checkers) != 1 {
+		t.Errorf("Expected default-on to run when named, got %d planned checkers", len(plan.Checkers))
+	}
+}
+
+// overrideMockConfig enables opt-in checkers only for the repositories listed
+type overrideMockConfig struct {
+	optInMockConfig
+	overrides map[string]map[string]core.CheckerConfig
+}
+
+func (m *overrideMockConfig) ForRepository(repo core.Repository) core.Config {
+	if checkers, ok := m.overrides[repo.Name]; ok {
+		return &optInMockConfig{checkers: checkers}
+	}
+	return m
+}
+
+func TestEngine_AppliesOverridesPerRepository(t *testing.T) {
+	checkerRegistry := &mockCheckerRegistry{}
+	checkerRegistry.Register(&mockChecker{
+		id:     "opt-in",
+		config: core.CheckerConfig{Enabled: false},
+		result: core.CheckResult{ID: "opt-in", Status: core.StatusHealthy, Score: 100, MaxScore: 100},
+	})
+	config := &overrideMockConfig{overrides: map[string]map[string]core.CheckerConfig{
+		"payments": {"opt-in": {Enabled: true}},
+	}}
+
+	engine := NewEngine(checkerRegistry, &mockAnalyzerRegistry{}, config, &mockLogger{})
+	result, err := engine.ExecuteHealthCheck(context.Background(), []core.Repository{
+		{Name: "billing", Path: "/path/to/billing"},
+		{Name: "payments", Path: "/path/to/payments"},
+	})
+	if err != nil {
+		t.Fatalf("ExecuteHealthCheck failed: %v", err)
+	}
+
+	for _, repoResult := range result.RepositoryResults {
+		expected := 0
+		if repoResult.Repository.Name == "payments" {
+			expected = 1
+		}
+		if got := len(repoResult.CheckResults); got != expected {
+			t.Errorf("%s: expected %d checker results, got %d", repoResult.Repository.Name, expected, got)
+		}
+	}
+}
+
+type recordingProgressReporter struct {
+	events []Progress
+}
+
+func (r *recordingProgressReporter) ReportProgress(_ context.Context, progress Progress) {
+	r.events = append(r.events, progress)
+}
+
+func (r *recordingProgressReporter) ReportError(_ context.Context, _ error) {}
+
+func TestEngine_ReportsProgressPerRepository(t *testing.T) {
+	checkerRegistry := &mockCheckerRegistry{}
+	checkerRegistry.Register(&mockChecker{
+		id:       "test-checker",
+		name:     "Test Checker",
+		category: "test",
+		result:   core.CheckResult{ID: "test-checker", Status: core.StatusHealthy, Score: 100, MaxScore: 100},
+	})
+
+	engine := NewEngine(checkerRegistry, &mockAnalyzerRegistry{}, &mockConfig{}, &mockLogger{})
+	reporter := &recordingProgressReporter{}
+	engine.SetProgressReporter(reporter)
+
+	repos := []core.Repository{{Name: "a", Path: "/a"}, {Name: "b", Path: "/b"}}
+	if _, err := engine.ExecuteHealthCheck(context.Background(), repos); err != nil {
+		t.Fatalf("ExecuteHealthCheck failed: %v", err)
+	}
+
+	if len(reporter.events) != 2 {
+		t.Fatalf("Expected one progress event per repository, got %d", len(reporter.events))
+	}
+	last := reporter.events[1]
+	if last.CompletedRepos != 2 || last.TotalRepos != 2 || last.PercentComplete != 100 {
+		t.Errorf("Unexpected final progress %+v", last)
+	}
+	for _, event := range reporter.events {
+		if event.Status != ProgressRepositoryCompleted || event.Result == nil || event.Result.Repository.Name != event.CurrentStep {
+			t.Errorf("Expected completed event carrying its repository result, got %+v", event)
+		}
+	}
+}
+
+func TestEngine_SelectCheckers(t *testing.T) {
+	checkerRegistry := &mockCheckerRegistry{}
+	for _, checker := range []*mockChecker{
+		{id: "git-status", category: "git", config: core.CheckerConfig{Enabled: true}},
+		{id: "git-hooks", category: "git", config: core.CheckerConfig{Enabled: true}},
+		{id: "license-check", category: "compliance", config: core.CheckerConfig{Enabled: true}},
+		{id: "opt-in", category: "quality", config: core.CheckerConfig{Enabled: false}},
+	} {
+		checker.result = core.CheckResult{ID: checker.id, Status: core.StatusHealthy, Score: 100, MaxScore: 100}
+		checkerRegistry.Register(checker)
+	}
+
+	repos := []core.Repository{{Name: "repo", Path: "/path/to/repo"}}
+
+	tests := []struct {
+		name       string
+		categories []string
+		ids        []string
+		expected   []string
+	}{
+		{"no selection", nil, nil, []string{"git-hooks", "git-status", "license-check"}},
+		{"by ID", nil, []string{"git-status"}, []string{"git-status"}},
+		{"category and ID union", []string{"git"}, []string{"license-check"}, []string{"git-hooks", "git-status", "license-check"}},
+		{"opt-in by ID", nil, []string{"opt-in"}, []string{"opt-in"}},
+		{"opt-in not enabled by category", []string{"quality"}, nil, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			engine := NewEngine(checkerRegistry, &mockAnalyzerRegistry{}, &optInMockConfig{}, &mockLogger{})
+			engine.SelectCheckers(tt.categories, tt.ids)
+
+			result, err := engine.ExecuteHealthCheck(context.Background(), repos)
+			if err != nil {
+				t.Fatalf("ExecuteHealthCheck failed: %v", err)
+			}
+
+			var got []string
+			for _, checkResult := range result.RepositoryResults[0].CheckResults {
+				got = append(got, checkResult.ID)
+			}
+			sort.Strings(got)
+			if strings.Join(got, ",") != strings.Join(tt.expected, ",") {
+				t.Errorf("Expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+type recordingTracer struct {
+	mu    sync.Mutex
+	spans []*recordingSpan
+}
+
+type recordingSpan struct {
+	name  string
+	attrs map[string]interface{}
+	ended bool
+}
+
+func (r *recordingTracer) Start(ctx context.Context, name string, attrs ...tracing.Attribute) (context.Context, tracing.Span) {
+	span := &recordingSpan{name: name, attrs: make(map[string]interface{})}
+	span.SetAttributes(attrs...)
+	r.mu.Lock()
+	r.spans = append(r.spans, span)
+	r.mu.Unlock()
+	return ctx, span
+}
+
+func (s *recordingSpan) SetAttributes(attrs ...tracing.Attribute) {
+	for _, attr := range attrs {
+		s.attrs[attr.Key] = attr.Value
+	}
+}
+
+func (s *recordingSpan) RecordError(err error) {}
+
+func (s *recordingSpan) End() { s.ended = true }
+
+func TestEngine_TracesWorkflowRepositoriesAndCheckers(t *testing.T) {
+	checkerRegistry := &mockCheckerRegistry{}
+	checkerRegistry.Register(&mockChecker{
+		id:       "test-checker",
+		name:     "Test Checker",
+		category: "test",
+		result:   core.CheckResult{ID: "test-checker", Status: core.StatusWarning, Score: 50, MaxScore: 100},
+	})
+
+	engine := NewEngine(checkerRegistry, &mockAnalyzerRegistry{}, &mockConfig{}, &mockLogger{})
+	tracer := &recordingTracer{}
+	engine.SetTracer(tracer)
+
+	repos := []core.Repository{{Name: "a", Path: "/a"}, {Name: "b", Path: "/b"}}
+	if _, err := engine.ExecuteHealthCheck(context.Background(), repos); err != nil {
+		t.Fatalf("ExecuteHealthCheck failed: %v", err)
+	}
+
+	counts := make(map[string]int)
+	for _, span := range tracer.spans {
+		counts[span.name]++
+		if !span.ended {
+			t.Errorf("Span %s was not ended", span.name)
+		}
+		if span.name == "health.checker" {
+			if span.attrs["checker.id"] != "test-checker" || span.attrs["checker.status"] != string(core.StatusWarning) {
+				t.Errorf("Unexpected checker span attributes: %v", span.attrs)
+			}
+			if _, ok := span.attrs["checker.duration_ms"]; !ok {
+				t.Errorf("Expected checker duration attribute")
+			}
+		}
+	}
+	if counts["health.workflow"] != 1 || counts["health.repository"] != 2 || counts["health.checker"] != 2 {
+		t.Errorf("Unexpected spans: %v", counts)
+	}
+}
+
+func TestEngine_ReportsCheckerErrorsSeparately(t *testing.T) {
+	checkerRegistry := &mockCheckerRegistry{}
+	checkerRegistry.Register(&mockChecker{
+		id:       "healthy-checker",
+		name:     "Healthy Checker",
+		category: "test",
+		result:   core.CheckResult{ID: "healthy-checker", Category: "test", Status: core.StatusHealthy, Score: 100, MaxScore: 100},
+	})
+	checkerRegistry.Register(&mockChecker{
+		id:       "broken-checker",
+		name:     "Broken Checker",
+		category: "test",
+		err:      fmt.Errorf("mvn: executable file not found in $PATH"),
+	})
+	config := &mockConfig{engineConfig: core.EngineConfig{
+		Scoring: core.ScoringConfig{Model: core.ScoringModelBinary},
+	}}
+
+	engine := NewEngine(checkerRegistry, &mockAnalyzerRegistry{}, config, &mockLogger{})
+	result, err := engine.ExecuteHealthCheck(context.Background(), []core.Repository{{Name: "test-repo", Path: "/path/to/repo"}})
+	if err != nil {
+		t.Fatalf("ExecuteHealthCheck failed: %v", err)
+	}
+
+	repoResult := result.RepositoryResults[0]
+	if repoResult.Status != core.StatusErrored {
+		t.Errorf("Expected repository status errored, got %s", repoResult.Status)
+	}
+	for _, checkResult := range repoResult.CheckResults {
+		if checkResult.ID != "broken-checker" {
+			continue
+		}
+		if checkResult.Status != core.StatusErrored || len(checkResult.Issues) != 0 {
+			t.Errorf("Expected an errored result without issues, got %+v", checkResult)
+		}
+		if len(checkResult.Errors) != 1 || !strings.Contains(checkResult.Errors[0].Message, "mvn") {
+			t.Errorf("Expected the checker error message, got %+v", checkResult.Errors)
+		}
+		if checkResult.MaxScore != 0 {
+			t.Errorf("Expected errored checker to be left out of scoring, got %d/%d", checkResult.Score, checkResult.MaxScore)
+		}
+	}
+	if repoResult.Score != 100 {
+		t.Errorf("Expected score from the checker that ran only, got %d", repoResult.Score)
+	}
+	if result.Summary.ErroredChecks != 1 || result.Summary.FailedRepos != 1 {
+		t.Errorf("Expected 1 errored check and 1 failed repo, got %+v", result.Summary)
+	}
+}
+
+func TestEngine_ErroredCategoryLeftOutOfScore(t *testing.T) {
+	checkerRegistry := &mockCheckerRegistry{}
+	checkerRegistry.Register(&mockChecker{
+		id:       "healthy-checker",
+		name:     "Healthy Checker",
+		category: "git",
+		result:   core.CheckResult{ID: "healthy-checker", Category: "git", Status: core.StatusHealthy, Score: 100, MaxScore: 100},
+	})
+	checkerRegistry.Register(&mockChecker{
+		id:       "broken-checker",
+		name:     "Broken Checker",
+		category: "security",
+		err:      fmt.Errorf("trivy: executable file not found in $PATH"),
+	})
+
+	engine := NewEngine(checkerRegistry, &mockAnalyzerRegistry{}, &mockConfig{}, &mockLogger{})
+	result, err := engine.ExecuteHealthCheck(context.Background(), []core.Repository{{Name: "test-repo", Path: "/path/to/repo"}})
+	if err != nil {
+		t.Fatalf("ExecuteHealthCheck failed: %v", err)
+	}
+
+	repoResult := result.RepositoryResults[0]
+	if repoResult.Score != 100 {
+		t.Errorf("Expected the errored category to be left out of the score, got %d", repoResult.Score)
+	}
+	for _, score := range repoResult.CategoryScores {
+		if score.Category == "security" {
+			t.Errorf("Expected no category score for security, got %+v", score)
+		}
+	}
+}
+
+func TestEngine_SkippedCheckLeftOutOfScore(t *testing.T) {
+	checkerRegistry := &mockCheckerRegistry{}
+	checkerRegistry.Register(&mockChecker{
+		id:       "outdated",
+		name:     "Outdated",
+		category: "dependencies",
+		result: core.CheckResult{ID: "outdated", Category: "dependencies", Status: core.StatusWarning, Score: 0, MaxScore: 100,
+			Issues: []core.Issue{{Type: "outdated_dependency", Severity: core.SeverityHigh, Message: "lodash is outdated"}}},
+	})
+	checkerRegistry.Register(&mockChecker{
+		id:       "vulnerabilities",
+		name:     "Vulnerabilities",
+		category: "dependencies",
+		result: core.CheckResult{ID: "vulnerabilities", Category: "dependencies", Status: core.StatusHealthy,
+			Metadata: map[string]string{core.MetadataSkipped: "true"}},
+	})
+	config := &mockConfig{engineConfig: core.EngineConfig{
+		Scoring: core.ScoringConfig{Model: core.ScoringModelBinary},
+	}}
+
+	engine := NewEngine(checkerRegistry, &mockAnalyzerRegistry{}, config, &mockLogger{})
+	result, err := engine.ExecuteHealthCheck(context.Background(), []core.Repository{{Name: "test-repo", Path: "/path/to/repo"}})
+	if err != nil {
+		t.Fatalf("ExecuteHealthCheck failed: %v", err)
+	}
+
+	repoResult := result.RepositoryResults[0]
+	if len(repoResult.CategoryScores) != 1 || repoResult.CategoryScores[0].Score != 0 {
+		t.Errorf("Expected the skipped check not to raise the category score, got %+v", repoResult.CategoryScores)
+	}
+	for _, checkResult := range repoResult.CheckResults {
+		if checkResult.ID == "vulnerabilities" && checkResult.MaxScore != 0 {
+			t.Errorf("Expected the skipped check not to be scored, got %d/%d", checkResult.Score, checkResult.MaxScore)
+		}
+	}
+}
+
+// concurrencyChecker records how many of its checks run at the same time
+type concurrencyChecker struct {
+	mockChecker
+	running *int32
+	peak    *int32
+}
+
+func (c *concurrencyChecker) Check(ctx context.Context, repoCtx core.RepositoryContext) (core.CheckResult, error) {
+	current := atomic.AddInt32(c.running, 1)
+	defer atomic.AddInt32(c.running, -1)
+	for {
+		peak := atomic.LoadInt32(c.peak)
+		if current <= peak || atomic.CompareAndSwapInt32(c.peak, peak, current) {
+			break
+		}
+	}
+	time.Sleep(20 * time.Millisecond)
+	return core.CheckResult{ID: c.id, Category: c.category, Status: core.StatusHealthy, Score: 100, MaxScore: 100}, nil
+}
+
+func TestEngine_LimitsExternalToolCheckers(t *testing.T) {
+	repos := make([]core.Repository, 4)
+	for i := range repos {
+		repos[i] = core.Repository{Name: fmt.Sprintf("repo-%d", i), Path: "/path/to/repo"}
+	}
+
+	tests := []struct {
+		category string
+		limited  bool
+	}{
+		{"dependencies", true},
+		{"security", true},
+		{"docs", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.category, func(t *testing.T) {
+			var running, peak int32
+			checkerRegistry := &mockCheckerRegistry{}
+			checkerRegistry.Register(&concurrencyChecker{
+				mockChecker: mockChecker{id: tt.category + "-checker", name: "Checker", category: tt.category},
+				running:     &running,
+				peak:        &peak,
+			})
+			config := &mockConfig{engineConfig: core.EngineConfig{MaxConcurrency: 4, MaxExternalProcesses: 1}}
+
+			engine := NewEngine(checkerRegistry, &mockAnalyzerRegistry{}, config, &mockLogger{})
+			if _, err := engine.ExecuteHealthCheck(context.Background(), repos); err != nil {
+				t.Fatalf("ExecuteHealthCheck failed: %v", err)
+			}
+
+			if tt.limited && peak != 1 {
+				t.Errorf("Expected at most 1 %s checker at a time, got %d", tt.category, peak)
+			}
+			if !tt.limited && peak < 2 {
+				t.Errorf("Expected %s checkers to run in parallel, peak was %d", tt.category, peak)
+			}
+		})
+	}
+}
+
+func TestEngine_DetectsRepositoryLanguage(t *testing.T) {
+	repoPath := t.TempDir()
+	for _, name := range []string{"main.go", "util.go", "tools/gen.py"} {
+		path := filepath.Join(repoPath, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte("x"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	engine := NewEngine(&mockCheckerRegistry{}, &mockAnalyzerRegistry{}, &mockConfig{}, &mockLogger{})
+	result, err := engine.ExecuteHealthCheck(context.Background(), []core.Repository{
+		{Name: "untagged", Path: repoPath},
+		{Name: "tagged", Path: repoPath, Language: "python"},
+	})
+	if err != nil {
+		t.Fatalf("ExecuteHealthCheck failed: %v", err)
+	}
+
+	languages := map[string]string{}
+	for _, repoResult := range result.RepositoryResults {
+		languages[repoResult.Repository.Name] = repoResult.Repository.Language
+		if repoResult.Languages["go"] != 2 || repoResult.Languages["python"] != 1 {
+			t.Errorf("Expected the language breakdown for %s, got %v", repoResult.Repository.Name, repoResult.Languages)
+		}
+	}
+	if languages["untagged"] != "go" {
+		t.Errorf("Expected detected language go, got %q", languages["untagged"])
+	}
+	if languages["tagged"] != "python" {
+		t.Errorf("Expected the configured language to be kept, got %q", languages["tagged"])
+	}
+}
+
+// stubAnalyzer returns a fixed analysis for its language
+type stubAnalyzer struct {
+	language string
+	result   core.AnalysisResult
+	index    *core.FileIndex // Index passed to the last Analyze call
+}
+
+func (a *stubAnalyzer) Name() string                         { return a.language + " analyzer" }
+func (a *stubAnalyzer) Language() string                     { return a.language }
+func (a *stubAnalyzer) SupportedExtensions() []string        { return nil }
+func (a *stubAnalyzer) CanAnalyze(repo core.Repository) bool { return true }
+func (a *stubAnalyzer) Analyze(ctx context.Context, repoPath string, config core.AnalyzerConfig) (*core.AnalysisResult, error) {
+	a.index = config.Index
+	result := a.result
+	return &result, nil
+}
+
+func TestEngine_AggregatesAnalysisAcrossLanguages(t *testing.T) {
+	repoPath := t.TempDir()
+	for _, name := range []string{"main.go", "util.go", "tools/gen.py"} {
+		path := filepath.Join(repoPath, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte("x"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	analyzers := &mockAnalyzerRegistry{}
+	goAnalyzer := &stubAnalyzer{language: "go", result: core.AnalysisResult{
+		Files: map[string]*core.FileAnalysis{"main.go": {Lines: 40}, "util.go": {Lines: 20}},
+		Functions: []core.FunctionInfo{
+			{Name: "main", Complexity: 1}, {Name: "parse", Complexity: 5}, {Name: "run", Complexity: 3},
+		},
+	}}
+	pythonAnalyzer := &stubAnalyzer{language: "python", result: core.AnalysisResult{
+		Files:     map[string]*core.FileAnalysis{"tools/gen.py": {Lines: 10}},
+		Functions: []core.FunctionInfo{{Name: "generate", Complexity: 7}},
+	}}
+	analyzers.Register(goAnalyzer)
+	analyzers.Register(pythonAnalyzer)
+	analyzers.Register(&stubAnalyzer{language: "java"})
+
+	engine := NewEngine(&mockCheckerRegistry{}, analyzers, &mockConfig{}, &mockLogger{})
+	result, err := engine.ExecuteHealthCheck(context.Background(), []core.Repository{{Name: "mixed", Path: repoPath}})
+	if err != nil {
+		t.Fatalf("ExecuteHealthCheck failed: %v", err)
+	}
+
+	repoResult := result.RepositoryResults[0]
+	if repoResult.AnalysisResult == nil || repoResult.AnalysisResult.Language != "go" {
+		t.Fatalf("Expected the primary analysis to stay the go one, got %+v", repoResult.AnalysisResult)
+	}
+	aggregate := repoResult.Aggregate
+	if aggregate == nil {
+		t.Fatal("Expected an aggregate")
+	}
+	if aggregate.TotalFiles != 3 || aggregate.TotalLines != 70 || aggregate.TotalFunctions != 4 ||
+		aggregate.AverageComplexity != 4 || aggregate.MaxComplexity != 7 {
+		t.Errorf("Unexpected totals: %+v", aggregate)
+	}
+	if len(aggregate.Languages) != 2 || aggregate.Languages[0].Language != "go" || aggregate.Languages[1].Language != "python" {
+		t.Fatalf("Expected go and python metrics only, got %+v", aggregate.Languages)
+	}
+	if aggregate.Languages[0].AverageComplexity != 3 || aggregate.Languages[1].Lines != 10 {
+		t.Errorf("Unexpected per-language metrics: %+v", aggregate.Languages)
+	}
+
+	// Both analyses share one file index of the repository, which the result does not keep
+	if goAnalyzer.index == nil || goAnalyzer.index != pythonAnalyzer.index || goAnalyzer.index.Root() != repoPath {
+		t.Errorf("Expected one shared index of %s, got %v and %v", repoPath, goAnalyzer.index, pythonAnalyzer.index)
+	}
+	if repoResult.Repository.Files != nil {
+		t.Error("Expected the result not to keep the file index")
+	}
+}
+
+func TestEngine_UsesProvidedRepositoryFiles(t *testing.T) {
+	repoPath := t.TempDir()
+	for _, name := range []string{"main.go", "tools/gen.py", "tools/lint.py", "tools/check.py"} {
+		path := filepath.Join(repoPath, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte("x"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	analyzers := &mockAnalyzerRegistry{}
+	goAnalyzer := &stubAnalyzer{language: "go"}
+	analyzers.Register(goAnalyzer)
+	analyzers.Register(&stubAnalyzer{language: "python"})
+
+	// The deleted file of the change is left out; the unlisted Python files are not seen
+	engine := NewEngine(&mockCheckerRegistry{}, analyzers, &mockConfig{}, &mockLogger{})
+	engine.SetRepositoryFiles("listed", []string{"main.go", "tools/gen.py", "removed.go"})
+	result, err := engine.ExecuteHealthCheck(context.Background(), []core.Repository{{Name: "listed", Path: repoPath}})
+	if err != nil {
+		t.Fatalf("ExecuteHealthCheck failed: %v", err)
+	}
+
+	repoResult := result.RepositoryResults[0]
+	if repoResult.Repository.Language != "go" || repoResult.Languages["go"] != 1 || repoResult.Languages["python"] != 1 {
+		t.Errorf("Expected languages of the listed files, got %s %v", repoResult.Repository.Language, repoResult.Languages)
+	}
+	if goAnalyzer.index == nil {
+		t.Fatal("Expected the analyzer to get the file index")
+	}
+	files, _, err := goAnalyzer.index.Files()
+	if err != nil {
+		t.Fatalf("Files failed: %v", err)
+	}
+	if len(files) != 2 || files[0].Path != filepath.Join(repoPath, "main.go") || files[1].Path != filepath.Join(repoPath, "tools", "gen.py") {
+		t.Errorf("Expected only the listed files that exist, got %+v", files)
+	}
+}
+
+// panicChecker panics for one repository and succeeds for the others
+type panicChecker struct {
+	mockChecker
+	panicFor string
+}
+
+func (c *panicChecker) Check(ctx context.Context, repoCtx core.RepositoryContext) (core.CheckResult, error) {
+	if repoCtx.Repository.Name == c.panicFor {
+		panic("boom")
+	}
+	return core.CheckResult{ID: c.id, Category: c.category, Status: core.StatusHealthy, Score: 100, MaxScore: 100}, nil
+}
+
+func TestEngine_RunsCheckersConcurrentlyInOrder(t *testing.T) {
+	var running, peak int32
+	checkerRegistry := &mockCheckerRegistry{}
+	for _, id := range []string{"c-checker", "a-checker", "b-checker"} {
+		checkerRegistry.Register(&concurrencyChecker{
+			mockChecker: mockChecker{id: id, name: id, category: "docs"},
+			running:     &running,
+			peak:        &peak,
+		})
+	}
+	config := &mockConfig{engineConfig: core.EngineConfig{MaxConcurrency: 1}}
+
+	engine := NewEngine(checkerRegistry, &mockAnalyzerRegistry{}, config, &mockLogger{})
+	result, err := engine.ExecuteHealthCheck(context.Background(), []core.Repository{{Name: "repo", Path: "/path/to/repo"}})
+	if err != nil {
+		t.Fatalf("ExecuteHealthCheck failed: %v", err)
+	}
+
+	var ids []string
+	for _, checkResult := range result.RepositoryResults[0].CheckResults {
+		ids = append(ids, checkResult.ID)
+	}
+	if strings.Join(ids, ",") != "a-checker,b-checker,c-checker" {
+		t.Errorf("Expected results in checker ID order, got %v", ids)
+	}
+	if peak != 1 {
+		t.Errorf("Expected max_concurrency 1 to run one checker at a time, peak was %d", peak)
+	}
+}
+
+func TestEngine_IsolatesRepositoryFailures(t *testing.T) {
+	checkerRegistry := &mockCheckerRegistry{}
+	checkerRegistry.Register(&panicChecker{
+		mockChecker: mockChecker{id: "flaky-checker", name: "Flaky Checker", category: "test"},
+		panicFor:    "bad",
+	})
+
+	engine := NewEngine(checkerRegistry, &mockAnalyzerRegistry{}, &mockConfig{}, &mockLogger{})
+	repos := []core.Repository{{Name: "good-1", Path: "/a"}, {Name: "bad", Path: "/b"}, {Name: "good-2", Path: "/c"}}
+	result, err := engine.ExecuteHealthCheck(context.Background(), repos)
+	if err != nil {
+		t.Fatalf("ExecuteHealthCheck failed: %v", err)
+	}
+
+	for i, repoResult := range result.RepositoryResults {
+		if repoResult.Repository.Name != repos[i].Name {
+			t.Errorf("Expected result %d for %s, got %s", i, repos[i].Name, repoResult.Repository.Name)
+		}
+		expected := core.StatusHealthy
+		if repoResult.Repository.Name == "bad" {
+			expected = core.StatusErrored
+		}
+		if repoResult.Status != expected {
+			t.Errorf("Expected %s to be %s, got %s", repoResult.Repository.Name, expected, repoResult.Status)
+		}
+	}
+}
+
+func BenchmarkEngine_ExecuteHealthCheck(b *testing.B) {
+	checkerRegistry := &mockCheckerRegistry{}
+	for i := 0; i < 5; i++ {
+		checkerRegistry.Register(&concurrencyChecker{
+			mockChecker: mockChecker{id: fmt.Sprintf("checker-%d", i), name: "Checker", category: "docs"},
+			running:     new(int32),
+			peak:        new(int32),
+		})
+	}
+	repos := make([]core.Repository, 20)
+	for i := range repos {
+		repos[i] = core.Repository{Name: fmt.Sprintf("repo-%d", i), Path: "/path/to/repo"}
+	}
+
+	for _, concurrency := range []int{1, 4, 16} {
+		b.Run(fmt.Sprintf("max_concurrency=%d", concurrency), func(b *testing.B) {
+			config := &mockConfig{engineConfig: core.EngineConfig{MaxConcurrency: concurrency}}
+			engine := NewEngine(checkerRegistry, &mockAnalyzerRegistry{}, config, &mockLogger{})
+			for i := 0; i < b.N; i++ {
+				if _, err := engine.ExecuteHealthCheck(context.Background(), repos); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func TestEngine_ChecksSubprojectsSeparately(t *testing.T) {
+	repoPath := t.TempDir()
+	for _, name := range []string{"services/api/go.mod", "web/package.json", "README.md"} {
+		path := filepath.Join(repoPath, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte("x"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	checkerRegistry := &mockCheckerRegistry{}
+	checkerRegistry.Register(&mockChecker{
+		id:       "docs-checker",
+		name:     "Docs Checker",
+		category: "docs",
+		result: core.CheckResult{ID: "docs-checker", Category: "docs", Status: core.StatusWarning, Score: 60, MaxScore: 100,
+			Issues: []core.Issue{{Type: "missing_docs", Message: "missing docs", Location: &core.Location{File: "README.md", Line: 1}}}},
+	})
+	checkerRegistry.Register(&mockChecker{
+		id:       "git-checker",
+		name:     "Git Checker",
+		category: "git",
+		result:   core.CheckResult{ID: "git-checker", Category: "git", Status: core.StatusHealthy, Score: 100, MaxScore: 100},
+	})
+	config := &mockConfig{engineConfig: core.EngineConfig{Subprojects: core.SubprojectConfig{Enabled: true}}}
+
+	engine := NewEngine(checkerRegistry, &mockAnalyzerRegistry{}, config, &mockLogger{})
+	result, err := engine.ExecuteHealthCheck(context.Background(), []core.Repository{{Name: "mono", Path: repoPath}})
+	if err != nil {
+		t.Fatalf("ExecuteHealthCheck failed: %v", err)
+	}
+
+	repoResult := result.RepositoryResults[0]
+	if len(repoResult.Subprojects) != 2 {
+		t.Fatalf("Expected 2 sub-projects, got %+v", repoResult.Subprojects)
+	}
+	if repoResult.Subprojects[0].Path != "services/api" || repoResult.Subprojects[1].Path != "web" {
+		t.Errorf("Unexpected sub-projects: %+v", repoResult.Subprojects)
+	}
+	if repoResult.Subprojects[0].Score != 60 || repoResult.Subprojects[0].Status != core.StatusWarning {
+		t.Errorf("Expected sub-project score from its own checks, got %+v", repoResult.Subprojects[0])
+	}
+
+	// One git check at the root plus one docs check per sub-project
+	var files []string
+	gitChecks := 0
+	for _, checkResult := range repoResult.CheckResults {
+		if checkResult.ID == "git-checker" {
+			gitChecks++
+			continue
+		}
+		for _, issue := range checkResult.Issues {
+			files = append(files, issue.Location.File)
+		}
+	}
+	if gitChecks != 1 {
+		t.Errorf("Expected the git checker to run once, got %d", gitChecks)
+	}
+	sort.Strings(files)
+	if strings.Join(files, ",") != "services/api/README.md,web/README.md" {
+		t.Errorf("Expected issue paths relative to the repository, got %v", files)
+	}
+	if repoResult.Score != 80 {
+		t.Errorf("Expected the rolled-up score 80, got %d", repoResult.Score)
+	}
+}
+
+func TestEngine_RunsHooks(t *testing.T) {
+	logPath := filepath.Join(t.TempDir(), "hooks.log")
+	appendLog := func(line string) []string {
+		return []string{"-c", fmt.Sprintf(`echo "%s" >> %q`, line, logPath)}
+	}
+
+	checkerRegistry := &mockCheckerRegistry{}
+	checkerRegistry.Register(&mockChecker{id: "broken-checker", name: "Broken", category: "docs", err: fmt.Errorf("boom")})
+
+	engine := NewEngine(checkerRegistry, &mockAnalyzerRegistry{}, &mockConfig{}, &mockLogger{})
+	engine.SetHooks(hooks.NewRunner([]healthconfig.HookConfig{
+		{Name: "gate", Event: "pre_check", Command: "sh", Args: []string{"-c", `test "$REPOS_REPOSITORY_NAME" != blocked`}, FailOnError: true},
+		{Name: "start", Event: "pre_check", Command: "sh", Args: appendLog("pre $REPOS_REPOSITORY_NAME")},
+		{Name: "alert", Event: "on_error", Command: "sh", Args: appendLog("error $REPOS_CHECKER_ID: $REPOS_CHECKER_ERROR")},
+		{Name: "report", Event: "post_check", Command: "sh", Args: appendLog("post $REPOS_STATUS")},
+		{Name: "flaky", Event: "post_check", Command: "false"},
+	}, &mockLogger{}))
+
+	repos := []core.Repository{{Name: "app", Path: t.TempDir()}, {Name: "blocked", Path: t.TempDir()}}
+	result, err := engine.ExecuteHealthCheck(context.Background(), repos)
+	if err != nil {
+		t.Fatalf("ExecuteHealthCheck failed: %v", err)
+	}
+
+	data, err := os.ReadFile(logPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := "pre app\nerror broken-checker: boom\npost errored\n"
+	if string(data) != expected {
+		t.Errorf("Expected hook log %q, got %q", expected, string(data))
+	}
+
+	app, blocked := result.RepositoryResults[0], result.RepositoryResults[1]
+	if app.Error != "" {
+		t.Errorf("Expected a failing hook without fail_on_error to be ignored, got %q", app.Error)
+	}
+	if blocked.Status != core.StatusCritical || !strings.Contains(blocked.Error, "gate") || len(blocked.CheckResults) != 0 {
+		t.Errorf("Expected the failing pre_check hook to skip the repository, got %s %q", blocked.Status, blocked.Error)
+	}
+}
+
+func TestSummarize_RanksRepositories(t *testing.T) {
+	results := []core.RepositoryResult{
+		{Repository: core.Repository{Name: "api"}, Status: core.StatusWarning, Score: 70},
+		{Repository: core.Repository{Name: "web"}, Status: core.StatusHealthy, Score: 95},
+		{Repository: core.Repository{Name: "legacy"}, Status: core.StatusCritical, Score: 70},
+		{Repository: core.Repository{Name: "tools"}, Status: core.StatusErrored, Score: 10},
+	}
+
+	summary := Summarize(results)
+
+	var names []string
+	for _, rank := range summary.Ranking {
+		names = append(names, fmt.Sprintf("%d:%s", rank.Rank, rank.Name))
+	}
+	if got := strings.Join(names, " "); got != "1:web 2:api 3:legacy 4:tools" {
+		t.Errorf("Expected ties to be broken by status, got %s", got)
+	}
+	if summary.StatusCounts[core.StatusErrored] != 1 || summary.StatusCounts[core.StatusHealthy] != 1 {
+		t.Errorf("Unexpected status counts: %v", summary.StatusCounts)
+	}
+	if _, ok := summary.StatusCounts[core.StatusWarning]; !ok {
+		t.Error("Expected every status bucket to be present")
+	}
+
+	worst := Worst(summary.Ranking, 2)
+	if len(worst) != 2 || worst[0].Name != "tools" || worst[1].Name != "legacy" {
+		t.Errorf("Expected the two lowest repositories, lowest first, got %+v", worst)
+	}
+	if len(Worst(summary.Ranking, 10)) != 4 || len(Worst(summary.Ranking, 0)) != 0 {
+		t.Error("Expected Worst to be bounded by the ranking")
+	}
+}
+
+func TestCheckerTimings(t *testing.T) {
+	results := []core.RepositoryResult{
+		{CheckResults: []core.CheckResult{
+			{ID: "git-status", Duration: 20 * time.Millisecond, SubprocessDuration: 15 * time.Millisecond},
+			{ID: "dependencies-outdated", Duration: 3 * time.Second, SubprocessDuration: 2 * time.Second},
+		}},
+		{CheckResults: []core.CheckResult{
+			{ID: "git-status", Duration: 40 * time.Millisecond},
+			{ID: "readme-check", Duration: 20 * time.Millisecond},
+		}},
+	}
+
+	timings := CheckerTimings(results)
+	if len(timings) != 3 {
+		t.Fatalf("Expected one timing per checker, got %+v", timings)
+	}
+	expected := []core.CheckerTiming{
+		{ID: "dependencies-outdated", Runs: 1, Total: 3 * time.Second, Max: 3 * time.Second, Subprocess: 2 * time.Second},
+		{ID: "git-status", Runs: 2, Total: 60 * time.Millisecond, Max: 40 * time.Millisecond, Subprocess: 15 * time.Millisecond},
+		{ID: "readme-check", Runs: 1, Total: 20 * time.Millisecond, Max: 20 * time.Millisecond},
+	}
+	for i, want := range expected {
+		if timings[i] != want {
+			t.Errorf("Timing %d: expected %+v, got %+v", i, want, timings[i])
+		}
+	}
+}
+
+func TestEngine_SummaryListsWorstRepositories(t *testing.T) {
+	checkerRegistry := &mockCheckerRegistry{}
+	checkerRegistry.Register(&mockChecker{
+		id: "test-checker", name: "Test Checker", category: "test",
+		result: core.CheckResult{ID: "test-checker", Category: "test", Status: core.StatusHealthy, Score: 150, MaxScore: 100},
+	})
+
+	engine := NewEngine(checkerRegistry, &mockAnalyzerRegistry{}, &mockConfig{}, &mockLogger{})
+	engine.SetTop(1)
+
+	result, err := engine.ExecuteHealthCheck(context.Background(), []core.Repository{{Name: "a"}, {Name: "b"}})
+	if err != nil {
+		t.Fatalf("ExecuteHealthCheck failed: %v", err)
+	}
+	if len(result.Summary.Ranking) != 2 || len(result.Summary.Worst) != 1 || result.Summary.Worst[0].Name != "b" {
+		t.Errorf("Expected a ranking of two and one worst repository, got %+v", result.Summary)
+	}
+	for _, repoResult := range result.RepositoryResults {
+		if repoResult.Score != 100 || repoResult.MaxScore != 100 {
+			t.Errorf("Expected scores normalized to 0-100, got %d/%d", repoResult.Score, repoResult.MaxScore)
+		}
+	}
+}
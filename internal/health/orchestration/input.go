@@ -0,0 +1,98 @@
+package orchestration
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/codcod/repos/internal/core"
+)
+
+// Input describes the repositories to check, as given by upstream tooling such
+// as a CI job that already knows the files of a change. Repositories that list
+// their files are not walked.
+type Input struct {
+	Repositories []InputRepository `json:"repositories"`
+}
+
+// InputRepository is one repository of an Input
+type InputRepository struct {
+	Name     string            `json:"name,omitempty"` // Defaults to the base name of Path
+	Path     string            `json:"path"`
+	URL      string            `json:"url,omitempty"`
+	Branch   string            `json:"branch,omitempty"`
+	Language string            `json:"language,omitempty"` // Detected from the files when empty
+	Tags     []string          `json:"tags,omitempty"`
+	Metadata map[string]string `json:"metadata,omitempty"`
+	// Files are the files to check, absolute or relative to Path. Without them
+	// the repository is walked; an empty list checks no files.
+	Files []string `json:"files"`
+}
+
+// LoadInput reads and validates an input file. Relative repository paths are
+// relative to the working directory, as in config.yaml.
+func LoadInput(path string) (*Input, error) {
+	data, err := os.ReadFile(path) //nolint:gosec // Path is provided by the user
+	if err != nil {
+		return nil, fmt.Errorf("failed to read input: %w", err)
+	}
+
+	var input Input
+	if err := json.Unmarshal(data, &input); err != nil {
+		return nil, fmt.Errorf("failed to parse input %s: %w", path, err)
+	}
+	if len(input.Repositories) == 0 {
+		return nil, fmt.Errorf("input %s lists no repositories", path)
+	}
+
+	names := make(map[string]bool, len(input.Repositories))
+	for i := range input.Repositories {
+		repo := &input.Repositories[i]
+		if repo.Path == "" {
+			return nil, fmt.Errorf("input %s: repository %d has no path", path, i)
+		}
+		if repo.Name == "" {
+			absPath, err := filepath.Abs(repo.Path)
+			if err != nil {
+				return nil, fmt.Errorf("input %s: %w", path, err)
+			}
+			repo.Name = filepath.Base(absPath)
+		}
+		if names[repo.Name] {
+			return nil, fmt.Errorf("input %s: repository %s is listed twice", path, repo.Name)
+		}
+		names[repo.Name] = true
+	}
+	return &input, nil
+}
+
+// CoreRepositories returns the repositories of the input to check
+func (in *Input) CoreRepositories() []core.Repository {
+	repos := make([]core.Repository, len(in.Repositories))
+	for i, repo := range in.Repositories {
+		metadata := make(map[string]string, len(repo.Metadata))
+		for key, value := range repo.Metadata {
+			metadata[key] = value
+		}
+		repos[i] = core.Repository{
+			Name:     repo.Name,
+			Path:     repo.Path,
+			URL:      repo.URL,
+			Branch:   repo.Branch,
+			Tags:     repo.Tags,
+			Language: repo.Language,
+			Metadata: metadata,
+		}
+	}
+	return repos
+}
+
+// Apply provides the engine with the files of each repository that lists them
+func (in *Input) Apply(e *Engine) {
+	for _, repo := range in.Repositories {
+		if repo.Files != nil {
+			e.SetRepositoryFiles(repo.Name, repo.Files)
+		}
+	}
+}
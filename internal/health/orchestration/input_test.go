@@ -0,0 +1,50 @@
+package orchestration
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLoadInput(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "files.json")
+	content := `{"repositories": [
+		{"path": "services/api", "files": ["main.go", "handler.go"], "tags": ["backend"]},
+		{"name": "web", "path": "web", "language": "javascript", "metadata": {"team": "frontend"}}
+	]}`
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	input, err := LoadInput(path)
+	if err != nil {
+		t.Fatalf("LoadInput failed: %v", err)
+	}
+	repos := input.CoreRepositories()
+	if len(repos) != 2 || repos[0].Name != "api" || repos[0].Tags[0] != "backend" || repos[1].Language != "javascript" || repos[1].Metadata["team"] != "frontend" {
+		t.Errorf("Unexpected repositories: %+v", repos)
+	}
+
+	// Only repositories listing their files skip the walk
+	engine := NewEngine(&mockCheckerRegistry{}, &mockAnalyzerRegistry{}, &mockConfig{}, &mockLogger{})
+	input.Apply(engine)
+	if len(engine.repositoryFiles) != 1 || len(engine.repositoryFiles["api"]) != 2 {
+		t.Errorf("Expected the files of api only, got %v", engine.repositoryFiles)
+	}
+
+	for content, message := range map[string]string{
+		`{"repositories": []}`:                             "lists no repositories",
+		`{"repositories": [{"name": "api"}]}`:              "has no path",
+		`{"repositories": [{"path": "a"}, {"path": "a"}]}`: "listed twice",
+		`[]`: "failed to parse",
+	} {
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := LoadInput(path); err == nil || !strings.Contains(err.Error(), message) {
+			t.Errorf("Expected an error containing %q for %s, got %v", message, content, err)
+		}
+	}
+}
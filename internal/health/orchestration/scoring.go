@@ -0,0 +1,70 @@
+package orchestration
+
+import (
+	"fmt"
+
+	"github.com/codcod/repos/internal/core"
+)
+
+// maxCheckScore is the score of a check with no issues under the engine's scoring models
+const maxCheckScore = 100
+
+// ScoringModel rescores check results so that scores are comparable across checkers
+type ScoringModel interface {
+	// Score sets the result's Score and MaxScore
+	Score(result *core.CheckResult)
+}
+
+// NewScoringModel returns the model selected in config, or nil for the checker
+// model, which keeps the scores checkers report
+func NewScoringModel(config core.ScoringConfig) (ScoringModel, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+
+	switch config.Model {
+	case core.ScoringModelBinary:
+		return binaryScoring{}, nil
+	case core.ScoringModelGraded:
+		return gradedScoring{penalties: config.SeverityPenalties()}, nil
+	case "", core.ScoringModelChecker:
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("unknown scoring model %q", config.Model)
+	}
+}
+
+// binaryScoring scores a check 0 if it reports any non-advisory issue and 100 otherwise
+type binaryScoring struct{}
+
+func (binaryScoring) Score(result *core.CheckResult) {
+	result.MaxScore = maxCheckScore
+	result.Score = maxCheckScore
+	for _, issue := range result.Issues {
+		if !issue.Severity.Advisory() {
+			result.Score = 0
+			break
+		}
+	}
+}
+
+// gradedScoring subtracts a penalty per issue according to its severity. Advisory
+// issues have no penalty.
+type gradedScoring struct {
+	penalties map[core.Severity]int
+}
+
+func (g gradedScoring) Score(result *core.CheckResult) {
+	penalty := 0
+	for _, issue := range result.Issues {
+		penalty += g.penalties[issue.Severity]
+	}
+
+	if result.Metrics == nil {
+		result.Metrics = make(map[string]interface{})
+	}
+	result.Metrics["score_penalty"] = penalty
+
+	result.MaxScore = maxCheckScore
+	result.Score = max(maxCheckScore-penalty, 0)
+}
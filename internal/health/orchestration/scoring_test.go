@@ -0,0 +1,93 @@
+package orchestration
+
+import (
+	"context"
+	"testing"
+
+	"github.com/codcod/repos/internal/core"
+)
+
+func TestNewScoringModel(t *testing.T) {
+	issues := []core.Issue{
+		{Type: "a", Severity: core.SeverityHigh},
+		{Type: "b", Severity: core.SeverityLow},
+		{Type: "c", Severity: core.SeverityLow},
+	}
+
+	tests := []struct {
+		name     string
+		config   core.ScoringConfig
+		issues   []core.Issue
+		expected int
+	}{
+		{"binary with issues", core.ScoringConfig{Model: core.ScoringModelBinary}, issues, 0},
+		{"binary without issues", core.ScoringConfig{Model: core.ScoringModelBinary}, nil, 100},
+		{"binary ignores info", core.ScoringConfig{Model: core.ScoringModelBinary}, []core.Issue{{Type: "d", Severity: core.SeverityInfo}}, 100},
+		{"graded defaults", core.ScoringConfig{Model: core.ScoringModelGraded}, issues, 65},
+		{"graded ignores info", core.ScoringConfig{Model: core.ScoringModelGraded}, append([]core.Issue{{Type: "d", Severity: core.SeverityInfo}}, issues...), 65},
+		{"graded overrides", core.ScoringConfig{Model: core.ScoringModelGraded, Penalties: map[string]int{"high": 90, "low": 0}}, issues, 10},
+		{"graded floors at zero", core.ScoringConfig{Model: core.ScoringModelGraded, Penalties: map[string]int{"low": 60}}, issues, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			model, err := NewScoringModel(tt.config)
+			if err != nil {
+				t.Fatalf("NewScoringModel failed: %v", err)
+			}
+
+			result := core.CheckResult{Score: 42, MaxScore: 70, Issues: tt.issues}
+			model.Score(&result)
+			if result.Score != tt.expected || result.MaxScore != 100 {
+				t.Errorf("Expected score %d/100, got %d/%d", tt.expected, result.Score, result.MaxScore)
+			}
+		})
+	}
+
+	if model, err := NewScoringModel(core.ScoringConfig{}); err != nil || model != nil {
+		t.Errorf("Expected no model for the default checker scoring, got %v, %v", model, err)
+	}
+	if _, err := NewScoringModel(core.ScoringConfig{Model: "linear"}); err == nil {
+		t.Error("Expected an error for an unknown model")
+	}
+	if _, err := NewScoringModel(core.ScoringConfig{Model: core.ScoringModelGraded, Penalties: map[string]int{"severe": 1}}); err == nil {
+		t.Error("Expected an error for an unknown severity")
+	}
+	if _, err := NewScoringModel(core.ScoringConfig{Model: core.ScoringModelGraded, Penalties: map[string]int{"info": 1}}); err == nil {
+		t.Error("Expected an error for an info penalty")
+	}
+}
+
+func TestEngine_AppliesScoringModel(t *testing.T) {
+	checkerRegistry := &mockCheckerRegistry{}
+	checkerRegistry.Register(&mockChecker{
+		id:       "test-checker",
+		name:     "Test Checker",
+		category: "test",
+		result: core.CheckResult{
+			ID:       "test-checker",
+			Category: "test",
+			Status:   core.StatusWarning,
+			Score:    70,
+			MaxScore: 100,
+			Issues:   []core.Issue{{Type: "stale", Severity: core.SeverityMedium}},
+		},
+	})
+	config := &mockConfig{engineConfig: core.EngineConfig{
+		Scoring: core.ScoringConfig{Model: core.ScoringModelGraded},
+	}}
+
+	engine := NewEngine(checkerRegistry, &mockAnalyzerRegistry{}, config, &mockLogger{})
+	result, err := engine.ExecuteHealthCheck(context.Background(), []core.Repository{{Name: "test-repo", Path: "/path/to/repo"}})
+	if err != nil {
+		t.Fatalf("ExecuteHealthCheck failed: %v", err)
+	}
+
+	checkResult := result.RepositoryResults[0].CheckResults[0]
+	if checkResult.Score != 90 {
+		t.Errorf("Expected graded score 90, got %d", checkResult.Score)
+	}
+	if checkResult.Metrics["score_penalty"] != 10 {
+		t.Errorf("Expected score_penalty 10, got %v", checkResult.Metrics["score_penalty"])
+	}
+}
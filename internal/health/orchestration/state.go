@@ -0,0 +1,148 @@
+package orchestration
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/codcod/repos/internal/core"
+	"github.com/codcod/repos/internal/git"
+)
+
+const stateVersion = 1
+
+// State records the results of finished repositories so that an interrupted
+// run can resume. A recorded repository is skipped until its HEAD commit, the
+// configuration or the checker selection changes. Repositories without a commit
+// or with uncommitted changes are always checked again.
+type State struct {
+	path  string
+	head  func(dir string) (string, error)
+	dirty func(dir string) (bool, error)
+
+	mu           sync.Mutex
+	Version      int                   `json:"version"`
+	UpdatedAt    time.Time             `json:"updated_at"`
+	Repositories map[string]StateEntry `json:"repositories"`
+}
+
+// StateEntry is the recorded result of one repository
+type StateEntry struct {
+	StateKey
+	Result core.RepositoryResult `json:"result"`
+}
+
+// StateKey identifies the tree a repository was checked at and what it was
+// checked with
+type StateKey struct {
+	Head   string `json:"head"`
+	Dirty  bool   `json:"dirty"`
+	Config string `json:"config"` // hash of the configuration and checker selection
+}
+
+// reusable reports whether a result recorded under k holds for a check under
+// current. The content of a dirty tree is not identified by its HEAD, and a
+// configuration without a hash is unknown, so such results are never reused.
+func (k StateKey) reusable(current StateKey) bool {
+	return k.Head != "" && k.Config != "" && !k.Dirty && k == current
+}
+
+// LoadState reads the state file at path. A missing file is an empty state
+// that Record creates.
+func LoadState(path string) (*State, error) {
+	state := &State{
+		path:         path,
+		head:         git.HeadCommit,
+		dirty:        git.HasChanges,
+		Version:      stateVersion,
+		Repositories: make(map[string]StateEntry),
+	}
+
+	data, err := os.ReadFile(path) //nolint:gosec // Path is provided by the user
+	if errors.Is(err, os.ErrNotExist) {
+		return state, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read state: %w", err)
+	}
+	if err := json.Unmarshal(data, state); err != nil {
+		return nil, fmt.Errorf("failed to parse state %s: %w", path, err)
+	}
+	if state.Version != stateVersion {
+		return nil, fmt.Errorf("unsupported state version %d in %s", state.Version, path)
+	}
+	if state.Repositories == nil {
+		state.Repositories = make(map[string]StateEntry)
+	}
+	return state, nil
+}
+
+// Key returns the state key of the repository checked with the configuration
+// hash config. Its HEAD is "" if it has no commit, and it is dirty if its
+// changes cannot be listed.
+func (s *State) Key(repo core.Repository, config string) StateKey {
+	key := StateKey{Config: config}
+	if head, err := s.head(repo.Path); err == nil {
+		key.Head = head
+	}
+	if dirty, err := s.dirty(repo.Path); err != nil || dirty {
+		key.Dirty = true
+	}
+	return key
+}
+
+// Lookup returns the recorded result of the repository if it can be reused for
+// a check under key
+func (s *State) Lookup(repo core.Repository, key StateKey) (core.RepositoryResult, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	entry, ok := s.Repositories[repo.Name]
+	if !ok || !entry.reusable(key) {
+		return core.RepositoryResult{}, false
+	}
+	return entry.Result, true
+}
+
+// Record stores the result of a repository checked under key and rewrites the
+// state file. Errored results and repositories without a commit are not
+// recorded, so they are checked again on resume.
+func (s *State) Record(result core.RepositoryResult, key StateKey) error {
+	if key.Head == "" || result.Status == core.StatusErrored || result.Error != "" {
+		return nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.Repositories[result.Repository.Name] = StateEntry{StateKey: key, Result: result}
+	s.UpdatedAt = time.Now().UTC()
+	return s.save()
+}
+
+// save writes the state atomically, so an interrupted write keeps the previous state
+func (s *State) save() error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode state: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".*")
+	if err != nil {
+		return fmt.Errorf("failed to write state: %w", err)
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+	if _, err := tmp.Write(append(data, '\n')); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("failed to write state: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write state: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), s.path); err != nil {
+		return fmt.Errorf("failed to write state: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,183 @@
+package orchestration
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/codcod/repos/internal/core"
+)
+
+// countingChecker counts the checks of each repository
+type countingChecker struct {
+	mockChecker
+	mu     sync.Mutex
+	checks map[string]int
+}
+
+func (c *countingChecker) Check(ctx context.Context, repoCtx core.RepositoryContext) (core.CheckResult, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.checks[repoCtx.Repository.Name]++
+	return core.CheckResult{ID: c.id, Category: c.category, Status: core.StatusHealthy, Score: 100, MaxScore: 100}, nil
+}
+
+func TestEngine_ResumesFromState(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.json")
+	heads := map[string]string{"/src/same": "aaa", "/src/changed": "bbb"}
+	loadState := func() *State {
+		t.Helper()
+		state, err := LoadState(path)
+		if err != nil {
+			t.Fatalf("LoadState failed: %v", err)
+		}
+		state.head = func(dir string) (string, error) {
+			if head, ok := heads[dir]; ok {
+				return head, nil
+			}
+			return "", errors.New("not a git repository")
+		}
+		state.dirty = func(string) (bool, error) { return false, nil }
+		return state
+	}
+
+	checker := &countingChecker{mockChecker: mockChecker{id: "count", category: "test"}, checks: map[string]int{}}
+	checkers := &mockCheckerRegistry{}
+	checkers.Register(checker)
+	repos := []core.Repository{
+		{Name: "same", Path: "/src/same"},
+		{Name: "changed", Path: "/src/changed"},
+		{Name: "untracked", Path: "/src/untracked"},
+	}
+
+	run := func() *core.WorkflowResult {
+		t.Helper()
+		engine := NewEngine(checkers, &mockAnalyzerRegistry{}, &mockConfig{}, &mockLogger{})
+		engine.SetState(loadState())
+		result, err := engine.ExecuteHealthCheck(context.Background(), repos)
+		if err != nil {
+			t.Fatalf("ExecuteHealthCheck failed: %v", err)
+		}
+		return result
+	}
+
+	run()
+	if _, err := os.Stat(path); err != nil {
+		t.Fatalf("Expected the state file to be written: %v", err)
+	}
+	if state := loadState(); len(state.Repositories) != 2 || state.Repositories["same"].Head != "aaa" {
+		t.Fatalf("Expected the repositories with a HEAD to be recorded, got %+v", state.Repositories)
+	}
+
+	heads["/src/changed"] = "ccc"
+	result := run()
+	want := map[string]int{"same": 1, "changed": 2, "untracked": 2}
+	for name, count := range want {
+		if checker.checks[name] != count {
+			t.Errorf("Expected %s to be checked %d times, got %d", name, count, checker.checks[name])
+		}
+	}
+	if len(result.RepositoryResults) != 3 || result.RepositoryResults[0].Repository.Name != "same" ||
+		result.RepositoryResults[0].Score != 100 || result.Summary.SuccessfulRepos != 3 {
+		t.Errorf("Expected the recorded result in the summary, got %+v", result.RepositoryResults)
+	}
+}
+
+func TestState_SkipsErroredResults(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.json")
+	state, err := LoadState(path)
+	if err != nil {
+		t.Fatalf("LoadState failed: %v", err)
+	}
+
+	errored := erroredRepositoryResult(core.Repository{Name: "broken"}, errors.New("boom"))
+	key := StateKey{Head: "aaa", Config: "config"}
+	if err := state.Record(errored, key); err != nil {
+		t.Fatalf("Record failed: %v", err)
+	}
+	if _, ok := state.Lookup(core.Repository{Name: "broken"}, key); ok {
+		t.Error("Expected an errored repository to be retried")
+	}
+	if _, err := os.Stat(path); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("Expected no state file for errored results, got %v", err)
+	}
+
+	if err := os.WriteFile(path, []byte(`{"version": 9}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadState(path); err == nil {
+		t.Error("Expected an unsupported version to fail")
+	}
+}
+
+func TestState_LookupComparesDirtyTreeAndConfig(t *testing.T) {
+	state, err := LoadState(filepath.Join(t.TempDir(), "state.json"))
+	if err != nil {
+		t.Fatalf("LoadState failed: %v", err)
+	}
+	repo := core.Repository{Name: "api", Path: "/src/api"}
+	result := core.RepositoryResult{Repository: repo, Status: core.StatusHealthy, Score: 100}
+
+	recorded := StateKey{Head: "aaa", Config: "config"}
+	if err := state.Record(result, recorded); err != nil {
+		t.Fatalf("Record failed: %v", err)
+	}
+	if _, ok := state.Lookup(repo, recorded); !ok {
+		t.Error("Expected the result to be reused under the same key")
+	}
+
+	tests := map[string]StateKey{
+		"moved HEAD":       {Head: "bbb", Config: "config"},
+		"dirty tree":       {Head: "aaa", Dirty: true, Config: "config"},
+		"changed config":   {Head: "aaa", Config: "other"},
+		"unknown config":   {Head: "aaa"},
+		"no commit at all": {Config: "config"},
+	}
+	for name, key := range tests {
+		if _, ok := state.Lookup(repo, key); ok {
+			t.Errorf("Expected no result to be reused with a %s", name)
+		}
+	}
+
+	dirty := StateKey{Head: "ccc", Dirty: true, Config: "config"}
+	if err := state.Record(result, dirty); err != nil {
+		t.Fatalf("Record failed: %v", err)
+	}
+	if _, ok := state.Lookup(repo, dirty); ok {
+		t.Error("Expected a result recorded on a dirty tree not to be reused")
+	}
+}
+
+func TestEngine_StateKeyCoversCheckerSelection(t *testing.T) {
+	state, err := LoadState(filepath.Join(t.TempDir(), "state.json"))
+	if err != nil {
+		t.Fatalf("LoadState failed: %v", err)
+	}
+	state.head = func(string) (string, error) { return "aaa", nil }
+	state.dirty = func(string) (bool, error) { return false, nil }
+
+	checker := &countingChecker{mockChecker: mockChecker{id: "count", category: "test"}, checks: map[string]int{}}
+	checkers := &mockCheckerRegistry{}
+	checkers.Register(checker)
+	repos := []core.Repository{{Name: "api", Path: "/src/api"}}
+
+	run := func(categories []string) {
+		t.Helper()
+		engine := NewEngine(checkers, &mockAnalyzerRegistry{}, &mockConfig{}, &mockLogger{})
+		engine.SetState(state)
+		engine.SelectCheckers(categories, nil)
+		if _, err := engine.ExecuteHealthCheck(context.Background(), repos); err != nil {
+			t.Fatalf("ExecuteHealthCheck failed: %v", err)
+		}
+	}
+
+	run(nil)
+	run(nil)
+	run([]string{"test"})
+	if checker.checks["api"] != 2 {
+		t.Errorf("Expected a new category selection to check the repository again, got %d checks", checker.checks["api"])
+	}
+}
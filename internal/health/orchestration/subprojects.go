@@ -0,0 +1,121 @@
+package orchestration
+
+import (
+	"context"
+	"path"
+	"path/filepath"
+
+	"github.com/codcod/repos/internal/core"
+	"github.com/codcod/repos/internal/health/analyzers/language"
+	"github.com/codcod/repos/internal/health/subprojects"
+)
+
+// repositoryScopedCategories are the checker categories that check the
+// repository as a whole, such as its git history or CI configuration. In a
+// monorepo they run once at the root instead of once per sub-project.
+var repositoryScopedCategories = map[string]bool{
+	"git":        true,
+	"ci":         true,
+	"compliance": true,
+}
+
+// discoverSubprojects returns the sub-projects of a repository, or nil when
+// sub-project mode is disabled or the repository has none
+func (e *Engine) discoverSubprojects(repo core.Repository) []string {
+	if !e.subprojects.Enabled {
+		return nil
+	}
+
+	paths, err := subprojects.Discover(repo.Path, e.subprojects.Manifests, e.subprojects.MaxDepth)
+	if err != nil {
+		e.logger.Warn("Sub-project discovery failed",
+			core.String("repository", repo.Name),
+			core.Error("error", err))
+		return nil
+	}
+	return paths
+}
+
+// runSubprojectCheckers runs the repository-scoped checkers at the root and the
+// others in each sub-project. It returns all check results, with sub-project
+// file paths made relative to the repository, and the health of each sub-project.
+func (e *Engine) runSubprojectCheckers(
+	ctx context.Context,
+	repoCtx core.RepositoryContext,
+	paths []string,
+	checkerConfigs map[string]core.CheckerConfig,
+) ([]core.CheckResult, []core.SubprojectResult, error) {
+	var rootCheckers []core.Checker
+	for _, checker := range e.getEnabledCheckers(repoCtx.Repository, checkerConfigs) {
+		if repositoryScopedCategories[checker.Category()] {
+			rootCheckers = append(rootCheckers, checker)
+		}
+	}
+	results, err := e.runCheckerSet(ctx, repoCtx, rootCheckers)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	subprojectResults := make([]core.SubprojectResult, 0, len(paths))
+	for _, relPath := range paths {
+		subRepo := repoCtx.Repository
+		subRepo.Name = repoCtx.Repository.Name + "/" + relPath
+		subRepo.Path = filepath.Join(repoCtx.Repository.Path, filepath.FromSlash(relPath))
+		subRepo.Language = ""
+		if languages, err := language.Detect(subRepo.Path, e.languageExcludes()); err == nil {
+			subRepo.Language = languages.Primary()
+		}
+		subCtx := repoCtx
+		subCtx.Repository = subRepo
+
+		var checkers []core.Checker
+		for _, checker := range e.getEnabledCheckers(subRepo, checkerConfigs) {
+			if !repositoryScopedCategories[checker.Category()] {
+				checkers = append(checkers, checker)
+			}
+		}
+		subResults, err := e.runCheckerSet(ctx, subCtx, checkers)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		categoryScores := e.calculateCategoryScores(subResults)
+		subprojectResults = append(subprojectResults, core.SubprojectResult{
+			Name:           subRepo.Name,
+			Path:           relPath,
+			Status:         e.calculateOverallStatus(subResults),
+			Score:          e.calculateScore(categoryScores),
+			CategoryScores: categoryScores,
+		})
+
+		for i := range subResults {
+			relocateResult(&subResults[i], relPath)
+		}
+		results = append(results, subResults...)
+	}
+
+	return results, subprojectResults, nil
+}
+
+// relocateResult tags a sub-project's check result with the sub-project and
+// makes the file paths of its issues relative to the repository root
+func relocateResult(result *core.CheckResult, relPath string) {
+	// Copy the metadata and issues so that results shared between runs are left unchanged
+	metadata := make(map[string]string, len(result.Metadata)+1)
+	for key, value := range result.Metadata {
+		metadata[key] = value
+	}
+	metadata["subproject"] = relPath
+	result.Metadata = metadata
+
+	issues := make([]core.Issue, len(result.Issues))
+	for i, issue := range result.Issues {
+		if location := issue.Location; location != nil && location.File != "" && !filepath.IsAbs(location.File) {
+			relocated := *location
+			relocated.File = path.Join(relPath, filepath.ToSlash(location.File))
+			issue.Location = &relocated
+		}
+		issues[i] = issue
+	}
+	result.Issues = issues
+}
@@ -33,6 +33,50 @@ type ExecutionPlanConfig struct {
 	CacheTTL            time.Duration `json:"cache_ttl"`
 }
 
+// CategoryWeightProvider is implemented by configurations that assign weights to categories
+type CategoryWeightProvider interface {
+	// GetCategoryWeight returns the weight for a category and whether it is configured
+	GetCategoryWeight(category string) (float64, bool)
+}
+
+// CheckerSkipProvider is implemented by configurations that skip checkers for
+// some repositories
+type CheckerSkipProvider interface {
+	// SkippedCheckers returns the IDs of the checkers not to run on the repository
+	SkippedCheckers(repo core.Repository) map[string]bool
+}
+
+// RepositoryConfigProvider is implemented by configurations that vary per
+// repository, such as those with conditional overrides
+type RepositoryConfigProvider interface {
+	// ForRepository returns the configuration to check the repository with
+	ForRepository(repo core.Repository) core.Config
+}
+
+// RepositoryPlan is what a health check would run on one repository
+type RepositoryPlan struct {
+	Repository core.Repository
+	// Languages are the languages that would be analyzed, primary first
+	Languages []string
+	Checkers  []core.Checker
+	Excluded  []ExcludedChecker
+}
+
+// ExcludedChecker is a registered checker that would not run, with the reason
+type ExcludedChecker struct {
+	Checker core.Checker
+	Reason  string
+}
+
+// Reasons a registered checker does not run on a repository
+const (
+	ReasonNotSelected    = "not selected"
+	ReasonSkipped        = "skipped for the repository's tags"
+	ReasonUnsupported    = "does not support the repository"
+	ReasonDisabled       = "opt-in and not enabled"
+	ReasonConfigDisabled = "disabled in the configuration"
+)
+
 // ProgressReporter reports progress during execution
 type ProgressReporter interface {
 	ReportProgress(ctx context.Context, progress Progress)
@@ -49,4 +93,10 @@ type Progress struct {
 	EstimatedTimeLeft time.Duration `json:"estimated_time_left"`
 	CurrentStep       string        `json:"current_step"`
 	Status            string        `json:"status"`
+
+	// Result holds the finished repository when Status is ProgressRepositoryCompleted
+	Result *core.RepositoryResult `json:"result,omitempty"`
 }
+
+// ProgressRepositoryCompleted is the progress status reported as each repository finishes
+const ProgressRepositoryCompleted = "repository_completed"
@@ -0,0 +1,195 @@
+package reporting
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/codcod/repos/internal/core"
+)
+
+const (
+	baselineVersion = 1
+
+	// baselineContextKey marks issues that were matched against a baseline
+	baselineContextKey = "baseline"
+	baselineKnown      = "known"
+)
+
+var (
+	baselineDigits     = regexp.MustCompile(`\d+`)
+	baselineWhitespace = regexp.MustCompile(`\s+`)
+)
+
+// Baseline is a snapshot of accepted findings; findings it contains are reported
+// as known and do not fail subsequent runs.
+type Baseline struct {
+	Version   int             `json:"version"`
+	CreatedAt time.Time       `json:"created_at"`
+	Findings  []BaselineEntry `json:"findings"`
+
+	index map[string]bool
+}
+
+// BaselineEntry describes one accepted finding. Only the fingerprint is used for
+// matching; the remaining fields make the file reviewable.
+type BaselineEntry struct {
+	Fingerprint string `json:"fingerprint"`
+	Repository  string `json:"repository"`
+	Checker     string `json:"checker"`
+	File        string `json:"file,omitempty"`
+	Message     string `json:"message"`
+}
+
+// NewBaseline records every finding in the workflow result except advisory ones
+func NewBaseline(result core.WorkflowResult) *Baseline {
+	baseline := &Baseline{Version: baselineVersion, CreatedAt: time.Now().UTC(), Findings: []BaselineEntry{}}
+	seen := make(map[string]bool)
+
+	for _, repoResult := range result.RepositoryResults {
+		for _, checkResult := range repoResult.CheckResults {
+			for _, issue := range checkResult.Issues {
+				if issue.Severity.Advisory() {
+					continue
+				}
+				fingerprint := FindingFingerprint(repoResult.Repository, checkResult.ID, issue)
+				if seen[fingerprint] {
+					continue
+				}
+				seen[fingerprint] = true
+				baseline.Findings = append(baseline.Findings, BaselineEntry{
+					Fingerprint: fingerprint,
+					Repository:  repoResult.Repository.Name,
+					Checker:     checkResult.ID,
+					File:        issueFile(repoResult.Repository, issue),
+					Message:     issue.Message,
+				})
+			}
+		}
+	}
+
+	// Keep the file stable across runs so it diffs cleanly in version control
+	sort.Slice(baseline.Findings, func(i, j int) bool {
+		a, b := baseline.Findings[i], baseline.Findings[j]
+		if a.Repository != b.Repository {
+			return a.Repository < b.Repository
+		}
+		if a.Checker != b.Checker {
+			return a.Checker < b.Checker
+		}
+		if a.File != b.File {
+			return a.File < b.File
+		}
+		return a.Fingerprint < b.Fingerprint
+	})
+
+	return baseline
+}
+
+// LoadBaseline reads a baseline file written by Save
+func LoadBaseline(path string) (*Baseline, error) {
+	data, err := os.ReadFile(path) //nolint:gosec // Path is provided by the user
+	if err != nil {
+		return nil, fmt.Errorf("failed to read baseline: %w", err)
+	}
+
+	var baseline Baseline
+	if err := json.Unmarshal(data, &baseline); err != nil {
+		return nil, fmt.Errorf("failed to parse baseline %s: %w", path, err)
+	}
+	if baseline.Version != baselineVersion {
+		return nil, fmt.Errorf("unsupported baseline version %d in %s", baseline.Version, path)
+	}
+	return &baseline, nil
+}
+
+// Save writes the baseline to path as indented JSON
+func (b *Baseline) Save(path string) error {
+	data, err := json.MarshalIndent(b, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode baseline: %w", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0o600); err != nil {
+		return fmt.Errorf("failed to write baseline: %w", err)
+	}
+	return nil
+}
+
+// Contains reports whether the baseline holds the given fingerprint
+func (b *Baseline) Contains(fingerprint string) bool {
+	if b.index == nil {
+		b.index = make(map[string]bool, len(b.Findings))
+		for _, entry := range b.Findings {
+			b.index[entry.Fingerprint] = true
+		}
+	}
+	return b.index[fingerprint]
+}
+
+// Apply marks findings present in the baseline as known and records the number of
+// known and new findings in the result summary, which ExitCode then uses in place
+// of the repository status. Advisory findings are neither known nor new.
+func (b *Baseline) Apply(result *core.WorkflowResult) {
+	summary := &core.BaselineSummary{}
+	for r := range result.RepositoryResults {
+		known, added := b.ApplyRepository(&result.RepositoryResults[r])
+		summary.KnownFindings += known
+		summary.NewFindings += added
+	}
+	result.Summary.Baseline = summary
+}
+
+// ApplyRepository marks the findings of one repository present in the baseline
+// as known, and returns the number of known and new findings
+func (b *Baseline) ApplyRepository(repoResult *core.RepositoryResult) (known, added int) {
+	for c := range repoResult.CheckResults {
+		checkResult := &repoResult.CheckResults[c]
+		for i := range checkResult.Issues {
+			issue := &checkResult.Issues[i]
+			if issue.Severity.Advisory() {
+				continue
+			}
+			if !b.Contains(FindingFingerprint(repoResult.Repository, checkResult.ID, *issue)) {
+				added++
+				continue
+			}
+			if issue.Context == nil {
+				issue.Context = make(map[string]interface{})
+			}
+			issue.Context[baselineContextKey] = baselineKnown
+			known++
+		}
+	}
+	return known, added
+}
+
+// IsKnownFinding reports whether Apply matched the issue against the baseline
+func IsKnownFinding(issue core.Issue) bool {
+	return issue.Context[baselineContextKey] == baselineKnown
+}
+
+// FindingFingerprint identifies a finding by repository, checker, file and message.
+// Numbers in the message are ignored and whitespace is collapsed so that counts and
+// line numbers changing do not turn an existing finding into a new one.
+func FindingFingerprint(repo core.Repository, checkerID string, issue core.Issue) string {
+	message := strings.ToLower(strings.TrimSpace(issue.Message))
+	message = baselineDigits.ReplaceAllString(message, "#")
+	message = baselineWhitespace.ReplaceAllString(message, " ")
+
+	sum := sha256.Sum256([]byte(strings.Join([]string{repo.Name, checkerID, issueFile(repo, issue), message}, "\x00")))
+	return hex.EncodeToString(sum[:])
+}
+
+// issueFile returns the issue's file relative to the repository, or "" if it has none
+func issueFile(repo core.Repository, issue core.Issue) string {
+	if issue.Location == nil || issue.Location.File == "" {
+		return ""
+	}
+	return relativeFilePath(issue.Location.File, repo.Path)
+}
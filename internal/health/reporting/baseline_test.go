@@ -0,0 +1,89 @@
+package reporting
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/codcod/repos/internal/core"
+)
+
+func TestFindingFingerprint_IgnoresNumbersAndWhitespace(t *testing.T) {
+	repo := core.Repository{Name: "repo1", Path: "/repos/repo1"}
+	a := core.Issue{Message: "main.go contains 5 markers", Location: &core.Location{File: "/repos/repo1/main.go", Line: 3}}
+	b := core.Issue{Message: "Main.go  contains 12 markers", Location: &core.Location{File: "/repos/repo1/main.go", Line: 40}}
+
+	if FindingFingerprint(repo, "tech-debt", a) != FindingFingerprint(repo, "tech-debt", b) {
+		t.Error("Expected fingerprints to match when only numbers, case and whitespace differ")
+	}
+	if FindingFingerprint(repo, "tech-debt", a) == FindingFingerprint(repo, "other", a) {
+		t.Error("Expected fingerprints to differ between checkers")
+	}
+
+	moved := core.Repository{Name: "repo1", Path: "/elsewhere/repo1"}
+	c := core.Issue{Message: a.Message, Location: &core.Location{File: "/elsewhere/repo1/main.go"}}
+	if FindingFingerprint(repo, "tech-debt", a) != FindingFingerprint(moved, "tech-debt", c) {
+		t.Error("Expected fingerprints to use repository-relative file paths")
+	}
+}
+
+func TestBaseline_RoundTripAndApply(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "baseline.json")
+	if err := NewBaseline(outputTestResult()).Save(path); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	baseline, err := LoadBaseline(path)
+	if err != nil {
+		t.Fatalf("LoadBaseline failed: %v", err)
+	}
+	if len(baseline.Findings) != 1 {
+		t.Fatalf("Expected 1 finding in baseline, got %d", len(baseline.Findings))
+	}
+
+	result := outputTestResult()
+	result.Summary.FailedRepos = 1
+	checks := result.RepositoryResults[0].CheckResults
+	checks[0].Issues = append(checks[0].Issues, core.Issue{Type: "tech_debt_hotspot", Message: "util.go contains 9 markers"})
+
+	baseline.Apply(&result)
+
+	if got := result.Summary.Baseline; got == nil || got.KnownFindings != 1 || got.NewFindings != 1 {
+		t.Fatalf("Expected 1 known and 1 new finding, got %+v", got)
+	}
+	if !IsKnownFinding(checks[0].Issues[0]) || IsKnownFinding(checks[0].Issues[1]) {
+		t.Error("Expected only the baselined issue to be marked as known")
+	}
+	if code := ExitCode(result, ExitPolicy{}); code != 2 {
+		t.Errorf("Expected exit code 2 with new findings, got %d", code)
+	}
+
+	// Once the new finding is fixed, known findings alone do not fail the run
+	checks[0].Issues = checks[0].Issues[:1]
+	baseline.Apply(&result)
+	if code := ExitCode(result, ExitPolicy{}); code != 0 {
+		t.Errorf("Expected exit code 0 with only known findings, got %d", code)
+	}
+
+	// Advisory findings are never new
+	checks[0].Issues = append(checks[0].Issues, core.Issue{Type: "hint", Severity: core.SeverityInfo, Message: "consider a CHANGELOG"})
+	baseline.Apply(&result)
+	if got := result.Summary.Baseline; got.NewFindings != 0 || ExitCode(result, ExitPolicy{}) != 0 {
+		t.Errorf("Expected info findings to be ignored, got %+v", got)
+	}
+}
+
+func TestLoadBaseline_Errors(t *testing.T) {
+	if _, err := LoadBaseline(filepath.Join(t.TempDir(), "missing.json")); err == nil {
+		t.Error("Expected error for missing baseline file")
+	}
+
+	path := filepath.Join(t.TempDir(), "baseline.json")
+	baseline := NewBaseline(core.WorkflowResult{})
+	baseline.Version = 99
+	if err := baseline.Save(path); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	if _, err := LoadBaseline(path); err == nil {
+		t.Error("Expected error for unsupported baseline version")
+	}
+}
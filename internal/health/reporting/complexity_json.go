@@ -0,0 +1,165 @@
+package reporting
+
+import (
+	"encoding/json"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/codcod/repos/internal/core"
+)
+
+// ComplexityDetailedReport is the machine-readable complexity report.
+// Its JSON field names are consumed by external tooling and must remain stable.
+type ComplexityDetailedReport struct {
+	MaxComplexity int                          `json:"max_complexity"`
+	GeneratedAt   time.Time                    `json:"generated_at"`
+	Repositories  []ComplexityRepositoryReport `json:"repositories"`
+}
+
+// ComplexityRepositoryReport holds complexity results for a single repository
+type ComplexityRepositoryReport struct {
+	Name                    string                 `json:"name"`
+	Path                    string                 `json:"path"`
+	Language                string                 `json:"language"`
+	Metrics                 ComplexityMetrics      `json:"metrics"`
+	Files                   []ComplexityFileReport `json:"files"`
+	HighComplexityFunctions []ComplexityFunction   `json:"high_complexity_functions"`
+	LongestFunctions        []ComplexityFunction   `json:"longest_functions"`
+	Errors                  []core.AnalysisError   `json:"errors,omitempty"`
+}
+
+// ComplexityMetrics holds repository-level complexity metrics
+type ComplexityMetrics struct {
+	TotalFiles        int     `json:"total_files"`
+	TotalFunctions    int     `json:"total_functions"`
+	TotalComplexity   int     `json:"total_complexity"`
+	MaxComplexity     int     `json:"max_complexity"`
+	AverageComplexity float64 `json:"average_complexity"`
+	MaxFunctionLines  int     `json:"max_function_lines"`
+	// GeneratedFilesSkipped counts files left out because they are generated code
+	GeneratedFilesSkipped int `json:"generated_files_skipped,omitempty"`
+}
+
+// ComplexityFileReport holds complexity results for a single file
+type ComplexityFileReport struct {
+	Path              string  `json:"path"`
+	Functions         int     `json:"functions"`
+	TotalComplexity   int     `json:"total_complexity"`
+	MaxComplexity     int     `json:"max_complexity"`
+	AverageComplexity float64 `json:"average_complexity"`
+}
+
+// ComplexityFunction identifies a function, its cyclomatic complexity and its
+// length in lines (0 if unknown)
+type ComplexityFunction struct {
+	Name       string `json:"name"`
+	File       string `json:"file"`
+	Line       int    `json:"line"`
+	Complexity int    `json:"complexity"`
+	Lines      int    `json:"lines,omitempty"`
+}
+
+// NewComplexityDetailedReport builds a complexity report from repository results.
+// Functions with complexity at or above maxComplexity are listed as high complexity.
+func NewComplexityDetailedReport(maxComplexity int, results []core.RepositoryResult) ComplexityDetailedReport {
+	report := ComplexityDetailedReport{
+		MaxComplexity: maxComplexity,
+		GeneratedAt:   time.Now(),
+		Repositories:  []ComplexityRepositoryReport{},
+	}
+
+	formatter := NewComplexityFormatterWithThreshold(false, maxComplexity)
+	for _, result := range results {
+		if result.AnalysisResult == nil {
+			continue
+		}
+		report.Repositories = append(report.Repositories, formatter.buildComplexityRepositoryReport(result))
+	}
+
+	return report
+}
+
+// buildComplexityRepositoryReport converts a single repository's analysis into report form
+func (f *Formatter) buildComplexityRepositoryReport(result core.RepositoryResult) ComplexityRepositoryReport {
+	analysis := result.AnalysisResult
+	repoReport := ComplexityRepositoryReport{
+		Name:                    result.Repository.Name,
+		Path:                    result.Repository.Path,
+		Language:                analysis.Language,
+		Files:                   []ComplexityFileReport{},
+		HighComplexityFunctions: []ComplexityFunction{},
+		LongestFunctions:        []ComplexityFunction{},
+		Errors:                  analysis.Errors,
+	}
+
+	// Aggregate per-file metrics from the function list
+	files := make(map[string]*ComplexityFileReport)
+	for _, fn := range analysis.Functions {
+		path := f.getRelativePath(fn.File, result.Repository.Path)
+		file, exists := files[path]
+		if !exists {
+			file = &ComplexityFileReport{Path: path}
+			files[path] = file
+		}
+		file.Functions++
+		file.TotalComplexity += fn.Complexity
+		file.MaxComplexity = max(file.MaxComplexity, fn.Complexity)
+
+		repoReport.Metrics.TotalFunctions++
+		repoReport.Metrics.TotalComplexity += fn.Complexity
+		repoReport.Metrics.MaxComplexity = max(repoReport.Metrics.MaxComplexity, fn.Complexity)
+		repoReport.Metrics.MaxFunctionLines = max(repoReport.Metrics.MaxFunctionLines, fn.Lines())
+	}
+
+	// Include analyzed files without functions as well
+	for path := range analysis.Files {
+		relativePath := f.getRelativePath(path, result.Repository.Path)
+		if _, exists := files[relativePath]; !exists {
+			files[relativePath] = &ComplexityFileReport{Path: relativePath}
+		}
+	}
+
+	for _, file := range files {
+		if file.Functions > 0 {
+			file.AverageComplexity = float64(file.TotalComplexity) / float64(file.Functions)
+		}
+		repoReport.Files = append(repoReport.Files, *file)
+	}
+	sort.Slice(repoReport.Files, func(i, j int) bool {
+		return repoReport.Files[i].Path < repoReport.Files[j].Path
+	})
+
+	repoReport.Metrics.TotalFiles = len(repoReport.Files)
+	repoReport.Metrics.GeneratedFilesSkipped, _ = analysis.Metrics["generated_files_skipped"].(int)
+	if repoReport.Metrics.TotalFunctions > 0 {
+		repoReport.Metrics.AverageComplexity = float64(repoReport.Metrics.TotalComplexity) / float64(repoReport.Metrics.TotalFunctions)
+	}
+
+	for _, fn := range f.getComplexFunctions(analysis.Functions) {
+		repoReport.HighComplexityFunctions = append(repoReport.HighComplexityFunctions, f.complexityFunction(fn, result.Repository.Path))
+	}
+	for _, fn := range longestFunctions(analysis.Functions, maxLongestFunctions) {
+		repoReport.LongestFunctions = append(repoReport.LongestFunctions, f.complexityFunction(fn, result.Repository.Path))
+	}
+
+	return repoReport
+}
+
+// complexityFunction converts a function to its report form
+func (f *Formatter) complexityFunction(fn core.FunctionInfo, repoPath string) ComplexityFunction {
+	return ComplexityFunction{
+		Name:       fn.Name,
+		File:       f.getRelativePath(fn.File, repoPath),
+		Line:       fn.Line,
+		Complexity: fn.Complexity,
+		Lines:      fn.Lines(),
+	}
+}
+
+// WriteJSON writes v as indented JSON
+func WriteJSON(w io.Writer, v interface{}) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(v)
+}
@@ -0,0 +1,82 @@
+package reporting
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/codcod/repos/internal/core"
+)
+
+func TestNewComplexityDetailedReport(t *testing.T) {
+	results := []core.RepositoryResult{
+		{
+			Repository: core.Repository{Name: "repo1", Path: "/repos/repo1", Language: "go"},
+			AnalysisResult: &core.AnalysisResult{
+				Language: "go",
+				Files: map[string]*core.FileAnalysis{
+					"/repos/repo1/main.go":  {Path: "/repos/repo1/main.go"},
+					"/repos/repo1/empty.go": {Path: "/repos/repo1/empty.go"},
+				},
+				Functions: []core.FunctionInfo{
+					{Name: "simple", File: "/repos/repo1/main.go", Line: 3, Complexity: 2},
+					{Name: "complex", File: "/repos/repo1/main.go", Line: 10, EndLine: 40, Complexity: 15},
+				},
+			},
+		},
+		{
+			// Repositories without analysis are skipped
+			Repository: core.Repository{Name: "repo2", Path: "/repos/repo2"},
+		},
+	}
+
+	report := NewComplexityDetailedReport(10, results)
+
+	if report.MaxComplexity != 10 {
+		t.Errorf("Expected max complexity 10, got %d", report.MaxComplexity)
+	}
+	if len(report.Repositories) != 1 {
+		t.Fatalf("Expected 1 repository, got %d", len(report.Repositories))
+	}
+
+	repo := report.Repositories[0]
+	if repo.Metrics.TotalFunctions != 2 || repo.Metrics.TotalComplexity != 17 || repo.Metrics.MaxComplexity != 15 {
+		t.Errorf("Unexpected metrics: %+v", repo.Metrics)
+	}
+	if len(repo.Files) != 2 || repo.Files[0].Path != "empty.go" || repo.Files[1].Path != "main.go" {
+		t.Errorf("Expected sorted relative file paths, got %+v", repo.Files)
+	}
+	if len(repo.HighComplexityFunctions) != 1 {
+		t.Fatalf("Expected 1 high complexity function, got %d", len(repo.HighComplexityFunctions))
+	}
+
+	fn := repo.HighComplexityFunctions[0]
+	if fn.Name != "complex" || fn.File != "main.go" || fn.Line != 10 || fn.Complexity != 15 || fn.Lines != 31 {
+		t.Errorf("Unexpected high complexity function: %+v", fn)
+	}
+
+	// Only functions of known length are listed as the longest
+	if repo.Metrics.MaxFunctionLines != 31 || len(repo.LongestFunctions) != 1 || repo.LongestFunctions[0].Name != "complex" {
+		t.Errorf("Expected complex as the longest function, got %+v", repo.LongestFunctions)
+	}
+}
+
+func TestWriteJSON_ComplexityReportFieldNames(t *testing.T) {
+	report := NewComplexityDetailedReport(5, nil)
+
+	var buf bytes.Buffer
+	if err := WriteJSON(&buf, report); err != nil {
+		t.Fatalf("WriteJSON failed: %v", err)
+	}
+
+	var decoded map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatalf("Output is not valid JSON: %v", err)
+	}
+
+	for _, field := range []string{"max_complexity", "generated_at", "repositories"} {
+		if _, exists := decoded[field]; !exists {
+			t.Errorf("Expected field %q in JSON output", field)
+		}
+	}
+}
@@ -0,0 +1,124 @@
+package reporting
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/codcod/repos/internal/core"
+)
+
+var (
+	// complexityCSVHeader lists the columns of the per-function complexity export
+	complexityCSVHeader = []string{"repository", "file", "function", "language", "complexity", "line"}
+
+	// checksCSVHeader lists the columns of the per-checker export
+	checksCSVHeader = []string{"repository", "checker", "status", "severity", "score"}
+)
+
+// CSVFormatter writes health results as CSV for spreadsheets and metrics pipelines
+type CSVFormatter struct{}
+
+// NewCSVFormatter creates a new CSV formatter
+func NewCSVFormatter() *CSVFormatter {
+	return &CSVFormatter{}
+}
+
+// WriteComplexity writes one row per analyzed function
+func (f *CSVFormatter) WriteComplexity(w io.Writer, results []core.RepositoryResult) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(complexityCSVHeader); err != nil {
+		return err
+	}
+
+	for _, result := range results {
+		if result.AnalysisResult == nil {
+			continue
+		}
+		for _, fn := range result.AnalysisResult.Functions {
+			language := fn.Language
+			if language == "" {
+				language = result.AnalysisResult.Language
+			}
+			record := []string{
+				result.Repository.Name,
+				relativeFilePath(fn.File, result.Repository.Path),
+				fn.Name,
+				language,
+				strconv.Itoa(fn.Complexity),
+				strconv.Itoa(fn.Line),
+			}
+			if err := writer.Write(record); err != nil {
+				return err
+			}
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+// WriteCheckResults writes one row per checker result. The severity column holds
+// the most severe issue reported by the checker, or is empty when there are none.
+func (f *CSVFormatter) WriteCheckResults(w io.Writer, result core.WorkflowResult) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(checksCSVHeader); err != nil {
+		return err
+	}
+
+	for _, repoResult := range result.RepositoryResults {
+		for _, checkResult := range repoResult.CheckResults {
+			record := []string{
+				repoResult.Repository.Name,
+				checkResult.ID,
+				string(checkResult.Status),
+				string(highestSeverity(checkResult.Issues)),
+				strconv.Itoa(checkResult.Score),
+			}
+			if err := writer.Write(record); err != nil {
+				return err
+			}
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+// highestSeverity returns the most severe issue severity, or an empty severity without issues
+func highestSeverity(issues []core.Issue) core.Severity {
+	var highest core.Severity
+	for _, issue := range issues {
+		if severityRank(issue.Severity) > severityRank(highest) {
+			highest = issue.Severity
+		}
+	}
+	return highest
+}
+
+// severityRank orders severities from least to most severe
+func severityRank(severity core.Severity) int {
+	switch severity {
+	case core.SeverityInfo:
+		return 1
+	case core.SeverityLow:
+		return 2
+	case core.SeverityMedium:
+		return 3
+	case core.SeverityHigh:
+		return 4
+	case core.SeverityCritical:
+		return 5
+	default:
+		return 0
+	}
+}
+
+// relativeFilePath returns filePath relative to repoPath when it lies within it
+func relativeFilePath(filePath, repoPath string) string {
+	if repoPath != "" && strings.HasPrefix(filePath, repoPath) {
+		return strings.TrimPrefix(strings.TrimPrefix(filePath, repoPath), "/")
+	}
+	return filePath
+}
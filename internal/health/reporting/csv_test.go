@@ -0,0 +1,84 @@
+package reporting
+
+import (
+	"bytes"
+	"encoding/csv"
+	"reflect"
+	"testing"
+
+	"github.com/codcod/repos/internal/core"
+)
+
+func TestCSVFormatter_WriteComplexity(t *testing.T) {
+	results := []core.RepositoryResult{
+		{
+			Repository: core.Repository{Name: "repo,one", Path: "/repos/one"},
+			AnalysisResult: &core.AnalysisResult{
+				Language: "go",
+				Functions: []core.FunctionInfo{
+					{Name: `parse "quoted"`, File: "/repos/one/main.go", Line: 12, Complexity: 7},
+				},
+			},
+		},
+		{Repository: core.Repository{Name: "no-analysis"}},
+	}
+
+	var buf bytes.Buffer
+	if err := NewCSVFormatter().WriteComplexity(&buf, results); err != nil {
+		t.Fatalf("WriteComplexity failed: %v", err)
+	}
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("Output is not valid CSV: %v", err)
+	}
+
+	expected := [][]string{
+		complexityCSVHeader,
+		{"repo,one", "main.go", `parse "quoted"`, "go", "7", "12"},
+	}
+	if !reflect.DeepEqual(records, expected) {
+		t.Errorf("Expected %v, got %v", expected, records)
+	}
+}
+
+func TestCSVFormatter_WriteCheckResults(t *testing.T) {
+	result := core.WorkflowResult{
+		RepositoryResults: []core.RepositoryResult{
+			{
+				Repository: core.Repository{Name: "repo1"},
+				CheckResults: []core.CheckResult{
+					{
+						ID:     "readme-check",
+						Status: core.StatusWarning,
+						Score:  70,
+						Issues: []core.Issue{
+							{Severity: core.SeverityLow, Message: "line one\nline two"},
+							{Severity: core.SeverityHigh},
+						},
+					},
+					{ID: "git-status", Status: core.StatusHealthy, Score: 100},
+				},
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := NewCSVFormatter().WriteCheckResults(&buf, result); err != nil {
+		t.Fatalf("WriteCheckResults failed: %v", err)
+	}
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("Output is not valid CSV: %v", err)
+	}
+
+	expected := [][]string{
+		checksCSVHeader,
+		{"repo1", "readme-check", "warning", "high", "70"},
+		{"repo1", "git-status", "healthy", "", "100"},
+	}
+	if !reflect.DeepEqual(records, expected) {
+		t.Errorf("Expected %v, got %v", expected, records)
+	}
+}
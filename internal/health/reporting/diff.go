@@ -0,0 +1,261 @@
+package reporting
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+
+	"github.com/codcod/repos/internal/core"
+)
+
+// ResultDiff compares the findings and scores of two health check runs
+type ResultDiff struct {
+	NewIssues      []DiffIssue       `json:"new_issues"`
+	ResolvedIssues []DiffIssue       `json:"resolved_issues"`
+	Repositories   []RepositoryDelta `json:"repositories"`
+}
+
+// DiffIssue is a finding present in only one of the compared runs
+type DiffIssue struct {
+	Repository string     `json:"repository"`
+	Checker    string     `json:"checker"`
+	Issue      core.Issue `json:"issue"`
+}
+
+// RepositoryDelta is the change in a repository's score and in the scores of
+// its checkers. A score is nil when the repository or checker is missing from a run.
+type RepositoryDelta struct {
+	Repository string         `json:"repository"`
+	OldScore   *int           `json:"old_score"`
+	NewScore   *int           `json:"new_score"`
+	Checkers   []CheckerDelta `json:"checkers,omitempty"`
+}
+
+// CheckerDelta is the change in a checker's score
+type CheckerDelta struct {
+	Checker  string `json:"checker"`
+	OldScore *int   `json:"old_score"`
+	NewScore *int   `json:"new_score"`
+}
+
+// LoadWorkflowResult reads a result written with --format json
+func LoadWorkflowResult(path string) (*core.WorkflowResult, error) {
+	data, err := os.ReadFile(path) //nolint:gosec // Path is provided by the user
+	if err != nil {
+		return nil, fmt.Errorf("failed to read results: %w", err)
+	}
+
+	var result core.WorkflowResult
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse results %s: %w", path, err)
+	}
+	return &result, nil
+}
+
+// DiffResults compares an earlier run with a later one. Findings are matched by
+// the same fingerprint baselines use, so moved lines and changed counts don't
+// show up as a resolved finding plus a new one.
+func DiffResults(oldResult, newResult core.WorkflowResult) *ResultDiff {
+	diff := &ResultDiff{NewIssues: []DiffIssue{}, ResolvedIssues: []DiffIssue{}, Repositories: []RepositoryDelta{}}
+
+	oldFindings := fingerprintFindings(oldResult)
+	newFindings := fingerprintFindings(newResult)
+	diff.NewIssues = unmatchedFindings(newFindings, oldFindings)
+	diff.ResolvedIssues = unmatchedFindings(oldFindings, newFindings)
+
+	oldRepos := repositoriesByName(oldResult)
+	newRepos := repositoriesByName(newResult)
+	for _, name := range unionKeys(oldRepos, newRepos) {
+		oldRepo, inOld := oldRepos[name]
+		newRepo, inNew := newRepos[name]
+
+		delta := RepositoryDelta{Repository: name}
+		if inOld {
+			delta.OldScore = intPtr(oldRepo.Score)
+		}
+		if inNew {
+			delta.NewScore = intPtr(newRepo.Score)
+		}
+
+		oldChecks := checksByID(oldRepo)
+		newChecks := checksByID(newRepo)
+		for _, id := range unionKeys(oldChecks, newChecks) {
+			checkerDelta := CheckerDelta{Checker: id}
+			if check, ok := oldChecks[id]; ok {
+				checkerDelta.OldScore = intPtr(check.Score)
+			}
+			if check, ok := newChecks[id]; ok {
+				checkerDelta.NewScore = intPtr(check.Score)
+			}
+			if !sameScore(checkerDelta.OldScore, checkerDelta.NewScore) {
+				delta.Checkers = append(delta.Checkers, checkerDelta)
+			}
+		}
+
+		if !sameScore(delta.OldScore, delta.NewScore) || len(delta.Checkers) > 0 {
+			diff.Repositories = append(diff.Repositories, delta)
+		}
+	}
+
+	return diff
+}
+
+// NewCriticalIssues returns the number of new findings with critical severity
+func (d *ResultDiff) NewCriticalIssues() int {
+	count := 0
+	for _, finding := range d.NewIssues {
+		if finding.Issue.Severity == core.SeverityCritical {
+			count++
+		}
+	}
+	return count
+}
+
+// ExitCode returns 2 if the later run introduced critical findings and 0 otherwise
+func (d *ResultDiff) ExitCode() int {
+	if d.NewCriticalIssues() > 0 {
+		return 2
+	}
+	return 0
+}
+
+// Write prints the diff as text
+func (d *ResultDiff) Write(w io.Writer) error {
+	var err error
+	printf := func(format string, args ...interface{}) {
+		if err == nil {
+			_, err = fmt.Fprintf(w, format, args...)
+		}
+	}
+
+	printf("New issues: %d\n", len(d.NewIssues))
+	for _, finding := range d.NewIssues {
+		printf("  + [%s] %s/%s: %s%s\n", finding.Issue.Severity, finding.Repository, finding.Checker,
+			finding.Issue.Message, diffLocation(finding.Issue))
+	}
+	printf("Resolved issues: %d\n", len(d.ResolvedIssues))
+	for _, finding := range d.ResolvedIssues {
+		printf("  - [%s] %s/%s: %s%s\n", finding.Issue.Severity, finding.Repository, finding.Checker,
+			finding.Issue.Message, diffLocation(finding.Issue))
+	}
+
+	printf("Score changes: %d\n", len(d.Repositories))
+	for _, repo := range d.Repositories {
+		printf("  %s: %s\n", repo.Repository, scoreChange(repo.OldScore, repo.NewScore))
+		for _, checker := range repo.Checkers {
+			printf("    %s: %s\n", checker.Checker, scoreChange(checker.OldScore, checker.NewScore))
+		}
+	}
+	return err
+}
+
+// fingerprintedFinding is a finding with its fingerprint
+type fingerprintedFinding struct {
+	fingerprint string
+	finding     DiffIssue
+}
+
+// fingerprintFindings lists the findings of a run in report order
+func fingerprintFindings(result core.WorkflowResult) []fingerprintedFinding {
+	var findings []fingerprintedFinding
+	for _, repoResult := range result.RepositoryResults {
+		for _, checkResult := range repoResult.CheckResults {
+			for _, issue := range checkResult.Issues {
+				findings = append(findings, fingerprintedFinding{
+					fingerprint: FindingFingerprint(repoResult.Repository, checkResult.ID, issue),
+					finding:     DiffIssue{Repository: repoResult.Repository.Name, Checker: checkResult.ID, Issue: issue},
+				})
+			}
+		}
+	}
+	return findings
+}
+
+// unmatchedFindings returns the findings with no counterpart in others. Equal
+// fingerprints are matched one to one, so a second copy of a finding is new.
+func unmatchedFindings(findings, others []fingerprintedFinding) []DiffIssue {
+	available := make(map[string]int)
+	for _, other := range others {
+		available[other.fingerprint]++
+	}
+
+	unmatched := []DiffIssue{}
+	for _, f := range findings {
+		if available[f.fingerprint] > 0 {
+			available[f.fingerprint]--
+			continue
+		}
+		unmatched = append(unmatched, f.finding)
+	}
+	return unmatched
+}
+
+// repositoriesByName indexes a run's repository results
+func repositoriesByName(result core.WorkflowResult) map[string]core.RepositoryResult {
+	repos := make(map[string]core.RepositoryResult, len(result.RepositoryResults))
+	for _, repoResult := range result.RepositoryResults {
+		repos[repoResult.Repository.Name] = repoResult
+	}
+	return repos
+}
+
+// checksByID indexes a repository's check results
+func checksByID(repoResult core.RepositoryResult) map[string]core.CheckResult {
+	checks := make(map[string]core.CheckResult, len(repoResult.CheckResults))
+	for _, checkResult := range repoResult.CheckResults {
+		checks[checkResult.ID] = checkResult
+	}
+	return checks
+}
+
+// unionKeys returns the keys of both maps in sorted order
+func unionKeys[V any](a, b map[string]V) []string {
+	keys := make([]string, 0, len(a)+len(b))
+	for key := range a {
+		keys = append(keys, key)
+	}
+	for key := range b {
+		if _, ok := a[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// sameScore reports whether two optional scores are equal
+func sameScore(a, b *int) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
+
+// scoreChange renders a score change such as "80 -> 65 (-15)"
+func scoreChange(oldScore, newScore *int) string {
+	switch {
+	case oldScore == nil:
+		return fmt.Sprintf("added (%d)", *newScore)
+	case newScore == nil:
+		return fmt.Sprintf("removed (was %d)", *oldScore)
+	default:
+		return fmt.Sprintf("%d -> %d (%+d)", *oldScore, *newScore, *newScore-*oldScore)
+	}
+}
+
+// diffLocation renders an issue's location as " (file:line)"
+func diffLocation(issue core.Issue) string {
+	if issue.Location == nil || issue.Location.File == "" {
+		return ""
+	}
+	if issue.Location.Line > 0 {
+		return fmt.Sprintf(" (%s:%d)", issue.Location.File, issue.Location.Line)
+	}
+	return fmt.Sprintf(" (%s)", issue.Location.File)
+}
+
+func intPtr(v int) *int {
+	return &v
+}
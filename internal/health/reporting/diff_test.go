@@ -0,0 +1,97 @@
+package reporting
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/codcod/repos/internal/core"
+)
+
+func diffTestResult(score int, checks ...core.CheckResult) core.WorkflowResult {
+	return core.WorkflowResult{RepositoryResults: []core.RepositoryResult{{
+		Repository:   core.Repository{Name: "api", Path: "/repos/api"},
+		Score:        score,
+		CheckResults: checks,
+	}}}
+}
+
+func TestDiffResults(t *testing.T) {
+	debt := core.Issue{Severity: core.SeverityLow, Message: "main.go contains 4 markers", Location: &core.Location{File: "main.go", Line: 10}}
+	movedDebt := core.Issue{Severity: core.SeverityLow, Message: "main.go contains 6 markers", Location: &core.Location{File: "main.go", Line: 30}}
+	license := core.Issue{Severity: core.SeverityMedium, Message: "No LICENSE file"}
+	vuln := core.Issue{Severity: core.SeverityCritical, Message: "CVE-2024-1234 in libfoo", Location: &core.Location{File: "go.mod"}}
+
+	oldResult := diffTestResult(80,
+		core.CheckResult{ID: "tech-debt", Score: 90, Issues: []core.Issue{debt}},
+		core.CheckResult{ID: "license-check", Score: 50, Issues: []core.Issue{license}},
+		core.CheckResult{ID: "git-status", Score: 100},
+	)
+	newResult := diffTestResult(70,
+		core.CheckResult{ID: "tech-debt", Score: 90, Issues: []core.Issue{movedDebt}},
+		core.CheckResult{ID: "license-check", Score: 100},
+		core.CheckResult{ID: "vulnerability-scan", Score: 0, Issues: []core.Issue{vuln}},
+		core.CheckResult{ID: "git-status", Score: 100},
+	)
+
+	diff := DiffResults(oldResult, newResult)
+
+	if len(diff.NewIssues) != 1 || diff.NewIssues[0].Checker != "vulnerability-scan" {
+		t.Errorf("Expected only the vulnerability to be new, got %+v", diff.NewIssues)
+	}
+	if len(diff.ResolvedIssues) != 1 || diff.ResolvedIssues[0].Checker != "license-check" {
+		t.Errorf("Expected only the license issue to be resolved, got %+v", diff.ResolvedIssues)
+	}
+	if diff.NewCriticalIssues() != 1 || diff.ExitCode() != 2 {
+		t.Errorf("Expected one new critical issue and exit code 2, got %d and %d", diff.NewCriticalIssues(), diff.ExitCode())
+	}
+
+	var out bytes.Buffer
+	if err := diff.Write(&out); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+	for _, want := range []string{
+		"  + [critical] api/vulnerability-scan: CVE-2024-1234 in libfoo (go.mod)\n",
+		"  - [medium] api/license-check: No LICENSE file\n",
+		"  api: 80 -> 70 (-10)\n",
+		"    license-check: 50 -> 100 (+50)\n",
+		"    vulnerability-scan: added (0)\n",
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("Expected output to contain %q, got:\n%s", want, out.String())
+		}
+	}
+	if strings.Contains(out.String(), "git-status") || strings.Contains(out.String(), "tech-debt") {
+		t.Errorf("Expected unchanged checkers to be left out, got:\n%s", out.String())
+	}
+
+	if unchanged := DiffResults(newResult, newResult); len(unchanged.NewIssues) != 0 || len(unchanged.Repositories) != 0 || unchanged.ExitCode() != 0 {
+		t.Errorf("Expected no differences between identical results, got %+v", unchanged)
+	}
+}
+
+func TestLoadWorkflowResult(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "result.json")
+	file, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := WriteJSON(file, outputTestResult()); err != nil {
+		t.Fatal(err)
+	}
+	_ = file.Close()
+
+	result, err := LoadWorkflowResult(path)
+	if err != nil {
+		t.Fatalf("LoadWorkflowResult failed: %v", err)
+	}
+	if len(result.RepositoryResults) != len(outputTestResult().RepositoryResults) {
+		t.Errorf("Expected the written repositories back, got %+v", result)
+	}
+
+	if _, err := LoadWorkflowResult(filepath.Join(t.TempDir(), "missing.json")); err == nil {
+		t.Error("Expected an error for a missing file")
+	}
+}
@@ -24,7 +24,7 @@ Create a formatter and display results:
 	formatter.DisplayResults(workflowResult)
 
 	// Get appropriate exit code for the shell
-	exitCode := reporting.ExitCode(workflowResult)
+	exitCode := reporting.ExitCode(workflowResult, reporting.ExitPolicy{})
 	os.Exit(exitCode)
 
 # Output Formats
@@ -0,0 +1,23 @@
+package reporting
+
+import (
+	"crypto/sha1" //nolint:gosec // Used for deduplication fingerprints, not security
+	"encoding/hex"
+	"regexp"
+	"strings"
+
+	"github.com/codcod/repos/internal/core"
+)
+
+// findingMarkerPrefix identifies the tickets and issues created by the health command
+const findingMarkerPrefix = "repos-health-"
+
+// findingMarkerPattern finds the markers in the text of tickets and issues
+var findingMarkerPattern = regexp.MustCompile(regexp.QuoteMeta(findingMarkerPrefix) + `[0-9a-f]{16}`)
+
+// findingMarker returns a stable fingerprint for a finding, which the reporters
+// that open tickets embed in them so re-runs do not create duplicates
+func findingMarker(repoName, checkerID string, issue core.Issue) string {
+	hash := sha1.Sum([]byte(strings.Join([]string{repoName, checkerID, issue.Type, issue.Message}, "\x00"))) //nolint:gosec // Not used for security
+	return findingMarkerPrefix + hex.EncodeToString(hash[:])[:16]
+}
@@ -2,9 +2,12 @@
 package reporting
 
 import (
+	"bytes"
 	"fmt"
+	"os"
+	"sort"
 	"strings"
-	"time"
+	"text/template"
 
 	"github.com/codcod/repos/internal/core"
 	"github.com/fatih/color"
@@ -14,84 +17,160 @@ import (
 type Formatter struct {
 	verbose             bool
 	ComplexityThreshold int // minimum complexity to show, default 10
+	color               bool
+	emoji               bool
+	quiet               bool
+	template            *template.Template
 }
 
-// NewFormatter creates a new result formatter
-func NewFormatter(verbose bool) *Formatter {
-	return &Formatter{
-		verbose:             verbose,
-		ComplexityThreshold: 10, // default threshold
+// FormatterOption configures a Formatter
+type FormatterOption func(*Formatter)
+
+// WithColor enables or disables ANSI colors. By default colors are used unless
+// NO_COLOR is set or stdout is not a terminal.
+func WithColor(enabled bool) FormatterOption {
+	return func(f *Formatter) {
+		f.color = enabled
 	}
 }
 
-// NewComplexityFormatterWithThreshold creates a formatter with a specific complexity threshold
-func NewComplexityFormatterWithThreshold(verbose bool, threshold int) *Formatter {
-	return &Formatter{
-		verbose:             verbose,
-		ComplexityThreshold: threshold,
+// WithEmoji enables or disables status emoji; without them statuses are shown
+// as text markers such as [OK] and [FAIL]. Emoji are enabled by default.
+func WithEmoji(enabled bool) FormatterOption {
+	return func(f *Formatter) {
+		f.emoji = enabled
 	}
 }
 
-// DisplayResults formats and displays the health analysis results
-func (f *Formatter) DisplayResults(result core.WorkflowResult) {
-	// Display each repository individually (removed summary)
-	f.displayRepositoryReports(result.RepositoryResults)
-
-	f.displayTiming(result)
+// WithQuiet shows only the repositories and checks that are not healthy,
+// followed by a one-line tally, so problems stand out in CI logs
+func WithQuiet(enabled bool) FormatterOption {
+	return func(f *Formatter) {
+		f.quiet = enabled
+	}
 }
 
-// displayRepositoryReports shows individual reports for each repository
-func (f *Formatter) displayRepositoryReports(results []core.RepositoryResult) {
-	color.Green("=== Repository Health Reports ===")
-
-	for i, result := range results {
-		if i > 0 {
-			fmt.Println() // Add spacing between repositories
-		}
-		f.displayIndividualRepositoryReport(result)
+// WithTemplate lays out the report with a console template, as returned by
+// ConsoleTemplate, instead of DefaultConsoleTemplate. A nil template keeps the
+// default. Quiet output is not templated.
+func WithTemplate(tmpl *template.Template) FormatterOption {
+	return func(f *Formatter) {
+		f.template = tmpl
 	}
 }
 
-// displayIndividualRepositoryReport shows a comprehensive report for a single repository
-func (f *Formatter) displayIndividualRepositoryReport(result core.RepositoryResult) {
-	// Repository header in red (removed separator line)
-	color.Red("Repository: %s", result.Repository.Name)
+// NewFormatter creates a new result formatter
+func NewFormatter(verbose bool, opts ...FormatterOption) *Formatter {
+	return NewComplexityFormatterWithThreshold(verbose, 10, opts...) // default threshold
+}
 
-	// Language - handle empty case
-	language := result.Repository.Language
-	if language == "" {
-		language = "Unknown"
+// NewComplexityFormatterWithThreshold creates a formatter with a specific complexity threshold
+func NewComplexityFormatterWithThreshold(verbose bool, threshold int, opts ...FormatterOption) *Formatter {
+	f := &Formatter{
+		verbose:             verbose,
+		ComplexityThreshold: threshold,
+		color:               !color.NoColor,
+		emoji:               true,
+	}
+	for _, opt := range opts {
+		opt(f)
 	}
-	fmt.Printf("Language: %s\n", language)
+	return f
+}
 
-	// Status with emoji and score
-	statusEmoji := f.getStatusEmoji(result.Status)
-	statusText := f.getStatusText(result.Status)
+// paint returns a printer for the attributes that honors the color setting
+func (f *Formatter) paint(attributes ...color.Attribute) *color.Color {
+	c := color.New(attributes...)
+	if f.color {
+		c.EnableColor()
+	} else {
+		c.DisableColor()
+	}
+	return c
+}
 
-	// Calculate proper max score if it's 0
-	maxScore := result.MaxScore
-	if maxScore == 0 {
-		maxScore = 100 // Default to 100 if not set
+// DisplayResults formats and displays the health analysis results
+func (f *Formatter) DisplayResults(result core.WorkflowResult) {
+	if f.quiet {
+		if f.displayProblems(result.RepositoryResults) {
+			fmt.Println()
+		}
+		f.displayTally(result.RepositoryResults)
+		f.displayBaseline(result)
+		return
 	}
 
-	fmt.Printf("Status: %s %s (%d/%d)\n", statusEmoji, statusText, result.Score, maxScore)
+	f.displayReport(result)
+}
 
-	// Add blank line before health checks
-	fmt.Println()
+// displayReport renders the report with the console template
+func (f *Formatter) displayReport(result core.WorkflowResult) {
+	tmpl := f.template
+	if tmpl == nil {
+		tmpl = defaultConsoleTemplate
+	}
+	tmpl, err := tmpl.Clone()
+	if err == nil {
+		var out bytes.Buffer
+		err = tmpl.Funcs(f.templateFuncs()).Execute(&out, ConsoleReport{WorkflowResult: result, Verbose: f.verbose})
+		fmt.Print(out.String())
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error rendering console template: %v\n", err)
+	}
+}
 
-	// Health checks results
-	if len(result.CheckResults) > 0 {
-		fmt.Println("Health checks results")
+// displayProblems shows each repository that is not healthy with only its
+// checks that are not healthy. It reports whether anything was shown.
+func (f *Formatter) displayProblems(results []core.RepositoryResult) bool {
+	first := true
+	for _, result := range results {
+		var failing []core.CheckResult
 		for _, checkResult := range result.CheckResults {
+			if checkResult.Status != core.StatusHealthy {
+				failing = append(failing, checkResult)
+			}
+		}
+		if result.Status == core.StatusHealthy && len(failing) == 0 {
+			continue
+		}
+
+		if !first {
+			fmt.Println()
+		}
+		first = false
+		_, _ = f.paint(color.FgRed).Printf("Repository: %s %s %s (%d/100)\n", result.Repository.Name,
+			f.getStatusEmoji(result.Status), f.getStatusText(result.Status), result.Score)
+		for _, checkResult := range failing {
 			f.displayCheckResultSimple(checkResult)
 		}
 	}
+	return !first
+}
 
-	// Add blank line before complexity section
-	fmt.Println()
+// displayTally prints one line counting repositories by status
+func (f *Formatter) displayTally(results []core.RepositoryResult) {
+	counts := make(map[core.HealthStatus]int)
+	failingChecks := 0
+	for _, result := range results {
+		counts[result.Status]++
+		for _, checkResult := range result.CheckResults {
+			if checkResult.Status != core.StatusHealthy {
+				failingChecks++
+			}
+		}
+	}
 
-	// Cyclomatic complexity
-	f.displayCyclomaticComplexitySimple(result)
+	c := f.paint(color.FgGreen)
+	if counts[core.StatusCritical]+counts[core.StatusErrored] > 0 {
+		c = f.paint(color.FgRed)
+	} else if counts[core.StatusWarning] > 0 || failingChecks > 0 {
+		c = f.paint(color.FgYellow)
+	}
+	_, _ = c.Printf("%d %s: %d healthy, %d warning, %d critical, %d errored; %d %s attention\n",
+		len(results), pluralize(len(results), "repository", "repositories"),
+		counts[core.StatusHealthy], counts[core.StatusWarning], counts[core.StatusCritical], counts[core.StatusErrored],
+		failingChecks, pluralize(failingChecks, "check needs", "checks need"))
 }
 
 // getStatusText returns a simple text representation of the status
@@ -103,19 +182,25 @@ func (f *Formatter) getStatusText(status core.HealthStatus) string {
 		return "Warning"
 	case core.StatusCritical:
 		return "Critical"
+	case core.StatusErrored:
+		return "Errored"
 	default:
 		return "Unknown"
 	}
 }
 
-// getStatusEmoji returns the emoji for the overall status
+// getStatusEmoji returns the emoji for the overall status, or a text marker
+// when emoji are disabled
 func (f *Formatter) getStatusEmoji(status core.HealthStatus) string {
+	if !f.emoji {
+		return statusMarker(status)
+	}
 	switch status {
 	case core.StatusHealthy:
 		return "✅"
 	case core.StatusWarning:
 		return "⚠️"
-	case core.StatusCritical:
+	case core.StatusCritical, core.StatusErrored:
 		return "❌"
 	default:
 		return "❓"
@@ -126,27 +211,44 @@ func (f *Formatter) getStatusEmoji(status core.HealthStatus) string {
 func (f *Formatter) displayCheckResultSimple(result core.CheckResult) {
 	emoji := f.getCheckStatusEmoji(result.Status)
 
+	// Checks of a monorepo's sub-projects are labeled with the sub-project
+	name := result.Name
+	if subproject := result.Metadata["subproject"]; subproject != "" {
+		name = fmt.Sprintf("%s [%s]", result.Name, subproject)
+	}
+
+	// A checker that could not run has no score, only the reason it failed
+	if result.Status == core.StatusErrored {
+		fmt.Printf("%s %s (%s): errored\n", emoji, name, result.Category)
+		for _, checkErr := range result.Errors {
+			_, _ = f.paint(color.FgHiBlack).Printf("  - %s\n", checkErr.Message)
+		}
+		return
+	}
+
 	// Check if this is a warning about tool not being available
 	scoreDisplay := fmt.Sprintf("%d", result.Score)
 	if result.Status == core.StatusWarning && f.isToolUnavailableWarning(result) {
 		scoreDisplay = "unknown"
 	}
 
-	fmt.Printf("%s %s (%s): %s\n", emoji, result.Name, result.Category, scoreDisplay)
+	fmt.Printf("%s %s (%s): %s\n", emoji, name, result.Category, scoreDisplay)
 
 	// Show top 3 issues in grey
-	if len(result.Issues) > 0 {
-		limit := 3
-		if len(result.Issues) < limit {
-			limit = len(result.Issues)
-		}
+	for _, issue := range result.Issues[:min(3, len(result.Issues))] {
+		_, _ = f.paint(color.FgHiBlack).Printf("  - %s%s\n", issue.Message, issueSuffix(issue))
+	}
+}
 
-		for i := 0; i < limit; i++ {
-			issue := result.Issues[i]
-			// Print issues in grey color
-			_, _ = color.New(color.FgHiBlack).Printf("  - %s\n", issue.Message)
-		}
+// issueSuffix marks known findings and advisory issues in the simple format
+func issueSuffix(issue core.Issue) string {
+	if IsKnownFinding(issue) {
+		return " (known)"
+	}
+	if issue.Severity.Advisory() {
+		return " (info)"
 	}
+	return ""
 }
 
 // isToolUnavailableWarning checks if a warning is about a tool not being available
@@ -168,38 +270,27 @@ func (f *Formatter) isToolUnavailableWarning(result core.CheckResult) bool {
 	return false
 }
 
-// displayCyclomaticComplexitySimple shows complexity issues in the simple format
-func (f *Formatter) displayCyclomaticComplexitySimple(result core.RepositoryResult) {
-	if result.AnalysisResult == nil {
-		return
-	}
-
-	analysis := *result.AnalysisResult
-
-	// Only show if there are functions with complexity issues
-	complexFunctions := f.getComplexFunctions(analysis.Functions)
-
-	if len(complexFunctions) == 0 {
-		return
-	}
-
-	fmt.Println("Cyclomatic complexity")
+// maxLongestFunctions is how many of the longest functions reports list
+const maxLongestFunctions = 5
 
-	// Show top complex functions (limit to reasonable number)
-	limit := 10
-	if len(complexFunctions) < limit {
-		limit = len(complexFunctions)
+// longestFunctions returns up to n functions of known length, longest first
+func longestFunctions(functions []core.FunctionInfo, n int) []core.FunctionInfo {
+	var known []core.FunctionInfo
+	for _, fn := range functions {
+		if fn.Lines() > 0 {
+			known = append(known, fn)
+		}
 	}
+	sort.SliceStable(known, func(i, j int) bool { return known[i].Lines() > known[j].Lines() })
+	return known[:min(n, len(known))]
+}
 
-	for i := 0; i < limit; i++ {
-		fn := complexFunctions[i]
-		// Make file path relative to repository
-		relativePath := f.getRelativePath(fn.File, result.Repository.Path)
-		// Format: path:line:column: 'function name' is too complex (score)
-		// Note: We don't have column info, so using 0
-		fmt.Printf("  - %s:%d:0: '%s' is too complex (%d)\n",
-			relativePath, fn.Line, fn.Name, fn.Complexity)
+// pluralize returns the singular or plural form based on count
+func pluralize(count int, singular, plural string) string {
+	if count == 1 {
+		return singular
 	}
+	return plural
 }
 
 // getRelativePath returns a path relative to the repository root
@@ -234,10 +325,14 @@ func (f *Formatter) getComplexFunctions(functions []core.FunctionInfo) []core.Fu
 	return complexFunctions
 }
 
-// getCheckStatusEmoji returns the appropriate emoji for a check status
+// getCheckStatusEmoji returns the appropriate emoji for a check status, or a
+// text marker when emoji are disabled
 func (f *Formatter) getCheckStatusEmoji(status core.HealthStatus) string {
+	if !f.emoji {
+		return statusMarker(status)
+	}
 	switch status {
-	case core.StatusCritical:
+	case core.StatusCritical, core.StatusErrored:
 		return "❌"
 	case core.StatusWarning:
 		return "⚠️"
@@ -246,6 +341,20 @@ func (f *Formatter) getCheckStatusEmoji(status core.HealthStatus) string {
 	}
 }
 
+// statusMarker returns a plain text marker for a status
+func statusMarker(status core.HealthStatus) string {
+	switch status {
+	case core.StatusHealthy:
+		return "[OK]"
+	case core.StatusWarning:
+		return "[WARN]"
+	case core.StatusCritical, core.StatusErrored:
+		return "[FAIL]"
+	default:
+		return "[?]"
+	}
+}
+
 // Helper functions for the new display format
 
 // sortFunctionsByComplexity sorts functions by complexity in descending order
@@ -259,23 +368,177 @@ func (f *Formatter) sortFunctionsByComplexity(functions []core.FunctionInfo) {
 	}
 }
 
-// displayTiming shows execution timing information
-func (f *Formatter) displayTiming(result core.WorkflowResult) {
-	if !f.verbose {
+// displayBaseline summarizes how findings compared against the baseline, if one was used
+func (f *Formatter) displayBaseline(result core.WorkflowResult) {
+	baseline := result.Summary.Baseline
+	if baseline == nil {
+		return
+	}
+
+	fmt.Println()
+	if baseline.NewFindings > 0 {
+		_, _ = f.paint(color.FgRed).Printf("Baseline: %d new %s, %d known\n", baseline.NewFindings,
+			pluralize(baseline.NewFindings, "finding", "findings"), baseline.KnownFindings)
 		return
 	}
+	_, _ = f.paint(color.FgGreen).Printf("Baseline: no new findings (%d known)\n", baseline.KnownFindings)
+}
+
+// Exit codes of a health run across all of its repositories
+const (
+	// ExitHealthy means every repository passed the failure threshold
+	ExitHealthy = 0
+	// ExitFindings means a repository has findings at or above the threshold
+	ExitFindings = 2
+	// ExitErrored means a checker could not run, or a repository failed
+	// before its checks ran, so the results are incomplete
+	ExitErrored = 3
+)
+
+// ExitPolicy decides which results fail a run and the exit code of each
+// outcome. The zero value fails on critical checks with the default codes.
+type ExitPolicy struct {
+	// FailOn holds the fail_on level of categories. The checks of a category
+	// with a level fail the run from that status on, replacing the default of
+	// failing on critical checks.
+	FailOn map[string]core.FailOn
+	// Gate fails the run when a repository or category scores below its minimum
+	Gate core.ScoreGate
+	// Codes replaces the exit code of each outcome; nil uses core.DefaultExitCodes
+	Codes *core.ExitCodes
+}
+
+// ExitCode determines the exit code of a run. Errored checks and repositories
+// take precedence, since they leave results missing, unless the category of an
+// errored check never fails; repositories that failed before any check ran
+// always do. Otherwise a repository failing its fail_on levels or falling short
+// of the score gate fails the run; when a baseline was applied, only findings
+// missing from it do in place of the fail_on levels. A run that passes while a
+// repository has warnings is the warning outcome, unless a baseline was applied.
+func ExitCode(result core.WorkflowResult, policy ExitPolicy) int {
+	codes := core.DefaultExitCodes
+	if policy.Codes != nil {
+		codes = *policy.Codes
+	}
+
+	switch outcome(result, policy) {
+	case ExitErrored:
+		return codes.Errored
+	case ExitFindings:
+		return codes.Critical
+	}
+	if result.Summary.Baseline == nil {
+		for _, repo := range result.RepositoryResults {
+			if repo.Status == core.StatusWarning {
+				return codes.Warning
+			}
+		}
+	}
+	return codes.Healthy
+}
 
-	fmt.Println("=== Timing Information ===")
-	if !result.StartTime.IsZero() && !result.EndTime.IsZero() {
-		duration := result.EndTime.Sub(result.StartTime)
-		fmt.Printf("Total execution time: %v\n", duration.Round(time.Millisecond))
+// outcome returns ExitErrored, ExitFindings or ExitHealthy for a run
+func outcome(result core.WorkflowResult, policy ExitPolicy) int {
+	if hasErrors(result, policy.FailOn) {
+		return ExitErrored
+	}
+	if findingsFail(result, policy.FailOn) || len(ScoreShortfalls(result, policy.Gate)) > 0 {
+		return ExitFindings
 	}
+	return ExitHealthy
 }
 
-// ExitCode determines the appropriate exit code based on results
-func ExitCode(result core.WorkflowResult) int {
-	if result.Summary.FailedRepos > 0 {
-		return 2 // Critical issues found
+// findingsFail reports whether the findings of a run fail it: new findings
+// when a baseline was applied, and otherwise a repository that fails its
+// fail_on levels, or any failed repository without levels
+func findingsFail(result core.WorkflowResult, failOn map[string]core.FailOn) bool {
+	if result.Summary.Baseline != nil {
+		return result.Summary.Baseline.NewFindings > 0
 	}
-	return 0 // Success
+	if len(failOn) == 0 {
+		return result.Summary.FailedRepos > 0
+	}
+	for _, repo := range result.RepositoryResults {
+		if repositoryFails(repo, failOn) {
+			return true
+		}
+	}
+	return false
+}
+
+// ScoreShortfall is a repository, or one of its categories, scoring below the
+// minimum the score gate sets for it
+type ScoreShortfall struct {
+	Repository string
+	Category   string // Empty for the overall score
+	Score      int
+	MinScore   int
+}
+
+// Gap is how many points the score falls short by
+func (s ScoreShortfall) Gap() int {
+	return s.MinScore - s.Score
+}
+
+// ScoreShortfalls returns every repository and category scoring below the
+// gate, in the order of the results. Repositories that failed before their
+// checks ran have no score to judge; they already fail the run as errored.
+func ScoreShortfalls(result core.WorkflowResult, gate core.ScoreGate) []ScoreShortfall {
+	var shortfalls []ScoreShortfall
+	for _, repo := range result.RepositoryResults {
+		if repo.Error != "" || len(repo.CheckResults) == 0 {
+			continue
+		}
+		name := repo.Repository.Name
+		if minScore := gate.MinScoreFor(name); minScore > 0 && repo.Score < minScore {
+			shortfalls = append(shortfalls, ScoreShortfall{Repository: name, Score: repo.Score, MinScore: minScore})
+		}
+		for _, category := range repo.CategoryScores {
+			if minScore := gate.Categories[category.Category]; minScore > 0 && category.Score < minScore {
+				shortfalls = append(shortfalls, ScoreShortfall{
+					Repository: name,
+					Category:   category.Category,
+					Score:      category.Score,
+					MinScore:   minScore,
+				})
+			}
+		}
+	}
+	return shortfalls
+}
+
+// hasErrors reports whether a check errored, outside categories whose fail_on
+// is never, or a repository failed before its checks ran
+func hasErrors(result core.WorkflowResult, failOn map[string]core.FailOn) bool {
+	if len(result.RepositoryResults) == 0 {
+		return result.Summary.ErroredChecks > 0
+	}
+	for _, repo := range result.RepositoryResults {
+		if repo.Error != "" || repo.Status == core.StatusErrored {
+			return true
+		}
+		for _, check := range repo.CheckResults {
+			if check.Status == core.StatusErrored && failOn[check.Category] != core.FailOnNever {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// repositoryFails reports whether a repository fails the run under the fail_on levels
+func repositoryFails(repo core.RepositoryResult, failOn map[string]core.FailOn) bool {
+	if repo.Error != "" || len(repo.CheckResults) == 0 {
+		return repo.Status != core.StatusHealthy && repo.Status != core.StatusWarning
+	}
+	for _, check := range repo.CheckResults {
+		level, ok := failOn[check.Category]
+		if !ok {
+			level = core.FailOnCritical
+		}
+		if level.Fails(check.Status) {
+			return true
+		}
+	}
+	return false
 }
@@ -1,10 +1,14 @@
 package reporting
 
 import (
+	"io"
+	"os"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/codcod/repos/internal/core"
+	"github.com/fatih/color"
 )
 
 func TestNewFormatter(t *testing.T) {
@@ -24,6 +28,55 @@ func TestNewFormatter(t *testing.T) {
 	}
 }
 
+// captureStdout returns what fn prints to stdout, including colored output
+func captureStdout(t *testing.T, fn func()) string {
+	t.Helper()
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdout, colorOutput := os.Stdout, color.Output
+	os.Stdout, color.Output = w, w
+	defer func() { os.Stdout, color.Output = stdout, colorOutput }()
+
+	fn()
+	_ = w.Close()
+	out, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(out)
+}
+
+func TestFormatter_WithoutColorAndEmoji(t *testing.T) {
+	result := core.WorkflowResult{RepositoryResults: []core.RepositoryResult{{
+		Repository: core.Repository{Name: "repo"},
+		Status:     core.StatusWarning,
+		Score:      70,
+		CheckResults: []core.CheckResult{{
+			Name: "README", Category: "docs", Status: core.StatusWarning, Score: 70,
+			Issues: []core.Issue{{Message: "missing usage section"}},
+		}},
+	}}}
+
+	plain := captureStdout(t, func() {
+		NewFormatter(false, WithColor(false), WithEmoji(false)).DisplayResults(result)
+	})
+	if strings.Contains(plain, "\x1b[") {
+		t.Errorf("Expected no ANSI escape codes, got %q", plain)
+	}
+	if strings.Contains(plain, "⚠️") || !strings.Contains(plain, "Status: [WARN] Warning (70/100)") {
+		t.Errorf("Expected text status markers, got %q", plain)
+	}
+
+	colored := captureStdout(t, func() {
+		NewFormatter(false, WithColor(true)).DisplayResults(result)
+	})
+	if !strings.Contains(colored, "\x1b[") || !strings.Contains(colored, "⚠️") {
+		t.Errorf("Expected colors and emoji, got %q", colored)
+	}
+}
+
 func TestExitCode(t *testing.T) {
 	// Test successful result
 	successResult := core.WorkflowResult{
@@ -32,8 +85,8 @@ func TestExitCode(t *testing.T) {
 			FailedRepos:     0,
 		},
 	}
-	if ExitCode(successResult) != 0 {
-		t.Errorf("Expected exit code 0 for successful result, got %d", ExitCode(successResult))
+	if ExitCode(successResult, ExitPolicy{}) != 0 {
+		t.Errorf("Expected exit code 0 for successful result, got %d", ExitCode(successResult, ExitPolicy{}))
 	}
 
 	// Test failed result
@@ -43,8 +96,192 @@ func TestExitCode(t *testing.T) {
 			FailedRepos:     2,
 		},
 	}
-	if ExitCode(failedResult) != 2 {
-		t.Errorf("Expected exit code 2 for failed result, got %d", ExitCode(failedResult))
+	if ExitCode(failedResult, ExitPolicy{}) != 2 {
+		t.Errorf("Expected exit code 2 for failed result, got %d", ExitCode(failedResult, ExitPolicy{}))
+	}
+}
+
+func TestExitCode_FailOn(t *testing.T) {
+	result := core.WorkflowResult{
+		Summary: core.WorkflowSummary{SuccessfulRepos: 1, FailedRepos: 1},
+		RepositoryResults: []core.RepositoryResult{
+			{Status: core.StatusWarning, CheckResults: []core.CheckResult{
+				{Category: "security", Status: core.StatusWarning},
+			}},
+			{Status: core.StatusCritical, CheckResults: []core.CheckResult{
+				{Category: "documentation", Status: core.StatusCritical},
+				{Category: "quality", Status: core.StatusWarning},
+			}},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		failOn   map[string]core.FailOn
+		expected int
+	}{
+		{"default levels", nil, 2},
+		{"security warnings fail", map[string]core.FailOn{"security": core.FailOnWarning, "documentation": core.FailOnNever}, 2},
+		{"documentation never fails", map[string]core.FailOn{"documentation": core.FailOnNever}, 0},
+		{"other categories keep critical", map[string]core.FailOn{"security": core.FailOnCritical, "documentation": core.FailOnNever}, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if code := ExitCode(result, ExitPolicy{FailOn: tt.failOn}); code != tt.expected {
+				t.Errorf("Expected exit code %d, got %d", tt.expected, code)
+			}
+		})
+	}
+
+	// A repository that failed before its checks ran always fails as errored
+	result.RepositoryResults = append(result.RepositoryResults, core.RepositoryResult{Status: core.StatusErrored, Error: "pre_check hook failed"})
+	if code := ExitCode(result, ExitPolicy{FailOn: map[string]core.FailOn{"documentation": core.FailOnNever}}); code != ExitErrored {
+		t.Errorf("Expected exit code %d for a failed repository, got %d", ExitErrored, code)
+	}
+}
+
+func TestExitCode_ScoreGate(t *testing.T) {
+	result := core.WorkflowResult{
+		Summary: core.WorkflowSummary{SuccessfulRepos: 2},
+		RepositoryResults: []core.RepositoryResult{
+			{
+				Repository:     core.Repository{Name: "api"},
+				Status:         core.StatusWarning,
+				Score:          62,
+				CategoryScores: []core.CategoryScore{{Category: "security", Score: 50}, {Category: "docs", Score: 90}},
+				CheckResults:   []core.CheckResult{{Category: "security", Status: core.StatusWarning}},
+			},
+			{
+				Repository:   core.Repository{Name: "web"},
+				Status:       core.StatusHealthy,
+				Score:        95,
+				CheckResults: []core.CheckResult{{Category: "security", Status: core.StatusHealthy}},
+			},
+		},
+	}
+
+	gate := core.ScoreGate{MinScore: 70, Categories: map[string]int{"security": 60}}
+	shortfalls := ScoreShortfalls(result, gate)
+	expected := []ScoreShortfall{
+		{Repository: "api", Score: 62, MinScore: 70},
+		{Repository: "api", Category: "security", Score: 50, MinScore: 60},
+	}
+	if len(shortfalls) != len(expected) || shortfalls[0] != expected[0] || shortfalls[1] != expected[1] {
+		t.Fatalf("Expected %+v, got %+v", expected, shortfalls)
+	}
+	if shortfalls[0].Gap() != 8 {
+		t.Errorf("Expected api to fall 8 points short, got %d", shortfalls[0].Gap())
+	}
+
+	if code := ExitCode(result, ExitPolicy{Gate: gate}); code != ExitFindings {
+		t.Errorf("Expected a shortfall to fail the run, got %d", code)
+	}
+	if code := ExitCode(result, ExitPolicy{Gate: core.ScoreGate{MinScore: 70, Repositories: map[string]int{"api": 60}}}); code != ExitHealthy {
+		t.Errorf("Expected the repository's own minimum to pass, got %d", code)
+	}
+	// Either condition fails the run
+	if code := ExitCode(result, ExitPolicy{FailOn: map[string]core.FailOn{"security": core.FailOnWarning}}); code != ExitFindings {
+		t.Errorf("Expected fail_on to fail the run without a gate, got %d", code)
+	}
+}
+
+func TestExitCode_Codes(t *testing.T) {
+	healthy := core.RepositoryResult{Status: core.StatusHealthy, CheckResults: []core.CheckResult{
+		{Category: "security", Status: core.StatusHealthy},
+	}}
+	warning := core.RepositoryResult{Status: core.StatusWarning, CheckResults: []core.CheckResult{
+		{Category: "quality", Status: core.StatusWarning},
+	}}
+	critical := core.RepositoryResult{Status: core.StatusCritical, CheckResults: []core.CheckResult{
+		{Category: "security", Status: core.StatusCritical},
+	}}
+	errored := core.RepositoryResult{Status: core.StatusCritical, CheckResults: []core.CheckResult{
+		{Category: "dependencies", Status: core.StatusErrored},
+	}}
+	codes := core.ExitCodes{Healthy: 0, Warning: 1, Critical: 2, Errored: 3}
+
+	tests := []struct {
+		name     string
+		repos    []core.RepositoryResult
+		baseline *core.BaselineSummary
+		codes    core.ExitCodes
+		expected int
+	}{
+		{name: "healthy", repos: []core.RepositoryResult{healthy}, codes: codes, expected: 0},
+		{name: "warning", repos: []core.RepositoryResult{healthy, warning}, codes: codes, expected: 1},
+		{name: "critical", repos: []core.RepositoryResult{warning, critical}, codes: codes, expected: 2},
+		{name: "errored", repos: []core.RepositoryResult{critical, errored}, codes: codes, expected: 3},
+		{name: "warnings pass by default", repos: []core.RepositoryResult{warning}, codes: core.DefaultExitCodes, expected: ExitHealthy},
+		{name: "baseline without new findings", repos: []core.RepositoryResult{warning},
+			baseline: &core.BaselineSummary{KnownFindings: 1}, codes: codes, expected: 0},
+		{name: "custom codes", repos: []core.RepositoryResult{errored},
+			codes: core.ExitCodes{Critical: 10, Errored: 20}, expected: 20},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := core.WorkflowResult{RepositoryResults: tt.repos}
+			result.Summary.Baseline = tt.baseline
+			for _, repo := range tt.repos {
+				if repo.Status != core.StatusHealthy && repo.Status != core.StatusWarning {
+					result.Summary.FailedRepos++
+				}
+			}
+			if code := ExitCode(result, ExitPolicy{Codes: &tt.codes}); code != tt.expected {
+				t.Errorf("Expected exit code %d, got %d", tt.expected, code)
+			}
+		})
+	}
+}
+
+func TestExitCode_MixedRepositories(t *testing.T) {
+	healthy := core.RepositoryResult{Status: core.StatusHealthy, CheckResults: []core.CheckResult{
+		{Category: "security", Status: core.StatusHealthy},
+	}}
+	warning := core.RepositoryResult{Status: core.StatusWarning, CheckResults: []core.CheckResult{
+		{Category: "quality", Status: core.StatusWarning},
+	}}
+	critical := core.RepositoryResult{Status: core.StatusCritical, CheckResults: []core.CheckResult{
+		{Category: "security", Status: core.StatusCritical},
+	}}
+	errored := core.RepositoryResult{Status: core.StatusCritical, CheckResults: []core.CheckResult{
+		{Category: "documentation", Status: core.StatusHealthy},
+		{Category: "dependencies", Status: core.StatusErrored},
+	}}
+
+	tests := []struct {
+		name     string
+		repos    []core.RepositoryResult
+		failOn   map[string]core.FailOn
+		baseline *core.BaselineSummary
+		expected int
+	}{
+		{name: "all pass", repos: []core.RepositoryResult{healthy, warning}, expected: ExitHealthy},
+		{name: "one repository with findings", repos: []core.RepositoryResult{healthy, warning, critical}, expected: ExitFindings},
+		{name: "findings and an errored check", repos: []core.RepositoryResult{critical, errored, healthy}, expected: ExitErrored},
+		{name: "errored check only", repos: []core.RepositoryResult{healthy, errored}, expected: ExitErrored},
+		{name: "warnings fail by category", repos: []core.RepositoryResult{healthy, warning},
+			failOn: map[string]core.FailOn{"quality": core.FailOnWarning}, expected: ExitFindings},
+		{name: "errored category that never fails", repos: []core.RepositoryResult{healthy, errored},
+			failOn: map[string]core.FailOn{"dependencies": core.FailOnNever}, expected: ExitHealthy},
+		{name: "errored check with a clean baseline", repos: []core.RepositoryResult{critical, errored},
+			baseline: &core.BaselineSummary{KnownFindings: 1}, expected: ExitErrored},
+		{name: "known findings with a baseline", repos: []core.RepositoryResult{healthy, critical},
+			baseline: &core.BaselineSummary{KnownFindings: 1}, expected: ExitHealthy},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := core.WorkflowResult{RepositoryResults: tt.repos}
+			result.Summary.Baseline = tt.baseline
+			for _, repo := range tt.repos {
+				if repo.Status != core.StatusHealthy && repo.Status != core.StatusWarning {
+					result.Summary.FailedRepos++
+				}
+			}
+			if code := ExitCode(result, ExitPolicy{FailOn: tt.failOn}); code != tt.expected {
+				t.Errorf("Expected exit code %d, got %d", tt.expected, code)
+			}
+		})
 	}
 }
 
@@ -292,3 +529,140 @@ func TestComplexityFunctions(t *testing.T) {
 		}
 	}
 }
+
+func TestFormatter_DisplayResults_AnalysisErrors(t *testing.T) {
+	formatter := NewFormatter(true)
+
+	workflowResult := core.WorkflowResult{
+		RepositoryResults: []core.RepositoryResult{
+			{
+				Repository: core.Repository{Name: "partial-repo", Path: "/path/to/partial-repo", Language: "go"},
+				Status:     core.StatusHealthy,
+				AnalysisResult: &core.AnalysisResult{
+					Language: "go",
+					Errors: []core.AnalysisError{
+						{Path: "/path/to/partial-repo/broken.go", Reason: "expected ')'"},
+						{Path: "/path/to/partial-repo/locked.go", Reason: "permission denied"},
+					},
+				},
+			},
+		},
+	}
+
+	// Test that analysis errors are reported without panicking
+	formatter.DisplayResults(workflowResult)
+
+	if got := pluralize(1, "file", "files"); got != "file" {
+		t.Errorf("Expected singular form, got %s", got)
+	}
+	if got := pluralize(2, "file", "files"); got != "files" {
+		t.Errorf("Expected plural form, got %s", got)
+	}
+}
+
+func TestFormatter_DisplaySummary(t *testing.T) {
+	ranking := []core.RepositoryRank{
+		{Rank: 1, Name: "web", Score: 95, Status: core.StatusHealthy},
+		{Rank: 2, Name: "legacy", Score: 30, Status: core.StatusCritical},
+	}
+	result := core.WorkflowResult{Summary: core.WorkflowSummary{
+		AverageScore: 62,
+		StatusCounts: map[core.HealthStatus]int{core.StatusHealthy: 1, core.StatusCritical: 1},
+		Ranking:      ranking,
+		Worst:        ranking[1:],
+	}}
+
+	out := captureStdout(t, func() {
+		NewFormatter(false, WithColor(false), WithEmoji(false)).DisplayResults(result)
+	})
+	for _, expected := range []string{
+		"Repositories: 2 (1 healthy, 0 warning, 1 critical, 0 errored)",
+		"Average score: 62/100",
+		"  1. [OK] web (95/100)",
+		"  2. [FAIL] legacy (30/100)",
+		"Lowest 1:\n  [FAIL] legacy (30/100)",
+	} {
+		if !strings.Contains(out, expected) {
+			t.Errorf("Expected %q in summary, got %q", expected, out)
+		}
+	}
+
+	result.Summary.Ranking = ranking[:1]
+	out = captureStdout(t, func() { NewFormatter(false).DisplayResults(result) })
+	if strings.Contains(out, "Summary") {
+		t.Errorf("Expected no summary for a single repository, got %q", out)
+	}
+}
+
+func TestFormatter_Quiet(t *testing.T) {
+	result := core.WorkflowResult{RepositoryResults: []core.RepositoryResult{
+		{
+			Repository: core.Repository{Name: "web"},
+			Status:     core.StatusHealthy,
+			Score:      100,
+			CheckResults: []core.CheckResult{
+				{Name: "Git Status", Category: "git", Status: core.StatusHealthy, Score: 100},
+			},
+		},
+		{
+			Repository: core.Repository{Name: "legacy"},
+			Status:     core.StatusCritical,
+			Score:      40,
+			CheckResults: []core.CheckResult{
+				{Name: "Git Status", Category: "git", Status: core.StatusHealthy, Score: 100},
+				{Name: "License", Category: "compliance", Status: core.StatusCritical, Score: 0,
+					Issues: []core.Issue{{Message: "No license file"}}},
+			},
+		},
+	}}
+
+	out := captureStdout(t, func() {
+		NewFormatter(false, WithColor(false), WithEmoji(false), WithQuiet(true)).DisplayResults(result)
+	})
+	expected := "Repository: legacy [FAIL] Critical (40/100)\n" +
+		"[FAIL] License (compliance): 0\n" +
+		"  - No license file\n" +
+		"\n" +
+		"2 repositories: 1 healthy, 0 warning, 1 critical, 0 errored; 1 check needs attention\n"
+	if out != expected {
+		t.Errorf("Expected only the failing check and a tally:\n%q\ngot:\n%q", expected, out)
+	}
+
+	out = captureStdout(t, func() {
+		NewFormatter(false, WithColor(false), WithQuiet(true)).DisplayResults(core.WorkflowResult{RepositoryResults: result.RepositoryResults[:1]})
+	})
+	if out != "1 repository: 1 healthy, 0 warning, 0 critical, 0 errored; 0 checks need attention\n" {
+		t.Errorf("Expected only the tally for healthy results, got %q", out)
+	}
+}
+
+func TestFormatter_Aggregate(t *testing.T) {
+	result := core.WorkflowResult{RepositoryResults: []core.RepositoryResult{{
+		Repository: core.Repository{Name: "mixed", Language: "go"},
+		Status:     core.StatusHealthy,
+		Score:      100,
+		Aggregate: &core.AnalysisAggregate{
+			TotalFiles: 3, TotalLines: 70, TotalFunctions: 4, AverageComplexity: 4, MaxComplexity: 7,
+			Languages: []core.LanguageMetrics{
+				{Language: "go", Files: 2, Lines: 60, Functions: 3, AverageComplexity: 3, MaxComplexity: 5},
+				{Language: "python", Files: 1, Lines: 10, Functions: 1, AverageComplexity: 7, MaxComplexity: 7},
+			},
+		},
+	}}}
+
+	compact := captureStdout(t, func() { NewFormatter(false, WithColor(false)).DisplayResults(result) })
+	if strings.Contains(compact, "Analysis:") {
+		t.Errorf("Expected the aggregate only in verbose mode, got %q", compact)
+	}
+
+	verbose := captureStdout(t, func() { NewFormatter(true, WithColor(false)).DisplayResults(result) })
+	for _, want := range []string{
+		"Analysis: 3 files, 70 lines, 4 functions, average complexity 4.0 (max 7)",
+		"  go: 2 files, 3 functions, average complexity 3.0",
+		"  python: 1 file, 1 function, average complexity 7.0",
+	} {
+		if !strings.Contains(verbose, want) {
+			t.Errorf("Expected %q in %q", want, verbose)
+		}
+	}
+}
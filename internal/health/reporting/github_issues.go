@@ -0,0 +1,218 @@
+package reporting
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/codcod/repos/internal/core"
+	"github.com/codcod/repos/internal/github"
+	healthconfig "github.com/codcod/repos/internal/health/config"
+)
+
+// githubIssueLabel marks the GitHub issues created by the health command
+const githubIssueLabel = "repos-health"
+
+// githubHost is the host of github.com remotes
+const githubHost = "github.com"
+
+// GitHubIssueReporter opens GitHub issues for critical health findings in the
+// repositories they were found in
+type GitHubIssueReporter struct {
+	config healthconfig.GitHubConfig
+	client *github.Client
+	logger core.Logger
+	// hosts are the remote hosts whose repositories the client can reach
+	hosts map[string]bool
+}
+
+// NewGitHubIssueReporter creates a new GitHub issue reporter
+func NewGitHubIssueReporter(config healthconfig.GitHubConfig, logger core.Logger) *GitHubIssueReporter {
+	if config.Token == "" {
+		config.Token = os.Getenv("GITHUB_TOKEN")
+	}
+
+	hosts := map[string]bool{githubHost: true}
+	if parsed, err := url.Parse(config.BaseURL); err == nil && parsed.Hostname() != "" {
+		hosts[strings.ToLower(parsed.Hostname())] = true
+	}
+
+	return &GitHubIssueReporter{
+		config: config,
+		client: github.NewClient(github.ClientOptions{BaseURL: config.BaseURL, Token: config.Token}),
+		logger: logger,
+		hosts:  hosts,
+	}
+}
+
+// Report opens an issue for every critical issue that has no open issue yet.
+// Findings carry the same fingerprint as JIRA tickets, and the open issues of a
+// repository are listed once to find it. Errors are logged as warnings and
+// never fail the health run; the number of issues created is returned.
+func (r *GitHubIssueReporter) Report(ctx context.Context, result core.WorkflowResult) int {
+	if !r.config.Enabled || !r.config.CreateIssues {
+		return 0
+	}
+	if r.config.Token == "" {
+		r.logger.Warn("GitHub issue creation enabled but no token is configured; set token or GITHUB_TOKEN")
+		return 0
+	}
+
+	created := 0
+	for _, repoResult := range result.RepositoryResults {
+		if !hasCriticalIssue(repoResult) {
+			continue
+		}
+		repoCreated, stop := r.reportRepository(ctx, repoResult)
+		created += repoCreated
+		if stop {
+			return created
+		}
+	}
+
+	return created
+}
+
+// reportRepository opens the missing issues of one repository. It returns the
+// number of issues created and whether reporting should stop.
+func (r *GitHubIssueReporter) reportRepository(ctx context.Context, repoResult core.RepositoryResult) (int, bool) {
+	repo := repoResult.Repository
+	slug := githubRepoSlug(repo.URL, r.hosts)
+	if slug == "" {
+		r.logger.Warn("Cannot open GitHub issues for a repository whose URL is not on github.com or the configured base_url host",
+			core.String("repository", repo.Name),
+			core.String("url", repo.URL))
+		return 0, false
+	}
+
+	existing, err := r.openMarkers(ctx, slug)
+	if err != nil {
+		r.logger.Warn("Failed to list GitHub issues",
+			core.String("repository", repo.Name),
+			core.Error("error", err))
+		return 0, stopReporting(ctx, err)
+	}
+
+	created := 0
+	for _, checkResult := range repoResult.CheckResults {
+		for _, issue := range criticalIssues(checkResult) {
+			marker := findingMarker(repo.Name, checkResult.ID, issue)
+			if existing[marker] {
+				r.logger.Debug("GitHub issue already exists",
+					core.String("repository", repo.Name),
+					core.String("marker", marker))
+				continue
+			}
+
+			if err := r.createIssue(ctx, slug, repo, checkResult, issue, marker); err != nil {
+				r.logger.Warn("Failed to create GitHub issue",
+					core.String("repository", repo.Name),
+					core.String("checker", checkResult.ID),
+					core.Error("error", err))
+				if stopReporting(ctx, err) {
+					return created, true
+				}
+				continue
+			}
+			existing[marker] = true
+			created++
+		}
+	}
+	return created, false
+}
+
+// openMarkers returns the deduplication markers of a repository's open issues
+func (r *GitHubIssueReporter) openMarkers(ctx context.Context, slug string) (map[string]bool, error) {
+	var issues []struct {
+		Body string `json:"body"`
+	}
+	path := fmt.Sprintf("/repos/%s/issues?state=open&labels=%s", slug, url.QueryEscape(githubIssueLabel))
+	if err := r.client.GetAll(ctx, path, &issues); err != nil {
+		return nil, err
+	}
+
+	markers := make(map[string]bool)
+	for _, issue := range issues {
+		for _, marker := range findingMarkerPattern.FindAllString(issue.Body, -1) {
+			markers[marker] = true
+		}
+	}
+	return markers, nil
+}
+
+// createIssue opens a GitHub issue for the finding
+func (r *GitHubIssueReporter) createIssue(ctx context.Context, slug string, repo core.Repository, checkResult core.CheckResult, issue core.Issue, marker string) error {
+	payload := map[string]interface{}{
+		"title":  fmt.Sprintf("%s: %s", checkResult.Name, issue.Message),
+		"body":   githubIssueBody(repo, checkResult, issue, marker),
+		"labels": []string{githubIssueLabel},
+	}
+	return r.client.Post(ctx, fmt.Sprintf("/repos/%s/issues", slug), payload, nil)
+}
+
+// githubIssueBody builds the issue body in Markdown, with the deduplication
+// marker in a comment
+func githubIssueBody(repo core.Repository, checkResult core.CheckResult, issue core.Issue, marker string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "**Check:** %s (%s)\n", checkResult.Name, checkResult.Category)
+	fmt.Fprintf(&b, "**Severity:** %s\n\n", issue.Severity)
+	fmt.Fprintf(&b, "%s\n", issue.Message)
+	if issue.Location != nil {
+		fmt.Fprintf(&b, "\n**Location:** `%s:%d`\n", issue.Location.File, issue.Location.Line)
+	}
+	if issue.Suggestion != "" {
+		fmt.Fprintf(&b, "\n**Suggestion:** %s\n", issue.Suggestion)
+	}
+	fmt.Fprintf(&b, "\n<!-- %s -->\n", marker)
+	return b.String()
+}
+
+// hasCriticalIssue reports whether any check of a repository found a critical issue
+func hasCriticalIssue(repoResult core.RepositoryResult) bool {
+	for _, checkResult := range repoResult.CheckResults {
+		for _, issue := range checkResult.Issues {
+			if issue.Severity == core.SeverityCritical {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// stopReporting reports whether an error makes further GitHub requests
+// pointless: the run was cancelled, the token was rejected, or the rate
+// limit is exhausted
+func stopReporting(ctx context.Context, err error) bool {
+	var apiErr *github.APIError
+	if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusUnauthorized {
+		return true
+	}
+	return ctx.Err() != nil || errors.Is(err, github.ErrRateLimited)
+}
+
+// githubRepoSlug returns owner/repo from an HTTPS, SSH or scp-style remote
+// URL on one of hosts, or "" if it names none. Remotes on other hosts are
+// refused so that findings are never filed on an unrelated GitHub repository
+// that happens to share the owner and name.
+func githubRepoSlug(remote string, hosts map[string]bool) string {
+	var host, path string
+	if parsed, err := url.Parse(remote); err == nil && parsed.Host != "" {
+		host, path = parsed.Hostname(), parsed.Path
+	} else if at := strings.Index(remote, "@"); at >= 0 {
+		host, path, _ = strings.Cut(remote[at+1:], ":")
+	}
+	if !hosts[strings.ToLower(host)] {
+		return ""
+	}
+
+	path = strings.TrimSuffix(strings.Trim(path, "/"), ".git")
+	owner, name, found := strings.Cut(path, "/")
+	if !found || owner == "" || name == "" || strings.Contains(name, "/") {
+		return ""
+	}
+	return owner + "/" + name
+}
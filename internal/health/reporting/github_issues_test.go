@@ -0,0 +1,128 @@
+package reporting
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	healthconfig "github.com/codcod/repos/internal/health/config"
+)
+
+func TestGitHubIssueReporter_CreatesAndDeduplicates(t *testing.T) {
+	var mu sync.Mutex
+	bodies := []string{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if r.Header.Get("Authorization") != "token secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if r.URL.Path != "/repos/owner/repo1/issues" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		switch r.Method {
+		case http.MethodGet:
+			if r.URL.Query().Get("labels") != githubIssueLabel {
+				t.Errorf("Expected issues to be listed by label, got %s", r.URL.RawQuery)
+			}
+			issues := []map[string]string{}
+			for _, body := range bodies {
+				issues = append(issues, map[string]string{"body": body})
+			}
+			_ = json.NewEncoder(w).Encode(issues)
+		case http.MethodPost:
+			var payload struct {
+				Body   string   `json:"body"`
+				Labels []string `json:"labels"`
+			}
+			_ = json.NewDecoder(r.Body).Decode(&payload)
+			if len(payload.Labels) != 1 || payload.Labels[0] != githubIssueLabel {
+				t.Errorf("Expected the %s label, got %v", githubIssueLabel, payload.Labels)
+			}
+			bodies = append(bodies, payload.Body)
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"number":1}`))
+		}
+	}))
+	defer server.Close()
+
+	result := jiraTestResult()
+	result.RepositoryResults[0].Repository.URL = "git@github.com:owner/repo1.git"
+	config := healthconfig.GitHubConfig{Enabled: true, CreateIssues: true, Token: "secret", BaseURL: server.URL}
+
+	logger := &testLogger{}
+	if created := NewGitHubIssueReporter(config, logger).Report(context.Background(), result); created != 1 {
+		t.Fatalf("Expected 1 issue for the critical finding, got %d (warnings: %v)", created, logger.warns)
+	}
+	if !strings.Contains(bodies[0], findingMarkerPrefix) {
+		t.Errorf("Expected the issue body to carry a marker, got %q", bodies[0])
+	}
+
+	if created := NewGitHubIssueReporter(config, logger).Report(context.Background(), result); created != 0 {
+		t.Errorf("Expected no duplicate issue on re-run, got %d", created)
+	}
+	if len(bodies) != 1 || len(logger.warns) != 0 {
+		t.Errorf("Expected one issue and no warnings, got %d issues and warnings %v", len(bodies), logger.warns)
+	}
+}
+
+func TestGitHubIssueReporter_RequiresCreateIssues(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("Expected no request, got %s %s", r.Method, r.URL.Path)
+	}))
+	defer server.Close()
+
+	result := jiraTestResult()
+	result.RepositoryResults[0].Repository.URL = "https://github.com/owner/repo1"
+	config := healthconfig.GitHubConfig{Enabled: true, Token: "secret", BaseURL: server.URL}
+	if created := NewGitHubIssueReporter(config, &testLogger{}).Report(context.Background(), result); created != 0 {
+		t.Errorf("Expected no issues without create_issues, got %d", created)
+	}
+}
+
+func TestGitHubIssueReporter_SkipsOtherHosts(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("Expected no request, got %s %s", r.Method, r.URL.Path)
+	}))
+	defer server.Close()
+
+	result := jiraTestResult()
+	result.RepositoryResults[0].Repository.URL = "https://gitlab.com/owner/repo1.git"
+	config := healthconfig.GitHubConfig{Enabled: true, CreateIssues: true, Token: "secret", BaseURL: server.URL}
+
+	logger := &testLogger{}
+	if created := NewGitHubIssueReporter(config, logger).Report(context.Background(), result); created != 0 {
+		t.Errorf("Expected no issues for a GitLab remote, got %d", created)
+	}
+	if len(logger.warns) != 1 {
+		t.Errorf("Expected a warning for the GitLab remote, got %v", logger.warns)
+	}
+}
+
+func TestGitHubRepoSlug(t *testing.T) {
+	hosts := map[string]bool{"github.com": true, "github.example.com": true}
+	tests := map[string]string{
+		"git@github.com:owner/repo.git":               "owner/repo",
+		"https://GitHub.com/owner/repo":               "owner/repo",
+		"https://github.example.com/owner/repo":       "owner/repo",
+		"ssh://git@github.example.com/owner/repo.git": "owner/repo",
+		"https://gitlab.com/acme/app.git":             "",
+		"git@bitbucket.org:acme/app.git":              "",
+		"https://gitlab.com/group/sub/project.git":    "",
+		"/tmp/repo": "",
+	}
+	for input, expected := range tests {
+		if got := githubRepoSlug(input, hosts); got != expected {
+			t.Errorf("githubRepoSlug(%q) = %q, want %q", input, got, expected)
+		}
+	}
+}
@@ -0,0 +1,52 @@
+package reporting
+
+import (
+	"html/template"
+	"io"
+
+	"github.com/codcod/repos/internal/core"
+)
+
+// htmlReportTemplate renders a self-contained HTML report; html/template escapes all values
+var htmlReportTemplate = template.Must(template.New("report").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>Repository Health Report</title>
+<style>
+body { font-family: sans-serif; margin: 2em; }
+table { border-collapse: collapse; margin-bottom: 1.5em; }
+th, td { border: 1px solid #ccc; padding: 4px 8px; text-align: left; vertical-align: top; }
+.healthy { color: #2e7d32; } .warning { color: #ef6c00; } .critical, .errored { color: #c62828; }
+</style>
+</head>
+<body>
+<h1>Repository Health Report</h1>
+<p>{{.TotalRepos}} repositories, average score {{.Summary.AverageScore}}, {{.Summary.TotalIssues}} issues.</p>
+{{if gt (len .Summary.Ranking) 1}}
+<h2>Ranking</h2>
+<p>{{range $status, $count := .Summary.StatusCounts}}<span class="{{$status}}">{{$count}} {{$status}}</span> {{end}}</p>
+<table>
+<tr><th>Rank</th><th>Repository</th><th>Status</th><th>Score</th></tr>
+{{range .Summary.Ranking}}<tr><td>{{.Rank}}</td><td>{{.Name}}</td><td class="{{.Status}}">{{.Status}}</td><td>{{.Score}}/100</td></tr>
+{{end}}</table>
+{{with .Summary.Worst}}<p>Lowest scores: {{range $i, $rank := .}}{{if $i}}, {{end}}{{$rank.Name}} ({{$rank.Score}}){{end}}</p>{{end}}
+{{end}}
+{{range .RepositoryResults}}
+<h2>{{.Repository.Name}} <span class="{{.Status}}">{{.Status}}</span> ({{.Score}}/{{.MaxScore}})</h2>
+<table>
+<tr><th>Check</th><th>Category</th><th>Status</th><th>Score</th><th>Issues</th></tr>
+{{range .CheckResults}}<tr>
+<td>{{.Name}}</td><td>{{.Category}}</td><td class="{{.Status}}">{{.Status}}</td><td>{{.Score}}/{{.MaxScore}}</td>
+<td>{{range .Errors}}<div class="errored">[errored] {{.Message}}</div>{{end}}{{range .Issues}}<div>[{{.Severity}}] {{.Message}}{{with .Location}} ({{.File}}:{{.Line}}){{end}}</div>{{end}}</td>
+</tr>
+{{end}}</table>
+{{end}}
+</body>
+</html>
+`))
+
+// WriteHTML writes the workflow result as a standalone HTML report
+func WriteHTML(w io.Writer, result core.WorkflowResult) error {
+	return htmlReportTemplate.Execute(w, result)
+}
@@ -0,0 +1,232 @@
+package reporting
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/codcod/repos/internal/core"
+	healthconfig "github.com/codcod/repos/internal/health/config"
+)
+
+const (
+	defaultJIRATimeout   = 30 * time.Second
+	defaultJIRAIssueType = "Bug"
+)
+
+// errJIRAAuth signals that JIRA rejected the credentials, so further requests are pointless
+var errJIRAAuth = errors.New("JIRA authentication failed")
+
+// JIRAReporter creates JIRA tickets for critical health findings
+type JIRAReporter struct {
+	config healthconfig.JIRAConfig
+	client *http.Client
+	logger core.Logger
+}
+
+// NewJIRAReporter creates a new JIRA reporter
+func NewJIRAReporter(config healthconfig.JIRAConfig, logger core.Logger) *JIRAReporter {
+	if config.Timeout == 0 {
+		config.Timeout = defaultJIRATimeout
+	}
+	if config.IssueType == "" {
+		config.IssueType = defaultJIRAIssueType
+	}
+	if config.APIToken == "" {
+		config.APIToken = os.Getenv("JIRA_API_TOKEN")
+	}
+	config.BaseURL = strings.TrimSuffix(config.BaseURL, "/")
+
+	return &JIRAReporter{
+		config: config,
+		client: &http.Client{Timeout: config.Timeout},
+		logger: logger,
+	}
+}
+
+// Report creates a ticket for every critical issue that does not already have one.
+// Errors are logged as warnings and never fail the health run; the number of
+// tickets created is returned.
+func (r *JIRAReporter) Report(ctx context.Context, result core.WorkflowResult) int {
+	if !r.config.Enabled {
+		return 0
+	}
+	if r.config.BaseURL == "" || r.config.Project == "" {
+		r.logger.Warn("JIRA integration enabled but base_url or project is not configured")
+		return 0
+	}
+
+	created := 0
+	for _, repoResult := range result.RepositoryResults {
+		for _, checkResult := range repoResult.CheckResults {
+			for _, issue := range criticalIssues(checkResult) {
+				ticketCreated, err := r.reportIssue(ctx, repoResult.Repository, checkResult, issue)
+				if ticketCreated {
+					created++
+				}
+				if err == nil {
+					continue
+				}
+				if r.warnFailed(ctx, repoResult.Repository, checkResult, err) {
+					return created
+				}
+			}
+		}
+	}
+
+	return created
+}
+
+// warnFailed logs a ticket that could not be created and reports whether the
+// error makes further requests pointless
+func (r *JIRAReporter) warnFailed(ctx context.Context, repo core.Repository, checkResult core.CheckResult, err error) bool {
+	r.logger.Warn("Failed to create JIRA ticket",
+		core.String("repository", repo.Name),
+		core.String("checker", checkResult.ID),
+		core.Error("error", err))
+	return errors.Is(err, errJIRAAuth) || ctx.Err() != nil
+}
+
+// criticalIssues returns the critical issues of a check result
+func criticalIssues(checkResult core.CheckResult) []core.Issue {
+	var critical []core.Issue
+	for _, issue := range checkResult.Issues {
+		if issue.Severity == core.SeverityCritical {
+			critical = append(critical, issue)
+		}
+	}
+	return critical
+}
+
+// reportIssue creates a ticket for a single finding unless one already exists.
+// It reports whether a new ticket was created.
+func (r *JIRAReporter) reportIssue(ctx context.Context, repo core.Repository, checkResult core.CheckResult, issue core.Issue) (bool, error) {
+	marker := findingMarker(repo.Name, checkResult.ID, issue)
+
+	exists, err := r.ticketExists(ctx, marker)
+	if err != nil {
+		return false, err
+	}
+	if exists {
+		r.logger.Debug("JIRA ticket already exists",
+			core.String("repository", repo.Name),
+			core.String("marker", marker))
+		return false, nil
+	}
+
+	if err := r.createTicket(ctx, repo, checkResult, issue, marker); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// ticketExists searches for an existing ticket carrying the deduplication marker
+func (r *JIRAReporter) ticketExists(ctx context.Context, marker string) (bool, error) {
+	jql := fmt.Sprintf(`project = "%s" AND description ~ "\"%s\""`, r.config.Project, marker)
+	endpoint := fmt.Sprintf("%s/rest/api/2/search?maxResults=1&fields=key&jql=%s", r.config.BaseURL, url.QueryEscape(jql))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return false, err
+	}
+
+	var response struct {
+		Total int `json:"total"`
+	}
+	if err := r.do(req, &response); err != nil {
+		return false, err
+	}
+
+	return response.Total > 0, nil
+}
+
+// createTicket creates a JIRA ticket for the finding
+func (r *JIRAReporter) createTicket(ctx context.Context, repo core.Repository, checkResult core.CheckResult, issue core.Issue, marker string) error {
+	payload := map[string]interface{}{
+		"fields": map[string]interface{}{
+			"project":     map[string]string{"key": r.config.Project},
+			"summary":     fmt.Sprintf("[%s] %s: %s", repo.Name, checkResult.Name, issue.Message),
+			"description": jiraDescription(repo, checkResult, issue, marker),
+			"issuetype":   map[string]string{"name": r.config.IssueType},
+			"priority":    map[string]string{"name": jiraPriority(issue.Severity)},
+		},
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, r.config.BaseURL+"/rest/api/2/issue", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	return r.do(req, nil)
+}
+
+// do sends an authenticated request and decodes the JSON response into out, if given
+func (r *JIRAReporter) do(req *http.Request, out interface{}) error {
+	req.SetBasicAuth(r.config.Username, r.config.APIToken)
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	switch {
+	case resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden:
+		return fmt.Errorf("%w: status %d", errJIRAAuth, resp.StatusCode)
+	case resp.StatusCode >= 300:
+		return fmt.Errorf("JIRA API returned status %d", resp.StatusCode)
+	}
+
+	if out == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+// jiraDescription builds the ticket description, including the deduplication marker
+func jiraDescription(repo core.Repository, checkResult core.CheckResult, issue core.Issue, marker string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Repository: %s\n", repo.Name)
+	if repo.URL != "" {
+		fmt.Fprintf(&b, "URL: %s\n", repo.URL)
+	}
+	fmt.Fprintf(&b, "Check: %s (%s)\n", checkResult.Name, checkResult.Category)
+	fmt.Fprintf(&b, "Severity: %s\n\n", issue.Severity)
+	fmt.Fprintf(&b, "%s\n", issue.Message)
+	if issue.Location != nil {
+		fmt.Fprintf(&b, "\nLocation: %s:%d\n", issue.Location.File, issue.Location.Line)
+	}
+	if issue.Suggestion != "" {
+		fmt.Fprintf(&b, "\nSuggestion: %s\n", issue.Suggestion)
+	}
+	fmt.Fprintf(&b, "\n%s", marker)
+	return b.String()
+}
+
+// jiraPriority maps a finding severity to a JIRA priority name
+func jiraPriority(severity core.Severity) string {
+	switch severity {
+	case core.SeverityCritical:
+		return "Highest"
+	case core.SeverityHigh:
+		return "High"
+	case core.SeverityMedium:
+		return "Medium"
+	default:
+		return "Low"
+	}
+}
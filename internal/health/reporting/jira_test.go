@@ -0,0 +1,143 @@
+package reporting
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/codcod/repos/internal/core"
+	healthconfig "github.com/codcod/repos/internal/health/config"
+)
+
+type testLogger struct {
+	mu    sync.Mutex
+	warns []string
+}
+
+func (l *testLogger) Debug(msg string, fields ...core.Field) {}
+func (l *testLogger) Info(msg string, fields ...core.Field)  {}
+func (l *testLogger) Error(msg string, fields ...core.Field) {}
+func (l *testLogger) Fatal(msg string, fields ...core.Field) {}
+func (l *testLogger) Warn(msg string, fields ...core.Field) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.warns = append(l.warns, msg)
+}
+
+func jiraTestResult() core.WorkflowResult {
+	return core.WorkflowResult{
+		RepositoryResults: []core.RepositoryResult{
+			{
+				Repository: core.Repository{Name: "repo1"},
+				CheckResults: []core.CheckResult{
+					{
+						ID:   "vulnerability-scan",
+						Name: "Vulnerability Scan",
+						Issues: []core.Issue{
+							{Type: "vulnerability", Severity: core.SeverityCritical, Message: "CVE-2024-0001 in libfoo"},
+							{Type: "vulnerability", Severity: core.SeverityLow, Message: "minor issue"},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestJIRAReporter_CreatesAndDeduplicates(t *testing.T) {
+	var mu sync.Mutex
+	descriptions := []string{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if user, token, ok := r.BasicAuth(); !ok || user != "bot" || token != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		switch r.URL.Path {
+		case "/rest/api/2/search":
+			total := 0
+			for _, description := range descriptions {
+				if strings.Contains(r.URL.Query().Get("jql"), description[strings.LastIndex(description, "\n")+1:]) {
+					total++
+				}
+			}
+			_ = json.NewEncoder(w).Encode(map[string]int{"total": total})
+		case "/rest/api/2/issue":
+			var payload struct {
+				Fields struct {
+					Description string            `json:"description"`
+					Priority    map[string]string `json:"priority"`
+				} `json:"fields"`
+			}
+			_ = json.NewDecoder(r.Body).Decode(&payload)
+			if payload.Fields.Priority["name"] != "Highest" {
+				t.Errorf("Expected Highest priority, got %v", payload.Fields.Priority)
+			}
+			descriptions = append(descriptions, payload.Fields.Description)
+			w.WriteHeader(http.StatusCreated)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	reporter := NewJIRAReporter(healthconfig.JIRAConfig{
+		Enabled:  true,
+		BaseURL:  server.URL + "/",
+		Username: "bot",
+		APIToken: "secret",
+		Project:  "OPS",
+	}, &testLogger{})
+
+	// Only the critical issue produces a ticket
+	if created := reporter.Report(context.Background(), jiraTestResult()); created != 1 {
+		t.Fatalf("Expected 1 ticket on first run, got %d", created)
+	}
+
+	// A re-run finds the marker and does not create another ticket
+	if created := reporter.Report(context.Background(), jiraTestResult()); created != 0 {
+		t.Errorf("Expected no tickets on re-run, got %d", created)
+	}
+
+	if len(descriptions) != 1 || !strings.Contains(descriptions[0], findingMarkerPrefix) {
+		t.Errorf("Expected one ticket with a deduplication marker, got %v", descriptions)
+	}
+}
+
+func TestJIRAReporter_FailsSoftlyOnAuthError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	logger := &testLogger{}
+	reporter := NewJIRAReporter(healthconfig.JIRAConfig{
+		Enabled:  true,
+		BaseURL:  server.URL,
+		Username: "bot",
+		APIToken: "wrong",
+		Project:  "OPS",
+	}, logger)
+
+	if created := reporter.Report(context.Background(), jiraTestResult()); created != 0 {
+		t.Errorf("Expected no tickets, got %d", created)
+	}
+	if len(logger.warns) != 1 {
+		t.Errorf("Expected a single warning, got %v", logger.warns)
+	}
+}
+
+func TestJIRAReporter_Disabled(t *testing.T) {
+	reporter := NewJIRAReporter(healthconfig.JIRAConfig{Enabled: false}, &testLogger{})
+	if created := reporter.Report(context.Background(), jiraTestResult()); created != 0 {
+		t.Errorf("Expected no tickets when disabled, got %d", created)
+	}
+}
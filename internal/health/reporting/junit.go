@@ -0,0 +1,117 @@
+package reporting
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/codcod/repos/internal/core"
+)
+
+// JUnitTestSuites is the root element of a JUnit XML report
+type JUnitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Errors   int              `xml:"errors,attr"`
+	Suites   []JUnitTestSuite `xml:"testsuite"`
+}
+
+// JUnitTestSuite holds the checks run against one repository
+type JUnitTestSuite struct {
+	Name     string          `xml:"name,attr"`
+	Tests    int             `xml:"tests,attr"`
+	Failures int             `xml:"failures,attr"`
+	Errors   int             `xml:"errors,attr"`
+	Time     float64         `xml:"time,attr"`
+	Cases    []JUnitTestCase `xml:"testcase"`
+}
+
+// JUnitTestCase represents a single checker result
+type JUnitTestCase struct {
+	ClassName string        `xml:"classname,attr"`
+	Name      string        `xml:"name,attr"`
+	Time      float64       `xml:"time,attr"`
+	Failure   *JUnitFailure `xml:"failure,omitempty"`
+	Error     *JUnitFailure `xml:"error,omitempty"`
+}
+
+// JUnitFailure describes why a check did not pass
+type JUnitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// NewJUnitReport converts a workflow result into a JUnit report. Checks with a
+// warning or critical status are reported as failures so CI systems surface them,
+// and checkers that could not run are reported as errors.
+func NewJUnitReport(result core.WorkflowResult) JUnitTestSuites {
+	report := JUnitTestSuites{}
+
+	for _, repoResult := range result.RepositoryResults {
+		suite := JUnitTestSuite{
+			Name: repoResult.Repository.Name,
+			Time: repoResult.EndTime.Sub(repoResult.StartTime).Seconds(),
+		}
+
+		for _, checkResult := range repoResult.CheckResults {
+			testCase := JUnitTestCase{
+				ClassName: fmt.Sprintf("%s.%s", repoResult.Repository.Name, checkResult.Category),
+				Name:      checkResult.Name,
+				Time:      checkResult.Duration.Seconds(),
+			}
+
+			if checkResult.Status == core.StatusErrored {
+				messages := make([]string, 0, len(checkResult.Errors))
+				for _, checkErr := range checkResult.Errors {
+					messages = append(messages, checkErr.Message)
+				}
+				testCase.Error = &JUnitFailure{
+					Message: "checker could not run",
+					Type:    string(core.StatusErrored),
+					Text:    strings.Join(messages, "\n"),
+				}
+				suite.Errors++
+			}
+
+			if checkResult.Status == core.StatusWarning || checkResult.Status == core.StatusCritical {
+				messages := make([]string, 0, len(checkResult.Issues))
+				for _, issue := range checkResult.Issues {
+					messages = append(messages, fmt.Sprintf("[%s] %s", issue.Severity, issue.Message))
+				}
+				testCase.Failure = &JUnitFailure{
+					Message: fmt.Sprintf("%s (score %d/%d)", checkResult.Status, checkResult.Score, checkResult.MaxScore),
+					Type:    string(checkResult.Status),
+					Text:    strings.Join(messages, "\n"),
+				}
+				suite.Failures++
+			}
+
+			suite.Cases = append(suite.Cases, testCase)
+			suite.Tests++
+		}
+
+		report.Tests += suite.Tests
+		report.Failures += suite.Failures
+		report.Errors += suite.Errors
+		report.Suites = append(report.Suites, suite)
+	}
+
+	return report
+}
+
+// WriteJUnit writes the workflow result as JUnit XML
+func WriteJUnit(w io.Writer, result core.WorkflowResult) error {
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(NewJUnitReport(result)); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
@@ -0,0 +1,121 @@
+package reporting
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/codcod/repos/internal/core"
+	"github.com/codcod/repos/internal/health/orchestration"
+)
+
+// NDJSON record types
+const (
+	ndjsonRepository = "repository"
+	ndjsonSummary    = "summary"
+	ndjsonError      = "error"
+)
+
+// ndjsonRecord is a single line of NDJSON output. The type field tells consumers
+// which of the other fields is set.
+type ndjsonRecord struct {
+	Type       string                 `json:"type"`
+	Repository *core.RepositoryResult `json:"repository,omitempty"`
+	Summary    *ndjsonSummaryRecord   `json:"summary,omitempty"`
+	Error      string                 `json:"error,omitempty"`
+}
+
+// ndjsonSummaryRecord holds the workflow totals written as the final line
+type ndjsonSummaryRecord struct {
+	TotalRepos int                  `json:"total_repos"`
+	StartTime  time.Time            `json:"start_time"`
+	EndTime    time.Time            `json:"end_time"`
+	Duration   time.Duration        `json:"duration"`
+	Totals     core.WorkflowSummary `json:"totals"`
+}
+
+// NDJSONFormatter streams results as newline-delimited JSON: one repository result
+// per line as each repository completes, followed by a final summary line.
+// It implements orchestration.ProgressReporter so it can be attached to the engine.
+type NDJSONFormatter struct {
+	mu       sync.Mutex
+	encoder  *json.Encoder
+	baseline *Baseline
+	err      error
+}
+
+// NewNDJSONFormatter creates a formatter that writes NDJSON records to w
+func NewNDJSONFormatter(w io.Writer) *NDJSONFormatter {
+	return &NDJSONFormatter{encoder: json.NewEncoder(w)}
+}
+
+// SetBaseline marks the findings of each repository line present in the
+// baseline as known, before the line is written
+func (f *NDJSONFormatter) SetBaseline(baseline *Baseline) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.baseline = baseline
+}
+
+// WriteRepository writes one repository result as a line
+func (f *NDJSONFormatter) WriteRepository(result core.RepositoryResult) error {
+	return f.write(ndjsonRecord{Type: ndjsonRepository, Repository: &result})
+}
+
+// WriteSummary writes the workflow totals as a line; it should be written last
+func (f *NDJSONFormatter) WriteSummary(result core.WorkflowResult) error {
+	return f.write(ndjsonRecord{Type: ndjsonSummary, Summary: &ndjsonSummaryRecord{
+		TotalRepos: result.TotalRepos,
+		StartTime:  result.StartTime,
+		EndTime:    result.EndTime,
+		Duration:   result.Duration,
+		Totals:     result.Summary,
+	}})
+}
+
+// ReportProgress writes each repository result as the engine reports it complete
+func (f *NDJSONFormatter) ReportProgress(_ context.Context, progress orchestration.Progress) {
+	if progress.Status == orchestration.ProgressRepositoryCompleted && progress.Result != nil {
+		_ = f.WriteRepository(*progress.Result)
+	}
+}
+
+// ReportError writes an error line
+func (f *NDJSONFormatter) ReportError(_ context.Context, err error) {
+	_ = f.write(ndjsonRecord{Type: ndjsonError, Error: err.Error()})
+}
+
+// Err returns the first error encountered while writing, if any
+func (f *NDJSONFormatter) Err() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.err
+}
+
+// write encodes a record; after the first failure further records are dropped
+func (f *NDJSONFormatter) write(record ndjsonRecord) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.err != nil {
+		return f.err
+	}
+	if f.baseline != nil && record.Repository != nil {
+		f.baseline.ApplyRepository(record.Repository)
+	}
+	f.err = f.encoder.Encode(record)
+	return f.err
+}
+
+// WriteNDJSON writes a complete workflow result as NDJSON
+func WriteNDJSON(w io.Writer, result core.WorkflowResult) error {
+	formatter := NewNDJSONFormatter(w)
+	for _, repoResult := range result.RepositoryResults {
+		if err := formatter.WriteRepository(repoResult); err != nil {
+			return err
+		}
+	}
+	return formatter.WriteSummary(result)
+}
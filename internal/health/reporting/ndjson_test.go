@@ -0,0 +1,82 @@
+package reporting
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/codcod/repos/internal/core"
+	"github.com/codcod/repos/internal/health/orchestration"
+)
+
+func TestNDJSONFormatter_StreamsRepositoriesThenSummary(t *testing.T) {
+	var buf bytes.Buffer
+	formatter := NewNDJSONFormatter(&buf)
+	result := outputTestResult()
+
+	// Progress events other than completed repositories are ignored
+	formatter.ReportProgress(context.Background(), orchestration.Progress{Status: "running"})
+	formatter.ReportProgress(context.Background(), orchestration.Progress{
+		Status: orchestration.ProgressRepositoryCompleted,
+		Result: &result.RepositoryResults[0],
+	})
+	result.Summary.TotalIssues = 1
+	if err := formatter.WriteSummary(result); err != nil {
+		t.Fatalf("WriteSummary failed: %v", err)
+	}
+
+	var records []map[string]json.RawMessage
+	scanner := bufio.NewScanner(&buf)
+	for scanner.Scan() {
+		var record map[string]json.RawMessage
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			t.Fatalf("Line is not valid JSON: %v", err)
+		}
+		records = append(records, record)
+	}
+
+	if len(records) != 2 {
+		t.Fatalf("Expected 2 lines, got %d", len(records))
+	}
+	if string(records[0]["type"]) != `"repository"` || string(records[1]["type"]) != `"summary"` {
+		t.Errorf("Expected repository then summary lines, got %s and %s", records[0]["type"], records[1]["type"])
+	}
+
+	var summary struct {
+		TotalRepos int                  `json:"total_repos"`
+		Totals     core.WorkflowSummary `json:"totals"`
+	}
+	if err := json.Unmarshal(records[1]["summary"], &summary); err != nil {
+		t.Fatal(err)
+	}
+	if summary.TotalRepos != 1 || summary.Totals.TotalIssues != 1 {
+		t.Errorf("Unexpected summary %+v", summary)
+	}
+}
+
+func TestNDJSONFormatter_AppliesBaselineToRepositoryLines(t *testing.T) {
+	var buf bytes.Buffer
+	formatter := NewNDJSONFormatter(&buf)
+	result := outputTestResult()
+	formatter.SetBaseline(NewBaseline(result))
+
+	if err := formatter.WriteRepository(outputTestResult().RepositoryResults[0]); err != nil {
+		t.Fatalf("WriteRepository failed: %v", err)
+	}
+
+	var record struct {
+		Repository core.RepositoryResult `json:"repository"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &record); err != nil {
+		t.Fatal(err)
+	}
+	for _, checkResult := range record.Repository.CheckResults {
+		for _, issue := range checkResult.Issues {
+			if !issue.Severity.Advisory() && !IsKnownFinding(issue) {
+				t.Errorf("Expected baselined finding %q to be marked as known", issue.Message)
+			}
+		}
+	}
+}
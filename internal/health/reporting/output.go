@@ -0,0 +1,164 @@
+package reporting
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/codcod/repos/internal/core"
+)
+
+// reportWriters maps output file extensions to the writer for that format
+var reportWriters = map[string]func(io.Writer, core.WorkflowResult) error{
+	".json": func(f io.Writer, result core.WorkflowResult) error { return WriteJSON(f, result) },
+	".csv": func(f io.Writer, result core.WorkflowResult) error {
+		return NewCSVFormatter().WriteCheckResults(f, result)
+	},
+	".xml":    func(f io.Writer, result core.WorkflowResult) error { return WriteJUnit(f, result) },
+	".html":   func(f io.Writer, result core.WorkflowResult) error { return WriteHTML(f, result) },
+	".sarif":  func(f io.Writer, result core.WorkflowResult) error { return WriteSARIF(f, result) },
+	".ndjson": func(f io.Writer, result core.WorkflowResult) error { return WriteNDJSON(f, result) },
+}
+
+// ReportFormatForPath returns the report format implied by the file extension
+// (json, csv, xml, html, sarif or ndjson), or an error if the extension is not supported.
+func ReportFormatForPath(path string) (string, error) {
+	ext := strings.ToLower(filepath.Ext(path))
+	if _, ok := reportWriters[ext]; !ok {
+		return "", fmt.Errorf("unsupported output file extension %q (supported: .json, .csv, .xml, .html, .sarif, .ndjson)", ext)
+	}
+	return strings.TrimPrefix(ext, "."), nil
+}
+
+// ReportOutput is one report written by a run: to Path, or to stdout when Path is empty
+type ReportOutput struct {
+	Format string
+	Path   string
+}
+
+// stdoutFormats are the formats that may be written to stdout
+var stdoutFormats = map[string]bool{"console": true, "json": true, "csv": true, "ndjson": true}
+
+// PlanOutputs pairs the requested formats with the output files. Each file's
+// format is implied by its extension, and each format other than console takes
+// the next unused file of its format. Formats left without a file are written to
+// stdout, which only console, json, csv and ndjson support and only one format
+// may use. Files whose format was not requested are written in addition, and
+// without any formats the console report is written to stdout.
+func PlanOutputs(formats, paths []string) ([]ReportOutput, error) {
+	if len(formats) == 0 {
+		formats = []string{"console"}
+	}
+
+	files, err := outputFiles(paths)
+	if err != nil {
+		return nil, err
+	}
+
+	used := make([]bool, len(files))
+	var outputs []ReportOutput
+	var stdout []string
+	for _, name := range formats {
+		name = strings.ToLower(strings.TrimSpace(name))
+		format, err := reportFormat(name)
+		if err != nil {
+			return nil, err
+		}
+
+		output := claimFile(files, used, format)
+		if output.Path == "" {
+			if !stdoutFormats[format] {
+				return nil, fmt.Errorf("format %s needs an --output file ending in .%s", name, format)
+			}
+			stdout = append(stdout, format)
+		}
+		outputs = append(outputs, output)
+	}
+	if len(stdout) > 1 {
+		return nil, fmt.Errorf("formats %s would all write to stdout; give all but one an --output file", strings.Join(stdout, ", "))
+	}
+
+	for i, file := range files {
+		if !used[i] {
+			outputs = append(outputs, file)
+		}
+	}
+	return outputs, nil
+}
+
+// outputFiles describes each output file with the format implied by its extension
+func outputFiles(paths []string) ([]ReportOutput, error) {
+	files := make([]ReportOutput, 0, len(paths))
+	seen := make(map[string]bool)
+	for _, path := range paths {
+		if seen[filepath.Clean(path)] {
+			return nil, fmt.Errorf("output %s is given more than once", path)
+		}
+		seen[filepath.Clean(path)] = true
+		format, err := ReportFormatForPath(path)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, ReportOutput{Format: format, Path: path})
+	}
+	return files, nil
+}
+
+// reportFormat returns the format a requested format name is written in
+func reportFormat(name string) (string, error) {
+	format := name
+	if format == "junit" {
+		format = "xml" // JUnit reports are .xml files
+	}
+	if !stdoutFormats[format] && reportWriters["."+format] == nil {
+		return "", fmt.Errorf("unsupported format %q (supported: console, json, csv, ndjson, sarif, html, junit)", name)
+	}
+	return format, nil
+}
+
+// claimFile marks the first unused file of format as used and returns it. The
+// console format and formats without a file get an output to stdout.
+func claimFile(files []ReportOutput, used []bool, format string) ReportOutput {
+	if format == "console" {
+		return ReportOutput{Format: format}
+	}
+	for i, file := range files {
+		if !used[i] && file.Format == format {
+			used[i] = true
+			return file
+		}
+	}
+	return ReportOutput{Format: format}
+}
+
+// StdoutFormat returns the format planned for stdout, or "" if none is
+func StdoutFormat(outputs []ReportOutput) string {
+	for _, output := range outputs {
+		if output.Path == "" {
+			return output.Format
+		}
+	}
+	return ""
+}
+
+// WriteReportFile writes the workflow result to path in the format implied by its extension
+func WriteReportFile(path string, result core.WorkflowResult) error {
+	write, ok := reportWriters[strings.ToLower(filepath.Ext(path))]
+	if !ok {
+		_, err := ReportFormatForPath(path)
+		return err
+	}
+
+	file, err := os.Create(path) //nolint:gosec // Path is provided by the user
+	if err != nil {
+		return fmt.Errorf("failed to create output file: %w", err)
+	}
+
+	if err := write(file, result); err != nil {
+		_ = file.Close()
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return file.Close()
+}
@@ -0,0 +1,202 @@
+package reporting
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/codcod/repos/internal/core"
+)
+
+func outputTestResult() core.WorkflowResult {
+	return core.WorkflowResult{
+		TotalRepos: 1,
+		RepositoryResults: []core.RepositoryResult{
+			{
+				Repository: core.Repository{Name: "repo1", Path: "/repos/repo1"},
+				Status:     core.StatusWarning,
+				CheckResults: []core.CheckResult{
+					{
+						ID:       "tech-debt",
+						Name:     "Technical Debt Markers",
+						Category: "quality",
+						Status:   core.StatusWarning,
+						Score:    70,
+						MaxScore: 100,
+						Issues: []core.Issue{
+							{
+								Type:     "tech_debt_hotspot",
+								Severity: core.SeverityMedium,
+								Message:  "main.go contains <5> markers",
+								Location: &core.Location{File: "/repos/repo1/main.go", Line: 3},
+							},
+						},
+					},
+					{ID: "git-status", Name: "Git Status", Category: "git", Status: core.StatusHealthy, Score: 100, MaxScore: 100},
+				},
+			},
+		},
+	}
+}
+
+func TestReportFormatForPath(t *testing.T) {
+	for path, expected := range map[string]string{
+		"out/health.json":  "json",
+		"health.SARIF":     "sarif",
+		"report.xml":       "xml",
+		"report.html":      "html",
+		"metrics.csv":      "csv",
+		"report.txt":       "",
+		"no-extension-out": "",
+	} {
+		format, err := ReportFormatForPath(path)
+		if expected == "" {
+			if err == nil {
+				t.Errorf("Expected error for %s", path)
+			}
+			continue
+		}
+		if err != nil || format != expected {
+			t.Errorf("ReportFormatForPath(%s) = %q, %v; want %q", path, format, err, expected)
+		}
+	}
+}
+
+func TestPlanOutputs(t *testing.T) {
+	tests := []struct {
+		name     string
+		formats  []string
+		paths    []string
+		expected []ReportOutput
+		err      string
+	}{
+		{"default console", nil, nil, []ReportOutput{{Format: "console"}}, ""},
+		{"console and sarif", []string{"console", "sarif"}, []string{"report.sarif"},
+			[]ReportOutput{{Format: "console"}, {Format: "sarif", Path: "report.sarif"}}, ""},
+		{"junit takes .xml", []string{"junit", "json"}, []string{"out/tests.xml"},
+			[]ReportOutput{{Format: "xml", Path: "out/tests.xml"}, {Format: "json"}}, ""},
+		{"unrequested file is extra", []string{"console"}, []string{"health.html"},
+			[]ReportOutput{{Format: "console"}, {Format: "html", Path: "health.html"}}, ""},
+		{"file-only run", []string{"sarif", "html"}, []string{"a.html", "b.sarif"},
+			[]ReportOutput{{Format: "sarif", Path: "b.sarif"}, {Format: "html", Path: "a.html"}}, ""},
+		{"file format without output", []string{"console", "sarif"}, nil, nil, "needs an --output file ending in .sarif"},
+		{"two formats on stdout", []string{"console", "json"}, nil, nil, "would all write to stdout"},
+		{"same output twice", []string{"json"}, []string{"a.json", "./a.json"}, nil, "given more than once"},
+		{"unknown format", []string{"yaml"}, nil, nil, "unsupported format"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			outputs, err := PlanOutputs(tt.formats, tt.paths)
+			if tt.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.err) {
+					t.Fatalf("Expected error containing %q, got %v", tt.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("PlanOutputs failed: %v", err)
+			}
+			if len(outputs) != len(tt.expected) {
+				t.Fatalf("Expected %+v, got %+v", tt.expected, outputs)
+			}
+			for i := range outputs {
+				if outputs[i] != tt.expected[i] {
+					t.Errorf("Expected %+v, got %+v", tt.expected, outputs)
+				}
+			}
+		})
+	}
+}
+
+func TestWriteReportFile(t *testing.T) {
+	dir := t.TempDir()
+	result := outputTestResult()
+
+	for _, name := range []string{"health.json", "health.csv", "health.xml", "health.html", "health.sarif", "health.ndjson"} {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(dir, name)
+			if err := WriteReportFile(path, result); err != nil {
+				t.Fatalf("WriteReportFile failed: %v", err)
+			}
+			data, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			switch filepath.Ext(name) {
+			case ".sarif":
+				var log SARIFLog
+				if err := json.Unmarshal(data, &log); err != nil {
+					t.Fatalf("Invalid SARIF: %v", err)
+				}
+				if len(log.Runs) != 1 || len(log.Runs[0].Results) != 1 {
+					t.Fatalf("Expected one run with one result, got %+v", log.Runs)
+				}
+				sarifResult := log.Runs[0].Results[0]
+				if sarifResult.RuleID != "tech-debt/tech_debt_hotspot" || sarifResult.Level != "warning" {
+					t.Errorf("Unexpected SARIF result %+v", sarifResult)
+				}
+				if uri := sarifResult.Locations[0].PhysicalLocation.ArtifactLocation.URI; uri != "main.go" {
+					t.Errorf("Expected repository-relative URI, got %s", uri)
+				}
+			case ".xml":
+				var report JUnitTestSuites
+				if err := xml.Unmarshal(data, &report); err != nil {
+					t.Fatalf("Invalid JUnit XML: %v", err)
+				}
+				if report.Tests != 2 || report.Failures != 1 {
+					t.Errorf("Expected 2 tests and 1 failure, got %d and %d", report.Tests, report.Failures)
+				}
+			case ".html":
+				if !strings.Contains(string(data), "main.go contains &lt;5&gt; markers") {
+					t.Error("Expected HTML report to contain the escaped issue message")
+				}
+			case ".json":
+				if !json.Valid(data) {
+					t.Error("Expected valid JSON")
+				}
+			case ".ndjson":
+				if lines := strings.Split(strings.TrimSpace(string(data)), "\n"); len(lines) != 2 {
+					t.Errorf("Expected a repository line and a summary line, got %d lines", len(lines))
+				}
+			case ".csv":
+				if !strings.HasPrefix(string(data), "repository,checker,status,severity,score\n") {
+					t.Errorf("Unexpected CSV header: %q", strings.SplitN(string(data), "\n", 2)[0])
+				}
+			}
+		})
+	}
+}
+
+func TestReports_ErroredChecks(t *testing.T) {
+	result := outputTestResult()
+	result.RepositoryResults[0].CheckResults = append(result.RepositoryResults[0].CheckResults, core.CheckResult{
+		ID:       "dependencies-outdated",
+		Name:     "Outdated Dependencies",
+		Category: "dependencies",
+		Status:   core.StatusErrored,
+		Errors:   []core.CheckError{{Type: "timeout", Message: "context deadline exceeded"}},
+	})
+
+	report := NewJUnitReport(result)
+	if report.Tests != 3 || report.Failures != 1 || report.Errors != 1 {
+		t.Errorf("Expected 3 tests, 1 failure and 1 error, got %d, %d and %d", report.Tests, report.Failures, report.Errors)
+	}
+	if testCase := report.Suites[0].Cases[2]; testCase.Error == nil || testCase.Failure != nil || testCase.Error.Text != "context deadline exceeded" {
+		t.Errorf("Expected the errored check as a JUnit error, got %+v", testCase)
+	}
+
+	log := NewSARIFLog(result)
+	invocation := log.Runs[0].Invocations[0]
+	if invocation.ExecutionSuccessful || len(invocation.ToolExecutionNotifications) != 1 ||
+		invocation.ToolExecutionNotifications[0].Descriptor.ID != "dependencies-outdated" {
+		t.Errorf("Expected a failed invocation with one notification, got %+v", invocation)
+	}
+	if len(log.Runs[0].Results) != 1 {
+		t.Errorf("Expected checker errors to stay out of the results, got %+v", log.Runs[0].Results)
+	}
+}
@@ -0,0 +1,188 @@
+package reporting
+
+import (
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/codcod/repos/internal/core"
+)
+
+const (
+	sarifVersion = "2.1.0"
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+)
+
+// SARIFLog is a minimal SARIF 2.1.0 document, as consumed by code scanning tools
+type SARIFLog struct {
+	Version string     `json:"version"`
+	Schema  string     `json:"$schema"`
+	Runs    []SARIFRun `json:"runs"`
+}
+
+// SARIFRun holds the results for a single repository
+type SARIFRun struct {
+	Tool        SARIFTool         `json:"tool"`
+	Invocations []SARIFInvocation `json:"invocations,omitempty"`
+	Results     []SARIFResult     `json:"results"`
+	Properties  map[string]string `json:"properties,omitempty"`
+}
+
+// SARIFInvocation reports whether every checker ran, with a notification for each one that did not
+type SARIFInvocation struct {
+	ExecutionSuccessful        bool                `json:"executionSuccessful"`
+	ToolExecutionNotifications []SARIFNotification `json:"toolExecutionNotifications,omitempty"`
+}
+
+// SARIFNotification describes a checker that could not run
+type SARIFNotification struct {
+	Level      string                   `json:"level"`
+	Message    SARIFMessage             `json:"message"`
+	Descriptor SARIFReportingDescriptor `json:"descriptor"`
+}
+
+// SARIFReportingDescriptor identifies the checker a notification is about
+type SARIFReportingDescriptor struct {
+	ID string `json:"id"`
+}
+
+// SARIFTool describes the tool that produced a run
+type SARIFTool struct {
+	Driver SARIFDriver `json:"driver"`
+}
+
+// SARIFDriver describes the health command and the rules it reports
+type SARIFDriver struct {
+	Name  string      `json:"name"`
+	Rules []SARIFRule `json:"rules"`
+}
+
+// SARIFRule describes a single checker
+type SARIFRule struct {
+	ID               string       `json:"id"`
+	Name             string       `json:"name"`
+	ShortDescription SARIFMessage `json:"shortDescription"`
+}
+
+// SARIFResult is a single finding
+type SARIFResult struct {
+	RuleID    string          `json:"ruleId"`
+	Level     string          `json:"level"`
+	Message   SARIFMessage    `json:"message"`
+	Locations []SARIFLocation `json:"locations,omitempty"`
+}
+
+// SARIFMessage holds plain-text message content
+type SARIFMessage struct {
+	Text string `json:"text"`
+}
+
+// SARIFLocation points at a file and line within the repository
+type SARIFLocation struct {
+	PhysicalLocation SARIFPhysicalLocation `json:"physicalLocation"`
+}
+
+// SARIFPhysicalLocation identifies an artifact and region
+type SARIFPhysicalLocation struct {
+	ArtifactLocation SARIFArtifactLocation `json:"artifactLocation"`
+	Region           *SARIFRegion          `json:"region,omitempty"`
+}
+
+// SARIFArtifactLocation identifies a file relative to the repository root
+type SARIFArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+// SARIFRegion identifies a position within a file
+type SARIFRegion struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn,omitempty"`
+	EndLine     int `json:"endLine,omitempty"`
+}
+
+// NewSARIFLog converts a workflow result into a SARIF log with one run per repository
+func NewSARIFLog(result core.WorkflowResult) SARIFLog {
+	log := SARIFLog{Version: sarifVersion, Schema: sarifSchema, Runs: []SARIFRun{}}
+
+	for _, repoResult := range result.RepositoryResults {
+		run := SARIFRun{
+			Tool:       SARIFTool{Driver: SARIFDriver{Name: "repos-health", Rules: []SARIFRule{}}},
+			Results:    []SARIFResult{},
+			Properties: map[string]string{"repository": repoResult.Repository.Name},
+		}
+
+		invocation := SARIFInvocation{ExecutionSuccessful: true}
+		rules := make(map[string]SARIFRule)
+		for _, checkResult := range repoResult.CheckResults {
+			for _, checkErr := range checkResult.Errors {
+				invocation.ExecutionSuccessful = false
+				invocation.ToolExecutionNotifications = append(invocation.ToolExecutionNotifications, SARIFNotification{
+					Level:      "error",
+					Message:    SARIFMessage{Text: checkErr.Message},
+					Descriptor: SARIFReportingDescriptor{ID: checkResult.ID},
+				})
+			}
+
+			for _, issue := range checkResult.Issues {
+				ruleID := fmt.Sprintf("%s/%s", checkResult.ID, issue.Type)
+				if _, exists := rules[ruleID]; !exists {
+					rules[ruleID] = SARIFRule{
+						ID:               ruleID,
+						Name:             checkResult.Name,
+						ShortDescription: SARIFMessage{Text: fmt.Sprintf("%s: %s", checkResult.Name, issue.Type)},
+					}
+				}
+
+				sarifResult := SARIFResult{
+					RuleID:  ruleID,
+					Level:   sarifLevel(issue.Severity),
+					Message: SARIFMessage{Text: issue.Message},
+				}
+				if issue.Location != nil && issue.Location.File != "" {
+					location := SARIFLocation{PhysicalLocation: SARIFPhysicalLocation{
+						ArtifactLocation: SARIFArtifactLocation{URI: relativeFilePath(issue.Location.File, repoResult.Repository.Path)},
+					}}
+					if issue.Location.Line > 0 {
+						location.PhysicalLocation.Region = &SARIFRegion{
+							StartLine:   issue.Location.Line,
+							StartColumn: issue.Location.Column,
+							EndLine:     issue.Location.EndLine,
+						}
+					}
+					sarifResult.Locations = []SARIFLocation{location}
+				}
+				run.Results = append(run.Results, sarifResult)
+			}
+		}
+
+		run.Invocations = []SARIFInvocation{invocation}
+
+		for _, rule := range rules {
+			run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, rule)
+		}
+		sort.Slice(run.Tool.Driver.Rules, func(i, j int) bool {
+			return run.Tool.Driver.Rules[i].ID < run.Tool.Driver.Rules[j].ID
+		})
+
+		log.Runs = append(log.Runs, run)
+	}
+
+	return log
+}
+
+// WriteSARIF writes the workflow result as a SARIF log
+func WriteSARIF(w io.Writer, result core.WorkflowResult) error {
+	return WriteJSON(w, NewSARIFLog(result))
+}
+
+// sarifLevel maps a finding severity to a SARIF result level
+func sarifLevel(severity core.Severity) string {
+	switch severity {
+	case core.SeverityCritical, core.SeverityHigh:
+		return "error"
+	case core.SeverityMedium:
+		return "warning"
+	default:
+		return "note"
+	}
+}
@@ -0,0 +1,325 @@
+package reporting
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/codcod/repos/internal/core"
+	healthconfig "github.com/codcod/repos/internal/health/config"
+	"github.com/fatih/color"
+)
+
+// ConsoleReport is the data a console template is executed with: the workflow
+// result, whose fields such as .RepositoryResults and .Summary are available
+// directly, and the formatter settings that change what is shown.
+//
+// Besides the text/template builtins, console templates can call:
+//
+//	color NAME TEXT          TEXT in green, red, yellow or grey, when colors are enabled
+//	emoji STATUS             status emoji, or a marker such as [OK] with --no-emoji
+//	checkEmoji STATUS        emoji of a check status
+//	statusText STATUS        Healthy, Warning, Critical, Errored or Unknown
+//	pluralize N ONE MANY     ONE if N is 1, otherwise MANY
+//	relPath PATH ROOT        PATH relative to the repository root ROOT
+//	first N LIST             at most the first N elements of LIST
+//	tooComplex FUNCTIONS     functions at or above the complexity threshold, most complex first
+//	longest FUNCTIONS N      the N longest functions of known length, longest first
+//	issueLabel ISSUE         " (known)" for baseline findings, " (info)" for advisories, otherwise ""
+//	toolUnavailable CHECK    whether a check's issues say its tool is not available
+//	round DURATION           DURATION rounded to milliseconds
+type ConsoleReport struct {
+	core.WorkflowResult
+	// Verbose is set by --verbose
+	Verbose bool
+}
+
+// StatusCount returns the number of repositories with the status, e.g.
+// {{$.StatusCount "healthy"}}
+func (r ConsoleReport) StatusCount(status core.HealthStatus) int {
+	return r.Summary.StatusCounts[status]
+}
+
+// DefaultConsoleTemplate is the layout of the console report. Control actions
+// start with {{- so that only content lines end up in the output; a line
+// holding just {{""}} prints an empty line before a conditional section.
+const DefaultConsoleTemplate = `{{color "green" "=== Repository Health Reports ==="}}
+{{- range $i, $repo := .RepositoryResults}}
+{{- if $i}}
+{{end}}
+{{color "red" (printf "Repository: %s" $repo.Repository.Name)}}
+Language: {{or $repo.Repository.Language "Unknown"}}
+Status: {{emoji $repo.Status}} {{statusText $repo.Status}} ({{$repo.Score}}/{{or $repo.MaxScore 100}})
+{{- if $.Verbose}}
+{{- range $repo.CategoryScores}}
+{{color "grey" (printf "  %s: %d/100 (weight %.1f)" .Category .Score .Weight)}}
+{{- end}}
+{{- end}}
+{{- with $repo.Subprojects}}
+Sub-projects: {{len .}}
+{{- range .}}
+  {{emoji .Status}} {{.Path}} ({{.Score}}/100)
+{{- if $.Verbose}}
+{{- range .CategoryScores}}
+{{color "grey" (printf "    %s: %d/100" .Category .Score)}}
+{{- end}}
+{{- end}}
+{{- end}}
+{{- end}}
+{{""}}
+{{- with $repo.CheckResults}}
+Health checks results
+{{- range .}}
+{{- if eq .Status "errored"}}
+{{checkEmoji .Status}} {{.Name}}{{with index .Metadata "subproject"}} [{{.}}]{{end}} ({{.Category}}): errored
+{{- range .Errors}}
+{{color "grey" (printf "  - %s" .Message)}}
+{{- end}}
+{{- else}}
+{{checkEmoji .Status}} {{.Name}}{{with index .Metadata "subproject"}} [{{.}}]{{end}} ({{.Category}}): {{if and (eq .Status "warning") (toolUnavailable .)}}unknown{{else}}{{.Score}}{{end}}
+{{- range first 3 .Issues}}
+{{color "grey" (printf "  - %s%s" .Message (issueLabel .))}}
+{{- end}}
+{{- end}}
+{{- end}}
+{{- end}}
+{{""}}
+{{- with $repo.AnalysisResult}}
+{{- with first 10 (tooComplex .Functions)}}
+Cyclomatic complexity
+{{- range .}}
+  - {{relPath .File $repo.Repository.Path}}:{{.Line}}:0: '{{.Name}}' is too complex ({{.Complexity}})
+{{- end}}
+{{- end}}
+{{- if $.Verbose}}
+{{- with longest .Functions 5}}
+Longest functions
+{{- range .}}
+  - {{relPath .File $repo.Repository.Path}}:{{.Line}}:0: '{{.Name}}' is {{.Lines}} {{pluralize .Lines "line" "lines"}} long
+{{- end}}
+{{- end}}
+{{- end}}
+{{- end}}
+{{- if $.Verbose}}
+{{- with $repo.Aggregate}}
+Analysis: {{.TotalFiles}} {{pluralize .TotalFiles "file" "files"}}, {{.TotalLines}} {{pluralize .TotalLines "line" "lines"}}, {{.TotalFunctions}} {{pluralize .TotalFunctions "function" "functions"}}, average complexity {{printf "%.1f" .AverageComplexity}} (max {{.MaxComplexity}})
+{{- range .Languages}}
+{{color "grey" (printf "  %s: %d %s, %d %s, average complexity %.1f" .Language .Files (pluralize .Files "file" "files") .Functions (pluralize .Functions "function" "functions") .AverageComplexity)}}
+{{- end}}
+{{- end}}
+{{- end}}
+{{- with $repo.AnalysisResult}}
+{{- with .Errors}}
+{{color "yellow" (printf "%s  %d %s could not be analyzed" (emoji "warning") (len .) (pluralize (len .) "file" "files"))}}
+{{- if $.Verbose}}
+{{- range .}}
+{{color "grey" (printf "  - %s: %s" (relPath .Path $repo.Repository.Path) .Reason)}}
+{{- end}}
+{{- end}}
+{{- end}}
+{{- end}}
+{{- end}}
+{{- with .Summary}}
+{{- if ge (len .Ranking) 2}}
+{{""}}
+{{color "green" "=== Summary ==="}}
+Repositories: {{len .Ranking}} ({{$.StatusCount "healthy"}} healthy, {{$.StatusCount "warning"}} warning, {{$.StatusCount "critical"}} critical, {{$.StatusCount "errored"}} errored)
+Average score: {{.AverageScore}}/100
+Ranking:
+{{- range .Ranking}}
+  {{printf "%3d." .Rank}} {{emoji .Status}} {{.Name}} ({{.Score}}/100)
+{{- end}}
+{{- with .Worst}}
+{{color "red" (printf "Lowest %d:" (len .))}}
+{{- range .}}
+  {{emoji .Status}} {{.Name}} ({{.Score}}/100)
+{{- end}}
+{{- end}}
+{{- end}}
+{{- with .Baseline}}
+{{""}}
+{{- if .NewFindings}}
+{{color "red" (printf "Baseline: %d new %s, %d known" .NewFindings (pluralize .NewFindings "finding" "findings") .KnownFindings)}}
+{{- else}}
+{{color "green" (printf "Baseline: no new findings (%d known)" .KnownFindings)}}
+{{- end}}
+{{- end}}
+{{- end}}
+{{- if .Verbose}}
+=== Timing Information ===
+{{- if and (not .StartTime.IsZero) (not .EndTime.IsZero)}}
+Total execution time: {{round (.EndTime.Sub .StartTime)}}
+{{- end}}
+{{- with .Summary.CheckerTimings}}
+Slowest checkers:
+{{- range first 10 .}}
+  {{printf "%-28s %10v total, %10v max over %d %s" .ID (round .Total) (round .Max) .Runs (pluralize .Runs "run" "runs")}}{{if .Subprocess}}, {{round .Subprocess}} in external commands{{end}}
+{{- end}}
+{{- end}}
+{{- end}}
+`
+
+// defaultConsoleTemplate is DefaultConsoleTemplate parsed
+var defaultConsoleTemplate = template.Must(parseConsoleTemplate(DefaultConsoleTemplate))
+
+// templateColors are the colors console templates can use by name
+var templateColors = map[string]color.Attribute{
+	"green":  color.FgGreen,
+	"red":    color.FgRed,
+	"yellow": color.FgYellow,
+	"grey":   color.FgHiBlack,
+}
+
+// templateFuncs returns the functions available to console templates, bound
+// to the formatter's color, emoji and complexity settings
+func (f *Formatter) templateFuncs() template.FuncMap {
+	return template.FuncMap{
+		"color": func(name, text string) (string, error) {
+			attribute, ok := templateColors[name]
+			if !ok {
+				return "", fmt.Errorf("unknown color %q (supported: green, red, yellow, grey)", name)
+			}
+			return f.paint(attribute).Sprint(text), nil
+		},
+		"emoji":      f.getStatusEmoji,
+		"checkEmoji": f.getCheckStatusEmoji,
+		"statusText": f.getStatusText,
+		"pluralize":  pluralize,
+		"relPath":    f.getRelativePath,
+		"first":      firstElements,
+		"tooComplex": f.getComplexFunctions,
+		"longest": func(functions []core.FunctionInfo, n int) []core.FunctionInfo {
+			return longestFunctions(functions, n)
+		},
+		"issueLabel": func(issue core.Issue) string {
+			if IsKnownFinding(issue) {
+				return " (known)"
+			}
+			if issue.Severity.Advisory() {
+				return " (info)"
+			}
+			return ""
+		},
+		"toolUnavailable": f.isToolUnavailableWarning,
+		"round": func(d time.Duration) time.Duration {
+			return d.Round(time.Millisecond)
+		},
+	}
+}
+
+// firstElements returns at most the first n elements of a slice
+func firstElements(n int, list interface{}) (interface{}, error) {
+	value := reflect.ValueOf(list)
+	if !value.IsValid() {
+		return list, nil
+	}
+	if value.Kind() != reflect.Slice && value.Kind() != reflect.Array {
+		return nil, fmt.Errorf("first: %s is not a list", value.Type())
+	}
+	if value.Len() > n {
+		value = value.Slice(0, n)
+	}
+	return value.Interface(), nil
+}
+
+// parseConsoleTemplate parses a console template with the template functions
+// of a default formatter; they are rebound to the actual formatter when it runs
+func parseConsoleTemplate(text string) (*template.Template, error) {
+	return template.New("console").Funcs(NewFormatter(false).templateFuncs()).Parse(text)
+}
+
+// ConsoleTemplate returns the template configured as reporters.console.template,
+// or nil for the built-in layout, which is also selected by "table". The value
+// is the template itself when it contains "{{", otherwise the path of a file
+// holding it. The template is executed once against a sample report so that
+// mistakes such as unknown fields are found before any check runs.
+func ConsoleTemplate(config core.Config) (*template.Template, error) {
+	reporter, ok := config.GetReporterConfig("console")
+	if !ok || reporter.Template == "" || reporter.Template == "table" {
+		return nil, nil
+	}
+
+	text := reporter.Template
+	if !strings.Contains(text, "{{") {
+		data, err := os.ReadFile(text) //nolint:gosec // Template path is provided by the user
+		if err != nil {
+			return nil, fmt.Errorf("reporters.console.template: %w", err)
+		}
+		text = string(data)
+	}
+
+	tmpl, err := parseConsoleTemplate(text)
+	if err != nil {
+		return nil, fmt.Errorf("reporters.console.template: %w", err)
+	}
+	if err := tmpl.Execute(io.Discard, sampleConsoleReport()); err != nil {
+		return nil, fmt.Errorf("reporters.console.template: %w", err)
+	}
+	return tmpl, nil
+}
+
+// sampleConsoleReport is a verbose report with every section filled in, used
+// to try out console templates
+func sampleConsoleReport() ConsoleReport {
+	now := time.Now()
+	ranking := []core.RepositoryRank{
+		{Rank: 1, Name: "web", Score: 90, Status: core.StatusHealthy},
+		{Rank: 2, Name: "legacy", Score: 40, Status: core.StatusCritical},
+	}
+	repository := core.RepositoryResult{
+		Repository:     core.Repository{Name: "web", Path: "/src/web", Language: "go"},
+		Status:         core.StatusHealthy,
+		Score:          90,
+		MaxScore:       100,
+		CategoryScores: []core.CategoryScore{{Category: "git", Score: 90, Weight: 1}},
+		Subprojects:    []core.SubprojectResult{{Name: "api", Path: "api", Status: core.StatusHealthy, Score: 90}},
+		CheckResults: []core.CheckResult{
+			{ID: "readme", Name: "README", Category: "documentation", Status: core.StatusWarning, Score: 60, MaxScore: 100,
+				Issues: []core.Issue{{Type: "missing_section", Severity: core.SeverityMedium, Message: "Missing usage section",
+					Location: &core.Location{File: "README.md", Line: 1}}},
+				Metadata: map[string]string{"subproject": "api"}},
+			core.ErroredResult("git-status", "Git Status", "git", "web", fmt.Errorf("git not found")),
+		},
+		AnalysisResult: &core.AnalysisResult{
+			Language:  "go",
+			Functions: []core.FunctionInfo{{Name: "main", File: "/src/web/main.go", Line: 1, EndLine: 40, Complexity: 12}},
+			Errors:    []core.AnalysisError{{Path: "/src/web/broken.go", Reason: "syntax error"}},
+		},
+		Aggregate: &core.AnalysisAggregate{TotalFiles: 1, TotalLines: 40, TotalFunctions: 1, AverageComplexity: 12, MaxComplexity: 12,
+			Languages: []core.LanguageMetrics{{Language: "go", Files: 1, Lines: 40, Functions: 1, AverageComplexity: 12, MaxComplexity: 12}}},
+	}
+	return ConsoleReport{
+		WorkflowResult: core.WorkflowResult{
+			StartTime:         now,
+			EndTime:           now.Add(time.Second),
+			TotalRepos:        1,
+			RepositoryResults: []core.RepositoryResult{repository},
+			Summary: core.WorkflowSummary{
+				AverageScore:   65,
+				StatusCounts:   map[core.HealthStatus]int{core.StatusHealthy: 1, core.StatusCritical: 1},
+				Ranking:        ranking,
+				Worst:          ranking[1:],
+				Baseline:       &core.BaselineSummary{KnownFindings: 1, NewFindings: 1},
+				CheckerTimings: []core.CheckerTiming{{ID: "readme", Name: "README", Runs: 1, Total: time.Second, Max: time.Second}},
+			},
+		},
+		Verbose: true,
+	}
+}
+
+// ConsoleTemplateRule reports a console template that does not parse or fails
+// on a sample report, so --validate-config finds it
+type ConsoleTemplateRule struct{}
+
+func (ConsoleTemplateRule) Validate(config *healthconfig.AdvancedConfig) error {
+	_, err := ConsoleTemplate(config)
+	return err
+}
+
+func (ConsoleTemplateRule) GetDescription() string {
+	return "Console Template"
+}
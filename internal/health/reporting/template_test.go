@@ -0,0 +1,76 @@
+package reporting
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/codcod/repos/internal/core"
+	healthconfig "github.com/codcod/repos/internal/health/config"
+)
+
+// consoleTemplateConfig returns a configuration with the console template set
+func consoleTemplateConfig(template string) *healthconfig.AdvancedConfig {
+	return &healthconfig.AdvancedConfig{Reporters: map[string]core.ReporterConfig{
+		"console": {Enabled: true, Template: template},
+	}}
+}
+
+func TestConsoleTemplate(t *testing.T) {
+	tmpl, err := ConsoleTemplate(consoleTemplateConfig(`Report for {{len .RepositoryResults}}
+{{- range .RepositoryResults}}
+{{emoji .Status}} {{.Repository.Name}} {{.Score}}{{if $.Verbose}} verbose{{end}}
+{{- end}}
+`))
+	if err != nil {
+		t.Fatalf("ConsoleTemplate failed: %v", err)
+	}
+
+	result := core.WorkflowResult{RepositoryResults: []core.RepositoryResult{
+		{Repository: core.Repository{Name: "web"}, Status: core.StatusCritical, Score: 40},
+	}}
+	out := captureStdout(t, func() {
+		NewFormatter(false, WithColor(false), WithEmoji(false), WithTemplate(tmpl)).DisplayResults(result)
+	})
+	if out != "Report for 1\n[FAIL] web 40\n" {
+		t.Errorf("Unexpected templated output %q", out)
+	}
+
+	for _, value := range []string{"", "table"} {
+		if tmpl, err := ConsoleTemplate(consoleTemplateConfig(value)); tmpl != nil || err != nil {
+			t.Errorf("Expected the built-in layout for %q, got %v, %v", value, tmpl, err)
+		}
+	}
+}
+
+func TestConsoleTemplate_File(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "report.tmpl")
+	if err := os.WriteFile(path, []byte("{{.Summary.AverageScore}}\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ConsoleTemplate(consoleTemplateConfig(path)); err != nil {
+		t.Errorf("Expected the template file to load, got %v", err)
+	}
+	if _, err := ConsoleTemplate(consoleTemplateConfig(path + ".missing")); err == nil {
+		t.Error("Expected an error for a missing template file")
+	}
+}
+
+func TestConsoleTemplate_Invalid(t *testing.T) {
+	for _, template := range []string{
+		"{{range .RepositoryResults}}",                 // Parse error
+		"{{.Repositories}}",                            // Unknown field
+		`{{color "blue" "text"}}`,                      // Unknown color
+		"{{range .RepositoryResults}}{{.Nope}}{{end}}", // Unknown field in a section
+	} {
+		_, err := ConsoleTemplate(consoleTemplateConfig(template))
+		if err == nil || !strings.HasPrefix(err.Error(), "reporters.console.template:") {
+			t.Errorf("%s: expected a template error, got %v", template, err)
+		}
+	}
+
+	if err := (ConsoleTemplateRule{}).Validate(consoleTemplateConfig("{{.Nope}}")); err == nil {
+		t.Error("Expected the validation rule to reject the template")
+	}
+}
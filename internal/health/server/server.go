@@ -0,0 +1,268 @@
+// Package server exposes health checks over HTTP so that a long-running service
+// can evaluate repositories on demand
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/codcod/repos/internal/core"
+	healthconfig "github.com/codcod/repos/internal/health/config"
+	"github.com/codcod/repos/internal/health/hooks"
+	"github.com/codcod/repos/internal/health/orchestration"
+	"github.com/codcod/repos/internal/health/reporting"
+)
+
+const (
+	// DefaultMaxConcurrent is the number of health checks run at the same time
+	DefaultMaxConcurrent = 4
+	// DefaultTimeout bounds a single health check request
+	DefaultTimeout = 5 * time.Minute
+
+	// maxRequestBytes caps the size of a request body
+	maxRequestBytes = 1 << 20
+)
+
+// errOutsideRoot rejects request paths outside the directory the server may check
+var errOutsideRoot = errors.New("path is outside the directory this server checks")
+
+// HealthRequest is the body of a POST /health request
+type HealthRequest struct {
+	Path       string   `json:"path"`
+	Categories []string `json:"categories,omitempty"`
+	// TimeoutSeconds shortens the server timeout for this request; it cannot extend it
+	TimeoutSeconds int `json:"timeout_seconds,omitempty"`
+}
+
+// errorResponse is the body of every failed request
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+// Server runs health checks for HTTP requests, reusing the orchestration engine
+type Server struct {
+	checkerRegistry  core.CheckerRegistry
+	analyzerRegistry core.AnalyzerRegistry
+	config           *healthconfig.AdvancedConfig
+	logger           core.Logger
+	root             string
+	timeout          time.Duration
+	slots            chan struct{}
+}
+
+// NewServer creates a server that checks only directories under root, the
+// working directory if empty, and runs at most maxConcurrent health checks at
+// a time, each bounded by timeout. Non-positive values use the defaults.
+func NewServer(
+	checkerRegistry core.CheckerRegistry,
+	analyzerRegistry core.AnalyzerRegistry,
+	config *healthconfig.AdvancedConfig,
+	logger core.Logger,
+	root string,
+	maxConcurrent int,
+	timeout time.Duration,
+) (*Server, error) {
+	if root == "" {
+		root = "."
+	}
+	absRoot, err := filepath.Abs(root)
+	if err != nil {
+		return nil, fmt.Errorf("invalid root %q: %w", root, err)
+	}
+	// Symlinks are resolved so that a link cannot lead a request outside the root
+	resolvedRoot, err := filepath.EvalSymlinks(absRoot)
+	if err != nil {
+		return nil, fmt.Errorf("root %q: %w", root, err)
+	}
+
+	if maxConcurrent <= 0 {
+		maxConcurrent = DefaultMaxConcurrent
+	}
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+
+	return &Server{
+		checkerRegistry:  checkerRegistry,
+		analyzerRegistry: analyzerRegistry,
+		config:           config,
+		logger:           logger,
+		root:             resolvedRoot,
+		timeout:          timeout,
+		slots:            make(chan struct{}, maxConcurrent),
+	}, nil
+}
+
+// Handler returns the HTTP routes: POST /health and GET /healthz
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/health", s.handleHealth)
+	mux.HandleFunc("/healthz", s.handleLiveness)
+	return mux
+}
+
+// ListenAndServe serves until ctx is cancelled, then waits for running
+// requests to finish before returning
+func (s *Server) ListenAndServe(ctx context.Context, addr string) error {
+	httpServer := &http.Server{
+		Addr:              addr,
+		Handler:           s.Handler(),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- httpServer.ListenAndServe()
+	}()
+
+	select {
+	case err := <-errCh:
+		return err
+	case <-ctx.Done():
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), s.timeout)
+	defer cancel()
+	if err := httpServer.Shutdown(shutdownCtx); err != nil {
+		return err
+	}
+	if err := <-errCh; !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// handleLiveness reports that the server is up
+func (s *Server) handleLiveness(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		writeError(w, http.StatusMethodNotAllowed, "use GET")
+		return
+	}
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	_, _ = fmt.Fprintln(w, "ok")
+}
+
+// handleHealth runs the health checks for one repository
+func (s *Server) handleHealth(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, "use POST")
+		return
+	}
+
+	var req HealthRequest
+	decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxRequestBytes))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid request body: %v", err))
+		return
+	}
+	repo, err := repositoryForPath(s.root, req.Path)
+	if errors.Is(err, errOutsideRoot) {
+		writeError(w, http.StatusForbidden, err.Error())
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	timeout := s.requestTimeout(req)
+	ctx, cancel := context.WithTimeout(r.Context(), timeout)
+	defer cancel()
+
+	// Wait for a free slot, but no longer than the request may take
+	select {
+	case s.slots <- struct{}{}:
+		defer func() { <-s.slots }()
+	case <-ctx.Done():
+		writeError(w, http.StatusServiceUnavailable, "server busy, try again later")
+		return
+	}
+
+	result, err := s.runHealthCheck(ctx, req, repo)
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		writeError(w, http.StatusGatewayTimeout, fmt.Sprintf("health check did not finish within %s", timeout))
+		return
+	case err != nil:
+		s.logger.Error("Health check request failed", core.String("path", repo.Path), core.Error("error", err))
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := reporting.WriteJSON(w, result); err != nil {
+		s.logger.Warn("Failed to write health check response", core.Error("error", err))
+	}
+}
+
+// requestTimeout returns the timeout a request asks for, capped at the server's
+// own timeout.
+func (s *Server) requestTimeout(req HealthRequest) time.Duration {
+	if requested := time.Duration(req.TimeoutSeconds) * time.Second; requested > 0 && requested < s.timeout {
+		return requested
+	}
+	return s.timeout
+}
+
+// runHealthCheck runs the requested categories on a repository. A run cut
+// short by ctx returns its error.
+func (s *Server) runHealthCheck(ctx context.Context, req HealthRequest, repo core.Repository) (*core.WorkflowResult, error) {
+	config := s.config.FilterByCategories(req.Categories)
+	engine := orchestration.NewEngine(s.checkerRegistry, s.analyzerRegistry, config, s.logger)
+	engine.SelectCheckers(req.Categories, nil)
+	engine.SetHooks(hooks.NewRunner(config.Extensions.Hooks, s.logger))
+
+	result, err := engine.ExecuteHealthCheck(ctx, []core.Repository{repo})
+	if err == nil && ctx.Err() != nil {
+		err = ctx.Err()
+	}
+	return result, err
+}
+
+// repositoryForPath describes the repository directory at path, which must be
+// under root. Relative paths are resolved against root.
+func repositoryForPath(root, path string) (core.Repository, error) {
+	if path == "" {
+		return core.Repository{}, errors.New("path is required")
+	}
+
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(root, path)
+	}
+	absPath, err := filepath.EvalSymlinks(filepath.Clean(path))
+	if err != nil {
+		return core.Repository{}, fmt.Errorf("path %q: %w", path, err)
+	}
+	if rel, err := filepath.Rel(root, absPath); err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return core.Repository{}, fmt.Errorf("%w: %q", errOutsideRoot, path)
+	}
+	info, err := os.Stat(absPath)
+	if err != nil {
+		return core.Repository{}, fmt.Errorf("path %q: %w", path, err)
+	}
+	if !info.IsDir() {
+		return core.Repository{}, fmt.Errorf("path %q is not a directory", path)
+	}
+
+	// The engine detects the language from the repository's files
+	return core.Repository{
+		Name:     filepath.Base(absPath),
+		Path:     absPath,
+		Metadata: make(map[string]string),
+	}, nil
+}
+
+// writeError writes a JSON error body with the given status
+func writeError(w http.ResponseWriter, status int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(errorResponse{Error: message})
+}
@@ -0,0 +1,149 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/codcod/repos/internal/core"
+	checker_registry "github.com/codcod/repos/internal/health/checkers/registry"
+	healthconfig "github.com/codcod/repos/internal/health/config"
+	"github.com/codcod/repos/internal/platform/commands"
+)
+
+type nopLogger struct{}
+
+func (nopLogger) Debug(string, ...core.Field) {}
+func (nopLogger) Info(string, ...core.Field)  {}
+func (nopLogger) Warn(string, ...core.Field)  {}
+func (nopLogger) Error(string, ...core.Field) {}
+func (nopLogger) Fatal(string, ...core.Field) {}
+
+// newTestServer creates a server that may check the temporary directories of tests
+func newTestServer(t *testing.T, maxConcurrent int) *Server {
+	t.Helper()
+	registry := checker_registry.NewCheckerRegistry(commands.NewMockCommandExecutor())
+	srv, err := NewServer(registry, nil, healthconfig.NewDefaultAdvancedConfig(), nopLogger{}, os.TempDir(), maxConcurrent, time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return srv
+}
+
+func postHealth(handler http.Handler, body string) *httptest.ResponseRecorder {
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/health", strings.NewReader(body)))
+	return recorder
+}
+
+func TestServer_Health(t *testing.T) {
+	repoPath := t.TempDir()
+	if err := os.WriteFile(filepath.Join(repoPath, "README.md"), []byte("# Project\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	body, _ := json.Marshal(HealthRequest{Path: repoPath, Categories: []string{"documentation"}})
+	recorder := postHealth(newTestServer(t, 1).Handler(), string(body))
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+
+	var result core.WorkflowResult
+	if err := json.Unmarshal(recorder.Body.Bytes(), &result); err != nil {
+		t.Fatalf("Invalid JSON response: %v", err)
+	}
+	if len(result.RepositoryResults) != 1 {
+		t.Fatalf("Expected one repository result, got %d", len(result.RepositoryResults))
+	}
+	repoResult := result.RepositoryResults[0]
+	if repoResult.Repository.Path != repoPath || len(repoResult.CheckResults) == 0 {
+		t.Fatalf("Unexpected repository result: %+v", repoResult)
+	}
+	for _, check := range repoResult.CheckResults {
+		if check.Category != "documentation" {
+			t.Errorf("Expected only documentation checks, got %s", check.ID)
+		}
+	}
+}
+
+func TestServer_HealthRejectsBadRequests(t *testing.T) {
+	handler := newTestServer(t, 1).Handler()
+	file := filepath.Join(t.TempDir(), "file.txt")
+	if err := os.WriteFile(file, nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string]string{
+		"invalid json":  `{"path":`,
+		"missing path":  `{"categories": ["git"]}`,
+		"unknown field": `{"path": "/tmp", "category": "git"}`,
+		"not directory": `{"path": "` + file + `"}`,
+	}
+	for name, body := range tests {
+		t.Run(name, func(t *testing.T) {
+			recorder := postHealth(handler, body)
+			if recorder.Code != http.StatusBadRequest {
+				t.Errorf("Expected 400, got %d", recorder.Code)
+			}
+			var response errorResponse
+			if err := json.Unmarshal(recorder.Body.Bytes(), &response); err != nil || response.Error == "" {
+				t.Errorf("Expected a JSON error, got %q", recorder.Body.String())
+			}
+		})
+	}
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/health", nil))
+	if recorder.Code != http.StatusMethodNotAllowed {
+		t.Errorf("Expected 405 for GET /health, got %d", recorder.Code)
+	}
+}
+
+func TestServer_HealthRejectsPathsOutsideRoot(t *testing.T) {
+	root := t.TempDir()
+	outside := t.TempDir()
+	if err := os.Symlink(outside, filepath.Join(root, "link")); err != nil {
+		t.Fatal(err)
+	}
+	registry := checker_registry.NewCheckerRegistry(commands.NewMockCommandExecutor())
+	srv, err := NewServer(registry, nil, healthconfig.NewDefaultAdvancedConfig(), nopLogger{}, root, 1, time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for name, path := range map[string]string{
+		"absolute": outside,
+		"relative": "../" + filepath.Base(outside),
+		"symlink":  filepath.Join(root, "link"),
+	} {
+		t.Run(name, func(t *testing.T) {
+			body, _ := json.Marshal(HealthRequest{Path: path})
+			if recorder := postHealth(srv.Handler(), string(body)); recorder.Code != http.StatusForbidden {
+				t.Errorf("Expected 403, got %d: %s", recorder.Code, recorder.Body.String())
+			}
+		})
+	}
+}
+
+func TestServer_HealthBusy(t *testing.T) {
+	srv := newTestServer(t, 1)
+	srv.slots <- struct{}{} // Occupy the only slot
+
+	recorder := postHealth(srv.Handler(), `{"path": "`+t.TempDir()+`", "timeout_seconds": 1}`)
+	if recorder.Code != http.StatusServiceUnavailable {
+		t.Errorf("Expected 503 while all slots are taken, got %d", recorder.Code)
+	}
+}
+
+func TestServer_Liveness(t *testing.T) {
+	recorder := httptest.NewRecorder()
+	newTestServer(t, 1).Handler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	if recorder.Code != http.StatusOK || strings.TrimSpace(recorder.Body.String()) != "ok" {
+		t.Errorf("Expected 200 ok, got %d %q", recorder.Code, recorder.Body.String())
+	}
+}
@@ -0,0 +1,112 @@
+// Package subprojects finds the projects of a monorepo: directories below the
+// repository root that contain a build manifest such as go.mod or package.json
+package subprojects
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// DefaultManifests are the file names that mark a sub-project when none are configured
+var DefaultManifests = []string{
+	"go.mod",
+	"package.json",
+	"pom.xml",
+	"build.gradle",
+	"build.gradle.kts",
+	"Cargo.toml",
+	"pyproject.toml",
+	"setup.py",
+	"composer.json",
+	"Gemfile",
+	"mix.exs",
+	"pubspec.yaml",
+}
+
+// DefaultMaxDepth is the deepest directory level searched when none is configured
+const DefaultMaxDepth = 3
+
+// skippedDirs hold dependencies or build output rather than projects
+var skippedDirs = map[string]bool{
+	"node_modules": true, "vendor": true, "third_party": true,
+	"target": true, "build": true, "dist": true,
+	"venv": true, "__pycache__": true, "testdata": true,
+}
+
+// Discover returns the paths, relative to repoPath and slash-separated, of the
+// directories that contain one of the manifests, searching at most maxDepth
+// levels below the root. The root itself is not a sub-project, and directories
+// inside a sub-project belong to it rather than forming sub-projects of their own.
+func Discover(repoPath string, manifests []string, maxDepth int) ([]string, error) {
+	if len(manifests) == 0 {
+		manifests = DefaultManifests
+	}
+	if maxDepth <= 0 {
+		maxDepth = DefaultMaxDepth
+	}
+
+	walk := &discovery{repoPath: repoPath, manifests: manifests, maxDepth: maxDepth}
+	if err := filepath.WalkDir(repoPath, walk.visit); err != nil {
+		return nil, err
+	}
+
+	sort.Strings(walk.found)
+	return walk.found, nil
+}
+
+// discovery collects the sub-projects found while walking a repository
+type discovery struct {
+	repoPath  string
+	manifests []string
+	maxDepth  int
+	found     []string
+}
+
+// visit is the filepath.WalkDirFunc of the search
+func (w *discovery) visit(path string, d fs.DirEntry, err error) error {
+	if err != nil {
+		if path == w.repoPath {
+			return err
+		}
+		return nil // Skip unreadable entries
+	}
+	if !d.IsDir() || path == w.repoPath {
+		return nil
+	}
+	if skipDir(d.Name()) {
+		return filepath.SkipDir
+	}
+
+	relPath, err := filepath.Rel(w.repoPath, path)
+	if err != nil {
+		return nil
+	}
+	relPath = filepath.ToSlash(relPath)
+
+	if hasManifest(path, w.manifests) {
+		w.found = append(w.found, relPath)
+		return filepath.SkipDir
+	}
+	if strings.Count(relPath, "/")+1 >= w.maxDepth {
+		return filepath.SkipDir
+	}
+	return nil
+}
+
+// skipDir reports whether a directory is hidden or holds dependencies or build output
+func skipDir(name string) bool {
+	return strings.HasPrefix(name, ".") || skippedDirs[name]
+}
+
+// hasManifest reports whether dir directly contains any of the manifests
+func hasManifest(dir string, manifests []string) bool {
+	for _, manifest := range manifests {
+		if info, err := os.Stat(filepath.Join(dir, manifest)); err == nil && !info.IsDir() {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,59 @@
+package subprojects
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func writeFiles(t *testing.T, root string, names ...string) {
+	t.Helper()
+	for _, name := range names {
+		path := filepath.Join(root, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte("x"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestDiscover(t *testing.T) {
+	root := t.TempDir()
+	writeFiles(t, root,
+		"package.json",                            // The root is not a sub-project
+		"services/api/go.mod",                     // Found
+		"services/api/web/package.json",           // Inside the api sub-project
+		"packages/ui/package.json",                // Found
+		"packages/ui/node_modules/x/package.json", // Dependency
+		"tools/deep/nested/project/go.mod",        // Below the default depth
+		".github/actions/check/package.json",      // Hidden directory
+		"docs/README.md",
+	)
+
+	found, err := Discover(root, nil, 0)
+	if err != nil {
+		t.Fatalf("Discover failed: %v", err)
+	}
+	expected := []string{"packages/ui", "services/api"}
+	if !reflect.DeepEqual(found, expected) {
+		t.Errorf("Expected %v, got %v", expected, found)
+	}
+
+	found, err = Discover(root, []string{"go.mod"}, 4)
+	if err != nil {
+		t.Fatalf("Discover failed: %v", err)
+	}
+	expected = []string{"services/api", "tools/deep/nested/project"}
+	if !reflect.DeepEqual(found, expected) {
+		t.Errorf("Expected %v with configured manifests and depth, got %v", expected, found)
+	}
+}
+
+func TestDiscover_MissingRepository(t *testing.T) {
+	if _, err := Discover(filepath.Join(t.TempDir(), "missing"), nil, 0); err == nil {
+		t.Error("Expected an error for a missing repository")
+	}
+}
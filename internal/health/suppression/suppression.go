@@ -0,0 +1,205 @@
+// Package suppression implements inline comments that acknowledge individual
+// findings, e.g.
+//
+//	legacy.Call() // health:ignore deprecated-components
+//
+// A comment suppresses findings on its own line and on the line below it. It
+// names the checker IDs or issue types it applies to, separated by commas or
+// spaces; "complexity" suppresses the complexity of the function declared
+// there, and a comment without names suppresses every finding.
+package suppression
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/codcod/repos/internal/core"
+)
+
+// Marker starts a suppression comment
+const Marker = "health:ignore"
+
+// ComplexityRule suppresses the complexity of a function
+const ComplexityRule = "complexity"
+
+// MetricName is the result metric counting suppressed findings
+const MetricName = "suppressed_findings"
+
+// commentTokens are the comment openers a marker may follow, covering C-style,
+// shell, SQL, Lisp-style and markup comments
+var commentTokens = []string{"//", "/*", "*", "#", "--", ";", "<!--"}
+
+// Index finds suppression comments in a repository's files, reading each file
+// at most once
+type Index struct {
+	repoPath string
+	files    map[string]map[int][]string // file -> line -> rules; nil rules suppress everything
+}
+
+// NewIndex creates an index for the repository at repoPath
+func NewIndex(repoPath string) *Index {
+	return &Index{repoPath: repoPath, files: make(map[string]map[int][]string)}
+}
+
+// Suppressed reports whether a finding at file:line is suppressed for any of
+// the rules. Relative paths are resolved against the repository.
+func (x *Index) Suppressed(file string, line int, rules ...string) bool {
+	if file == "" || line <= 0 {
+		return false
+	}
+
+	comments := x.comments(file)
+	for _, commentLine := range []int{line, line - 1} {
+		suppressed, found := comments[commentLine]
+		if !found {
+			continue
+		}
+		if suppressed == nil {
+			return true
+		}
+		for _, rule := range suppressed {
+			for _, candidate := range rules {
+				if rule == candidate {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// FilterIssues removes the suppressed issues of a check result and records how
+// many were removed. A check left without issues is reported healthy.
+func (x *Index) FilterIssues(result *core.CheckResult) int {
+	kept := result.Issues[:0]
+	suppressed := 0
+	for _, issue := range result.Issues {
+		if issue.Location != nil && x.Suppressed(issue.Location.File, issue.Location.Line, result.ID, issue.Type) {
+			suppressed++
+			continue
+		}
+		kept = append(kept, issue)
+	}
+	if suppressed == 0 {
+		return 0
+	}
+
+	result.Issues = kept
+	if result.Metrics == nil {
+		result.Metrics = make(map[string]interface{})
+	}
+	result.Metrics[MetricName] = suppressed
+	if len(kept) == 0 && result.Status != core.StatusErrored {
+		result.Status = core.StatusHealthy
+		result.Score = result.MaxScore
+	}
+	return suppressed
+}
+
+// FilterFunctions removes functions whose complexity is suppressed from an
+// analysis result and records how many were removed
+func (x *Index) FilterFunctions(result *core.AnalysisResult) int {
+	if result == nil {
+		return 0
+	}
+
+	kept := result.Functions[:0]
+	suppressed := 0
+	for _, fn := range result.Functions {
+		if x.Suppressed(fn.File, fn.Line, ComplexityRule) {
+			suppressed++
+			continue
+		}
+		kept = append(kept, fn)
+	}
+	if suppressed == 0 {
+		return 0
+	}
+
+	result.Functions = kept
+	if result.Metrics == nil {
+		result.Metrics = make(map[string]interface{})
+	}
+	result.Metrics[MetricName] = suppressed
+	return suppressed
+}
+
+// comments returns the suppression comments of a file by line
+func (x *Index) comments(file string) map[int][]string {
+	path := file
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(x.repoPath, filepath.FromSlash(file))
+	}
+	if comments, cached := x.files[path]; cached {
+		return comments
+	}
+
+	comments := make(map[int][]string)
+	x.files[path] = comments
+
+	f, err := os.Open(path) //nolint:gosec // Path is within the repository
+	if err != nil {
+		return comments
+	}
+	defer func() { _ = f.Close() }()
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+		if rules, ok := ParseComment(scanner.Text()); ok {
+			comments[lineNum] = rules
+		}
+	}
+	return comments
+}
+
+// ParseComment returns the rules named by a suppression comment on a line, and
+// whether the line has one. A comment without rules returns nil rules.
+func ParseComment(line string) ([]string, bool) {
+	idx := strings.Index(line, Marker)
+	if idx < 0 {
+		return nil, false
+	}
+
+	if !endsWithCommentToken(strings.TrimRight(line[:idx], " \t")) {
+		return nil, false
+	}
+
+	rest := line[idx+len(Marker):]
+	if rest != "" && rest[0] != ' ' && rest[0] != '\t' {
+		return nil, false // Part of a longer word, e.g. health:ignored
+	}
+	return commentRules(rest), true
+}
+
+// endsWithCommentToken reports whether the text before the marker opens a comment
+func endsWithCommentToken(prefix string) bool {
+	for _, token := range commentTokens {
+		if strings.HasSuffix(prefix, token) {
+			return true
+		}
+	}
+	return false
+}
+
+// commentRules returns the rules listed after the marker, up to the end of a
+// block comment, or nil if there are none
+func commentRules(rest string) []string {
+	for _, closer := range []string{"*/", "-->"} {
+		if end := strings.Index(rest, closer); end >= 0 {
+			rest = rest[:end]
+		}
+	}
+
+	rules := strings.FieldsFunc(rest, func(r rune) bool {
+		return r == ',' || r == ' ' || r == '\t'
+	})
+	if len(rules) == 0 {
+		return nil
+	}
+	return rules
+}
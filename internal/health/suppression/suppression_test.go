@@ -0,0 +1,102 @@
+package suppression
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/codcod/repos/internal/core"
+)
+
+func TestParseComment(t *testing.T) {
+	tests := []struct {
+		line  string
+		rules []string
+		found bool
+	}{
+		{"legacy.Call() // health:ignore deprecated-components", []string{"deprecated-components"}, true},
+		{"# health:ignore tech-debt, complexity", []string{"tech-debt", "complexity"}, true},
+		{"/* health:ignore complexity */ int f() {", []string{"complexity"}, true},
+		{"<!-- health:ignore broken_link -->", []string{"broken_link"}, true},
+		{"-- health:ignore", nil, true},
+		{`fmt.Println("health:ignore complexity")`, nil, false},
+		{"// health:ignored", nil, false},
+		{"plain code", nil, false},
+	}
+	for _, tt := range tests {
+		rules, found := ParseComment(tt.line)
+		if found != tt.found || !reflect.DeepEqual(rules, tt.rules) {
+			t.Errorf("%q: expected %v %v, got %v %v", tt.line, tt.rules, tt.found, rules, found)
+		}
+	}
+}
+
+func writeSource(t *testing.T) string {
+	t.Helper()
+	repoPath := t.TempDir()
+	source := "package main\n" +
+		"\n" +
+		"// health:ignore complexity\n" +
+		"func tangled() {}\n" +
+		"\n" +
+		"func plain() {} // TODO fix  health:ignore tech-debt\n" +
+		"// TODO later // health:ignore tech-debt\n" +
+		"var x = 1 // health:ignore\n"
+	if err := os.WriteFile(filepath.Join(repoPath, "main.go"), []byte(source), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return repoPath
+}
+
+func TestIndex_FilterIssues(t *testing.T) {
+	repoPath := writeSource(t)
+	issueAt := func(issueType string, line int) core.Issue {
+		return core.Issue{Type: issueType, Location: &core.Location{File: "main.go", Line: line}}
+	}
+
+	result := core.CheckResult{
+		ID:       "tech-debt",
+		Status:   core.StatusWarning,
+		Score:    60,
+		MaxScore: 100,
+		Issues: []core.Issue{
+			issueAt("tech_debt_hotspot", 6),        // marker not in a comment position
+			issueAt("tech_debt_hotspot", 7),        // same line, matched by checker ID
+			issueAt("tech_debt_hotspot", 8),        // line above is not a marker, own line has a bare marker
+			issueAt("tech_debt_hotspot", 4),        // line above only suppresses complexity
+			{Type: "tech_debt_threshold_exceeded"}, // no location
+		},
+	}
+
+	if suppressed := NewIndex(repoPath).FilterIssues(&result); suppressed != 2 {
+		t.Fatalf("Expected 2 suppressed issues, got %d: %+v", suppressed, result.Issues)
+	}
+	if len(result.Issues) != 3 || result.Metrics[MetricName] != 2 {
+		t.Errorf("Unexpected result: %+v", result)
+	}
+	if result.Status != core.StatusWarning {
+		t.Errorf("Expected status to be kept while issues remain, got %s", result.Status)
+	}
+
+	onlySuppressed := core.CheckResult{ID: "x", Status: core.StatusCritical, MaxScore: 100, Issues: []core.Issue{issueAt("any", 8)}}
+	NewIndex(repoPath).FilterIssues(&onlySuppressed)
+	if onlySuppressed.Status != core.StatusHealthy || onlySuppressed.Score != 100 {
+		t.Errorf("Expected a check without remaining issues to be healthy, got %+v", onlySuppressed)
+	}
+}
+
+func TestIndex_FilterFunctions(t *testing.T) {
+	repoPath := writeSource(t)
+	result := &core.AnalysisResult{Functions: []core.FunctionInfo{
+		{Name: "tangled", File: filepath.Join(repoPath, "main.go"), Line: 4, Complexity: 30},
+		{Name: "plain", File: filepath.Join(repoPath, "main.go"), Line: 6, Complexity: 1},
+	}}
+
+	if suppressed := NewIndex(repoPath).FilterFunctions(result); suppressed != 1 {
+		t.Fatalf("Expected 1 suppressed function, got %d", suppressed)
+	}
+	if len(result.Functions) != 1 || result.Functions[0].Name != "plain" || result.Metrics[MetricName] != 1 {
+		t.Errorf("Unexpected result: %+v", result)
+	}
+}
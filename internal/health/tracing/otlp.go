@@ -0,0 +1,349 @@
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	healthconfig "github.com/codcod/repos/internal/health/config"
+)
+
+const (
+	defaultServiceName  = "repos-health"
+	defaultOTLPTimeout  = 10 * time.Second
+	otlpTracesPath      = "/v1/traces"
+	exportBatchSize     = 512 // the default of the OpenTelemetry batch span processor
+	instrumentationName = "github.com/codcod/repos/internal/health"
+
+	// OTLP span kind and status codes
+	otlpSpanKindInternal = 1
+	otlpStatusError      = 2
+)
+
+// spanContextKey stores the active span in a context
+type spanContextKey struct{}
+
+// OTLPTracer sends ended spans to an OTLP/HTTP collector as JSON. Spans are
+// exported in the background in batches of exportBatchSize, and Shutdown
+// exports the rest.
+type OTLPTracer struct {
+	endpoint    string
+	serviceName string
+	headers     map[string]string
+	client      *http.Client
+
+	mu        sync.Mutex
+	spans     []*otlpSpan
+	exporting sync.WaitGroup
+	exportErr error
+}
+
+// NewTracer returns an OTLP tracer when an endpoint is configured, either in the
+// tracing integration or through the standard OTEL_EXPORTER_OTLP_* environment
+// variables, and the no-op tracer otherwise. The returned shutdown function
+// flushes recorded spans.
+func NewTracer(config healthconfig.TracingConfig) (Tracer, func(context.Context) error) {
+	endpoint := tracesEndpoint(config)
+	if endpoint == "" {
+		return Noop(), func(context.Context) error { return nil }
+	}
+
+	tracer := NewOTLPTracer(endpoint, config)
+	return tracer, tracer.Shutdown
+}
+
+// NewOTLPTracer creates a tracer that exports to the given traces endpoint
+func NewOTLPTracer(endpoint string, config healthconfig.TracingConfig) *OTLPTracer {
+	serviceName := config.ServiceName
+	if serviceName == "" {
+		serviceName = defaultServiceName
+	}
+	timeout := config.Timeout
+	if timeout <= 0 {
+		timeout = defaultOTLPTimeout
+	}
+
+	return &OTLPTracer{
+		endpoint:    endpoint,
+		serviceName: serviceName,
+		headers:     exportHeaders(config),
+		client:      &http.Client{Timeout: timeout},
+	}
+}
+
+// exportHeaders merges the headers from OTEL_EXPORTER_OTLP_HEADERS, then
+// OTEL_EXPORTER_OTLP_TRACES_HEADERS, then the configuration, later ones
+// replacing earlier ones with the same name
+func exportHeaders(config healthconfig.TracingConfig) map[string]string {
+	headers := make(map[string]string)
+	for _, variable := range []string{"OTEL_EXPORTER_OTLP_HEADERS", "OTEL_EXPORTER_OTLP_TRACES_HEADERS"} {
+		for key, value := range parseHeaders(os.Getenv(variable)) {
+			headers[key] = value
+		}
+	}
+	for key, value := range config.Headers {
+		headers[key] = value
+	}
+	return headers
+}
+
+// parseHeaders parses the key1=value1,key2=value2 list of the OTLP headers
+// variables, whose values are URL-encoded. Malformed entries are skipped.
+func parseHeaders(list string) map[string]string {
+	headers := make(map[string]string)
+	for _, entry := range strings.Split(list, ",") {
+		key, value, ok := strings.Cut(entry, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			continue
+		}
+		decoded, err := url.PathUnescape(strings.TrimSpace(value))
+		if err != nil {
+			continue
+		}
+		headers[key] = decoded
+	}
+	return headers
+}
+
+// tracesEndpoint resolves the OTLP traces URL. Base endpoints get /v1/traces
+// appended, as the OpenTelemetry exporters do; the traces-specific environment
+// variable is used as given.
+func tracesEndpoint(config healthconfig.TracingConfig) string {
+	endpoint := config.OTLPEndpoint
+	if endpoint == "" {
+		if traces := os.Getenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT"); traces != "" {
+			return traces
+		}
+		endpoint = os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT")
+	}
+	if endpoint == "" {
+		return ""
+	}
+
+	endpoint = strings.TrimSuffix(endpoint, "/")
+	if !strings.HasSuffix(endpoint, otlpTracesPath) {
+		endpoint += otlpTracesPath
+	}
+	return endpoint
+}
+
+// Start begins a span, parented to the span in ctx if there is one
+func (t *OTLPTracer) Start(ctx context.Context, name string, attrs ...Attribute) (context.Context, Span) {
+	span := &otlpSpan{
+		tracer: t,
+		name:   name,
+		spanID: randomHex(8),
+		start:  time.Now(),
+		attrs:  make(map[string]interface{}),
+	}
+	if parent, ok := ctx.Value(spanContextKey{}).(*otlpSpan); ok {
+		span.traceID = parent.traceID
+		span.parentID = parent.spanID
+	} else {
+		span.traceID = randomHex(16)
+	}
+	span.SetAttributes(attrs...)
+
+	return context.WithValue(ctx, spanContextKey{}, span), span
+}
+
+// Shutdown waits for the batches being exported, then exports the remaining
+// spans. It returns the first export error of the run.
+func (t *OTLPTracer) Shutdown(ctx context.Context) error {
+	t.exporting.Wait()
+
+	t.mu.Lock()
+	spans := t.spans
+	t.spans = nil
+	exportErr := t.exportErr
+	t.mu.Unlock()
+
+	if len(spans) > 0 {
+		if err := t.export(ctx, spans); err != nil && exportErr == nil {
+			exportErr = err
+		}
+	}
+	return exportErr
+}
+
+// record queues an ended span, and exports the queue in the background once
+// it holds a full batch
+func (t *OTLPTracer) record(span *otlpSpan) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.spans = append(t.spans, span)
+	if len(t.spans) < exportBatchSize {
+		return
+	}
+
+	batch := t.spans
+	t.spans = nil
+	t.exporting.Add(1)
+	go func() {
+		defer t.exporting.Done()
+		if err := t.export(context.Background(), batch); err != nil {
+			t.mu.Lock()
+			if t.exportErr == nil {
+				t.exportErr = err
+			}
+			t.mu.Unlock()
+		}
+	}()
+}
+
+// export sends a batch of spans to the collector
+func (t *OTLPTracer) export(ctx context.Context, spans []*otlpSpan) error {
+	body, err := json.Marshal(t.exportRequest(spans))
+	if err != nil {
+		return fmt.Errorf("failed to encode spans: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, t.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create OTLP request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for key, value := range t.headers {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := t.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to export spans: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode >= 300 {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("OTLP collector returned %d: %s", resp.StatusCode, strings.TrimSpace(string(message)))
+	}
+	return nil
+}
+
+// exportRequest builds an OTLP ExportTraceServiceRequest in its JSON encoding
+func (t *OTLPTracer) exportRequest(spans []*otlpSpan) map[string]interface{} {
+	encoded := make([]map[string]interface{}, 0, len(spans))
+	for _, span := range spans {
+		encoded = append(encoded, span.encode())
+	}
+
+	return map[string]interface{}{
+		"resourceSpans": []map[string]interface{}{{
+			"resource": map[string]interface{}{
+				"attributes": encodeAttributes(map[string]interface{}{"service.name": t.serviceName}),
+			},
+			"scopeSpans": []map[string]interface{}{{
+				"scope": map[string]interface{}{"name": instrumentationName},
+				"spans": encoded,
+			}},
+		}},
+	}
+}
+
+// otlpSpan is a span recorded by the OTLP tracer
+type otlpSpan struct {
+	tracer   *OTLPTracer
+	name     string
+	traceID  string
+	spanID   string
+	parentID string
+	start    time.Time
+
+	mu      sync.Mutex
+	end     time.Time
+	attrs   map[string]interface{}
+	errText string
+	ended   bool
+}
+
+func (s *otlpSpan) SetAttributes(attrs ...Attribute) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, attr := range attrs {
+		s.attrs[attr.Key] = attr.Value
+	}
+}
+
+func (s *otlpSpan) RecordError(err error) {
+	if err == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.errText = err.Error()
+}
+
+func (s *otlpSpan) End() {
+	s.mu.Lock()
+	if s.ended {
+		s.mu.Unlock()
+		return
+	}
+	s.ended = true
+	s.end = time.Now()
+	s.mu.Unlock()
+
+	s.tracer.record(s)
+}
+
+// encode returns the span in the OTLP JSON encoding
+func (s *otlpSpan) encode() map[string]interface{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	span := map[string]interface{}{
+		"traceId":           s.traceID,
+		"spanId":            s.spanID,
+		"name":              s.name,
+		"kind":              otlpSpanKindInternal,
+		"startTimeUnixNano": strconv.FormatInt(s.start.UnixNano(), 10),
+		"endTimeUnixNano":   strconv.FormatInt(s.end.UnixNano(), 10),
+		"attributes":        encodeAttributes(s.attrs),
+	}
+	if s.parentID != "" {
+		span["parentSpanId"] = s.parentID
+	}
+	if s.errText != "" {
+		span["status"] = map[string]interface{}{"code": otlpStatusError, "message": s.errText}
+	}
+	return span
+}
+
+// encodeAttributes converts attributes to OTLP KeyValue objects
+func encodeAttributes(attrs map[string]interface{}) []map[string]interface{} {
+	encoded := make([]map[string]interface{}, 0, len(attrs))
+	for key, value := range attrs {
+		var anyValue map[string]interface{}
+		switch v := value.(type) {
+		case string:
+			anyValue = map[string]interface{}{"stringValue": v}
+		case int64:
+			anyValue = map[string]interface{}{"intValue": strconv.FormatInt(v, 10)}
+		case bool:
+			anyValue = map[string]interface{}{"boolValue": v}
+		default:
+			anyValue = map[string]interface{}{"stringValue": fmt.Sprint(v)}
+		}
+		encoded = append(encoded, map[string]interface{}{"key": key, "value": anyValue})
+	}
+	return encoded
+}
+
+// randomHex returns n random bytes as a hex string
+func randomHex(n int) string {
+	b := make([]byte, n)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
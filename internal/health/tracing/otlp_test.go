@@ -0,0 +1,181 @@
+package tracing
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	healthconfig "github.com/codcod/repos/internal/health/config"
+)
+
+type otlpRequest struct {
+	ResourceSpans []struct {
+		ScopeSpans []struct {
+			Spans []struct {
+				TraceID      string `json:"traceId"`
+				SpanID       string `json:"spanId"`
+				ParentSpanID string `json:"parentSpanId"`
+				Name         string `json:"name"`
+				Attributes   []struct {
+					Key   string                 `json:"key"`
+					Value map[string]interface{} `json:"value"`
+				} `json:"attributes"`
+				Status *struct {
+					Code    int    `json:"code"`
+					Message string `json:"message"`
+				} `json:"status"`
+			} `json:"spans"`
+		} `json:"scopeSpans"`
+	} `json:"resourceSpans"`
+}
+
+func TestNewTracer_NoEndpointIsNoop(t *testing.T) {
+	t.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", "")
+	t.Setenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT", "")
+
+	tracer, shutdown := NewTracer(healthconfig.TracingConfig{})
+	if _, ok := tracer.(noopTracer); !ok {
+		t.Errorf("Expected no-op tracer, got %T", tracer)
+	}
+	if err := shutdown(context.Background()); err != nil {
+		t.Errorf("Unexpected shutdown error: %v", err)
+	}
+}
+
+func TestTracesEndpoint(t *testing.T) {
+	t.Setenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT", "")
+	t.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", "http://collector:4318/")
+	if got := tracesEndpoint(healthconfig.TracingConfig{}); got != "http://collector:4318/v1/traces" {
+		t.Errorf("Unexpected endpoint from environment: %s", got)
+	}
+
+	config := healthconfig.TracingConfig{OTLPEndpoint: "http://other:4318/v1/traces"}
+	if got := tracesEndpoint(config); got != "http://other:4318/v1/traces" {
+		t.Errorf("Unexpected configured endpoint: %s", got)
+	}
+}
+
+func TestExportHeaders(t *testing.T) {
+	t.Setenv("OTEL_EXPORTER_OTLP_HEADERS", "Authorization=Bearer%20env, X-Tenant=a,malformed")
+	t.Setenv("OTEL_EXPORTER_OTLP_TRACES_HEADERS", "X-Tenant=b")
+
+	headers := exportHeaders(healthconfig.TracingConfig{Headers: map[string]string{"X-Team": "health"}})
+	want := map[string]string{"Authorization": "Bearer env", "X-Tenant": "b", "X-Team": "health"}
+	if len(headers) != len(want) {
+		t.Fatalf("Expected headers %v, got %v", want, headers)
+	}
+	for key, value := range want {
+		if headers[key] != value {
+			t.Errorf("Expected header %s to be %q, got %q", key, value, headers[key])
+		}
+	}
+
+	configured := exportHeaders(healthconfig.TracingConfig{Headers: map[string]string{"Authorization": "Bearer config"}})
+	if configured["Authorization"] != "Bearer config" {
+		t.Errorf("Expected configured headers to replace environment ones, got %q", configured["Authorization"])
+	}
+}
+
+func TestOTLPTracer_ExportsFullBatchesBeforeShutdown(t *testing.T) {
+	var mu sync.Mutex
+	var batches []int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var received otlpRequest
+		if err := json.NewDecoder(r.Body).Decode(&received); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		mu.Lock()
+		batches = append(batches, len(received.ResourceSpans[0].ScopeSpans[0].Spans))
+		mu.Unlock()
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	tracer := NewOTLPTracer(server.URL+otlpTracesPath, healthconfig.TracingConfig{})
+	for i := 0; i < exportBatchSize+1; i++ {
+		_, span := tracer.Start(context.Background(), "health.checker")
+		span.End()
+	}
+
+	tracer.exporting.Wait()
+	mu.Lock()
+	if len(batches) != 1 || batches[0] != exportBatchSize {
+		t.Errorf("Expected one full batch before shutdown, got %v", batches)
+	}
+	mu.Unlock()
+
+	if err := tracer.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown failed: %v", err)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if len(batches) != 2 || batches[1] != 1 {
+		t.Errorf("Expected shutdown to export the remaining span, got %v", batches)
+	}
+}
+
+func TestOTLPTracer_ExportsParentAndChildSpans(t *testing.T) {
+	var received otlpRequest
+	var authorization string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization = r.Header.Get("Authorization")
+		if r.URL.Path != "/v1/traces" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if err := json.NewDecoder(r.Body).Decode(&received); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	tracer, shutdown := NewTracer(healthconfig.TracingConfig{
+		OTLPEndpoint: server.URL,
+		Headers:      map[string]string{"Authorization": "Bearer token"},
+	})
+
+	ctx, parent := tracer.Start(context.Background(), "health.workflow")
+	_, child := tracer.Start(ctx, "health.checker", String("checker.id", "readme"), Int("checker.duration_ms", 12))
+	child.RecordError(errors.New("boom"))
+	child.End()
+	parent.End()
+
+	if err := shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown failed: %v", err)
+	}
+	if authorization != "Bearer token" {
+		t.Errorf("Expected configured headers to be sent, got %q", authorization)
+	}
+
+	spans := received.ResourceSpans[0].ScopeSpans[0].Spans
+	if len(spans) != 2 {
+		t.Fatalf("Expected 2 spans, got %d", len(spans))
+	}
+	checker, workflow := spans[0], spans[1]
+	if checker.Name != "health.checker" || workflow.Name != "health.workflow" {
+		t.Fatalf("Unexpected span order: %s, %s", checker.Name, workflow.Name)
+	}
+	if checker.TraceID != workflow.TraceID || checker.ParentSpanID != workflow.SpanID || workflow.ParentSpanID != "" {
+		t.Errorf("Expected checker span to be a child of the workflow span")
+	}
+	if checker.Status == nil || checker.Status.Code != otlpStatusError || checker.Status.Message != "boom" {
+		t.Errorf("Expected error status on checker span, got %+v", checker.Status)
+	}
+
+	attrs := make(map[string]interface{})
+	for _, attr := range checker.Attributes {
+		for _, value := range attr.Value {
+			attrs[attr.Key] = value
+		}
+	}
+	if attrs["checker.id"] != "readme" || attrs["checker.duration_ms"] != "12" {
+		t.Errorf("Unexpected attributes: %v", attrs)
+	}
+}
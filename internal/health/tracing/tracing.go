@@ -0,0 +1,70 @@
+// Package tracing provides lightweight span tracing for health check runs.
+//
+// The engine starts spans through the Tracer interface. The
+// default tracer is a no-op, so tracing costs nothing unless an exporter such as
+// the OTLP tracer is configured.
+package tracing
+
+import (
+	"context"
+	"time"
+)
+
+// Tracer starts spans. A span started from a context that carries another span
+// becomes its child.
+type Tracer interface {
+	Start(ctx context.Context, name string, attrs ...Attribute) (context.Context, Span)
+}
+
+// Span is a timed operation within a trace
+type Span interface {
+	// SetAttributes adds or replaces attributes on the span
+	SetAttributes(attrs ...Attribute)
+	// RecordError marks the span as failed
+	RecordError(err error)
+	// End finishes the span; later calls have no effect
+	End()
+}
+
+// Attribute is a key/value pair attached to a span
+type Attribute struct {
+	Key   string
+	Value interface{}
+}
+
+// String creates a string attribute
+func String(key, value string) Attribute {
+	return Attribute{Key: key, Value: value}
+}
+
+// Int creates an integer attribute
+func Int(key string, value int) Attribute {
+	return Attribute{Key: key, Value: int64(value)}
+}
+
+// Bool creates a boolean attribute
+func Bool(key string, value bool) Attribute {
+	return Attribute{Key: key, Value: value}
+}
+
+// Duration creates an attribute holding the duration in milliseconds
+func Duration(key string, value time.Duration) Attribute {
+	return Attribute{Key: key, Value: value.Milliseconds()}
+}
+
+// Noop returns a tracer that records nothing
+func Noop() Tracer {
+	return noopTracer{}
+}
+
+type noopTracer struct{}
+
+func (noopTracer) Start(ctx context.Context, _ string, _ ...Attribute) (context.Context, Span) {
+	return ctx, noopSpan{}
+}
+
+type noopSpan struct{}
+
+func (noopSpan) SetAttributes(...Attribute) {}
+func (noopSpan) RecordError(error)          {}
+func (noopSpan) End()                       {}
@@ -0,0 +1,80 @@
+package watch
+
+import (
+	"path/filepath"
+
+	"github.com/codcod/repos/internal/core"
+)
+
+// MergeAnalysis updates a repository's full analysis with one that covered only
+// the changed files. Results for changed files are taken from the partial
+// analysis, so files that were deleted drop out; totals are recomputed.
+func MergeAnalysis(previous, partial *core.AnalysisResult, changed []string) *core.AnalysisResult {
+	if previous == nil {
+		return partial
+	}
+	if partial == nil {
+		partial = &core.AnalysisResult{}
+	}
+
+	kept := unchanged(changed)
+	merged := &core.AnalysisResult{
+		Language: previous.Language,
+		Files:    mergeFiles(previous.Files, partial.Files, kept),
+		Metrics:  make(map[string]interface{}, len(previous.Metrics)),
+	}
+
+	for _, fn := range previous.Functions {
+		if kept(fn.File) {
+			merged.Functions = append(merged.Functions, fn)
+		}
+	}
+	merged.Functions = append(merged.Functions, partial.Functions...)
+	core.SortFunctions(merged.Functions)
+
+	for _, pattern := range previous.Patterns {
+		if kept(pattern.Location.File) {
+			merged.Patterns = append(merged.Patterns, pattern)
+		}
+	}
+	merged.Patterns = append(merged.Patterns, partial.Patterns...)
+
+	for _, analysisErr := range previous.Errors {
+		if kept(analysisErr.Path) {
+			merged.Errors = append(merged.Errors, analysisErr)
+		}
+	}
+	merged.Errors = append(merged.Errors, partial.Errors...)
+
+	for key, value := range previous.Metrics {
+		merged.Metrics[key] = value
+	}
+	merged.RecomputeTotals()
+	return merged
+}
+
+// mergeFiles returns the kept file results of the previous analysis with those
+// of the partial one
+func mergeFiles(previous, partial map[string]*core.FileAnalysis, kept func(string) bool) map[string]*core.FileAnalysis {
+	merged := make(map[string]*core.FileAnalysis, len(previous))
+	for path, file := range previous {
+		if kept(path) {
+			merged[path] = file
+		}
+	}
+	for path, file := range partial {
+		merged[path] = file
+	}
+	return merged
+}
+
+// unchanged returns a function reporting whether a file is not one of changed
+func unchanged(changed []string) func(string) bool {
+	isChanged := make(map[string]bool, len(changed))
+	for _, file := range changed {
+		isChanged[filepath.Clean(file)] = true
+	}
+	return func(file string) bool {
+		return !isChanged[filepath.Clean(file)]
+	}
+}
@@ -0,0 +1,201 @@
+// Package watch reports file changes in repositories so health checks can be
+// re-run while developing. Changes are debounced: a burst of writes, such as a
+// formatter rewriting many files or an editor's save sequence, is delivered as
+// one batch once the repositories have been quiet for the debounce interval.
+package watch
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+
+	"github.com/codcod/repos/internal/core"
+)
+
+// DefaultDebounce is how long the repositories must be quiet before a batch of
+// changes is delivered
+const DefaultDebounce = 500 * time.Millisecond
+
+// skipDirs are directories whose changes never affect health checks or that
+// change too often to watch
+var skipDirs = map[string]bool{".git": true, "node_modules": true, "vendor": true, "__pycache__": true, ".terraform": true}
+
+// Changes maps repository names to the files that changed in them, sorted.
+// File paths start with the repository's configured path, like the paths
+// analyzers report.
+type Changes map[string][]string
+
+// Repositories returns the names of the changed repositories, sorted
+func (c Changes) Repositories() []string {
+	names := make([]string, 0, len(c))
+	for name := range c {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// sortFiles sorts the files of every repository
+func (c Changes) sortFiles() {
+	for _, files := range c {
+		sort.Strings(files)
+	}
+}
+
+// Watcher watches the directories of repositories for file changes
+type Watcher struct {
+	fsw      *fsnotify.Watcher
+	roots    map[string]core.Repository // Absolute repository path -> repository
+	debounce time.Duration
+}
+
+// New starts watching every directory of the repositories. A debounce of zero
+// uses DefaultDebounce.
+func New(repos []core.Repository, debounce time.Duration) (*Watcher, error) {
+	if debounce <= 0 {
+		debounce = DefaultDebounce
+	}
+
+	fsw, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create file watcher: %w", err)
+	}
+
+	w := &Watcher{fsw: fsw, roots: make(map[string]core.Repository), debounce: debounce}
+	for _, repo := range repos {
+		root, err := filepath.Abs(repo.Path)
+		if err != nil {
+			root = repo.Path
+		}
+		if err := w.addTree(root); err != nil {
+			_ = fsw.Close()
+			return nil, fmt.Errorf("failed to watch %s: %w", repo.Name, err)
+		}
+		w.roots[root] = repo
+	}
+	return w, nil
+}
+
+// Close stops watching
+func (w *Watcher) Close() error {
+	return w.fsw.Close()
+}
+
+// Run delivers batches of changes to onChange until ctx is cancelled. onChange
+// runs on Run's goroutine; changes made while it runs are collected for the
+// next batch.
+func (w *Watcher) Run(ctx context.Context, onChange func(Changes)) error {
+	pending := make(Changes)
+	seen := make(map[string]bool)
+	timer := time.NewTimer(w.debounce)
+	timer.Stop()
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case err, ok := <-w.fsw.Errors:
+			if !ok {
+				return nil
+			}
+			return fmt.Errorf("file watcher failed: %w", err)
+		case event, ok := <-w.fsw.Events:
+			if !ok {
+				return nil
+			}
+			if !w.record(event, pending, seen) {
+				continue
+			}
+			timer.Reset(w.debounce)
+		case <-timer.C:
+			if len(pending) == 0 {
+				continue
+			}
+			pending.sortFiles()
+			batch := pending
+			pending = make(Changes)
+			seen = make(map[string]bool)
+			onChange(batch)
+		}
+	}
+}
+
+// record adds an event's file to the pending changes and reports whether it is
+// relevant. New directories are watched as well.
+func (w *Watcher) record(event fsnotify.Event, pending Changes, seen map[string]bool) bool {
+	if event.Op == fsnotify.Chmod || ignoredFile(event.Name) {
+		return false
+	}
+
+	repo, rel, ok := w.repository(event.Name)
+	if !ok {
+		return false
+	}
+
+	if event.Has(fsnotify.Create) {
+		if info, err := os.Stat(event.Name); err == nil && info.IsDir() {
+			if !skipDirs[info.Name()] {
+				_ = w.addTree(event.Name) // Files created inside are reported by later events
+			}
+			return false
+		}
+	}
+
+	path := filepath.Join(repo.Path, rel)
+	if !seen[path] {
+		seen[path] = true
+		pending[repo.Name] = append(pending[repo.Name], path)
+	}
+	return true
+}
+
+// repository returns the repository containing path and the path relative to
+// it. Paths inside skipped directories belong to no repository.
+func (w *Watcher) repository(path string) (core.Repository, string, bool) {
+	for root, repo := range w.roots {
+		rel, err := filepath.Rel(root, path)
+		if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			continue
+		}
+		for _, part := range strings.Split(filepath.Dir(rel), string(filepath.Separator)) {
+			if skipDirs[part] {
+				return core.Repository{}, "", false
+			}
+		}
+		return repo, rel, true
+	}
+	return core.Repository{}, "", false
+}
+
+// addTree watches a directory and its subdirectories
+func (w *Watcher) addTree(root string) error {
+	return filepath.WalkDir(root, func(path string, entry os.DirEntry, err error) error {
+		if err != nil {
+			if path == root {
+				return err
+			}
+			return nil // Skip unreadable directories
+		}
+		if !entry.IsDir() {
+			return nil
+		}
+		if path != root && skipDirs[entry.Name()] {
+			return filepath.SkipDir
+		}
+		return w.fsw.Add(path)
+	})
+}
+
+// ignoredFile reports whether a file is an editor's temporary file
+func ignoredFile(path string) bool {
+	name := filepath.Base(path)
+	return strings.HasSuffix(name, "~") || strings.HasSuffix(name, ".swp") ||
+		strings.HasSuffix(name, ".swx") || strings.HasPrefix(name, ".#")
+}
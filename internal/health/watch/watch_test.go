@@ -0,0 +1,109 @@
+package watch
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/codcod/repos/internal/core"
+)
+
+func TestWatcher_DebouncesChanges(t *testing.T) {
+	repoPath := t.TempDir()
+	for _, dir := range []string{"pkg", ".git", "node_modules"} {
+		if err := os.Mkdir(filepath.Join(repoPath, dir), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	watcher, err := New([]core.Repository{{Name: "app", Path: repoPath}}, 100*time.Millisecond)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer watcher.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	batches := make(chan Changes, 4)
+	go func() {
+		_ = watcher.Run(ctx, func(changes Changes) { batches <- changes })
+	}()
+
+	// A burst of writes, including ignored ones, arrives as one batch
+	for _, name := range []string{"main.go", "pkg/util.go", "main.go", ".git/index", "node_modules/x.js", "main.go~"} {
+		if err := os.WriteFile(filepath.Join(repoPath, name), []byte("package x\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	select {
+	case changes := <-batches:
+		expected := Changes{"app": {filepath.Join(repoPath, "main.go"), filepath.Join(repoPath, "pkg", "util.go")}}
+		if !reflect.DeepEqual(changes, expected) {
+			t.Errorf("Expected %v, got %v", expected, changes)
+		}
+	case <-ctx.Done():
+		t.Fatal("Timed out waiting for changes")
+	}
+
+	// Directories created while watching are watched too
+	if err := os.Mkdir(filepath.Join(repoPath, "internal"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(50 * time.Millisecond)
+	if err := os.WriteFile(filepath.Join(repoPath, "internal", "new.go"), []byte("package x\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case changes := <-batches:
+		if files := changes["app"]; len(files) != 1 || files[0] != filepath.Join(repoPath, "internal", "new.go") {
+			t.Errorf("Expected the file in the new directory, got %v", changes)
+		}
+	case <-ctx.Done():
+		t.Fatal("Timed out waiting for changes in a new directory")
+	}
+}
+
+func TestMergeAnalysis(t *testing.T) {
+	previous := &core.AnalysisResult{
+		Language: "go",
+		Files: map[string]*core.FileAnalysis{
+			"app/a.go": {Path: "app/a.go", Lines: 10},
+			"app/b.go": {Path: "app/b.go", Lines: 20},
+		},
+		Functions: []core.FunctionInfo{
+			{Name: "A", File: "app/a.go", Line: 1, Complexity: 2},
+			{Name: "B", File: "app/b.go", Line: 1, Complexity: 8},
+		},
+		Metrics: map[string]interface{}{"detected_cycles": 0},
+	}
+	partial := &core.AnalysisResult{
+		Files:     map[string]*core.FileAnalysis{"app/a.go": {Path: "app/a.go", Lines: 30}},
+		Functions: []core.FunctionInfo{{Name: "A2", File: "app/a.go", Line: 5, Complexity: 4}},
+	}
+
+	// b.go was deleted, so the partial analysis has nothing for it
+	merged := MergeAnalysis(previous, partial, []string{"app/a.go", "app/b.go"})
+
+	if merged.TotalFiles != 1 || merged.TotalLines != 30 || merged.TotalFunctions != 1 {
+		t.Errorf("Unexpected totals: files=%d lines=%d functions=%d", merged.TotalFiles, merged.TotalLines, merged.TotalFunctions)
+	}
+	if merged.Functions[0].Name != "A2" || merged.AverageComplexity != 4 {
+		t.Errorf("Expected the re-analyzed function only, got %+v", merged.Functions)
+	}
+	if merged.Metrics["max_complexity"] != 4 || merged.Metrics["detected_cycles"] != 0 {
+		t.Errorf("Expected recomputed and kept metrics, got %v", merged.Metrics)
+	}
+	if len(previous.Functions) != 2 {
+		t.Error("Expected the previous analysis to be left unchanged")
+	}
+
+	unchanged := MergeAnalysis(previous, &core.AnalysisResult{}, []string{"app/README.md"})
+	if unchanged.TotalFunctions != 2 || unchanged.Metrics["total_complexity"] != 10 {
+		t.Errorf("Expected an unrelated change to keep the analysis, got %+v", unchanged)
+	}
+}
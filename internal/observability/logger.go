@@ -256,6 +256,8 @@ func (l *CheckerLogger) LogResult(result core.RepositoryResult) {
 		l.WithFields(fields).Warn("check completed with warnings")
 	case core.StatusCritical:
 		l.WithFields(fields).Error("check completed with critical issues")
+	case core.StatusErrored:
+		l.WithFields(fields).Error("check could not run")
 	default:
 		l.WithFields(fields).Info("check completed")
 	}
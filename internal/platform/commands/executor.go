@@ -63,6 +63,7 @@ func (e *OSCommandExecutor) ExecuteInDir(ctx context.Context, dir, command strin
 
 	err := cmd.Run()
 	duration := time.Since(start)
+	recordDuration(ctx, duration)
 
 	result := CommandResult{
 		Stdout:   stdout.String(),
@@ -98,6 +99,7 @@ func (e *OSCommandExecutor) ExecuteWithTimeout(ctx context.Context, timeout time
 
 	err := cmd.Run()
 	duration := time.Since(start)
+	recordDuration(ctx, duration)
 
 	result := CommandResult{
 		Stdout:   stdout.String(),
@@ -165,10 +167,12 @@ func (m *MockCommandExecutor) Execute(ctx context.Context, command string, args
 	})
 
 	if result, exists := m.responses[fullCommand]; exists {
+		recordDuration(ctx, result.Duration)
 		return result
 	}
 
 	// Default response
+	recordDuration(ctx, 100*time.Millisecond)
 	return CommandResult{
 		ExitCode: 0,
 		Stdout:   "mock output",
@@ -192,10 +196,12 @@ func (m *MockCommandExecutor) ExecuteInDir(ctx context.Context, dir, command str
 	})
 
 	if result, exists := m.responses[fullCommand]; exists {
+		recordDuration(ctx, result.Duration)
 		return result
 	}
 
 	// Default response
+	recordDuration(ctx, 100*time.Millisecond)
 	return CommandResult{
 		ExitCode: 0,
 		Stdout:   "mock output from " + dir,
@@ -332,3 +332,16 @@ func indexOf(s, substr string) int {
 	}
 	return -1
 }
+
+func TestWithTimer(t *testing.T) {
+	executor := NewOSCommandExecutor(10 * time.Second)
+	ctx, timer := WithTimer(context.Background())
+
+	first := executor.Execute(ctx, "echo", "one")
+	second := executor.ExecuteInDir(ctx, "/tmp", "echo", "two")
+	executor.Execute(context.Background(), "echo", "untimed")
+
+	if timer.Total() != first.Duration+second.Duration {
+		t.Errorf("Expected %v in commands, got %v", first.Duration+second.Duration, timer.Total())
+	}
+}
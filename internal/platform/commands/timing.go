@@ -0,0 +1,35 @@
+package commands
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+)
+
+// timerKey is the context key of a Timer
+type timerKey struct{}
+
+// Timer adds up the time spent running commands with a context returned by
+// WithTimer, e.g. the external tools run by one checker. It is safe for
+// concurrent use.
+type Timer struct {
+	total atomic.Int64
+}
+
+// WithTimer returns a context whose commands are timed by the returned Timer
+func WithTimer(ctx context.Context) (context.Context, *Timer) {
+	timer := &Timer{}
+	return context.WithValue(ctx, timerKey{}, timer), timer
+}
+
+// Total returns the time spent in commands so far
+func (t *Timer) Total() time.Duration {
+	return time.Duration(t.total.Load())
+}
+
+// recordDuration adds a command's duration to the context's Timer, if any
+func recordDuration(ctx context.Context, d time.Duration) {
+	if timer, ok := ctx.Value(timerKey{}).(*Timer); ok {
+		timer.total.Add(int64(d))
+	}
+}
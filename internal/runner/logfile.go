@@ -0,0 +1,266 @@
+package runner
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/codcod/repos/internal/config"
+	"github.com/codcod/repos/internal/util"
+)
+
+// Log formats of the per-repository log files
+const (
+	LogFormatText = "text"
+	LogFormatJSON = "json"
+)
+
+// LogOptions configures the per-repository log files written by RunCommandWithOptions
+type LogOptions struct {
+	Dir      string // Directory of the log files; empty disables logging
+	MaxSize  int64  // Size in bytes at which a text log is rotated and JSON output is truncated; 0 means no limit
+	MaxFiles int    // Log files kept per repository, rotated ones included; 0 keeps all
+	Format   string // LogFormatText (the default) or LogFormatJSON
+}
+
+// Validate checks the format and limits
+func (o LogOptions) Validate() error {
+	switch o.Format {
+	case "", LogFormatText, LogFormatJSON:
+	default:
+		return fmt.Errorf("unknown log format %q, use %s or %s", o.Format, LogFormatText, LogFormatJSON)
+	}
+	if o.MaxSize < 0 {
+		return fmt.Errorf("log max size must not be negative")
+	}
+	if o.MaxFiles < 0 {
+		return fmt.Errorf("log max files must not be negative")
+	}
+	return nil
+}
+
+// CommandRecord is the JSON log record of a command run in one repository
+type CommandRecord struct {
+	Repository string    `json:"repository"`
+	Command    string    `json:"command"`
+	Directory  string    `json:"directory"`
+	StartTime  time.Time `json:"start_time"`
+	DurationMs int64     `json:"duration_ms"`
+	ExitCode   int       `json:"exit_code"`
+	Stdout     string    `json:"stdout"`
+	Stderr     string    `json:"stderr"`
+	// Truncated is set when output beyond the log max size was dropped
+	Truncated bool   `json:"truncated,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
+
+// rotatingFile is a log file that is moved aside to path.1, path.2, ... when a
+// write would grow it past maxSize. Older logs of the repository beyond
+// maxFiles are removed on each rotation.
+type rotatingFile struct {
+	mu       sync.Mutex
+	file     *os.File
+	path     string
+	repoName string
+	size     int64
+	maxSize  int64
+	maxFiles int
+}
+
+// newRotatingFile wraps a log file that was just created and may hold a header
+func newRotatingFile(file *os.File, repoName string, options LogOptions) (*rotatingFile, error) {
+	info, err := file.Stat()
+	if err != nil {
+		return nil, err
+	}
+	return &rotatingFile{
+		file:     file,
+		path:     file.Name(),
+		repoName: repoName,
+		size:     info.Size(),
+		maxSize:  options.MaxSize,
+		maxFiles: options.MaxFiles,
+	}, nil
+}
+
+// Write appends to the log, rotating it first when it would grow past maxSize
+func (f *rotatingFile) Write(p []byte) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.maxSize > 0 && f.size > 0 && f.size+int64(len(p)) > f.maxSize {
+		if err := f.rotate(); err != nil {
+			return 0, err
+		}
+	}
+	n, err := f.file.Write(p)
+	f.size += int64(n)
+	return n, err
+}
+
+// rotate shifts the existing backups up by one, moves the log to path.1 and
+// reopens an empty log
+func (f *rotatingFile) rotate() error {
+	if err := f.file.Close(); err != nil {
+		return err
+	}
+
+	backups, _ := filepath.Glob(f.path + ".*")
+	for i := len(backups); i >= 1; i-- {
+		_ = os.Rename(fmt.Sprintf("%s.%d", f.path, i), fmt.Sprintf("%s.%d", f.path, i+1))
+	}
+	if err := os.Rename(f.path, f.path+".1"); err != nil {
+		return fmt.Errorf("failed to rotate log file: %w", err)
+	}
+
+	// #nosec G304 - The log file path was created by PrepareLogFile
+	file, err := os.Create(f.path)
+	if err != nil {
+		return fmt.Errorf("failed to create log file: %w", err)
+	}
+	f.file = file
+	f.size = 0
+	return pruneLogs(filepath.Dir(f.path), f.repoName, f.maxFiles)
+}
+
+// Sync flushes the log to disk
+func (f *rotatingFile) Sync() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.file.Sync()
+}
+
+// Close closes the log
+func (f *rotatingFile) Close() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.file.Close()
+}
+
+// outputCapture collects a command's stdout and stderr for its JSON record,
+// keeping at most limit bytes of output in total when limit is positive
+type outputCapture struct {
+	mu        sync.Mutex
+	limit     int64
+	used      int64
+	stdout    strings.Builder
+	stderr    strings.Builder
+	truncated bool
+}
+
+// captureWriter writes lines into one stream of an outputCapture
+type captureWriter struct {
+	capture *outputCapture
+	stderr  bool
+}
+
+// Write records as much of p as the limit allows
+func (w captureWriter) Write(p []byte) (int, error) {
+	c := w.capture
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	kept := p
+	if c.limit > 0 && c.used+int64(len(p)) > c.limit {
+		kept = p[:max(c.limit-c.used, 0)]
+		c.truncated = true
+	}
+	c.used += int64(len(kept))
+	if w.stderr {
+		c.stderr.Write(kept)
+	} else {
+		c.stdout.Write(kept)
+	}
+	return len(p), nil
+}
+
+// writeCommandRecord writes the record as a single JSON line to a new log file
+// of the repository and returns its path
+func writeCommandRecord(repo config.Repository, logDir string, record CommandRecord) (string, error) {
+	if err := util.EnsureDirectoryExists(logDir); err != nil {
+		return "", fmt.Errorf("failed to create log directory: %w", err)
+	}
+
+	data, err := json.Marshal(record)
+	if err != nil {
+		return "", err
+	}
+	logFilePath := filepath.Join(logDir, fmt.Sprintf("%s_%s.json",
+		repo.Name,
+		record.StartTime.Format("20060102_150405")))
+	if err := os.WriteFile(logFilePath, append(data, '\n'), 0600); err != nil {
+		return "", fmt.Errorf("failed to write log file: %w", err)
+	}
+	return logFilePath, nil
+}
+
+// pruneLogs removes the oldest log files of a repository, rotated ones
+// included, so that at most maxFiles remain. Zero keeps every file.
+func pruneLogs(logDir, repoName string, maxFiles int) error {
+	if maxFiles <= 0 {
+		return nil
+	}
+
+	logs, err := repoLogs(logDir, repoName)
+	if err != nil {
+		return err
+	}
+	for _, log := range logs[min(maxFiles, len(logs)):] {
+		if err := os.Remove(filepath.Join(logDir, log.name)); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// repoLog is a log file of a repository and when it was last written
+type repoLog struct {
+	name    string
+	modTime time.Time
+}
+
+// repoLogs lists the log files of a repository in logDir, rotated ones
+// included, newest first
+func repoLogs(logDir, repoName string) ([]repoLog, error) {
+	entries, err := os.ReadDir(logDir)
+	if err != nil {
+		return nil, err
+	}
+	pattern := regexp.MustCompile(`^` + regexp.QuoteMeta(repoName) + `_\d{8}_\d{6}\.(log|json)(\.\d+)?$`)
+
+	var logs []repoLog
+	for _, entry := range entries {
+		if entry.IsDir() || !pattern.MatchString(entry.Name()) {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		logs = append(logs, repoLog{name: entry.Name(), modTime: info.ModTime()})
+	}
+
+	// A rotated backup is older than the file it was rotated from
+	sort.Slice(logs, func(i, j int) bool {
+		if !logs[i].modTime.Equal(logs[j].modTime) {
+			return logs[i].modTime.After(logs[j].modTime)
+		}
+		return rotationIndex(logs[i].name) < rotationIndex(logs[j].name)
+	})
+	return logs, nil
+}
+
+// rotationIndex returns N for a backup named file.log.N, and 0 for the live log
+func rotationIndex(name string) int {
+	var index int
+	if dot := strings.LastIndex(name, "."); dot >= 0 {
+		_, _ = fmt.Sscanf(name[dot+1:], "%d", &index)
+	}
+	return index
+}
@@ -0,0 +1,146 @@
+package runner
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/codcod/repos/internal/config"
+)
+
+func TestRunCommandWithOptions_RotatesTextLog(t *testing.T) {
+	tmpDir := t.TempDir()
+	logDir := filepath.Join(tmpDir, "logs")
+	repo := config.Repository{Name: "test-repo", Path: tmpDir}
+
+	err := RunCommandWithOptions(repo, "for i in $(seq 1 40); do echo line $i; done", LogOptions{
+		Dir:      logDir,
+		MaxSize:  256,
+		MaxFiles: 3,
+	})
+	if err != nil {
+		t.Fatalf("RunCommandWithOptions failed: %v", err)
+	}
+
+	logs, _ := filepath.Glob(filepath.Join(logDir, "test-repo_*"))
+	if len(logs) != 3 {
+		t.Fatalf("Expected the log and two rotated backups, got %v", logs)
+	}
+	for _, log := range logs {
+		info, err := os.Stat(log)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if info.Size() > 256 {
+			t.Errorf("Expected %s to stay within the max size, got %d bytes", log, info.Size())
+		}
+	}
+}
+
+func TestRunCommandWithOptions_JSONRecord(t *testing.T) {
+	tmpDir := t.TempDir()
+	logDir := filepath.Join(tmpDir, "logs")
+	repo := config.Repository{Name: "test-repo", Path: tmpDir}
+
+	err := RunCommandWithOptions(repo, "echo out; echo err >&2; exit 3", LogOptions{Dir: logDir, Format: LogFormatJSON})
+	if err == nil {
+		t.Fatal("Expected the failing command to return an error")
+	}
+
+	logs, _ := filepath.Glob(filepath.Join(logDir, "test-repo_*.json"))
+	if len(logs) != 1 {
+		t.Fatalf("Expected one JSON log, got %v", logs)
+	}
+	data, err := os.ReadFile(logs[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	var record CommandRecord
+	if err := json.Unmarshal(data, &record); err != nil {
+		t.Fatalf("Expected a JSON record, got %q: %v", data, err)
+	}
+	if record.Repository != "test-repo" || record.ExitCode != 3 || record.Stdout != "out\n" || record.Stderr != "err\n" {
+		t.Errorf("Unexpected record: %+v", record)
+	}
+	if record.Command != "echo out; echo err >&2; exit 3" || record.Error == "" || record.Truncated {
+		t.Errorf("Unexpected record: %+v", record)
+	}
+}
+
+func TestRunCommandWithOptions_TruncatesJSONOutput(t *testing.T) {
+	tmpDir := t.TempDir()
+	logDir := filepath.Join(tmpDir, "logs")
+	repo := config.Repository{Name: "test-repo", Path: tmpDir}
+
+	err := RunCommandWithOptions(repo, "echo 0123456789; echo 0123456789", LogOptions{Dir: logDir, Format: LogFormatJSON, MaxSize: 15})
+	if err != nil {
+		t.Fatalf("RunCommandWithOptions failed: %v", err)
+	}
+
+	logs, _ := filepath.Glob(filepath.Join(logDir, "test-repo_*.json"))
+	if len(logs) != 1 {
+		t.Fatalf("Expected one JSON log, got %v", logs)
+	}
+	data, _ := os.ReadFile(logs[0])
+	var record CommandRecord
+	if err := json.Unmarshal(data, &record); err != nil {
+		t.Fatal(err)
+	}
+	if record.Stdout != "0123456789\n0123" || !record.Truncated {
+		t.Errorf("Expected output truncated to 15 bytes, got %+v", record)
+	}
+}
+
+func TestPruneLogs(t *testing.T) {
+	logDir := t.TempDir()
+	now := time.Now()
+	files := []string{
+		"api_20240101_100000.log",
+		"api_20240102_100000.log.1",
+		"api_20240102_100000.log",
+		"api_20240103_100000.json",
+		"api_gateway_20240101_100000.log", // Another repository
+		"notes.txt",
+	}
+	for i, name := range files {
+		path := filepath.Join(logDir, name)
+		if err := os.WriteFile(path, []byte("x"), 0600); err != nil {
+			t.Fatal(err)
+		}
+		modTime := now.Add(time.Duration(i-len(files)) * time.Hour)
+		if err := os.Chtimes(path, modTime, modTime); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := pruneLogs(logDir, "api", 2); err != nil {
+		t.Fatalf("pruneLogs failed: %v", err)
+	}
+
+	for name, kept := range map[string]bool{
+		"api_20240101_100000.log":         false,
+		"api_20240102_100000.log.1":       false,
+		"api_20240102_100000.log":         true,
+		"api_20240103_100000.json":        true,
+		"api_gateway_20240101_100000.log": true,
+		"notes.txt":                       true,
+	} {
+		if _, err := os.Stat(filepath.Join(logDir, name)); (err == nil) != kept {
+			t.Errorf("Expected %s kept=%t", name, kept)
+		}
+	}
+}
+
+func TestLogOptionsValidate(t *testing.T) {
+	if err := (LogOptions{Format: "xml"}).Validate(); err == nil {
+		t.Error("Expected an unknown format to be rejected")
+	}
+	if err := (LogOptions{MaxFiles: -1}).Validate(); err == nil {
+		t.Error("Expected negative max files to be rejected")
+	}
+	if err := (LogOptions{Format: LogFormatJSON, MaxSize: 1 << 20, MaxFiles: 5}).Validate(); err != nil {
+		t.Errorf("Expected valid options, got %v", err)
+	}
+}
@@ -20,7 +20,8 @@ import (
 // OutputProcessor handles processing of command output
 type OutputProcessor struct {
 	RepoName  string
-	LogFile   *os.File
+	LogFile   io.Writer // Receives each line prefixed by the repository name
+	Output    io.Writer // Receives each line as is, e.g. for a JSON record
 	IsStderr  bool
 	HeaderSet bool
 }
@@ -53,12 +54,18 @@ func (p *OutputProcessor) ProcessOutput(reader io.Reader, wg *sync.WaitGroup) {
 		if p.LogFile != nil {
 			// Add stderr section header if needed
 			if p.IsStderr && !p.HeaderSet {
-				_, _ = p.LogFile.WriteString("\n=== STDERR ===\n")
+				_, _ = io.WriteString(p.LogFile, "\n=== STDERR ===\n")
 				p.HeaderSet = true
 			}
 
 			_, _ = fmt.Fprintf(p.LogFile, "%s | %s\n", p.RepoName, line)
-			_ = p.LogFile.Sync()
+			if syncer, ok := p.LogFile.(interface{ Sync() error }); ok {
+				_ = syncer.Sync()
+			}
+		}
+
+		if p.Output != nil {
+			_, _ = fmt.Fprintln(p.Output, line)
 		}
 	}
 }
@@ -95,7 +102,19 @@ func PrepareLogFile(repo config.Repository, logDir, command, repoDir string) (*o
 
 // RunCommand runs a command in the repository directory
 func RunCommand(repo config.Repository, command string, logDir string) error {
+	return RunCommandWithOptions(repo, command, LogOptions{Dir: logDir})
+}
+
+// RunCommandWithOptions runs a command in the repository directory, logging its
+// output as configured by options
+//
+//nolint:gocyclo
+func RunCommandWithOptions(repo config.Repository, command string, options LogOptions) error {
 	logger := util.NewLogger()
+	if err := options.Validate(); err != nil {
+		return err
+	}
+	jsonLog := options.Dir != "" && options.Format == LogFormatJSON
 
 	// Determine repository directory
 	repoDir := util.GetRepoDir(repo)
@@ -119,18 +138,33 @@ func RunCommand(repo config.Repository, command string, logDir string) error {
 		return fmt.Errorf("failed to create stderr pipe: %w", err)
 	}
 
-	// Prepare log file
-	logFile, logFilePath, err := PrepareLogFile(repo, logDir, command, repoDir)
-	if err != nil {
-		return err
-	}
-	if logFile != nil {
-		defer func() { _ = logFile.Close() }()
+	// Prepare the text log, or capture the output for a JSON record
+	var logWriter io.Writer
+	var logFilePath string
+	var capture *outputCapture
+	if jsonLog {
+		capture = &outputCapture{limit: options.MaxSize}
+	} else {
+		var logFile *os.File
+		logFile, logFilePath, err = PrepareLogFile(repo, options.Dir, command, repoDir)
+		if err != nil {
+			return err
+		}
+		if logFile != nil {
+			rotating, err := newRotatingFile(logFile, repo.Name, options)
+			if err != nil {
+				_ = logFile.Close()
+				return err
+			}
+			defer func() { _ = rotating.Close() }()
+			logWriter = rotating
+		}
 	}
 
 	// Run the command
 	logger.Info(repo, "Running '%s'", command)
 
+	startTime := time.Now()
 	if err := cmd.Start(); err != nil {
 		return fmt.Errorf("failed to start command: %w", err)
 	}
@@ -142,14 +176,18 @@ func RunCommand(repo config.Repository, command string, logDir string) error {
 	// Process stdout and stderr in real-time
 	stdoutProcessor := &OutputProcessor{
 		RepoName: repo.Name,
-		LogFile:  logFile,
+		LogFile:  logWriter,
 		IsStderr: false,
 	}
 	stderrProcessor := &OutputProcessor{
 		RepoName: repo.Name,
-		LogFile:  logFile,
+		LogFile:  logWriter,
 		IsStderr: true,
 	}
+	if capture != nil {
+		stdoutProcessor.Output = captureWriter{capture: capture}
+		stderrProcessor.Output = captureWriter{capture: capture, stderr: true}
+	}
 
 	go stdoutProcessor.ProcessOutput(stdoutPipe, &wg)
 	go stderrProcessor.ProcessOutput(stderrPipe, &wg)
@@ -160,7 +198,33 @@ func RunCommand(repo config.Repository, command string, logDir string) error {
 	// Wait for the command to complete
 	err = cmd.Wait()
 
-	if logFile != nil && err == nil {
+	if capture != nil {
+		record := CommandRecord{
+			Repository: repo.Name,
+			Command:    command,
+			Directory:  repoDir,
+			StartTime:  startTime,
+			DurationMs: time.Since(startTime).Milliseconds(),
+			ExitCode:   cmd.ProcessState.ExitCode(),
+			Stdout:     capture.stdout.String(),
+			Stderr:     capture.stderr.String(),
+			Truncated:  capture.truncated,
+		}
+		if err != nil {
+			record.Error = err.Error()
+		}
+		var writeErr error
+		if logFilePath, writeErr = writeCommandRecord(repo, options.Dir, record); writeErr != nil {
+			return writeErr
+		}
+	}
+	if logFilePath != "" {
+		if pruneErr := pruneLogs(options.Dir, repo.Name, options.MaxFiles); pruneErr != nil {
+			logger.Warn(repo, "Unable to remove old logs: %v", pruneErr)
+		}
+	}
+
+	if logFilePath != "" && err == nil {
 		logger.Info(repo, "Log saved to %s", logFilePath)
 	}
 